@@ -0,0 +1,9 @@
+data "proxmox_virtual_environment_cluster_join_info" "cluster" {}
+
+output "cluster_join_info" {
+  value = {
+    cluster_name = data.proxmox_virtual_environment_cluster_join_info.cluster.cluster_name
+    fingerprint  = data.proxmox_virtual_environment_cluster_join_info.cluster.fingerprint
+    peer_address = data.proxmox_virtual_environment_cluster_join_info.cluster.peer_address
+  }
+}
@@ -0,0 +1,20 @@
+data "proxmox_virtual_environment_datastores" "images" {
+  node_names = ["pve1", "pve2", "pve3"]
+
+  filters = {
+    content_types = ["images"]
+  }
+}
+
+# place the disk on the store with the most available space across the nodes
+resource "proxmox_virtual_environment_vm" "example" {
+  node_name = data.proxmox_virtual_environment_datastores.images.most_free_node_name
+
+  disk {
+    datastore_id = data.proxmox_virtual_environment_datastores.images.most_free
+    interface    = "scsi0"
+    size         = 32
+  }
+
+  # ...
+}
@@ -0,0 +1,13 @@
+data "proxmox_virtual_environment_firewall_log" "vm" {
+  node_name = "pve"
+  vm_id     = 100
+  limit     = 20
+  since     = "2024-01-01T00:00:00Z"
+}
+
+output "dropped_packets" {
+  value = [
+    for entry in data.proxmox_virtual_environment_firewall_log.vm.entries : entry
+    if entry.action == "DROP"
+  ]
+}
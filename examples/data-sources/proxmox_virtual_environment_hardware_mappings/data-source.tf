@@ -20,3 +20,20 @@ output "data_proxmox_virtual_environment_hardware_mappings_pci" {
 output "data_proxmox_virtual_environment_hardware_mappings_usb" {
   value = data.proxmox_virtual_environment_hardware_mappings.example-usb
 }
+
+# The mapping IDs can be used to validate the references of a VM at plan time.
+resource "proxmox_virtual_environment_vm" "example" {
+  node_name = "pve"
+
+  hostpci {
+    device  = "hostpci0"
+    mapping = "gpu"
+  }
+
+  lifecycle {
+    precondition {
+      condition     = contains(data.proxmox_virtual_environment_hardware_mappings.example-pci.ids, "gpu")
+      error_message = "The PCI hardware mapping \"gpu\" does not exist."
+    }
+  }
+}
@@ -0,0 +1,7 @@
+data "proxmox_virtual_environment_node_cpu_models" "pve" {
+  node_name = "pve"
+}
+
+output "pve_custom_cpu_models" {
+  value = [for m in data.proxmox_virtual_environment_node_cpu_models.pve.models : m.name if m.custom]
+}
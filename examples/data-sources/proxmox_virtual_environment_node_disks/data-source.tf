@@ -0,0 +1,7 @@
+data "proxmox_virtual_environment_node_disks" "unused" {
+  node_name = "pve"
+}
+
+output "unused_disks" {
+  value = data.proxmox_virtual_environment_node_disks.unused.disks
+}
@@ -0,0 +1,24 @@
+data "proxmox_virtual_environment_node_pci_devices" "gpu" {
+  node_name = "pve"
+
+  filters = {
+    class             = "0300"
+    vendor_id         = "10de"
+    device_name_regex = "RTX"
+    has_iommu_group   = true
+  }
+}
+
+resource "proxmox_virtual_environment_vm" "gpu_vm" {
+  node_name = "pve"
+  machine   = "q35"
+
+  hostpci {
+    device = "hostpci0"
+    # fails if there is not exactly one matching GPU
+    id   = one(data.proxmox_virtual_environment_node_pci_devices.gpu.ids)
+    pcie = true
+  }
+
+  # ...
+}
@@ -0,0 +1,14 @@
+data "proxmox_virtual_environment_node_status" "pve" {
+  node_name = "pve"
+
+  # fail the plan early if the node is not prepared for the PCI passthrough
+  requirements = {
+    iommu              = true
+    cpu_flags          = ["vmx"]
+    min_kernel_version = "6.8"
+  }
+}
+
+output "pve_kernel" {
+  value = data.proxmox_virtual_environment_node_status.pve.kernel.release
+}
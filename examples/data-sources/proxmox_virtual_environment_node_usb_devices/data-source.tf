@@ -0,0 +1,12 @@
+data "proxmox_virtual_environment_node_usb_devices" "receiver" {
+  node_name = "pve"
+
+  filters = {
+    vendor_id         = "046d"
+    device_name_regex = "Receiver"
+  }
+}
+
+output "receiver_ports" {
+  value = data.proxmox_virtual_environment_node_usb_devices.receiver.devices[*].port
+}
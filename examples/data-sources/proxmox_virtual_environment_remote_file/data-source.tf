@@ -0,0 +1,16 @@
+data "proxmox_virtual_environment_remote_file" "nightly" {
+  url              = "https://cloud.debian.org/images/cloud/bookworm/daily/latest/debian-12-generic-amd64-daily.qcow2"
+  compute_checksum = true
+}
+
+resource "proxmox_virtual_environment_file" "nightly" {
+  content_type = "import"
+  datastore_id = "local"
+  node_name    = "pve"
+
+  source_file {
+    path      = data.proxmox_virtual_environment_remote_file.nightly.url
+    file_name = "debian-12-nightly.qcow2"
+    checksum  = data.proxmox_virtual_environment_remote_file.nightly.checksum
+  }
+}
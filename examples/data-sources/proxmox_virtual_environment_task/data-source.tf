@@ -0,0 +1,8 @@
+data "proxmox_virtual_environment_task" "failed_clone" {
+  upid      = "UPID:pve:000D1F5B:0185C71C:64F0E1B3:qmclone:100:root@pam:"
+  log_lines = 100
+}
+
+output "failed_clone_log" {
+  value = data.proxmox_virtual_environment_task.failed_clone.log
+}
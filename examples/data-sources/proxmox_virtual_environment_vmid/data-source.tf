@@ -0,0 +1,13 @@
+data "proxmox_virtual_environment_vmid" "example" {
+  min = 10000
+  max = 19999
+}
+
+resource "proxmox_virtual_environment_vm" "example" {
+  node_name = "pve"
+  vm_id     = data.proxmox_virtual_environment_vmid.example.vm_id
+
+  lifecycle {
+    ignore_changes = [vm_id]
+  }
+}
@@ -0,0 +1,2 @@
+#!/usr/bin/env sh
+terraform import proxmox_virtual_environment_backup_job.example backup-1a2b3c4d-5e6f
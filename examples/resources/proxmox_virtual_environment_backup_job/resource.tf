@@ -0,0 +1,13 @@
+resource "proxmox_virtual_environment_backup_job" "example" {
+  schedule       = "sat 02:00"
+  storage        = "local"
+  vm_ids         = [100, 101]
+  mode           = "snapshot"
+  compress       = "zstd"
+  notes_template = "{{guestname}}"
+
+  prune_backups = {
+    keep_last  = 3
+    keep_daily = 7
+  }
+}
@@ -0,0 +1,28 @@
+# the provider of the node joining the cluster
+provider "proxmox" {
+  alias    = "new_node"
+  endpoint = "https://10.0.0.3:8006/"
+  username = "root@pam"
+  password = var.new_node_root_password
+  insecure = true
+}
+
+data "proxmox_virtual_environment_cluster_join_info" "cluster" {}
+
+resource "proxmox_virtual_environment_cluster_node_join" "new_node" {
+  provider = proxmox.new_node
+
+  peer_address = data.proxmox_virtual_environment_cluster_join_info.cluster.peer_address
+  fingerprint  = data.proxmox_virtual_environment_cluster_join_info.cluster.fingerprint
+  password     = var.cluster_root_password
+
+  links = [
+    {
+      address = "10.0.0.3"
+    },
+  ]
+
+  timeouts = {
+    create = "30m"
+  }
+}
@@ -19,4 +19,12 @@ resource "proxmox_virtual_environment_cluster_options" "options" {
     package_replication        = "always"
     package_replication_target = "default-matcher"
   }
+  registered_tags = ["prod", "dev"]
+  tag_style = {
+    color_map = {
+      prod = "FF0000:FFFFFF"
+      dev  = "00FF00"
+    }
+    shape = "dense"
+  }
 }
@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+# Group membership can be imported using its unique identifier, e.g.: {group}:{user@realm}
+terraform import proxmox_virtual_environment_group_membership.operations_automation operations-team:operations-automation@pve
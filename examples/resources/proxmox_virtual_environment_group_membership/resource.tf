@@ -0,0 +1,16 @@
+resource "proxmox_virtual_environment_user" "operations_automation" {
+  comment       = "Managed by Terraform"
+  manage_groups = false
+  password      = "a-strong-password"
+  user_id       = "operations-automation@pve"
+}
+
+resource "proxmox_virtual_environment_group" "operations_team" {
+  comment  = "Managed by Terraform"
+  group_id = "operations-team"
+}
+
+resource "proxmox_virtual_environment_group_membership" "operations_automation" {
+  group_id = proxmox_virtual_environment_group.operations_team.group_id
+  user_id  = proxmox_virtual_environment_user.operations_automation.user_id
+}
@@ -0,0 +1,18 @@
+resource "proxmox_virtual_environment_network_linux_bond" "bond0" {
+  node_name = "pve"
+  name      = "bond0"
+
+  address = "99.99.99.99/16"
+
+  comment = "bond0 comment"
+
+  mode             = "802.3ad"
+  xmit_hash_policy = "layer3+4"
+
+  slaves = [
+    # Network interfaces to enslave to the bond, specified by their interface name.
+    # They must not be used by another bond or bridge.
+    "ens19",
+    "ens20",
+  ]
+}
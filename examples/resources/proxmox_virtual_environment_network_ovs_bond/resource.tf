@@ -0,0 +1,17 @@
+resource "proxmox_virtual_environment_network_ovs_bridge" "vmbr1" {
+  node_name = "pve"
+  name      = "vmbr1"
+}
+
+resource "proxmox_virtual_environment_network_ovs_bond" "bond1" {
+  node_name = "pve"
+  name      = "bond1"
+
+  bridge = proxmox_virtual_environment_network_ovs_bridge.vmbr1.name
+  mode   = "balance-slb"
+
+  slaves = [
+    "ens20",
+    "ens21",
+  ]
+}
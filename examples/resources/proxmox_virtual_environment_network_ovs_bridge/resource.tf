@@ -0,0 +1,12 @@
+resource "proxmox_virtual_environment_network_ovs_bridge" "vmbr1" {
+  node_name = "pve"
+  name      = "vmbr1"
+
+  comment = "vmbr1 comment"
+
+  ports = [
+    # Physical interfaces to attach to the bridge. OVS bonds and OVS internal ports are attached
+    # to the bridge by their own resources, e.g. proxmox_virtual_environment_network_ovs_intport.
+    "ens19"
+  ]
+}
@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+#Interfaces can be imported using the `node_name:iface` format, e.g.
+terraform import proxmox_virtual_environment_network_ovs_intport.vlan10 pve:vlan10
@@ -0,0 +1,13 @@
+resource "proxmox_virtual_environment_network_ovs_bridge" "vmbr1" {
+  node_name = "pve"
+  name      = "vmbr1"
+}
+
+resource "proxmox_virtual_environment_network_ovs_intport" "vlan10" {
+  node_name = "pve"
+  name      = "vlan10"
+
+  bridge  = proxmox_virtual_environment_network_ovs_bridge.vmbr1.name
+  vlan    = 10
+  address = "10.10.10.2/24"
+}
@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+# The node configuration can be imported using the node name, e.g.:
+terraform import proxmox_virtual_environment_node_config.pve pve
@@ -0,0 +1,10 @@
+resource "proxmox_virtual_environment_node_config" "pve" {
+  node_name             = "pve"
+  description           = "Managed by Terraform"
+  startall_onboot_delay = 30
+
+  wakeonlan = {
+    mac_address    = "aa:bb:cc:dd:ee:ff"
+    bind_interface = "vmbr0"
+  }
+}
@@ -0,0 +1,9 @@
+resource "proxmox_virtual_environment_node_lvm_thinpool" "data" {
+  node_name   = "pve"
+  name        = "data2"
+  device      = "/dev/sdd"
+  add_storage = true
+
+  # set to `true` and apply before removing the resource
+  allow_destroy = false
+}
@@ -0,0 +1,11 @@
+resource "proxmox_virtual_environment_node_zfs_pool" "tank" {
+  node_name   = "pve"
+  name        = "tank"
+  devices     = ["/dev/sdb", "/dev/sdc"]
+  raid_level  = "mirror"
+  compression = "lz4"
+  add_storage = true
+
+  # set to `true` and apply before removing the resource
+  allow_destroy = false
+}
@@ -0,0 +1,5 @@
+resource "proxmox_virtual_environment_notification_endpoint_gotify" "example" {
+  name   = "example"
+  server = "https://gotify.example.com"
+  token  = "secret"
+}
@@ -0,0 +1,11 @@
+resource "proxmox_virtual_environment_notification_endpoint_smtp" "example" {
+  name         = "example"
+  server       = "smtp.example.com"
+  port         = 587
+  mode         = "starttls"
+  username     = "pve@example.com"
+  password     = "secret"
+  from_address = "pve@example.com"
+  mailto       = ["admins@example.com"]
+  mailto_user  = ["root@pam"]
+}
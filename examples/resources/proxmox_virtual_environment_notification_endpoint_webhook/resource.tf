@@ -0,0 +1,12 @@
+resource "proxmox_virtual_environment_notification_endpoint_webhook" "example" {
+  name   = "example"
+  url    = "https://hooks.example.com/{{ secrets.channel }}"
+  method = "post"
+  body   = "{\"text\": \"{{ escape title }}: {{ escape message }}\"}"
+  header = {
+    "Content-Type" = "application/json"
+  }
+  secret = {
+    channel = "secret-channel-id"
+  }
+}
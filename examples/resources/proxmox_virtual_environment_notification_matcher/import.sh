@@ -0,0 +1,2 @@
+#!/usr/bin/env sh
+terraform import proxmox_virtual_environment_notification_matcher.example example
@@ -0,0 +1,10 @@
+resource "proxmox_virtual_environment_notification_matcher" "example" {
+  name           = "example"
+  mode           = "all"
+  match_severity = ["warning", "error"]
+  match_field    = ["exact:type=vzdump"]
+  target = [
+    "mail-to-root",
+    proxmox_virtual_environment_notification_endpoint_gotify.example.name,
+  ]
+}
@@ -0,0 +1,14 @@
+resource "proxmox_virtual_environment_realm_ad" "example" {
+  realm    = "example"
+  comment  = "Managed by Terraform"
+  domain   = "example.com"
+  server1  = "dc1.example.com"
+  server2  = "dc2.example.com"
+  mode     = "ldap+starttls"
+  bind_dn  = "CN=proxmox,OU=Services,DC=example,DC=com"
+  password = var.ad_bind_password
+
+  sync_defaults_options = {
+    scope = "users"
+  }
+}
@@ -0,0 +1,23 @@
+resource "proxmox_virtual_environment_realm_ldap" "example" {
+  realm     = "example"
+  comment   = "Managed by Terraform"
+  server1   = "ldap.example.com"
+  mode      = "ldaps"
+  verify    = true
+  base_dn   = "ou=people,dc=example,dc=com"
+  user_attr = "uid"
+  bind_dn   = "cn=proxmox,ou=services,dc=example,dc=com"
+  password  = var.ldap_bind_password
+
+  group_dn        = "ou=groups,dc=example,dc=com"
+  group_name_attr = "cn"
+  sync_attributes = "email=mail,firstname=givenName,lastname=sn"
+
+  sync_defaults_options = {
+    scope           = "both"
+    enable_new      = true
+    remove_vanished = ["acl", "entry", "properties"]
+  }
+
+  trigger_sync = true
+}
@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+#Realms can be imported using the realm identifier, e.g.:
+terraform import proxmox_virtual_environment_realm_openid.example example
@@ -0,0 +1,10 @@
+resource "proxmox_virtual_environment_realm_openid" "example" {
+  realm          = "example"
+  comment        = "Managed by Terraform"
+  issuer_url     = "https://auth.example.com/realms/example"
+  client_id      = "proxmox"
+  client_key     = var.openid_client_key
+  username_claim = "email"
+  scopes         = "openid email profile"
+  autocreate     = true
+}
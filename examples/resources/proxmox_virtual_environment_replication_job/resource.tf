@@ -0,0 +1,7 @@
+resource "proxmox_virtual_environment_replication_job" "example" {
+  vm_id       = 101
+  target_node = "pve2"
+  schedule    = "*/15"
+  rate        = 50
+  comment     = "Managed by Terraform"
+}
@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+# SDN subnet can be imported using the VNet ID and the subnet ID, separated by a slash
+terraform import proxmox_virtual_environment_sdn_subnet.example vnet1/simple1-10.0.0.0-24
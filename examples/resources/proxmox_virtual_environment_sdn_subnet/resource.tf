@@ -0,0 +1,24 @@
+resource "proxmox_virtual_environment_sdn_zone_simple" "example" {
+  id    = "simple1"
+  nodes = ["pve"]
+  ipam  = "pve"
+}
+
+resource "proxmox_virtual_environment_sdn_vnet" "example" {
+  id   = "vnet1"
+  zone = proxmox_virtual_environment_sdn_zone_simple.example.id
+}
+
+resource "proxmox_virtual_environment_sdn_subnet" "example" {
+  vnet    = proxmox_virtual_environment_sdn_vnet.example.id
+  cidr    = "10.0.0.0/24"
+  gateway = "10.0.0.1"
+  snat    = true
+
+  dhcp_range = [
+    {
+      start_address = "10.0.0.100"
+      end_address   = "10.0.0.200"
+    },
+  ]
+}
@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+# SDN VNet can be imported using its unique identifier (VNet ID)
+terraform import proxmox_virtual_environment_sdn_vnet.example vnet1
@@ -0,0 +1,12 @@
+resource "proxmox_virtual_environment_sdn_zone_vlan" "example" {
+  id     = "vlan1"
+  nodes  = ["pve"]
+  bridge = "vmbr0"
+}
+
+resource "proxmox_virtual_environment_sdn_vnet" "example" {
+  id    = "vnet1"
+  zone  = proxmox_virtual_environment_sdn_zone_vlan.example.id
+  tag   = 100
+  alias = "Servers"
+}
@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+#Datastores can be imported using the datastore identifier, e.g.:
+terraform import proxmox_virtual_environment_storage_cifs.example example
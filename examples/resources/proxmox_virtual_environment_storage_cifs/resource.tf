@@ -0,0 +1,8 @@
+resource "proxmox_virtual_environment_storage_cifs" "example" {
+  id       = "example"
+  server   = "smb.example.com"
+  share    = "proxmox"
+  username = "proxmox"
+  password = var.cifs_password
+  content  = ["backup", "iso"]
+}
@@ -0,0 +1,6 @@
+resource "proxmox_virtual_environment_storage_directory" "example" {
+  id      = "example"
+  path    = "/srv/example"
+  content = ["iso", "snippets", "vztmpl"]
+  nodes   = ["pve"]
+}
@@ -0,0 +1,11 @@
+resource "proxmox_virtual_environment_storage_nfs" "example" {
+  id      = "example"
+  server  = "nfs.example.com"
+  export  = "/export/proxmox"
+  content = ["backup", "images", "iso"]
+
+  prune_backups = {
+    keep_last  = 3
+    keep_daily = 7
+  }
+}
@@ -0,0 +1,14 @@
+resource "proxmox_virtual_environment_storage_pbs" "example" {
+  id          = "example"
+  server      = "pbs.example.com"
+  datastore   = "backups"
+  username    = "backup@pbs"
+  password    = var.pbs_password
+  fingerprint = "b4:6f:2b:ed:8e:5c:0d:3a:90:7e:91:0c:4b:4e:1a:6d:32:e5:f0:93:0b:ac:2b:86:18:c3:0e:46:aa:15:fa:7e"
+
+  prune_backups = {
+    keep_daily   = 7
+    keep_weekly  = 4
+    keep_monthly = 6
+  }
+}
@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+# Cluster tags are global and can be imported using e.g.:
+terraform import proxmox_virtual_environment_tags.tags cluster
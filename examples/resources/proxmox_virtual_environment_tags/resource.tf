@@ -0,0 +1,8 @@
+resource "proxmox_virtual_environment_tags" "tags" {
+  registered_tags = ["prod", "staging"]
+
+  color_map = {
+    prod    = "FF0000:FFFFFF"
+    staging = "FFA500"
+  }
+}
@@ -0,0 +1,21 @@
+resource "proxmox_virtual_environment_vm_agent_exec" "restart_nginx" {
+  node_name = "pve"
+  vm_id     = 100
+  command   = ["systemctl", "restart", "nginx"]
+
+  triggers = {
+    config = sha256(file("${path.module}/nginx.conf"))
+  }
+}
+
+resource "proxmox_virtual_environment_vm_agent_exec" "hostname" {
+  node_name           = "pve"
+  vm_id               = 100
+  command             = ["/bin/sh", "-c", "hostname; exit 3"]
+  expected_exit_codes = [0, 3]
+  timeout             = 600
+}
+
+output "hostname" {
+  value = proxmox_virtual_environment_vm_agent_exec.hostname.stdout
+}
@@ -0,0 +1,14 @@
+resource "proxmox_virtual_environment_vm_agent_file" "motd" {
+  node_name   = "pve"
+  vm_id       = 100
+  path        = "/etc/motd"
+  content     = "Managed by Terraform\n"
+  permissions = "0644"
+}
+
+resource "proxmox_virtual_environment_vm_agent_file" "logo" {
+  node_name      = "pve"
+  vm_id          = 100
+  path           = "/var/www/html/logo.png"
+  content_base64 = filebase64("${path.module}/logo.png")
+}
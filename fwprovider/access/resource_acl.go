@@ -49,7 +49,7 @@ func (r *aclResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 			"Each ACL consists of a path, a user, group or token, a role, and a flag to allow propagation of permissions.",
 		Attributes: map[string]schema.Attribute{
 			"group_id": schema.StringAttribute{
-				Description: "The group the ACL should apply to (mutually exclusive with `token_id` and `user_id`)",
+				Description: "The group the ACL should apply to (exactly one of `group_id`, `token_id` and `user_id` must be set)",
 				Optional:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -77,14 +77,14 @@ func (r *aclResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				},
 			},
 			"token_id": schema.StringAttribute{
-				Description: "The token the ACL should apply to (mutually exclusive with `group_id` and `user_id`)",
+				Description: "The token the ACL should apply to, in the `user@realm!token` format (exactly one of `group_id`, `token_id` and `user_id` must be set)",
 				Optional:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"user_id": schema.StringAttribute{
-				Description: "The user the ACL should apply to (mutually exclusive with `group_id` and `token_id`)",
+				Description: "The user the ACL should apply to (exactly one of `group_id`, `token_id` and `user_id` must be set)",
 				Optional:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -96,7 +96,7 @@ func (r *aclResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 
 func (r *aclResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
-		resourcevalidator.Conflicting(
+		resourcevalidator.ExactlyOneOf(
 			path.MatchRoot("group_id"),
 			path.MatchRoot("token_id"),
 			path.MatchRoot("user_id"),
@@ -163,6 +163,7 @@ func (r *aclResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	// the same path is listed once per principal and role, so look for the exact entry managed by this resource
 	for _, acl := range acls {
 		switch acl.Type {
 		case "group":
@@ -201,32 +202,19 @@ func (r *aclResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 }
 
 func (r *aclResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var (
-		state aclResourceModel
-		plan  aclResourceModel
-	)
+	var plan aclResourceModel
 
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	stateBody := state.intoUpdateBody()
-	stateBody.Delete = proxmoxtypes.CustomBool(true).Pointer()
-
-	err := r.client.Access().UpdateACL(ctx, stateBody)
+	// all attributes except `propagate` require replacement, and PVE rewrites the existing
+	// ACL entry for the same path, principal and role, so the update is done in place
+	err := r.client.Access().UpdateACL(ctx, plan.intoUpdateBody())
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to delete old ACL", apiCallFailed+err.Error())
-		return
-	}
-
-	planBody := plan.intoUpdateBody()
-
-	err = r.client.Access().UpdateACL(ctx, planBody)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to create ACL", apiCallFailed+err.Error())
+		resp.Diagnostics.AddError("Unable to update ACL", apiCallFailed+err.Error())
 		return
 	}
 
@@ -16,6 +16,7 @@ import (
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/stretchr/testify/require"
 
@@ -74,6 +75,22 @@ func TestAccAcl_User(t *testing.T) {
 				ImportStateIdFunc: testAccACLImportStateIDFunc(),
 				ImportStateVerify: true,
 			},
+			{
+				Config: te.RenderConfig(`resource "proxmox_virtual_environment_acl" "test" {
+					user_id = "{{.UserID}}"
+					path = "/"
+					propagate = false
+					role_id = "NoAccess"
+				}`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("proxmox_virtual_environment_acl.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: test.ResourceAttributes("proxmox_virtual_environment_acl.test", map[string]string{
+					"propagate": "false",
+				}),
+			},
 			{
 				Config: te.RenderConfig(`resource "proxmox_virtual_environment_acl" "test" {
 					user_id = "{{.UserID}}"
@@ -147,6 +164,14 @@ func TestAccAcl_Validators(t *testing.T) {
 				}`,
 				ExpectError: regexp.MustCompile(`.*Error: Invalid Attribute Combination`),
 			},
+			{
+				PlanOnly: true,
+				Config: `resource "proxmox_virtual_environment_acl" "test" {
+					path = "/"
+					role_id = "test"
+				}`,
+				ExpectError: regexp.MustCompile(`.*Error: Invalid Attribute Combination`),
+			},
 		},
 	})
 }
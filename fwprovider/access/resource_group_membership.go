@@ -0,0 +1,211 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+var (
+	_ resource.Resource                = (*groupMembershipResource)(nil)
+	_ resource.ResourceWithConfigure   = (*groupMembershipResource)(nil)
+	_ resource.ResourceWithImportState = (*groupMembershipResource)(nil)
+)
+
+const groupMembershipIDFormat = "{group}:{user@realm}"
+
+type groupMembershipResource struct {
+	client proxmox.Client
+}
+
+type groupMembershipModel struct {
+	ID      types.String `tfsdk:"id"`
+	GroupID types.String `tfsdk:"group_id"`
+	UserID  types.String `tfsdk:"user_id"`
+}
+
+// NewGroupMembershipResource creates a new group membership resource.
+func NewGroupMembershipResource() resource.Resource {
+	return &groupMembershipResource{}
+}
+
+func (r *groupMembershipResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the membership of a user in a group.",
+		MarkdownDescription: "Manages the membership of a user in a group.\n\n" +
+			"PVE stores the group memberships in the user objects, so the memberships managed by this resource " +
+			"must not be managed by the user or the group resources as well. Set `manage_groups` to `false` on the " +
+			"`proxmox_virtual_environment_user` resource of the user, and keep `manage_members` disabled on the " +
+			"`proxmox_virtual_environment_group` resource of the group.",
+		Attributes: map[string]schema.Attribute{
+			"group_id": schema.StringAttribute{
+				Description: "The group identifier.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": attribute.ResourceID(),
+			"user_id": schema.StringAttribute{
+				Description: "The user identifier, in the `user@realm` format.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *groupMembershipResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+func (r *groupMembershipResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_group_membership"
+}
+
+func (r *groupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan groupMembershipModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Access().AddUserToGroup(ctx, plan.UserID.ValueString(), plan.GroupID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create group membership", apiCallFailed+err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(plan.GroupID.ValueString() + ":" + plan.UserID.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *groupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state groupMembershipModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the user object is the source of truth for the memberships, the group only lists them
+	user, err := r.client.Access().GetUser(ctx, state.UserID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Unable to read group membership", apiCallFailed+err.Error())
+
+		return
+	}
+
+	if user.Groups == nil || !slices.Contains(*user.Groups, state.GroupID.ValueString()) {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *groupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan groupMembershipModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// all attributes require replacement, there is nothing to update
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *groupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state groupMembershipModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Access().RemoveUserFromGroup(ctx, state.UserID.ValueString(), state.GroupID.ValueString())
+	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError("Unable to delete group membership", apiCallFailed+err.Error())
+	}
+}
+
+func (r *groupMembershipResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	groupID, userID, found := strings.Cut(req.ID, ":")
+	if !found || groupID == "" || userID == "" {
+		resp.Diagnostics.AddError(
+			"Unable to import group membership",
+			fmt.Sprintf("invalid group membership ID format %#v, expected %v", req.ID, groupMembershipIDFormat),
+		)
+
+		return
+	}
+
+	model := groupMembershipModel{
+		ID:      types.StringValue(req.ID),
+		GroupID: types.StringValue(groupID),
+		UserID:  types.StringValue(userID),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
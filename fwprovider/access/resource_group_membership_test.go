@@ -0,0 +1,139 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceGroupMembership(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitEnvironment(t)
+
+	userID := fmt.Sprintf("%s@pve", gofakeit.Username())
+	groupID := gofakeit.Word() + "-group"
+
+	te.AddTemplateVars(map[string]any{
+		"GroupID": groupID,
+		"UserID":  userID,
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_user" "user" {
+					manage_groups = false
+					user_id       = "{{.UserID}}"
+				}
+				resource "proxmox_virtual_environment_group" "group" {
+					group_id = "{{.GroupID}}"
+				}
+				resource "proxmox_virtual_environment_group_membership" "membership" {
+					group_id = proxmox_virtual_environment_group.group.group_id
+					user_id  = proxmox_virtual_environment_user.user.user_id
+				}`),
+				Check: test.ResourceAttributes("proxmox_virtual_environment_group_membership.membership", map[string]string{
+					"group_id": groupID,
+					"id":       fmt.Sprintf("%s:%s", groupID, userID),
+					"user_id":  userID,
+				}),
+			},
+			{
+				// the membership shows up on the user and the group, without causing a diff on either
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_user" "user" {
+					manage_groups = false
+					user_id       = "{{.UserID}}"
+				}
+				resource "proxmox_virtual_environment_group" "group" {
+					group_id = "{{.GroupID}}"
+				}
+				resource "proxmox_virtual_environment_group_membership" "membership" {
+					group_id = proxmox_virtual_environment_group.group.group_id
+					user_id  = proxmox_virtual_environment_user.user.user_id
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_user.user", map[string]string{
+						"groups.#": "1",
+						"groups.0": groupID,
+					}),
+					test.ResourceAttributes("proxmox_virtual_environment_group.group", map[string]string{
+						"members.#": "1",
+						"members.0": userID,
+					}),
+				),
+			},
+			{
+				ResourceName:      "proxmox_virtual_environment_group_membership.membership",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s:%s", groupID, userID),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceGroupManageMembers(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitEnvironment(t)
+
+	userID := fmt.Sprintf("%s@pve", gofakeit.Username())
+	groupID := gofakeit.Word() + "-group"
+
+	te.AddTemplateVars(map[string]any{
+		"GroupID": groupID,
+		"UserID":  userID,
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_user" "user" {
+					manage_groups = false
+					user_id       = "{{.UserID}}"
+				}
+				resource "proxmox_virtual_environment_group" "group" {
+					group_id       = "{{.GroupID}}"
+					manage_members = true
+					members        = [proxmox_virtual_environment_user.user.user_id]
+				}`),
+				Check: test.ResourceAttributes("proxmox_virtual_environment_group.group", map[string]string{
+					"members.#": "1",
+					"members.0": userID,
+				}),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_user" "user" {
+					manage_groups = false
+					user_id       = "{{.UserID}}"
+				}
+				resource "proxmox_virtual_environment_group" "group" {
+					group_id       = "{{.GroupID}}"
+					manage_members = true
+				}`),
+				Check: test.ResourceAttributes("proxmox_virtual_environment_group.group", map[string]string{
+					"members.#": "0",
+				}),
+			},
+		},
+	})
+}
@@ -0,0 +1,425 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/go-querystring/query"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// builtInRealms are the realms that always exist in PVE and can't be managed.
+var builtInRealms = []string{"pam", "pve"}
+
+type realmModel interface {
+	importFromAPI(id string, data *access.RealmGetResponseData, diags *diag.Diagnostics)
+	toAPIRequestBody(ctx context.Context, diags *diag.Diagnostics) *access.RealmData
+	// secrets returns the write-only values, i.e. password and client key, which are never returned by the API.
+	secrets() (password *string, clientKey *string)
+	// copyWriteOnly copies the attributes that can't be read back from the API from the other model.
+	copyWriteOnly(other realmModel)
+	// syncRequest returns the realm sync request, or nil if the sync should not be triggered.
+	syncRequest(ctx context.Context, diags *diag.Diagnostics) *access.RealmSyncRequestBody
+	getID() string
+}
+
+type realmGenericModel struct {
+	ID      types.String `tfsdk:"id"`
+	Realm   types.String `tfsdk:"realm"`
+	Comment types.String `tfsdk:"comment"`
+	Default types.Bool   `tfsdk:"default"`
+	TFA     types.String `tfsdk:"tfa"`
+}
+
+func (m *realmGenericModel) importFromAPI(id string, data *access.RealmGetResponseData, _ *diag.Diagnostics) {
+	m.ID = types.StringValue(id)
+	m.Realm = types.StringValue(id)
+
+	m.Comment = types.StringPointerValue(data.Comment)
+	m.Default = realmBoolValue(data.Default, false)
+	m.TFA = types.StringPointerValue(data.TFA)
+}
+
+func (m *realmGenericModel) toAPIRequestBody(_ context.Context, _ *diag.Diagnostics) *access.RealmData {
+	return &access.RealmData{
+		Comment: m.Comment.ValueStringPointer(),
+		Default: proxmoxtypes.CustomBoolPtr(m.Default.ValueBoolPointer()),
+		TFA:     m.TFA.ValueStringPointer(),
+	}
+}
+
+func (m *realmGenericModel) secrets() (*string, *string) {
+	return nil, nil
+}
+
+func (m *realmGenericModel) copyWriteOnly(_ realmModel) {}
+
+func (m *realmGenericModel) syncRequest(_ context.Context, _ *diag.Diagnostics) *access.RealmSyncRequestBody {
+	return nil
+}
+
+func (m *realmGenericModel) getID() string {
+	return m.Realm.ValueString()
+}
+
+// realmBoolValue converts a boolean flag returned by the API, which is omitted when it has the default value.
+func realmBoolValue(v *proxmoxtypes.CustomBool, def bool) types.Bool {
+	if v == nil {
+		return types.BoolValue(def)
+	}
+
+	return types.BoolValue(bool(*v))
+}
+
+func realmAttributesWith(extraAttributes map[string]schema.Attribute) map[string]schema.Attribute {
+	result := map[string]schema.Attribute{
+		"comment": schema.StringAttribute{
+			Description: "Description of the realm.",
+			Optional:    true,
+		},
+		"default": schema.BoolAttribute{
+			Description: "Use this realm as the default for login.",
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(false),
+		},
+		"id": attribute.ResourceID("The realm identifier, same as `realm`."),
+		"realm": schema.StringAttribute{
+			Description: "The realm identifier.",
+			MarkdownDescription: "The realm identifier. The built-in `pam` and `pve` realms can't be managed " +
+				"with this resource.",
+			Required: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(
+					regexp.MustCompile(`^[A-Za-z][A-Za-z0-9.\-_]+$`),
+					"must be a valid realm identifier",
+				),
+				stringvalidator.LengthAtMost(32),
+				stringvalidator.NoneOfCaseInsensitive(builtInRealms...),
+			},
+		},
+		"tfa": schema.StringAttribute{
+			Description:         "Use two-factor authentication.",
+			MarkdownDescription: "Use two-factor authentication, e.g. `type=oath,step=30,digits=6`.",
+			Optional:            true,
+		},
+	}
+
+	maps.Copy(result, extraAttributes)
+
+	return result
+}
+
+type realmResourceConfig struct {
+	typeNameSuffix string
+	realmType      string
+	modelFunc      func() realmModel
+}
+
+type genericRealmResource struct {
+	client *access.Client
+	config realmResourceConfig
+}
+
+func newGenericRealmResource(cfg realmResourceConfig) *genericRealmResource {
+	return &genericRealmResource{config: cfg}
+}
+
+func (r *genericRealmResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + r.config.typeNameSuffix
+}
+
+func (r *genericRealmResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Access()
+}
+
+func (r *genericRealmResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	plan := r.config.modelFunc()
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := &diag.Diagnostics{}
+	data := plan.toAPIRequestBody(ctx, diags)
+	resp.Diagnostics.Append(*diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := &access.RealmCreateRequestBody{
+		RealmData: *data,
+		ID:        plan.getID(),
+		Type:      r.config.realmType,
+	}
+	body.Password, body.ClientKey = plan.secrets()
+
+	if err := r.client.CreateRealm(ctx, body); err != nil {
+		resp.Diagnostics.AddError("Unable to Create Authentication Realm", err.Error())
+
+		return
+	}
+
+	readModel := r.read(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+
+	// the sync runs after the state is saved, so a failed sync does not leave the realm unmanaged
+	r.sync(ctx, plan, &resp.Diagnostics)
+}
+
+func (r *genericRealmResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state := r.config.modelFunc()
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	realm, err := r.client.GetRealm(ctx, state.getID())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Unable to Read Authentication Realm", err.Error())
+
+		return
+	}
+
+	readModel := r.config.modelFunc()
+	diags := &diag.Diagnostics{}
+	readModel.importFromAPI(state.getID(), realm, diags)
+	readModel.copyWriteOnly(state)
+	resp.Diagnostics.Append(*diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+}
+
+func (r *genericRealmResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := r.config.modelFunc()
+	state := r.config.modelFunc()
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := &diag.Diagnostics{}
+	planData := plan.toAPIRequestBody(ctx, diags)
+	stateData := state.toAPIRequestBody(ctx, diags)
+	resp.Diagnostics.Append(*diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := &access.RealmUpdateRequestBody{
+		RealmData: *planData,
+	}
+	body.Password, body.ClientKey = plan.secrets()
+
+	// the username claim can be set only at creation
+	body.UsernameClaim = nil
+
+	toDelete, err := realmDeletedKeys(planData, stateData)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Update Authentication Realm", err.Error())
+
+		return
+	}
+
+	if len(toDelete) > 0 {
+		d := strings.Join(toDelete, ",")
+		body.Delete = &d
+	}
+
+	if err = r.client.UpdateRealm(ctx, plan.getID(), body); err != nil {
+		resp.Diagnostics.AddError("Unable to Update Authentication Realm", err.Error())
+
+		return
+	}
+
+	readModel := r.read(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+
+	// the sync runs after the state is saved, so a failed sync does not leave the realm unmanaged
+	r.sync(ctx, plan, &resp.Diagnostics)
+}
+
+func (r *genericRealmResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state := r.config.modelFunc()
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteRealm(ctx, state.getID()); err != nil &&
+		!errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError("Unable to Delete Authentication Realm", err.Error())
+	}
+}
+
+func (r *genericRealmResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if slices.Contains(builtInRealms, strings.ToLower(req.ID)) {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("The built-in realm %q can't be managed by this resource", req.ID),
+		)
+
+		return
+	}
+
+	realm, err := r.client.GetRealm(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.Diagnostics.AddError(fmt.Sprintf("Authentication realm %s does not exist", req.ID), err.Error())
+
+			return
+		}
+
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Import Authentication Realm %s", req.ID), err.Error())
+
+		return
+	}
+
+	if realm.Type != r.config.realmType {
+		resp.Diagnostics.AddError(
+			"Unexpected Realm Type",
+			fmt.Sprintf("Realm %q has type %q, expected %q", req.ID, realm.Type, r.config.realmType),
+		)
+
+		return
+	}
+
+	readModel := r.config.modelFunc()
+	diags := &diag.Diagnostics{}
+	readModel.importFromAPI(req.ID, realm, diags)
+	resp.Diagnostics.Append(*diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+}
+
+// read reads the realm back from the API, keeping the write-only attributes of the given model.
+func (r *genericRealmResource) read(ctx context.Context, model realmModel, diags *diag.Diagnostics) realmModel {
+	realm, err := r.client.GetRealm(ctx, model.getID())
+	if err != nil {
+		diags.AddError("Unable to Read Authentication Realm", err.Error())
+
+		return nil
+	}
+
+	readModel := r.config.modelFunc()
+	readModel.importFromAPI(model.getID(), realm, diags)
+	readModel.copyWriteOnly(model)
+
+	return readModel
+}
+
+// sync runs the realm sync if requested by the model, and waits for it to complete.
+func (r *genericRealmResource) sync(ctx context.Context, model realmModel, diags *diag.Diagnostics) {
+	syncReq := model.syncRequest(ctx, diags)
+	if syncReq == nil || diags.HasError() {
+		return
+	}
+
+	if err := r.client.SyncRealm(ctx, model.getID(), syncReq); err != nil {
+		diags.AddError("Unable to Sync Authentication Realm", err.Error())
+	}
+}
+
+// realmDeletedKeys returns the API keys which are set in the state, but not in the plan.
+func realmDeletedKeys(plan, state *access.RealmData) ([]string, error) {
+	planValues, err := query.Values(plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode realm data: %w", err)
+	}
+
+	stateValues, err := query.Values(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode realm data: %w", err)
+	}
+
+	var toDelete []string
+
+	for k := range stateValues {
+		if !planValues.Has(k) {
+			toDelete = append(toDelete, k)
+		}
+	}
+
+	slices.Sort(toDelete)
+
+	return toDelete, nil
+}
+
+// Schema is required to satisfy the resource.Resource interface. It should be implemented by the specific resource.
+func (r *genericRealmResource) Schema(_ context.Context, _ resource.SchemaRequest, _ *resource.SchemaResponse) {
+	// Intentionally left blank. Should be set by the specific resource.
+}
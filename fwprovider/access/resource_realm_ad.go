@@ -0,0 +1,80 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &realmADResource{}
+	_ resource.ResourceWithImportState = &realmADResource{}
+)
+
+type realmADModel struct {
+	realmDirectoryModel
+
+	BaseDN types.String `tfsdk:"base_dn"`
+	Domain types.String `tfsdk:"domain"`
+}
+
+func (m *realmADModel) importFromAPI(id string, data *access.RealmGetResponseData, diags *diag.Diagnostics) {
+	m.realmDirectoryModel.importFromAPI(id, data, diags)
+
+	m.BaseDN = types.StringPointerValue(data.BaseDN)
+	m.Domain = types.StringPointerValue(data.Domain)
+}
+
+func (m *realmADModel) toAPIRequestBody(ctx context.Context, diags *diag.Diagnostics) *access.RealmData {
+	data := m.realmDirectoryModel.toAPIRequestBody(ctx, diags)
+
+	data.BaseDN = m.BaseDN.ValueStringPointer()
+	data.Domain = m.Domain.ValueStringPointer()
+
+	return data
+}
+
+type realmADResource struct {
+	*genericRealmResource
+}
+
+// NewRealmADResource creates a new Active Directory realm resource.
+func NewRealmADResource() resource.Resource {
+	return &realmADResource{
+		genericRealmResource: newGenericRealmResource(realmResourceConfig{
+			typeNameSuffix: "_realm_ad",
+			realmType:      access.RealmTypeAD,
+			modelFunc:      func() realmModel { return &realmADModel{} },
+		}),
+	}
+}
+
+func (r *realmADResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an Active Directory authentication realm.",
+		MarkdownDescription: "Manages an Active Directory authentication realm. Users and groups of the realm " +
+			"can be synchronized from the domain controller.",
+		Attributes: realmDirectoryAttributesWith(map[string]schema.Attribute{
+			"base_dn": schema.StringAttribute{
+				Description: "LDAP base domain name.",
+				Optional:    true,
+			},
+			"domain": schema.StringAttribute{
+				Description:         "AD domain name.",
+				MarkdownDescription: "AD domain name, e.g. `example.com`.",
+				Required:            true,
+			},
+		}),
+	}
+}
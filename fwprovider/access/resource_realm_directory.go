@@ -0,0 +1,364 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/types/stringset"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// realmDirectoryModel contains the attributes shared by the LDAP and Active Directory realms.
+type realmDirectoryModel struct {
+	realmGenericModel
+
+	BindDN              types.String            `tfsdk:"bind_dn"`
+	CAPath              types.String            `tfsdk:"capath"`
+	CaseSensitive       types.Bool              `tfsdk:"case_sensitive"`
+	Cert                types.String            `tfsdk:"cert"`
+	CertKey             types.String            `tfsdk:"cert_key"`
+	Filter              types.String            `tfsdk:"filter"`
+	GroupClasses        types.String            `tfsdk:"group_classes"`
+	GroupDN             types.String            `tfsdk:"group_dn"`
+	GroupFilter         types.String            `tfsdk:"group_filter"`
+	GroupNameAttr       types.String            `tfsdk:"group_name_attr"`
+	Mode                types.String            `tfsdk:"mode"`
+	Password            types.String            `tfsdk:"password"`
+	Port                types.Int64             `tfsdk:"port"`
+	Server1             types.String            `tfsdk:"server1"`
+	Server2             types.String            `tfsdk:"server2"`
+	SSLVersion          types.String            `tfsdk:"ssl_version"`
+	SyncAttributes      types.String            `tfsdk:"sync_attributes"`
+	SyncDefaultsOptions *realmSyncDefaultsModel `tfsdk:"sync_defaults_options"`
+	TriggerSync         types.Bool              `tfsdk:"trigger_sync"`
+	UserClasses         types.String            `tfsdk:"user_classes"`
+	Verify              types.Bool              `tfsdk:"verify"`
+}
+
+type realmSyncDefaultsModel struct {
+	EnableNew      types.Bool      `tfsdk:"enable_new"`
+	RemoveVanished stringset.Value `tfsdk:"remove_vanished"`
+	Scope          types.String    `tfsdk:"scope"`
+}
+
+// syncDefaultsOptionsData returns the "sync-defaults-options" parameter string for the API, or nil if not defined.
+func (m *realmDirectoryModel) syncDefaultsOptionsData(ctx context.Context, diags *diag.Diagnostics) *string {
+	if m.SyncDefaultsOptions == nil {
+		return nil
+	}
+
+	var params []string
+
+	if !m.SyncDefaultsOptions.EnableNew.IsNull() && !m.SyncDefaultsOptions.EnableNew.IsUnknown() {
+		params = append(params, fmt.Sprintf("enable-new=%d", boolToInt(m.SyncDefaultsOptions.EnableNew.ValueBool())))
+	}
+
+	removeVanished := m.SyncDefaultsOptions.RemoveVanished.ValueStringPointer(ctx, diags, stringset.WithSeparator(";"))
+	if removeVanished != nil {
+		params = append(params, fmt.Sprintf("remove-vanished=%s", *removeVanished))
+	}
+
+	if !m.SyncDefaultsOptions.Scope.IsNull() && m.SyncDefaultsOptions.Scope.ValueString() != "" {
+		params = append(params, fmt.Sprintf("scope=%s", m.SyncDefaultsOptions.Scope.ValueString()))
+	}
+
+	if len(params) == 0 {
+		return nil
+	}
+
+	return ptr.Ptr(strings.Join(params, ","))
+}
+
+func (m *realmDirectoryModel) importSyncDefaultsOptions(data *string, diags *diag.Diagnostics) {
+	if data == nil || *data == "" {
+		m.SyncDefaultsOptions = nil
+
+		return
+	}
+
+	opts := &realmSyncDefaultsModel{
+		EnableNew:      types.BoolNull(),
+		RemoveVanished: stringset.NewValueString(nil, diags),
+		Scope:          types.StringNull(),
+	}
+
+	for _, param := range strings.Split(*data, ",") {
+		k, v, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found {
+			continue
+		}
+
+		switch k {
+		case "enable-new":
+			opts.EnableNew = types.BoolValue(v == "1")
+		case "remove-vanished":
+			if v != "none" {
+				opts.RemoveVanished = stringset.NewValueString(&v, diags, stringset.WithSeparator(";"))
+			}
+		case "scope":
+			opts.Scope = types.StringValue(v)
+		}
+	}
+
+	m.SyncDefaultsOptions = opts
+}
+
+func (m *realmDirectoryModel) importFromAPI(id string, data *access.RealmGetResponseData, diags *diag.Diagnostics) {
+	m.realmGenericModel.importFromAPI(id, data, diags)
+
+	m.BindDN = types.StringPointerValue(data.BindDN)
+	m.CAPath = types.StringPointerValue(data.CAPath)
+	m.CaseSensitive = realmBoolValue(data.CaseSensitive, true)
+	m.Cert = types.StringPointerValue(data.Cert)
+	m.CertKey = types.StringPointerValue(data.CertKey)
+	m.Filter = types.StringPointerValue(data.Filter)
+	m.GroupClasses = types.StringPointerValue(data.GroupClasses)
+	m.GroupDN = types.StringPointerValue(data.GroupDN)
+	m.GroupFilter = types.StringPointerValue(data.GroupFilter)
+	m.GroupNameAttr = types.StringPointerValue(data.GroupNameAttr)
+	m.Mode = types.StringPointerValue(data.Mode)
+	m.Port = types.Int64PointerValue(data.Port)
+	m.Server1 = types.StringPointerValue(data.Server1)
+	m.Server2 = types.StringPointerValue(data.Server2)
+	m.SSLVersion = types.StringPointerValue(data.SSLVersion)
+	m.SyncAttributes = types.StringPointerValue(data.SyncAttributes)
+	m.UserClasses = types.StringPointerValue(data.UserClasses)
+	m.Verify = realmBoolValue(data.Verify, false)
+
+	m.importSyncDefaultsOptions(data.SyncDefaultsOptions, diags)
+}
+
+func (m *realmDirectoryModel) toAPIRequestBody(ctx context.Context, diags *diag.Diagnostics) *access.RealmData {
+	data := m.realmGenericModel.toAPIRequestBody(ctx, diags)
+
+	data.BindDN = m.BindDN.ValueStringPointer()
+	data.CAPath = m.CAPath.ValueStringPointer()
+	data.CaseSensitive = proxmoxtypes.CustomBoolPtr(m.CaseSensitive.ValueBoolPointer())
+	data.Cert = m.Cert.ValueStringPointer()
+	data.CertKey = m.CertKey.ValueStringPointer()
+	data.Filter = m.Filter.ValueStringPointer()
+	data.GroupClasses = m.GroupClasses.ValueStringPointer()
+	data.GroupDN = m.GroupDN.ValueStringPointer()
+	data.GroupFilter = m.GroupFilter.ValueStringPointer()
+	data.GroupNameAttr = m.GroupNameAttr.ValueStringPointer()
+	data.Mode = m.Mode.ValueStringPointer()
+	data.Port = m.Port.ValueInt64Pointer()
+	data.Server1 = m.Server1.ValueStringPointer()
+	data.Server2 = m.Server2.ValueStringPointer()
+	data.SSLVersion = m.SSLVersion.ValueStringPointer()
+	data.SyncAttributes = m.SyncAttributes.ValueStringPointer()
+	data.SyncDefaultsOptions = m.syncDefaultsOptionsData(ctx, diags)
+	data.UserClasses = m.UserClasses.ValueStringPointer()
+	data.Verify = proxmoxtypes.CustomBoolPtr(m.Verify.ValueBoolPointer())
+
+	return data
+}
+
+func (m *realmDirectoryModel) secrets() (*string, *string) {
+	return m.Password.ValueStringPointer(), nil
+}
+
+func (m *realmDirectoryModel) copyWriteOnly(other realmModel) {
+	var o *realmDirectoryModel
+
+	switch om := other.(type) {
+	case *realmLDAPModel:
+		o = &om.realmDirectoryModel
+	case *realmADModel:
+		o = &om.realmDirectoryModel
+	default:
+		return
+	}
+
+	m.Password = o.Password
+	m.TriggerSync = o.TriggerSync
+}
+
+func (m *realmDirectoryModel) syncRequest(_ context.Context, _ *diag.Diagnostics) *access.RealmSyncRequestBody {
+	if !m.TriggerSync.ValueBool() {
+		return nil
+	}
+
+	// the sync options default to the "sync-defaults-options" configured for the realm,
+	// except for the scope which is mandatory for the sync request
+	body := &access.RealmSyncRequestBody{
+		Scope: ptr.Ptr("both"),
+	}
+
+	if m.SyncDefaultsOptions != nil && m.SyncDefaultsOptions.Scope.ValueString() != "" {
+		body.Scope = m.SyncDefaultsOptions.Scope.ValueStringPointer()
+	}
+
+	return body
+}
+
+func realmDirectoryAttributesWith(extraAttributes map[string]schema.Attribute) map[string]schema.Attribute {
+	result := map[string]schema.Attribute{
+		"bind_dn": schema.StringAttribute{
+			Description: "LDAP bind domain name.",
+			Optional:    true,
+		},
+		"capath": schema.StringAttribute{
+			Description: "Path to the CA certificate store.",
+			Optional:    true,
+		},
+		"case_sensitive": schema.BoolAttribute{
+			Description: "Whether the username is case-sensitive.",
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(true),
+		},
+		"cert": schema.StringAttribute{
+			Description: "Path to the client certificate.",
+			Optional:    true,
+		},
+		"cert_key": schema.StringAttribute{
+			Description: "Path to the client certificate key.",
+			Optional:    true,
+		},
+		"filter": schema.StringAttribute{
+			Description: "LDAP filter for user sync.",
+			Optional:    true,
+		},
+		"group_classes": schema.StringAttribute{
+			Description:         "The objectclasses for groups.",
+			MarkdownDescription: "The objectclasses for groups, e.g. `groupOfNames,group,univentionGroup,ipausergroup`.",
+			Optional:            true,
+		},
+		"group_dn": schema.StringAttribute{
+			Description: "LDAP base domain name for group sync. If not set, the `base_dn` will be used.",
+			Optional:    true,
+		},
+		"group_filter": schema.StringAttribute{
+			Description: "LDAP filter for group sync.",
+			Optional:    true,
+		},
+		"group_name_attr": schema.StringAttribute{
+			Description: "LDAP attribute representing a groups name.",
+			Optional:    true,
+		},
+		"mode": schema.StringAttribute{
+			Description:         "LDAP protocol mode.",
+			MarkdownDescription: "LDAP protocol mode (`ldap`, `ldaps` or `ldap+starttls`).",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("ldap", "ldaps", "ldap+starttls"),
+			},
+		},
+		"password": schema.StringAttribute{
+			Description: "LDAP bind password.",
+			MarkdownDescription: "LDAP bind password. The password is stored in `/etc/pve/priv/realm/<realm>.pw` " +
+				"and can't be read back from the API.",
+			Optional:  true,
+			Sensitive: true,
+		},
+		"port": schema.Int64Attribute{
+			Description: "Server port.",
+			Optional:    true,
+			Validators: []validator.Int64{
+				int64validator.Between(1, 65535),
+			},
+		},
+		"server1": schema.StringAttribute{
+			Description: "Server IP address (or DNS name).",
+			Required:    true,
+		},
+		"server2": schema.StringAttribute{
+			Description: "Fallback server IP address (or DNS name).",
+			Optional:    true,
+		},
+		"ssl_version": schema.StringAttribute{
+			Description:         "LDAPS TLS/SSL version.",
+			MarkdownDescription: "LDAPS TLS/SSL version (`tlsv1`, `tlsv1_1`, `tlsv1_2` or `tlsv1_3`).",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("tlsv1", "tlsv1_1", "tlsv1_2", "tlsv1_3"),
+			},
+		},
+		"sync_attributes": schema.StringAttribute{
+			Description: "Comma separated list of key=value pairs for specifying which LDAP attributes map to " +
+				"which PVE user field.",
+			MarkdownDescription: "Comma separated list of key=value pairs for specifying which LDAP attributes map to " +
+				"which PVE user field, e.g. `email=mail,firstname=givenName`.",
+			Optional: true,
+		},
+		"sync_defaults_options": schema.SingleNestedAttribute{
+			Description: "The default options for the realm sync.",
+			Optional:    true,
+			Attributes: map[string]schema.Attribute{
+				"enable_new": schema.BoolAttribute{
+					Description: "Enable newly synced users immediately.",
+					Optional:    true,
+				},
+				"remove_vanished": stringset.ResourceAttribute(
+					"What to remove when entries vanish from the directory.",
+					"What to remove when entries vanish from the directory. "+
+						"Any combination of `acl`, `entry` and `properties`.",
+					func(a *schema.SetAttribute) {
+						a.Validators = append(a.Validators, setvalidator.ValueStringsAre(
+							stringvalidator.OneOf("acl", "entry", "properties"),
+						))
+					},
+				),
+				"scope": schema.StringAttribute{
+					Description:         "Select what to sync.",
+					MarkdownDescription: "Select what to sync (`users`, `groups` or `both`).",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.OneOf("users", "groups", "both"),
+					},
+				},
+			},
+		},
+		"trigger_sync": schema.BoolAttribute{
+			Description: "Run a realm sync after the realm is created or updated, and wait for it to complete.",
+			MarkdownDescription: "Run a realm sync after the realm is created or updated, and wait for it to " +
+				"complete. The sync uses the `sync_defaults_options`, the scope defaults to `both` if not set.",
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(false),
+		},
+		"user_classes": schema.StringAttribute{
+			Description:         "The objectclasses for users.",
+			MarkdownDescription: "The objectclasses for users, e.g. `inetorgperson,posixaccount,person,user`.",
+			Optional:            true,
+		},
+		"verify": schema.BoolAttribute{
+			Description: "Verify the server's SSL certificate.",
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(false),
+		},
+	}
+
+	maps.Copy(result, extraAttributes)
+
+	return realmAttributesWith(result)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
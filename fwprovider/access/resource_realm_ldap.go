@@ -0,0 +1,80 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &realmLDAPResource{}
+	_ resource.ResourceWithImportState = &realmLDAPResource{}
+)
+
+type realmLDAPModel struct {
+	realmDirectoryModel
+
+	BaseDN   types.String `tfsdk:"base_dn"`
+	UserAttr types.String `tfsdk:"user_attr"`
+}
+
+func (m *realmLDAPModel) importFromAPI(id string, data *access.RealmGetResponseData, diags *diag.Diagnostics) {
+	m.realmDirectoryModel.importFromAPI(id, data, diags)
+
+	m.BaseDN = types.StringPointerValue(data.BaseDN)
+	m.UserAttr = types.StringPointerValue(data.UserAttr)
+}
+
+func (m *realmLDAPModel) toAPIRequestBody(ctx context.Context, diags *diag.Diagnostics) *access.RealmData {
+	data := m.realmDirectoryModel.toAPIRequestBody(ctx, diags)
+
+	data.BaseDN = m.BaseDN.ValueStringPointer()
+	data.UserAttr = m.UserAttr.ValueStringPointer()
+
+	return data
+}
+
+type realmLDAPResource struct {
+	*genericRealmResource
+}
+
+// NewRealmLDAPResource creates a new LDAP realm resource.
+func NewRealmLDAPResource() resource.Resource {
+	return &realmLDAPResource{
+		genericRealmResource: newGenericRealmResource(realmResourceConfig{
+			typeNameSuffix: "_realm_ldap",
+			realmType:      access.RealmTypeLDAP,
+			modelFunc:      func() realmModel { return &realmLDAPModel{} },
+		}),
+	}
+}
+
+func (r *realmLDAPResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an LDAP authentication realm.",
+		MarkdownDescription: "Manages an LDAP authentication realm. Users and groups of the realm can be " +
+			"synchronized from the LDAP server.",
+		Attributes: realmDirectoryAttributesWith(map[string]schema.Attribute{
+			"base_dn": schema.StringAttribute{
+				Description: "LDAP base domain name.",
+				Required:    true,
+			},
+			"user_attr": schema.StringAttribute{
+				Description:         "LDAP user attribute name.",
+				MarkdownDescription: "LDAP user attribute name, e.g. `uid`.",
+				Required:            true,
+			},
+		}),
+	}
+}
@@ -0,0 +1,177 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &realmOpenIDResource{}
+	_ resource.ResourceWithImportState = &realmOpenIDResource{}
+)
+
+type realmOpenIDModel struct {
+	realmGenericModel
+
+	ACRValues        types.String `tfsdk:"acr_values"`
+	Autocreate       types.Bool   `tfsdk:"autocreate"`
+	ClientID         types.String `tfsdk:"client_id"`
+	ClientKey        types.String `tfsdk:"client_key"`
+	GroupsAutocreate types.Bool   `tfsdk:"groups_autocreate"`
+	GroupsClaim      types.String `tfsdk:"groups_claim"`
+	GroupsOverwrite  types.Bool   `tfsdk:"groups_overwrite"`
+	IssuerURL        types.String `tfsdk:"issuer_url"`
+	Prompt           types.String `tfsdk:"prompt"`
+	QueryUserinfo    types.Bool   `tfsdk:"query_userinfo"`
+	Scopes           types.String `tfsdk:"scopes"`
+	UsernameClaim    types.String `tfsdk:"username_claim"`
+}
+
+func (m *realmOpenIDModel) importFromAPI(id string, data *access.RealmGetResponseData, diags *diag.Diagnostics) {
+	m.realmGenericModel.importFromAPI(id, data, diags)
+
+	m.ACRValues = types.StringPointerValue(data.ACRValues)
+	m.Autocreate = realmBoolValue(data.Autocreate, false)
+	m.ClientID = types.StringPointerValue(data.ClientID)
+	m.GroupsAutocreate = realmBoolValue(data.GroupsAutocreate, false)
+	m.GroupsClaim = types.StringPointerValue(data.GroupsClaim)
+	m.GroupsOverwrite = realmBoolValue(data.GroupsOverwrite, false)
+	m.IssuerURL = types.StringPointerValue(data.IssuerURL)
+	m.Prompt = types.StringPointerValue(data.Prompt)
+	m.QueryUserinfo = realmBoolValue(data.QueryUserinfo, true)
+	m.Scopes = types.StringPointerValue(data.Scopes)
+	m.UsernameClaim = types.StringPointerValue(data.UsernameClaim)
+}
+
+func (m *realmOpenIDModel) toAPIRequestBody(ctx context.Context, diags *diag.Diagnostics) *access.RealmData {
+	data := m.realmGenericModel.toAPIRequestBody(ctx, diags)
+
+	data.ACRValues = m.ACRValues.ValueStringPointer()
+	data.Autocreate = proxmoxtypes.CustomBoolPtr(m.Autocreate.ValueBoolPointer())
+	data.ClientID = m.ClientID.ValueStringPointer()
+	data.GroupsAutocreate = proxmoxtypes.CustomBoolPtr(m.GroupsAutocreate.ValueBoolPointer())
+	data.GroupsClaim = m.GroupsClaim.ValueStringPointer()
+	data.GroupsOverwrite = proxmoxtypes.CustomBoolPtr(m.GroupsOverwrite.ValueBoolPointer())
+	data.IssuerURL = m.IssuerURL.ValueStringPointer()
+	data.Prompt = m.Prompt.ValueStringPointer()
+	data.QueryUserinfo = proxmoxtypes.CustomBoolPtr(m.QueryUserinfo.ValueBoolPointer())
+	data.Scopes = m.Scopes.ValueStringPointer()
+	data.UsernameClaim = m.UsernameClaim.ValueStringPointer()
+
+	return data
+}
+
+func (m *realmOpenIDModel) secrets() (*string, *string) {
+	return nil, m.ClientKey.ValueStringPointer()
+}
+
+func (m *realmOpenIDModel) copyWriteOnly(other realmModel) {
+	if o, ok := other.(*realmOpenIDModel); ok {
+		m.ClientKey = o.ClientKey
+	}
+}
+
+type realmOpenIDResource struct {
+	*genericRealmResource
+}
+
+// NewRealmOpenIDResource creates a new OpenID Connect realm resource.
+func NewRealmOpenIDResource() resource.Resource {
+	return &realmOpenIDResource{
+		genericRealmResource: newGenericRealmResource(realmResourceConfig{
+			typeNameSuffix: "_realm_openid",
+			realmType:      access.RealmTypeOpenID,
+			modelFunc:      func() realmModel { return &realmOpenIDModel{} },
+		}),
+	}
+}
+
+func (r *realmOpenIDResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an OpenID Connect authentication realm.",
+		Attributes: realmAttributesWith(map[string]schema.Attribute{
+			"acr_values": schema.StringAttribute{
+				Description: "Specifies the Authentication Context Class Reference values that the " +
+					"Authorization Server is being requested to use for the Auth Request.",
+				Optional: true,
+			},
+			"autocreate": schema.BoolAttribute{
+				Description: "Automatically create users if they do not exist.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"client_id": schema.StringAttribute{
+				Description: "OpenID Client ID.",
+				Required:    true,
+			},
+			"client_key": schema.StringAttribute{
+				Description:         "OpenID Client Key.",
+				MarkdownDescription: "OpenID Client Key. The key can't be read back from the API.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"groups_autocreate": schema.BoolAttribute{
+				Description: "Automatically create groups if they do not exist.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"groups_claim": schema.StringAttribute{
+				Description: "OpenID claim used to retrieve groups with.",
+				Optional:    true,
+			},
+			"groups_overwrite": schema.BoolAttribute{
+				Description: "All groups will be overwritten for the user on login.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"issuer_url": schema.StringAttribute{
+				Description: "OpenID Issuer URL.",
+				Required:    true,
+			},
+			"prompt": schema.StringAttribute{
+				Description: "Specifies whether the Authorization Server prompts the End-User for " +
+					"reauthentication and consent.",
+				Optional: true,
+			},
+			"query_userinfo": schema.BoolAttribute{
+				Description: "Enables querying the userinfo endpoint for claims values.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"scopes": schema.StringAttribute{
+				Description:         "Specifies the scopes (user details) that should be authorized and returned.",
+				MarkdownDescription: "Specifies the scopes (user details) that should be authorized and returned, e.g. `email profile`.",
+				Optional:            true,
+			},
+			"username_claim": schema.StringAttribute{
+				Description: "OpenID claim used to generate the unique username.",
+				MarkdownDescription: "OpenID claim used to generate the unique username. The value can't be changed " +
+					"after the realm is created.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		}),
+	}
+}
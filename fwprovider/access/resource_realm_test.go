@@ -0,0 +1,134 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceRealm(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitEnvironment(t)
+
+	realmID := "r" + gofakeit.LetterN(8)
+
+	te.AddTemplateVars(map[string]any{
+		"RealmID": realmID,
+	})
+
+	tests := []struct {
+		name  string
+		steps []resource.TestStep
+	}{
+		{"built-in realm is rejected", []resource.TestStep{{
+			Config: te.RenderConfig(`resource "proxmox_virtual_environment_realm_openid" "realm" {
+				realm      = "pam"
+				issuer_url = "https://auth.example.com"
+				client_id  = "proxmox"
+			}`),
+			ExpectError: regexp.MustCompile(`value must be none of`),
+		}}},
+		{"create and update openid realm", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`resource "proxmox_virtual_environment_realm_openid" "realm" {
+					realm      = "{{.RealmID}}"
+					comment    = "Managed by Terraform"
+					issuer_url = "https://auth.example.com"
+					client_id  = "proxmox"
+					client_key = "secret"
+					autocreate = true
+				}`),
+				Check: test.ResourceAttributes("proxmox_virtual_environment_realm_openid.realm", map[string]string{
+					"autocreate": "true",
+					"client_id":  "proxmox",
+					"comment":    "Managed by Terraform",
+					"issuer_url": "https://auth.example.com",
+				}),
+			},
+			{
+				Config: te.RenderConfig(`resource "proxmox_virtual_environment_realm_openid" "realm" {
+					realm      = "{{.RealmID}}"
+					issuer_url = "https://auth.example.com"
+					client_id  = "proxmox-2"
+					client_key = "secret"
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_realm_openid.realm", map[string]string{
+						"autocreate": "false",
+						"client_id":  "proxmox-2",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_realm_openid.realm", []string{
+						"comment",
+					}),
+				),
+			},
+			{
+				ResourceName:            "proxmox_virtual_environment_realm_openid.realm",
+				ImportState:             true,
+				ImportStateId:           realmID,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client_key"},
+			},
+		}},
+		{"create and update ldap realm", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`resource "proxmox_virtual_environment_realm_ldap" "realm" {
+					realm     = "{{.RealmID}}l"
+					server1   = "ldap.example.com"
+					base_dn   = "dc=example,dc=com"
+					user_attr = "uid"
+					sync_defaults_options = {
+						scope           = "users"
+						remove_vanished = ["acl", "entry"]
+					}
+				}`),
+				Check: test.ResourceAttributes("proxmox_virtual_environment_realm_ldap.realm", map[string]string{
+					"base_dn":                     "dc=example,dc=com",
+					"case_sensitive":              "true",
+					"sync_defaults_options.scope": "users",
+					"sync_defaults_options.remove_vanished.#": "2",
+				}),
+			},
+			{
+				Config: te.RenderConfig(`resource "proxmox_virtual_environment_realm_ldap" "realm" {
+					realm     = "{{.RealmID}}l"
+					server1   = "ldap.example.com"
+					server2   = "ldap2.example.com"
+					base_dn   = "dc=example,dc=com"
+					user_attr = "cn"
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_realm_ldap.realm", map[string]string{
+						"server2":   "ldap2.example.com",
+						"user_attr": "cn",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_realm_ldap.realm", []string{
+						"sync_defaults_options.scope",
+					}),
+				),
+			},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: te.AccProviders,
+				Steps:                    tt.steps,
+			})
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/stretchr/testify/require"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
@@ -172,11 +173,57 @@ func TestAccResourceUserToken(t *testing.T) {
 						}),
 					),
 				},
+				{
+					Config: te.RenderConfig(`resource "proxmox_virtual_environment_user_token" "user_token" {
+						comment  			  = "Managed by Terraform 2"
+						privileges_separation = false
+						secret_regenerated    = "1"
+						token_name 			  = "{{.TokenName}}"
+						user_id  			  = "{{.UserID}}"
+					}`),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction(
+								"proxmox_virtual_environment_user_token.user_token",
+								plancheck.ResourceActionReplace,
+							),
+						},
+					},
+					Check: test.ResourceAttributes("proxmox_virtual_environment_user_token.user_token", map[string]string{
+						"secret_regenerated": "1",
+						"value":              fmt.Sprintf("%s!%s=.*", userID, tokenName),
+					}),
+				},
+				{
+					PreConfig: func() {
+						err := te.AccessClient().DeleteUserToken(context.Background(), userID, tokenName)
+						require.NoError(t, err)
+					},
+					Config: te.RenderConfig(`resource "proxmox_virtual_environment_user_token" "user_token" {
+						comment  			  = "Managed by Terraform 2"
+						privileges_separation = false
+						secret_regenerated    = "1"
+						token_name 			  = "{{.TokenName}}"
+						user_id  			  = "{{.UserID}}"
+					}`),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							plancheck.ExpectResourceAction(
+								"proxmox_virtual_environment_user_token.user_token",
+								plancheck.ResourceActionCreate,
+							),
+						},
+					},
+					Check: test.ResourceAttributes("proxmox_virtual_environment_user_token.user_token", map[string]string{
+						"value": fmt.Sprintf("%s!%s=.*", userID, tokenName),
+					}),
+				},
 				{
 					ResourceName:      "proxmox_virtual_environment_user_token.user_token",
 					ImportState:       true,
 					ImportStateVerify: true,
 					ImportStateVerifyIgnore: []string{
+						"secret_regenerated",
 						"value",
 					},
 				},
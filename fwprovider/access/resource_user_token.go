@@ -8,6 +8,7 @@ package access
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -27,6 +28,7 @@ import (
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
 )
 
@@ -41,13 +43,14 @@ type userTokenResource struct {
 }
 
 type userTokenModel struct {
-	Comment        types.String `tfsdk:"comment"`
-	ExpirationDate types.String `tfsdk:"expiration_date"`
-	ID             types.String `tfsdk:"id"`
-	PrivSeparation types.Bool   `tfsdk:"privileges_separation"`
-	UserID         types.String `tfsdk:"user_id"`
-	TokenName      types.String `tfsdk:"token_name"`
-	Value          types.String `tfsdk:"value"`
+	Comment           types.String `tfsdk:"comment"`
+	ExpirationDate    types.String `tfsdk:"expiration_date"`
+	ID                types.String `tfsdk:"id"`
+	PrivSeparation    types.Bool   `tfsdk:"privileges_separation"`
+	SecretRegenerated types.String `tfsdk:"secret_regenerated"`
+	UserID            types.String `tfsdk:"user_id"`
+	TokenName         types.String `tfsdk:"token_name"`
+	Value             types.String `tfsdk:"value"`
 }
 
 // NewUserTokenResource creates a new user token resource.
@@ -85,6 +88,15 @@ func (r *userTokenResource) Schema(
 				Computed: true,
 				Default:  booldefault.StaticBool(true),
 			},
+			"secret_regenerated": schema.StringAttribute{
+				Description: "Arbitrary value that triggers regeneration of the token secret when changed.",
+				MarkdownDescription: "Arbitrary value that triggers regeneration of the token secret when changed. " +
+					"The token is re-created, and the new secret is captured in `value`.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"token_name": schema.StringAttribute{
 				Description: "User-specific token identifier.",
 				Required:    true,
@@ -138,8 +150,6 @@ func (r *userTokenResource) Configure(
 	}
 
 	r.client = cfg.Client
-
-	r.client = cfg.Client
 }
 
 func (r *userTokenResource) Metadata(
@@ -174,6 +184,16 @@ func (r *userTokenResource) Create(ctx context.Context, req resource.CreateReque
 		body.ExpirationDate = &v
 	}
 
+	// an expired token is removed from the state on read, but it still exists in PVE and would block the creation
+	data, err := r.client.Access().GetUserToken(ctx, plan.UserID.ValueString(), plan.TokenName.ValueString())
+	if err == nil && userTokenExpired(data) {
+		err = r.client.Access().DeleteUserToken(ctx, plan.UserID.ValueString(), plan.TokenName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error deleting expired user token", err.Error())
+			return
+		}
+	}
+
 	value, err := r.client.Access().CreateUserToken(ctx, plan.UserID.ValueString(), plan.TokenName.ValueString(), &body)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating user token", err.Error())
@@ -200,7 +220,24 @@ func (r *userTokenResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	data, err := r.client.Access().GetUserToken(ctx, state.UserID.ValueString(), state.TokenName.ValueString())
 	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
 		resp.Diagnostics.AddError("Error reading user token", err.Error())
+
+		return
+	}
+
+	if userTokenExpired(data) {
+		resp.Diagnostics.AddWarning(
+			"User token has expired",
+			fmt.Sprintf("The user token %q has expired and will be re-created.", state.ID.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+
 		return
 	}
 
@@ -253,7 +290,7 @@ func (r *userTokenResource) Update(ctx context.Context, req resource.UpdateReque
 
 	err := r.client.Access().UpdateUserToken(ctx, plan.UserID.ValueString(), plan.TokenName.ValueString(), &body)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating user token", err.Error())
+		resp.Diagnostics.AddError("Error updating user token", err.Error())
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -321,3 +358,9 @@ func (r *userTokenResource) ImportState(
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
+
+// userTokenExpired returns true if the token has an expiration date set in the past.
+func userTokenExpired(data *access.UserTokenGetResponseData) bool {
+	return data.ExpirationDate != nil && *data.ExpirationDate > 0 &&
+		time.Unix(int64(*data.ExpirationDate), 0).Before(time.Now())
+}
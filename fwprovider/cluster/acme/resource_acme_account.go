@@ -39,8 +39,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/acme/account"
 )
 
@@ -59,6 +61,8 @@ func NewACMEAccountResource() resource.Resource {
 type acmeAccountResource struct {
 	// The ACME account API client
 	client *account.Client
+	// The Proxmox API client, used to look up the nodes using the account
+	api proxmox.Client
 }
 
 // acmeAccountModel maps the schema data for the ACME account resource.
@@ -99,7 +103,8 @@ func (r *acmeAccountResource) Schema(
 	resp.Schema = schema.Schema{
 		Description: "Manages an ACME account in a Proxmox VE cluster.",
 		MarkdownDescription: "Manages an ACME account in a Proxmox VE cluster.\n\n" +
-			"~> This resource requires `root@pam` authentication.",
+			"~> This resource requires `root@pam` authentication.\n\n" +
+			"-> The account can't be deleted while it's used by the ACME configuration of a node.",
 		Attributes: map[string]schema.Attribute{
 			"contact": schema.StringAttribute{
 				Description: "The contact email addresses.",
@@ -122,6 +127,7 @@ func (r *acmeAccountResource) Schema(
 			"eab_hmac_key": schema.StringAttribute{
 				Description: "The HMAC key for External Account Binding.",
 				Optional:    true,
+				Sensitive:   true,
 			},
 			"eab_kid": schema.StringAttribute{
 				Description: "The Key Identifier for External Account Binding.",
@@ -166,6 +172,7 @@ func (r *acmeAccountResource) Configure(
 	}
 
 	r.client = cfg.Client.Cluster().ACME().Account()
+	r.api = cfg.Client
 }
 
 // Create creates a new ACME account on the Proxmox cluster.
@@ -264,7 +271,30 @@ func (r *acmeAccountResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	err := r.client.Delete(ctx, state.Name.ValueString())
+	nodeNames, err := r.usedBy(ctx, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to check whether ACME account '%s' is in use", state.Name),
+			err.Error(),
+		)
+
+		return
+	}
+
+	if len(nodeNames) > 0 {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("ACME account '%s' is in use", state.Name),
+			fmt.Sprintf(
+				"The account is used to order the certificates of the nodes: %s. "+
+					"Remove the ACME configuration of the nodes, or switch them to another account, before deleting it.",
+				strings.Join(nodeNames, ", "),
+			),
+		)
+
+		return
+	}
+
+	err = r.client.Delete(ctx, state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to delete ACME account '%s'", state.Name),
@@ -284,6 +314,34 @@ func (r *acmeAccountResource) ImportState(
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
 
+// usedBy returns the names of the nodes which are configured to order certificates using the account.
+func (r *acmeAccountResource) usedBy(ctx context.Context, name string) ([]string, error) {
+	list, err := r.api.Node("").ListNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	var nodeNames []string
+
+	for _, node := range list {
+		cfg, err := r.api.Node(node.Name).GetConfig(ctx)
+		if err != nil {
+			tflog.Warn(ctx, "failed to read the node config, skipping it", map[string]any{
+				"node_name": node.Name,
+				"error":     err.Error(),
+			})
+
+			continue
+		}
+
+		if cfg.ACMEAccountName() == name {
+			nodeNames = append(nodeNames, node.Name)
+		}
+	}
+
+	return nodeNames, nil
+}
+
 func (r *acmeAccountResource) readBack(
 	ctx context.Context,
 	data *acmeAccountModel,
@@ -65,6 +65,7 @@ func (r *acmePluginResource) Schema(
 			"data": schema.MapAttribute{
 				Description: "DNS plugin data.",
 				Optional:    true,
+				Sensitive:   true,
 				ElementType: types.StringType,
 			},
 			"digest": schema.StringAttribute{
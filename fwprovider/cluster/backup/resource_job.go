@@ -0,0 +1,553 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backup
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/types/stringset"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/backup"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var (
+	_ resource.ResourceWithConfigure        = &jobResource{}
+	_ resource.ResourceWithImportState      = &jobResource{}
+	_ resource.ResourceWithConfigValidators = &jobResource{}
+)
+
+type pruneBackupsModel struct {
+	KeepAll     types.Bool  `tfsdk:"keep_all"`
+	KeepLast    types.Int64 `tfsdk:"keep_last"`
+	KeepHourly  types.Int64 `tfsdk:"keep_hourly"`
+	KeepDaily   types.Int64 `tfsdk:"keep_daily"`
+	KeepWeekly  types.Int64 `tfsdk:"keep_weekly"`
+	KeepMonthly types.Int64 `tfsdk:"keep_monthly"`
+	KeepYearly  types.Int64 `tfsdk:"keep_yearly"`
+}
+
+type jobModel struct {
+	ID               types.String       `tfsdk:"id"`
+	All              types.Bool         `tfsdk:"all"`
+	BandwidthLimit   types.Int64        `tfsdk:"bandwidth_limit"`
+	Comment          types.String       `tfsdk:"comment"`
+	Compress         types.String       `tfsdk:"compress"`
+	Enabled          types.Bool         `tfsdk:"enabled"`
+	MailNotification types.String       `tfsdk:"mail_notification"`
+	MailTo           stringset.Value    `tfsdk:"mailto"`
+	Mode             types.String       `tfsdk:"mode"`
+	Node             types.String       `tfsdk:"node"`
+	NotesTemplate    types.String       `tfsdk:"notes_template"`
+	NotificationMode types.String       `tfsdk:"notification_mode"`
+	Pool             types.String       `tfsdk:"pool"`
+	PruneBackups     *pruneBackupsModel `tfsdk:"prune_backups"`
+	Schedule         types.String       `tfsdk:"schedule"`
+	Storage          types.String       `tfsdk:"storage"`
+	VMIDs            types.Set          `tfsdk:"vm_ids"`
+}
+
+// pruneBackupsKeys maps the PVE retention options to the attributes of the `prune_backups` block.
+//
+//nolint:gochecknoglobals
+var pruneBackupsKeys = []struct {
+	key   string
+	value func(m *pruneBackupsModel) *types.Int64
+}{
+	{"keep-last", func(m *pruneBackupsModel) *types.Int64 { return &m.KeepLast }},
+	{"keep-hourly", func(m *pruneBackupsModel) *types.Int64 { return &m.KeepHourly }},
+	{"keep-daily", func(m *pruneBackupsModel) *types.Int64 { return &m.KeepDaily }},
+	{"keep-weekly", func(m *pruneBackupsModel) *types.Int64 { return &m.KeepWeekly }},
+	{"keep-monthly", func(m *pruneBackupsModel) *types.Int64 { return &m.KeepMonthly }},
+	{"keep-yearly", func(m *pruneBackupsModel) *types.Int64 { return &m.KeepYearly }},
+}
+
+func (m *jobModel) importFromAPI(data *backup.JobData, diags *diag.Diagnostics) {
+	m.ID = types.StringValue(data.ID)
+
+	// PVE omits the default values, so the booleans are normalized to avoid diffs
+	m.All = types.BoolValue(data.All != nil && bool(*data.All))
+	m.Enabled = types.BoolValue(data.Enabled == nil || bool(*data.Enabled))
+
+	m.BandwidthLimit = types.Int64PointerValue(data.BandwidthLimit)
+	m.Comment = types.StringPointerValue(data.Comment)
+	m.Compress = types.StringPointerValue(data.Compress)
+	m.MailNotification = types.StringPointerValue(data.MailNotification)
+	m.MailTo = stringset.NewValueString(data.MailTo, diags, stringset.WithSeparator(","))
+	m.Mode = types.StringPointerValue(data.Mode)
+	m.Node = types.StringPointerValue(data.Node)
+	m.NotesTemplate = types.StringPointerValue(data.NotesTemplate)
+	m.NotificationMode = types.StringPointerValue(data.NotificationMode)
+	m.Pool = types.StringPointerValue(data.Pool)
+	m.Schedule = types.StringValue(data.Schedule)
+	m.Storage = types.StringPointerValue(data.Storage)
+
+	m.PruneBackups = nil
+
+	if data.PruneBackups != nil && len(*data.PruneBackups) > 0 {
+		pb := &pruneBackupsModel{
+			KeepAll: types.BoolNull(),
+		}
+
+		if v, ok := (*data.PruneBackups)["keep-all"]; ok {
+			pb.KeepAll = types.BoolValue(v == "1")
+		}
+
+		for _, k := range pruneBackupsKeys {
+			*k.value(pb) = types.Int64Null()
+
+			if v, ok := (*data.PruneBackups)[k.key]; ok {
+				i, err := strconv.ParseInt(v, 10, 64)
+				if err != nil {
+					diags.AddError("Unable to parse the backup retention", fmt.Sprintf("invalid %s: %s", k.key, v))
+
+					continue
+				}
+
+				*k.value(pb) = types.Int64Value(i)
+			}
+		}
+
+		m.PruneBackups = pb
+	}
+
+	m.VMIDs = types.SetNull(types.Int64Type)
+
+	if data.VMIDs != nil && *data.VMIDs != "" {
+		var vmIDs []attr.Value
+
+		for _, id := range strings.Split(*data.VMIDs, ",") {
+			i, err := strconv.ParseInt(strings.TrimSpace(id), 10, 64)
+			if err != nil {
+				diags.AddError("Unable to parse the backup VM IDs", fmt.Sprintf("invalid VM ID: %s", id))
+
+				continue
+			}
+
+			vmIDs = append(vmIDs, types.Int64Value(i))
+		}
+
+		var d diag.Diagnostics
+
+		m.VMIDs, d = types.SetValue(types.Int64Type, vmIDs)
+		diags.Append(d...)
+	}
+}
+
+func (m *jobModel) toAPIRequestBody(ctx context.Context, diags *diag.Diagnostics) *backup.JobRequestData {
+	data := &backup.JobRequestData{}
+
+	data.ID = m.ID.ValueString()
+	data.All = proxmoxtypes.CustomBool(m.All.ValueBool()).Pointer()
+	data.BandwidthLimit = m.BandwidthLimit.ValueInt64Pointer()
+	data.Comment = m.Comment.ValueStringPointer()
+	data.Compress = m.Compress.ValueStringPointer()
+	data.Enabled = proxmoxtypes.CustomBool(m.Enabled.ValueBool()).Pointer()
+	data.MailNotification = m.MailNotification.ValueStringPointer()
+	data.MailTo = m.MailTo.ValueStringPointer(ctx, diags, stringset.WithSeparator(","))
+	data.Mode = m.Mode.ValueStringPointer()
+	data.Node = m.Node.ValueStringPointer()
+	data.NotesTemplate = m.NotesTemplate.ValueStringPointer()
+	data.NotificationMode = m.NotificationMode.ValueStringPointer()
+	data.Pool = m.Pool.ValueStringPointer()
+	data.Schedule = m.Schedule.ValueString()
+	data.Storage = m.Storage.ValueStringPointer()
+
+	if m.PruneBackups != nil {
+		pb := backup.PruneBackups{}
+
+		if !m.PruneBackups.KeepAll.IsNull() && !m.PruneBackups.KeepAll.IsUnknown() {
+			pb["keep-all"] = "0"
+			if m.PruneBackups.KeepAll.ValueBool() {
+				pb["keep-all"] = "1"
+			}
+		}
+
+		for _, k := range pruneBackupsKeys {
+			if v := k.value(m.PruneBackups); !v.IsNull() && !v.IsUnknown() {
+				pb[k.key] = strconv.FormatInt(v.ValueInt64(), 10)
+			}
+		}
+
+		if len(pb) > 0 {
+			data.PruneBackups = &pb
+		}
+	}
+
+	if !m.VMIDs.IsNull() && !m.VMIDs.IsUnknown() {
+		var vmIDs []int64
+
+		diags.Append(m.VMIDs.ElementsAs(ctx, &vmIDs, false)...)
+
+		sort.Slice(vmIDs, func(i, j int) bool { return vmIDs[i] < vmIDs[j] })
+
+		ids := make([]string, len(vmIDs))
+		for i, id := range vmIDs {
+			ids[i] = strconv.FormatInt(id, 10)
+		}
+
+		if len(ids) > 0 {
+			data.VMIDs = ptr.Ptr(strings.Join(ids, ","))
+		}
+	}
+
+	return data
+}
+
+func (m *jobModel) toDelete(ctx context.Context, state *jobModel, diags *diag.Diagnostics) []string {
+	var toDelete []string
+
+	checkDelete(m.BandwidthLimit, state.BandwidthLimit, &toDelete, "bwlimit")
+	checkDelete(m.Comment, state.Comment, &toDelete, "comment")
+	checkDelete(m.Compress, state.Compress, &toDelete, "compress")
+	checkDelete(m.MailNotification, state.MailNotification, &toDelete, "mailnotification")
+	checkDelete(m.Mode, state.Mode, &toDelete, "mode")
+	checkDelete(m.Node, state.Node, &toDelete, "node")
+	checkDelete(m.NotesTemplate, state.NotesTemplate, &toDelete, "notes-template")
+	checkDelete(m.NotificationMode, state.NotificationMode, &toDelete, "notification-mode")
+	checkDelete(m.Pool, state.Pool, &toDelete, "pool")
+	checkDelete(m.Storage, state.Storage, &toDelete, "storage")
+	checkDelete(m.VMIDs, state.VMIDs, &toDelete, "vmid")
+
+	if m.PruneBackups == nil && state.PruneBackups != nil {
+		toDelete = append(toDelete, "prune-backups")
+	}
+
+	if len(m.MailTo.ValueList(ctx, diags)) == 0 && len(state.MailTo.ValueList(ctx, diags)) > 0 {
+		toDelete = append(toDelete, "mailto")
+	}
+
+	return toDelete
+}
+
+func checkDelete(planField, stateField attr.Value, toDelete *[]string, apiName string) {
+	// the attribute must be removed via the API if it is set in the state,
+	// but has been removed from the resource to use the PVE default
+	if planField.IsNull() && !stateField.IsNull() {
+		*toDelete = append(*toDelete, apiName)
+	}
+}
+
+// generateJobID generates a job ID in the same format as PVE does, e.g. `backup-1a2b3c4d-5e6f`.
+func generateJobID() (string, error) {
+	b := make([]byte, 6)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate backup job ID: %w", err)
+	}
+
+	return fmt.Sprintf("backup-%s-%s", hex.EncodeToString(b[:4]), hex.EncodeToString(b[4:])), nil
+}
+
+type jobResource struct {
+	client *backup.Client
+}
+
+// NewJobResource creates a new backup job resource.
+func NewJobResource() resource.Resource {
+	return &jobResource{}
+}
+
+func (r *jobResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backup_job"
+}
+
+func (r *jobResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster().Backup()
+}
+
+func (r *jobResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	keepAttribute := func(desc string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Description: desc,
+			Optional:    true,
+			Validators:  []validator.Int64{int64validator.AtLeast(1)},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages a cluster backup (vzdump) job.",
+		MarkdownDescription: "Manages a cluster backup (vzdump) job.\n\n" +
+			"Exactly one of `vm_ids`, `pool` or `all` selects the guests to back up. " +
+			"Deleting the job does not delete the backups it has created.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the backup job. Generated if not set, e.g. `backup-1a2b3c4d-5e6f`.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`),
+						"must start with a letter and contain only letters, digits, `-` and `_`",
+					),
+				},
+			},
+			"all": schema.BoolAttribute{
+				Description: "Whether to back up all guests on the selected node(s).",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"bandwidth_limit": schema.Int64Attribute{
+				Description: "The I/O bandwidth limit in KiB/s.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"comment": schema.StringAttribute{
+				Description: "The comment of the backup job.",
+				Optional:    true,
+			},
+			"compress": schema.StringAttribute{
+				Description: "The compression algorithm, one of `0` (none), `1`, `gzip`, `lzo` or `zstd`.",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf("0", "1", "gzip", "lzo", "zstd")},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the backup job is enabled.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"mail_notification": schema.StringAttribute{
+				Description: "When to send an email notification, one of `always` or `failure`. " +
+					"Deprecated in PVE 8.1 in favor of the notification system.",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf("always", "failure")},
+			},
+			"mailto": stringset.ResourceAttribute(
+				"The email addresses or users to send the email notifications to.", "",
+			),
+			"mode": schema.StringAttribute{
+				Description: "The backup mode, one of `snapshot`, `suspend` or `stop`. " +
+					"If not set, PVE default is `snapshot`.",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf("snapshot", "suspend", "stop")},
+			},
+			"node": schema.StringAttribute{
+				Description: "Only run the backup job on this node.",
+				Optional:    true,
+			},
+			"notes_template": schema.StringAttribute{
+				Description: "The template for the notes of the backups, e.g. `{{guestname}}`.",
+				Optional:    true,
+			},
+			"notification_mode": schema.StringAttribute{
+				Description: "The notification mode, one of `auto`, `legacy-sendmail` or `notification-system`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("auto", "legacy-sendmail", "notification-system"),
+				},
+			},
+			"pool": schema.StringAttribute{
+				Description: "Back up all guests of this pool.",
+				Optional:    true,
+			},
+			"prune_backups": schema.SingleNestedAttribute{
+				Description: "The retention options of the backups. If not set, the retention of the storage is used.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"keep_all": schema.BoolAttribute{
+						Description: "Keep all backups, conflicts with the other options.",
+						Optional:    true,
+					},
+					"keep_last":    keepAttribute("Keep the last N backups."),
+					"keep_hourly":  keepAttribute("Keep the backups of the last N hours."),
+					"keep_daily":   keepAttribute("Keep the backups of the last N days."),
+					"keep_weekly":  keepAttribute("Keep the backups of the last N weeks."),
+					"keep_monthly": keepAttribute("Keep the backups of the last N months."),
+					"keep_yearly":  keepAttribute("Keep the backups of the last N years."),
+				},
+			},
+			"schedule": schema.StringAttribute{
+				Description: "The schedule of the backup job in the calendar event format, " +
+					"e.g. `daily`, `sat 02:00` or `mon..fri 21:30`.",
+				Required:   true,
+				Validators: []validator.String{validators.CalendarEventValidator()},
+			},
+			"storage": schema.StringAttribute{
+				Description: "The storage to store the backups in. If not set, PVE default is `local`.",
+				Optional:    true,
+			},
+			"vm_ids": schema.SetAttribute{
+				Description: "The IDs of the guests to back up.",
+				Optional:    true,
+				ElementType: types.Int64Type,
+			},
+		},
+	}
+}
+
+func (r *jobResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("vm_ids"),
+			path.MatchRoot("pool"),
+			path.MatchRoot("all"),
+		),
+	}
+}
+
+func (r *jobResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan jobModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ID.IsUnknown() || plan.ID.ValueString() == "" {
+		id, err := generateJobID()
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Create Backup Job", err.Error())
+
+			return
+		}
+
+		plan.ID = types.StringValue(id)
+	}
+
+	reqData := plan.toAPIRequestBody(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.CreateJob(ctx, reqData); err != nil {
+		resp.Diagnostics.AddError("Unable to Create Backup Job", err.Error())
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *jobResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state jobModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, err := r.client.GetJob(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Unable to Read Backup Job", err.Error())
+
+		return
+	}
+
+	readModel := &jobModel{}
+	readModel.importFromAPI(data, &resp.Diagnostics)
+
+	// PVE stores the schedule as is, but keep the configured value if it only differs in whitespace or case
+	if normalizedState, e := proxmoxtypes.ParseCalendarEvent(state.Schedule.ValueString()); e == nil {
+		if normalizedRead, e := proxmoxtypes.ParseCalendarEvent(data.Schedule); e == nil && normalizedState == normalizedRead {
+			readModel.Schedule = state.Schedule
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+}
+
+func (r *jobResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state jobModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := plan.toAPIRequestBody(ctx, &resp.Diagnostics)
+	reqData.Delete = plan.toDelete(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.UpdateJob(ctx, reqData); err != nil {
+		resp.Diagnostics.AddError("Unable to Update Backup Job", err.Error())
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *jobResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state jobModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteJob(ctx, state.ID.ValueString()); err != nil &&
+		!errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError("Unable to Delete Backup Job", err.Error())
+	}
+}
+
+func (r *jobResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,153 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backup_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceBackupJob(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	tests := []struct {
+		name  string
+		steps []resource.TestStep
+	}{
+		{"invalid configuration", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_backup_job" "acc_job" {
+					schedule = "sat 25:00"
+					all      = true
+				}`),
+				ExpectError: regexp.MustCompile(`valid calendar event`),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_backup_job" "acc_job" {
+					schedule = "daily"
+					all      = true
+					pool     = "pool"
+				}`),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		}},
+		{"create and update backup job", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_backup_job" "acc_job" {
+					id       = "acc-backup-job"
+					schedule = "sat 02:00"
+					storage  = "local"
+					vm_ids   = [100, 101]
+					mode     = "snapshot"
+					compress = "zstd"
+
+					prune_backups = {
+						keep_last  = 3
+						keep_daily = 7
+					}
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_backup_job.acc_job", map[string]string{
+						"id":                       "acc-backup-job",
+						"schedule":                 "sat 02:00",
+						"all":                      "false",
+						"enabled":                  "true",
+						"vm_ids.#":                 "2",
+						"prune_backups.keep_last":  "3",
+						"prune_backups.keep_daily": "7",
+					}),
+				),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_backup_job" "acc_job" {
+					id       = "acc-backup-job"
+					schedule = "sat 02:00"
+					storage  = "local"
+					vm_ids   = [101, 100]
+					mode     = "snapshot"
+					compress = "zstd"
+
+					prune_backups = {
+						keep_last  = 3
+						keep_daily = 7
+					}
+				}`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_backup_job" "acc_job" {
+					id       = "acc-backup-job"
+					schedule = "mon..fri 21:30"
+					all      = true
+					enabled  = false
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_backup_job.acc_job", map[string]string{
+						"schedule": "mon..fri 21:30",
+						"all":      "true",
+						"enabled":  "false",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_backup_job.acc_job", []string{
+						"vm_ids",
+						"storage",
+						"mode",
+						"compress",
+						"prune_backups",
+					}),
+				),
+			},
+			{
+				ResourceName:      "proxmox_virtual_environment_backup_job.acc_job",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		}},
+		{"generated job id", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_backup_job" "acc_job" {
+					schedule = "daily"
+					pool     = proxmox_virtual_environment_pool.acc_pool.pool_id
+				}
+
+				resource "proxmox_virtual_environment_pool" "acc_pool" {
+					pool_id = "acc-backup-pool"
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"proxmox_virtual_environment_backup_job.acc_job", "id", regexp.MustCompile(`^backup-[0-9a-f]{8}-[0-9a-f]{4}$`),
+					),
+				),
+			},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: te.AccProviders,
+				Steps:                    tt.steps,
+			})
+		})
+	}
+}
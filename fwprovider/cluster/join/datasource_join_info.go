@@ -0,0 +1,246 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package join
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+)
+
+var (
+	_ datasource.DataSource              = &joinInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &joinInfoDataSource{}
+)
+
+type joinInfoDataSourceModel struct {
+	ClusterName   types.String            `tfsdk:"cluster_name"`
+	ConfigDigest  types.String            `tfsdk:"config_digest"`
+	Fingerprint   types.String            `tfsdk:"fingerprint"`
+	ID            types.String            `tfsdk:"id"`
+	NodeName      types.String            `tfsdk:"node_name"`
+	Nodes         []joinInfoNodeModel     `tfsdk:"nodes"`
+	PeerAddress   types.String            `tfsdk:"peer_address"`
+	PreferredNode types.String            `tfsdk:"preferred_node"`
+	Totem         map[string]types.String `tfsdk:"totem"`
+}
+
+type joinInfoNodeModel struct {
+	Address     types.String `tfsdk:"address"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+	Name        types.String `tfsdk:"name"`
+	NodeID      types.Int64  `tfsdk:"node_id"`
+	QuorumVotes types.Int64  `tfsdk:"quorum_votes"`
+	Ring0Addr   types.String `tfsdk:"ring0_address"`
+}
+
+// NewJoinInfoDataSource creates a new data source for the information required to join a node to the cluster.
+func NewJoinInfoDataSource() datasource.DataSource {
+	return &joinInfoDataSource{}
+}
+
+type joinInfoDataSource struct {
+	client proxmox.Client
+}
+
+func (d *joinInfoDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_join_info"
+}
+
+// Schema defines the schema for the data source.
+func (d *joinInfoDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the information required to join a node to the cluster.",
+		MarkdownDescription: "Retrieves the information required to join a node to the cluster, " +
+			"i.e. the corosync totem configuration, the cluster nodes and the certificate fingerprint of the node " +
+			"to join through. Use it with the `proxmox_virtual_environment_cluster_node_join` resource.",
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Description: "The name of the cluster.",
+				Computed:    true,
+			},
+			"config_digest": schema.StringAttribute{
+				Description: "The digest of the corosync configuration.",
+				Computed:    true,
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "The SSL certificate fingerprint of the node to join through.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier attribute.",
+				Computed:    true,
+			},
+			"node_name": schema.StringAttribute{
+				Description: "The cluster node to join through. Defaults to the node serving the API request.",
+				Optional:    true,
+			},
+			"nodes": schema.ListNestedAttribute{
+				Description: "The nodes of the cluster.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							Description: "The address of the node's API.",
+							Computed:    true,
+						},
+						"fingerprint": schema.StringAttribute{
+							Description: "The SSL certificate fingerprint of the node.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the node.",
+							Computed:    true,
+						},
+						"node_id": schema.Int64Attribute{
+							Description: "The corosync node identifier.",
+							Computed:    true,
+						},
+						"quorum_votes": schema.Int64Attribute{
+							Description: "The number of quorum votes of the node.",
+							Computed:    true,
+						},
+						"ring0_address": schema.StringAttribute{
+							Description: "The address of the node's first corosync link.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"peer_address": schema.StringAttribute{
+				Description: "The API address of the node to join through.",
+				Computed:    true,
+			},
+			"preferred_node": schema.StringAttribute{
+				Description: "The name of the node to join through.",
+				Computed:    true,
+			},
+			"totem": schema.MapAttribute{
+				Description: "The corosync totem configuration. Nested values are encoded as JSON.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *joinInfoDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+func (d *joinInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model joinInfoDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := d.client.Cluster().Config().GetJoinInfo(ctx, model.NodeName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Cluster Join Information",
+			"Could not retrieve the cluster join information, is the node a member of a cluster? "+err.Error(),
+		)
+
+		return
+	}
+
+	model.ConfigDigest = types.StringValue(info.ConfigDigest)
+	model.PreferredNode = types.StringValue(info.PreferredNode)
+	model.Totem = totemValues(info.Totem)
+	model.ClusterName = types.StringNull()
+
+	if name, ok := model.Totem["cluster_name"]; ok {
+		model.ClusterName = name
+	}
+
+	model.Fingerprint = types.StringNull()
+	model.PeerAddress = types.StringNull()
+	model.Nodes = make([]joinInfoNodeModel, 0, len(info.NodeList))
+
+	for _, node := range info.NodeList {
+		if node.Name == info.PreferredNode {
+			model.Fingerprint = types.StringPointerValue(node.PVEFingerprint)
+			model.PeerAddress = types.StringPointerValue(node.PVEAddress)
+		}
+
+		m := joinInfoNodeModel{
+			Address:     types.StringPointerValue(node.PVEAddress),
+			Fingerprint: types.StringPointerValue(node.PVEFingerprint),
+			Name:        types.StringValue(node.Name),
+			NodeID:      types.Int64Null(),
+			QuorumVotes: types.Int64Null(),
+			Ring0Addr:   types.StringPointerValue(node.Ring0Address),
+		}
+
+		if node.NodeID != nil {
+			m.NodeID = types.Int64Value(int64(*node.NodeID))
+		}
+
+		if node.QuorumVotes != nil {
+			m.QuorumVotes = types.Int64Value(int64(*node.QuorumVotes))
+		}
+
+		model.Nodes = append(model.Nodes, m)
+	}
+
+	model.ID = types.StringValue(info.PreferredNode)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+// totemValues converts the totem configuration to strings, keeping the nested values, like the
+// interface sections, as JSON.
+func totemValues(totem map[string]json.RawMessage) map[string]types.String {
+	values := make(map[string]types.String, len(totem))
+
+	for k, raw := range totem {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			values[k] = types.StringValue(s)
+		} else {
+			values[k] = types.StringValue(string(raw))
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,476 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package join
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	clusterconfig "github.com/bpg/terraform-provider-proxmox/proxmox/cluster/config"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 10 * time.Minute
+
+	// maxLinks is the number of corosync links supported by PVE.
+	maxLinks = 8
+)
+
+var (
+	_ resource.Resource              = &nodeJoinResource{}
+	_ resource.ResourceWithConfigure = &nodeJoinResource{}
+)
+
+type nodeJoinModel struct {
+	ClusterName    types.String        `tfsdk:"cluster_name"`
+	Fingerprint    types.String        `tfsdk:"fingerprint"`
+	Force          types.Bool          `tfsdk:"force"`
+	ID             types.String        `tfsdk:"id"`
+	LeaveOnDestroy types.Bool          `tfsdk:"leave_on_destroy"`
+	Links          []nodeJoinLinkModel `tfsdk:"links"`
+	NodeID         types.Int64         `tfsdk:"node_id"`
+	NodeName       types.String        `tfsdk:"node_name"`
+	Password       types.String        `tfsdk:"password"`
+	PeerAddress    types.String        `tfsdk:"peer_address"`
+	Timeouts       timeouts.Value      `tfsdk:"timeouts"`
+	Votes          types.Int64         `tfsdk:"votes"`
+}
+
+type nodeJoinLinkModel struct {
+	Address  types.String `tfsdk:"address"`
+	Priority types.Int64  `tfsdk:"priority"`
+}
+
+// NewNodeJoinResource creates a new resource for joining a node to a cluster.
+func NewNodeJoinResource() resource.Resource {
+	return &nodeJoinResource{}
+}
+
+type nodeJoinResource struct {
+	client proxmox.Client
+}
+
+func (r *nodeJoinResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_node_join"
+}
+
+func (r *nodeJoinResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Joins a node to an existing cluster.",
+		MarkdownDescription: "Joins a node to an existing cluster.\n\n" +
+			"The resource must be managed by a provider configured for the joining node, usually a provider alias, " +
+			"using the `root@pam` user with a password, as the API tokens of the node are replaced by the ones of " +
+			"the cluster. The information about the cluster can be retrieved with the " +
+			"`proxmox_virtual_environment_cluster_join_info` data source of the provider of an existing " +
+			"cluster node.\n\n" +
+			"A node that is already a member of a cluster is not joined again. Destroying the resource keeps the node " +
+			"in the cluster, unless `leave_on_destroy` is set.",
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Description: "The name of the cluster the node is a member of.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "The SSL certificate fingerprint of the peer node.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"force": schema.BoolAttribute{
+				Description: "Whether to join the node even if it seems to be a member of a cluster already.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": attribute.ResourceID(),
+			"leave_on_destroy": schema.BoolAttribute{
+				Description: "Whether to remove the node from the cluster when the resource is destroyed.",
+				MarkdownDescription: "Whether to remove the node from the cluster when the resource is destroyed. " +
+					"The node is removed from the cluster configuration through the API of the peer node, using the " +
+					"`password` and `fingerprint`. As with `pvecm delnode`, the node must not be powered on again " +
+					"with its current configuration afterwards. Defaults to `false`, so the node stays in the cluster.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"links": schema.ListNestedAttribute{
+				Description: "The corosync links of the node, in the order of the link numbers.",
+				MarkdownDescription: "The corosync links of the node, in the order of the link numbers. They must " +
+					"match the links of the cluster. Defaults to the address the node's hostname resolves to.",
+				Optional: true,
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, maxLinks),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							Description: "The address of the node on the link.",
+							Required:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "The priority of the link, when the links are used in passive mode.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(0, 255),
+							},
+						},
+					},
+				},
+			},
+			"node_id": schema.Int64Attribute{
+				Description: "The corosync node identifier of the node. Defaults to the next free identifier.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"node_name": schema.StringAttribute{
+				Description: "The name of the joined node.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "The password of the `root@pam` user of the peer node.",
+				Required:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"peer_address": schema.StringAttribute{
+				Description: "The address or hostname of an existing cluster node to join through.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+			"votes": schema.Int64Attribute{
+				Description: "The number of quorum votes of the node. Defaults to `1`.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *nodeJoinResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+func (r *nodeJoinResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan nodeJoinModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, d := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(d...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	membership, err := r.membership(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read the cluster status of the node", err.Error())
+		return
+	}
+
+	if membership.clusterName != "" && !plan.Force.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"Node is already a member of a cluster",
+			fmt.Sprintf("The node %q is already a member of the cluster %q, it was not joined again.",
+				membership.nodeName, membership.clusterName,
+			),
+		)
+	} else {
+		err = r.client.Cluster().Config().Join(ctx, plan.joinRequestBody())
+		if err != nil {
+			// the API of the node is restarted with the cluster's configuration and certificates while joining,
+			// so the task status may not be readable even if the join succeeded
+			tflog.Warn(ctx, "Unable to confirm the result of the join task, checking the cluster status", map[string]any{
+				"error": err.Error(),
+			})
+
+			membership, err = r.waitForMembership(ctx, err)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to join the cluster", err.Error())
+				return
+			}
+		} else {
+			membership, err = r.membership(ctx)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to read the cluster status of the node", err.Error())
+				return
+			}
+		}
+	}
+
+	plan.ClusterName = types.StringValue(membership.clusterName)
+	plan.NodeName = types.StringValue(membership.nodeName)
+	plan.ID = types.StringValue(membership.nodeName)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *nodeJoinResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state nodeJoinModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	membership, err := r.membership(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read the cluster status of the node", err.Error())
+		return
+	}
+
+	if membership.clusterName == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ClusterName = types.StringValue(membership.clusterName)
+	state.NodeName = types.StringValue(membership.nodeName)
+	state.ID = types.StringValue(membership.nodeName)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *nodeJoinResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state nodeJoinModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only `leave_on_destroy` and the timeouts can be updated in place, they are not sent to the API
+	plan.ClusterName = state.ClusterName
+	plan.NodeName = state.NodeName
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *nodeJoinResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state nodeJoinModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.LeaveOnDestroy.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"Node was not removed from the cluster",
+			fmt.Sprintf("The node %q is still a member of the cluster %q, it was only removed from the Terraform "+
+				"state. Set `leave_on_destroy` to remove the node from the cluster when the resource is destroyed.",
+				state.NodeName.ValueString(), state.ClusterName.ValueString(),
+			),
+		)
+
+		return
+	}
+
+	timeout, d := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(d...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// a node can't remove itself from the cluster, so the removal is done by the peer node
+	peer, err := state.peerClient()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to connect to the peer node", err.Error())
+		return
+	}
+
+	err = peer.Config().DeleteNode(ctx, state.NodeName.ValueString())
+	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError("Unable to remove the node from the cluster", err.Error())
+	}
+}
+
+type nodeMembership struct {
+	clusterName string
+	nodeName    string
+}
+
+// membership returns the cluster the node serving the API requests is a member of. The cluster name is empty
+// for a standalone node.
+func (r *nodeJoinResource) membership(ctx context.Context) (*nodeMembership, error) {
+	status, err := r.client.Cluster().GetClusterStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newNodeMembership(status), nil
+}
+
+// waitForMembership polls the cluster status until the node is a member of a cluster, or the context expires.
+func (r *nodeJoinResource) waitForMembership(ctx context.Context, joinErr error) (*nodeMembership, error) {
+	for {
+		membership, err := r.membership(ctx)
+		if err == nil && membership.clusterName != "" {
+			return membership, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("the node did not become a member of the cluster: %w", joinErr)
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func newNodeMembership(status []*cluster.StatusResponseData) *nodeMembership {
+	m := &nodeMembership{}
+
+	for _, s := range status {
+		switch s.Type {
+		case "cluster":
+			m.clusterName = s.Name
+		case "node":
+			if s.Local != nil && bool(*s.Local) {
+				m.nodeName = s.Name
+			}
+		}
+	}
+
+	return m
+}
+
+func (m *nodeJoinModel) joinRequestBody() *clusterconfig.JoinRequestBody {
+	body := &clusterconfig.JoinRequestBody{
+		Fingerprint: m.Fingerprint.ValueString(),
+		Hostname:    m.PeerAddress.ValueString(),
+		NodeID:      m.NodeID.ValueInt64Pointer(),
+		Password:    m.Password.ValueString(),
+		Votes:       m.Votes.ValueInt64Pointer(),
+	}
+
+	if m.Force.ValueBool() {
+		body.Force = proxmoxtypes.CustomBool(true).Pointer()
+	}
+
+	for _, link := range m.Links {
+		value := "address=" + link.Address.ValueString()
+
+		if !link.Priority.IsNull() {
+			value += fmt.Sprintf(",priority=%d", link.Priority.ValueInt64())
+		}
+
+		body.Links = append(body.Links, value)
+	}
+
+	return body
+}
+
+// peerClient returns a client for the API of the peer node, authenticated as `root@pam`.
+func (m *nodeJoinModel) peerClient() (*cluster.Client, error) {
+	host := m.PeerAddress.ValueString()
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), "8006")
+	}
+
+	conn, err := api.NewPinnedConnection("https://"+host, m.Fingerprint.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := api.NewCredentials("root@pam", m.Password.ValueString(), "", "", "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := api.NewClient(creds, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cluster.Client{Client: client}, nil
+}
@@ -154,7 +154,8 @@ func (r *metricsServerResource) Schema(
 			},
 			"influx_token": schema.StringAttribute{
 				Description: "The InfluxDB access token. Only necessary when using the http v2 " +
-					"api. If the v2 compatibility api is used, use `user:password` instead.",
+					"api. If the v2 compatibility api is used, use `user:password` instead. " +
+					"The token is write-only, so changes made outside of Terraform are not detected.",
 				Optional:  true,
 				Default:   nil,
 				Sensitive: true,
@@ -215,6 +216,9 @@ func (r *metricsServerResource) Read(
 	readModel := &metricsServerModel{}
 	readModel.importFromAPI(state.ID.ValueString(), data)
 
+	// the token is write-only, PVE stores it separately and never returns it
+	readModel.InfluxToken = state.InfluxToken
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
 }
 
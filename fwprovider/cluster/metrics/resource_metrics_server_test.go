@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
 )
@@ -125,6 +126,52 @@ func TestAccResourceMetricsServer(t *testing.T) {
 				),
 			},
 		}},
+		{"create influxdb http server with token & import it", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_metrics_server" "acc_influxdb_http_server" {
+					name                = "acc_example_influxdb_http_server"
+					server              = "192.168.3.2"
+					port                = 8086
+					type                = "influxdb"
+					influx_db_proto     = "http"
+					influx_bucket       = "proxmox"
+					influx_organization = "example"
+					influx_token        = "secret-token"
+				  }`),
+				Check: test.ResourceAttributes("proxmox_virtual_environment_metrics_server.acc_influxdb_http_server", map[string]string{
+					"influx_db_proto":     "http",
+					"influx_bucket":       "proxmox",
+					"influx_organization": "example",
+					"influx_token":        "secret-token",
+				}),
+			},
+			{
+				// the token is not returned by the API, re-applying the same config must not produce a diff
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_metrics_server" "acc_influxdb_http_server" {
+					name                = "acc_example_influxdb_http_server"
+					server              = "192.168.3.2"
+					port                = 8086
+					type                = "influxdb"
+					influx_db_proto     = "http"
+					influx_bucket       = "proxmox"
+					influx_organization = "example"
+					influx_token        = "secret-token"
+				  }`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				ResourceName:            "proxmox_virtual_environment_metrics_server.acc_influxdb_http_server",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"influx_token"},
+			},
+		}},
 		{"create graphite udp metrics server & import it", []resource.TestStep{
 			{
 				ResourceName: "proxmox_virtual_environment_metrics_server.acc_graphite_server",
@@ -0,0 +1,276 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/notifications"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// nameRegex matches the safe ID format used by PVE for notification endpoint and matcher names.
+var nameRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*$`)
+
+type endpointModel interface {
+	base() *endpointBaseModel
+	importFromAPI(data *notifications.EndpointData, diags *diag.Diagnostics)
+	toAPIRequestBody(ctx context.Context, diags *diag.Diagnostics) *notifications.EndpointRequestData
+	// toDelete returns the type specific API attributes that are set in the state but removed from the plan.
+	toDelete(ctx context.Context, state endpointModel, diags *diag.Diagnostics) []string
+	// keepWriteOnly copies the secrets, which are never returned by the API, from the state.
+	keepWriteOnly(state endpointModel)
+}
+
+type endpointBaseModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Comment types.String `tfsdk:"comment"`
+	Disable types.Bool   `tfsdk:"disable"`
+}
+
+func (m *endpointBaseModel) base() *endpointBaseModel {
+	return m
+}
+
+func (m *endpointBaseModel) importFromAPI(data *notifications.EndpointData) {
+	m.ID = types.StringValue(data.Name)
+	m.Name = types.StringValue(data.Name)
+	m.Comment = types.StringPointerValue(data.Comment)
+	m.Disable = types.BoolPointerValue(data.Disable.PointerBool())
+}
+
+func (m *endpointBaseModel) toAPIRequestBody() *notifications.EndpointRequestData {
+	data := &notifications.EndpointRequestData{}
+
+	data.Name = m.Name.ValueString()
+	data.Comment = m.Comment.ValueStringPointer()
+
+	if !m.Disable.IsNull() && !m.Disable.IsUnknown() {
+		data.Disable = proxmoxtypes.CustomBool(m.Disable.ValueBool()).Pointer()
+	}
+
+	return data
+}
+
+func (m *endpointBaseModel) toDelete(state *endpointBaseModel) []string {
+	var toDelete []string
+
+	checkDelete(m.Comment, state.Comment, &toDelete, "comment")
+	checkDelete(m.Disable, state.Disable, &toDelete, "disable")
+
+	return toDelete
+}
+
+func checkDelete(planField, stateField attr.Value, toDelete *[]string, apiName string) {
+	// the attribute must be removed via the API if it is set in the state,
+	// but has been removed from the resource to use the PVE default
+	if planField.IsNull() && !stateField.IsNull() {
+		*toDelete = append(*toDelete, apiName)
+	}
+}
+
+func endpointAttributesWith(kind string, extraAttributes map[string]schema.Attribute) map[string]schema.Attribute {
+	result := map[string]schema.Attribute{
+		"id": attribute.ResourceID(),
+		"name": schema.StringAttribute{
+			Description: fmt.Sprintf("The name of the %s notification endpoint.", kind),
+			Required:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(nameRegex, "must be a valid notification endpoint name"),
+			},
+		},
+		"comment": schema.StringAttribute{
+			Description: "The comment of the notification endpoint.",
+			Optional:    true,
+		},
+		"disable": schema.BoolAttribute{
+			Description: "Set to `true` to disable the notification endpoint.",
+			Optional:    true,
+		},
+	}
+
+	maps.Copy(result, extraAttributes)
+
+	return result
+}
+
+type endpointResourceConfig struct {
+	typeNameSuffix string
+	endpointType   string
+	modelFunc      func() endpointModel
+}
+
+type genericEndpointResource struct {
+	client *notifications.Client
+	config endpointResourceConfig
+}
+
+func newGenericEndpointResource(cfg endpointResourceConfig) *genericEndpointResource {
+	return &genericEndpointResource{config: cfg}
+}
+
+func (r *genericEndpointResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + r.config.typeNameSuffix
+}
+
+func (r *genericEndpointResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster().Notifications()
+}
+
+func (r *genericEndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	plan := r.config.modelFunc()
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := plan.toAPIRequestBody(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.CreateEndpoint(ctx, r.config.endpointType, reqData); err != nil {
+		resp.Diagnostics.AddError("Unable to Create Notification Endpoint", err.Error())
+
+		return
+	}
+
+	plan.base().ID = plan.base().Name
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *genericEndpointResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state := r.config.modelFunc()
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, err := r.client.GetEndpoint(ctx, r.config.endpointType, state.base().ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Unable to Read Notification Endpoint", err.Error())
+
+		return
+	}
+
+	readModel := r.config.modelFunc()
+	readModel.importFromAPI(data, &resp.Diagnostics)
+	readModel.keepWriteOnly(state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+}
+
+func (r *genericEndpointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := r.config.modelFunc()
+	state := r.config.modelFunc()
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := plan.toAPIRequestBody(ctx, &resp.Diagnostics)
+	reqData.Delete = append(plan.base().toDelete(state.base()), plan.toDelete(ctx, state, &resp.Diagnostics)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.UpdateEndpoint(ctx, r.config.endpointType, reqData); err != nil {
+		resp.Diagnostics.AddError("Unable to Update Notification Endpoint", err.Error())
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *genericEndpointResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state := r.config.modelFunc()
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteEndpoint(ctx, r.config.endpointType, state.base().ID.ValueString()); err != nil &&
+		!errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError("Unable to Delete Notification Endpoint", err.Error())
+	}
+}
+
+func (r *genericEndpointResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// listPointer returns a pointer to the list, or nil if the list is empty.
+func listPointer(list []string) *[]string {
+	if len(list) == 0 {
+		return nil
+	}
+
+	return &list
+}
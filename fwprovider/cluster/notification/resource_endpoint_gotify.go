@@ -0,0 +1,91 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notification
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/notifications"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &GotifyEndpointResource{}
+	_ resource.ResourceWithImportState = &GotifyEndpointResource{}
+)
+
+type gotifyEndpointModel struct {
+	endpointBaseModel
+
+	Server types.String `tfsdk:"server"`
+	Token  types.String `tfsdk:"token"`
+}
+
+func (m *gotifyEndpointModel) importFromAPI(data *notifications.EndpointData, _ *diag.Diagnostics) {
+	m.endpointBaseModel.importFromAPI(data)
+
+	m.Server = types.StringPointerValue(data.Server)
+}
+
+func (m *gotifyEndpointModel) toAPIRequestBody(
+	_ context.Context,
+	_ *diag.Diagnostics,
+) *notifications.EndpointRequestData {
+	data := m.endpointBaseModel.toAPIRequestBody()
+
+	data.Server = m.Server.ValueStringPointer()
+	data.Token = m.Token.ValueStringPointer()
+
+	return data
+}
+
+func (m *gotifyEndpointModel) toDelete(_ context.Context, _ endpointModel, _ *diag.Diagnostics) []string {
+	return nil
+}
+
+func (m *gotifyEndpointModel) keepWriteOnly(state endpointModel) {
+	m.Token = state.(*gotifyEndpointModel).Token
+}
+
+// GotifyEndpointResource manages Gotify notification endpoints.
+type GotifyEndpointResource struct {
+	*genericEndpointResource
+}
+
+// NewGotifyEndpointResource creates a new Gotify notification endpoint resource.
+func NewGotifyEndpointResource() resource.Resource {
+	return &GotifyEndpointResource{
+		genericEndpointResource: newGenericEndpointResource(endpointResourceConfig{
+			typeNameSuffix: "_notification_endpoint_gotify",
+			endpointType:   notifications.EndpointTypeGotify,
+			modelFunc:      func() endpointModel { return &gotifyEndpointModel{} },
+		}),
+	}
+}
+
+// Schema defines the schema for the Gotify notification endpoint resource.
+func (r *GotifyEndpointResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Gotify notification endpoint.",
+		Attributes: endpointAttributesWith("Gotify", map[string]schema.Attribute{
+			"server": schema.StringAttribute{
+				Description: "The URL of the Gotify server.",
+				Required:    true,
+			},
+			"token": schema.StringAttribute{
+				Description: "The application token of the Gotify server. " +
+					"The token is write-only, so changes made outside of Terraform are not detected.",
+				Required:  true,
+				Sensitive: true,
+			},
+		}),
+	}
+}
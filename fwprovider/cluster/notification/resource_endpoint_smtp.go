@@ -0,0 +1,167 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notification
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/types/stringset"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/notifications"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &SMTPEndpointResource{}
+	_ resource.ResourceWithImportState = &SMTPEndpointResource{}
+)
+
+type smtpEndpointModel struct {
+	endpointBaseModel
+
+	Server      types.String    `tfsdk:"server"`
+	Port        types.Int64     `tfsdk:"port"`
+	Mode        types.String    `tfsdk:"mode"`
+	Username    types.String    `tfsdk:"username"`
+	Password    types.String    `tfsdk:"password"`
+	FromAddress types.String    `tfsdk:"from_address"`
+	Author      types.String    `tfsdk:"author"`
+	MailTo      stringset.Value `tfsdk:"mailto"`
+	MailToUser  stringset.Value `tfsdk:"mailto_user"`
+}
+
+func (m *smtpEndpointModel) importFromAPI(data *notifications.EndpointData, diags *diag.Diagnostics) {
+	m.endpointBaseModel.importFromAPI(data)
+
+	m.Server = types.StringPointerValue(data.Server)
+	m.Port = types.Int64PointerValue(data.Port)
+	m.Mode = types.StringPointerValue(data.Mode)
+	m.Username = types.StringPointerValue(data.Username)
+	m.FromAddress = types.StringPointerValue(data.FromAddress)
+	m.Author = types.StringPointerValue(data.Author)
+	m.MailTo = stringset.NewValueList(ptr.Or(data.MailTo, nil), diags)
+	m.MailToUser = stringset.NewValueList(ptr.Or(data.MailToUser, nil), diags)
+}
+
+func (m *smtpEndpointModel) toAPIRequestBody(
+	ctx context.Context,
+	diags *diag.Diagnostics,
+) *notifications.EndpointRequestData {
+	data := m.endpointBaseModel.toAPIRequestBody()
+
+	data.Server = m.Server.ValueStringPointer()
+	data.Port = m.Port.ValueInt64Pointer()
+	data.Mode = m.Mode.ValueStringPointer()
+	data.Username = m.Username.ValueStringPointer()
+	data.Password = m.Password.ValueStringPointer()
+	data.FromAddress = m.FromAddress.ValueStringPointer()
+	data.Author = m.Author.ValueStringPointer()
+	data.MailTo = listPointer(m.MailTo.ValueList(ctx, diags))
+	data.MailToUser = listPointer(m.MailToUser.ValueList(ctx, diags))
+
+	return data
+}
+
+func (m *smtpEndpointModel) toDelete(
+	ctx context.Context,
+	state endpointModel,
+	diags *diag.Diagnostics,
+) []string {
+	s := state.(*smtpEndpointModel)
+
+	var toDelete []string
+
+	checkDelete(m.Port, s.Port, &toDelete, "port")
+	checkDelete(m.Mode, s.Mode, &toDelete, "mode")
+	checkDelete(m.Username, s.Username, &toDelete, "username")
+	checkDelete(m.Password, s.Password, &toDelete, "password")
+	checkDelete(m.Author, s.Author, &toDelete, "author")
+
+	if len(m.MailTo.ValueList(ctx, diags)) == 0 && len(s.MailTo.ValueList(ctx, diags)) > 0 {
+		toDelete = append(toDelete, "mailto")
+	}
+
+	if len(m.MailToUser.ValueList(ctx, diags)) == 0 && len(s.MailToUser.ValueList(ctx, diags)) > 0 {
+		toDelete = append(toDelete, "mailto-user")
+	}
+
+	return toDelete
+}
+
+func (m *smtpEndpointModel) keepWriteOnly(state endpointModel) {
+	m.Password = state.(*smtpEndpointModel).Password
+}
+
+// SMTPEndpointResource manages SMTP notification endpoints.
+type SMTPEndpointResource struct {
+	*genericEndpointResource
+}
+
+// NewSMTPEndpointResource creates a new SMTP notification endpoint resource.
+func NewSMTPEndpointResource() resource.Resource {
+	return &SMTPEndpointResource{
+		genericEndpointResource: newGenericEndpointResource(endpointResourceConfig{
+			typeNameSuffix: "_notification_endpoint_smtp",
+			endpointType:   notifications.EndpointTypeSMTP,
+			modelFunc:      func() endpointModel { return &smtpEndpointModel{} },
+		}),
+	}
+}
+
+// Schema defines the schema for the SMTP notification endpoint resource.
+func (r *SMTPEndpointResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an SMTP notification endpoint.",
+		Attributes: endpointAttributesWith("SMTP", map[string]schema.Attribute{
+			"server": schema.StringAttribute{
+				Description: "The address of the SMTP relay.",
+				Required:    true,
+			},
+			"port": schema.Int64Attribute{
+				Description: "The port of the SMTP relay. If not set, PVE uses the default port of the mode.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.Between(1, 65535)},
+			},
+			"mode": schema.StringAttribute{
+				Description: "The encryption mode, one of `insecure`, `starttls` or `tls`. " +
+					"If not set, PVE default is `tls`.",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf("insecure", "starttls", "tls")},
+			},
+			"username": schema.StringAttribute{
+				Description: "The username for the SMTP authentication.",
+				Optional:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "The password for the SMTP authentication. " +
+					"The password is write-only, so changes made outside of Terraform are not detected.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"from_address": schema.StringAttribute{
+				Description: "The `From` address of the emails.",
+				Required:    true,
+			},
+			"author": schema.StringAttribute{
+				Description: "The author of the emails. If not set, PVE default is `Proxmox VE`.",
+				Optional:    true,
+			},
+			"mailto": stringset.ResourceAttribute("The email addresses to send the notifications to.", ""),
+			"mailto_user": stringset.ResourceAttribute(
+				"The users to send the notifications to, the email address is taken from the user configuration.",
+				"",
+			),
+		}),
+	}
+}
@@ -0,0 +1,215 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notification
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/notifications"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &WebhookEndpointResource{}
+	_ resource.ResourceWithImportState = &WebhookEndpointResource{}
+)
+
+type webhookEndpointModel struct {
+	endpointBaseModel
+
+	URL    types.String `tfsdk:"url"`
+	Method types.String `tfsdk:"method"`
+	Body   types.String `tfsdk:"body"`
+	Header types.Map    `tfsdk:"header"`
+	Secret types.Map    `tfsdk:"secret"`
+}
+
+func (m *webhookEndpointModel) importFromAPI(data *notifications.EndpointData, diags *diag.Diagnostics) {
+	m.endpointBaseModel.importFromAPI(data)
+
+	m.URL = types.StringPointerValue(data.URL)
+	m.Method = types.StringPointerValue(data.Method)
+	m.Body = types.StringNull()
+
+	if data.Body != nil {
+		body, err := base64.StdEncoding.DecodeString(*data.Body)
+		if err != nil {
+			diags.AddError("Unable to decode the webhook body", err.Error())
+		}
+
+		m.Body = types.StringValue(string(body))
+	}
+
+	m.Header = types.MapNull(types.StringType)
+
+	if data.Header != nil && len(*data.Header) > 0 {
+		headers := map[string]attr.Value{}
+
+		for _, h := range *data.Header {
+			name, value, err := parseKeyAndBase64Value(h)
+			if err != nil {
+				diags.AddError("Unable to parse the webhook header", err.Error())
+
+				continue
+			}
+
+			headers[name] = types.StringValue(value)
+		}
+
+		var d diag.Diagnostics
+
+		m.Header, d = types.MapValue(types.StringType, headers)
+		diags.Append(d...)
+	}
+}
+
+func (m *webhookEndpointModel) toAPIRequestBody(
+	ctx context.Context,
+	diags *diag.Diagnostics,
+) *notifications.EndpointRequestData {
+	data := m.endpointBaseModel.toAPIRequestBody()
+
+	data.URL = m.URL.ValueStringPointer()
+	data.Method = m.Method.ValueStringPointer()
+
+	if !m.Body.IsNull() && !m.Body.IsUnknown() {
+		body := base64.StdEncoding.EncodeToString([]byte(m.Body.ValueString()))
+		data.Body = &body
+	}
+
+	data.Header = keyAndBase64Values(ctx, m.Header, diags)
+	data.Secret = keyAndBase64Values(ctx, m.Secret, diags)
+
+	return data
+}
+
+func (m *webhookEndpointModel) toDelete(_ context.Context, state endpointModel, _ *diag.Diagnostics) []string {
+	s := state.(*webhookEndpointModel)
+
+	var toDelete []string
+
+	checkDelete(m.Body, s.Body, &toDelete, "body")
+	checkDelete(m.Header, s.Header, &toDelete, "header")
+	checkDelete(m.Secret, s.Secret, &toDelete, "secret")
+
+	return toDelete
+}
+
+func (m *webhookEndpointModel) keepWriteOnly(state endpointModel) {
+	m.Secret = state.(*webhookEndpointModel).Secret
+}
+
+// parseKeyAndBase64Value parses the `name=<name>,value=<base64 value>` format used by PVE for headers and secrets.
+func parseKeyAndBase64Value(s string) (string, string, error) {
+	var name, value string
+
+	for _, part := range strings.Split(s, ",") {
+		k, v, _ := strings.Cut(part, "=")
+
+		switch k {
+		case "name":
+			name = v
+		case "value":
+			decoded, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return "", "", fmt.Errorf("failed to decode the value of %q: %w", s, err)
+			}
+
+			value = string(decoded)
+		}
+	}
+
+	if name == "" {
+		return "", "", fmt.Errorf("missing name in %q", s)
+	}
+
+	return name, value, nil
+}
+
+// keyAndBase64Values converts a map to the `name=<name>,value=<base64 value>` format used by PVE.
+func keyAndBase64Values(ctx context.Context, m types.Map, diags *diag.Diagnostics) *[]string {
+	if m.IsNull() || m.IsUnknown() {
+		return nil
+	}
+
+	values := map[string]string{}
+	diags.Append(m.ElementsAs(ctx, &values, false)...)
+
+	result := make([]string, 0, len(values))
+
+	for name, value := range values {
+		result = append(result, fmt.Sprintf("name=%s,value=%s", name, base64.StdEncoding.EncodeToString([]byte(value))))
+	}
+
+	sort.Strings(result)
+
+	return listPointer(result)
+}
+
+// WebhookEndpointResource manages webhook notification endpoints.
+type WebhookEndpointResource struct {
+	*genericEndpointResource
+}
+
+// NewWebhookEndpointResource creates a new webhook notification endpoint resource.
+func NewWebhookEndpointResource() resource.Resource {
+	return &WebhookEndpointResource{
+		genericEndpointResource: newGenericEndpointResource(endpointResourceConfig{
+			typeNameSuffix: "_notification_endpoint_webhook",
+			endpointType:   notifications.EndpointTypeWebhook,
+			modelFunc:      func() endpointModel { return &webhookEndpointModel{} },
+		}),
+	}
+}
+
+// Schema defines the schema for the webhook notification endpoint resource.
+func (r *WebhookEndpointResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a webhook notification endpoint. Requires PVE 8.3 or later.",
+		MarkdownDescription: "Manages a webhook notification endpoint. Requires PVE 8.3 or later.\n\n" +
+			"The URL, the headers and the body can use the PVE notification templating, e.g. `{{ title }}`, " +
+			"and reference the secrets as `{{ secrets.<name> }}`.",
+		Attributes: endpointAttributesWith("webhook", map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Description: "The URL of the webhook.",
+				Required:    true,
+			},
+			"method": schema.StringAttribute{
+				Description: "The HTTP method of the webhook, one of `post`, `put` or `get`.",
+				Required:    true,
+				Validators:  []validator.String{stringvalidator.OneOf("post", "put", "get")},
+			},
+			"body": schema.StringAttribute{
+				Description: "The body of the webhook requests.",
+				Optional:    true,
+			},
+			"header": schema.MapAttribute{
+				Description: "The HTTP headers of the webhook requests.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"secret": schema.MapAttribute{
+				Description: "The secrets which can be referenced in the URL, the headers and the body. " +
+					"The secrets are write-only, so changes made outside of Terraform are not detected.",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+		}),
+	}
+}
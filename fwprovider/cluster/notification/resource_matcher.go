@@ -0,0 +1,378 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/types/stringset"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/notifications"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &MatcherResource{}
+	_ resource.ResourceWithImportState = &MatcherResource{}
+)
+
+var matchFieldRegex = regexp.MustCompile(`^(exact|regex):[^=]+=.*$`)
+
+type matcherModel struct {
+	ID            types.String    `tfsdk:"id"`
+	Name          types.String    `tfsdk:"name"`
+	Comment       types.String    `tfsdk:"comment"`
+	Disable       types.Bool      `tfsdk:"disable"`
+	InvertMatch   types.Bool      `tfsdk:"invert_match"`
+	MatchCalendar types.List      `tfsdk:"match_calendar"`
+	MatchField    types.List      `tfsdk:"match_field"`
+	MatchSeverity stringset.Value `tfsdk:"match_severity"`
+	Mode          types.String    `tfsdk:"mode"`
+	Target        stringset.Value `tfsdk:"target"`
+}
+
+func (m *matcherModel) importFromAPI(data *notifications.MatcherData, diags *diag.Diagnostics) {
+	m.ID = types.StringValue(data.Name)
+	m.Name = types.StringValue(data.Name)
+	m.Comment = types.StringPointerValue(data.Comment)
+	m.Disable = types.BoolPointerValue(data.Disable.PointerBool())
+	m.InvertMatch = types.BoolPointerValue(data.InvertMatch.PointerBool())
+	m.MatchCalendar = listValue(data.MatchCalendar, diags)
+	m.MatchField = listValue(data.MatchField, diags)
+	m.MatchSeverity = stringset.NewValueList(splitSeverities(ptr.Or(data.MatchSeverity, nil)), diags)
+	m.Mode = types.StringPointerValue(data.Mode)
+	m.Target = stringset.NewValueList(ptr.Or(data.Target, nil), diags)
+}
+
+func (m *matcherModel) toAPIRequestBody(ctx context.Context, diags *diag.Diagnostics) *notifications.MatcherRequestData {
+	data := &notifications.MatcherRequestData{}
+
+	data.Name = m.Name.ValueString()
+	data.Comment = m.Comment.ValueStringPointer()
+
+	if !m.Disable.IsNull() && !m.Disable.IsUnknown() {
+		data.Disable = proxmoxtypes.CustomBool(m.Disable.ValueBool()).Pointer()
+	}
+
+	if !m.InvertMatch.IsNull() && !m.InvertMatch.IsUnknown() {
+		data.InvertMatch = proxmoxtypes.CustomBool(m.InvertMatch.ValueBool()).Pointer()
+	}
+
+	data.MatchCalendar = listPointer(listStrings(ctx, m.MatchCalendar, diags))
+	data.MatchField = listPointer(listStrings(ctx, m.MatchField, diags))
+	data.MatchSeverity = listPointer(m.MatchSeverity.ValueList(ctx, diags))
+	data.Mode = m.Mode.ValueStringPointer()
+	data.Target = listPointer(m.Target.ValueList(ctx, diags))
+
+	return data
+}
+
+func (m *matcherModel) toDelete(ctx context.Context, state *matcherModel, diags *diag.Diagnostics) []string {
+	var toDelete []string
+
+	checkDelete(m.Comment, state.Comment, &toDelete, "comment")
+	checkDelete(m.Disable, state.Disable, &toDelete, "disable")
+	checkDelete(m.InvertMatch, state.InvertMatch, &toDelete, "invert-match")
+	checkDelete(m.MatchCalendar, state.MatchCalendar, &toDelete, "match-calendar")
+	checkDelete(m.MatchField, state.MatchField, &toDelete, "match-field")
+	checkDelete(m.Mode, state.Mode, &toDelete, "mode")
+
+	if len(m.MatchSeverity.ValueList(ctx, diags)) == 0 && len(state.MatchSeverity.ValueList(ctx, diags)) > 0 {
+		toDelete = append(toDelete, "match-severity")
+	}
+
+	if len(m.Target.ValueList(ctx, diags)) == 0 && len(state.Target.ValueList(ctx, diags)) > 0 {
+		toDelete = append(toDelete, "target")
+	}
+
+	return toDelete
+}
+
+// splitSeverities flattens the severities, as PVE also accepts and stores them as comma-separated lists.
+func splitSeverities(severities []string) []string {
+	var result []string
+
+	for _, s := range severities {
+		result = append(result, strings.Split(s, ",")...)
+	}
+
+	return result
+}
+
+func listValue(items *[]string, diags *diag.Diagnostics) types.List {
+	if items == nil || len(*items) == 0 {
+		return types.ListNull(types.StringType)
+	}
+
+	elems := make([]attr.Value, len(*items))
+	for i, item := range *items {
+		elems[i] = types.StringValue(item)
+	}
+
+	list, d := types.ListValue(types.StringType, elems)
+	diags.Append(d...)
+
+	return list
+}
+
+func listStrings(ctx context.Context, list types.List, diags *diag.Diagnostics) []string {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	var items []string
+
+	diags.Append(list.ElementsAs(ctx, &items, false)...)
+
+	return items
+}
+
+// MatcherResource manages notification matchers.
+type MatcherResource struct {
+	client *notifications.Client
+}
+
+// NewMatcherResource creates a new notification matcher resource.
+func NewMatcherResource() resource.Resource {
+	return &MatcherResource{}
+}
+
+// Metadata defines the name of the resource.
+func (r *MatcherResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_matcher"
+}
+
+// Configure sets the client for the resource.
+func (r *MatcherResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster().Notifications()
+}
+
+// Schema defines the schema for the resource.
+func (r *MatcherResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a notification matcher, which routes notifications to notification targets.",
+		MarkdownDescription: "Manages a notification matcher, which routes notifications to notification targets.\n\n" +
+			"The targets are referenced by name, so the built-in `mail-to-root` target can be used " +
+			"without being managed by Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ResourceID(),
+			"name": schema.StringAttribute{
+				Description: "The name of the notification matcher.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(nameRegex, "must be a valid notification matcher name"),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Description: "The comment of the notification matcher.",
+				Optional:    true,
+			},
+			"disable": schema.BoolAttribute{
+				Description: "Set to `true` to disable the notification matcher.",
+				Optional:    true,
+			},
+			"invert_match": schema.BoolAttribute{
+				Description: "Set to `true` to invert the result of the match.",
+				Optional:    true,
+			},
+			"match_calendar": schema.ListAttribute{
+				Description: "The calendar events to match the notification timestamp against, " +
+					"e.g. `mon-fri 8-17`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+			},
+			"match_field": schema.ListAttribute{
+				Description: "The metadata fields to match, in the `exact:<field>=<value>` or " +
+					"`regex:<field>=<regex>` format, e.g. `exact:type=vzdump`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(matchFieldRegex, "must be in the `exact:<field>=<value>` "+
+							"or `regex:<field>=<regex>` format"),
+					),
+				},
+			},
+			"match_severity": func() schema.SetAttribute {
+				a := stringset.ResourceAttribute(
+					"The severities to match, any of `info`, `notice`, `warning`, `error` or `unknown`.", "",
+				)
+				a.Validators = append(a.Validators, setvalidator.ValueStringsAre(
+					stringvalidator.OneOf("info", "notice", "warning", "error", "unknown"),
+				))
+
+				return a
+			}(),
+			"mode": schema.StringAttribute{
+				Description: "Whether `all` or `any` of the match rules must match. If not set, PVE default is `all`.",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf("all", "any")},
+			},
+			"target": stringset.ResourceAttribute(
+				"The names of the notification targets to send the matching notifications to.", "",
+			),
+		},
+	}
+}
+
+// Create creates a new notification matcher.
+func (r *MatcherResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan matcherModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := plan.toAPIRequestBody(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.CreateMatcher(ctx, reqData); err != nil {
+		resp.Diagnostics.AddError("Unable to Create Notification Matcher", err.Error())
+
+		return
+	}
+
+	plan.ID = plan.Name
+
+	r.read(ctx, &plan, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read reads the notification matcher.
+func (r *MatcherResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state matcherModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+	if !found {
+		if !resp.Diagnostics.HasError() {
+			resp.State.RemoveResource(ctx)
+		}
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *MatcherResource) read(ctx context.Context, model *matcherModel, diags *diag.Diagnostics) bool {
+	data, err := r.client.GetMatcher(ctx, model.ID.ValueString())
+	if err != nil {
+		if !errors.Is(err, api.ErrResourceDoesNotExist) {
+			diags.AddError("Unable to Read Notification Matcher", err.Error())
+		}
+
+		return false
+	}
+
+	model.importFromAPI(data, diags)
+
+	return true
+}
+
+// Update updates the notification matcher.
+func (r *MatcherResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state matcherModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := plan.toAPIRequestBody(ctx, &resp.Diagnostics)
+	reqData.Delete = plan.toDelete(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.UpdateMatcher(ctx, reqData); err != nil {
+		resp.Diagnostics.AddError("Unable to Update Notification Matcher", err.Error())
+
+		return
+	}
+
+	r.read(ctx, &plan, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the notification matcher.
+func (r *MatcherResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state matcherModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteMatcher(ctx, state.ID.ValueString()); err != nil &&
+		!errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError("Unable to Delete Notification Matcher", err.Error())
+	}
+}
+
+// ImportState imports a notification matcher by its name.
+func (r *MatcherResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,192 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notification_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceNotification(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	tests := []struct {
+		name  string
+		steps []resource.TestStep
+	}{
+		{"smtp endpoint", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_notification_endpoint_smtp" "acc_smtp" {
+					name         = "acc-smtp"
+					server       = "smtp.example.com"
+					port         = 587
+					mode         = "starttls"
+					username     = "user"
+					password     = "password"
+					from_address = "pve@example.com"
+					mailto       = ["admins@example.com"]
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_notification_endpoint_smtp.acc_smtp", map[string]string{
+						"id":            "acc-smtp",
+						"server":        "smtp.example.com",
+						"port":          "587",
+						"mode":          "starttls",
+						"password":      "password",
+						"mailto.#":      "1",
+						"mailto_user.#": "0",
+					}),
+				),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_notification_endpoint_smtp" "acc_smtp" {
+					name         = "acc-smtp"
+					server       = "smtp.example.com"
+					from_address = "pve@example.com"
+					mailto_user  = ["root@pam"]
+					mailto       = []
+					comment      = "managed by terraform"
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_notification_endpoint_smtp.acc_smtp", map[string]string{
+						"comment":       "managed by terraform",
+						"mailto.#":      "0",
+						"mailto_user.#": "1",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_notification_endpoint_smtp.acc_smtp", []string{
+						"port",
+						"mode",
+						"username",
+						"password",
+					}),
+				),
+			},
+			{
+				ResourceName:            "proxmox_virtual_environment_notification_endpoint_smtp.acc_smtp",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		}},
+		{"gotify endpoint and matcher", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_notification_endpoint_gotify" "acc_gotify" {
+					name   = "acc-gotify"
+					server = "https://gotify.example.com"
+					token  = "token"
+				}
+
+				resource "proxmox_virtual_environment_notification_matcher" "acc_matcher" {
+					name           = "acc-matcher"
+					match_severity = ["warning", "error"]
+					match_field    = ["exact:type=vzdump"]
+					target         = ["mail-to-root", proxmox_virtual_environment_notification_endpoint_gotify.acc_gotify.name]
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_notification_matcher.acc_matcher", map[string]string{
+						"id":               "acc-matcher",
+						"match_severity.#": "2",
+						"match_field.0":    "exact:type=vzdump",
+						"target.#":         "2",
+					}),
+				),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_notification_endpoint_gotify" "acc_gotify" {
+					name   = "acc-gotify"
+					server = "https://gotify.example.com"
+					token  = "token"
+				}
+
+				resource "proxmox_virtual_environment_notification_matcher" "acc_matcher" {
+					name           = "acc-matcher"
+					match_severity = ["warning", "error"]
+					match_field    = ["exact:type=vzdump"]
+					target         = ["mail-to-root", proxmox_virtual_environment_notification_endpoint_gotify.acc_gotify.name]
+				}`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_notification_matcher" "acc_matcher" {
+					name         = "acc-matcher"
+					invert_match = true
+					mode         = "any"
+					target       = ["mail-to-root"]
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_notification_matcher.acc_matcher", map[string]string{
+						"invert_match": "true",
+						"mode":         "any",
+						"target.#":     "1",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_notification_matcher.acc_matcher", []string{
+						"match_field",
+					}),
+				),
+			},
+			{
+				ResourceName:      "proxmox_virtual_environment_notification_matcher.acc_matcher",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		}},
+		{"webhook endpoint", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_notification_endpoint_webhook" "acc_webhook" {
+					name   = "acc-webhook"
+					url    = "https://hooks.example.com/{{ secrets.channel }}"
+					method = "post"
+					body   = "{{ title }}"
+					header = {
+						"Content-Type" = "text/plain"
+					}
+					secret = {
+						channel = "channel"
+					}
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_notification_endpoint_webhook.acc_webhook", map[string]string{
+						"body":                "{{ title }}",
+						"header.Content-Type": "text/plain",
+						"secret.channel":      "channel",
+					}),
+				),
+			},
+			{
+				ResourceName:            "proxmox_virtual_environment_notification_endpoint_webhook.acc_webhook",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret"},
+			},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: te.AccProviders,
+				Steps:                    tt.steps,
+			})
+		})
+	}
+}
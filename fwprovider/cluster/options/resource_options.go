@@ -10,10 +10,11 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -23,9 +24,11 @@ import (
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/types/stringset"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
 )
 
 const (
@@ -53,27 +56,42 @@ var (
 )
 
 type clusterOptionsModel struct {
-	ID                      types.String               `tfsdk:"id"`
-	BandwidthLimitClone     types.Int64                `tfsdk:"bandwidth_limit_clone"`
-	BandwidthLimitDefault   types.Int64                `tfsdk:"bandwidth_limit_default"`
-	BandwidthLimitMigration types.Int64                `tfsdk:"bandwidth_limit_migration"`
-	BandwidthLimitMove      types.Int64                `tfsdk:"bandwidth_limit_move"`
-	BandwidthLimitRestore   types.Int64                `tfsdk:"bandwidth_limit_restore"`
-	Console                 types.String               `tfsdk:"console"`
-	CrsHA                   types.String               `tfsdk:"crs_ha"`
-	CrsHARebalanceOnStart   types.Bool                 `tfsdk:"crs_ha_rebalance_on_start"`
-	Description             types.String               `tfsdk:"description"`
-	EmailFrom               types.String               `tfsdk:"email_from"`
-	HAShutdownPolicy        types.String               `tfsdk:"ha_shutdown_policy"`
-	HTTPProxy               types.String               `tfsdk:"http_proxy"`
-	Keyboard                types.String               `tfsdk:"keyboard"`
-	Language                types.String               `tfsdk:"language"`
-	MacPrefix               types.String               `tfsdk:"mac_prefix"`
-	MaxWorkers              types.Int64                `tfsdk:"max_workers"`
-	MigrationNetwork        types.String               `tfsdk:"migration_cidr"`
-	MigrationType           types.String               `tfsdk:"migration_type"`
-	NextID                  *clusterOptionsNextIDModel `tfsdk:"next_id"`
-	Notify                  *clusterOptionsNotifyModel `tfsdk:"notify"`
+	ID                      types.String                      `tfsdk:"id"`
+	BandwidthLimitClone     types.Int64                       `tfsdk:"bandwidth_limit_clone"`
+	BandwidthLimitDefault   types.Int64                       `tfsdk:"bandwidth_limit_default"`
+	BandwidthLimitMigration types.Int64                       `tfsdk:"bandwidth_limit_migration"`
+	BandwidthLimitMove      types.Int64                       `tfsdk:"bandwidth_limit_move"`
+	BandwidthLimitRestore   types.Int64                       `tfsdk:"bandwidth_limit_restore"`
+	Console                 types.String                      `tfsdk:"console"`
+	CrsHA                   types.String                      `tfsdk:"crs_ha"`
+	CrsHARebalanceOnStart   types.Bool                        `tfsdk:"crs_ha_rebalance_on_start"`
+	Description             types.String                      `tfsdk:"description"`
+	EmailFrom               types.String                      `tfsdk:"email_from"`
+	HAShutdownPolicy        types.String                      `tfsdk:"ha_shutdown_policy"`
+	HTTPProxy               types.String                      `tfsdk:"http_proxy"`
+	Keyboard                types.String                      `tfsdk:"keyboard"`
+	Language                types.String                      `tfsdk:"language"`
+	MacPrefix               types.String                      `tfsdk:"mac_prefix"`
+	MaxWorkers              types.Int64                       `tfsdk:"max_workers"`
+	MigrationNetwork        types.String                      `tfsdk:"migration_cidr"`
+	MigrationType           types.String                      `tfsdk:"migration_type"`
+	NextID                  *clusterOptionsNextIDModel        `tfsdk:"next_id"`
+	Notify                  *clusterOptionsNotifyModel        `tfsdk:"notify"`
+	RegisteredTags          stringset.Value                   `tfsdk:"registered_tags"`
+	TagStyle                *clusterOptionsTagStyleModel      `tfsdk:"tag_style"`
+	UserTagAccess           *clusterOptionsUserTagAccessModel `tfsdk:"user_tag_access"`
+}
+
+type clusterOptionsTagStyleModel struct {
+	CaseSensitive types.Bool   `tfsdk:"case_sensitive"`
+	ColorMap      types.Map    `tfsdk:"color_map"`
+	Ordering      types.String `tfsdk:"ordering"`
+	Shape         types.String `tfsdk:"shape"`
+}
+
+type clusterOptionsUserTagAccessModel struct {
+	UserAllow     types.String    `tfsdk:"user_allow"`
+	UserAllowList stringset.Value `tfsdk:"user_allow_list"`
 }
 
 type clusterOptionsNextIDModel struct {
@@ -229,39 +247,86 @@ func (m *clusterOptionsModel) crsData() string {
 	return ""
 }
 
+// bandwidthLimit returns the bandwidth limit settings managed by the resource.
+func (m *clusterOptionsModel) bandwidthLimit() *cluster.BandwidthLimit {
+	limit := func(v types.Int64) *int64 {
+		if v.IsNull() || v.IsUnknown() || v.ValueInt64() == 0 {
+			return nil
+		}
+
+		return v.ValueInt64Pointer()
+	}
+
+	return &cluster.BandwidthLimit{
+		Clone:     limit(m.BandwidthLimitClone),
+		Default:   limit(m.BandwidthLimitDefault),
+		Migration: limit(m.BandwidthLimitMigration),
+		Move:      limit(m.BandwidthLimitMove),
+		Restore:   limit(m.BandwidthLimitRestore),
+	}
+}
+
 // bandwidthData returns bandwidth limit settings parameter string for API, if any of bandwidth
 // limit settings are defined, otherwise empty string is returned.
 func (m *clusterOptionsModel) bandwidthData() string {
-	var bandwidthParams []string
+	return m.bandwidthLimit().String()
+}
 
-	if !m.BandwidthLimitClone.IsNull() && m.BandwidthLimitClone.ValueInt64() != 0 {
-		bandwidthParams = append(bandwidthParams, fmt.Sprintf("clone=%d", m.BandwidthLimitClone.ValueInt64()))
+// tagStyleData returns tag style settings parameter string for API, if any of tag style
+// settings are defined, otherwise empty string is returned.
+func (m *clusterOptionsModel) tagStyleData(ctx context.Context, diags *diag.Diagnostics) string {
+	if m.TagStyle == nil {
+		return ""
 	}
 
-	if !m.BandwidthLimitDefault.IsNull() && m.BandwidthLimitDefault.ValueInt64() != 0 {
-		bandwidthParams = append(bandwidthParams, fmt.Sprintf("default=%d", m.BandwidthLimitDefault.ValueInt64()))
+	tagStyle := &cluster.TagStyle{}
+
+	if !m.TagStyle.CaseSensitive.IsNull() && !m.TagStyle.CaseSensitive.IsUnknown() {
+		tagStyle.CaseSensitive = proxmoxtypes.CustomBool(m.TagStyle.CaseSensitive.ValueBool()).Pointer()
 	}
 
-	if !m.BandwidthLimitMigration.IsNull() && m.BandwidthLimitMigration.ValueInt64() != 0 {
-		bandwidthParams = append(bandwidthParams, fmt.Sprintf("migration=%d", m.BandwidthLimitMigration.ValueInt64()))
+	if !m.TagStyle.ColorMap.IsNull() && !m.TagStyle.ColorMap.IsUnknown() {
+		colors := map[string]string{}
+		diags.Append(m.TagStyle.ColorMap.ElementsAs(ctx, &colors, false)...)
+
+		colorMap := cluster.FormatTagColorMap(colors)
+		tagStyle.ColorMap = &colorMap
 	}
 
-	if !m.BandwidthLimitMove.IsNull() && m.BandwidthLimitMove.ValueInt64() != 0 {
-		bandwidthParams = append(bandwidthParams, fmt.Sprintf("move=%d", m.BandwidthLimitMove.ValueInt64()))
+	if !m.TagStyle.Ordering.IsNull() && !m.TagStyle.Ordering.IsUnknown() {
+		tagStyle.Ordering = m.TagStyle.Ordering.ValueStringPointer()
 	}
 
-	if !m.BandwidthLimitRestore.IsNull() && m.BandwidthLimitRestore.ValueInt64() != 0 {
-		bandwidthParams = append(bandwidthParams, fmt.Sprintf("restore=%d", m.BandwidthLimitRestore.ValueInt64()))
+	if !m.TagStyle.Shape.IsNull() && !m.TagStyle.Shape.IsUnknown() {
+		tagStyle.Shape = m.TagStyle.Shape.ValueStringPointer()
 	}
 
-	if len(bandwidthParams) > 0 {
-		return strings.Join(bandwidthParams, ",")
+	return tagStyle.String()
+}
+
+// userTagAccessData returns user tag access settings parameter string for API, if any of user tag access
+// settings are defined, otherwise empty string is returned.
+func (m *clusterOptionsModel) userTagAccessData(ctx context.Context, diags *diag.Diagnostics) string {
+	if m.UserTagAccess == nil {
+		return ""
 	}
 
-	return ""
+	userTagAccess := &cluster.UserTagAccess{
+		UserAllow: m.UserTagAccess.UserAllow.ValueStringPointer(),
+	}
+
+	if list := m.UserTagAccess.UserAllowList.ValueList(ctx, diags); len(list) > 0 {
+		userTagAccess.UserAllowList = &list
+	}
+
+	return userTagAccess.String()
 }
 
-func (m *clusterOptionsModel) toOptionsRequestBody() *cluster.OptionsRequestData {
+func (m *clusterOptionsModel) toOptionsRequestBody(
+	ctx context.Context,
+	current *cluster.OptionsResponseData,
+	diags *diag.Diagnostics,
+) *cluster.OptionsRequestData {
 	body := &cluster.OptionsRequestData{}
 
 	if !m.EmailFrom.IsUnknown() {
@@ -302,7 +367,7 @@ func (m *clusterOptionsModel) toOptionsRequestBody() *cluster.OptionsRequestData
 		body.MacPrefix = m.MacPrefix.ValueStringPointer()
 	}
 
-	if !m.MacPrefix.IsUnknown() {
+	if !m.Description.IsUnknown() {
 		body.Description = m.Description.ValueStringPointer()
 	}
 
@@ -311,11 +376,26 @@ func (m *clusterOptionsModel) toOptionsRequestBody() *cluster.OptionsRequestData
 		body.HASettings = &haData
 	}
 
-	bandwidthData := m.bandwidthData()
+	bandwidthLimit := m.bandwidthLimit()
+	bandwidthLimit.Other = unmanagedBandwidthLimits(current)
+
+	bandwidthData := bandwidthLimit.String()
 	if bandwidthData != "" {
 		body.BandwidthLimit = &bandwidthData
 	}
 
+	tagStyleData := m.tagStyleData(ctx, diags)
+	if tagStyleData != "" {
+		body.TagStyle = &tagStyleData
+	}
+
+	userTagAccessData := m.userTagAccessData(ctx, diags)
+	if userTagAccessData != "" {
+		body.UserTagAccess = &userTagAccessData
+	}
+
+	body.RegisteredTags = m.RegisteredTags.ValueStringPointer(ctx, diags, stringset.WithSeparator(";"))
+
 	crsData := m.crsData()
 	if crsData != "" {
 		body.ClusterResourceScheduling = &crsData
@@ -329,46 +409,39 @@ func (m *clusterOptionsModel) toOptionsRequestBody() *cluster.OptionsRequestData
 	return body
 }
 
-func (m *clusterOptionsModel) importFromOptionsAPI(_ context.Context, opts *cluster.OptionsResponseData) error {
-	m.BandwidthLimitClone = types.Int64Null()
-	m.BandwidthLimitDefault = types.Int64Null()
-	m.BandwidthLimitMigration = types.Int64Null()
-	m.BandwidthLimitMove = types.Int64Null()
-	m.BandwidthLimitRestore = types.Int64Null()
+// unmanagedBandwidthLimits returns the bandwidth limits of the current cluster options that are not
+// managed by the resource, so they can be passed through untouched.
+func unmanagedBandwidthLimits(current *cluster.OptionsResponseData) map[string]string {
+	if current == nil || current.BandwidthLimit == nil {
+		return nil
+	}
+
+	bandwidthLimit, err := cluster.ParseBandwidthLimit(*current.BandwidthLimit)
+	if err != nil {
+		return nil
+	}
+
+	return bandwidthLimit.Other
+}
+
+func (m *clusterOptionsModel) importFromOptionsAPI(ctx context.Context, opts *cluster.OptionsResponseData) error {
+	bandwidthLimit := &cluster.BandwidthLimit{}
 
-	//nolint:nestif
 	if opts.BandwidthLimit != nil {
-		for _, bandwidth := range strings.Split(*opts.BandwidthLimit, ",") {
-			bandwidthData := strings.SplitN(bandwidth, "=", 2)
-			bandwidthName := bandwidthData[0]
-
-			bandwidthLimit, err := strconv.ParseInt(bandwidthData[1], 10, 64)
-			if err != nil {
-				return fmt.Errorf("failed to parse bandwidth limit: %s", *opts.BandwidthLimit)
-			}
-
-			if bandwidthName == "clone" {
-				m.BandwidthLimitClone = types.Int64Value(bandwidthLimit)
-			}
-
-			if bandwidthName == "default" {
-				m.BandwidthLimitDefault = types.Int64Value(bandwidthLimit)
-			}
-
-			if bandwidthName == "migration" {
-				m.BandwidthLimitMigration = types.Int64Value(bandwidthLimit)
-			}
-
-			if bandwidthName == "move" {
-				m.BandwidthLimitMove = types.Int64Value(bandwidthLimit)
-			}
-
-			if bandwidthName == "restore" {
-				m.BandwidthLimitRestore = types.Int64Value(bandwidthLimit)
-			}
+		var err error
+
+		bandwidthLimit, err = cluster.ParseBandwidthLimit(*opts.BandwidthLimit)
+		if err != nil {
+			return err
 		}
 	}
 
+	m.BandwidthLimitClone = types.Int64PointerValue(bandwidthLimit.Clone)
+	m.BandwidthLimitDefault = types.Int64PointerValue(bandwidthLimit.Default)
+	m.BandwidthLimitMigration = types.Int64PointerValue(bandwidthLimit.Migration)
+	m.BandwidthLimitMove = types.Int64PointerValue(bandwidthLimit.Move)
+	m.BandwidthLimitRestore = types.Int64PointerValue(bandwidthLimit.Restore)
+
 	m.EmailFrom = types.StringPointerValue(opts.EmailFrom)
 	m.Keyboard = types.StringPointerValue(opts.Keyboard)
 	m.Language = types.StringPointerValue(opts.Language)
@@ -428,6 +501,49 @@ func (m *clusterOptionsModel) importFromOptionsAPI(_ context.Context, opts *clus
 		m.CrsHA = types.StringNull()
 	}
 
+	var diags diag.Diagnostics
+
+	if opts.RegisteredTags != nil && len(*opts.RegisteredTags) > 0 {
+		m.RegisteredTags = stringset.NewValueList(*opts.RegisteredTags, &diags)
+	} else {
+		m.RegisteredTags = stringset.Value{SetValue: types.SetNull(types.StringType)}
+	}
+
+	m.TagStyle = nil
+
+	if opts.TagStyle != nil {
+		m.TagStyle = &clusterOptionsTagStyleModel{
+			CaseSensitive: types.BoolPointerValue(opts.TagStyle.CaseSensitive.PointerBool()),
+			ColorMap:      types.MapNull(types.StringType),
+			Ordering:      types.StringPointerValue(opts.TagStyle.Ordering),
+			Shape:         types.StringPointerValue(opts.TagStyle.Shape),
+		}
+
+		if opts.TagStyle.ColorMap != nil && *opts.TagStyle.ColorMap != "" {
+			var d diag.Diagnostics
+
+			m.TagStyle.ColorMap, d = types.MapValueFrom(ctx, types.StringType, cluster.ParseTagColorMap(*opts.TagStyle.ColorMap))
+			diags.Append(d...)
+		}
+	}
+
+	m.UserTagAccess = nil
+
+	if opts.UserTagAccess != nil {
+		m.UserTagAccess = &clusterOptionsUserTagAccessModel{
+			UserAllow:     types.StringPointerValue(opts.UserTagAccess.UserAllow),
+			UserAllowList: stringset.Value{SetValue: types.SetNull(types.StringType)},
+		}
+
+		if opts.UserTagAccess.UserAllowList != nil && len(*opts.UserTagAccess.UserAllowList) > 0 {
+			m.UserTagAccess.UserAllowList = stringset.NewValueList(*opts.UserTagAccess.UserAllowList, &diags)
+		}
+	}
+
+	if diags.HasError() {
+		return fmt.Errorf("failed to import tag options: %v", diags.Errors())
+	}
+
 	return nil
 }
 
@@ -456,6 +572,9 @@ func (r *clusterOptionsResource) Schema(
 ) {
 	resp.Schema = schema.Schema{
 		Description: "Manages Proxmox VE Cluster Datacenter options.",
+		MarkdownDescription: "Manages Proxmox VE Cluster Datacenter options. Options not set in the resource, " +
+			"as well as bandwidth limits unknown to the provider, are left untouched. " +
+			"Destroying the resource resets the options it manages to their defaults.",
 		Attributes: map[string]schema.Attribute{
 			"id": attribute.ResourceID(),
 			"email_from": schema.StringAttribute{
@@ -685,10 +804,86 @@ func (r *clusterOptionsResource) Schema(
 				Description: "Restore I/O bandwidth limit in KiB/s.",
 				Optional:    true,
 			},
+			"registered_tags": registeredTagsAttribute(),
+			"tag_style": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"case_sensitive": schema.BoolAttribute{
+						Description: "Whether tags are compared case-sensitively when sorting and filtering.",
+						Optional:    true,
+					},
+					"color_map": schema.MapAttribute{
+						Description: "Map of tag names to their colors.",
+						MarkdownDescription: "Map of tag names to their colors. The color is the hex RGB " +
+							"background color, optionally followed by `:` and the hex RGB text color, " +
+							"e.g. `FF0000` or `FF0000:FFFFFF`.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.Map{
+							mapvalidator.ValueStringsAre(validators.TagColorValidator()),
+						},
+					},
+					"ordering": schema.StringAttribute{
+						Description:         "Ordering of tags in the web UI.",
+						MarkdownDescription: "Ordering of tags in the web UI. Must be `config` | `alphabetical` (default is `alphabetical`).",
+						Optional:            true,
+						Validators: []validator.String{stringvalidator.OneOf([]string{
+							"config",
+							"alphabetical",
+						}...)},
+					},
+					"shape": schema.StringAttribute{
+						Description:         "Shape of tags in the web UI tree.",
+						MarkdownDescription: "Shape of tags in the web UI tree. Must be `full` | `circle` | `dense` | `none` (default is `circle`).",
+						Optional:            true,
+						Validators: []validator.String{stringvalidator.OneOf([]string{
+							"full",
+							"circle",
+							"dense",
+							"none",
+						}...)},
+					},
+				},
+				Description: "Tag style settings.",
+				Optional:    true,
+			},
+			"user_tag_access": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"user_allow": schema.StringAttribute{
+						Description: "Which tags users can set or delete on resources they have access to.",
+						MarkdownDescription: "Which tags users can set or delete on resources they have access to. " +
+							"Must be `none` | `list` | `existing` | `free` (default is `free`).",
+						Optional: true,
+						Validators: []validator.String{stringvalidator.OneOf([]string{
+							"none",
+							"list",
+							"existing",
+							"free",
+						}...)},
+					},
+					"user_allow_list": tagsAttribute("List of tags users are allowed to set and delete " +
+						"(used by the `list` and `existing` modes)."),
+				},
+				Description: "User tag access settings.",
+				Optional:    true,
+			},
 		},
 	}
 }
 
+// tagsAttribute returns an optional set attribute of tags.
+func tagsAttribute(desc string) schema.SetAttribute {
+	attr := stringset.ResourceAttribute(desc, "")
+	attr.Computed = false
+	attr.Validators = append(attr.Validators, setvalidator.ValueStringsAre(validators.TagNameValidator()))
+
+	return attr
+}
+
+func registeredTagsAttribute() schema.SetAttribute {
+	return tagsAttribute("Tags that require `Sys.Modify` on `/` to set and delete, " +
+		"they are always shown in the tag selection.")
+}
+
 func (r *clusterOptionsResource) Configure(
 	_ context.Context,
 	req resource.ConfigureRequest,
@@ -727,7 +922,12 @@ func (r *clusterOptionsResource) Create(
 		return
 	}
 
-	body := plan.toOptionsRequestBody()
+	current := r.currentOptions(ctx, &resp.Diagnostics)
+	body := plan.toOptionsRequestBody(ctx, current, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	err := r.client.Cluster().CreateUpdateOptions(ctx, body)
 	if err != nil {
@@ -751,6 +951,22 @@ func (r *clusterOptionsResource) Create(
 	resp.Diagnostics.Append(diags...)
 }
 
+// currentOptions returns the current cluster options, which are used to pass through the settings not managed by
+// the resource.
+func (r *clusterOptionsResource) currentOptions(ctx context.Context, diags *diag.Diagnostics) *cluster.OptionsResponseData {
+	options, err := r.client.Cluster().GetOptions(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error get cluster options",
+			"Could not get cluster options, unexpected error: "+err.Error(),
+		)
+
+		return nil
+	}
+
+	return options
+}
+
 func (r *clusterOptionsResource) read(ctx context.Context, model *clusterOptionsModel, diags *diag.Diagnostics) {
 	options, err := r.client.Cluster().GetOptions(ctx)
 	if err != nil {
@@ -810,7 +1026,12 @@ func (r *clusterOptionsResource) Update(
 		return
 	}
 
-	body := plan.toOptionsRequestBody()
+	current := r.currentOptions(ctx, &resp.Diagnostics)
+	body := plan.toOptionsRequestBody(ctx, current, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	var toDelete []string
 
@@ -818,10 +1039,22 @@ func (r *clusterOptionsResource) Update(
 		toDelete = append(toDelete, "keyboard")
 	}
 
-	if plan.bandwidthData() != state.bandwidthData() && plan.bandwidthData() == "" {
+	if plan.bandwidthData() != state.bandwidthData() && body.BandwidthLimit == nil {
 		toDelete = append(toDelete, "bwlimit")
 	}
 
+	if state.tagStyleData(ctx, &resp.Diagnostics) != "" && body.TagStyle == nil {
+		toDelete = append(toDelete, "tag-style")
+	}
+
+	if state.userTagAccessData(ctx, &resp.Diagnostics) != "" && body.UserTagAccess == nil {
+		toDelete = append(toDelete, "user-tag-access")
+	}
+
+	if !plan.RegisteredTags.Equal(state.RegisteredTags) && body.RegisteredTags == nil {
+		toDelete = append(toDelete, "registered-tags")
+	}
+
 	if plan.crsData() != state.crsData() && plan.crsData() == "" {
 		toDelete = append(toDelete, "crs")
 	}
@@ -905,6 +1138,12 @@ func (r *clusterOptionsResource) Delete(
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := &cluster.OptionsRequestData{}
+
 	var toDelete []string
 
 	if !state.Keyboard.IsNull() && state.Keyboard.ValueString() != "" {
@@ -912,7 +1151,30 @@ func (r *clusterOptionsResource) Delete(
 	}
 
 	if state.bandwidthData() != "" {
-		toDelete = append(toDelete, "bwlimit")
+		// keep the bandwidth limits not managed by the resource
+		current := r.currentOptions(ctx, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		unmanaged := (&cluster.BandwidthLimit{Other: unmanagedBandwidthLimits(current)}).String()
+		if unmanaged != "" {
+			body.BandwidthLimit = &unmanaged
+		} else {
+			toDelete = append(toDelete, "bwlimit")
+		}
+	}
+
+	if state.tagStyleData(ctx, &resp.Diagnostics) != "" {
+		toDelete = append(toDelete, "tag-style")
+	}
+
+	if state.userTagAccessData(ctx, &resp.Diagnostics) != "" {
+		toDelete = append(toDelete, "user-tag-access")
+	}
+
+	if len(state.RegisteredTags.ValueList(ctx, &resp.Diagnostics)) > 0 {
+		toDelete = append(toDelete, "registered-tags")
 	}
 
 	if state.crsData() != "" {
@@ -965,10 +1227,10 @@ func (r *clusterOptionsResource) Delete(
 
 	if len(toDelete) > 0 {
 		d := strings.Join(toDelete, ",")
-		body := &cluster.OptionsRequestData{
-			Delete: &d,
-		}
+		body.Delete = &d
+	}
 
+	if body.Delete != nil || body.BandwidthLimit != nil {
 		err := r.client.Cluster().CreateUpdateOptions(ctx, body)
 		if err != nil {
 			resp.Diagnostics.AddError(
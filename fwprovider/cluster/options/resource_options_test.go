@@ -77,6 +77,20 @@ func testAccResourceClusterOptionsCreatedConfig() string {
       replication        = "always"
       replication_target = "default-matcher"
     }
+		registered_tags = ["prod", "dev"]
+		tag_style = {
+			case_sensitive = false
+			color_map = {
+				prod = "FF0000:FFFFFF"
+				dev  = "00FF00"
+			}
+			ordering = "config"
+			shape    = "dense"
+		}
+		user_tag_access = {
+			user_allow      = "list"
+			user_allow_list = ["team-a", "team-b"]
+		}
 	}
 	`,
 		100,
@@ -116,6 +130,14 @@ func testAccResourceClusterOptionsCreatedCheck() resource.TestCheckFunc {
 		resource.TestCheckResourceAttr(accTestClusterOptionsName, "notify.replication", "always"),
 		resource.TestCheckResourceAttr(accTestClusterOptionsName, "notify.replication_target", "default-matcher"),
 		resource.TestCheckNoResourceAttr(accTestClusterOptionsName, "bandwidth_limit_move"),
+		resource.TestCheckResourceAttr(accTestClusterOptionsName, "registered_tags.#", "2"),
+		resource.TestCheckResourceAttr(accTestClusterOptionsName, "tag_style.case_sensitive", "false"),
+		resource.TestCheckResourceAttr(accTestClusterOptionsName, "tag_style.color_map.prod", "FF0000:FFFFFF"),
+		resource.TestCheckResourceAttr(accTestClusterOptionsName, "tag_style.color_map.dev", "00FF00"),
+		resource.TestCheckResourceAttr(accTestClusterOptionsName, "tag_style.ordering", "config"),
+		resource.TestCheckResourceAttr(accTestClusterOptionsName, "tag_style.shape", "dense"),
+		resource.TestCheckResourceAttr(accTestClusterOptionsName, "user_tag_access.user_allow", "list"),
+		resource.TestCheckResourceAttr(accTestClusterOptionsName, "user_tag_access.user_allow_list.#", "2"),
 	)
 }
 
@@ -168,5 +190,8 @@ func testAccResourceClusterOptionsUpdatedCheck() resource.TestCheckFunc {
 		resource.TestCheckNoResourceAttr(accTestClusterOptionsName, "ha_shutdown_policy"),
 		resource.TestCheckNoResourceAttr(accTestClusterOptionsName, "http_proxy"),
 		resource.TestCheckNoResourceAttr(accTestClusterOptionsName, "keyboard"),
+		resource.TestCheckNoResourceAttr(accTestClusterOptionsName, "registered_tags"),
+		resource.TestCheckNoResourceAttr(accTestClusterOptionsName, "tag_style"),
+		resource.TestCheckNoResourceAttr(accTestClusterOptionsName, "user_tag_access"),
 	)
 }
@@ -0,0 +1,357 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/replication"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+const defaultSchedule = "*/15"
+
+var (
+	_ resource.ResourceWithConfigure   = &jobResource{}
+	_ resource.ResourceWithImportState = &jobResource{}
+)
+
+type jobModel struct {
+	ID               types.String  `tfsdk:"id"`
+	CleanupOnDestroy types.Bool    `tfsdk:"cleanup_on_destroy"`
+	Comment          types.String  `tfsdk:"comment"`
+	Disable          types.Bool    `tfsdk:"disable"`
+	Rate             types.Float64 `tfsdk:"rate"`
+	Schedule         types.String  `tfsdk:"schedule"`
+	TargetNode       types.String  `tfsdk:"target_node"`
+	VMID             types.Int64   `tfsdk:"vm_id"`
+}
+
+func (m *jobModel) importFromAPI(data *replication.JobData) {
+	m.ID = types.StringValue(data.ID)
+	m.Comment = types.StringPointerValue(data.Comment)
+	m.Rate = types.Float64PointerValue(data.Rate)
+	m.TargetNode = types.StringValue(data.Target)
+
+	// PVE omits the default values, so they are normalized to avoid diffs
+	m.Disable = types.BoolValue(data.Disable != nil && bool(*data.Disable))
+	m.Schedule = types.StringValue(defaultSchedule)
+
+	if data.Schedule != nil {
+		m.Schedule = types.StringValue(*data.Schedule)
+	}
+
+	if data.Guest != nil {
+		m.VMID = types.Int64Value(int64(*data.Guest))
+	} else if vmID, _, found := strings.Cut(data.ID, "-"); found {
+		// older PVE versions do not return the guest, but it is always the first part of the ID
+		if i, err := strconv.ParseInt(vmID, 10, 64); err == nil {
+			m.VMID = types.Int64Value(i)
+		}
+	}
+}
+
+func (m *jobModel) toAPIRequestBody() *replication.JobRequestData {
+	data := &replication.JobRequestData{}
+
+	data.ID = m.ID.ValueString()
+	data.Comment = m.Comment.ValueStringPointer()
+	data.Disable = proxmoxtypes.CustomBool(m.Disable.ValueBool()).Pointer()
+	data.Rate = m.Rate.ValueFloat64Pointer()
+	data.Schedule = m.Schedule.ValueStringPointer()
+	data.Target = m.TargetNode.ValueString()
+	data.Type = replication.JobTypeLocal
+
+	return data
+}
+
+func (m *jobModel) toDelete(state *jobModel) []string {
+	var toDelete []string
+
+	checkDelete(m.Comment, state.Comment, &toDelete, "comment")
+	checkDelete(m.Rate, state.Rate, &toDelete, "rate")
+
+	return toDelete
+}
+
+func checkDelete(planField, stateField attr.Value, toDelete *[]string, apiName string) {
+	// the attribute must be removed via the API if it is set in the state,
+	// but has been removed from the resource to use the PVE default
+	if planField.IsNull() && !stateField.IsNull() {
+		*toDelete = append(*toDelete, apiName)
+	}
+}
+
+// nextJobID returns the ID of the next replication job of the guest in the `<vmid>-<n>` format,
+// using the lowest job number that is not taken by another job of the guest yet.
+func nextJobID(vmID int64, jobs []*replication.JobData) string {
+	prefix := fmt.Sprintf("%d-", vmID)
+	taken := map[int]bool{}
+
+	for _, job := range jobs {
+		if n, found := strings.CutPrefix(job.ID, prefix); found {
+			if i, err := strconv.Atoi(n); err == nil {
+				taken[i] = true
+			}
+		}
+	}
+
+	n := 0
+	for taken[n] {
+		n++
+	}
+
+	return fmt.Sprintf("%s%d", prefix, n)
+}
+
+type jobResource struct {
+	client *replication.Client
+}
+
+// NewJobResource creates a new replication job resource.
+func NewJobResource() resource.Resource {
+	return &jobResource{}
+}
+
+func (r *jobResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_replication_job"
+}
+
+func (r *jobResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster().Replication()
+}
+
+func (r *jobResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a storage replication job.",
+		MarkdownDescription: "Manages a storage replication job.\n\n" +
+			"Replication requires all volumes of the guest to be on ZFS storage, " +
+			"PVE rejects the job otherwise.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the replication job in the `<vm_id>-<n>` format, e.g. `101-0`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cleanup_on_destroy": schema.BoolAttribute{
+				Description: "Whether to remove the replicated volumes from the target node when " +
+					"the job is destroyed. If `false`, the volumes are kept on the target node.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"comment": schema.StringAttribute{
+				Description: "The comment of the replication job.",
+				Optional:    true,
+			},
+			"disable": schema.BoolAttribute{
+				Description: "Whether the replication job is disabled.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"rate": schema.Float64Attribute{
+				Description: "The rate limit of the replication in MB/s. Unlimited if not set.",
+				Optional:    true,
+				Validators:  []validator.Float64{float64validator.AtLeast(1)},
+			},
+			"schedule": schema.StringAttribute{
+				Description: "The schedule of the replication job in the calendar event format, " +
+					"e.g. `*/30` or `mon..fri 21:30`. Defaults to `" + defaultSchedule + "`.",
+				Optional:   true,
+				Computed:   true,
+				Default:    stringdefault.StaticString(defaultSchedule),
+				Validators: []validator.String{validators.CalendarEventValidator()},
+			},
+			"target_node": schema.StringAttribute{
+				Description: "The name of the node to replicate the guest to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				Description: "The ID of the guest to replicate.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{int64validator.Between(100, 999999999)},
+			},
+		},
+	}
+}
+
+func (r *jobResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan jobModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jobs, err := r.client.ListJobs(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Create Replication Job", err.Error())
+
+		return
+	}
+
+	plan.ID = types.StringValue(nextJobID(plan.VMID.ValueInt64(), jobs))
+
+	if err = r.client.CreateJob(ctx, plan.toAPIRequestBody()); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Replication Job",
+			fmt.Sprintf(
+				"%s\n\nReplication requires all volumes of the guest to be on a storage "+
+					"that supports replication (ZFS), and the target node to have a storage with the same name.",
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *jobResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state jobModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, err := r.client.GetJob(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Unable to Read Replication Job", err.Error())
+
+		return
+	}
+
+	// the job is already being removed by the replication runner
+	if data.RemoveJob != nil {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	readModel := &jobModel{}
+	readModel.importFromAPI(data)
+
+	// the flag only affects the destroy, it is not stored in PVE
+	readModel.CleanupOnDestroy = state.CleanupOnDestroy
+	if readModel.CleanupOnDestroy.IsNull() {
+		readModel.CleanupOnDestroy = types.BoolValue(false)
+	}
+
+	// PVE stores the schedule as is, but keep the configured value if it only differs in whitespace or case
+	if normalizedState, e := proxmoxtypes.ParseCalendarEvent(state.Schedule.ValueString()); e == nil {
+		if normalizedRead, e := proxmoxtypes.ParseCalendarEvent(readModel.Schedule.ValueString()); e == nil &&
+			normalizedState == normalizedRead {
+			readModel.Schedule = state.Schedule
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+}
+
+func (r *jobResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state jobModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := plan.toAPIRequestBody()
+	reqData.Delete = plan.toDelete(&state)
+
+	if err := r.client.UpdateJob(ctx, reqData); err != nil {
+		resp.Diagnostics.AddError("Unable to Update Replication Job", err.Error())
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *jobResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state jobModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := &replication.JobDeleteRequestData{}
+	if !state.CleanupOnDestroy.ValueBool() {
+		reqData.Keep = proxmoxtypes.CustomBool(true).Pointer()
+	}
+
+	if err := r.client.DeleteJob(ctx, state.ID.ValueString(), reqData); err != nil &&
+		!errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError("Unable to Delete Replication Job", err.Error())
+	}
+}
+
+func (r *jobResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,79 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceReplicationJob(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	tests := []struct {
+		name  string
+		steps []resource.TestStep
+	}{
+		{"invalid configuration", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_replication_job" "acc_job" {
+					vm_id       = 100
+					target_node = "pve2"
+					schedule    = "*/75 25:00"
+				}`),
+				ExpectError: regexp.MustCompile(`valid calendar event`),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_replication_job" "acc_job" {
+					vm_id       = 100
+					target_node = "pve2"
+					rate        = 0
+				}`),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+			},
+		}},
+		{"guest without replicable volumes", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_vm" "acc_vm" {
+					node_name = "{{.NodeName}}"
+					started   = false
+
+					disk {
+						datastore_id = "local"
+						file_format  = "qcow2"
+						interface    = "scsi0"
+						size         = 1
+					}
+				}
+
+				resource "proxmox_virtual_environment_replication_job" "acc_job" {
+					vm_id       = proxmox_virtual_environment_vm.acc_vm.vm_id
+					target_node = "{{.NodeName}}"
+				}`),
+				ExpectError: regexp.MustCompile(`Unable to Create Replication Job`),
+			},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: te.AccProviders,
+				Steps:                    tt.steps,
+			})
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package pending provides the helpers to apply the changes of SDN resources,
+// and to track the changes that have not been applied yet.
+package pending
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+)
+
+// Attribute returns the schema of the computed `pending` attribute, which is `true` if the resource
+// has changes that have not been applied yet, e.g. after a failed apply. The attribute is always
+// planned as `false`, so pending changes show up in the plan and are applied by the next update.
+func Attribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Whether the resource has changes that have not been applied to the nodes yet.",
+		Computed:    true,
+		PlanModifiers: []planmodifier.Bool{
+			appliedModifier{},
+		},
+	}
+}
+
+type appliedModifier struct{}
+
+func (m appliedModifier) Description(_ context.Context) string {
+	return "Plans the changes of the resource to be applied."
+}
+
+func (m appliedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m appliedModifier) PlanModifyBool(
+	_ context.Context,
+	_ planmodifier.BoolRequest,
+	resp *planmodifier.BoolResponse,
+) {
+	resp.PlanValue = types.BoolValue(false)
+}
+
+// State returns the pending state of the SDN object at the given path, e.g. `zones/zone1`,
+// and whether its removal is pending.
+func State(ctx context.Context, client *sdn.Client, path string, diags *diag.Diagnostics) (types.Bool, bool) {
+	state, err := client.GetPendingState(ctx, path)
+	if err != nil {
+		diags.AddError("Unable to Read SDN Pending State", err.Error())
+
+		return types.BoolNull(), false
+	}
+
+	return types.BoolValue(state != ""), state == sdn.StateDeleted
+}
+
+// Apply applies the pending SDN configuration. The apply is shared with the other SDN resources
+// changed at the same time, so the network configuration of the nodes is only reloaded once.
+func Apply(ctx context.Context, applier *sdn.Applier, diags *diag.Diagnostics) bool {
+	if err := applier.Apply(ctx); err != nil {
+		diags.AddError("Unable to Apply SDN Configuration", err.Error())
+
+		return false
+	}
+
+	return true
+}
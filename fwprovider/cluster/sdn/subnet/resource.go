@@ -0,0 +1,402 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package subnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/pending"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	customtypes "github.com/bpg/terraform-provider-proxmox/fwprovider/types"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/subnets"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &Resource{}
+	_ resource.ResourceWithImportState = &Resource{}
+)
+
+type dhcpRangeModel struct {
+	StartAddress customtypes.IPAddrValue `tfsdk:"start_address"`
+	EndAddress   customtypes.IPAddrValue `tfsdk:"end_address"`
+}
+
+type model struct {
+	ID            types.String            `tfsdk:"id"`
+	CIDR          customtypes.IPCIDRValue `tfsdk:"cidr"`
+	DHCPDNSServer customtypes.IPAddrValue `tfsdk:"dhcp_dns_server"`
+	DHCPRanges    []dhcpRangeModel        `tfsdk:"dhcp_range"`
+	DNSZonePrefix types.String            `tfsdk:"dns_zone_prefix"`
+	Gateway       customtypes.IPAddrValue `tfsdk:"gateway"`
+	Pending       types.Bool              `tfsdk:"pending"`
+	SNAT          types.Bool              `tfsdk:"snat"`
+	Vnet          types.String            `tfsdk:"vnet"`
+}
+
+func (m *model) importFromAPI(vnet string, data *subnets.SubnetData) {
+	m.ID = types.StringValue(data.ID)
+	m.CIDR = customtypes.NewIPCIDRPointerValue(data.CIDR)
+	m.DHCPDNSServer = customtypes.NewIPAddrPointerValue(data.DHCPDNSServer)
+	m.DNSZonePrefix = types.StringPointerValue(data.DNSZonePrefix)
+	m.Gateway = customtypes.NewIPAddrPointerValue(data.Gateway)
+	m.Vnet = types.StringValue(vnet)
+
+	// PVE omits the default value, so it is normalized to avoid diffs
+	m.SNAT = types.BoolValue(data.SNAT != nil && bool(*data.SNAT))
+
+	m.DHCPRanges = nil
+
+	for _, r := range data.DHCPRanges {
+		m.DHCPRanges = append(m.DHCPRanges, dhcpRangeModel{
+			StartAddress: customtypes.NewIPAddrPointerValue(&r.StartAddress),
+			EndAddress:   customtypes.NewIPAddrPointerValue(&r.EndAddress),
+		})
+	}
+}
+
+func (m *model) toAPIRequestBody() *subnets.SubnetRequestData {
+	data := &subnets.SubnetRequestData{}
+
+	data.ID = m.ID.ValueString()
+	data.DHCPDNSServer = m.DHCPDNSServer.ValueStringPointer()
+	data.DNSZonePrefix = m.DNSZonePrefix.ValueStringPointer()
+	data.Gateway = m.Gateway.ValueStringPointer()
+	data.SNAT = proxmoxtypes.CustomBool(m.SNAT.ValueBool()).Pointer()
+	data.Type = ptr.Ptr(subnets.TypeSubnet)
+
+	for _, r := range m.DHCPRanges {
+		data.DHCPRanges = append(data.DHCPRanges, subnets.DHCPRange{
+			StartAddress: r.StartAddress.ValueString(),
+			EndAddress:   r.EndAddress.ValueString(),
+		})
+	}
+
+	return data
+}
+
+func (m *model) toDelete(state *model) []string {
+	var toDelete []string
+
+	checkDelete(m.DHCPDNSServer, state.DHCPDNSServer, &toDelete, "dhcp-dns-server")
+	checkDelete(m.DNSZonePrefix, state.DNSZonePrefix, &toDelete, "dnszoneprefix")
+	checkDelete(m.Gateway, state.Gateway, &toDelete, "gateway")
+
+	if len(m.DHCPRanges) == 0 && len(state.DHCPRanges) > 0 {
+		toDelete = append(toDelete, "dhcp-range")
+	}
+
+	return toDelete
+}
+
+func checkDelete(planField, stateField attr.Value, toDelete *[]string, apiName string) {
+	// the attribute must be removed via the API if it is set in the state,
+	// but has been removed from the resource to use the PVE default
+	if planField.IsNull() && !stateField.IsNull() {
+		*toDelete = append(*toDelete, apiName)
+	}
+}
+
+// Resource manages an SDN subnet.
+type Resource struct {
+	client    *cluster.Client
+	sdnClient *sdn.Client
+	applier   *sdn.Applier
+}
+
+// NewResource creates a new SDN subnet resource.
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_subnet"
+}
+
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster()
+	r.sdnClient = cfg.Client.Cluster().SDN()
+	r.applier = cfg.SDNApplier
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Subnet of a VNet in Proxmox SDN.",
+		MarkdownDescription: "Subnet of a VNet in Proxmox SDN. The changes are applied to the nodes automatically, " +
+			"together with the changes of the other SDN resources of the same plan.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the subnet in the `<zone>-<network>-<mask>` format, " +
+					"e.g. `zone1-10.0.0.0-24`.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cidr": schema.StringAttribute{
+				Description: "The subnet in CIDR notation, e.g. `10.0.0.0/24`.",
+				Required:    true,
+				CustomType:  customtypes.IPCIDRType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"dhcp_dns_server": schema.StringAttribute{
+				Description: "The DNS server sent to the DHCP clients.",
+				Optional:    true,
+				CustomType:  customtypes.IPAddrType{},
+			},
+			"dhcp_range": schema.ListNestedAttribute{
+				Description: "The ranges of addresses to lease to the guests via DHCP. " +
+					"DHCP must be enabled on the zone of the VNet.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"start_address": schema.StringAttribute{
+							Description: "The first address of the range.",
+							Required:    true,
+							CustomType:  customtypes.IPAddrType{},
+						},
+						"end_address": schema.StringAttribute{
+							Description: "The last address of the range.",
+							Required:    true,
+							CustomType:  customtypes.IPAddrType{},
+						},
+					},
+				},
+			},
+			"dns_zone_prefix": schema.StringAttribute{
+				Description: "The prefix of the DNS zone, e.g. `test` for `<hostname>.test.<domain>`.",
+				Optional:    true,
+			},
+			"gateway": schema.StringAttribute{
+				Description: "The gateway address of the subnet.",
+				Optional:    true,
+				CustomType:  customtypes.IPAddrType{},
+			},
+			"pending": pending.Attribute(),
+			"snat": schema.BoolAttribute{
+				Description: "Whether to enable source NAT for the traffic leaving the subnet.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"vnet": schema.StringAttribute{
+				Description: "The ID of the VNet of the subnet.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan model
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.SDNSubnets(plan.Vnet.ValueString())
+
+	// subnets are created by CIDR, PVE generates the ID from the zone and the CIDR
+	reqData := plan.toAPIRequestBody()
+	reqData.ID = plan.CIDR.ValueString()
+
+	if err := client.CreateSubnet(ctx, reqData); err != nil {
+		resp.Diagnostics.AddError("Unable to Create SDN Subnet", err.Error())
+
+		return
+	}
+
+	id, err := r.findSubnetID(ctx, client, plan.CIDR.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read SDN Subnet", err.Error())
+
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	plan.Pending = types.BoolValue(!pending.Apply(ctx, r.applier, &resp.Diagnostics))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// findSubnetID returns the ID of the subnet of the VNet with the given CIDR.
+func (r *Resource) findSubnetID(ctx context.Context, client *subnets.Client, cidr string) (string, error) {
+	list, err := client.GetSubnets(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return "", fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+	}
+
+	for _, s := range list {
+		if s.CIDR == nil {
+			continue
+		}
+
+		if p, err := netip.ParsePrefix(*s.CIDR); err == nil && p.Masked() == prefix.Masked() {
+			return s.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("subnet %s of VNet %s not found after creation", cidr, client.VnetID)
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state model
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readModel, found := r.read(ctx, state.Vnet.ValueString(), state.ID.ValueString(), &resp.Diagnostics)
+	if !found {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+}
+
+func (r *Resource) read(ctx context.Context, vnet string, id string, diags *diag.Diagnostics) (*model, bool) {
+	data, err := r.client.SDNSubnets(vnet).GetSubnet(ctx, id)
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			return nil, false
+		}
+
+		diags.AddError("Unable to Read SDN Subnet", err.Error())
+
+		return nil, true
+	}
+
+	readModel := &model{}
+	readModel.importFromAPI(vnet, data)
+
+	isPending, deleted := pending.State(
+		ctx, r.sdnClient, fmt.Sprintf("vnets/%s/subnets/%s", url.PathEscape(vnet), url.PathEscape(id)), diags,
+	)
+	readModel.Pending = isPending
+
+	return readModel, !deleted
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state model
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := plan.toAPIRequestBody()
+	reqData.Delete = plan.toDelete(&state)
+
+	if err := r.client.SDNSubnets(plan.Vnet.ValueString()).UpdateSubnet(ctx, reqData); err != nil {
+		resp.Diagnostics.AddError("Unable to Update SDN Subnet", err.Error())
+
+		return
+	}
+
+	plan.Pending = types.BoolValue(!pending.Apply(ctx, r.applier, &resp.Diagnostics))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state model
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.SDNSubnets(state.Vnet.ValueString()).DeleteSubnet(ctx, state.ID.ValueString())
+	if err != nil {
+		if !errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.Diagnostics.AddError("Unable to Delete SDN Subnet", err.Error())
+		}
+
+		return
+	}
+
+	pending.Apply(ctx, r.applier, &resp.Diagnostics)
+}
+
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	vnet, id, found := strings.Cut(req.ID, "/")
+	if !found || vnet == "" || id == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: `<vnet>/<subnet id>`. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	readModel, found := r.read(ctx, vnet, id, &resp.Diagnostics)
+	if !found {
+		resp.Diagnostics.AddError(fmt.Sprintf("Subnet %s of VNet %s does not exist", id, vnet), "")
+
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+}
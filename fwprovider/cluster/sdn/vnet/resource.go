@@ -0,0 +1,288 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/pending"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/vnets"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &Resource{}
+	_ resource.ResourceWithImportState = &Resource{}
+)
+
+type model struct {
+	ID        types.String `tfsdk:"id"`
+	Alias     types.String `tfsdk:"alias"`
+	Pending   types.Bool   `tfsdk:"pending"`
+	Tag       types.Int64  `tfsdk:"tag"`
+	VLANAware types.Bool   `tfsdk:"vlan_aware"`
+	Zone      types.String `tfsdk:"zone"`
+}
+
+func (m *model) importFromAPI(data *vnets.VnetData) {
+	m.ID = types.StringValue(data.ID)
+	m.Alias = types.StringPointerValue(data.Alias)
+	m.Tag = types.Int64PointerValue(data.Tag)
+	m.Zone = types.StringPointerValue(data.Zone)
+
+	// PVE omits the default value, so it is normalized to avoid diffs
+	m.VLANAware = types.BoolValue(data.VLANAware != nil && bool(*data.VLANAware))
+}
+
+func (m *model) toAPIRequestBody() *vnets.VnetRequestData {
+	data := &vnets.VnetRequestData{}
+
+	data.ID = m.ID.ValueString()
+	data.Alias = m.Alias.ValueStringPointer()
+	data.Tag = m.Tag.ValueInt64Pointer()
+	data.VLANAware = proxmoxtypes.CustomBool(m.VLANAware.ValueBool()).Pointer()
+	data.Zone = m.Zone.ValueStringPointer()
+
+	return data
+}
+
+func (m *model) toDelete(state *model) []string {
+	var toDelete []string
+
+	checkDelete(m.Alias, state.Alias, &toDelete, "alias")
+	checkDelete(m.Tag, state.Tag, &toDelete, "tag")
+
+	return toDelete
+}
+
+func checkDelete(planField, stateField attr.Value, toDelete *[]string, apiName string) {
+	// the attribute must be removed via the API if it is set in the state,
+	// but has been removed from the resource to use the PVE default
+	if planField.IsNull() && !stateField.IsNull() {
+		*toDelete = append(*toDelete, apiName)
+	}
+}
+
+// Resource manages an SDN VNet.
+type Resource struct {
+	client    *vnets.Client
+	sdnClient *sdn.Client
+	applier   *sdn.Applier
+}
+
+// NewResource creates a new SDN VNet resource.
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_vnet"
+}
+
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster().SDNVnets()
+	r.sdnClient = cfg.Client.Cluster().SDN()
+	r.applier = cfg.SDNApplier
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "VNet in Proxmox SDN.",
+		MarkdownDescription: "VNet in Proxmox SDN. The changes are applied to the nodes automatically, " +
+			"together with the changes of the other SDN resources of the same plan.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the VNet.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-z][a-z0-9]*[a-z0-9]$`),
+						"must be a valid VNet identifier",
+					),
+					stringvalidator.LengthAtMost(8),
+				},
+			},
+			"alias": schema.StringAttribute{
+				Description: "An alias of the VNet.",
+				Optional:    true,
+			},
+			"pending": pending.Attribute(),
+			"tag": schema.Int64Attribute{
+				Description: "The VLAN or VXLAN ID of the VNet, required by VLAN, QinQ and VXLAN zones.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.Between(1, 16777215)},
+			},
+			"vlan_aware": schema.BoolAttribute{
+				Description: "Whether to allow VLANs to pass through the VNet.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"zone": schema.StringAttribute{
+				Description: "The ID of the zone of the VNet.",
+				Required:    true,
+			},
+		},
+	}
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan model
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.CreateVnet(ctx, plan.toAPIRequestBody()); err != nil {
+		resp.Diagnostics.AddError("Unable to Create SDN VNet", err.Error())
+
+		return
+	}
+
+	plan.Pending = types.BoolValue(!pending.Apply(ctx, r.applier, &resp.Diagnostics))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state model
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readModel, found := r.read(ctx, state.ID.ValueString(), &resp.Diagnostics)
+	if !found {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+}
+
+func (r *Resource) read(ctx context.Context, id string, diags *diag.Diagnostics) (*model, bool) {
+	data, err := r.client.GetVnet(ctx, id)
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			return nil, false
+		}
+
+		diags.AddError("Unable to Read SDN VNet", err.Error())
+
+		return nil, true
+	}
+
+	readModel := &model{}
+	readModel.importFromAPI(data)
+
+	isPending, deleted := pending.State(ctx, r.sdnClient, "vnets/"+url.PathEscape(id), diags)
+	readModel.Pending = isPending
+
+	return readModel, !deleted
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state model
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := plan.toAPIRequestBody()
+	reqData.Delete = plan.toDelete(&state)
+
+	if err := r.client.UpdateVnet(ctx, reqData); err != nil {
+		resp.Diagnostics.AddError("Unable to Update SDN VNet", err.Error())
+
+		return
+	}
+
+	plan.Pending = types.BoolValue(!pending.Apply(ctx, r.applier, &resp.Diagnostics))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state model
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteVnet(ctx, state.ID.ValueString()); err != nil {
+		if !errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.Diagnostics.AddError("Unable to Delete SDN VNet", err.Error())
+		}
+
+		return
+	}
+
+	pending.Apply(ctx, r.applier, &resp.Diagnostics)
+}
+
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	readModel, found := r.read(ctx, req.ID, &resp.Diagnostics)
+	if !found {
+		resp.Diagnostics.AddError(fmt.Sprintf("VNet %s does not exist", req.ID), "")
+
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+}
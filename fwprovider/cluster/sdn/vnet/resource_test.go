@@ -0,0 +1,142 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vnet_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceSDNVnetAndSubnet(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	tests := []struct {
+		name  string
+		steps []resource.TestStep
+	}{
+		{"create and update vnet and subnet", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_zone_simple" "acc_zone" {
+					id    = "acczone"
+					nodes = ["{{.NodeName}}"]
+				}
+
+				resource "proxmox_virtual_environment_sdn_vnet" "acc_vnet" {
+					id    = "accvnet"
+					zone  = proxmox_virtual_environment_sdn_zone_simple.acc_zone.id
+					alias = "acceptance"
+				}
+
+				resource "proxmox_virtual_environment_sdn_subnet" "acc_subnet" {
+					vnet    = proxmox_virtual_environment_sdn_vnet.acc_vnet.id
+					cidr    = "10.99.0.0/24"
+					gateway = "10.99.0.1"
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_zone_simple.acc_zone", map[string]string{
+						"pending": "false",
+					}),
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_vnet.acc_vnet", map[string]string{
+						"alias":      "acceptance",
+						"vlan_aware": "false",
+						"pending":    "false",
+					}),
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_subnet.acc_subnet", map[string]string{
+						"id":      "acczone-10.99.0.0-24",
+						"gateway": "10.99.0.1",
+						"snat":    "false",
+						"pending": "false",
+					}),
+				),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_zone_simple" "acc_zone" {
+					id    = "acczone"
+					nodes = ["{{.NodeName}}"]
+				}
+
+				resource "proxmox_virtual_environment_sdn_vnet" "acc_vnet" {
+					id    = "accvnet"
+					zone  = proxmox_virtual_environment_sdn_zone_simple.acc_zone.id
+					alias = "acceptance"
+				}
+
+				resource "proxmox_virtual_environment_sdn_subnet" "acc_subnet" {
+					vnet    = proxmox_virtual_environment_sdn_vnet.acc_vnet.id
+					cidr    = "10.99.0.0/24"
+					gateway = "10.99.0.1"
+				}`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_zone_simple" "acc_zone" {
+					id    = "acczone"
+					nodes = ["{{.NodeName}}"]
+				}
+
+				resource "proxmox_virtual_environment_sdn_vnet" "acc_vnet" {
+					id         = "accvnet"
+					zone       = proxmox_virtual_environment_sdn_zone_simple.acc_zone.id
+					vlan_aware = true
+				}
+
+				resource "proxmox_virtual_environment_sdn_subnet" "acc_subnet" {
+					vnet = proxmox_virtual_environment_sdn_vnet.acc_vnet.id
+					cidr = "10.99.0.0/24"
+					snat = true
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_vnet.acc_vnet", map[string]string{
+						"vlan_aware": "true",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_sdn_vnet.acc_vnet", []string{
+						"alias",
+					}),
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_subnet.acc_subnet", map[string]string{
+						"snat": "true",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_sdn_subnet.acc_subnet", []string{
+						"gateway",
+					}),
+				),
+			},
+			{
+				ResourceName:      "proxmox_virtual_environment_sdn_vnet.acc_vnet",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "proxmox_virtual_environment_sdn_subnet.acc_subnet",
+				ImportState:       true,
+				ImportStateId:     "accvnet/acczone-10.99.0.0-24",
+				ImportStateVerify: true,
+			},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: te.AccProviders,
+				Steps:                    tt.steps,
+			})
+		})
+	}
+}
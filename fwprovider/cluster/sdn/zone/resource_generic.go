@@ -11,11 +11,14 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"net/url"
 	"regexp"
 
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/pending"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/types/stringset"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/zones"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -36,6 +39,7 @@ type genericModel struct {
 	DNSZone    types.String    `tfsdk:"dns_zone"`
 	Nodes      stringset.Value `tfsdk:"nodes"`
 	MTU        types.Int64     `tfsdk:"mtu"`
+	Pending    types.Bool      `tfsdk:"pending"`
 }
 
 func (m *genericModel) importFromAPI(name string, data *zones.ZoneData, diags *diag.Diagnostics) {
@@ -68,6 +72,10 @@ func (m *genericModel) getID() string {
 	return m.ID.ValueString()
 }
 
+func (m *genericModel) setPending(pending types.Bool) {
+	m.Pending = pending
+}
+
 func genericAttributesWith(extraAttributes map[string]schema.Attribute) map[string]schema.Attribute {
 	// Start with generic attributes as the base
 	result := map[string]schema.Attribute{
@@ -104,7 +112,8 @@ func genericAttributesWith(extraAttributes map[string]schema.Attribute) map[stri
 			Optional:    true,
 			Description: "MTU value for the zone.",
 		},
-		"nodes": stringset.ResourceAttribute("The Proxmox nodes which the zone and associated VNets should be deployed on", "", stringset.WithRequired()),
+		"nodes":   stringset.ResourceAttribute("The Proxmox nodes which the zone and associated VNets should be deployed on", "", stringset.WithRequired()),
+		"pending": pending.Attribute(),
 		"reverse_dns": schema.StringAttribute{
 			Optional:    true,
 			Description: "Reverse DNS API server address.",
@@ -123,6 +132,7 @@ type zoneModel interface {
 	importFromAPI(name string, data *zones.ZoneData, diags *diag.Diagnostics)
 	toAPIRequestBody(ctx context.Context, diags *diag.Diagnostics) *zones.ZoneRequestData
 	getID() string
+	setPending(pending types.Bool)
 }
 
 type zoneResourceConfig struct {
@@ -132,8 +142,10 @@ type zoneResourceConfig struct {
 }
 
 type genericZoneResource struct {
-	client *zones.Client
-	config zoneResourceConfig
+	client    *zones.Client
+	sdnClient *sdn.Client
+	applier   *sdn.Applier
+	config    zoneResourceConfig
 }
 
 func newGenericZoneResource(cfg zoneResourceConfig) resource.Resource {
@@ -167,6 +179,8 @@ func (r *genericZoneResource) Configure(
 	}
 
 	r.client = cfg.Client.Cluster().SDNZones()
+	r.sdnClient = cfg.Client.Cluster().SDN()
+	r.applier = cfg.SDNApplier
 }
 
 func (r *genericZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -192,6 +206,7 @@ func (r *genericZoneResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	plan.setPending(types.BoolValue(!pending.Apply(ctx, r.applier, &resp.Diagnostics)))
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
@@ -221,7 +236,18 @@ func (r *genericZoneResource) Read(ctx context.Context, req resource.ReadRequest
 	readModel := r.config.modelFunc()
 	diags := &diag.Diagnostics{}
 	readModel.importFromAPI(zone.ID, zone, diags)
+
+	isPending, deleted := pending.State(ctx, r.sdnClient, "zones/"+url.PathEscape(zone.ID), diags)
+	readModel.setPending(isPending)
+
 	resp.Diagnostics.Append(*diags...)
+
+	if deleted {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
 }
 
@@ -246,6 +272,7 @@ func (r *genericZoneResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	plan.setPending(types.BoolValue(!pending.Apply(ctx, r.applier, &resp.Diagnostics)))
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
@@ -257,13 +284,18 @@ func (r *genericZoneResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	if err := r.client.DeleteZone(ctx, state.getID()); err != nil &&
-		!errors.Is(err, api.ErrResourceDoesNotExist) {
-		resp.Diagnostics.AddError(
-			"Unable to Delete SDN Zone",
-			err.Error(),
-		)
+	if err := r.client.DeleteZone(ctx, state.getID()); err != nil {
+		if !errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.Diagnostics.AddError(
+				"Unable to Delete SDN Zone",
+				err.Error(),
+			)
+		}
+
+		return
 	}
+
+	pending.Apply(ctx, r.applier, &resp.Diagnostics)
 }
 
 func (r *genericZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -282,6 +314,8 @@ func (r *genericZoneResource) ImportState(ctx context.Context, req resource.Impo
 	readModel := r.config.modelFunc()
 	diags := &diag.Diagnostics{}
 	readModel.importFromAPI(zone.ID, zone, diags)
+	isPending, _ := pending.State(ctx, r.sdnClient, "zones/"+url.PathEscape(zone.ID), diags)
+	readModel.setPending(isPending)
 	resp.Diagnostics.Append(*diags...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
 }
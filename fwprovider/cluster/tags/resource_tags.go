@@ -0,0 +1,334 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package tags
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/types/stringset"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+)
+
+var (
+	_ resource.Resource                = &tagsResource{}
+	_ resource.ResourceWithConfigure   = &tagsResource{}
+	_ resource.ResourceWithImportState = &tagsResource{}
+)
+
+type tagsModel struct {
+	ID             types.String    `tfsdk:"id"`
+	ColorMap       types.Map       `tfsdk:"color_map"`
+	RegisteredTags stringset.Value `tfsdk:"registered_tags"`
+}
+
+// importFromOptionsAPI sets the managed attributes of the model from the cluster options. An attribute is
+// managed if it is set in the model, or if all is set, e.g. on import.
+func (m *tagsModel) importFromOptionsAPI(
+	ctx context.Context,
+	opts *cluster.OptionsResponseData,
+	all bool,
+	diags *diag.Diagnostics,
+) {
+	if all || !m.RegisteredTags.IsNull() {
+		if opts.RegisteredTags != nil && len(*opts.RegisteredTags) > 0 {
+			m.RegisteredTags = stringset.NewValueList(*opts.RegisteredTags, diags)
+		} else {
+			m.RegisteredTags = stringset.Value{SetValue: types.SetNull(types.StringType)}
+		}
+	}
+
+	if all || !m.ColorMap.IsNull() {
+		m.ColorMap = types.MapNull(types.StringType)
+
+		if opts.TagStyle != nil && opts.TagStyle.ColorMap != nil && *opts.TagStyle.ColorMap != "" {
+			var d diag.Diagnostics
+
+			m.ColorMap, d = types.MapValueFrom(ctx, types.StringType, cluster.ParseTagColorMap(*opts.TagStyle.ColorMap))
+			diags.Append(d...)
+		}
+	}
+}
+
+// NewTagsResource creates a new resource for managing the cluster tag registry.
+func NewTagsResource() resource.Resource {
+	return &tagsResource{}
+}
+
+type tagsResource struct {
+	client proxmox.Client
+}
+
+func (r *tagsResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_tags"
+}
+
+// Schema defines the schema for the resource.
+func (r *tagsResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the registered tags and the tag colors of a Proxmox VE cluster.",
+		MarkdownDescription: "Manages the registered tags and the tag colors of a Proxmox VE cluster. " +
+			"The other tag style settings, as well as the attributes not set in the resource, are left untouched. " +
+			"Do not manage the same settings with the `proxmox_virtual_environment_cluster_options` resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ResourceID(),
+			"color_map": schema.MapAttribute{
+				Description: "Map of tag names to their colors.",
+				MarkdownDescription: "Map of tag names to their colors. The color is the hex RGB " +
+					"background color, optionally followed by `:` and the hex RGB text color, " +
+					"e.g. `FF0000` or `FF0000:FFFFFF`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.KeysAre(validators.TagNameValidator()),
+					mapvalidator.ValueStringsAre(validators.TagColorValidator()),
+				},
+			},
+			"registered_tags": registeredTagsAttribute(),
+		},
+	}
+}
+
+func registeredTagsAttribute() schema.SetAttribute {
+	attr := stringset.ResourceAttribute("Tags that require `Sys.Modify` on `/` to set and delete, "+
+		"they are always shown in the tag selection.", "")
+	attr.Computed = false
+	attr.Validators = append(
+		attr.Validators,
+		setvalidator.SizeAtLeast(1),
+		setvalidator.ValueStringsAre(validators.TagNameValidator()),
+	)
+
+	return attr
+}
+
+func (r *tagsResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// apply updates the cluster options to match the plan. The attributes set in the state, but not in the plan,
+// are removed. The other tag style settings are passed through.
+func (r *tagsResource) apply(ctx context.Context, plan, state *tagsModel, diags *diag.Diagnostics) {
+	current, err := r.client.Cluster().GetOptions(ctx)
+	if err != nil {
+		diags.AddError("Error reading cluster options", "Could not read cluster options: "+err.Error())
+		return
+	}
+
+	body := &cluster.OptionsRequestData{}
+
+	var toDelete []string
+
+	if registered := plan.RegisteredTags.ValueList(ctx, diags); len(registered) > 0 {
+		body.RegisteredTags = plan.RegisteredTags.ValueStringPointer(ctx, diags, stringset.WithSeparator(";"))
+	} else if len(state.RegisteredTags.ValueList(ctx, diags)) > 0 {
+		toDelete = append(toDelete, "registered-tags")
+	}
+
+	if !plan.ColorMap.IsNull() || !state.ColorMap.IsNull() {
+		tagStyle := cluster.TagStyle{}
+		if current.TagStyle != nil {
+			tagStyle = *current.TagStyle
+		}
+
+		tagStyle.ColorMap = nil
+
+		if !plan.ColorMap.IsNull() {
+			colors := map[string]string{}
+			diags.Append(plan.ColorMap.ElementsAs(ctx, &colors, false)...)
+
+			colorMap := cluster.FormatTagColorMap(colors)
+			tagStyle.ColorMap = &colorMap
+		}
+
+		if s := tagStyle.String(); s != "" {
+			body.TagStyle = &s
+		} else if current.TagStyle != nil {
+			toDelete = append(toDelete, "tag-style")
+		}
+	}
+
+	if diags.HasError() {
+		return
+	}
+
+	if len(toDelete) > 0 {
+		d := strings.Join(toDelete, ",")
+		body.Delete = &d
+	}
+
+	if body.RegisteredTags == nil && body.TagStyle == nil && body.Delete == nil {
+		return
+	}
+
+	err = r.client.Cluster().CreateUpdateOptions(ctx, body)
+	if err != nil {
+		diags.AddError("Error updating cluster tags", "Could not update cluster tags: "+err.Error())
+	}
+}
+
+func (r *tagsResource) read(ctx context.Context, model *tagsModel, all bool, diags *diag.Diagnostics) {
+	opts, err := r.client.Cluster().GetOptions(ctx)
+	if err != nil {
+		diags.AddError("Error reading cluster options", "Could not read cluster options: "+err.Error())
+		return
+	}
+
+	model.importFromOptionsAPI(ctx, opts, all, diags)
+}
+
+// Create sets the cluster tags.
+func (r *tagsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan tagsModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := tagsModel{
+		ColorMap:       types.MapNull(types.StringType),
+		RegisteredTags: stringset.Value{SetValue: types.SetNull(types.StringType)},
+	}
+
+	r.apply(ctx, &plan, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue("cluster")
+
+	r.read(ctx, &plan, false, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read reads the cluster tags.
+func (r *tagsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state tagsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, false, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update updates the cluster tags.
+func (r *tagsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state tagsModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &plan, false, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the registered tags and the tag colors managed by the resource.
+func (r *tagsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state tagsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan := tagsModel{
+		ColorMap:       types.MapNull(types.StringType),
+		RegisteredTags: stringset.Value{SetValue: types.SetNull(types.StringType)},
+	}
+
+	r.apply(ctx, &plan, &state, &resp.Diagnostics)
+}
+
+// ImportState imports the cluster tags.
+func (r *tagsResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	state := tagsModel{ID: types.StringValue(req.ID)}
+
+	r.read(ctx, &state, true, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
@@ -0,0 +1,61 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package tags_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceTags(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_tags" "test" {
+					registered_tags = ["acc-prod", "acc-dev"]
+					color_map = {
+						acc-prod = "FF0000:FFFFFF"
+					}
+				}`),
+				Check: test.ResourceAttributes("proxmox_virtual_environment_tags.test", map[string]string{
+					"registered_tags.#":  "2",
+					"color_map.%":        "1",
+					"color_map.acc-prod": "FF0000:FFFFFF",
+				}),
+			},
+			{
+				ResourceName:      "proxmox_virtual_environment_tags.test",
+				ImportState:       true,
+				ImportStateId:     "cluster",
+				ImportStateVerify: true,
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_tags" "test" {
+					registered_tags = ["acc-prod"]
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_tags.test", map[string]string{
+						"registered_tags.#": "1",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_tags.test", []string{
+						"color_map",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,147 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vmid
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+)
+
+var (
+	_ datasource.DataSource              = &DataSource{}
+	_ datasource.DataSourceWithConfigure = &DataSource{}
+)
+
+type model struct {
+	ID   types.String `tfsdk:"id"`
+	Max  types.Int64  `tfsdk:"max"`
+	Min  types.Int64  `tfsdk:"min"`
+	VMID types.Int64  `tfsdk:"vm_id"`
+}
+
+// DataSource is the data source implementation for a free VM identifier.
+type DataSource struct {
+	client *cluster.Client
+}
+
+// NewDataSource creates a new free VM identifier data source.
+func NewDataSource() datasource.DataSource {
+	return &DataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *DataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vmid"
+}
+
+// Schema defines the schema for the data source.
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves a free VM identifier within a range.",
+		MarkdownDescription: "Retrieves a free VM identifier within a range. The identifier is picked at a random " +
+			"offset of the range, so concurrent Terraform runs are unlikely to get the same one, and it's confirmed " +
+			"to be free by the cluster.\n\n" +
+			"~> The data source is read on every plan, and returns a different identifier once the previous one " +
+			"is in use. Add `vm_id` to `ignore_changes` of the VM or container using the identifier, " +
+			"to prevent it from being replaced.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier attribute.",
+				Computed:    true,
+			},
+			"max": schema.Int64Attribute{
+				Description: fmt.Sprintf("The highest identifier to return, defaults to `%d`.", cluster.MaxVMID),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(cluster.MinVMID, cluster.MaxVMID),
+				},
+			},
+			"min": schema.Int64Attribute{
+				Description: fmt.Sprintf("The lowest identifier to return, defaults to `%d`.", cluster.MinVMID),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(cluster.MinVMID, cluster.MaxVMID),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				Description: "The free VM identifier.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client.Cluster()
+}
+
+// Read finds a free VM identifier.
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state model
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lower := cluster.MinVMID
+	if !state.Min.IsNull() {
+		lower = int(state.Min.ValueInt64())
+	}
+
+	upper := cluster.MaxVMID
+	if !state.Max.IsNull() {
+		upper = int(state.Max.ValueInt64())
+	}
+
+	if lower > upper {
+		resp.Diagnostics.AddError(
+			"Invalid VM Identifier Range",
+			fmt.Sprintf("`min` (%d) must not be greater than `max` (%d).", lower, upper),
+		)
+
+		return
+	}
+
+	id, err := d.client.FindFreeID(ctx, lower, upper)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Find a Free VM Identifier", err.Error())
+
+		return
+	}
+
+	state.ID = types.StringValue(strconv.Itoa(id))
+	state.VMID = types.Int64Value(int64(id))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
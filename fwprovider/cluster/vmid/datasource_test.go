@@ -0,0 +1,60 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vmid_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccDatasourceVMID(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitEnvironment(t)
+
+	tests := []struct {
+		name  string
+		steps []resource.TestStep
+	}{
+		{"find a free id in range", []resource.TestStep{{
+			Config: te.RenderConfig(`data "proxmox_virtual_environment_vmid" "test" {
+				min = 90000
+				max = 90009
+			}`),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestMatchResourceAttr("data.proxmox_virtual_environment_vmid.test", "vm_id",
+					regexp.MustCompile(`^9000\d$`)),
+				resource.TestCheckResourceAttrPair(
+					"data.proxmox_virtual_environment_vmid.test", "id",
+					"data.proxmox_virtual_environment_vmid.test", "vm_id",
+				),
+			),
+		}}},
+		{"invalid range", []resource.TestStep{{
+			Config: te.RenderConfig(`data "proxmox_virtual_environment_vmid" "test" {
+				min = 90009
+				max = 90000
+			}`),
+			ExpectError: regexp.MustCompile(`must not be greater than`),
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: te.AccProviders,
+				Steps:                    tt.steps,
+			})
+		})
+	}
+}
@@ -9,11 +9,14 @@ package config
 import (
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
 )
 
 // Resource is the global configuration for all resources.
 type Resource struct {
 	Client proxmox.Client
 
-	IDGenerator cluster.IDGenerator
+	IDGenerator  cluster.IDGenerator
+	SDNApplier   *sdn.Applier
+	TagValidator *cluster.TagValidator
 }
@@ -0,0 +1,133 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+)
+
+const (
+	// defaultTimeout is the default time in seconds to wait for the agent to respond and the command to exit.
+	defaultTimeout = 300
+
+	// outputMaxSize limits the size of the output of a command stored in the state.
+	outputMaxSize = 64 * 1024
+)
+
+func nodeNameAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "The name of the node of the VM.",
+		Required:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+func vmIDAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Description: "The ID of the VM, which must have the QEMU guest agent installed and enabled.",
+		Required:    true,
+		PlanModifiers: []planmodifier.Int64{
+			int64planmodifier.RequiresReplace(),
+		},
+		Validators: []validator.Int64{
+			int64validator.Between(100, 999999999),
+		},
+	}
+}
+
+func timeoutAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Description: fmt.Sprintf("The time in seconds to wait for the agent to respond, e.g. while the VM "+
+			"is booting, and for the command to exit (defaults to `%d`).", defaultTimeout),
+		Optional: true,
+		Computed: true,
+		Default:  int64default.StaticInt64(defaultTimeout),
+		Validators: []validator.Int64{
+			int64validator.AtLeast(1),
+		},
+	}
+}
+
+func triggersAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		Description: "Arbitrary values which cause the resource to be replaced when they change.",
+		ElementType: types.StringType,
+		Optional:    true,
+		PlanModifiers: []planmodifier.Map{
+			mapplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// execCommand runs a command in the guest once the agent responds, and waits for it to exit.
+// It returns the PID of the command along with its status.
+func execCommand(
+	ctx context.Context,
+	vmAPI *vms.Client,
+	command []string,
+	inputData *string,
+) (int, *vms.AgentExecStatusResponseData, error) {
+	err := vmAPI.WaitForAgent(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	pid, err := vmAPI.AgentExec(ctx, &vms.AgentExecRequestBody{
+		Command:   command,
+		InputData: inputData,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	status, err := vmAPI.WaitForAgentExec(ctx, pid)
+
+	return pid, status, err
+}
+
+// exitCode returns the exit code of the command, or an error if it was terminated by a signal.
+func exitCode(status *vms.AgentExecStatusResponseData) (int, error) {
+	if status.ExitCode == nil {
+		signal := "unknown"
+		if status.Signal != nil {
+			signal = fmt.Sprintf("%d", *status.Signal)
+		}
+
+		return 0, fmt.Errorf("the command was terminated by signal %s", signal)
+	}
+
+	return *status.ExitCode, nil
+}
+
+// truncateOutput caps the output of a command, so that a chatty command does not bloat the state.
+func truncateOutput(output *string) string {
+	s := ptr.Or(output, "")
+
+	if len(s) > outputMaxSize {
+		// the cut may split a multibyte character
+		s = strings.ToValidUTF8(s[:outputMaxSize], "")
+	}
+
+	return s
+}
@@ -0,0 +1,112 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package agent
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+)
+
+func Test_exitCode(t *testing.T) {
+	t.Parallel()
+
+	code, err := exitCode(&vms.AgentExecStatusResponseData{ExitCode: ptr.Ptr(3)})
+	require.NoError(t, err)
+	assert.Equal(t, 3, code)
+
+	_, err = exitCode(&vms.AgentExecStatusResponseData{Signal: ptr.Ptr(9)})
+	require.ErrorContains(t, err, "signal 9")
+}
+
+func Test_truncateOutput(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, truncateOutput(nil))
+	assert.Equal(t, "ok\n", truncateOutput(ptr.Ptr("ok\n")))
+
+	// the cut splits the last "é"
+	long := strings.Repeat("a", outputMaxSize-1) + "é"
+	assert.Equal(t, strings.Repeat("a", outputMaxSize-1), truncateOutput(&long))
+}
+
+func Test_fileContent(t *testing.T) {
+	t.Parallel()
+
+	binary := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff}
+
+	tests := []struct {
+		name    string
+		model   fileResourceModel
+		want    []byte
+		wantErr string
+	}{
+		{
+			name:  "text",
+			model: fileResourceModel{Content: types.StringValue("héllo\n"), ContentBase64: types.StringNull()},
+			want:  []byte("héllo\n"),
+		},
+		{
+			name: "base64",
+			model: fileResourceModel{
+				Content:       types.StringNull(),
+				ContentBase64: types.StringValue(base64.StdEncoding.EncodeToString(binary)),
+			},
+			want: binary,
+		},
+		{
+			name:    "NUL in text",
+			model:   fileResourceModel{Content: types.StringValue("a\x00b"), ContentBase64: types.StringNull()},
+			wantErr: "content_base64",
+		},
+		{
+			name:    "invalid base64",
+			model:   fileResourceModel{Content: types.StringNull(), ContentBase64: types.StringValue("not base64!")},
+			wantErr: "not valid base64",
+		},
+		{
+			name: "too large",
+			model: fileResourceModel{
+				Content:       types.StringValue(strings.Repeat("a", fileContentMaxSize+1)),
+				ContentBase64: types.StringNull(),
+			},
+			wantErr: "at most",
+		},
+		{
+			name: "largest",
+			model: fileResourceModel{
+				Content:       types.StringValue(strings.Repeat("a", fileContentMaxSize)),
+				ContentBase64: types.StringNull(),
+			},
+			want: []byte(strings.Repeat("a", fileContentMaxSize)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := fileContent(tt.model)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(base64.StdEncoding.EncodeToString(got)), 60*1024)
+		})
+	}
+}
@@ -0,0 +1,265 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+)
+
+var (
+	_ resource.Resource              = &execResource{}
+	_ resource.ResourceWithConfigure = &execResource{}
+)
+
+type execResourceModel struct {
+	ID                types.String   `tfsdk:"id"`
+	NodeName          types.String   `tfsdk:"node_name"`
+	VMID              types.Int64    `tfsdk:"vm_id"`
+	Command           []types.String `tfsdk:"command"`
+	InputData         types.String   `tfsdk:"input_data"`
+	ExpectedExitCodes types.Set      `tfsdk:"expected_exit_codes"`
+	Timeout           types.Int64    `tfsdk:"timeout"`
+	Triggers          types.Map      `tfsdk:"triggers"`
+	ExitCode          types.Int64    `tfsdk:"exit_code"`
+	Stderr            types.String   `tfsdk:"stderr"`
+	Stdout            types.String   `tfsdk:"stdout"`
+}
+
+// NewExecResource creates a new resource for executing a command in a VM using the QEMU guest agent.
+func NewExecResource() resource.Resource {
+	return &execResource{}
+}
+
+type execResource struct {
+	client proxmox.Client
+}
+
+func (r *execResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_vm_agent_exec"
+}
+
+// Schema defines the schema for the resource.
+func (r *execResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Executes a command in a VM using the QEMU guest agent.",
+		MarkdownDescription: "Executes a command in a VM using the QEMU guest agent, e.g. to configure the guest " +
+			"after it boots without an SSH connection to it. The command is executed when the resource is created, " +
+			"and executed again only when the resource is replaced, e.g. when one of the `triggers` changes.",
+		Attributes: map[string]schema.Attribute{
+			"id":        attribute.ResourceID(),
+			"node_name": nodeNameAttribute(),
+			"vm_id":     vmIDAttribute(),
+			"command": schema.ListAttribute{
+				Description: "The command to execute and its arguments, e.g. `[\"systemctl\", \"restart\", \"nginx\"]`.",
+				MarkdownDescription: "The command to execute and its arguments, e.g. " +
+					"`[\"systemctl\", \"restart\", \"nginx\"]`. The command is not run by a shell, so use e.g. " +
+					"`[\"/bin/sh\", \"-c\", \"...\"]` for the shell features.",
+				ElementType: types.StringType,
+				Required:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"input_data": schema.StringAttribute{
+				Description: "The data to pass to the standard input of the command.",
+				Optional:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expected_exit_codes": schema.SetAttribute{
+				Description: "The exit codes of a successful execution (defaults to `[0]`). " +
+					"Any other exit code fails the creation of the resource.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Computed:    true,
+				Default:     setdefault.StaticValue(types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(0)})),
+			},
+			"timeout":  timeoutAttribute(),
+			"triggers": triggersAttribute(),
+			"exit_code": schema.Int64Attribute{
+				Description: "The exit code of the command.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"stderr": schema.StringAttribute{
+				Description: fmt.Sprintf("The standard error of the command, truncated to %d KiB.", outputMaxSize/1024),
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"stdout": schema.StringAttribute{
+				Description: fmt.Sprintf("The standard output of the command, truncated to %d KiB.", outputMaxSize/1024),
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *execResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// Create executes the command.
+func (r *execResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan execResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var expectedExitCodes []int64
+
+	resp.Diagnostics.Append(plan.ExpectedExitCodes.ElementsAs(ctx, &expectedExitCodes, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	command := make([]string, 0, len(plan.Command))
+	for _, arg := range plan.Command {
+		command = append(command, arg.ValueString())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(plan.Timeout.ValueInt64())*time.Second)
+	defer cancel()
+
+	vmID := int(plan.VMID.ValueInt64())
+
+	pid, status, err := execCommand(ctx, r.client.Node(plan.NodeName.ValueString()).VM(vmID), command, plan.InputData.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error executing command",
+			fmt.Sprintf("Could not execute %q in VM %d: %s", strings.Join(command, " "), vmID, err),
+		)
+
+		return
+	}
+
+	code, err := exitCode(status)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error executing command",
+			fmt.Sprintf("Could not execute %q in VM %d: %s", strings.Join(command, " "), vmID, err),
+		)
+
+		return
+	}
+
+	plan.ExitCode = types.Int64Value(int64(code))
+	plan.Stderr = types.StringValue(truncateOutput(status.ErrData))
+	plan.Stdout = types.StringValue(truncateOutput(status.OutData))
+
+	if !slices.Contains(expectedExitCodes, int64(code)) {
+		resp.Diagnostics.AddError(
+			"Unexpected exit code",
+			fmt.Sprintf("The command %q in VM %d exited with code %d.\n\nstdout:\n%s\n\nstderr:\n%s",
+				strings.Join(command, " "), vmID, code, plan.Stdout.ValueString(), plan.Stderr.ValueString()),
+		)
+
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%d/%d", plan.NodeName.ValueString(), vmID, pid))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read keeps the state, as the execution of the command can't be read back.
+func (r *execResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state execResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update only stores the attributes which don't affect the executed command, i.e. `timeout`
+// and `expected_exit_codes`, as the other attributes require the replacement of the resource.
+func (r *execResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state execResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.ExitCode = state.ExitCode
+	plan.Stderr = state.Stderr
+	plan.Stdout = state.Stdout
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the resource from the state only, as the execution of the command can't be undone.
+func (r *execResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
@@ -0,0 +1,330 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package agent
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+)
+
+// fileContentMaxSize is the maximum size of the content of a file, as the agent accepts
+// at most 60 KiB of base64 encoded content.
+const fileContentMaxSize = 60 * 1024 / 4 * 3
+
+var (
+	_ resource.Resource                   = &fileResource{}
+	_ resource.ResourceWithConfigure      = &fileResource{}
+	_ resource.ResourceWithValidateConfig = &fileResource{}
+
+	permissionsRegex = regexp.MustCompile(`^[0-7]{3,4}$`)
+)
+
+type fileResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	NodeName      types.String `tfsdk:"node_name"`
+	VMID          types.Int64  `tfsdk:"vm_id"`
+	Path          types.String `tfsdk:"path"`
+	Content       types.String `tfsdk:"content"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	Permissions   types.String `tfsdk:"permissions"`
+	Timeout       types.Int64  `tfsdk:"timeout"`
+	Triggers      types.Map    `tfsdk:"triggers"`
+}
+
+// NewFileResource creates a new resource for writing a file in a VM using the QEMU guest agent.
+func NewFileResource() resource.Resource {
+	return &fileResource{}
+}
+
+type fileResource struct {
+	client proxmox.Client
+}
+
+func (r *fileResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_vm_agent_file"
+}
+
+// Schema defines the schema for the resource.
+func (r *fileResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Writes a file in a VM using the QEMU guest agent.",
+		MarkdownDescription: "Writes a file in a VM using the QEMU guest agent, e.g. to configure the guest " +
+			"after it boots without an SSH connection to it. The file is written again when its content or " +
+			"permissions change, or one of the `triggers` changes. The file is left in the guest when the " +
+			"resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"id":        attribute.ResourceID(),
+			"node_name": nodeNameAttribute(),
+			"vm_id":     vmIDAttribute(),
+			"path": schema.StringAttribute{
+				Description: "The absolute path of the file in the guest.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "The text content of the file.",
+				MarkdownDescription: fmt.Sprintf("The text content of the file, of at most %d bytes. "+
+					"Use `content_base64` for a binary content.", fileContentMaxSize),
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("content_base64")),
+				},
+			},
+			"content_base64": schema.StringAttribute{
+				Description: "The base64 encoded content of the file, e.g. using `filebase64()`.",
+				MarkdownDescription: fmt.Sprintf("The base64 encoded content of the file, of at most %d bytes "+
+					"once decoded, e.g. using `filebase64()`.", fileContentMaxSize),
+				Optional:  true,
+				Sensitive: true,
+			},
+			"permissions": schema.StringAttribute{
+				Description: "The octal permissions of the file, e.g. `0644`.",
+				MarkdownDescription: "The octal permissions of the file, e.g. `0644`. They are set with `chmod`, " +
+					"so they are only supported by the Unix-like guests.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(permissionsRegex, "must be octal permissions, e.g. `0644`"),
+				},
+			},
+			"timeout":  timeoutAttribute(),
+			"triggers": triggersAttribute(),
+		},
+	}
+}
+
+func (r *fileResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// ValidateConfig rejects the content which can't be written as is, when it is known.
+func (r *fileResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var cfg fileResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &cfg)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if cfg.Content.IsUnknown() || cfg.ContentBase64.IsUnknown() {
+		return
+	}
+
+	_, err := fileContent(cfg)
+	if err != nil {
+		attr := path.Root("content")
+		if !cfg.ContentBase64.IsNull() {
+			attr = path.Root("content_base64")
+		}
+
+		resp.Diagnostics.AddAttributeError(attr, "Invalid file content", err.Error())
+	}
+}
+
+// Create writes the file.
+func (r *fileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan fileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.write(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error writing file",
+			fmt.Sprintf("Could not write %q in VM %d: %s", plan.Path.ValueString(), plan.VMID.ValueInt64(), err),
+		)
+
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%d/%s", plan.NodeName.ValueString(), plan.VMID.ValueInt64(), plan.Path.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read keeps the state, as the agent can't read the file back without a size limit.
+func (r *fileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state fileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update writes the file again.
+func (r *fileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state fileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.write(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error writing file",
+			fmt.Sprintf("Could not write %q in VM %d: %s", plan.Path.ValueString(), plan.VMID.ValueInt64(), err),
+		)
+
+		return
+	}
+
+	plan.ID = state.ID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the resource from the state only, as the agent has no API to delete a file.
+func (r *fileResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// write writes the file once the agent responds, and sets its permissions.
+func (r *fileResource) write(ctx context.Context, model fileResourceModel) error {
+	content, err := fileContent(model)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(model.Timeout.ValueInt64())*time.Second)
+	defer cancel()
+
+	vmAPI := r.client.Node(model.NodeName.ValueString()).VM(int(model.VMID.ValueInt64()))
+
+	err = vmAPI.WaitForAgent(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the content is always sent base64 encoded, so that a binary content survives the form encoding
+	err = vmAPI.AgentFileWrite(ctx, &vms.AgentFileWriteRequestBody{
+		Content: base64.StdEncoding.EncodeToString(content),
+		Encode:  false,
+		File:    model.Path.ValueString(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if model.Permissions.IsNull() {
+		return nil
+	}
+
+	command := []string{"chmod", model.Permissions.ValueString(), model.Path.ValueString()}
+
+	_, status, err := execCommand(ctx, vmAPI, command, nil)
+	if err != nil {
+		return fmt.Errorf("could not set the permissions: %w", err)
+	}
+
+	code, err := exitCode(status)
+	if err != nil {
+		return fmt.Errorf("could not set the permissions: %w", err)
+	}
+
+	if code != 0 {
+		return fmt.Errorf("could not set the permissions: %q exited with code %d: %s",
+			strings.Join(command, " "), code, truncateOutput(status.ErrData))
+	}
+
+	return nil
+}
+
+// fileContent returns the decoded content of the file, or an error if it can't be written by the agent.
+func fileContent(model fileResourceModel) ([]byte, error) {
+	var content []byte
+
+	if !model.ContentBase64.IsNull() {
+		decoded, err := base64.StdEncoding.DecodeString(model.ContentBase64.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("the content is not valid base64: %w", err)
+		}
+
+		content = decoded
+	} else {
+		s := model.Content.ValueString()
+
+		// a text content is expected to be text, as Terraform strings can't hold arbitrary bytes anyway
+		if !utf8.ValidString(s) || strings.ContainsRune(s, 0) {
+			return nil, errors.New("the content is not valid text, use `content_base64` for a binary content")
+		}
+
+		content = []byte(s)
+	}
+
+	if len(content) > fileContentMaxSize {
+		return nil, fmt.Errorf("the content is %d bytes, the agent accepts at most %d bytes", len(content), fileContentMaxSize)
+	}
+
+	return content, nil
+}
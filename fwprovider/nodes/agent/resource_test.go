@@ -0,0 +1,128 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package agent_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceVMAgent(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitEnvironment(t)
+
+	vm := `
+	resource "proxmox_virtual_environment_file" "cloud_config" {
+		content_type = "snippets"
+		datastore_id = "local"
+		node_name    = "{{.NodeName}}"
+		source_raw {
+			data = <<-EOF
+			#cloud-config
+			runcmd:
+			  - apt update
+			  - apt install -y qemu-guest-agent
+			  - systemctl start qemu-guest-agent
+			EOF
+			file_name = "agent-cloud-config.yaml"
+		}
+	}
+
+	resource "proxmox_virtual_environment_download_file" "ubuntu_cloud_image" {
+		content_type        = "iso"
+		datastore_id        = "local"
+		node_name           = "{{.NodeName}}"
+		url                 = "{{.CloudImagesServer}}/jammy/current/jammy-server-cloudimg-amd64.img"
+		overwrite_unmanaged = true
+	}
+
+	resource "proxmox_virtual_environment_vm" "test_vm" {
+		node_name = "{{.NodeName}}"
+		started   = true
+		agent {
+			enabled = true
+		}
+		memory {
+			dedicated = 2048
+		}
+		disk {
+			datastore_id = "local-lvm"
+			file_id      = proxmox_virtual_environment_download_file.ubuntu_cloud_image.id
+			interface    = "virtio0"
+			size         = 20
+		}
+		initialization {
+			ip_config {
+				ipv4 {
+					address = "dhcp"
+				}
+			}
+			user_data_file_id = proxmox_virtual_environment_file.cloud_config.id
+		}
+		network_device {
+			bridge = "vmbr0"
+		}
+	}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(vm + `
+				resource "proxmox_virtual_environment_vm_agent_file" "test" {
+					node_name   = "{{.NodeName}}"
+					vm_id       = proxmox_virtual_environment_vm.test_vm.vm_id
+					path        = "/tmp/terraform.txt"
+					content     = "hello\n"
+					permissions = "0600"
+					timeout     = 900
+				}
+
+				resource "proxmox_virtual_environment_vm_agent_exec" "test" {
+					node_name = "{{.NodeName}}"
+					vm_id     = proxmox_virtual_environment_vm.test_vm.vm_id
+					command   = ["stat", "-c", "%a %s", proxmox_virtual_environment_vm_agent_file.test.path]
+				}
+
+				resource "proxmox_virtual_environment_vm_agent_exec" "exit_code" {
+					node_name           = "{{.NodeName}}"
+					vm_id               = proxmox_virtual_environment_vm.test_vm.vm_id
+					command             = ["/bin/sh", "-c", "cat; exit 3"]
+					input_data          = "from stdin"
+					expected_exit_codes = [3]
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributesSet("proxmox_virtual_environment_vm_agent_file.test", []string{"id"}),
+					test.ResourceAttributes("proxmox_virtual_environment_vm_agent_exec.test", map[string]string{
+						"exit_code": "0",
+						"stdout":    "600 6\n",
+					}),
+					test.ResourceAttributes("proxmox_virtual_environment_vm_agent_exec.exit_code", map[string]string{
+						"exit_code": "3",
+						"stdout":    "from stdin",
+					}),
+				),
+			},
+			{
+				Config: te.RenderConfig(vm + `
+				resource "proxmox_virtual_environment_vm_agent_exec" "test" {
+					node_name = "{{.NodeName}}"
+					vm_id     = proxmox_virtual_environment_vm.test_vm.vm_id
+					command   = ["false"]
+				}`),
+				ExpectError: regexp.MustCompile(`exited with code 1`),
+			},
+		},
+	})
+}
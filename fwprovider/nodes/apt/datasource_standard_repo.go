@@ -104,6 +104,10 @@ func (d *standardRepositoryDataSource) Schema(
 				Computed:    true,
 				Description: "The description of the APT standard repository.",
 			},
+			SchemaAttrNameEnabled: schema.BoolAttribute{
+				Computed:    true,
+				Description: "Indicates the activation status.",
+			},
 			SchemaAttrNameFilePath: schema.StringAttribute{
 				Computed:    true,
 				Description: "The absolute path of the source list file that contains this standard repository.",
@@ -118,6 +118,9 @@ type modelStandardRepo struct {
 	// Description is the description of the APT standard repository.
 	Description types.String `tfsdk:"description"`
 
+	// Enabled indicates the activation status of the APT standard repository.
+	Enabled types.Bool `tfsdk:"enabled"`
+
 	// FilePath is the path of the source list file that contains the APT standard repository.
 	FilePath types.String `tfsdk:"file_path"`
 
@@ -236,6 +239,12 @@ func (srp *modelStandardRepo) importFromAPI(_ context.Context, data *api.GetResp
 
 			srp.Name = types.StringValue(repo.Name)
 			srp.Status = types.Int64PointerValue(repo.Status)
+
+			// The activation status is only available when the standard repository has been configured.
+			srp.Enabled = types.BoolNull()
+			if repo.Status != nil {
+				srp.Enabled = types.BoolValue(*repo.Status == 1)
+			}
 		}
 	}
 
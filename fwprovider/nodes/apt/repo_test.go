@@ -98,6 +98,31 @@ func TestAccDataSourceRepo(t *testing.T) {
 	}
 }
 
+// Run tests for APT standard repository resource definitions with a handle that is not available on the node, which
+// must be rejected during planning.
+func TestAccResourceStandardRepoUnknownHandle(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitEnvironment(t)
+
+	resource.ParallelTest(
+		t, resource.TestCase{
+			ProtoV6ProviderFactories: te.AccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: te.RenderConfig(`
+					resource "proxmox_virtual_environment_apt_standard_repository" "test" {
+						handle = "ceph-argonaut-enterprise"
+						node   = "{{.NodeName}}"
+					}`),
+					ExpectError: regexp.MustCompile(`Unknown APT standard repository handle`),
+					PlanOnly:    true,
+				},
+			},
+		},
+	)
+}
+
 func TestAccDataSourceStandardRepo(t *testing.T) {
 	t.Helper()
 	t.Parallel()
@@ -289,8 +314,9 @@ func TestAccResourceRepoValidInput(t *testing.T) {
 // specified.
 // Only the [Create] and [Read] method implementations of the
 // [github.com/hashicorp/terraform-plugin-framework/resource.Resource] interface are tested in sequential steps because
-// [Delete] and [Update] are no-op due to the non-existing capability of the Proxmox VE API of deleting or updating a
-// configured APT standard repository.
+// [Delete] is no-op due to the non-existing capability of the Proxmox VE API of deleting a configured APT standard
+// repository. The [Update] implementation is tested against the fake Proxmox VE API server in
+// [TestAccResourceStandardRepoFakeUpdate].
 //
 // [Create]: https://developer.hashicorp.com/terraform/plugin/framework/resources/create
 // [Delete]: https://developer.hashicorp.com/terraform/plugin/framework/resources/delete
@@ -321,6 +347,7 @@ func TestAccResourceStandardRepoValidInput(t *testing.T) {
 					// The provided attributes and computed attributes should be set.
 					Check: resource.ComposeTestCheckFunc(
 						test.ResourceAttributes("proxmox_virtual_environment_apt_standard_repository.test", map[string]string{
+							"enabled":   "true",
 							"file_path": "/etc/apt/sources.list",
 							"handle":    "no-subscription",
 							"node":      te.NodeName,
@@ -351,3 +378,61 @@ func TestAccResourceStandardRepoValidInput(t *testing.T) {
 		},
 	)
 }
+
+// Run tests for the [Update] implementation of the APT standard repository resource, which toggles the activation
+// status, against the fake Proxmox VE API server since a real node can not be restored to its initial sources.
+//
+// [Update]: https://developer.hashicorp.com/terraform/plugin/framework/resources/update
+func TestAccResourceStandardRepoFakeUpdate(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitEnvironment(t)
+	if te.Fake == nil {
+		t.Skip("requires the fake Proxmox VE API server, set TF_ACC_FAKE=1")
+	}
+
+	resource.Test(
+		t, resource.TestCase{
+			ProtoV6ProviderFactories: te.AccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: te.RenderConfig(`
+					resource "proxmox_virtual_environment_apt_standard_repository" "test" {
+						handle = "no-subscription"
+						node   = "{{.NodeName}}"
+					}`),
+					Check: test.ResourceAttributes(testAccResourceStandardRepoSelector, map[string]string{
+						"enabled":   "true",
+						"file_path": "/etc/apt/sources.list",
+						"index":     "0",
+						"status":    "1",
+					}),
+				},
+				{
+					Config: te.RenderConfig(`
+					resource "proxmox_virtual_environment_apt_standard_repository" "test" {
+						enabled = false
+						handle  = "no-subscription"
+						node    = "{{.NodeName}}"
+					}`),
+					Check: test.ResourceAttributes(testAccResourceStandardRepoSelector, map[string]string{
+						"enabled": "false",
+						"status":  "0",
+					}),
+				},
+				{
+					Config: te.RenderConfig(`
+					resource "proxmox_virtual_environment_apt_standard_repository" "test" {
+						enabled = true
+						handle  = "no-subscription"
+						node    = "{{.NodeName}}"
+					}`),
+					Check: test.ResourceAttributes(testAccResourceStandardRepoSelector, map[string]string{
+						"enabled": "true",
+						"status":  "1",
+					}),
+				},
+			},
+		},
+	)
+}
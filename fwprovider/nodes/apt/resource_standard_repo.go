@@ -15,11 +15,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
@@ -27,6 +29,7 @@ import (
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	api "github.com/bpg/terraform-provider-proxmox/proxmox/nodes/apt/repositories"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
 )
 
 const (
@@ -39,6 +42,7 @@ var (
 	_ resource.Resource                = &standardRepositoryResource{}
 	_ resource.ResourceWithConfigure   = &standardRepositoryResource{}
 	_ resource.ResourceWithImportState = &standardRepositoryResource{}
+	_ resource.ResourceWithModifyPlan  = &standardRepositoryResource{}
 )
 
 // standardRepositoryResource contains the APT standard repository resource's internal data.
@@ -61,16 +65,21 @@ func (r *standardRepositoryResource) read(ctx context.Context, srp *modelStandar
 
 	for _, stdRepo := range data.StandardRepos {
 		// Check if the APT standard repository is configured…
-		if stdRepo.Handle == srp.Handle.ValueString() && stdRepo.Status == nil {
+		if stdRepo.Handle == srp.Handle.ValueString() {
 			// …handle the situation gracefully if not to signal that the repository has been removed outside of Terraform and
 			// must be added back again.
-			return false, diags
+			if stdRepo.Status == nil {
+				return false, diags
+			}
+
+			srp.importFromAPI(ctx, data)
+
+			return true, nil
 		}
 	}
 
-	srp.importFromAPI(ctx, data)
-
-	return true, nil
+	// The handle is not available for the Proxmox VE version of the node.
+	return false, diags
 }
 
 // readBack reads information about an APT standard repository from the Proxmox VE API and then updates the response
@@ -136,16 +145,47 @@ func (r *standardRepositoryResource) Create(
 		return
 	}
 
-	body := &api.AddRequestBody{
-		Handle: srp.Handle.ValueString(),
-		Node:   srp.Node.ValueString(),
+	enabled := srp.Enabled.ValueBool()
+
+	// Adding an APT standard repository that is already configured creates a duplicate entry in the source list file,
+	// so it is only added when it can not be found.
+	found, diags := r.read(ctx, &srp)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if err := r.client.Node(srp.Node.ValueString()).APT().Repositories().Add(ctx, body); err != nil {
-		resp.Diagnostics.AddError(
-			fmt.Sprintf("Could not add APT standard repository with handle %v on node %v", srp.Handle, srp.Node),
-			err.Error(),
-		)
+	if !found {
+		body := &api.AddRequestBody{
+			Handle: srp.Handle.ValueString(),
+			Node:   srp.Node.ValueString(),
+		}
+
+		if err := r.client.Node(srp.Node.ValueString()).APT().Repositories().Add(ctx, body); err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Could not add APT standard repository with handle %v on node %v", srp.Handle, srp.Node),
+				err.Error(),
+			)
+
+			return
+		}
+
+		found, diags = r.read(ctx, &srp)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if found && srp.Enabled.ValueBool() != enabled {
+		srp.Enabled = types.BoolValue(enabled)
+		r.modify(ctx, &srp, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
 	r.readBack(ctx, &srp, &resp.Diagnostics, &resp.State)
@@ -187,6 +227,80 @@ func (r *standardRepositoryResource) ImportState(
 	r.readBack(ctx, &srp, &resp.Diagnostics, &resp.State)
 }
 
+// modify sets the activation status of an APT standard repository through the index within the defining source list
+// file.
+func (r *standardRepositoryResource) modify(ctx context.Context, srp *modelStandardRepo, diags *diag.Diagnostics) {
+	body := &api.ModifyRequestBody{
+		Enabled: proxmoxtypes.CustomBool(srp.Enabled.ValueBool()),
+		Index:   srp.Index.ValueInt64(),
+		Path:    srp.FilePath.ValueString(),
+	}
+
+	if err := r.client.Node(srp.Node.ValueString()).APT().Repositories().Modify(ctx, body); err != nil {
+		diags.AddError(
+			fmt.Sprintf("Could not modify APT standard repository with handle %v on node %v", srp.Handle, srp.Node),
+			err.Error(),
+		)
+	}
+}
+
+// ModifyPlan ensures that the handle of the APT standard repository is available on the Proxmox VE node, since the
+// list of standard repositories depends on the Proxmox VE version of the node.
+func (r *standardRepositoryResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var srp modelStandardRepo
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &srp)...)
+
+	if resp.Diagnostics.HasError() || srp.Handle.IsUnknown() || srp.Node.IsUnknown() {
+		return
+	}
+
+	data, err := r.client.Node(srp.Node.ValueString()).APT().Repositories().Get(ctx)
+	if err != nil {
+		// The node might not be reachable yet, the handle is validated again when the repository is created.
+		tflog.Debug(ctx, "Could not read APT repositories to validate the standard repository handle", map[string]any{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	handles := make([]string, 0, len(data.StandardRepos))
+
+	for _, stdRepo := range data.StandardRepos {
+		if stdRepo.Handle == srp.Handle.ValueString() {
+			return
+		}
+
+		handles = append(handles, fmt.Sprintf("%q", stdRepo.Handle))
+	}
+
+	pveVersion := "unknown"
+	if ver, e := r.client.Version().Version(ctx); e == nil {
+		pveVersion = ver.Version.String()
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root(SchemaAttrNameStandardHandle),
+		"Unknown APT standard repository handle",
+		fmt.Sprintf(
+			"The handle %q is not available on node %q (Proxmox VE version %s), must be one of: %s",
+			srp.Handle.ValueString(),
+			srp.Node.ValueString(),
+			pveVersion,
+			strings.Join(handles, ", "),
+		),
+	)
+}
+
 // Metadata defines the name of the APT standard repository resource.
 func (r *standardRepositoryResource) Metadata(
 	_ context.Context,
@@ -235,6 +349,12 @@ func (r *standardRepositoryResource) Schema(
 				Computed:    true,
 				Description: "The description of the APT standard repository.",
 			},
+			SchemaAttrNameEnabled: schema.BoolAttribute{
+				Computed:    true,
+				Default:     booldefault.StaticBool(ResourceRepoActivationStatus),
+				Description: "Indicates the activation status.",
+				Optional:    true,
+			},
 			SchemaAttrNameFilePath: schema.StringAttribute{
 				Computed:    true,
 				Description: "The absolute path of the source list file that contains this standard repository.",
@@ -245,7 +365,8 @@ func (r *standardRepositoryResource) Schema(
 				MarkdownDescription: "The handle of the APT standard repository. Must be `ceph-quincy-enterprise` | " +
 					"`ceph-quincy-no-subscription` | `ceph-quincy-test` | `ceph-reef-enterprise` | `ceph-reef-no-subscription` " +
 					"| `ceph-reef-test` | `ceph-squid-enterprise` | `ceph-squid-no-subscription` | `ceph-squid-test` " +
-					"| `enterprise` | `no-subscription` | `test`.",
+					"| `enterprise` | `no-subscription` | `test`. The handles available on a node depend on its " +
+					"Proxmox VE version, and unavailable handles are rejected during planning.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -283,13 +404,33 @@ func (r *standardRepositoryResource) Schema(
 	}
 }
 
-// Update is currently a no-op for APT repositories due to the non-existing capability of the Proxmox VE API of updating
-// a configured APT standard repository.
-// Also see Terraform's "Delete" framework documentation about [recommendations] and [caveats].
-//
-// [caveats]: https://developer.hashicorp.com/terraform/plugin/framework/resources/delete#caveats
-// [recommendations]: https://developer.hashicorp.com/terraform/plugin/framework/resources/delete#recommendations
-func (r *standardRepositoryResource) Update(_ context.Context, _ resource.UpdateRequest, _ *resource.UpdateResponse) {
+// Update updates the activation status of a configured APT standard repository, which is the only attribute that can
+// be changed through the Proxmox VE API.
+func (r *standardRepositoryResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var srpPlan, srpState modelStandardRepo
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &srpPlan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &srpState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The file path and index are computed, so they must be taken from the current state.
+	srpPlan.FilePath = srpState.FilePath
+	srpPlan.Index = srpState.Index
+
+	r.modify(ctx, &srpPlan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.readBack(ctx, &srpPlan, &resp.Diagnostics, &resp.State)
 }
 
 // NewStandardRepositoryResource returns a new resource for managing an APT standard repository.
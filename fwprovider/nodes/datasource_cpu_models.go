@@ -0,0 +1,160 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+)
+
+var (
+	_ datasource.DataSource              = &cpuModelsDataSource{}
+	_ datasource.DataSourceWithConfigure = &cpuModelsDataSource{}
+)
+
+type cpuModelsDataSourceModel struct {
+	NodeName types.String    `tfsdk:"node_name"`
+	Models   []cpuModelModel `tfsdk:"models"`
+	Names    []types.String  `tfsdk:"names"`
+}
+
+type cpuModelModel struct {
+	Name   types.String `tfsdk:"name"`
+	Vendor types.String `tfsdk:"vendor"`
+	Custom types.Bool   `tfsdk:"custom"`
+}
+
+// NewCPUModelsDataSource creates a new data source for the CPU models of a node.
+func NewCPUModelsDataSource() datasource.DataSource {
+	return &cpuModelsDataSource{}
+}
+
+type cpuModelsDataSource struct {
+	client proxmox.Client
+}
+
+// Metadata defines the name of the data source.
+func (d *cpuModelsDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_node_cpu_models"
+}
+
+// Schema defines the schema for the data source.
+func (d *cpuModelsDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the CPU models supported by a node.",
+		MarkdownDescription: "Retrieves the CPU models supported by a node, i.e. the built-in models of its QEMU " +
+			"version and the custom models defined in `/etc/pve/virtual-guest/cpu-models.conf`. The names can be " +
+			"used as the `cpu.type` of the `proxmox_virtual_environment_vm` resource, the custom models with the " +
+			"`custom-` prefix.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+			},
+			"models": schema.ListNestedAttribute{
+				Description: "The CPU models, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the model, e.g. `x86-64-v2-AES` or `custom-gpu`.",
+							Computed:    true,
+						},
+						"vendor": schema.StringAttribute{
+							Description: "The CPU vendor, e.g. `GenuineIntel`, or `default` for the generic models.",
+							Computed:    true,
+						},
+						"custom": schema.BoolAttribute{
+							Description: "Whether the model is a custom model.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"names": schema.ListAttribute{
+				Description: "The names of the CPU models, sorted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Configure sets the client for the data source.
+func (d *cpuModelsDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read reads the CPU models of the node.
+func (d *cpuModelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model cpuModelsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	models, err := d.client.Node(model.NodeName.ValueString()).ListCPUModels(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to list the CPU models", err.Error())
+
+		return
+	}
+
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].Name < models[j].Name
+	})
+
+	model.Models = make([]cpuModelModel, 0, len(models))
+	model.Names = make([]types.String, 0, len(models))
+
+	for _, m := range models {
+		model.Models = append(model.Models, cpuModelModel{
+			Name:   types.StringValue(m.Name),
+			Vendor: types.StringValue(m.Vendor),
+			Custom: types.BoolValue(bool(m.Custom)),
+		})
+		model.Names = append(model.Names, types.StringValue(m.Name))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
@@ -0,0 +1,238 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/utils"
+)
+
+// remoteFileDefaultTimeout is the default time in seconds to retrieve the remote file.
+const remoteFileDefaultTimeout = 600
+
+var _ datasource.DataSource = &remoteFileDataSource{}
+
+type remoteFileDataSourceModel struct {
+	URL             types.String `tfsdk:"url"`
+	ComputeChecksum types.Bool   `tfsdk:"compute_checksum"`
+	Insecure        types.Bool   `tfsdk:"insecure"`
+	Timeout         types.Int64  `tfsdk:"timeout"`
+	Size            types.Int64  `tfsdk:"size"`
+	ETag            types.String `tfsdk:"etag"`
+	LastModified    types.String `tfsdk:"last_modified"`
+	Checksum        types.String `tfsdk:"checksum"`
+}
+
+// remoteFileInfo contains the metadata of a remote file. The size is -1 when it is unknown.
+type remoteFileInfo struct {
+	Size         int64
+	ETag         string
+	LastModified string
+	Checksum     string
+}
+
+// NewRemoteFileDataSource creates a new data source for retrieving the metadata of a remote file.
+func NewRemoteFileDataSource() datasource.DataSource {
+	return &remoteFileDataSource{}
+}
+
+type remoteFileDataSource struct{}
+
+func (d *remoteFileDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_remote_file"
+}
+
+// Schema defines the schema for the data source.
+func (d *remoteFileDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the metadata of a remote file, and optionally computes its checksum.",
+		MarkdownDescription: "Retrieves the metadata of a remote file, and optionally computes its checksum, " +
+			"e.g. to verify a file whose checksum changes with every release. The file is retrieved by the " +
+			"provider, not by a Proxmox VE node.",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Description: "The URL of the file.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(httpRegex, "must match HTTP URL regex `"+httpRegex.String()+"`"),
+				},
+			},
+			"compute_checksum": schema.BoolAttribute{
+				Description: "Whether to download the file to compute its SHA-256 checksum (defaults to `false`).",
+				MarkdownDescription: "Whether to download the file to compute its SHA-256 checksum (defaults to " +
+					"`false`). The file is downloaded on every read, so it is best used with small files, or files " +
+					"which are downloaded anyway.",
+				Optional: true,
+			},
+			"insecure": schema.BoolAttribute{
+				Description: "Whether to skip the TLS verification of the server (defaults to `false`).",
+				Optional:    true,
+			},
+			"timeout": schema.Int64Attribute{
+				Description: fmt.Sprintf("The time in seconds to retrieve the file (defaults to `%d`).",
+					remoteFileDefaultTimeout),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Description: "The size of the file in bytes. It is not set when the server does not report " +
+					"the size and the checksum is not computed.",
+				Computed: true,
+			},
+			"etag": schema.StringAttribute{
+				Description: "The entity tag of the file, as reported by the server.",
+				Computed:    true,
+			},
+			"last_modified": schema.StringAttribute{
+				Description: "The last modification date of the file, as reported by the server.",
+				Computed:    true,
+			},
+			"checksum": schema.StringAttribute{
+				Description: "The SHA-256 checksum of the file, when `compute_checksum` is set.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *remoteFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model remoteFileDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := int64(remoteFileDefaultTimeout)
+	if !model.Timeout.IsNull() {
+		timeout = model.Timeout.ValueInt64()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if model.Insecure.ValueBool() {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+	}
+
+	info, err := fetchRemoteFile(ctx, &http.Client{Transport: transport}, model.URL.ValueString(),
+		model.ComputeChecksum.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Remote File",
+			fmt.Sprintf("Could not retrieve %q: %s", model.URL.ValueString(), err.Error()),
+		)
+
+		return
+	}
+
+	model.Size = types.Int64Null()
+	if info.Size >= 0 {
+		model.Size = types.Int64Value(info.Size)
+	}
+
+	model.ETag = types.StringValue(info.ETag)
+	model.LastModified = types.StringValue(info.LastModified)
+	model.Checksum = types.StringNull()
+
+	if info.Checksum != "" {
+		model.Checksum = types.StringValue(info.Checksum)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+// fetchRemoteFile retrieves the metadata of a remote file with a HEAD request, or with a GET request
+// when the checksum is computed or the server does not support HEAD requests.
+func fetchRemoteFile(ctx context.Context, client *http.Client, url string, computeChecksum bool) (*remoteFileInfo, error) {
+	method := http.MethodHead
+	if computeChecksum {
+		method = http.MethodGet
+	}
+
+	res, err := doRemoteFileRequest(ctx, client, method, url)
+	if err != nil {
+		return nil, err
+	}
+
+	if method == http.MethodHead &&
+		(res.StatusCode == http.StatusMethodNotAllowed || res.StatusCode == http.StatusNotImplemented) {
+		utils.CloseOrLogError(ctx)(res.Body)
+
+		res, err = doRemoteFileRequest(ctx, client, http.MethodGet, url)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	defer utils.CloseOrLogError(ctx)(res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected HTTP status %q", res.Status)
+	}
+
+	info := &remoteFileInfo{
+		Size:         res.ContentLength,
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+	}
+
+	if computeChecksum {
+		h := sha256.New()
+
+		info.Size, err = io.Copy(h, res.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download the file: %w", err)
+		}
+
+		info.Checksum = hex.EncodeToString(h.Sum(nil))
+	}
+
+	return info, nil
+}
+
+func doRemoteFileRequest(ctx context.Context, client *http.Client, method, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the HTTP %s request: %w", method, err)
+	}
+
+	//nolint:bodyclose
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform the HTTP %s request: %w", method, err)
+	}
+
+	return res, nil
+}
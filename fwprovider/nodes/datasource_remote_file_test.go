@@ -0,0 +1,69 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchRemoteFile(t *testing.T) {
+	t.Parallel()
+
+	const content = "hello world"
+
+	newServer := func(head bool) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodHead && !head {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+
+				return
+			}
+
+			w.Header().Set("ETag", `"abc"`)
+			w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+			http.ServeContent(w, r, "", time.Time{}, strings.NewReader(content))
+		}))
+
+		t.Cleanup(server.Close)
+
+		return server
+	}
+
+	server := newServer(true)
+
+	info, err := fetchRemoteFile(context.Background(), server.Client(), server.URL, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), info.Size)
+	assert.Equal(t, `"abc"`, info.ETag)
+	assert.Equal(t, "Wed, 21 Oct 2015 07:28:00 GMT", info.LastModified)
+	assert.Empty(t, info.Checksum)
+
+	info, err = fetchRemoteFile(context.Background(), server.Client(), server.URL, true)
+	require.NoError(t, err)
+	assert.Equal(t, "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", info.Checksum)
+
+	// the metadata is retrieved with a GET request when HEAD is not supported
+	server = newServer(false)
+
+	info, err = fetchRemoteFile(context.Background(), server.Client(), server.URL, false)
+	require.NoError(t, err)
+	assert.Equal(t, `"abc"`, info.ETag)
+
+	missing := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(missing.Close)
+
+	_, err = fetchRemoteFile(context.Background(), missing.Client(), missing.URL, false)
+	require.ErrorContains(t, err, "unexpected HTTP status")
+}
@@ -0,0 +1,367 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+)
+
+var (
+	_ datasource.DataSource              = &statusDataSource{}
+	_ datasource.DataSourceWithConfigure = &statusDataSource{}
+)
+
+type statusDataSourceModel struct {
+	NodeName     types.String        `tfsdk:"node_name"`
+	BootMode     types.String        `tfsdk:"boot_mode"`
+	CPU          *statusCPUModel     `tfsdk:"cpu"`
+	IOMMUActive  types.Bool          `tfsdk:"iommu_active"`
+	Kernel       *statusKernelModel  `tfsdk:"kernel"`
+	LoadAverage  []types.Float64     `tfsdk:"load_average"`
+	Memory       *statusUsageModel   `tfsdk:"memory"`
+	PVEVersion   types.String        `tfsdk:"pve_version"`
+	Requirements *statusRequirements `tfsdk:"requirements"`
+	RootFS       *statusUsageModel   `tfsdk:"root_fs"`
+	SecureBoot   types.Bool          `tfsdk:"secure_boot"`
+	Swap         *statusUsageModel   `tfsdk:"swap"`
+	Uptime       types.Int64         `tfsdk:"uptime"`
+}
+
+type statusCPUModel struct {
+	Cores   types.Int64   `tfsdk:"cores"`
+	Count   types.Int64   `tfsdk:"count"`
+	Flags   types.Set     `tfsdk:"flags"`
+	MHz     types.Float64 `tfsdk:"mhz"`
+	Model   types.String  `tfsdk:"model"`
+	Sockets types.Int64   `tfsdk:"sockets"`
+}
+
+type statusKernelModel struct {
+	Machine types.String `tfsdk:"machine"`
+	Release types.String `tfsdk:"release"`
+	SysName types.String `tfsdk:"sysname"`
+	Version types.String `tfsdk:"version"`
+}
+
+type statusUsageModel struct {
+	Free  types.Int64 `tfsdk:"free"`
+	Total types.Int64 `tfsdk:"total"`
+	Used  types.Int64 `tfsdk:"used"`
+}
+
+func (m *statusDataSourceModel) importFromAPI(
+	ctx context.Context,
+	data *nodes.GetInfoResponseData,
+	diags *diag.Diagnostics,
+) {
+	m.BootMode = types.StringNull()
+	m.SecureBoot = types.BoolNull()
+
+	if data.BootInfo != nil {
+		m.BootMode = types.StringValue(data.BootInfo.Mode)
+		m.SecureBoot = types.BoolPointerValue(data.BootInfo.SecureBoot.PointerBool())
+	}
+
+	var flags []string
+	if data.CPUInfo.CPUFlags != nil {
+		flags = strings.Fields(*data.CPUInfo.CPUFlags)
+		sort.Strings(flags)
+	}
+
+	cpuFlags, d := types.SetValueFrom(ctx, types.StringType, flags)
+	diags.Append(d...)
+
+	m.CPU = &statusCPUModel{
+		Cores:   int64PointerValue(data.CPUInfo.CPUCores),
+		Count:   int64PointerValue(data.CPUInfo.CPUCount),
+		Flags:   cpuFlags,
+		MHz:     types.Float64PointerValue(data.CPUInfo.CPUMHz.PointerFloat64()),
+		Model:   types.StringPointerValue(data.CPUInfo.CPUModel),
+		Sockets: int64PointerValue(data.CPUInfo.CPUSockets),
+	}
+
+	m.Kernel = nil
+	if data.CurrentKernel != nil {
+		m.Kernel = &statusKernelModel{
+			Machine: types.StringValue(data.CurrentKernel.Machine),
+			Release: types.StringValue(data.CurrentKernel.Release),
+			SysName: types.StringValue(data.CurrentKernel.SysName),
+			Version: types.StringValue(data.CurrentKernel.Version),
+		}
+	}
+
+	m.LoadAverage = make([]types.Float64, 0, len(data.LoadAverage))
+	for _, l := range data.LoadAverage {
+		m.LoadAverage = append(m.LoadAverage, types.Float64Value(float64(l)))
+	}
+
+	m.Memory = &statusUsageModel{
+		Free:  int64PointerValue(data.MemoryInfo.Free),
+		Total: int64PointerValue(data.MemoryInfo.Total),
+		Used:  int64PointerValue(data.MemoryInfo.Used),
+	}
+
+	// the PVE version is reported as `pve-manager/<version>/<commit>`
+	m.PVEVersion = types.StringNull()
+	if data.PVEVersion != nil {
+		parts := strings.Split(*data.PVEVersion, "/")
+		if len(parts) > 1 {
+			m.PVEVersion = types.StringValue(parts[1])
+		}
+	}
+
+	m.RootFS = &statusUsageModel{
+		Free:  types.Int64PointerValue(data.RootFS.Free),
+		Total: types.Int64PointerValue(data.RootFS.Total),
+		Used:  types.Int64PointerValue(data.RootFS.Used),
+	}
+
+	m.Swap = &statusUsageModel{
+		Free:  types.Int64PointerValue(data.SwapInfo.Free),
+		Total: types.Int64PointerValue(data.SwapInfo.Total),
+		Used:  types.Int64PointerValue(data.SwapInfo.Used),
+	}
+
+	m.Uptime = int64PointerValue(data.Uptime)
+}
+
+func int64PointerValue(v *int) types.Int64 {
+	if v == nil {
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(int64(*v))
+}
+
+// NewStatusDataSource creates a new data source for the status of a node.
+func NewStatusDataSource() datasource.DataSource {
+	return &statusDataSource{}
+}
+
+type statusDataSource struct {
+	client proxmox.Client
+}
+
+// Metadata defines the name of the data source.
+func (d *statusDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_node_status"
+}
+
+// Schema defines the schema for the data source.
+func (d *statusDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	usageAttributes := func(what string) map[string]schema.Attribute {
+		return map[string]schema.Attribute{
+			"free": schema.Int64Attribute{
+				Description: fmt.Sprintf("The free %s in bytes.", what),
+				Computed:    true,
+			},
+			"total": schema.Int64Attribute{
+				Description: fmt.Sprintf("The total %s in bytes.", what),
+				Computed:    true,
+			},
+			"used": schema.Int64Attribute{
+				Description: fmt.Sprintf("The used %s in bytes.", what),
+				Computed:    true,
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the status of a node.",
+		MarkdownDescription: "Retrieves the status of a node, e.g. the running kernel, the CPU features, and whether " +
+			"the IOMMU is active. The `requirements` of the node can be asserted, so that a plan fails early if the " +
+			"node is not prepared for the configuration, e.g. for the PCI passthrough to VMs.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+			},
+			"boot_mode": schema.StringAttribute{
+				Description: "The boot mode of the node (`efi` or `legacy-bios`).",
+				Computed:    true,
+			},
+			"cpu": schema.SingleNestedAttribute{
+				Description: "The CPU information of the node.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"cores": schema.Int64Attribute{
+						Description: "The number of CPU cores per socket.",
+						Computed:    true,
+					},
+					"count": schema.Int64Attribute{
+						Description: "The number of logical CPUs.",
+						Computed:    true,
+					},
+					"flags": schema.SetAttribute{
+						Description: "The CPU flags, e.g. `vmx` or `svm` for the hardware virtualization support.",
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+					"mhz": schema.Float64Attribute{
+						Description: "The CPU frequency in MHz.",
+						Computed:    true,
+					},
+					"model": schema.StringAttribute{
+						Description: "The CPU model.",
+						Computed:    true,
+					},
+					"sockets": schema.Int64Attribute{
+						Description: "The number of CPU sockets.",
+						Computed:    true,
+					},
+				},
+			},
+			"iommu_active": schema.BoolAttribute{
+				Description: "Whether the IOMMU is active, i.e. the PCI devices of the node are assigned to IOMMU groups.",
+				Computed:    true,
+			},
+			"kernel": schema.SingleNestedAttribute{
+				Description: "The running kernel of the node.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"machine": schema.StringAttribute{
+						Description: "The hardware architecture, e.g. `x86_64`.",
+						Computed:    true,
+					},
+					"release": schema.StringAttribute{
+						Description: "The kernel release, e.g. `6.8.12-4-pve`.",
+						Computed:    true,
+					},
+					"sysname": schema.StringAttribute{
+						Description: "The operating system name, e.g. `Linux`.",
+						Computed:    true,
+					},
+					"version": schema.StringAttribute{
+						Description: "The kernel build version.",
+						Computed:    true,
+					},
+				},
+			},
+			"load_average": schema.ListAttribute{
+				Description: "The load average of the node over the last 1, 5 and 15 minutes.",
+				Computed:    true,
+				ElementType: types.Float64Type,
+			},
+			"memory": schema.SingleNestedAttribute{
+				Description: "The memory usage of the node.",
+				Computed:    true,
+				Attributes:  usageAttributes("memory"),
+			},
+			"pve_version": schema.StringAttribute{
+				Description: "The version of Proxmox VE running on the node, e.g. `8.3.0`.",
+				Computed:    true,
+			},
+			"requirements": requirementsAttribute(),
+			"root_fs": schema.SingleNestedAttribute{
+				Description: "The usage of the root filesystem of the node.",
+				Computed:    true,
+				Attributes:  usageAttributes("space"),
+			},
+			"secure_boot": schema.BoolAttribute{
+				Description: "Whether secure boot is enabled on the node.",
+				Computed:    true,
+			},
+			"swap": schema.SingleNestedAttribute{
+				Description: "The swap usage of the node.",
+				Computed:    true,
+				Attributes:  usageAttributes("swap"),
+			},
+			"uptime": schema.Int64Attribute{
+				Description: "The uptime of the node in seconds.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure sets the client for the data source.
+func (d *statusDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read reads the status of the node, and asserts its requirements.
+func (d *statusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model statusDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeAPI := d.client.Node(model.NodeName.ValueString())
+
+	info, err := nodeAPI.GetInfo(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read node status", err.Error())
+
+		return
+	}
+
+	model.importFromAPI(ctx, info, &resp.Diagnostics)
+
+	model.IOMMUActive = types.BoolNull()
+
+	devices, err := nodeAPI.ListPCIDevices(ctx)
+	if err == nil {
+		model.IOMMUActive = types.BoolValue(nodes.IOMMUActive(devices))
+	} else {
+		tflog.Warn(ctx, "Unable to list PCI devices to determine whether the IOMMU is active", map[string]any{
+			"node":  model.NodeName.ValueString(),
+			"error": err.Error(),
+		})
+	}
+
+	if model.Requirements != nil {
+		model.Requirements.check(ctx, &model, &resp.Diagnostics)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
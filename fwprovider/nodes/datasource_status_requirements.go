@@ -0,0 +1,157 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// statusRequirements are the prerequisites of the configuration, which are asserted against the node status.
+type statusRequirements struct {
+	CPUFlags         []types.String `tfsdk:"cpu_flags"`
+	IOMMU            types.Bool     `tfsdk:"iommu"`
+	MinKernelVersion types.String   `tfsdk:"min_kernel_version"`
+	MinPVEVersion    types.String   `tfsdk:"min_pve_version"`
+}
+
+func requirementsAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "The requirements of the node. Reading the data source fails if any of them is not met.",
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"cpu_flags": schema.SetAttribute{
+				Description: "The CPU flags the node must have, e.g. `[\"vmx\"]`.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"iommu": schema.BoolAttribute{
+				Description: "Whether the IOMMU must be active, e.g. for the PCI passthrough to VMs.",
+				Optional:    true,
+			},
+			"min_kernel_version": schema.StringAttribute{
+				Description: "The minimum version of the running kernel, e.g. `6.8`.",
+				Optional:    true,
+			},
+			"min_pve_version": schema.StringAttribute{
+				Description: "The minimum version of Proxmox VE, e.g. `8.2`.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// check asserts the requirements against the node status, and adds an error for every requirement not met.
+func (r *statusRequirements) check(ctx context.Context, status *statusDataSourceModel, diags *diag.Diagnostics) {
+	root := path.Root("requirements")
+	nodeName := status.NodeName.ValueString()
+
+	if r.IOMMU.ValueBool() {
+		switch {
+		case status.IOMMUActive.IsNull():
+			diags.AddAttributeError(
+				root.AtName("iommu"),
+				"Unable to assert node requirement",
+				fmt.Sprintf("Could not list the PCI devices of node %q to determine whether the IOMMU is active.", nodeName),
+			)
+		case !status.IOMMUActive.ValueBool():
+			diags.AddAttributeError(
+				root.AtName("iommu"),
+				"Node requirement not met",
+				fmt.Sprintf("The IOMMU is not active on node %q. Enable it on the kernel command line "+
+					"(e.g. `intel_iommu=on iommu=pt`), and reboot the node.", nodeName),
+			)
+		}
+	}
+
+	if len(r.CPUFlags) > 0 {
+		var flags []string
+		if status.CPU != nil {
+			diags.Append(status.CPU.Flags.ElementsAs(ctx, &flags, false)...)
+		}
+
+		var missing []string
+
+		for _, f := range r.CPUFlags {
+			if !slices.Contains(flags, f.ValueString()) {
+				missing = append(missing, f.ValueString())
+			}
+		}
+
+		if len(missing) > 0 {
+			diags.AddAttributeError(
+				root.AtName("cpu_flags"),
+				"Node requirement not met",
+				fmt.Sprintf("The CPU of node %q does not have the flags: %s.", nodeName, strings.Join(missing, ", ")),
+			)
+		}
+	}
+
+	if !r.MinKernelVersion.IsNull() {
+		release := ""
+		if status.Kernel != nil {
+			release = status.Kernel.Release.ValueString()
+		}
+
+		// the kernel release has the ABI and the flavour appended to the version, e.g. `6.8.12-4-pve`
+		checkMinVersion(
+			root.AtName("min_kernel_version"),
+			"kernel",
+			nodeName,
+			r.MinKernelVersion.ValueString(),
+			strings.Split(release, "-")[0],
+			diags,
+		)
+	}
+
+	if !r.MinPVEVersion.IsNull() {
+		checkMinVersion(
+			root.AtName("min_pve_version"),
+			"Proxmox VE",
+			nodeName,
+			r.MinPVEVersion.ValueString(),
+			status.PVEVersion.ValueString(),
+			diags,
+		)
+	}
+}
+
+func checkMinVersion(p path.Path, what string, nodeName string, required string, actual string, diags *diag.Diagnostics) {
+	minVersion, err := version.NewVersion(required)
+	if err != nil {
+		diags.AddAttributeError(p, "Invalid version", fmt.Sprintf("Could not parse the version %q: %s", required, err))
+
+		return
+	}
+
+	actualVersion, err := version.NewVersion(actual)
+	if err != nil {
+		diags.AddAttributeError(
+			p,
+			"Unable to assert node requirement",
+			fmt.Sprintf("Could not determine the %s version of node %q.", what, nodeName),
+		)
+
+		return
+	}
+
+	if actualVersion.LessThan(minVersion) {
+		diags.AddAttributeError(
+			p,
+			"Node requirement not met",
+			fmt.Sprintf("The %s version of node %q is %s, but at least %s is required.", what, nodeName, actual, minVersion),
+		)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+)
+
+const testNodeStatusJSON = `{
+	"boot-info": {"mode": "efi", "secureboot": 0},
+	"cpuinfo": {
+		"cores": 4, "cpus": 8, "flags": "fpu vme vmx sse4_2", "mhz": "3400.000",
+		"model": "Intel(R) Core(TM) i7-6700 CPU @ 3.40GHz", "sockets": 1
+	},
+	"current-kernel": {"machine": "x86_64", "release": "6.8.12-4-pve", "sysname": "Linux", "version": "#1 SMP"},
+	"loadavg": ["0.10", "0.15", "0.12"],
+	"memory": {"free": 1024, "total": 4096, "used": 3072},
+	"pveversion": "pve-manager/8.3.0/c1689ccb1065a83b",
+	"rootfs": {"avail": 10, "free": 20, "total": 100, "used": 80},
+	"swap": {"free": 0, "total": 0, "used": 0},
+	"uptime": 3600
+}`
+
+func Test_statusRequirementsCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	data := &nodes.GetInfoResponseData{}
+	require.NoError(t, json.Unmarshal([]byte(testNodeStatusJSON), data))
+
+	status := &statusDataSourceModel{NodeName: types.StringValue("pve")}
+
+	var diags diag.Diagnostics
+
+	status.importFromAPI(ctx, data, &diags)
+	require.False(t, diags.HasError())
+
+	assert.Equal(t, "efi", status.BootMode.ValueString())
+	assert.Equal(t, "8.3.0", status.PVEVersion.ValueString())
+	assert.Equal(t, "6.8.12-4-pve", status.Kernel.Release.ValueString())
+	assert.InDelta(t, 3400.0, status.CPU.MHz.ValueFloat64(), 0.001)
+	assert.Len(t, status.LoadAverage, 3)
+
+	tests := []struct {
+		name         string
+		iommuActive  types.Bool
+		requirements statusRequirements
+		wantErrors   int
+	}{
+		{
+			"all requirements met",
+			types.BoolValue(true),
+			statusRequirements{
+				CPUFlags:         []types.String{types.StringValue("vmx")},
+				IOMMU:            types.BoolValue(true),
+				MinKernelVersion: types.StringValue("6.8.12"),
+				MinPVEVersion:    types.StringValue("8.2"),
+			},
+			0,
+		},
+		{
+			"iommu not active",
+			types.BoolValue(false),
+			statusRequirements{IOMMU: types.BoolValue(true)},
+			1,
+		},
+		{
+			"iommu unknown",
+			types.BoolNull(),
+			statusRequirements{IOMMU: types.BoolValue(true)},
+			1,
+		},
+		{
+			"iommu not required",
+			types.BoolValue(false),
+			statusRequirements{IOMMU: types.BoolValue(false)},
+			0,
+		},
+		{
+			"missing cpu flag",
+			types.BoolValue(true),
+			statusRequirements{CPUFlags: []types.String{types.StringValue("vmx"), types.StringValue("svm")}},
+			1,
+		},
+		{
+			"kernel too old",
+			types.BoolValue(true),
+			statusRequirements{MinKernelVersion: types.StringValue("6.11")},
+			1,
+		},
+		{
+			"pve too old and invalid kernel version",
+			types.BoolValue(true),
+			statusRequirements{MinKernelVersion: types.StringValue("latest"), MinPVEVersion: types.StringValue("9.0")},
+			2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := *status
+			s.IOMMUActive = tt.iommuActive
+
+			var diags diag.Diagnostics
+
+			tt.requirements.check(ctx, &s, &diags)
+			assert.Len(t, diags.Errors(), tt.wantErrors, diags)
+		})
+	}
+}
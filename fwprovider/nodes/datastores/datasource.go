@@ -76,7 +76,10 @@ func (d *Datasource) Read(ctx context.Context, req datasource.ReadRequest, resp
 		return
 	}
 
-	storageAPI := d.client.Node(model.NodeName.ValueString()).Storage("")
+	nodeNames := model.NodeNames
+	if len(nodeNames) == 0 {
+		nodeNames = []types.String{model.NodeName}
+	}
 
 	r := storage.DatastoreListRequestBody{}
 	if model.Filters != nil {
@@ -85,38 +88,66 @@ func (d *Datasource) Read(ctx context.Context, req datasource.ReadRequest, resp
 		r.Target = model.Filters.Target.ValueStringPointer()
 	}
 
-	dsList, err := storageAPI.ListDatastores(ctx, &r)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to read datastores",
-			err.Error(),
-		)
+	model.Datastores = []Datastore{}
 
-		return
+	for _, nodeName := range nodeNames {
+		dsList, err := d.client.Node(nodeName.ValueString()).Storage("").ListDatastores(ctx, &r)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read datastores",
+				fmt.Sprintf("Could not list datastores of node %q: %s", nodeName.ValueString(), err.Error()),
+			)
+
+			return
+		}
+
+		for _, ds := range dsList {
+			datastore := Datastore{}
+
+			if ds.ContentTypes != nil {
+				datastore.ContentTypes = stringset.NewValueList(*ds.ContentTypes, &resp.Diagnostics)
+			}
+
+			datastore.Active = types.BoolPointerValue(ds.Active.PointerBool())
+			datastore.Enabled = types.BoolPointerValue(ds.Enabled.PointerBool())
+			datastore.ID = types.StringValue(ds.ID)
+			datastore.NodeName = nodeName
+			datastore.Shared = types.BoolPointerValue(ds.Shared.PointerBool())
+			datastore.SpaceAvailable = types.Int64PointerValue(ds.SpaceAvailable.PointerInt64())
+			datastore.SpaceTotal = types.Int64PointerValue(ds.SpaceTotal.PointerInt64())
+			datastore.SpaceUsed = types.Int64PointerValue(ds.SpaceUsed.PointerInt64())
+			datastore.SpaceUsedFraction = types.Float64PointerValue(ds.SpaceUsedPercentage.PointerFloat64())
+			datastore.Type = types.StringValue(ds.Type)
+
+			model.Datastores = append(model.Datastores, datastore)
+		}
 	}
 
-	model.Datastores = make([]Datastore, 0, len(dsList))
+	model.MostFree = types.StringNull()
+	model.MostFreeNodeName = types.StringNull()
 
-	for _, ds := range dsList {
-		datastore := Datastore{}
+	if mostFree := mostFreeDatastore(model.Datastores); mostFree != nil {
+		model.MostFree = mostFree.ID
+		model.MostFreeNodeName = mostFree.NodeName
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
 
-		if ds.ContentTypes != nil {
-			datastore.ContentTypes = stringset.NewValueList(*ds.ContentTypes, &resp.Diagnostics)
+// mostFreeDatastore returns the active and enabled datastore with the most available space, or nil if there is none.
+// The first of the datastores with the same available space is returned, so the result is stable across reads.
+func mostFreeDatastore(datastores []Datastore) *Datastore {
+	var mostFree *Datastore
+
+	for i, ds := range datastores {
+		if !ds.Active.ValueBool() || (!ds.Enabled.IsNull() && !ds.Enabled.ValueBool()) || ds.SpaceAvailable.IsNull() {
+			continue
 		}
 
-		datastore.Active = types.BoolPointerValue(ds.Active.PointerBool())
-		datastore.Enabled = types.BoolPointerValue(ds.Enabled.PointerBool())
-		datastore.ID = types.StringValue(ds.ID)
-		datastore.NodeName = types.StringValue(model.NodeName.ValueString())
-		datastore.Shared = types.BoolPointerValue(ds.Shared.PointerBool())
-		datastore.SpaceAvailable = types.Int64PointerValue(ds.SpaceAvailable.PointerInt64())
-		datastore.SpaceTotal = types.Int64PointerValue(ds.SpaceTotal.PointerInt64())
-		datastore.SpaceUsed = types.Int64PointerValue(ds.SpaceUsed.PointerInt64())
-		datastore.SpaceUsedFraction = types.Float64PointerValue(ds.SpaceUsedPercentage.PointerFloat64())
-		datastore.Type = types.StringValue(ds.Type)
-
-		model.Datastores = append(model.Datastores, datastore)
+		if mostFree == nil || ds.SpaceAvailable.ValueInt64() > mostFree.SpaceAvailable.ValueInt64() {
+			mostFree = &datastores[i]
+		}
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+	return mostFree
 }
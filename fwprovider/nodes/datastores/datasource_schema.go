@@ -9,8 +9,13 @@ package datastores
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/types/stringset"
 )
@@ -22,11 +27,24 @@ func (d *Datasource) Schema(
 	resp *datasource.SchemaResponse,
 ) {
 	resp.Schema = schema.Schema{
-		Description: "Retrieves information about all the datastores available to a specific node.",
+		Description: "Retrieves information about all the datastores available to specific nodes.",
 		Attributes: map[string]schema.Attribute{
 			"node_name": schema.StringAttribute{
 				Description: "The name of the node to retrieve the stores from.",
-				Required:    true,
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("node_names")),
+				},
+			},
+			"node_names": schema.ListAttribute{
+				Description: "The names of the nodes to retrieve the stores from, " +
+					"e.g. to compare the capacity of the stores across the cluster.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
 			},
 			"filters": schema.SingleNestedAttribute{
 				Description: "The filters to apply to the stores.",
@@ -93,6 +111,15 @@ func (d *Datasource) Schema(
 					},
 				},
 			},
+			"most_free": schema.StringAttribute{
+				Description: "The ID of the active and enabled store with the most available space " +
+					"among the listed stores, which is null if there is none.",
+				Computed: true,
+			},
+			"most_free_node_name": schema.StringAttribute{
+				Description: "The name of the node of the `most_free` store.",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -44,6 +44,31 @@ func TestAccDatasourceDatastores(t *testing.T) {
 				}),
 			),
 		}}},
+		{"read datastores of multiple nodes", []resource.TestStep{{
+			Config: te.RenderConfig(`data "proxmox_virtual_environment_datastores" "test" {
+				node_names = ["{{.NodeName}}"]
+				filters = {
+					content_types = ["iso"]
+				}
+			}`),
+
+			Check: resource.ComposeTestCheckFunc(
+				test.NoResourceAttributesSet("data.proxmox_virtual_environment_datastores.test", []string{
+					"node_name",
+				}),
+				test.ResourceAttributes("data.proxmox_virtual_environment_datastores.test", map[string]string{
+					"datastores.#":           "1",
+					"datastores.0.node_name": te.NodeName,
+					"most_free":              "local",
+					"most_free_node_name":    te.NodeName,
+				}),
+				test.ResourceAttributesSet("data.proxmox_virtual_environment_datastores.test", []string{
+					"datastores.0.space_available",
+					"datastores.0.space_total",
+					"datastores.0.space_used",
+				}),
+			),
+		}}},
 	}
 
 	for _, tt := range tests {
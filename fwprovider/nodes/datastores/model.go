@@ -13,13 +13,16 @@ import (
 )
 
 type Model struct {
-	NodeName types.String `tfsdk:"node_name"`
-	Filters  *struct {
+	NodeName  types.String   `tfsdk:"node_name"`
+	NodeNames []types.String `tfsdk:"node_names"`
+	Filters   *struct {
 		ContentTypes stringset.Value `tfsdk:"content_types"`
 		ID           types.String    `tfsdk:"id"`
 		Target       types.String    `tfsdk:"target"`
 	} `tfsdk:"filters"`
-	Datastores []Datastore `tfsdk:"datastores"`
+	Datastores       []Datastore  `tfsdk:"datastores"`
+	MostFree         types.String `tfsdk:"most_free"`
+	MostFreeNodeName types.String `tfsdk:"most_free_node_name"`
 }
 
 type Datastore struct {
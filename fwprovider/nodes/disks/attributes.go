@@ -0,0 +1,40 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package disks
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+// addStorageAttribute returns the schema of the flag which registers the new pool as a datastore.
+func addStorageAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Whether to add the pool as a datastore of the node (defaults to `false`).",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+		PlanModifiers: []planmodifier.Bool{
+			boolplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// allowDestroyAttribute returns the schema of the guard which must be set before a pool can be destroyed.
+func allowDestroyAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Whether the pool can be destroyed (defaults to `false`).",
+		MarkdownDescription: "Whether the pool can be destroyed (defaults to `false`). Destroying the pool wipes " +
+			"its disks and removes the datastore configuration, so it must be explicitly allowed, and the change " +
+			"applied, before the resource is removed or replaced.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
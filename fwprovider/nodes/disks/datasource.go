@@ -0,0 +1,177 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package disks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/disks"
+)
+
+var (
+	_ datasource.DataSource              = &disksDataSource{}
+	_ datasource.DataSourceWithConfigure = &disksDataSource{}
+)
+
+type disksDataSourceModel struct {
+	NodeName types.String `tfsdk:"node_name"`
+	Disks    []diskModel  `tfsdk:"disks"`
+}
+
+type diskModel struct {
+	DevPath types.String `tfsdk:"dev_path"`
+	Health  types.String `tfsdk:"health"`
+	Model   types.String `tfsdk:"model"`
+	Serial  types.String `tfsdk:"serial"`
+	Size    types.Int64  `tfsdk:"size"`
+	Type    types.String `tfsdk:"type"`
+	Vendor  types.String `tfsdk:"vendor"`
+	WWN     types.String `tfsdk:"wwn"`
+}
+
+// NewDisksDataSource creates a new data source for listing the unused disks of a node.
+func NewDisksDataSource() datasource.DataSource {
+	return &disksDataSource{}
+}
+
+type disksDataSource struct {
+	client proxmox.Client
+}
+
+func (d *disksDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_node_disks"
+}
+
+// Schema defines the schema for the data source.
+func (d *disksDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the unused disks of a node, which can be used to create ZFS or LVM thin pools.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+			},
+			"disks": schema.ListNestedAttribute{
+				Description: "The list of unused disks.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"dev_path": schema.StringAttribute{
+							Description: "The device path of the disk, e.g. `/dev/sdb`.",
+							Computed:    true,
+						},
+						"health": schema.StringAttribute{
+							Description: "The SMART health status of the disk.",
+							Computed:    true,
+						},
+						"model": schema.StringAttribute{
+							Description: "The model of the disk.",
+							Computed:    true,
+						},
+						"serial": schema.StringAttribute{
+							Description: "The serial number of the disk.",
+							Computed:    true,
+						},
+						"size": schema.Int64Attribute{
+							Description: "The size of the disk in bytes.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the disk, e.g. `hdd`, `ssd` or `nvme`.",
+							Computed:    true,
+						},
+						"vendor": schema.StringAttribute{
+							Description: "The vendor of the disk.",
+							Computed:    true,
+						},
+						"wwn": schema.StringAttribute{
+							Description: "The World Wide Name of the disk.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *disksDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+func (d *disksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model disksDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, err := d.client.Node(model.NodeName.ValueString()).Disks().ListDisks(ctx, &disks.ListRequestBody{
+		Type: ptr.Ptr("unused"),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Disks",
+			fmt.Sprintf("Could not list disks of node %q: %s", model.NodeName.ValueString(), err.Error()),
+		)
+
+		return
+	}
+
+	model.Disks = make([]diskModel, 0, len(list))
+
+	for _, disk := range list {
+		model.Disks = append(model.Disks, diskModel{
+			DevPath: types.StringValue(disk.DevPath),
+			Health:  types.StringPointerValue(disk.Health),
+			Model:   types.StringPointerValue(disk.Model),
+			Serial:  types.StringPointerValue(disk.Serial),
+			Size:    types.Int64Value(disk.Size),
+			Type:    types.StringPointerValue(disk.Type),
+			Vendor:  types.StringPointerValue(disk.Vendor),
+			WWN:     types.StringPointerValue(disk.WWN),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
@@ -0,0 +1,305 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package disks
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/disks"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var (
+	_ resource.Resource              = &lvmThinPoolResource{}
+	_ resource.ResourceWithConfigure = &lvmThinPoolResource{}
+)
+
+type lvmThinPoolResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	NodeName     types.String `tfsdk:"node_name"`
+	Name         types.String `tfsdk:"name"`
+	Device       types.String `tfsdk:"device"`
+	AddStorage   types.Bool   `tfsdk:"add_storage"`
+	AllowDestroy types.Bool   `tfsdk:"allow_destroy"`
+	VolumeGroup  types.String `tfsdk:"volume_group"`
+	Size         types.Int64  `tfsdk:"size"`
+	MetadataSize types.Int64  `tfsdk:"metadata_size"`
+}
+
+// NewLVMThinPoolResource creates a new resource for managing LVM thin pools on the disks of a node.
+func NewLVMThinPoolResource() resource.Resource {
+	return &lvmThinPoolResource{}
+}
+
+type lvmThinPoolResource struct {
+	client proxmox.Client
+}
+
+func (r *lvmThinPoolResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_node_lvm_thinpool"
+}
+
+// Schema defines the schema for the resource.
+func (r *lvmThinPoolResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an LVM thin pool created on an unused disk of a Proxmox VE node.",
+		MarkdownDescription: "Manages an LVM thin pool created on an unused disk of a Proxmox VE node. " +
+			"Proxmox VE creates a volume group and a thin pool, both named after `name`, which use the whole disk. " +
+			"The pool can only be destroyed when `allow_destroy` is set to `true`.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ResourceID("A unique identifier with format `<node name>:<pool name>`"),
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the volume group and of the thin pool.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[A-Za-z][A-Za-z0-9\-_.]*$`),
+						"must start with a letter and contain only alphanumeric characters, '-', '_' or '.'",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"device": schema.StringAttribute{
+				Description: "The device path of the unused disk to create the pool on, e.g. `/dev/sdb`.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"add_storage":   addStorageAttribute(),
+			"allow_destroy": allowDestroyAttribute(),
+			"volume_group": schema.StringAttribute{
+				Description: "The name of the volume group of the thin pool.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Description: "The size of the thin pool in bytes.",
+				Computed:    true,
+			},
+			"metadata_size": schema.Int64Attribute{
+				Description: "The size of the thin pool metadata in bytes.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *lvmThinPoolResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+func (r *lvmThinPoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan lvmThinPoolResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Node(plan.NodeName.ValueString()).Disks().CreateLVMThinPool(ctx, &disks.LVMThinPoolCreateRequestBody{
+		AddStorage: proxmoxtypes.CustomBool(plan.AddStorage.ValueBool()).Pointer(),
+		Device:     plan.Device.ValueString(),
+		Name:       plan.Name.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating LVM thin pool",
+			fmt.Sprintf("Could not create LVM thin pool %q, unexpected error: %s", plan.Name.ValueString(), err.Error()),
+		)
+
+		return
+	}
+
+	plan.ID = types.StringValue(plan.NodeName.ValueString() + ":" + plan.Name.ValueString())
+	plan.VolumeGroup = plan.Name
+
+	found := r.read(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"LVM thin pool not found after creation",
+			fmt.Sprintf(
+				"Pool %q on node %q could not be read after creation",
+				plan.Name.ValueString(), plan.NodeName.ValueString()),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *lvmThinPoolResource) read(ctx context.Context, model *lvmThinPoolResourceModel, diags *diag.Diagnostics) bool {
+	pools, err := r.client.Node(model.NodeName.ValueString()).Disks().ListLVMThinPools(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error listing LVM thin pools",
+			"Could not list LVM thin pools, unexpected error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	for _, pool := range pools {
+		if pool.LV != model.Name.ValueString() || pool.VG != model.VolumeGroup.ValueString() {
+			continue
+		}
+
+		model.Size = types.Int64Value(pool.LVSize)
+		model.MetadataSize = types.Int64Value(pool.MetadataSize)
+
+		return true
+	}
+
+	return false
+}
+
+// Read reads an LVM thin pool.
+func (r *lvmThinPoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state lvmThinPoolResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update updates an LVM thin pool. Only `allow_destroy` can be changed in place, so nothing is sent to the API.
+func (r *lvmThinPoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan lvmThinPoolResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"LVM thin pool not found",
+			fmt.Sprintf("Pool %q on node %q could not be read", plan.Name.ValueString(), plan.NodeName.ValueString()),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete destroys an LVM thin pool and its volume group, if allowed.
+func (r *lvmThinPoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state lvmThinPoolResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.AllowDestroy.ValueBool() {
+		resp.Diagnostics.AddError(
+			"LVM thin pool destroy not allowed",
+			fmt.Sprintf("Pool %q on node %q can only be destroyed when `allow_destroy` is set to `true`. "+
+				"Set it, apply the change, and try again.", state.Name.ValueString(), state.NodeName.ValueString()),
+		)
+
+		return
+	}
+
+	err := r.client.Node(state.NodeName.ValueString()).Disks().DeleteLVMThinPool(
+		ctx,
+		state.Name.ValueString(),
+		&disks.PoolDeleteRequestBody{
+			CleanupConfig: proxmoxtypes.CustomBool(true).Pointer(),
+			CleanupDisks:  proxmoxtypes.CustomBool(true).Pointer(),
+			VolumeGroup:   state.VolumeGroup.ValueStringPointer(),
+		},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting LVM thin pool",
+			fmt.Sprintf("Could not delete LVM thin pool %q, unexpected error: %s", state.Name.ValueString(), err.Error()),
+		)
+	}
+}
@@ -0,0 +1,359 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package disks
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/disks"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var (
+	_ resource.Resource              = &zfsPoolResource{}
+	_ resource.ResourceWithConfigure = &zfsPoolResource{}
+)
+
+type zfsPoolResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	NodeName     types.String   `tfsdk:"node_name"`
+	Name         types.String   `tfsdk:"name"`
+	Devices      []types.String `tfsdk:"devices"`
+	RAIDLevel    types.String   `tfsdk:"raid_level"`
+	Ashift       types.Int64    `tfsdk:"ashift"`
+	Compression  types.String   `tfsdk:"compression"`
+	AddStorage   types.Bool     `tfsdk:"add_storage"`
+	AllowDestroy types.Bool     `tfsdk:"allow_destroy"`
+	Size         types.Int64    `tfsdk:"size"`
+	Health       types.String   `tfsdk:"health"`
+}
+
+func (m *zfsPoolResourceModel) toCreateRequestBody() *disks.ZFSPoolCreateRequestBody {
+	devices := make([]string, 0, len(m.Devices))
+	for _, device := range m.Devices {
+		devices = append(devices, device.ValueString())
+	}
+
+	return &disks.ZFSPoolCreateRequestBody{
+		AddStorage:  proxmoxtypes.CustomBool(m.AddStorage.ValueBool()).Pointer(),
+		Ashift:      m.Ashift.ValueInt64Pointer(),
+		Compression: m.Compression.ValueStringPointer(),
+		Devices:     strings.Join(devices, ","),
+		Name:        m.Name.ValueString(),
+		RAIDLevel:   m.RAIDLevel.ValueString(),
+	}
+}
+
+// NewZFSPoolResource creates a new resource for managing ZFS pools on the disks of a node.
+func NewZFSPoolResource() resource.Resource {
+	return &zfsPoolResource{}
+}
+
+type zfsPoolResource struct {
+	client proxmox.Client
+}
+
+func (r *zfsPoolResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_node_zfs_pool"
+}
+
+// Schema defines the schema for the resource.
+func (r *zfsPoolResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a ZFS pool created on the unused disks of a Proxmox VE node.",
+		MarkdownDescription: "Manages a ZFS pool created on the unused disks of a Proxmox VE node. " +
+			"The pool can only be destroyed when `allow_destroy` is set to `true`.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ResourceID("A unique identifier with format `<node name>:<pool name>`"),
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the pool.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[A-Za-z][A-Za-z0-9\-_.]*$`),
+						"must start with a letter and contain only alphanumeric characters, '-', '_' or '.'",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"devices": schema.ListAttribute{
+				Description: "The device paths of the unused disks to create the pool on, e.g. `/dev/sdb`.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"raid_level": schema.StringAttribute{
+				Description: "The RAID level of the pool. Must be one of `single`, `mirror`, `raid10`, " +
+					"`raidz`, `raidz2`, `raidz3`, `draid`, `draid2` or `draid3`.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						"single", "mirror", "raid10", "raidz", "raidz2", "raidz3", "draid", "draid2", "draid3",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ashift": schema.Int64Attribute{
+				Description: "The pool sector size exponent (defaults to `12`).",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(12),
+				Validators: []validator.Int64{
+					int64validator.Between(9, 16),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"compression": schema.StringAttribute{
+				Description: "The compression algorithm of the pool (defaults to `on`). Must be one of `on`, " +
+					"`off`, `gzip`, `lz4`, `lzjb`, `zle` or `zstd`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("on"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("on", "off", "gzip", "lz4", "lzjb", "zle", "zstd"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"add_storage":   addStorageAttribute(),
+			"allow_destroy": allowDestroyAttribute(),
+			"size": schema.Int64Attribute{
+				Description: "The size of the pool in bytes.",
+				Computed:    true,
+			},
+			"health": schema.StringAttribute{
+				Description: "The health of the pool.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *zfsPoolResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+func (r *zfsPoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan zfsPoolResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Node(plan.NodeName.ValueString()).Disks().CreateZFSPool(ctx, plan.toCreateRequestBody())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating ZFS pool",
+			fmt.Sprintf("Could not create ZFS pool %q, unexpected error: %s", plan.Name.ValueString(), err.Error()),
+		)
+
+		return
+	}
+
+	plan.ID = types.StringValue(plan.NodeName.ValueString() + ":" + plan.Name.ValueString())
+
+	found := r.read(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"ZFS pool not found after creation",
+			fmt.Sprintf(
+				"Pool %q on node %q could not be read after creation",
+				plan.Name.ValueString(), plan.NodeName.ValueString()),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *zfsPoolResource) read(ctx context.Context, model *zfsPoolResourceModel, diags *diag.Diagnostics) bool {
+	pools, err := r.client.Node(model.NodeName.ValueString()).Disks().ListZFSPools(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error listing ZFS pools",
+			"Could not list ZFS pools, unexpected error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	for _, pool := range pools {
+		if pool.Name != model.Name.ValueString() {
+			continue
+		}
+
+		model.Size = types.Int64Value(pool.Size)
+		model.Health = types.StringValue(pool.Health)
+
+		return true
+	}
+
+	return false
+}
+
+// Read reads a ZFS pool.
+func (r *zfsPoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state zfsPoolResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update updates a ZFS pool. Only `allow_destroy` can be changed in place, so nothing is sent to the API.
+func (r *zfsPoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan zfsPoolResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"ZFS pool not found",
+			fmt.Sprintf("Pool %q on node %q could not be read", plan.Name.ValueString(), plan.NodeName.ValueString()),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete destroys a ZFS pool, if allowed.
+func (r *zfsPoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state zfsPoolResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.AllowDestroy.ValueBool() {
+		resp.Diagnostics.AddError(
+			"ZFS pool destroy not allowed",
+			fmt.Sprintf("Pool %q on node %q can only be destroyed when `allow_destroy` is set to `true`. "+
+				"Set it, apply the change, and try again.", state.Name.ValueString(), state.NodeName.ValueString()),
+		)
+
+		return
+	}
+
+	err := r.client.Node(state.NodeName.ValueString()).Disks().DeleteZFSPool(
+		ctx,
+		state.Name.ValueString(),
+		&disks.PoolDeleteRequestBody{
+			CleanupConfig: proxmoxtypes.CustomBool(true).Pointer(),
+			CleanupDisks:  proxmoxtypes.CustomBool(true).Pointer(),
+		},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting ZFS pool",
+			fmt.Sprintf("Could not delete ZFS pool %q, unexpected error: %s", state.Name.ValueString(), err.Error()),
+		)
+	}
+}
@@ -0,0 +1,288 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	proxmoxfirewall "github.com/bpg/terraform-provider-proxmox/proxmox/firewall"
+)
+
+// defaultLogLimit is the number of log entries returned when no limit is configured.
+const defaultLogLimit = 50
+
+var (
+	_ datasource.DataSource              = &logDataSource{}
+	_ datasource.DataSourceWithConfigure = &logDataSource{}
+)
+
+type logDataSourceModel struct {
+	ContainerID types.Int64     `tfsdk:"container_id"`
+	Entries     []logEntryModel `tfsdk:"entries"`
+	Limit       types.Int64     `tfsdk:"limit"`
+	NodeName    types.String    `tfsdk:"node_name"`
+	Since       types.String    `tfsdk:"since"`
+	VMID        types.Int64     `tfsdk:"vm_id"`
+}
+
+type logEntryModel struct {
+	Action    types.String `tfsdk:"action"`
+	Chain     types.String `tfsdk:"chain"`
+	Dest      types.String `tfsdk:"dest"`
+	DPort     types.String `tfsdk:"dport"`
+	Line      types.Int64  `tfsdk:"line"`
+	Message   types.String `tfsdk:"message"`
+	Policy    types.Bool   `tfsdk:"policy"`
+	Proto     types.String `tfsdk:"proto"`
+	Source    types.String `tfsdk:"source"`
+	SPort     types.String `tfsdk:"sport"`
+	Timestamp types.String `tfsdk:"timestamp"`
+}
+
+// NewLogDataSource creates a new data source for reading the firewall log of a node, VM or container.
+func NewLogDataSource() datasource.DataSource {
+	return &logDataSource{}
+}
+
+type logDataSource struct {
+	client proxmox.Client
+}
+
+func (d *logDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_log"
+}
+
+// Schema defines the schema for the data source.
+func (d *logDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the firewall log of a node, VM or container.",
+		MarkdownDescription: "Retrieves the firewall log of a node, VM or container.\n\n" +
+			"Only the packets matched by rules or policies with logging enabled are written to the log, " +
+			"so the `log` level of the rules being debugged must be set to a value other than `nolog`.",
+		Attributes: map[string]schema.Attribute{
+			"container_id": schema.Int64Attribute{
+				Description: "The ID of the container to read the firewall log of.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.ConflictsWith(path.MatchRoot("vm_id")),
+				},
+			},
+			"entries": schema.ListNestedAttribute{
+				Description: "The entries of the firewall log, oldest first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							Description: "The action applied to the packet, e.g. `ACCEPT`, `DROP` or `REJECT`.",
+							Computed:    true,
+						},
+						"chain": schema.StringAttribute{
+							Description: "The firewall chain which logged the packet, e.g. `tap100i0-IN`.",
+							Computed:    true,
+						},
+						"dest": schema.StringAttribute{
+							Description: "The destination address of the packet.",
+							Computed:    true,
+						},
+						"dport": schema.StringAttribute{
+							Description: "The destination port of the packet.",
+							Computed:    true,
+						},
+						"line": schema.Int64Attribute{
+							Description: "The line number of the entry in the log.",
+							Computed:    true,
+						},
+						"message": schema.StringAttribute{
+							Description: "The text of the entry following the timestamp.",
+							Computed:    true,
+						},
+						"policy": schema.BoolAttribute{
+							Description: "Whether the action was applied by the chain policy rather than by a rule.",
+							Computed:    true,
+						},
+						"proto": schema.StringAttribute{
+							Description: "The protocol of the packet, e.g. `TCP`, `UDP` or `ICMP`.",
+							Computed:    true,
+						},
+						"source": schema.StringAttribute{
+							Description: "The source address of the packet.",
+							Computed:    true,
+						},
+						"sport": schema.StringAttribute{
+							Description: "The source port of the packet.",
+							Computed:    true,
+						},
+						"timestamp": schema.StringAttribute{
+							Description: "The time at which the entry was logged, in RFC3339 format.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"limit": schema.Int64Attribute{
+				Description: fmt.Sprintf(
+					"The maximum number of entries to return, counted from the end of the log (defaults to `%d`).",
+					defaultLogLimit,
+				),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+			},
+			"since": schema.StringAttribute{
+				Description: "Only return the entries logged at or after this time, in RFC3339 format.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.NewParseValidator(func(s string) (time.Time, error) {
+						return time.Parse(time.RFC3339, s)
+					}, "must be a valid RFC3339 date"),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				Description: "The ID of the VM to read the firewall log of. The log of the host is read " +
+					"if neither `vm_id` nor `container_id` is set.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.ConflictsWith(path.MatchRoot("container_id")),
+				},
+			},
+		},
+	}
+}
+
+func (d *logDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+func (d *logDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model logDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeClient := d.client.Node(model.NodeName.ValueString())
+
+	var (
+		logClient proxmoxfirewall.Log
+		target    string
+	)
+
+	switch {
+	case !model.VMID.IsNull():
+		logClient = nodeClient.VM(int(model.VMID.ValueInt64())).FirewallLog()
+		target = fmt.Sprintf("VM %d", model.VMID.ValueInt64())
+	case !model.ContainerID.IsNull():
+		logClient = nodeClient.Container(int(model.ContainerID.ValueInt64())).FirewallLog()
+		target = fmt.Sprintf("container %d", model.ContainerID.ValueInt64())
+	default:
+		logClient = nodeClient.FirewallLog()
+		target = fmt.Sprintf("node %q", model.NodeName.ValueString())
+	}
+
+	params := &proxmoxfirewall.LogRequestParams{
+		Limit: defaultLogLimit,
+	}
+
+	if !model.Limit.IsNull() {
+		params.Limit = int(model.Limit.ValueInt64())
+	}
+
+	if !model.Since.IsNull() {
+		// the value has been validated already
+		since, _ := time.Parse(time.RFC3339, model.Since.ValueString())
+		params.Since = &since
+	}
+
+	entries, err := logClient.GetLog(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Firewall Log",
+			fmt.Sprintf("Could not read the firewall log of %s: %s", target, err.Error()),
+		)
+
+		return
+	}
+
+	model.Entries = make([]logEntryModel, 0, len(entries))
+
+	for _, entry := range entries {
+		e := logEntryModel{
+			Action:    stringValueOrNull(entry.Action),
+			Chain:     stringValueOrNull(entry.Chain),
+			Dest:      stringValueOrNull(entry.Destination),
+			DPort:     stringValueOrNull(entry.DestinationPort),
+			Line:      types.Int64Value(int64(entry.LineNumber)),
+			Message:   types.StringValue(entry.Message),
+			Policy:    types.BoolValue(entry.Policy),
+			Proto:     stringValueOrNull(entry.Protocol),
+			Source:    stringValueOrNull(entry.Source),
+			SPort:     stringValueOrNull(entry.SourcePort),
+			Timestamp: types.StringNull(),
+		}
+
+		if entry.Timestamp != nil {
+			e.Timestamp = types.StringValue(entry.Timestamp.Format(time.RFC3339))
+		}
+
+		model.Entries = append(model.Entries, e)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(s)
+}
@@ -0,0 +1,39 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package firewall_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccDatasourceFirewallLog(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitEnvironment(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{{
+			Config: te.RenderConfig(`data "proxmox_virtual_environment_firewall_log" "test" {
+				node_name = "{{.NodeName}}"
+				limit     = 10
+			}`),
+			Check: resource.ComposeTestCheckFunc(
+				test.ResourceAttributesSet("data.proxmox_virtual_environment_firewall_log.test", []string{
+					"node_name",
+					"entries.#",
+				}),
+			),
+		}},
+	})
+}
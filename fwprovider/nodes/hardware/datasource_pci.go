@@ -0,0 +1,339 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package hardware
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+)
+
+var (
+	_ datasource.DataSource              = &pciDevicesDataSource{}
+	_ datasource.DataSourceWithConfigure = &pciDevicesDataSource{}
+)
+
+type pciDevicesDataSourceModel struct {
+	NodeName         types.String     `tfsdk:"node_name"`
+	Filters          *pciFiltersModel `tfsdk:"filters"`
+	IncludeMDevTypes types.Bool       `tfsdk:"include_mdev_types"`
+	IDs              []types.String   `tfsdk:"ids"`
+	Devices          []pciDeviceModel `tfsdk:"devices"`
+}
+
+type pciFiltersModel struct {
+	Class           types.String `tfsdk:"class"`
+	DeviceNameRegex types.String `tfsdk:"device_name_regex"`
+	HasIOMMUGroup   types.Bool   `tfsdk:"has_iommu_group"`
+	MDevCapable     types.Bool   `tfsdk:"mdev_capable"`
+	VendorID        types.String `tfsdk:"vendor_id"`
+}
+
+type pciDeviceModel struct {
+	Class      types.String    `tfsdk:"class"`
+	DeviceID   types.String    `tfsdk:"device_id"`
+	DeviceName types.String    `tfsdk:"device_name"`
+	ID         types.String    `tfsdk:"id"`
+	IOMMUGroup types.Int64     `tfsdk:"iommu_group"`
+	MDev       types.Bool      `tfsdk:"mdev"`
+	MDevTypes  []mdevTypeModel `tfsdk:"mdev_types"`
+	VendorID   types.String    `tfsdk:"vendor_id"`
+	VendorName types.String    `tfsdk:"vendor_name"`
+}
+
+type mdevTypeModel struct {
+	Available   types.Int64  `tfsdk:"available"`
+	Description types.String `tfsdk:"description"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+}
+
+// NewPCIDevicesDataSource creates a new data source for listing the PCI devices of a node.
+func NewPCIDevicesDataSource() datasource.DataSource {
+	return &pciDevicesDataSource{}
+}
+
+type pciDevicesDataSource struct {
+	client proxmox.Client
+}
+
+func (d *pciDevicesDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_node_pci_devices"
+}
+
+// Schema defines the schema for the data source.
+func (d *pciDevicesDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the PCI devices of a node, e.g. to select the device to pass through to a VM.",
+		MarkdownDescription: "Retrieves the PCI devices of a node, e.g. to select the device to pass through to a VM. " +
+			"The bridges, memory controllers and processors are not listed.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+			},
+			"filters": schema.SingleNestedAttribute{
+				Description: "The filters to apply to the devices.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"class": schema.StringAttribute{
+						Description: "Only list the devices whose class starts with the given hexadecimal prefix, " +
+							"e.g. `03` for display controllers, or `0300` for VGA compatible controllers.",
+						Optional: true,
+					},
+					"device_name_regex": schema.StringAttribute{
+						Description: "Only list the devices whose name matches the given regular expression.",
+						Optional:    true,
+						Validators:  []validator.String{validators.RegexValidator()},
+					},
+					"has_iommu_group": schema.BoolAttribute{
+						Description: "Only list the devices which are, or are not, assigned to an IOMMU group.",
+						Optional:    true,
+					},
+					"mdev_capable": schema.BoolAttribute{
+						Description: "Only list the devices which do, or do not, support mediated devices.",
+						Optional:    true,
+					},
+					"vendor_id": schema.StringAttribute{
+						Description: "Only list the devices with the given hexadecimal vendor ID, e.g. `10de`.",
+						Optional:    true,
+					},
+				},
+			},
+			"include_mdev_types": schema.BoolAttribute{
+				Description: "Whether to retrieve the mediated device types of the devices supporting them. " +
+					"It requires a request per device, so it is disabled by default.",
+				Optional: true,
+			},
+			"ids": schema.ListAttribute{
+				Description: "The sorted IDs of the devices, e.g. `0000:01:00.0`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"devices": schema.ListNestedAttribute{
+				Description: "The list of devices, sorted by ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"class": schema.StringAttribute{
+							Description: "The class of the device, e.g. `0x030000`.",
+							Computed:    true,
+						},
+						"device_id": schema.StringAttribute{
+							Description: "The device ID, e.g. `0x1e84`.",
+							Computed:    true,
+						},
+						"device_name": schema.StringAttribute{
+							Description: "The name of the device.",
+							Computed:    true,
+						},
+						"id": schema.StringAttribute{
+							Description: "The ID of the device, e.g. `0000:01:00.0`.",
+							Computed:    true,
+						},
+						"iommu_group": schema.Int64Attribute{
+							Description: "The IOMMU group of the device, which is `-1` if the IOMMU is not active.",
+							Computed:    true,
+						},
+						"mdev": schema.BoolAttribute{
+							Description: "Whether the device supports mediated devices.",
+							Computed:    true,
+						},
+						"mdev_types": schema.ListNestedAttribute{
+							Description: "The mediated device types of the device, " +
+								"only retrieved if `include_mdev_types` is `true`.",
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"available": schema.Int64Attribute{
+										Description: "The number of the mediated devices of the type which can still be created.",
+										Computed:    true,
+									},
+									"description": schema.StringAttribute{
+										Description: "The description of the type.",
+										Computed:    true,
+									},
+									"name": schema.StringAttribute{
+										Description: "The name of the type.",
+										Computed:    true,
+									},
+									"type": schema.StringAttribute{
+										Description: "The type, to be used as `mdev` of a `hostpci` device, e.g. `nvidia-63`.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+						"vendor_id": schema.StringAttribute{
+							Description: "The vendor ID, e.g. `0x10de`.",
+							Computed:    true,
+						},
+						"vendor_name": schema.StringAttribute{
+							Description: "The name of the vendor.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *pciDevicesDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+func (d *pciDevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model pciDevicesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeClient := d.client.Node(model.NodeName.ValueString())
+
+	list, err := nodeClient.ListPCIDevices(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read PCI Devices",
+			fmt.Sprintf("Could not list PCI devices of node %q: %s", model.NodeName.ValueString(), err.Error()),
+		)
+
+		return
+	}
+
+	list = filterPCIDevices(list, model.Filters)
+
+	model.IDs = make([]types.String, 0, len(list))
+	model.Devices = make([]pciDeviceModel, 0, len(list))
+
+	for _, device := range list {
+		m := pciDeviceModel{
+			Class:      types.StringValue(device.Class),
+			DeviceID:   types.StringValue(device.Device),
+			DeviceName: types.StringPointerValue(device.DeviceName),
+			ID:         types.StringValue(device.ID),
+			IOMMUGroup: types.Int64Value(int64(device.IOMMUGroup)),
+			MDev:       types.BoolValue(device.MDev != nil && bool(*device.MDev)),
+			VendorID:   types.StringValue(device.Vendor),
+			VendorName: types.StringPointerValue(device.VendorName),
+		}
+
+		// the mediated device types are listed by a request per device, which is slow for the GPUs with many types
+		if model.IncludeMDevTypes.ValueBool() && m.MDev.ValueBool() {
+			mdevTypes, err := nodeClient.ListPCIDeviceMDevTypes(ctx, device.ID)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Read PCI Devices",
+					fmt.Sprintf("Could not list mediated device types of node %q: %s", model.NodeName.ValueString(), err.Error()),
+				)
+
+				return
+			}
+
+			m.MDevTypes = make([]mdevTypeModel, 0, len(mdevTypes))
+
+			for _, t := range mdevTypes {
+				m.MDevTypes = append(m.MDevTypes, mdevTypeModel{
+					Available:   types.Int64Value(int64(t.Available)),
+					Description: types.StringValue(t.Description),
+					Name:        types.StringPointerValue(t.Name),
+					Type:        types.StringValue(t.Type),
+				})
+			}
+
+			slices.SortFunc(m.MDevTypes, func(a, b mdevTypeModel) int {
+				return strings.Compare(a.Type.ValueString(), b.Type.ValueString())
+			})
+		}
+
+		model.IDs = append(model.IDs, m.ID)
+		model.Devices = append(model.Devices, m)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+// filterPCIDevices returns the devices matching the filters, sorted by ID.
+func filterPCIDevices(list []*nodes.PCIDeviceListResponseData, f *pciFiltersModel) []*nodes.PCIDeviceListResponseData {
+	filtered := make([]*nodes.PCIDeviceListResponseData, 0, len(list))
+
+	if f == nil {
+		f = &pciFiltersModel{}
+	}
+
+	nameRegex := compileNameRegex(f.DeviceNameRegex)
+
+	for _, device := range list {
+		if !f.Class.IsNull() && !strings.HasPrefix(normalizeHexID(device.Class), normalizeHexID(f.Class.ValueString())) {
+			continue
+		}
+
+		if !f.VendorID.IsNull() && normalizeHexID(device.Vendor) != normalizeHexID(f.VendorID.ValueString()) {
+			continue
+		}
+
+		if !f.HasIOMMUGroup.IsNull() && (device.IOMMUGroup >= 0) != f.HasIOMMUGroup.ValueBool() {
+			continue
+		}
+
+		if !f.MDevCapable.IsNull() && (device.MDev != nil && bool(*device.MDev)) != f.MDevCapable.ValueBool() {
+			continue
+		}
+
+		if !matchesName(nameRegex, device.DeviceName) {
+			continue
+		}
+
+		filtered = append(filtered, device)
+	}
+
+	slices.SortFunc(filtered, func(a, b *nodes.PCIDeviceListResponseData) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return filtered
+}
@@ -0,0 +1,61 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package hardware_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccDataSourceNodeHardwareDevices(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitEnvironment(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				data "proxmox_virtual_environment_node_pci_devices" "test" {
+					node_name = "{{.NodeName}}"
+				}
+
+				data "proxmox_virtual_environment_node_pci_devices" "none" {
+					node_name = "{{.NodeName}}"
+					filters = {
+						device_name_regex = "^no such device$"
+					}
+				}
+
+				data "proxmox_virtual_environment_node_usb_devices" "test" {
+					node_name = "{{.NodeName}}"
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributesSet("data.proxmox_virtual_environment_node_pci_devices.test", []string{
+						"devices.0.class",
+						"devices.0.id",
+						"devices.0.vendor_id",
+						"ids.0",
+					}),
+					test.ResourceAttributes("data.proxmox_virtual_environment_node_pci_devices.none", map[string]string{
+						"devices.#": "0",
+						"ids.#":     "0",
+					}),
+					test.ResourceAttributesSet("data.proxmox_virtual_environment_node_usb_devices.test", []string{
+						"ids.#",
+					}),
+				),
+			},
+		},
+	})
+}
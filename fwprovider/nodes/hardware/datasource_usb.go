@@ -0,0 +1,263 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package hardware
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+)
+
+var (
+	_ datasource.DataSource              = &usbDevicesDataSource{}
+	_ datasource.DataSourceWithConfigure = &usbDevicesDataSource{}
+)
+
+type usbDevicesDataSourceModel struct {
+	NodeName types.String     `tfsdk:"node_name"`
+	Filters  *usbFiltersModel `tfsdk:"filters"`
+	IDs      []types.String   `tfsdk:"ids"`
+	Devices  []usbDeviceModel `tfsdk:"devices"`
+}
+
+type usbFiltersModel struct {
+	Class           types.Int64  `tfsdk:"class"`
+	DeviceNameRegex types.String `tfsdk:"device_name_regex"`
+	VendorID        types.String `tfsdk:"vendor_id"`
+}
+
+type usbDeviceModel struct {
+	Class        types.Int64  `tfsdk:"class"`
+	DeviceName   types.String `tfsdk:"device_name"`
+	ID           types.String `tfsdk:"id"`
+	Manufacturer types.String `tfsdk:"manufacturer"`
+	Port         types.String `tfsdk:"port"`
+	ProductID    types.String `tfsdk:"product_id"`
+	Serial       types.String `tfsdk:"serial"`
+	Speed        types.String `tfsdk:"speed"`
+	VendorID     types.String `tfsdk:"vendor_id"`
+}
+
+// NewUSBDevicesDataSource creates a new data source for listing the USB devices of a node.
+func NewUSBDevicesDataSource() datasource.DataSource {
+	return &usbDevicesDataSource{}
+}
+
+type usbDevicesDataSource struct {
+	client proxmox.Client
+}
+
+func (d *usbDevicesDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_node_usb_devices"
+}
+
+// Schema defines the schema for the data source.
+func (d *usbDevicesDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the USB devices of a node, e.g. to select the device to pass through to a VM.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+			},
+			"filters": schema.SingleNestedAttribute{
+				Description: "The filters to apply to the devices.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"class": schema.Int64Attribute{
+						Description: "Only list the devices of the given class, e.g. `9` for hubs.",
+						Optional:    true,
+					},
+					"device_name_regex": schema.StringAttribute{
+						Description: "Only list the devices whose product name matches the given regular expression.",
+						Optional:    true,
+						Validators:  []validator.String{validators.RegexValidator()},
+					},
+					"vendor_id": schema.StringAttribute{
+						Description: "Only list the devices with the given hexadecimal vendor ID, e.g. `046d`.",
+						Optional:    true,
+					},
+				},
+			},
+			"ids": schema.ListAttribute{
+				Description: "The sorted IDs of the devices, e.g. `046d:c52b`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"devices": schema.ListNestedAttribute{
+				Description: "The list of devices, sorted by ID and port.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"class": schema.Int64Attribute{
+							Description: "The class of the device.",
+							Computed:    true,
+						},
+						"device_name": schema.StringAttribute{
+							Description: "The product name of the device.",
+							Computed:    true,
+						},
+						"id": schema.StringAttribute{
+							Description: "The ID of the device in the `<vendor_id>:<product_id>` format, " +
+								"to be used as `host` of a `usb` device, e.g. `046d:c52b`.",
+							Computed: true,
+						},
+						"manufacturer": schema.StringAttribute{
+							Description: "The manufacturer of the device.",
+							Computed:    true,
+						},
+						"port": schema.StringAttribute{
+							Description: "The port of the device in the `<bus>-<port path>` format, " +
+								"to pass through whatever device is plugged into it, e.g. `1-2.3`.",
+							Computed: true,
+						},
+						"product_id": schema.StringAttribute{
+							Description: "The product ID, e.g. `c52b`.",
+							Computed:    true,
+						},
+						"serial": schema.StringAttribute{
+							Description: "The serial number of the device.",
+							Computed:    true,
+						},
+						"speed": schema.StringAttribute{
+							Description: "The speed of the device, e.g. `480` for USB 2.0 high speed.",
+							Computed:    true,
+						},
+						"vendor_id": schema.StringAttribute{
+							Description: "The vendor ID, e.g. `046d`.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *usbDevicesDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+func (d *usbDevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model usbDevicesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, err := d.client.Node(model.NodeName.ValueString()).ListUSBDevices(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read USB Devices",
+			fmt.Sprintf("Could not list USB devices of node %q: %s", model.NodeName.ValueString(), err.Error()),
+		)
+
+		return
+	}
+
+	devices := filterUSBDevices(list, model.Filters)
+
+	model.IDs = make([]types.String, 0, len(devices))
+	model.Devices = devices
+
+	for _, device := range devices {
+		model.IDs = append(model.IDs, device.ID)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+// filterUSBDevices returns the models of the devices matching the filters, sorted by ID and port.
+func filterUSBDevices(list []*nodes.USBDeviceListResponseData, f *usbFiltersModel) []usbDeviceModel {
+	devices := make([]usbDeviceModel, 0, len(list))
+
+	if f == nil {
+		f = &usbFiltersModel{}
+	}
+
+	nameRegex := compileNameRegex(f.DeviceNameRegex)
+
+	for _, device := range list {
+		if !f.Class.IsNull() && int64(device.Class) != f.Class.ValueInt64() {
+			continue
+		}
+
+		if !f.VendorID.IsNull() && normalizeHexID(device.VendorID) != normalizeHexID(f.VendorID.ValueString()) {
+			continue
+		}
+
+		if !matchesName(nameRegex, device.Product) {
+			continue
+		}
+
+		// the root hubs have no port path
+		port := types.StringNull()
+		if device.USBPath != nil {
+			port = types.StringValue(fmt.Sprintf("%d-%s", device.BusNum, *device.USBPath))
+		}
+
+		devices = append(devices, usbDeviceModel{
+			Class:        types.Int64Value(int64(device.Class)),
+			DeviceName:   types.StringPointerValue(device.Product),
+			ID:           types.StringValue(fmt.Sprintf("%s:%s", normalizeHexID(device.VendorID), normalizeHexID(device.ProductID))),
+			Manufacturer: types.StringPointerValue(device.Manufacturer),
+			Port:         port,
+			ProductID:    types.StringValue(normalizeHexID(device.ProductID)),
+			Serial:       types.StringPointerValue(device.Serial),
+			Speed:        types.StringValue(device.Speed),
+			VendorID:     types.StringValue(normalizeHexID(device.VendorID)),
+		})
+	}
+
+	slices.SortFunc(devices, func(a, b usbDeviceModel) int {
+		return cmp.Or(
+			cmp.Compare(a.ID.ValueString(), b.ID.ValueString()),
+			cmp.Compare(a.Port.ValueString(), b.Port.ValueString()),
+		)
+	})
+
+	return devices
+}
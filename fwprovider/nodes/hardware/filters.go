@@ -0,0 +1,35 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package hardware
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// normalizeHexID lowercases a hexadecimal ID, e.g. a vendor ID or a class, and strips its `0x` prefix,
+// as PVE reports the IDs of PCI devices with the prefix, and the IDs of USB devices without it.
+func normalizeHexID(id string) string {
+	return strings.TrimPrefix(strings.ToLower(id), "0x")
+}
+
+// compileNameRegex compiles the regular expression of a name filter, which is nil if the filter is not set.
+// The expression is validated by the schema, so it compiles.
+func compileNameRegex(v types.String) *regexp.Regexp {
+	if v.ValueString() == "" {
+		return nil
+	}
+
+	return regexp.MustCompile(v.ValueString())
+}
+
+// matchesName returns true if there is no name filter, or the name is set and matches it.
+func matchesName(re *regexp.Regexp, name *string) bool {
+	return re == nil || (name != nil && re.MatchString(*name))
+}
@@ -0,0 +1,97 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package hardware
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+func Test_filterPCIDevices(t *testing.T) {
+	t.Parallel()
+
+	devices := []*nodes.PCIDeviceListResponseData{
+		{ID: "0000:02:00.0", Class: "0x020000", Vendor: "0x8086", DeviceName: ptr.Ptr("I210 Gigabit Network Connection"), IOMMUGroup: 14},
+		{
+			ID: "0000:01:00.1", Class: "0x040300", Vendor: "0x10de", DeviceName: ptr.Ptr("TU104 HD Audio Controller"),
+			IOMMUGroup: 1,
+		},
+		{
+			ID: "0000:01:00.0", Class: "0x030000", Vendor: "0x10de", DeviceName: ptr.Ptr("TU104 [GeForce RTX 2080]"),
+			IOMMUGroup: 1, MDev: proxmoxtypes.CustomBool(true).Pointer(),
+		},
+		{ID: "0000:00:02.0", Class: "0x030000", Vendor: "0x8086", IOMMUGroup: -1},
+	}
+
+	ids := func(list []*nodes.PCIDeviceListResponseData) []string {
+		r := make([]string, 0, len(list))
+		for _, d := range list {
+			r = append(r, d.ID)
+		}
+
+		return r
+	}
+
+	tests := []struct {
+		name    string
+		filters *pciFiltersModel
+		want    []string
+	}{
+		{"no filters", nil, []string{"0000:00:02.0", "0000:01:00.0", "0000:01:00.1", "0000:02:00.0"}},
+		{"class", &pciFiltersModel{Class: types.StringValue("0x03")}, []string{"0000:00:02.0", "0000:01:00.0"}},
+		{
+			"class and vendor",
+			&pciFiltersModel{Class: types.StringValue("0300"), VendorID: types.StringValue("10DE")},
+			[]string{"0000:01:00.0"},
+		},
+		{"device name", &pciFiltersModel{DeviceNameRegex: types.StringValue(`(?i)geforce|audio`)}, []string{"0000:01:00.0", "0000:01:00.1"}},
+		{"iommu group", &pciFiltersModel{HasIOMMUGroup: types.BoolValue(false)}, []string{"0000:00:02.0"}},
+		{"mdev capable", &pciFiltersModel{MDevCapable: types.BoolValue(true)}, []string{"0000:01:00.0"}},
+		{"not mdev capable", &pciFiltersModel{MDevCapable: types.BoolValue(false)}, []string{"0000:00:02.0", "0000:01:00.1", "0000:02:00.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, ids(filterPCIDevices(devices, tt.filters)))
+		})
+	}
+}
+
+func Test_filterUSBDevices(t *testing.T) {
+	t.Parallel()
+
+	devices := []*nodes.USBDeviceListResponseData{
+		{BusNum: 3, Class: 0, VendorID: "046d", ProductID: "c52b", Product: ptr.Ptr("USB Receiver"), USBPath: ptr.Ptr("2")},
+		{BusNum: 1, Class: 9, VendorID: "1d6b", ProductID: "0002", Product: ptr.Ptr("xHCI Host Controller")},
+		{BusNum: 1, Class: 0, VendorID: "046d", ProductID: "c52b", Product: ptr.Ptr("USB Receiver"), USBPath: ptr.Ptr("1.4")},
+	}
+
+	list := filterUSBDevices(devices, nil)
+	assert.Len(t, list, 3)
+	assert.Equal(t, "046d:c52b", list[0].ID.ValueString())
+	assert.Equal(t, "1-1.4", list[0].Port.ValueString())
+	assert.Equal(t, "3-2", list[1].Port.ValueString())
+	assert.True(t, list[2].Port.IsNull())
+
+	list = filterUSBDevices(devices, &usbFiltersModel{Class: types.Int64Value(9)})
+	assert.Len(t, list, 1)
+	assert.Equal(t, "1d6b:0002", list[0].ID.ValueString())
+
+	list = filterUSBDevices(devices, &usbFiltersModel{
+		VendorID:        types.StringValue("0x046D"),
+		DeviceNameRegex: types.StringValue("Receiver"),
+	})
+	assert.Len(t, list, 2)
+}
@@ -0,0 +1,211 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+)
+
+// pendingChangesAttribute returns the schema of the flag which reports the pending network configuration changes.
+func pendingChangesAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Whether the network configuration of the node has changes which have not been applied yet.",
+		MarkdownDescription: "Whether the network configuration of the node has changes which have not been " +
+			"applied yet, e.g. because `skip_apply` is set, or because applying the configuration failed. " +
+			"PVE keeps the pending changes in `/etc/network/interfaces.new` until they are applied or reverted.",
+		Computed: true,
+	}
+}
+
+// skipApplyAttribute returns the schema of the flag which disables applying the network configuration.
+func skipApplyAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Whether to skip applying the network configuration of the node after a change " +
+			"(defaults to `false`).",
+		MarkdownDescription: "Whether to skip applying the network configuration of the node after a change " +
+			"(defaults to `false`). The changes are then kept pending until they are applied, e.g. from the " +
+			"web interface or by another interface of the node.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// applyNetworkConfiguration applies the network configuration of the node, unless it is skipped.
+// The reloads requested by the interfaces of the same node during an apply are batched by the client.
+func applyNetworkConfiguration(ctx context.Context, client *nodes.Client, skip bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if skip {
+		return diags
+	}
+
+	err := client.ReloadNetworkConfiguration(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error reloading network configuration",
+			fmt.Sprintf("Could not reload network configuration on node '%s', unexpected error: %s",
+				client.NodeName, err.Error()),
+		)
+	}
+
+	return diags
+}
+
+// readPendingChanges returns whether the network configuration of the node has pending changes.
+func readPendingChanges(ctx context.Context, client *nodes.Client, diags *diag.Diagnostics) types.Bool {
+	changes, err := client.GetPendingNetworkChanges(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error reading pending network changes",
+			"Could not read pending network changes, unexpected error: "+err.Error(),
+		)
+
+		return types.BoolNull()
+	}
+
+	return types.BoolValue(strings.TrimSpace(changes) != "")
+}
+
+// warnPendingChanges warns about the pending network configuration changes which were expected to be applied.
+func warnPendingChanges(nodeName string, pendingChanges types.Bool, skipApply types.Bool, diags *diag.Diagnostics) {
+	if !pendingChanges.ValueBool() || skipApply.ValueBool() {
+		return
+	}
+
+	diags.AddWarning(
+		"Pending network configuration changes",
+		fmt.Sprintf("The network configuration of node '%s' has changes which have not been applied, "+
+			"the last apply may have failed. Apply or revert the changes of the node before retrying.", nodeName),
+	)
+}
+
+// validatePorts verifies that the ports of an interface exist on the node.
+func validatePorts(ctx context.Context, client *nodes.Client, ports []string) error {
+	if len(ports) == 0 {
+		return nil
+	}
+
+	ifaces, err := client.ListNetworkInterfaces(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list network interfaces to validate the ports: %w", err)
+	}
+
+	existing := make(map[string]struct{}, len(ifaces))
+	names := make([]string, 0, len(ifaces))
+
+	for _, iface := range ifaces {
+		existing[iface.Iface] = struct{}{}
+		names = append(names, iface.Iface)
+	}
+
+	var missing []string
+
+	for _, port := range ports {
+		if _, ok := existing[port]; !ok {
+			missing = append(missing, port)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf(
+			"the ports %q do not exist on node '%s', the existing interfaces are %q",
+			missing, client.NodeName, names,
+		)
+	}
+
+	return nil
+}
+
+// validateMembers verifies that the members of a bond exist on the node, and are not already used
+// by another bond, or as the port of a bridge.
+func validateMembers(ctx context.Context, client *nodes.Client, name string, members []string) error {
+	err := validatePorts(ctx, client, members)
+	if err != nil {
+		return err
+	}
+
+	ifaces, err := client.ListNetworkInterfaces(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list network interfaces to validate the members: %w", err)
+	}
+
+	for _, iface := range ifaces {
+		if iface.Iface == name {
+			continue
+		}
+
+		var used []string
+
+		for _, v := range []*string{iface.Slaves, iface.BridgePorts, iface.OVSBonds, iface.OVSPorts} {
+			if v != nil {
+				used = append(used, strings.Fields(*v)...)
+			}
+		}
+
+		for _, member := range members {
+			if slices.Contains(used, member) {
+				return fmt.Errorf("the interface %q is already used by the %s %q", member, iface.Type, iface.Iface)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkInterfaceType verifies that an interface has the type managed by the resource, so that e.g. an OVS
+// internal port is not mistaken for a Linux VLAN with the same name.
+func checkInterfaceType(
+	nodeName string,
+	iface *nodes.NetworkInterfaceListResponseData,
+	expected string,
+	diags *diag.Diagnostics,
+) bool {
+	if iface.Type == expected {
+		return true
+	}
+
+	diags.AddError(
+		"Unexpected network interface type",
+		fmt.Sprintf("Interface %q on node '%s' has type %q, expected %q",
+			iface.Iface, nodeName, iface.Type, expected),
+	)
+
+	return false
+}
+
+// validateOVSBridge verifies that the OVS bridge an interface is attached to exists on the node.
+func validateOVSBridge(ctx context.Context, client *nodes.Client, bridge string) error {
+	ifaces, err := client.ListNetworkInterfaces(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list network interfaces to validate the bridge: %w", err)
+	}
+
+	for _, iface := range ifaces {
+		if iface.Iface != bridge {
+			continue
+		}
+
+		if iface.Type != "OVSBridge" {
+			return fmt.Errorf("the interface %q on node '%s' is a %s, not an OVS bridge", bridge, client.NodeName, iface.Type)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("the OVS bridge %q does not exist on node '%s'", bridge, client.NodeName)
+}
@@ -0,0 +1,651 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	customtypes "github.com/bpg/terraform-provider-proxmox/fwprovider/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+const defaultBondMode = "balance-rr"
+
+var (
+	_ resource.Resource                   = &linuxBondResource{}
+	_ resource.ResourceWithConfigure      = &linuxBondResource{}
+	_ resource.ResourceWithImportState    = &linuxBondResource{}
+	_ resource.ResourceWithValidateConfig = &linuxBondResource{}
+)
+
+type linuxBondResourceModel struct {
+	// Base attributes
+	ID        types.String            `tfsdk:"id"`
+	NodeName  types.String            `tfsdk:"node_name"`
+	Name      types.String            `tfsdk:"name"`
+	Address   customtypes.IPCIDRValue `tfsdk:"address"`
+	Gateway   customtypes.IPAddrValue `tfsdk:"gateway"`
+	Address6  customtypes.IPCIDRValue `tfsdk:"address6"`
+	Gateway6  customtypes.IPAddrValue `tfsdk:"gateway6"`
+	Autostart types.Bool              `tfsdk:"autostart"`
+	MTU       types.Int64             `tfsdk:"mtu"`
+	Comment   types.String            `tfsdk:"comment"`
+	// Linux bond attributes
+	LACPRate       types.String   `tfsdk:"lacp_rate"`
+	MIIMon         types.Int64    `tfsdk:"miimon"`
+	Mode           types.String   `tfsdk:"mode"`
+	Primary        types.String   `tfsdk:"primary"`
+	Slaves         []types.String `tfsdk:"slaves"`
+	XmitHashPolicy types.String   `tfsdk:"xmit_hash_policy"`
+	// Network configuration attributes
+	PendingChanges types.Bool `tfsdk:"pending_changes"`
+	SkipApply      types.Bool `tfsdk:"skip_apply"`
+}
+
+func (m *linuxBondResourceModel) exportToNetworkInterfaceCreateUpdateBody() *nodes.NetworkInterfaceCreateUpdateRequestBody {
+	body := &nodes.NetworkInterfaceCreateUpdateRequestBody{
+		Iface:     m.Name.ValueString(),
+		Type:      "bond",
+		Autostart: proxmoxtypes.CustomBool(m.Autostart.ValueBool()).Pointer(),
+	}
+
+	body.CIDR = m.Address.ValueStringPointer()
+	body.Gateway = m.Gateway.ValueStringPointer()
+	body.CIDR6 = m.Address6.ValueStringPointer()
+	body.Gateway6 = m.Gateway6.ValueStringPointer()
+	body.Comments = m.Comment.ValueStringPointer()
+
+	if !m.MTU.IsUnknown() {
+		body.MTU = m.MTU.ValueInt64Pointer()
+	}
+
+	slaves := strings.Join(m.sanitizedSlaves(), " ")
+	body.Slaves = &slaves
+
+	body.BondMode = m.Mode.ValueStringPointer()
+	body.BondPrimary = m.Primary.ValueStringPointer()
+	body.BondXmitHashPolicy = m.XmitHashPolicy.ValueStringPointer()
+
+	return body
+}
+
+func (m *linuxBondResourceModel) sanitizedSlaves() []string {
+	var sanitizedSlaves []string
+
+	for _, slave := range m.Slaves {
+		slave := strings.TrimSpace(slave.ValueString())
+		if len(slave) > 0 {
+			sanitizedSlaves = append(sanitizedSlaves, slave)
+		}
+	}
+
+	sort.Strings(sanitizedSlaves)
+
+	return sanitizedSlaves
+}
+
+func (m *linuxBondResourceModel) importFromNetworkInterfaceList(iface *nodes.NetworkInterfaceListResponseData) {
+	m.Address = customtypes.NewIPCIDRPointerValue(iface.CIDR)
+	m.Gateway = customtypes.NewIPAddrPointerValue(iface.Gateway)
+	m.Address6 = customtypes.NewIPCIDRPointerValue(iface.CIDR6)
+	m.Gateway6 = customtypes.NewIPAddrPointerValue(iface.Gateway6)
+
+	m.Autostart = types.BoolPointerValue(iface.Autostart.PointerBool())
+	if m.Autostart.IsNull() {
+		m.Autostart = types.BoolValue(false)
+	}
+
+	m.MTU = types.Int64Null()
+
+	if iface.MTU != nil {
+		if v, err := strconv.Atoi(*iface.MTU); err == nil {
+			m.MTU = types.Int64Value(int64(v))
+		}
+	}
+
+	// Comments can be set to an empty string in plan, which will translate to a "no value" in PVE
+	// So we don't want to set it to null if it's empty, as this will be indicated as a plan drift
+	if iface.Comments != nil {
+		m.Comment = types.StringValue(strings.TrimSpace(*iface.Comments))
+	}
+
+	// PVE renders the slaves as `none` when the bond has none
+	m.Slaves = nil
+
+	if iface.Slaves != nil {
+		slaves := strings.Fields(*iface.Slaves)
+		sort.Strings(slaves)
+
+		for _, slave := range slaves {
+			if slave != "none" {
+				m.Slaves = append(m.Slaves, types.StringValue(slave))
+			}
+		}
+	}
+
+	m.Mode = types.StringValue(defaultBondMode)
+	if iface.BondMode != nil {
+		m.Mode = types.StringValue(*iface.BondMode)
+	}
+
+	m.Primary = types.StringPointerValue(iface.BondPrimary)
+	m.XmitHashPolicy = types.StringPointerValue(iface.BondXmitHash)
+	m.LACPRate = types.StringPointerValue(iface.BondLACPRate)
+	m.MIIMon = types.Int64Null()
+
+	if iface.BondMIIMon != nil {
+		if v, err := strconv.Atoi(*iface.BondMIIMon); err == nil {
+			m.MIIMon = types.Int64Value(int64(v))
+		}
+	}
+}
+
+// NewLinuxBondResource creates a new resource for managing Linux Bond network interfaces.
+func NewLinuxBondResource() resource.Resource {
+	return &linuxBondResource{}
+}
+
+type linuxBondResource struct {
+	client proxmox.Client
+}
+
+func (r *linuxBondResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_network_linux_bond"
+}
+
+// Schema defines the schema for the resource.
+func (r *linuxBondResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Linux Bond network interface in a Proxmox VE node.",
+		Attributes: map[string]schema.Attribute{
+			// Base attributes
+			"id": attribute.ResourceID("A unique identifier with format `<node name>:<iface>`"),
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The interface name, e.g. `bond0`.",
+				MarkdownDescription: "The interface name. Must be bond[N], where 0 ≤ N ≤ 9999 " +
+					"(bond0 - bond9999).",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^bond\d{1,4}$`),
+						`must be "bond" followed by a number, e.g. "bond0"`,
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"address": schema.StringAttribute{
+				Description: "The interface IPv4/CIDR address.",
+				CustomType:  customtypes.IPCIDRType{},
+				Optional:    true,
+			},
+			"gateway": schema.StringAttribute{
+				Description: "Default gateway address.",
+				CustomType:  customtypes.IPAddrType{},
+				Optional:    true,
+			},
+			"address6": schema.StringAttribute{
+				Description: "The interface IPv6/CIDR address.",
+				CustomType:  customtypes.IPCIDRType{},
+				Optional:    true,
+			},
+			"gateway6": schema.StringAttribute{
+				Description: "Default IPv6 gateway address.",
+				CustomType:  customtypes.IPAddrType{},
+				Optional:    true,
+			},
+			"autostart": schema.BoolAttribute{
+				Description: "Automatically start interface on boot (defaults to `true`).",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"mtu": schema.Int64Attribute{
+				Description: "The interface MTU.",
+				Optional:    true,
+			},
+			"comment": schema.StringAttribute{
+				Description: "Comment for the interface.",
+				Optional:    true,
+			},
+			// Linux Bond attributes
+			"lacp_rate": schema.StringAttribute{
+				Description: "The LACP rate of the bond, as rendered by PVE.",
+				MarkdownDescription: "The LACP rate of the bond, as rendered by PVE. The PVE API doesn't support " +
+					"setting it, so it's only reported when set manually in `/etc/network/interfaces`.",
+				Computed: true,
+			},
+			"miimon": schema.Int64Attribute{
+				Description: "The MII link monitoring interval of the bond in milliseconds, as rendered by PVE.",
+				MarkdownDescription: "The MII link monitoring interval of the bond in milliseconds, as rendered " +
+					"by PVE. The PVE API doesn't support setting it, and PVE renders `100` unless it's set " +
+					"manually in `/etc/network/interfaces`.",
+				Computed: true,
+			},
+			"mode": schema.StringAttribute{
+				Description: fmt.Sprintf("The bonding mode (defaults to `%s`).", defaultBondMode),
+				MarkdownDescription: fmt.Sprintf("The bonding mode, one of `balance-rr`, `active-backup`, "+
+					"`balance-xor`, `broadcast`, `802.3ad`, `balance-tlb` or `balance-alb` (defaults to `%s`).",
+					defaultBondMode),
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(defaultBondMode),
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						"balance-rr",
+						"active-backup",
+						"balance-xor",
+						"broadcast",
+						"802.3ad",
+						"balance-tlb",
+						"balance-alb",
+					),
+				},
+			},
+			"primary": schema.StringAttribute{
+				Description: "The primary slave of the bond, only used by the `active-backup` mode.",
+				Optional:    true,
+			},
+			"slaves": schema.ListAttribute{
+				Description: "The slave interfaces of the bond, which must not be used by another bond or bridge.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"xmit_hash_policy": schema.StringAttribute{
+				Description: "The transmit hash policy of the bond, only used by the `balance-xor` and " +
+					"`802.3ad` modes.",
+				MarkdownDescription: "The transmit hash policy of the bond, one of `layer2`, `layer2+3` or " +
+					"`layer3+4`. Only used by the `balance-xor` and `802.3ad` modes.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("layer2", "layer2+3", "layer3+4"),
+				},
+			},
+			// Network configuration attributes
+			"pending_changes": pendingChangesAttribute(),
+			"skip_apply":      skipApplyAttribute(),
+		},
+	}
+}
+
+// ValidateConfig rejects the options which PVE doesn't render for the configured mode,
+// as they would be dropped from the configuration and show up as a drift.
+func (r *linuxBondResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config linuxBondResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() || config.Mode.IsUnknown() {
+		return
+	}
+
+	mode := config.Mode.ValueString()
+	if config.Mode.IsNull() {
+		mode = defaultBondMode
+	}
+
+	if !config.XmitHashPolicy.IsNull() && mode != "balance-xor" && mode != "802.3ad" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("xmit_hash_policy"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("The transmit hash policy is only used by the `balance-xor` and `802.3ad` modes, got %q.", mode),
+		)
+	}
+
+	if !config.Primary.IsNull() && mode != "active-backup" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("primary"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("The primary slave is only used by the `active-backup` mode, got %q.", mode),
+		)
+	}
+}
+
+func (r *linuxBondResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+//nolint:dupl
+func (r *linuxBondResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan linuxBondResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := validateMembers(ctx, r.client.Node(plan.NodeName.ValueString()), plan.Name.ValueString(), plan.sanitizedSlaves())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("slaves"), "Invalid Linux Bond slaves", err.Error())
+
+		return
+	}
+
+	nodeClient := r.client.Node(plan.NodeName.ValueString())
+	body := plan.exportToNetworkInterfaceCreateUpdateBody()
+
+	err = nodeClient.CreateNetworkInterface(ctx, body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Linux Bond interface",
+			"Could not create Linux Bond, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	plan.ID = types.StringValue(plan.NodeName.ValueString() + ":" + plan.Name.ValueString())
+
+	applyDiags := applyNetworkConfiguration(ctx, nodeClient, plan.SkipApply.ValueBool())
+
+	found := r.read(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"Linux Bond interface not found after creation",
+			fmt.Sprintf(
+				"Interface %q on node %q could not be read after creation",
+				plan.Name.ValueString(), plan.NodeName.ValueString()),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(applyDiags...)
+}
+
+func (r *linuxBondResource) read(ctx context.Context, model *linuxBondResourceModel, diags *diag.Diagnostics) bool {
+	nodeClient := r.client.Node(model.NodeName.ValueString())
+
+	ifaces, err := nodeClient.ListNetworkInterfaces(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error listing network interfaces",
+			"Could not list network interfaces, unexpected error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	for _, iface := range ifaces {
+		if iface.Iface != model.Name.ValueString() {
+			continue
+		}
+
+		if !checkInterfaceType(model.NodeName.ValueString(), iface, "bond", diags) {
+			return false
+		}
+
+		model.importFromNetworkInterfaceList(iface)
+		model.PendingChanges = readPendingChanges(ctx, nodeClient, diags)
+
+		if model.SkipApply.IsNull() || model.SkipApply.IsUnknown() {
+			model.SkipApply = types.BoolValue(false)
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// Read reads a Linux Bond interface.
+func (r *linuxBondResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state linuxBondResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	warnPendingChanges(state.NodeName.ValueString(), state.PendingChanges, state.SkipApply, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update updates a Linux Bond interface.
+func (r *linuxBondResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state linuxBondResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := validateMembers(ctx, r.client.Node(plan.NodeName.ValueString()), plan.Name.ValueString(), plan.sanitizedSlaves())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("slaves"), "Invalid Linux Bond slaves", err.Error())
+
+		return
+	}
+
+	body := plan.exportToNetworkInterfaceCreateUpdateBody()
+
+	var toDelete []string
+
+	if !plan.MTU.Equal(state.MTU) && plan.MTU.ValueInt64() == 0 {
+		toDelete = append(toDelete, "mtu")
+		body.MTU = nil
+	}
+
+	if !plan.Gateway.Equal(state.Gateway) && plan.Gateway.ValueString() == "" {
+		toDelete = append(toDelete, "gateway")
+		body.Gateway = nil
+	}
+
+	if !plan.Gateway6.Equal(state.Gateway6) && plan.Gateway6.ValueString() == "" {
+		toDelete = append(toDelete, "gateway6")
+		body.Gateway6 = nil
+	}
+
+	if !plan.Primary.Equal(state.Primary) && plan.Primary.ValueString() == "" {
+		toDelete = append(toDelete, "bond-primary")
+		body.BondPrimary = nil
+	}
+
+	if !plan.XmitHashPolicy.Equal(state.XmitHashPolicy) && plan.XmitHashPolicy.ValueString() == "" {
+		toDelete = append(toDelete, "bond_xmit_hash_policy")
+		body.BondXmitHashPolicy = nil
+	}
+
+	if len(toDelete) > 0 {
+		body.Delete = &toDelete
+	}
+
+	nodeClient := r.client.Node(plan.NodeName.ValueString())
+
+	err = nodeClient.UpdateNetworkInterface(ctx, plan.Name.ValueString(), body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Linux Bond interface",
+			"Could not update Linux Bond, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	applyDiags := applyNetworkConfiguration(ctx, nodeClient, plan.SkipApply.ValueBool())
+
+	found := r.read(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"Linux Bond interface not found after update",
+			fmt.Sprintf(
+				"Interface %q on node %q could not be read after update",
+				plan.Name.ValueString(), plan.NodeName.ValueString()),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(applyDiags...)
+}
+
+// Delete deletes a Linux Bond interface.
+//
+//nolint:dupl
+func (r *linuxBondResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state linuxBondResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Node(state.NodeName.ValueString()).DeleteNetworkInterface(ctx, state.Name.ValueString())
+	if err != nil {
+		if strings.Contains(err.Error(), "interface does not exist") {
+			resp.Diagnostics.AddWarning(
+				"Linux Bond interface does not exist",
+				fmt.Sprintf("Could not delete Linux Bond '%s', interface does not exist, "+
+					"or has already been deleted outside of Terraform.", state.Name.ValueString()),
+			)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error deleting Linux Bond interface",
+				fmt.Sprintf("Could not delete Linux Bond '%s', unexpected error: %s",
+					state.Name.ValueString(), err.Error()),
+			)
+		}
+
+		return
+	}
+
+	resp.Diagnostics.Append(
+		applyNetworkConfiguration(ctx, r.client.Node(state.NodeName.ValueString()), state.SkipApply.ValueBool())...,
+	)
+}
+
+func (r *linuxBondResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	idParts := strings.Split(req.ID, ":")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: `node_name:iface`. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	nodeName := idParts[0]
+	iface := idParts[1]
+
+	state := linuxBondResourceModel{
+		ID:       types.StringValue(req.ID),
+		NodeName: types.StringValue(nodeName),
+		Name:     types.StringValue(iface),
+	}
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"Linux Bond interface not found",
+			fmt.Sprintf("Interface %q on node %q could not be imported", iface, nodeName),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
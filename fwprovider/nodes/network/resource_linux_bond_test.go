@@ -0,0 +1,126 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package network_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceLinuxBond(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	iface := os.Getenv("PROXMOX_VE_ACC_IFACE_NAME")
+	if iface == "" {
+		iface = "ens18"
+	}
+
+	bond := fmt.Sprintf("bond%d", gofakeit.Number(10, 9999))
+	vlan1 := gofakeit.Number(10, 2000)
+	vlan2 := gofakeit.Number(2001, 4094)
+	ipV4cidr := fmt.Sprintf("%s/24", gofakeit.IPv4Address())
+
+	slaves := fmt.Sprintf(`
+	resource "proxmox_virtual_environment_network_linux_vlan" "slave1" {
+		name = "%[1]s.%[2]d"
+		node_name = "{{.NodeName}}"
+	}
+
+	resource "proxmox_virtual_environment_network_linux_vlan" "slave2" {
+		name = "%[1]s.%[3]d"
+		node_name = "{{.NodeName}}"
+	}
+	`, iface, vlan1, vlan2)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: te.RenderConfig(slaves + fmt.Sprintf(`
+				resource "proxmox_virtual_environment_network_linux_bond" "test" {
+					address = "%s"
+					comment = "created by terraform"
+					mode = "active-backup"
+					mtu = 1499
+					name = "%s"
+					node_name = "{{.NodeName}}"
+					primary = proxmox_virtual_environment_network_linux_vlan.slave1.name
+					slaves = [
+						proxmox_virtual_environment_network_linux_vlan.slave2.name,
+						proxmox_virtual_environment_network_linux_vlan.slave1.name,
+					]
+				}
+				`, ipV4cidr, bond)),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_network_linux_bond.test", map[string]string{
+						"address":         ipV4cidr,
+						"autostart":       "true",
+						"comment":         "created by terraform",
+						"mode":            "active-backup",
+						"mtu":             "1499",
+						"name":            bond,
+						"pending_changes": "false",
+						"primary":         fmt.Sprintf("%s.%d", iface, vlan1),
+						"slaves.#":        "2",
+						"slaves.0":        fmt.Sprintf("%s.%d", iface, vlan1),
+						"slaves.1":        fmt.Sprintf("%s.%d", iface, vlan2),
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_network_linux_bond.test", []string{
+						"xmit_hash_policy",
+					}),
+					test.ResourceAttributesSet("proxmox_virtual_environment_network_linux_bond.test", []string{
+						"id",
+						"miimon",
+					}),
+				),
+			},
+			// Update testing
+			{
+				Config: te.RenderConfig(slaves + fmt.Sprintf(`
+				resource "proxmox_virtual_environment_network_linux_bond" "test" {
+					mode = "802.3ad"
+					name = "%s"
+					node_name = "{{.NodeName}}"
+					slaves = [
+						proxmox_virtual_environment_network_linux_vlan.slave1.name,
+						proxmox_virtual_environment_network_linux_vlan.slave2.name,
+					]
+					xmit_hash_policy = "layer3+4"
+				}
+				`, bond)),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_network_linux_bond.test", map[string]string{
+						"mode":             "802.3ad",
+						"name":             bond,
+						"xmit_hash_policy": "layer3+4",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_network_linux_bond.test", []string{
+						"address",
+						"mtu",
+						"primary",
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "proxmox_virtual_environment_network_linux_bond.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
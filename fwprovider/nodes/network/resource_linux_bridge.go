@@ -16,6 +16,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -34,9 +35,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &linuxBridgeResource{}
-	_ resource.ResourceWithConfigure   = &linuxBridgeResource{}
-	_ resource.ResourceWithImportState = &linuxBridgeResource{}
+	_ resource.Resource                   = &linuxBridgeResource{}
+	_ resource.ResourceWithConfigure      = &linuxBridgeResource{}
+	_ resource.ResourceWithImportState    = &linuxBridgeResource{}
+	_ resource.ResourceWithValidateConfig = &linuxBridgeResource{}
 )
 
 type linuxBridgeResourceModel struct {
@@ -53,7 +55,11 @@ type linuxBridgeResourceModel struct {
 	Comment   types.String            `tfsdk:"comment"`
 	// Linux bridge attributes
 	Ports     []types.String `tfsdk:"ports"`
+	VIDs      types.String   `tfsdk:"vids"`
 	VLANAware types.Bool     `tfsdk:"vlan_aware"`
+	// Network configuration attributes
+	PendingChanges types.Bool `tfsdk:"pending_changes"`
+	SkipApply      types.Bool `tfsdk:"skip_apply"`
 }
 
 func (m *linuxBridgeResourceModel) exportToNetworkInterfaceCreateUpdateBody() *nodes.NetworkInterfaceCreateUpdateRequestBody {
@@ -74,6 +80,24 @@ func (m *linuxBridgeResourceModel) exportToNetworkInterfaceCreateUpdateBody() *n
 
 	body.Comments = m.Comment.ValueStringPointer()
 
+	bridgePorts := strings.Join(m.sanitizedPorts(), " ")
+
+	if len(bridgePorts) > 0 {
+		body.BridgePorts = &bridgePorts
+	}
+
+	if m.VLANAware.ValueBool() {
+		body.BridgeVLANAware = proxmoxtypes.CustomBool(true).Pointer()
+
+		if !m.VIDs.IsUnknown() {
+			body.BridgeVIDs = m.VIDs.ValueStringPointer()
+		}
+	}
+
+	return body
+}
+
+func (m *linuxBridgeResourceModel) sanitizedPorts() []string {
 	var sanitizedPorts []string
 
 	for _, port := range m.Ports {
@@ -84,17 +108,8 @@ func (m *linuxBridgeResourceModel) exportToNetworkInterfaceCreateUpdateBody() *n
 	}
 
 	sort.Strings(sanitizedPorts)
-	bridgePorts := strings.Join(sanitizedPorts, " ")
-
-	if len(bridgePorts) > 0 {
-		body.BridgePorts = &bridgePorts
-	}
 
-	if m.VLANAware.ValueBool() {
-		body.BridgeVLANAware = proxmoxtypes.CustomBool(true).Pointer()
-	}
-
-	return body
+	return sanitizedPorts
 }
 
 func (m *linuxBridgeResourceModel) importFromNetworkInterfaceList(
@@ -131,6 +146,12 @@ func (m *linuxBridgeResourceModel) importFromNetworkInterfaceList(
 		m.VLANAware = types.BoolValue(false)
 	}
 
+	if iface.BridgeVIDs != nil && m.VLANAware.ValueBool() {
+		m.VIDs = types.StringValue(strings.Join(strings.Fields(*iface.BridgeVIDs), " "))
+	} else {
+		m.VIDs = types.StringNull()
+	}
+
 	if iface.BridgePorts != nil && len(*iface.BridgePorts) > 0 {
 		ports, diags := types.ListValueFrom(ctx, types.StringType, strings.Split(*iface.BridgePorts, " "))
 		if diags.HasError() {
@@ -229,19 +250,57 @@ func (r *linuxBridgeResource) Schema(
 			},
 			// Linux Bridge attributes
 			"ports": schema.ListAttribute{
-				Description: "The interface bridge ports.",
+				Description: "The interface bridge ports, which must be existing interfaces of the node.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"vids": schema.StringAttribute{
+				Description: "The VLAN IDs allowed on the VLAN aware bridge, e.g. `2-4094` or `10 20 100-200`.",
+				MarkdownDescription: "The VLAN IDs allowed on the VLAN aware bridge, separated by spaces, " +
+					"e.g. `2-4094` or `10 20 100-200`. Defaults to `2-4094` when `vlan_aware` is enabled.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^\d+(-\d+)?( \d+(-\d+)?)*$`),
+						"must be a space separated list of VLAN IDs or VLAN ID ranges",
+					),
+				},
+			},
 			"vlan_aware": schema.BoolAttribute{
 				Description: "Whether the interface bridge is VLAN aware (defaults to `false`).",
 				Optional:    true,
 				Computed:    true,
 			},
+			// Network configuration attributes
+			"pending_changes": pendingChangesAttribute(),
+			"skip_apply":      skipApplyAttribute(),
 		},
 	}
 }
 
+func (r *linuxBridgeResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config linuxBridgeResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.VIDs.IsNull() && !config.VIDs.IsUnknown() && !config.VLANAware.IsUnknown() && !config.VLANAware.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("vids"),
+			"Invalid Attribute Combination",
+			"The VLAN IDs can only be set on a VLAN aware bridge, `vlan_aware` must be `true`.",
+		)
+	}
+}
+
 func (r *linuxBridgeResource) Configure(
 	_ context.Context,
 	req resource.ConfigureRequest,
@@ -275,9 +334,18 @@ func (r *linuxBridgeResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	nodeClient := r.client.Node(plan.NodeName.ValueString())
+
+	err := validatePorts(ctx, nodeClient, plan.sanitizedPorts())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ports"), "Invalid Linux Bridge ports", err.Error())
+
+		return
+	}
+
 	body := plan.exportToNetworkInterfaceCreateUpdateBody()
 
-	err := r.client.Node(plan.NodeName.ValueString()).CreateNetworkInterface(ctx, body)
+	err = nodeClient.CreateNetworkInterface(ctx, body)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Linux Bridge interface",
@@ -289,6 +357,8 @@ func (r *linuxBridgeResource) Create(ctx context.Context, req resource.CreateReq
 
 	plan.ID = types.StringValue(plan.NodeName.ValueString() + ":" + plan.Name.ValueString())
 
+	applyDiags := applyNetworkConfiguration(ctx, nodeClient, plan.SkipApply.ValueBool())
+
 	found := r.read(ctx, &plan, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
@@ -306,17 +376,8 @@ func (r *linuxBridgeResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-
-	err = r.client.Node(plan.NodeName.ValueString()).ReloadNetworkConfiguration(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reloading network configuration",
-			fmt.Sprintf("Could not reload network configuration on node '%s', unexpected error: %s",
-				plan.NodeName.ValueString(), err.Error()),
-		)
-	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(applyDiags...)
 }
 
 func (r *linuxBridgeResource) read(ctx context.Context, model *linuxBridgeResourceModel, diags *diag.Diagnostics) bool {
@@ -335,6 +396,10 @@ func (r *linuxBridgeResource) read(ctx context.Context, model *linuxBridgeResour
 			continue
 		}
 
+		if !checkInterfaceType(model.NodeName.ValueString(), iface, "bridge", diags) {
+			return false
+		}
+
 		err = model.importFromNetworkInterfaceList(ctx, iface)
 		if err != nil {
 			diags.AddError(
@@ -345,6 +410,12 @@ func (r *linuxBridgeResource) read(ctx context.Context, model *linuxBridgeResour
 			return false
 		}
 
+		model.PendingChanges = readPendingChanges(ctx, r.client.Node(model.NodeName.ValueString()), diags)
+
+		if model.SkipApply.IsNull() || model.SkipApply.IsUnknown() {
+			model.SkipApply = types.BoolValue(false)
+		}
+
 		return true
 	}
 
@@ -374,6 +445,8 @@ func (r *linuxBridgeResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	warnPendingChanges(state.NodeName.ValueString(), state.PendingChanges, state.SkipApply, &resp.Diagnostics)
+
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -414,11 +487,25 @@ func (r *linuxBridgeResource) Update(ctx context.Context, req resource.UpdateReq
 		body.BridgeVLANAware = nil
 	}
 
+	// VIDs is computed, so it's unknown when removed from the configuration, and PVE restores the default
+	if plan.VIDs.IsUnknown() && !state.VIDs.IsNull() {
+		toDelete = append(toDelete, "bridge_vids")
+	}
+
 	if len(toDelete) > 0 {
 		body.Delete = &toDelete
 	}
 
-	err := r.client.Node(plan.NodeName.ValueString()).UpdateNetworkInterface(ctx, plan.Name.ValueString(), body)
+	nodeClient := r.client.Node(plan.NodeName.ValueString())
+
+	err := validatePorts(ctx, nodeClient, plan.sanitizedPorts())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ports"), "Invalid Linux Bridge ports", err.Error())
+
+		return
+	}
+
+	err = nodeClient.UpdateNetworkInterface(ctx, plan.Name.ValueString(), body)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating Linux Bridge interface",
@@ -428,6 +515,8 @@ func (r *linuxBridgeResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	applyDiags := applyNetworkConfiguration(ctx, nodeClient, plan.SkipApply.ValueBool())
+
 	found := r.read(ctx, &plan, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
@@ -446,15 +535,7 @@ func (r *linuxBridgeResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
-
-	err = r.client.Node(state.NodeName.ValueString()).ReloadNetworkConfiguration(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reloading network configuration",
-			fmt.Sprintf("Could not reload network configuration on node '%s', unexpected error: %s",
-				state.NodeName.ValueString(), err.Error()),
-		)
-	}
+	resp.Diagnostics.Append(applyDiags...)
 }
 
 // Delete deletes a Linux Bridge interface.
@@ -489,14 +570,9 @@ func (r *linuxBridgeResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	err = r.client.Node(state.NodeName.ValueString()).ReloadNetworkConfiguration(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reloading network configuration",
-			fmt.Sprintf("Could not reload network configuration on node '%s', unexpected error: %s",
-				state.NodeName.ValueString(), err.Error()),
-		)
-	}
+	resp.Diagnostics.Append(
+		applyNetworkConfiguration(ctx, r.client.Node(state.NodeName.ValueString()), state.SkipApply.ValueBool())...,
+	)
 }
 
 func (r *linuxBridgeResource) ImportState(
@@ -40,17 +40,21 @@ func TestAccResourceLinuxBridge(t *testing.T) {
 					mtu = 1499
 					name = "%s"
 					node_name = "{{.NodeName}}"
+					vids = "10 20 100-200"
 					vlan_aware = true
 				}
 				`, ipV4cidr1, iface)),
 				Check: resource.ComposeTestCheckFunc(
 					test.ResourceAttributes("proxmox_virtual_environment_network_linux_bridge.test", map[string]string{
-						"address":    ipV4cidr1,
-						"autostart":  "true",
-						"comment":    "created by terraform",
-						"mtu":        "1499",
-						"name":       iface,
-						"vlan_aware": "true",
+						"address":         ipV4cidr1,
+						"autostart":       "true",
+						"comment":         "created by terraform",
+						"mtu":             "1499",
+						"name":            iface,
+						"pending_changes": "false",
+						"skip_apply":      "false",
+						"vids":            "10 20 100-200",
+						"vlan_aware":      "true",
 					}),
 					test.ResourceAttributesSet("proxmox_virtual_environment_network_linux_bridge.test", []string{
 						"id",
@@ -81,6 +85,7 @@ func TestAccResourceLinuxBridge(t *testing.T) {
 					}),
 					test.NoResourceAttributesSet("proxmox_virtual_environment_network_linux_bridge.test", []string{
 						"mtu",
+						"vids",
 					}),
 					test.ResourceAttributesSet("proxmox_virtual_environment_network_linux_bridge.test", []string{
 						"id",
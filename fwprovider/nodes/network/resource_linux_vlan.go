@@ -307,6 +307,10 @@ func (r *linuxVLANResource) read(ctx context.Context, model *linuxVLANResourceMo
 			continue
 		}
 
+		if !checkInterfaceType(model.NodeName.ValueString(), iface, "vlan", diags) {
+			return false
+		}
+
 		model.importFromNetworkInterfaceList(iface)
 
 		return true
@@ -0,0 +1,571 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+const defaultOVSBondMode = "active-backup"
+
+var (
+	_ resource.Resource                = &ovsBondResource{}
+	_ resource.ResourceWithConfigure   = &ovsBondResource{}
+	_ resource.ResourceWithImportState = &ovsBondResource{}
+)
+
+type ovsBondResourceModel struct {
+	// Base attributes
+	ID        types.String `tfsdk:"id"`
+	NodeName  types.String `tfsdk:"node_name"`
+	Name      types.String `tfsdk:"name"`
+	Autostart types.Bool   `tfsdk:"autostart"`
+	MTU       types.Int64  `tfsdk:"mtu"`
+	Comment   types.String `tfsdk:"comment"`
+	// OVS bond attributes
+	Bridge     types.String   `tfsdk:"bridge"`
+	Mode       types.String   `tfsdk:"mode"`
+	OVSOptions types.String   `tfsdk:"ovs_options"`
+	Slaves     []types.String `tfsdk:"slaves"`
+	VLAN       types.Int64    `tfsdk:"vlan"`
+	// Network configuration attributes
+	PendingChanges types.Bool `tfsdk:"pending_changes"`
+	SkipApply      types.Bool `tfsdk:"skip_apply"`
+}
+
+func (m *ovsBondResourceModel) exportToNetworkInterfaceCreateUpdateBody() *nodes.NetworkInterfaceCreateUpdateRequestBody {
+	body := &nodes.NetworkInterfaceCreateUpdateRequestBody{
+		Iface:     m.Name.ValueString(),
+		Type:      "OVSBond",
+		Autostart: proxmoxtypes.CustomBool(m.Autostart.ValueBool()).Pointer(),
+	}
+
+	body.Comments = m.Comment.ValueStringPointer()
+
+	if !m.MTU.IsUnknown() {
+		body.MTU = m.MTU.ValueInt64Pointer()
+	}
+
+	slaves := strings.Join(m.sanitizedSlaves(), " ")
+	body.OVSBonds = &slaves
+
+	body.BondMode = m.Mode.ValueStringPointer()
+	body.OVSBridge = m.Bridge.ValueStringPointer()
+	body.OVSOptions = m.OVSOptions.ValueStringPointer()
+
+	if !m.VLAN.IsNull() && !m.VLAN.IsUnknown() {
+		tag := strconv.FormatInt(m.VLAN.ValueInt64(), 10)
+		body.OVSTag = &tag
+	}
+
+	return body
+}
+
+func (m *ovsBondResourceModel) sanitizedSlaves() []string {
+	var sanitizedSlaves []string
+
+	for _, slave := range m.Slaves {
+		slave := strings.TrimSpace(slave.ValueString())
+		if len(slave) > 0 {
+			sanitizedSlaves = append(sanitizedSlaves, slave)
+		}
+	}
+
+	sort.Strings(sanitizedSlaves)
+
+	return sanitizedSlaves
+}
+
+func (m *ovsBondResourceModel) importFromNetworkInterfaceList(iface *nodes.NetworkInterfaceListResponseData) {
+	m.Autostart = types.BoolPointerValue(iface.Autostart.PointerBool())
+	if m.Autostart.IsNull() {
+		m.Autostart = types.BoolValue(false)
+	}
+
+	m.MTU = types.Int64Null()
+
+	if iface.MTU != nil {
+		if v, err := strconv.Atoi(*iface.MTU); err == nil {
+			m.MTU = types.Int64Value(int64(v))
+		}
+	}
+
+	// Comments can be set to an empty string in plan, which will translate to a "no value" in PVE
+	// So we don't want to set it to null if it's empty, as this will be indicated as a plan drift
+	if iface.Comments != nil {
+		m.Comment = types.StringValue(strings.TrimSpace(*iface.Comments))
+	}
+
+	m.Slaves = nil
+
+	if iface.OVSBonds != nil {
+		slaves := strings.Fields(*iface.OVSBonds)
+		sort.Strings(slaves)
+
+		for _, slave := range slaves {
+			m.Slaves = append(m.Slaves, types.StringValue(slave))
+		}
+	}
+
+	m.Mode = types.StringValue(defaultOVSBondMode)
+	if iface.BondMode != nil {
+		m.Mode = types.StringValue(*iface.BondMode)
+	}
+
+	m.Bridge = types.StringPointerValue(iface.OVSBridge)
+	m.OVSOptions = types.StringPointerValue(iface.OVSOptions)
+	m.VLAN = types.Int64Null()
+
+	if iface.OVSTag != nil {
+		if v, err := strconv.Atoi(*iface.OVSTag); err == nil {
+			m.VLAN = types.Int64Value(int64(v))
+		}
+	}
+}
+
+// NewOVSBondResource creates a new resource for managing OVS Bond network interfaces.
+func NewOVSBondResource() resource.Resource {
+	return &ovsBondResource{}
+}
+
+type ovsBondResource struct {
+	client proxmox.Client
+}
+
+func (r *ovsBondResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_network_ovs_bond"
+}
+
+// Schema defines the schema for the resource.
+func (r *ovsBondResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an Open vSwitch Bond network interface in a Proxmox VE node.",
+		MarkdownDescription: "Manages an Open vSwitch Bond network interface in a Proxmox VE node. " +
+			"The `openvswitch-switch` package must be installed on the node.",
+		Attributes: map[string]schema.Attribute{
+			// Base attributes
+			"id": attribute.ResourceID("A unique identifier with format `<node name>:<iface>`"),
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The interface name, e.g. `bond0`.",
+				MarkdownDescription: "The interface name. Must be bond[N], where 0 ≤ N ≤ 9999 " +
+					"(bond0 - bond9999).",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^bond\d{1,4}$`),
+						`must be "bond" followed by a number, e.g. "bond0"`,
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"autostart": schema.BoolAttribute{
+				Description: "Automatically start interface on boot (defaults to `true`).",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"mtu": schema.Int64Attribute{
+				Description: "The interface MTU.",
+				Optional:    true,
+			},
+			"comment": schema.StringAttribute{
+				Description: "Comment for the interface.",
+				Optional:    true,
+			},
+			// OVS Bond attributes
+			"bridge": schema.StringAttribute{
+				Description: "The OVS bridge the bond is attached to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mode": schema.StringAttribute{
+				Description: fmt.Sprintf("The bonding mode (defaults to `%s`).", defaultOVSBondMode),
+				MarkdownDescription: fmt.Sprintf("The bonding mode, one of `active-backup`, `balance-slb`, "+
+					"`lacp-balance-slb` or `lacp-balance-tcp` (defaults to `%s`).", defaultOVSBondMode),
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(defaultOVSBondMode),
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						"active-backup",
+						"balance-slb",
+						"lacp-balance-slb",
+						"lacp-balance-tcp",
+					),
+				},
+			},
+			"ovs_options": schema.StringAttribute{
+				Description: "The OVS options of the bond, e.g. `other_config:bond-miimon-interval=100`.",
+				Optional:    true,
+			},
+			"slaves": schema.ListAttribute{
+				Description: "The slave interfaces of the bond, which must not be used by another bond or bridge.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(2),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"vlan": schema.Int64Attribute{
+				Description: "The VLAN tag of the bond.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 4094),
+				},
+			},
+			// Network configuration attributes
+			"pending_changes": pendingChangesAttribute(),
+			"skip_apply":      skipApplyAttribute(),
+		},
+	}
+}
+
+func (r *ovsBondResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// validate verifies the bridge and the slaves of the bond.
+func (r *ovsBondResource) validate(ctx context.Context, model *ovsBondResourceModel, diags *diag.Diagnostics) {
+	nodeClient := r.client.Node(model.NodeName.ValueString())
+
+	err := validateOVSBridge(ctx, nodeClient, model.Bridge.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("bridge"), "Invalid OVS Bond bridge", err.Error())
+	}
+
+	err = validateMembers(ctx, nodeClient, model.Name.ValueString(), model.sanitizedSlaves())
+	if err != nil {
+		diags.AddAttributeError(path.Root("slaves"), "Invalid OVS Bond slaves", err.Error())
+	}
+}
+
+//nolint:dupl
+func (r *ovsBondResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ovsBondResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.validate(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeClient := r.client.Node(plan.NodeName.ValueString())
+	body := plan.exportToNetworkInterfaceCreateUpdateBody()
+
+	err := nodeClient.CreateNetworkInterface(ctx, body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OVS Bond interface",
+			"Could not create OVS Bond, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	plan.ID = types.StringValue(plan.NodeName.ValueString() + ":" + plan.Name.ValueString())
+
+	applyDiags := applyNetworkConfiguration(ctx, nodeClient, plan.SkipApply.ValueBool())
+
+	found := r.read(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"OVS Bond interface not found after creation",
+			fmt.Sprintf(
+				"Interface %q on node %q could not be read after creation",
+				plan.Name.ValueString(), plan.NodeName.ValueString()),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(applyDiags...)
+}
+
+func (r *ovsBondResource) read(ctx context.Context, model *ovsBondResourceModel, diags *diag.Diagnostics) bool {
+	nodeClient := r.client.Node(model.NodeName.ValueString())
+
+	ifaces, err := nodeClient.ListNetworkInterfaces(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error listing network interfaces",
+			"Could not list network interfaces, unexpected error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	for _, iface := range ifaces {
+		if iface.Iface != model.Name.ValueString() {
+			continue
+		}
+
+		if !checkInterfaceType(model.NodeName.ValueString(), iface, "OVSBond", diags) {
+			return false
+		}
+
+		model.importFromNetworkInterfaceList(iface)
+		model.PendingChanges = readPendingChanges(ctx, nodeClient, diags)
+
+		if model.SkipApply.IsNull() || model.SkipApply.IsUnknown() {
+			model.SkipApply = types.BoolValue(false)
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// Read reads an OVS Bond interface.
+func (r *ovsBondResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ovsBondResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	warnPendingChanges(state.NodeName.ValueString(), state.PendingChanges, state.SkipApply, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update updates an OVS Bond interface.
+func (r *ovsBondResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ovsBondResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.validate(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := plan.exportToNetworkInterfaceCreateUpdateBody()
+
+	var toDelete []string
+
+	if !plan.MTU.Equal(state.MTU) && plan.MTU.ValueInt64() == 0 {
+		toDelete = append(toDelete, "mtu")
+		body.MTU = nil
+	}
+
+	if !plan.OVSOptions.Equal(state.OVSOptions) && plan.OVSOptions.ValueString() == "" {
+		toDelete = append(toDelete, "ovs_options")
+		body.OVSOptions = nil
+	}
+
+	if !plan.VLAN.Equal(state.VLAN) && plan.VLAN.IsNull() {
+		toDelete = append(toDelete, "ovs_tag")
+	}
+
+	if len(toDelete) > 0 {
+		body.Delete = &toDelete
+	}
+
+	nodeClient := r.client.Node(plan.NodeName.ValueString())
+
+	err := nodeClient.UpdateNetworkInterface(ctx, plan.Name.ValueString(), body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating OVS Bond interface",
+			"Could not update OVS Bond, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	applyDiags := applyNetworkConfiguration(ctx, nodeClient, plan.SkipApply.ValueBool())
+
+	found := r.read(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"OVS Bond interface not found after update",
+			fmt.Sprintf(
+				"Interface %q on node %q could not be read after update",
+				plan.Name.ValueString(), plan.NodeName.ValueString()),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(applyDiags...)
+}
+
+// Delete deletes an OVS Bond interface.
+//
+//nolint:dupl
+func (r *ovsBondResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ovsBondResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Node(state.NodeName.ValueString()).DeleteNetworkInterface(ctx, state.Name.ValueString())
+	if err != nil {
+		if strings.Contains(err.Error(), "interface does not exist") {
+			resp.Diagnostics.AddWarning(
+				"OVS Bond interface does not exist",
+				fmt.Sprintf("Could not delete OVS Bond '%s', interface does not exist, "+
+					"or has already been deleted outside of Terraform.", state.Name.ValueString()),
+			)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error deleting OVS Bond interface",
+				fmt.Sprintf("Could not delete OVS Bond '%s', unexpected error: %s",
+					state.Name.ValueString(), err.Error()),
+			)
+		}
+
+		return
+	}
+
+	resp.Diagnostics.Append(
+		applyNetworkConfiguration(ctx, r.client.Node(state.NodeName.ValueString()), state.SkipApply.ValueBool())...,
+	)
+}
+
+func (r *ovsBondResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	idParts := strings.Split(req.ID, ":")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: `node_name:iface`. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	nodeName := idParts[0]
+	iface := idParts[1]
+
+	state := ovsBondResourceModel{
+		ID:       types.StringValue(req.ID),
+		NodeName: types.StringValue(nodeName),
+		Name:     types.StringValue(iface),
+	}
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"OVS Bond interface not found",
+			fmt.Sprintf("Interface %q on node %q could not be imported", iface, nodeName),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
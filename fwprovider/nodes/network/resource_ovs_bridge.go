@@ -0,0 +1,585 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	customtypes "github.com/bpg/terraform-provider-proxmox/fwprovider/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var (
+	_ resource.Resource                = &ovsBridgeResource{}
+	_ resource.ResourceWithConfigure   = &ovsBridgeResource{}
+	_ resource.ResourceWithImportState = &ovsBridgeResource{}
+)
+
+type ovsBridgeResourceModel struct {
+	// Base attributes
+	ID        types.String            `tfsdk:"id"`
+	NodeName  types.String            `tfsdk:"node_name"`
+	Name      types.String            `tfsdk:"name"`
+	Address   customtypes.IPCIDRValue `tfsdk:"address"`
+	Gateway   customtypes.IPAddrValue `tfsdk:"gateway"`
+	Address6  customtypes.IPCIDRValue `tfsdk:"address6"`
+	Gateway6  customtypes.IPAddrValue `tfsdk:"gateway6"`
+	Autostart types.Bool              `tfsdk:"autostart"`
+	MTU       types.Int64             `tfsdk:"mtu"`
+	Comment   types.String            `tfsdk:"comment"`
+	// OVS bridge attributes
+	OVSOptions types.String   `tfsdk:"ovs_options"`
+	Ports      []types.String `tfsdk:"ports"`
+	// Network configuration attributes
+	PendingChanges types.Bool `tfsdk:"pending_changes"`
+	SkipApply      types.Bool `tfsdk:"skip_apply"`
+}
+
+// exportToNetworkInterfaceCreateUpdateBody exports the model to a request body. The OVS bonds and internal
+// ports attached to the bridge are managed by their own resources, but PVE lists them in the ports of the
+// bridge, so they must be kept in the ports on update.
+func (m *ovsBridgeResourceModel) exportToNetworkInterfaceCreateUpdateBody(
+	attached []string,
+) *nodes.NetworkInterfaceCreateUpdateRequestBody {
+	body := &nodes.NetworkInterfaceCreateUpdateRequestBody{
+		Iface:     m.Name.ValueString(),
+		Type:      "OVSBridge",
+		Autostart: proxmoxtypes.CustomBool(m.Autostart.ValueBool()).Pointer(),
+	}
+
+	body.CIDR = m.Address.ValueStringPointer()
+	body.Gateway = m.Gateway.ValueStringPointer()
+	body.CIDR6 = m.Address6.ValueStringPointer()
+	body.Gateway6 = m.Gateway6.ValueStringPointer()
+	body.Comments = m.Comment.ValueStringPointer()
+
+	if !m.MTU.IsUnknown() {
+		body.MTU = m.MTU.ValueInt64Pointer()
+	}
+
+	ports := append(m.sanitizedPorts(), attached...)
+	sort.Strings(ports)
+
+	if len(ports) > 0 {
+		ovsPorts := strings.Join(ports, " ")
+		body.OVSPorts = &ovsPorts
+	}
+
+	body.OVSOptions = m.OVSOptions.ValueStringPointer()
+
+	return body
+}
+
+func (m *ovsBridgeResourceModel) sanitizedPorts() []string {
+	var sanitizedPorts []string
+
+	for _, port := range m.Ports {
+		port := strings.TrimSpace(port.ValueString())
+		if len(port) > 0 {
+			sanitizedPorts = append(sanitizedPorts, port)
+		}
+	}
+
+	sort.Strings(sanitizedPorts)
+
+	return sanitizedPorts
+}
+
+func (m *ovsBridgeResourceModel) importFromNetworkInterfaceList(
+	iface *nodes.NetworkInterfaceListResponseData,
+	attached []string,
+) {
+	m.Address = customtypes.NewIPCIDRPointerValue(iface.CIDR)
+	m.Gateway = customtypes.NewIPAddrPointerValue(iface.Gateway)
+	m.Address6 = customtypes.NewIPCIDRPointerValue(iface.CIDR6)
+	m.Gateway6 = customtypes.NewIPAddrPointerValue(iface.Gateway6)
+
+	m.Autostart = types.BoolPointerValue(iface.Autostart.PointerBool())
+	if m.Autostart.IsNull() {
+		m.Autostart = types.BoolValue(false)
+	}
+
+	m.MTU = types.Int64Null()
+
+	if iface.MTU != nil {
+		if v, err := strconv.Atoi(*iface.MTU); err == nil {
+			m.MTU = types.Int64Value(int64(v))
+		}
+	}
+
+	// Comments can be set to an empty string in plan, which will translate to a "no value" in PVE
+	// So we don't want to set it to null if it's empty, as this will be indicated as a plan drift
+	if iface.Comments != nil {
+		m.Comment = types.StringValue(strings.TrimSpace(*iface.Comments))
+	}
+
+	m.OVSOptions = types.StringPointerValue(iface.OVSOptions)
+	m.Ports = nil
+
+	if iface.OVSPorts != nil {
+		ports := strings.Fields(*iface.OVSPorts)
+		sort.Strings(ports)
+
+		for _, port := range ports {
+			if !slices.Contains(attached, port) {
+				m.Ports = append(m.Ports, types.StringValue(port))
+			}
+		}
+	}
+}
+
+// NewOVSBridgeResource creates a new resource for managing OVS Bridge network interfaces.
+func NewOVSBridgeResource() resource.Resource {
+	return &ovsBridgeResource{}
+}
+
+type ovsBridgeResource struct {
+	client proxmox.Client
+}
+
+func (r *ovsBridgeResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_network_ovs_bridge"
+}
+
+// Schema defines the schema for the resource.
+func (r *ovsBridgeResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an Open vSwitch Bridge network interface in a Proxmox VE node.",
+		MarkdownDescription: "Manages an Open vSwitch Bridge network interface in a Proxmox VE node. " +
+			"The `openvswitch-switch` package must be installed on the node.",
+		Attributes: map[string]schema.Attribute{
+			// Base attributes
+			"id": attribute.ResourceID("A unique identifier with format `<node name>:<iface>`"),
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The interface name.",
+				MarkdownDescription: "The interface name. Must be vmbr[N], where 0 ≤ N ≤ 9999 " +
+					"(vmbr0 - vmbr9999).",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^vmbr\d{1,4}$`),
+						`must be "vmbr" followed by a number, e.g. "vmbr1"`,
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"address": schema.StringAttribute{
+				Description: "The interface IPv4/CIDR address.",
+				CustomType:  customtypes.IPCIDRType{},
+				Optional:    true,
+			},
+			"gateway": schema.StringAttribute{
+				Description: "Default gateway address.",
+				CustomType:  customtypes.IPAddrType{},
+				Optional:    true,
+			},
+			"address6": schema.StringAttribute{
+				Description: "The interface IPv6/CIDR address.",
+				CustomType:  customtypes.IPCIDRType{},
+				Optional:    true,
+			},
+			"gateway6": schema.StringAttribute{
+				Description: "Default IPv6 gateway address.",
+				CustomType:  customtypes.IPAddrType{},
+				Optional:    true,
+			},
+			"autostart": schema.BoolAttribute{
+				Description: "Automatically start interface on boot (defaults to `true`).",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"mtu": schema.Int64Attribute{
+				Description: "The interface MTU.",
+				Optional:    true,
+			},
+			"comment": schema.StringAttribute{
+				Description: "Comment for the interface.",
+				Optional:    true,
+			},
+			// OVS Bridge attributes
+			"ovs_options": schema.StringAttribute{
+				Description: "The OVS options of the bridge, e.g. `rstp_enable=true`.",
+				Optional:    true,
+			},
+			"ports": schema.ListAttribute{
+				Description: "The physical interfaces of the bridge.",
+				MarkdownDescription: "The physical interfaces of the bridge. The OVS bonds and OVS internal ports " +
+					"attached to the bridge are managed by their own resources, and are not listed here.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			// Network configuration attributes
+			"pending_changes": pendingChangesAttribute(),
+			"skip_apply":      skipApplyAttribute(),
+		},
+	}
+}
+
+func (r *ovsBridgeResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// attachedInterfaces returns the OVS bonds and internal ports attached to the bridge.
+func attachedInterfaces(ifaces []*nodes.NetworkInterfaceListResponseData, bridge string) []string {
+	var attached []string
+
+	for _, iface := range ifaces {
+		if iface.Type != "OVSBond" && iface.Type != "OVSIntPort" {
+			continue
+		}
+
+		if iface.OVSBridge != nil && *iface.OVSBridge == bridge {
+			attached = append(attached, iface.Iface)
+		}
+	}
+
+	return attached
+}
+
+//nolint:dupl
+func (r *ovsBridgeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ovsBridgeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeClient := r.client.Node(plan.NodeName.ValueString())
+
+	err := validatePorts(ctx, nodeClient, plan.sanitizedPorts())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ports"), "Invalid OVS Bridge ports", err.Error())
+
+		return
+	}
+
+	body := plan.exportToNetworkInterfaceCreateUpdateBody(nil)
+
+	err = nodeClient.CreateNetworkInterface(ctx, body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OVS Bridge interface",
+			"Could not create OVS Bridge, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	plan.ID = types.StringValue(plan.NodeName.ValueString() + ":" + plan.Name.ValueString())
+
+	applyDiags := applyNetworkConfiguration(ctx, nodeClient, plan.SkipApply.ValueBool())
+
+	found := r.read(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"OVS Bridge interface not found after creation",
+			fmt.Sprintf(
+				"Interface %q on node %q could not be read after creation",
+				plan.Name.ValueString(), plan.NodeName.ValueString()),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(applyDiags...)
+}
+
+func (r *ovsBridgeResource) read(ctx context.Context, model *ovsBridgeResourceModel, diags *diag.Diagnostics) bool {
+	nodeClient := r.client.Node(model.NodeName.ValueString())
+
+	ifaces, err := nodeClient.ListNetworkInterfaces(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error listing network interfaces",
+			"Could not list network interfaces, unexpected error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	for _, iface := range ifaces {
+		if iface.Iface != model.Name.ValueString() {
+			continue
+		}
+
+		if !checkInterfaceType(model.NodeName.ValueString(), iface, "OVSBridge", diags) {
+			return false
+		}
+
+		model.importFromNetworkInterfaceList(iface, attachedInterfaces(ifaces, iface.Iface))
+		model.PendingChanges = readPendingChanges(ctx, nodeClient, diags)
+
+		if model.SkipApply.IsNull() || model.SkipApply.IsUnknown() {
+			model.SkipApply = types.BoolValue(false)
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// Read reads an OVS Bridge interface.
+func (r *ovsBridgeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ovsBridgeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	warnPendingChanges(state.NodeName.ValueString(), state.PendingChanges, state.SkipApply, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update updates an OVS Bridge interface.
+func (r *ovsBridgeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ovsBridgeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeClient := r.client.Node(plan.NodeName.ValueString())
+
+	err := validatePorts(ctx, nodeClient, plan.sanitizedPorts())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ports"), "Invalid OVS Bridge ports", err.Error())
+
+		return
+	}
+
+	ifaces, err := nodeClient.ListNetworkInterfaces(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing network interfaces",
+			"Could not list network interfaces, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	body := plan.exportToNetworkInterfaceCreateUpdateBody(attachedInterfaces(ifaces, plan.Name.ValueString()))
+
+	var toDelete []string
+
+	if !plan.MTU.Equal(state.MTU) && plan.MTU.ValueInt64() == 0 {
+		toDelete = append(toDelete, "mtu")
+		body.MTU = nil
+	}
+
+	if !plan.Gateway.Equal(state.Gateway) && plan.Gateway.ValueString() == "" {
+		toDelete = append(toDelete, "gateway")
+		body.Gateway = nil
+	}
+
+	if !plan.Gateway6.Equal(state.Gateway6) && plan.Gateway6.ValueString() == "" {
+		toDelete = append(toDelete, "gateway6")
+		body.Gateway6 = nil
+	}
+
+	if !plan.OVSOptions.Equal(state.OVSOptions) && plan.OVSOptions.ValueString() == "" {
+		toDelete = append(toDelete, "ovs_options")
+		body.OVSOptions = nil
+	}
+
+	if body.OVSPorts == nil && len(state.Ports) > 0 {
+		toDelete = append(toDelete, "ovs_ports")
+	}
+
+	if len(toDelete) > 0 {
+		body.Delete = &toDelete
+	}
+
+	err = nodeClient.UpdateNetworkInterface(ctx, plan.Name.ValueString(), body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating OVS Bridge interface",
+			"Could not update OVS Bridge, unexpected error: "+err.Error(),
+		)
+
+		return
+	}
+
+	applyDiags := applyNetworkConfiguration(ctx, nodeClient, plan.SkipApply.ValueBool())
+
+	found := r.read(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"OVS Bridge interface not found after update",
+			fmt.Sprintf(
+				"Interface %q on node %q could not be read after update",
+				plan.Name.ValueString(), plan.NodeName.ValueString()),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(applyDiags...)
+}
+
+// Delete deletes an OVS Bridge interface.
+//
+//nolint:dupl
+func (r *ovsBridgeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ovsBridgeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Node(state.NodeName.ValueString()).DeleteNetworkInterface(ctx, state.Name.ValueString())
+	if err != nil {
+		if strings.Contains(err.Error(), "interface does not exist") {
+			resp.Diagnostics.AddWarning(
+				"OVS Bridge interface does not exist",
+				fmt.Sprintf("Could not delete OVS Bridge '%s', interface does not exist, "+
+					"or has already been deleted outside of Terraform.", state.Name.ValueString()),
+			)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error deleting OVS Bridge interface",
+				fmt.Sprintf("Could not delete OVS Bridge '%s', unexpected error: %s",
+					state.Name.ValueString(), err.Error()),
+			)
+		}
+
+		return
+	}
+
+	resp.Diagnostics.Append(
+		applyNetworkConfiguration(ctx, r.client.Node(state.NodeName.ValueString()), state.SkipApply.ValueBool())...,
+	)
+}
+
+func (r *ovsBridgeResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	idParts := strings.Split(req.ID, ":")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: `node_name:iface`. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	nodeName := idParts[0]
+	iface := idParts[1]
+
+	state := ovsBridgeResourceModel{
+		ID:       types.StringValue(req.ID),
+		NodeName: types.StringValue(nodeName),
+		Name:     types.StringValue(iface),
+	}
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"OVS Bridge interface not found",
+			fmt.Sprintf("Interface %q on node %q could not be imported", iface, nodeName),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
@@ -0,0 +1,114 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package network_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceOVS(t *testing.T) {
+	if os.Getenv("PROXMOX_VE_ACC_OVS") == "" {
+		t.Skip("OVS acceptance tests are disabled, the node must have the openvswitch-switch package installed")
+	}
+
+	te := test.InitEnvironment(t)
+
+	bridge := fmt.Sprintf("vmbr%d", gofakeit.Number(10, 9999))
+	intPort := fmt.Sprintf("ovsint%d", gofakeit.Number(10, 9999))
+	ipV4cidr := fmt.Sprintf("%s/24", gofakeit.IPv4Address())
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: te.RenderConfig(fmt.Sprintf(`
+				resource "proxmox_virtual_environment_network_ovs_bridge" "test" {
+					comment = "created by terraform"
+					name = "%[1]s"
+					node_name = "{{.NodeName}}"
+				}
+
+				resource "proxmox_virtual_environment_network_ovs_intport" "test" {
+					address = "%[3]s"
+					bridge = proxmox_virtual_environment_network_ovs_bridge.test.name
+					name = "%[2]s"
+					node_name = "{{.NodeName}}"
+					vlan = 10
+				}
+				`, bridge, intPort, ipV4cidr)),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_network_ovs_bridge.test", map[string]string{
+						"comment":         "created by terraform",
+						"name":            bridge,
+						"pending_changes": "false",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_network_ovs_bridge.test", []string{
+						"ports.#",
+					}),
+					test.ResourceAttributes("proxmox_virtual_environment_network_ovs_intport.test", map[string]string{
+						"address": ipV4cidr,
+						"bridge":  bridge,
+						"name":    intPort,
+						"vlan":    "10",
+					}),
+				),
+			},
+			// Update testing, the internal port must stay attached to the bridge
+			{
+				Config: te.RenderConfig(fmt.Sprintf(`
+				resource "proxmox_virtual_environment_network_ovs_bridge" "test" {
+					comment = "updated by terraform"
+					name = "%[1]s"
+					node_name = "{{.NodeName}}"
+					ovs_options = "rstp_enable=true"
+				}
+
+				resource "proxmox_virtual_environment_network_ovs_intport" "test" {
+					bridge = proxmox_virtual_environment_network_ovs_bridge.test.name
+					name = "%[2]s"
+					node_name = "{{.NodeName}}"
+				}
+				`, bridge, intPort)),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_network_ovs_bridge.test", map[string]string{
+						"comment":     "updated by terraform",
+						"ovs_options": "rstp_enable=true",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_network_ovs_intport.test", []string{
+						"address",
+						"vlan",
+					}),
+					test.ResourceAttributes("proxmox_virtual_environment_network_ovs_intport.test", map[string]string{
+						"bridge": bridge,
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "proxmox_virtual_environment_network_ovs_bridge.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "proxmox_virtual_environment_network_ovs_intport.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
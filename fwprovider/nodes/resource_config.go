@@ -0,0 +1,366 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+)
+
+var (
+	_ resource.Resource                = &configResource{}
+	_ resource.ResourceWithConfigure   = &configResource{}
+	_ resource.ResourceWithImportState = &configResource{}
+
+	macAddressRegex = regexp.MustCompile(`^[A-Fa-f0-9]{2}(:[A-Fa-f0-9]{2}){5}$`)
+)
+
+type configResourceModel struct {
+	ID                  types.String    `tfsdk:"id"`
+	NodeName            types.String    `tfsdk:"node_name"`
+	Description         types.String    `tfsdk:"description"`
+	StartAllOnBootDelay types.Int64     `tfsdk:"startall_onboot_delay"`
+	WakeOnLAN           *wakeOnLANModel `tfsdk:"wakeonlan"`
+}
+
+type wakeOnLANModel struct {
+	MACAddress       types.String `tfsdk:"mac_address"`
+	BindInterface    types.String `tfsdk:"bind_interface"`
+	BroadcastAddress types.String `tfsdk:"broadcast_address"`
+}
+
+func (m *configResourceModel) toUpdateRequestBody() *nodes.ConfigUpdateRequestBody {
+	body := &nodes.ConfigUpdateRequestBody{
+		Description: m.Description.ValueStringPointer(),
+	}
+
+	if !m.StartAllOnBootDelay.IsNull() {
+		body.StartAllOnbootDelay = ptr.Ptr(int(m.StartAllOnBootDelay.ValueInt64()))
+	}
+
+	if m.WakeOnLAN != nil {
+		body.WakeOnLan = &nodes.WakeOnLandConfig{
+			MACAddress:       m.WakeOnLAN.MACAddress.ValueString(),
+			BindInterface:    m.WakeOnLAN.BindInterface.ValueStringPointer(),
+			BroadcastAddress: m.WakeOnLAN.BroadcastAddress.ValueStringPointer(),
+		}
+	}
+
+	return body
+}
+
+// managedKeys returns the keys of the node configuration that are set by the model.
+func (m *configResourceModel) managedKeys() []string {
+	var keys []string
+
+	if !m.Description.IsNull() {
+		keys = append(keys, "description")
+	}
+
+	if !m.StartAllOnBootDelay.IsNull() {
+		keys = append(keys, "startall-onboot-delay")
+	}
+
+	if m.WakeOnLAN != nil {
+		keys = append(keys, "wakeonlan")
+	}
+
+	return keys
+}
+
+// importFromAPI sets the model from the node configuration. Unless all settings are imported, only the settings which
+// are already set in the model are updated, so the settings not managed by the resource don't cause a drift.
+func (m *configResourceModel) importFromAPI(data *nodes.ConfigGetResponseData, all bool) {
+	managed := m.managedKeys()
+
+	m.Description = types.StringNull()
+	if data.Description != nil && (all || slices.Contains(managed, "description")) {
+		// the description is stored as a comment in the configuration file, and is read back with a trailing newline
+		m.Description = types.StringValue(strings.TrimSuffix(*data.Description, "\n"))
+	}
+
+	m.StartAllOnBootDelay = types.Int64Null()
+	if data.StartAllOnbootDelay != nil && (all || slices.Contains(managed, "startall-onboot-delay")) {
+		m.StartAllOnBootDelay = types.Int64Value(int64(*data.StartAllOnbootDelay))
+	}
+
+	m.WakeOnLAN = nil
+	if data.WakeOnLan != nil && (all || slices.Contains(managed, "wakeonlan")) {
+		m.WakeOnLAN = &wakeOnLANModel{
+			MACAddress:       types.StringValue(data.WakeOnLan.MACAddress),
+			BindInterface:    types.StringPointerValue(data.WakeOnLan.BindInterface),
+			BroadcastAddress: types.StringPointerValue(data.WakeOnLan.BroadcastAddress),
+		}
+	}
+}
+
+// NewConfigResource creates a new resource for managing the configuration of a node.
+func NewConfigResource() resource.Resource {
+	return &configResource{}
+}
+
+type configResource struct {
+	client proxmox.Client
+}
+
+func (r *configResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_node_config"
+}
+
+// Schema defines the schema for the resource.
+func (r *configResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the configuration of a Proxmox VE node.",
+		MarkdownDescription: "Manages the configuration of a Proxmox VE node. Only the settings specified in the " +
+			"resource are managed, and they are removed from the node configuration when the resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ResourceID("The node name."),
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the node, shown in the notes panel of the web interface.",
+				Optional:    true,
+			},
+			"startall_onboot_delay": schema.Int64Attribute{
+				Description: "The initial delay in seconds before starting all the guests with on-boot enabled.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 300),
+				},
+			},
+			"wakeonlan": schema.SingleNestedAttribute{
+				Description: "The wake on LAN settings of the node.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"mac_address": schema.StringAttribute{
+						Description: "The MAC address to send the wake on LAN packet to.",
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(macAddressRegex, "must be a valid MAC address (A0:B1:C2:D3:E4:F5)"),
+						},
+					},
+					"bind_interface": schema.StringAttribute{
+						Description: "The interface to send the wake on LAN packet from.",
+						Optional:    true,
+					},
+					"broadcast_address": schema.StringAttribute{
+						Description: "The IPv4 broadcast address to send the wake on LAN packet to.",
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *configResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// Create applies the node configuration.
+func (r *configResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan configResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Node(plan.NodeName.ValueString()).UpdateConfig(ctx, plan.toUpdateRequestBody())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating node configuration",
+			fmt.Sprintf("Could not update the configuration of node %q, unexpected error: %s", plan.NodeName.ValueString(), err),
+		)
+
+		return
+	}
+
+	plan.ID = plan.NodeName
+
+	r.read(ctx, &plan, false, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *configResource) read(ctx context.Context, model *configResourceModel, all bool, diags *diag.Diagnostics) {
+	data, err := r.client.Node(model.NodeName.ValueString()).GetConfig(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error reading node configuration",
+			fmt.Sprintf("Could not read the configuration of node %q, unexpected error: %s", model.NodeName.ValueString(), err),
+		)
+
+		return
+	}
+
+	model.importFromAPI(data, all)
+}
+
+// Read reads the node configuration.
+func (r *configResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state configResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &state, false, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update updates the node configuration, and removes the settings which are no longer specified.
+func (r *configResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state configResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := plan.toUpdateRequestBody()
+
+	var toDelete []string
+
+	for _, key := range state.managedKeys() {
+		if !slices.Contains(plan.managedKeys(), key) {
+			toDelete = append(toDelete, key)
+		}
+	}
+
+	if len(toDelete) > 0 {
+		body.Delete = ptr.Ptr(strings.Join(toDelete, ","))
+	}
+
+	err := r.client.Node(plan.NodeName.ValueString()).UpdateConfig(ctx, body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating node configuration",
+			fmt.Sprintf("Could not update the configuration of node %q, unexpected error: %s", plan.NodeName.ValueString(), err),
+		)
+
+		return
+	}
+
+	r.read(ctx, &plan, false, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the managed settings from the node configuration.
+func (r *configResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state configResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys := state.managedKeys()
+	if len(keys) == 0 {
+		return
+	}
+
+	err := r.client.Node(state.NodeName.ValueString()).UpdateConfig(ctx, &nodes.ConfigUpdateRequestBody{
+		Delete: ptr.Ptr(strings.Join(keys, ",")),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating node configuration",
+			fmt.Sprintf("Could not reset the configuration of node %q, unexpected error: %s", state.NodeName.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports the configuration of the node with the given name.
+func (r *configResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	state := configResourceModel{
+		ID:       types.StringValue(req.ID),
+		NodeName: types.StringValue(req.ID),
+	}
+
+	r.read(ctx, &state, true, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
@@ -0,0 +1,135 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceNodeConfig(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_node_config" "test" {
+					node_name             = "{{.NodeName}}"
+					description           = "managed by terraform"
+					startall_onboot_delay = 10
+				}`),
+				Check: test.ResourceAttributes("proxmox_virtual_environment_node_config.test", map[string]string{
+					"id":                    te.NodeName,
+					"description":           "managed by terraform",
+					"startall_onboot_delay": "10",
+				}),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_node_config" "test" {
+					node_name   = "{{.NodeName}}"
+					description = "managed by terraform"
+					wakeonlan = {
+						mac_address = "aa:bb:cc:dd:ee:ff"
+					}
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_node_config.test", map[string]string{
+						"wakeonlan.mac_address": "aa:bb:cc:dd:ee:ff",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_node_config.test", []string{
+						"startall_onboot_delay",
+					}),
+				),
+			},
+			{
+				ResourceName:      "proxmox_virtual_environment_node_config.test",
+				ImportState:       true,
+				ImportStateId:     te.NodeName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccDataSourceNodeStatus(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitEnvironment(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				data "proxmox_virtual_environment_node_status" "test" {
+					node_name = "{{.NodeName}}"
+					requirements = {
+						min_pve_version = "8.0"
+					}
+				}`),
+				Check: test.ResourceAttributesSet("data.proxmox_virtual_environment_node_status.test", []string{
+					"boot_mode",
+					"cpu.count",
+					"cpu.model",
+					"iommu_active",
+					"kernel.release",
+					"memory.total",
+					"pve_version",
+					"uptime",
+				}),
+			},
+			{
+				Config: te.RenderConfig(`
+				data "proxmox_virtual_environment_node_status" "test" {
+					node_name = "{{.NodeName}}"
+					requirements = {
+						cpu_flags = ["no-such-flag"]
+					}
+				}`),
+				ExpectError: regexp.MustCompile(`does not have the flags: no-such-flag`),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceNodeCPUModels(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitEnvironment(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				data "proxmox_virtual_environment_node_cpu_models" "test" {
+					node_name = "{{.NodeName}}"
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributesSet("data.proxmox_virtual_environment_node_cpu_models.test", []string{
+						"models.0.name",
+						"models.0.vendor",
+						"models.0.custom",
+						"names.0",
+					}),
+					resource.TestCheckTypeSetElemAttr(
+						"data.proxmox_virtual_environment_node_cpu_models.test", "names.*", "x86-64-v2-AES",
+					),
+				),
+			},
+		},
+	})
+}
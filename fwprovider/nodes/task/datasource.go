@@ -0,0 +1,230 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/tasks"
+)
+
+// defaultLogLines is the default number of the last lines of the log to retrieve.
+const defaultLogLines = 50
+
+var (
+	_ datasource.DataSource              = &taskDataSource{}
+	_ datasource.DataSourceWithConfigure = &taskDataSource{}
+)
+
+type taskDataSourceModel struct {
+	UPID       types.String   `tfsdk:"upid"`
+	LogLines   types.Int64    `tfsdk:"log_lines"`
+	NodeName   types.String   `tfsdk:"node_name"`
+	Type       types.String   `tfsdk:"type"`
+	User       types.String   `tfsdk:"user"`
+	Status     types.String   `tfsdk:"status"`
+	ExitStatus types.String   `tfsdk:"exit_status"`
+	StartTime  types.String   `tfsdk:"start_time"`
+	EndTime    types.String   `tfsdk:"end_time"`
+	Log        []types.String `tfsdk:"log"`
+}
+
+// NewDataSource creates a new data source for retrieving the status and the log of a task.
+func NewDataSource() datasource.DataSource {
+	return &taskDataSource{}
+}
+
+type taskDataSource struct {
+	client proxmox.Client
+}
+
+func (d *taskDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_task"
+}
+
+// Schema defines the schema for the data source.
+func (d *taskDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the status and the log of a task.",
+		MarkdownDescription: "Retrieves the status and the log of a task, e.g. to debug a failed resource " +
+			"whose error message truncates the log of its task.",
+		Attributes: map[string]schema.Attribute{
+			"upid": schema.StringAttribute{
+				Description: "The unique ID of the task, e.g. " +
+					"`UPID:pve:000D1F5B:0185C71C:64F0E1B3:qmclone:100:root@pam:`.",
+				Required: true,
+				Validators: []validator.String{
+					validators.NewParseValidator(tasks.ParseTaskID, "value must be a task ID (UPID)"),
+				},
+			},
+			"log_lines": schema.Int64Attribute{
+				Description: fmt.Sprintf("The number of the last lines of the log to retrieve (defaults to `%d`). "+
+					"`0` skips the log.", defaultLogLines),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 5000),
+				},
+			},
+			"node_name": schema.StringAttribute{
+				Description: "The name of the node running the task.",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "The type of the task, e.g. `qmclone`.",
+				Computed:    true,
+			},
+			"user": schema.StringAttribute{
+				Description: "The user who started the task.",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the task, either `running` or `stopped`.",
+				Computed:    true,
+			},
+			"exit_status": schema.StringAttribute{
+				Description: "The exit status of the stopped task, e.g. `OK`, or the error of the failed task.",
+				Computed:    true,
+			},
+			"start_time": schema.StringAttribute{
+				Description: "The start time of the task, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"end_time": schema.StringAttribute{
+				Description: "The end time of the stopped task, in RFC 3339 format. It is not set if the task " +
+					"is no longer listed by the node.",
+				Computed: true,
+			},
+			"log": schema.ListAttribute{
+				Description: "The last lines of the log of the task.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *taskDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+func (d *taskDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model taskDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upid := model.UPID.ValueString()
+
+	// the ID is validated by the schema
+	tid, _ := tasks.ParseTaskID(upid)
+
+	tasksClient := d.client.Node(tid.NodeName).Tasks()
+
+	status, err := tasksClient.GetTaskStatus(ctx, upid)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Task",
+			fmt.Sprintf("Could not read the status of task %q: %s", upid, err.Error()),
+		)
+
+		return
+	}
+
+	model.NodeName = types.StringValue(tid.NodeName)
+	model.Type = types.StringValue(tid.Type)
+	model.User = types.StringValue(tid.User)
+	model.Status = types.StringValue(status.Status)
+	model.ExitStatus = types.StringNull()
+	model.StartTime = types.StringValue(tid.StartTime.Format(time.RFC3339))
+	model.EndTime = types.StringNull()
+
+	if status.ExitCode != "" {
+		model.ExitStatus = types.StringValue(status.ExitCode)
+	}
+
+	// the end time is only reported by the task list, so it is only read once the task is stopped
+	if status.Status != "running" {
+		t, err := tasksClient.GetTask(ctx, upid)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Task",
+				fmt.Sprintf("Could not read the end time of task %q: %s", upid, err.Error()),
+			)
+
+			return
+		}
+
+		if t != nil && t.EndTime != nil {
+			model.EndTime = types.StringValue(time.Unix(*t.EndTime, 0).UTC().Format(time.RFC3339))
+		}
+	}
+
+	logLines := defaultLogLines
+	if !model.LogLines.IsNull() {
+		logLines = int(model.LogLines.ValueInt64())
+	}
+
+	model.Log = []types.String{}
+
+	if logLines > 0 {
+		lines, err := tasksClient.GetTaskLogTail(ctx, upid, logLines)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Task",
+				fmt.Sprintf("Could not read the log of task %q: %s", upid, err.Error()),
+			)
+
+			return
+		}
+
+		for _, line := range lines {
+			model.Log = append(model.Log, types.StringValue(line))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
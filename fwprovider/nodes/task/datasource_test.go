@@ -0,0 +1,79 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package task_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+)
+
+func TestAccDataSourceTask(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitEnvironment(t)
+
+	ctx := context.Background()
+
+	vmID, err := cluster.NewIDGenerator(te.ClusterClient(), cluster.IDGeneratorConfig{RandomIDs: true}).NextID(ctx)
+	require.NoError(t, err)
+
+	upid, err := te.NodeClient().VM(0).CreateVMAsync(ctx, &vms.CreateRequestBody{VMID: vmID})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = te.NodeClient().VM(vmID).DeleteVM(ctx) //nolint:errcheck
+	})
+
+	require.NoError(t, te.NodeClient().Tasks().WaitForTask(ctx, *upid))
+
+	te.AddTemplateVars(map[string]any{
+		"UPID": *upid,
+	})
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				data "proxmox_virtual_environment_task" "test" {
+					upid = "{{.UPID}}"
+				}
+
+				data "proxmox_virtual_environment_task" "no_log" {
+					upid      = "{{.UPID}}"
+					log_lines = 0
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("data.proxmox_virtual_environment_task.test", map[string]string{
+						"node_name":   te.NodeName,
+						"type":        "qmcreate",
+						"status":      "stopped",
+						"exit_status": "OK",
+					}),
+					test.ResourceAttributesSet("data.proxmox_virtual_environment_task.test", []string{
+						"end_time",
+						"log.0",
+						"start_time",
+						"user",
+					}),
+					test.ResourceAttributes("data.proxmox_virtual_environment_task.no_log", map[string]string{
+						"log.#": "0",
+					}),
+				),
+			},
+		},
+	})
+}
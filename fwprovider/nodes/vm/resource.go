@@ -26,6 +26,7 @@ import (
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/vm/cpu"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/vm/rng"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/vm/vga"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/types/stringset"
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
@@ -47,12 +48,14 @@ var (
 	_ resource.Resource                = &Resource{}
 	_ resource.ResourceWithConfigure   = &Resource{}
 	_ resource.ResourceWithImportState = &Resource{}
+	_ resource.ResourceWithModifyPlan  = &Resource{}
 )
 
 // Resource implements the resource.Resource interface for managing VMs.
 type Resource struct {
-	client      proxmox.Client
-	idGenerator cluster.IDGenerator
+	client       proxmox.Client
+	idGenerator  cluster.IDGenerator
+	tagValidator *cluster.TagValidator
 }
 
 // NewResource creates a new resource for managing VMs.
@@ -91,6 +94,39 @@ func (r *Resource) Configure(
 
 	r.client = cfg.Client
 	r.idGenerator = cfg.IDGenerator
+	r.tagValidator = cfg.TagValidator
+}
+
+// ModifyPlan checks the tags changed by the plan against the cluster tag policy.
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planTags, stateTags stringset.Value
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &planTags)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("tags"), &stateTags)...)
+	}
+
+	if resp.Diagnostics.HasError() || planTags.IsUnknown() {
+		return
+	}
+
+	warning, err := r.tagValidator.Validate(
+		ctx,
+		stateTags.ValueList(ctx, &resp.Diagnostics),
+		planTags.ValueList(ctx, &resp.Diagnostics),
+	)
+	if warning != "" {
+		resp.Diagnostics.AddAttributeWarning(path.Root("tags"), "Tags may be rejected by the cluster", warning)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("tags"), "Tags rejected by the cluster", err.Error())
+	}
 }
 
 // Create creates a new VM.
@@ -109,7 +145,8 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	if plan.ID.ValueInt64() == 0 {
+	// a new VM gets its ID allocated at creation, so it can be re-allocated if taken concurrently
+	if plan.ID.ValueInt64() == 0 && plan.Clone != nil {
 		id, err := r.idGenerator.NextID(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError("Failed to generate VM ID", err.Error())
@@ -123,10 +160,19 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	var created bool
+
 	if plan.Clone != nil {
-		r.clone(ctx, plan, &resp.Diagnostics)
+		created = r.clone(ctx, plan, &resp.Diagnostics)
 	} else {
-		r.create(ctx, plan, &resp.Diagnostics)
+		created = r.create(ctx, &plan, &resp.Diagnostics)
+	}
+
+	if created {
+		// the VM is tracked as soon as it exists, so that a failure of any of the following steps leaves it
+		// in the state as tainted, and the next apply replaces it, instead of failing as it already exists
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("node_name"), plan.NodeName)...)
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -147,7 +193,8 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-func (r *Resource) create(ctx context.Context, plan Model, diags *diag.Diagnostics) {
+// create creates the VM, and returns true if it has been created.
+func (r *Resource) create(ctx context.Context, plan *Model, diags *diag.Diagnostics) bool {
 	createBody := &vms.CreateRequestBody{
 		Description: plan.Description.ValueStringPointer(),
 		Name:        plan.Name.ValueStringPointer(),
@@ -163,22 +210,43 @@ func (r *Resource) create(ctx context.Context, plan Model, diags *diag.Diagnosti
 	vga.FillCreateBody(ctx, plan.VGA, createBody, diags)
 
 	if diags.HasError() {
-		return
+		return false
 	}
 
 	// .VM(0) is used to create a new VM, the VM ID is not used in the API URL
 	vmAPI := r.client.Node(plan.NodeName.ValueString()).VM(0)
 
-	err := vmAPI.CreateVM(ctx, createBody)
+	if plan.ID.ValueInt64() != 0 {
+		if err := vmAPI.CreateVM(ctx, createBody); err != nil {
+			diags.AddError("Failed to create VM", err.Error())
+
+			return false
+		}
+
+		return true
+	}
+
+	id, err := r.idGenerator.CreateWithNextID(ctx, func(ctx context.Context, id int) error {
+		createBody.VMID = id
+
+		return vmAPI.CreateVM(ctx, createBody)
+	})
 	if err != nil {
 		diags.AddError("Failed to create VM", err.Error())
+
+		return false
 	}
+
+	plan.ID = types.Int64Value(int64(id))
+
+	return true
 }
 
-func (r *Resource) clone(ctx context.Context, plan Model, diags *diag.Diagnostics) {
+// clone clones the VM, and returns true if the clone has been created, even if it failed to be configured.
+func (r *Resource) clone(ctx context.Context, plan Model, diags *diag.Diagnostics) bool {
 	if plan.Clone == nil {
 		diags.AddError("Clone configuration is missing", "")
-		return
+		return false
 	}
 
 	sourceID := int(plan.Clone.ID.ValueInt64())
@@ -197,7 +265,7 @@ func (r *Resource) clone(ctx context.Context, plan Model, diags *diag.Diagnostic
 	}
 
 	if diags.HasError() {
-		return
+		return false
 	}
 
 	// now load the clone's configuration into a temporary model and update what is needed comparing to the plan
@@ -213,11 +281,11 @@ func (r *Resource) clone(ctx context.Context, plan Model, diags *diag.Diagnostic
 
 	read(ctx, r.client, &clone, diags)
 
-	if diags.HasError() {
-		return
+	if !diags.HasError() {
+		r.update(ctx, plan, clone, true, diags)
 	}
 
-	r.update(ctx, plan, clone, true, diags)
+	return true
 }
 
 //nolint:dupl
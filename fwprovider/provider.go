@@ -10,8 +10,10 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -26,20 +28,36 @@ import (
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/access"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/acme"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/backup"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/ha"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/hardwaremapping"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/join"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/metrics"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/notification"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/options"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/replication"
+	sdnsubnet "github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/subnet"
+	sdnvnet "github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/vnet"
 	sdnzone "github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/zone"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/tags"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/vmid"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/agent"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/apt"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/datastores"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/disks"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/firewall"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/hardware"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/network"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/task"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/vm"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/storage"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
 	proxmoxnodes "github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
 	"github.com/bpg/terraform-provider-proxmox/utils"
@@ -67,12 +85,17 @@ type proxmoxProvider struct {
 // proxmoxProviderModel maps provider schema data.
 type proxmoxProviderModel struct {
 	Endpoint            types.String `tfsdk:"endpoint"`
+	Endpoints           types.List   `tfsdk:"endpoints"`
 	Insecure            types.Bool   `tfsdk:"insecure"`
 	MinTLS              types.String `tfsdk:"min_tls"`
+	CACertificate       types.String `tfsdk:"ca_certificate"`
 	AuthTicket          types.String `tfsdk:"auth_ticket"`
 	CSRFPreventionToken types.String `tfsdk:"csrf_prevention_token"`
+	CredentialCommand   types.String `tfsdk:"credential_command"`
 	APIToken            types.String `tfsdk:"api_token"`
 	OTP                 types.String `tfsdk:"otp"`
+	OTPCode             types.String `tfsdk:"otp_code"`
+	OTPSecret           types.String `tfsdk:"otp_secret"`
 	Username            types.String `tfsdk:"username"`
 	Password            types.String `tfsdk:"password"`
 
@@ -81,22 +104,43 @@ type proxmoxProviderModel struct {
 		AgentSocket     types.String `tfsdk:"agent_socket"`
 		AgentForwarding types.Bool   `tfsdk:"agent_forwarding"`
 		PrivateKey      types.String `tfsdk:"private_key"`
+		KeyPassphrase   types.String `tfsdk:"private_key_passphrase"`
+		Certificate     types.String `tfsdk:"certificate"`
 		Password        types.String `tfsdk:"password"`
 		Username        types.String `tfsdk:"username"`
 		Socks5Server    types.String `tfsdk:"socks5_server"`
 		Socks5Username  types.String `tfsdk:"socks5_username"`
 		Socks5Password  types.String `tfsdk:"socks5_password"`
+		ProxyJump       types.String `tfsdk:"proxy_jump"`
+		SFTPChunkSize   types.Int64  `tfsdk:"sftp_chunk_size"`
 
 		Nodes []struct {
-			Name    types.String `tfsdk:"name"`
-			Address types.String `tfsdk:"address"`
-			Port    types.Int64  `tfsdk:"port"`
+			Name         types.String `tfsdk:"name"`
+			Address      types.String `tfsdk:"address"`
+			Port         types.Int64  `tfsdk:"port"`
+			ProxyJump    types.String `tfsdk:"proxy_jump"`
+			Socks5Server types.String `tfsdk:"socks5_server"`
+			APIEndpoint  types.String `tfsdk:"api_endpoint"`
+			APIInsecure  types.Bool   `tfsdk:"api_insecure"`
 		} `tfsdk:"node"`
 	} `tfsdk:"ssh"`
 	TmpDir         types.String `tfsdk:"tmp_dir"`
 	RandomVMIDs    types.Bool   `tfsdk:"random_vm_ids"`
 	RandomVMIDStat types.Int64  `tfsdk:"random_vm_id_start"`
 	RandomVMIDEnd  types.Int64  `tfsdk:"random_vm_id_end"`
+
+	AllowPostUploadCommands types.Bool `tfsdk:"allow_post_upload_commands"`
+
+	APIMaxParallelism      types.Int64 `tfsdk:"api_max_parallelism"`
+	APIMaxHeavyParallelism types.Int64 `tfsdk:"api_max_heavy_parallelism"`
+	DebugAPILogging        types.Bool  `tfsdk:"debug_api_logging"`
+
+	Retries    types.Int64  `tfsdk:"retries"`
+	MinBackoff types.String `tfsdk:"min_backoff"`
+	MaxBackoff types.String `tfsdk:"max_backoff"`
+
+	TaskPollBackoff  types.Float64 `tfsdk:"task_poll_backoff"`
+	TaskPollInterval types.String  `tfsdk:"task_poll_interval"`
 }
 
 func (p *proxmoxProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -108,6 +152,23 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 	resp.Schema = schema.Schema{
 		// Attributes specified in alphabetical order.
 		Attributes: map[string]schema.Attribute{
+			"allow_post_upload_commands": schema.BoolAttribute{
+				Description: "Whether to allow the file resources to run their post-upload commands on the nodes " +
+					"over SSH. Defaults to `false`.",
+				Optional: true,
+			},
+			"api_max_heavy_parallelism": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of the API requests starting heavy operations, i.e. "+
+					"clones, migrations and backups, sent concurrently. Defaults to `%d`.", api.DefaultMaxHeavyParallelism),
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"api_max_parallelism": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of the API requests sent concurrently, to avoid "+
+					"overloading the nodes during large applies. Defaults to `%d`.", api.DefaultMaxParallelism),
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
 			"api_token": schema.StringAttribute{
 				Description: "The API token for the Proxmox VE API.",
 				Optional:    true,
@@ -123,6 +184,20 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"credential_command": schema.StringAttribute{
+				Description: "A command executed with the system shell to obtain the credentials for the Proxmox VE API. " +
+					"It must print a JSON object with any of the `username`, `password`, `api_token`, `auth_ticket` " +
+					"and `csrf_prevention_token` keys, which replace the other credentials.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"debug_api_logging": schema.BoolAttribute{
+				Description: "Whether to log every API request and response, with the credentials and the secrets " +
+					"redacted, and a correlation ID added to the errors. Defaults to `false`.",
+				Optional: true,
+			},
 			"endpoint": schema.StringAttribute{
 				Description: "The endpoint for the Proxmox VE API.",
 				Optional:    true,
@@ -130,6 +205,24 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"endpoints": schema.ListAttribute{
+				Description: "The endpoints of the other nodes of the cluster for the Proxmox VE API, which are used " +
+					"in turn when `endpoint` can't be reached.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"ca_certificate": schema.StringAttribute{
+				Description: "The PEM encoded CA certificate, or the path to the file, to verify the certificate " +
+					"of the Proxmox VE API against, e.g. for a cluster using an internal CA. Takes precedence over " +
+					"`insecure`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 			"insecure": schema.BoolAttribute{
 				Description: "Whether to skip the TLS verification step.",
 				Optional:    true,
@@ -138,6 +231,9 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Description: "The minimum required TLS version for API calls." +
 					"Supported values: `1.0|1.1|1.2|1.3`. Defaults to `1.3`.",
 				Optional: true,
+				Validators: []validator.String{
+					validators.TLSVersionValidator(),
+				},
 			},
 			"otp": schema.StringAttribute{
 				Description: "The one-time password for the Proxmox VE API.",
@@ -145,6 +241,24 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				DeprecationMessage: "The `otp` attribute is deprecated and will be removed in a future release. " +
 					"Please use the `api_token` attribute instead.",
 			},
+			"otp_code": schema.StringAttribute{
+				Description: "A static TOTP code to complete the two-factor login for the Proxmox VE API. " +
+					"It can be used only once, so `otp_secret` is preferred.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"otp_secret": schema.StringAttribute{
+				Description: "The base32 encoded TOTP secret to compute the codes completing the two-factor login " +
+					"for the Proxmox VE API.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 			"password": schema.StringAttribute{
 				Description: "The password for the Proxmox VE API.",
 				Optional:    true,
@@ -164,6 +278,43 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:    true,
 				Validators:  []validator.Int64{int64validator.Between(100, 999999999)},
 			},
+			"retries": schema.Int64Attribute{
+				Description: "The number of times a request failing with a transient server error, e.g. `503`, " +
+					"is retried. `0` disables the retries. Defaults to `3`.",
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.Between(0, api.MaxRetries)},
+			},
+			"min_backoff": schema.StringAttribute{
+				Description: "The delay before the first retry of a request, which doubles with each retry, " +
+					"e.g. `500ms`. Defaults to `1s`.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.RetryBackoffValidator(),
+				},
+			},
+			"max_backoff": schema.StringAttribute{
+				Description: "The maximum delay between the retries of a request, e.g. `1m`. Defaults to `30s`.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.RetryBackoffValidator(),
+				},
+			},
+			"task_poll_backoff": schema.Float64Attribute{
+				Description: "The factor by which the delay between the polls of a task status grows, " +
+					"e.g. `2` to double it after each poll, up to 30 seconds. Defaults to `1`.",
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.Between(1, api.MaxTaskPollBackoff),
+				},
+			},
+			"task_poll_interval": schema.StringAttribute{
+				Description: "The delay between the polls of a task status while waiting for it to complete, " +
+					"e.g. `500ms`. Defaults to `1s`.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.TaskPollIntervalValidator(),
+				},
+			},
 			"tmp_dir": schema.StringAttribute{
 				Description: "The alternative temporary directory.",
 				Optional:    true,
@@ -207,11 +358,40 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 							Sensitive: true,
 						},
 						"private_key": schema.StringAttribute{
-							Description: "The unencrypted private key (in PEM format) used for the SSH connection. " +
+							Description: "The private key (in PEM or OpenSSH format) used for the SSH connection. " +
 								"Defaults to the value of the `PROXMOX_VE_SSH_PRIVATE_KEY` environment variable.",
 							Optional:  true,
 							Sensitive: true,
 						},
+						"private_key_passphrase": schema.StringAttribute{
+							Description: "The passphrase used to decrypt the `private_key`, if it is encrypted. " +
+								"Defaults to the value of the `PROXMOX_VE_SSH_PRIVATE_KEY_PASSPHRASE` environment variable.",
+							Optional:  true,
+							Sensitive: true,
+						},
+						"certificate": schema.StringAttribute{
+							Description: "The OpenSSH user certificate (the content of the `-cert.pub` file) signing the " +
+								"public key of the `private_key`, for the nodes which require signed keys. " +
+								"Defaults to the value of the `PROXMOX_VE_SSH_CERTIFICATE` environment variable.",
+							Optional: true,
+						},
+						"proxy_jump": schema.StringAttribute{
+							Description: "The jump host to reach the nodes through, as `[user@]host[:port]`, e.g. " +
+								"`admin@bastion.example.com:2222`. The user defaults to the SSH username, and the jump host " +
+								"is authenticated like the nodes. Defaults to the value of the `PROXMOX_VE_SSH_PROXY_JUMP` " +
+								"environment variable.",
+							Optional: true,
+						},
+						"sftp_chunk_size": schema.Int64Attribute{
+							Description: fmt.Sprintf("The size in bytes of the SFTP write requests of the staged uploads, "+
+								"between `%d` and `%d` (defaults to `%d`). Larger chunks improve the throughput to the distant "+
+								"nodes. Defaults to the value of the `PROXMOX_VE_SSH_SFTP_CHUNK_SIZE` environment variable.",
+								ssh.MinSFTPChunkSize, ssh.MaxSFTPChunkSize, ssh.DefaultSFTPChunkSize),
+							Optional: true,
+							Validators: []validator.Int64{
+								int64validator.Between(ssh.MinSFTPChunkSize, ssh.MaxSFTPChunkSize),
+							},
+						},
 						"socks5_password": schema.StringAttribute{
 							Description: "The password for the SOCKS5 proxy server. " +
 								"Defaults to the value of the `PROXMOX_VE_SSH_SOCKS5_PASSWORD` environment variable.",
@@ -244,6 +424,18 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 										Description: "The address of the Proxmox VE node.",
 										Required:    true,
 									},
+									"api_endpoint": schema.StringAttribute{
+										Description: "The API endpoint of the Proxmox VE node, e.g. `https://10.0.0.1:8006/`, " +
+											"to send the requests scoped to the node directly to, instead of proxying them " +
+											"through `endpoint`.",
+										Optional:   true,
+										Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+									},
+									"api_insecure": schema.BoolAttribute{
+										Description: "Whether to skip the TLS verification of `api_endpoint`, overriding " +
+											"`insecure`, e.g. when the node presents a self-signed certificate.",
+										Optional: true,
+									},
 									"name": schema.StringAttribute{
 										Description: "The name of the Proxmox VE node.",
 										Required:    true,
@@ -253,6 +445,15 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 										Optional:    true,
 										Validators:  []validator.Int64{int64validator.Between(1, 65535)},
 									},
+									"proxy_jump": schema.StringAttribute{
+										Description: "The jump host to reach the Proxmox VE node through, overriding `proxy_jump`.",
+										Optional:    true,
+									},
+									"socks5_server": schema.StringAttribute{
+										Description: "The address:port of the SOCKS5 proxy server to reach the Proxmox VE node " +
+											"through, overriding `socks5_server`.",
+										Optional: true,
+									},
 								},
 							},
 						},
@@ -302,10 +503,15 @@ func (p *proxmoxProvider) Configure(
 
 	// Check environment variables
 	endpoint := utils.GetAnyStringEnv("PROXMOX_VE_ENDPOINT")
+	endpoints := utils.GetAnyListEnv("PROXMOX_VE_ENDPOINTS")
 	insecure := utils.GetAnyBoolEnv("PROXMOX_VE_INSECURE")
 	minTLS := utils.GetAnyStringEnv("PROXMOX_VE_MIN_TLS")
+	caCertificate := utils.GetAnyStringEnv("PROXMOX_VE_CA_CERTIFICATE")
 	authTicket := utils.GetAnyStringEnv("PROXMOX_VE_AUTH_TICKET")
 	csrfPreventionToken := utils.GetAnyStringEnv("PROXMOX_VE_CSRF_PREVENTION_TOKEN")
+	credentialCommand := utils.GetAnyStringEnv("PROXMOX_VE_CREDENTIAL_COMMAND")
+	otpCode := utils.GetAnyStringEnv("PROXMOX_VE_OTP_CODE")
+	otpSecret := utils.GetAnyStringEnv("PROXMOX_VE_OTP_SECRET")
 	apiToken := utils.GetAnyStringEnv("PROXMOX_VE_API_TOKEN")
 	username := utils.GetAnyStringEnv("PROXMOX_VE_USERNAME")
 	password := utils.GetAnyStringEnv("PROXMOX_VE_PASSWORD")
@@ -318,6 +524,11 @@ func (p *proxmoxProvider) Configure(
 		endpoint = cfg.Endpoint.ValueString()
 	}
 
+	if !cfg.Endpoints.IsNull() {
+		endpoints = nil
+		resp.Diagnostics.Append(cfg.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+	}
+
 	if !cfg.Insecure.IsNull() {
 		insecure = cfg.Insecure.ValueBool()
 	}
@@ -326,6 +537,10 @@ func (p *proxmoxProvider) Configure(
 		minTLS = cfg.MinTLS.ValueString()
 	}
 
+	if !cfg.CACertificate.IsNull() {
+		caCertificate = cfg.CACertificate.ValueString()
+	}
+
 	if !cfg.AuthTicket.IsNull() {
 		authTicket = cfg.AuthTicket.ValueString()
 	}
@@ -334,10 +549,22 @@ func (p *proxmoxProvider) Configure(
 		csrfPreventionToken = cfg.CSRFPreventionToken.ValueString()
 	}
 
+	if !cfg.CredentialCommand.IsNull() {
+		credentialCommand = cfg.CredentialCommand.ValueString()
+	}
+
 	if !cfg.APIToken.IsNull() {
 		apiToken = cfg.APIToken.ValueString()
 	}
 
+	if !cfg.OTPCode.IsNull() {
+		otpCode = cfg.OTPCode.ValueString()
+	}
+
+	if !cfg.OTPSecret.IsNull() {
+		otpSecret = cfg.OTPSecret.ValueString()
+	}
+
 	if !cfg.Username.IsNull() {
 		username = cfg.Username.ValueString()
 	}
@@ -346,7 +573,7 @@ func (p *proxmoxProvider) Configure(
 		password = cfg.Password.ValueString()
 	}
 
-	if endpoint == "" {
+	if endpoint == "" && len(endpoints) == 0 {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("endpoint"),
 			"Missing Proxmox VE API Endpoint",
@@ -360,9 +587,28 @@ func (p *proxmoxProvider) Configure(
 		return
 	}
 
+	if credentialCommand != "" {
+		cc, err := api.RunCredentialCommand(ctx, credentialCommand)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credential_command"),
+				"Unable to obtain Proxmox VE API credentials",
+				err.Error(),
+			)
+
+			return
+		}
+
+		username, password, apiToken = cc.Username, cc.Password, cc.APIToken
+		authTicket, csrfPreventionToken = cc.AuthTicket, cc.CSRFPreventionToken
+	}
+
 	// Create the Proxmox VE API client
 
-	creds, err := api.NewCredentials(username, password, "", apiToken, authTicket, csrfPreventionToken)
+	creds, err := api.NewCredentials(
+		username, password, "", apiToken, authTicket, csrfPreventionToken,
+		api.WithTOTP(otpSecret, otpCode),
+	)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create Proxmox VE API credentials",
@@ -370,10 +616,33 @@ func (p *proxmoxProvider) Configure(
 		)
 	}
 
-	conn, err := api.NewConnection(
-		endpoint,
+	var connOpts []api.ConnectionOption
+
+	if caCertificate != "" {
+		rootCAs, e := api.LoadCACertificates(caCertificate)
+		if e != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Proxmox VE API CA certificate",
+				e.Error(),
+			)
+
+			return
+		}
+
+		if insecure {
+			tflog.Warn(ctx, "Both `insecure` and `ca_certificate` are set, the certificate of the Proxmox VE API "+
+				"is verified against `ca_certificate`")
+		}
+
+		connOpts = append(connOpts, api.WithRootCAs(rootCAs))
+	}
+
+	conn, err := api.NewFailoverConnection(
+		ctx,
+		append([]string{endpoint}, endpoints...),
 		insecure,
 		minTLS,
+		connOpts...,
 	)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -382,11 +651,117 @@ func (p *proxmoxProvider) Configure(
 		)
 	}
 
+	taskPollInterval := utils.GetAnyStringEnv("PROXMOX_VE_TASK_POLL_INTERVAL")
+	taskPollBackoff := utils.GetAnyFloatEnv("PROXMOX_VE_TASK_POLL_BACKOFF")
+
+	if !cfg.TaskPollInterval.IsNull() {
+		taskPollInterval = cfg.TaskPollInterval.ValueString()
+	}
+
+	if !cfg.TaskPollBackoff.IsNull() {
+		taskPollBackoff = cfg.TaskPollBackoff.ValueFloat64()
+	}
+
+	taskPolling, err := api.NewTaskPolling(taskPollInterval, taskPollBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid task polling configuration",
+			err.Error(),
+		)
+	}
+
+	retries := utils.GetAnyStringEnv("PROXMOX_VE_RETRIES")
+	minBackoff := utils.GetAnyStringEnv("PROXMOX_VE_MIN_BACKOFF")
+	maxBackoff := utils.GetAnyStringEnv("PROXMOX_VE_MAX_BACKOFF")
+
+	if !cfg.Retries.IsNull() {
+		retries = strconv.FormatInt(cfg.Retries.ValueInt64(), 10)
+	}
+
+	if !cfg.MinBackoff.IsNull() {
+		minBackoff = cfg.MinBackoff.ValueString()
+	}
+
+	if !cfg.MaxBackoff.IsNull() {
+		maxBackoff = cfg.MaxBackoff.ValueString()
+	}
+
+	retryPolicy, err := api.NewRetryPolicy(retries, minBackoff, maxBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid retry configuration",
+			err.Error(),
+		)
+	}
+
+	maxParallelism := utils.GetAnyIntEnv("PROXMOX_VE_API_MAX_PARALLELISM")
+	maxHeavyParallelism := utils.GetAnyIntEnv("PROXMOX_VE_API_MAX_HEAVY_PARALLELISM")
+
+	if !cfg.APIMaxParallelism.IsNull() {
+		maxParallelism = int(cfg.APIMaxParallelism.ValueInt64())
+	}
+
+	if !cfg.APIMaxHeavyParallelism.IsNull() {
+		maxHeavyParallelism = int(cfg.APIMaxHeavyParallelism.ValueInt64())
+	}
+
+	if maxParallelism <= 0 {
+		maxParallelism = api.DefaultMaxParallelism
+	}
+
+	if maxHeavyParallelism <= 0 {
+		maxHeavyParallelism = api.DefaultMaxHeavyParallelism
+	}
+
+	debugAPILogging := utils.GetAnyBoolEnv("PROXMOX_VE_DEBUG_API_LOGGING")
+
+	if !cfg.DebugAPILogging.IsNull() {
+		debugAPILogging = cfg.DebugAPILogging.ValueBool()
+	}
+
+	nodeConns := map[string]*api.Connection{}
+
+	if len(cfg.SSH) > 0 {
+		for _, n := range cfg.SSH[0].Nodes {
+			if n.APIEndpoint.IsNull() {
+				continue
+			}
+
+			nodeInsecure := insecure
+			if !n.APIInsecure.IsNull() {
+				nodeInsecure = n.APIInsecure.ValueBool()
+			}
+
+			nodeConn, e := api.NewConnection(n.APIEndpoint.ValueString(), nodeInsecure, minTLS, connOpts...)
+			if e != nil {
+				resp.Diagnostics.AddError(
+					fmt.Sprintf("Invalid API endpoint of the Proxmox VE node %q", n.Name.ValueString()),
+					e.Error(),
+				)
+
+				continue
+			}
+
+			nodeConns[n.Name.ValueString()] = nodeConn
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	apiClient, err := api.NewClient(creds, conn)
+	clientOpts := []api.ClientOption{
+		api.WithTaskPolling(taskPolling),
+		api.WithRetryPolicy(retryPolicy),
+		api.WithMaxParallelism(maxParallelism, maxHeavyParallelism),
+		api.WithNodeEndpoints(nodeConns),
+	}
+
+	if debugAPILogging {
+		clientOpts = append(clientOpts, api.WithDebugLogging())
+	}
+
+	apiClient, err := api.NewClient(creds, conn, clientOpts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create Proxmox VE API client",
@@ -399,10 +774,14 @@ func (p *proxmoxProvider) Configure(
 	sshAgent := utils.GetAnyBoolEnv("PROXMOX_VE_SSH_AGENT")
 	sshAgentForwarding := utils.GetAnyBoolEnv("PROXMOX_VE_SSH_AGENT_FORWARDING")
 	sshPrivateKey := utils.GetAnyStringEnv("PROXMOX_VE_SSH_PRIVATE_KEY")
+	sshKeyPassphrase := utils.GetAnyStringEnv("PROXMOX_VE_SSH_PRIVATE_KEY_PASSPHRASE")
+	sshCertificate := utils.GetAnyStringEnv("PROXMOX_VE_SSH_CERTIFICATE")
 	sshAgentSocket := utils.GetAnyStringEnv("SSH_AUTH_SOCK", "PROXMOX_VE_SSH_AUTH_SOCK")
 	sshSocks5Server := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_SERVER")
 	sshSocks5Username := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_USERNAME")
 	sshSocks5Password := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_PASSWORD")
+	sshProxyJump := utils.GetAnyStringEnv("PROXMOX_VE_SSH_PROXY_JUMP")
+	sshSFTPChunkSize := utils.GetAnyIntEnv("PROXMOX_VE_SSH_SFTP_CHUNK_SIZE")
 	nodeOverrides := map[string]ssh.ProxmoxNode{}
 
 	//nolint: nestif
@@ -431,6 +810,14 @@ func (p *proxmoxProvider) Configure(
 			sshPrivateKey = cfg.SSH[0].PrivateKey.ValueString()
 		}
 
+		if !cfg.SSH[0].KeyPassphrase.IsNull() {
+			sshKeyPassphrase = cfg.SSH[0].KeyPassphrase.ValueString()
+		}
+
+		if !cfg.SSH[0].Certificate.IsNull() {
+			sshCertificate = cfg.SSH[0].Certificate.ValueString()
+		}
+
 		if !cfg.SSH[0].Socks5Server.IsNull() {
 			sshSocks5Server = cfg.SSH[0].Socks5Server.ValueString()
 		}
@@ -443,6 +830,14 @@ func (p *proxmoxProvider) Configure(
 			sshSocks5Password = cfg.SSH[0].Socks5Password.ValueString()
 		}
 
+		if !cfg.SSH[0].ProxyJump.IsNull() {
+			sshProxyJump = cfg.SSH[0].ProxyJump.ValueString()
+		}
+
+		if !cfg.SSH[0].SFTPChunkSize.IsNull() {
+			sshSFTPChunkSize = int(cfg.SSH[0].SFTPChunkSize.ValueInt64())
+		}
+
 		for _, n := range cfg.SSH[0].Nodes {
 			nodePort := int32(n.Port.ValueInt64())
 			if nodePort == 0 {
@@ -452,6 +847,9 @@ func (p *proxmoxProvider) Configure(
 			nodeOverrides[n.Name.ValueString()] = ssh.ProxmoxNode{
 				Address: n.Address.ValueString(),
 				Port:    nodePort,
+
+				ProxyJump:    n.ProxyJump.ValueString(),
+				Socks5Server: n.Socks5Server.ValueString(),
 			}
 		}
 	}
@@ -465,8 +863,10 @@ func (p *proxmoxProvider) Configure(
 	}
 
 	sshClient, err := ssh.NewClient(
-		sshUsername, sshPassword, sshAgent, sshAgentSocket, sshAgentForwarding, sshPrivateKey,
+		sshUsername, sshPassword, sshAgent, sshAgentSocket, sshAgentForwarding,
+		sshPrivateKey, sshKeyPassphrase, sshCertificate,
 		sshSocks5Server, sshSocks5Username, sshSocks5Password,
+		sshProxyJump, sshSFTPChunkSize,
 		&apiResolverWithOverrides{
 			ar:        apiResolver{c: apiClient},
 			overrides: nodeOverrides,
@@ -502,6 +902,8 @@ func (p *proxmoxProvider) Configure(
 				RandomIDEnd:  int(cfg.RandomVMIDEnd.ValueInt64()),
 			},
 		),
+		SDNApplier:   sdn.NewApplier(client.Cluster().SDN(), sdn.DefaultApplyDelay),
+		TagValidator: cluster.NewTagValidator(client.Cluster()),
 	}
 
 	resp.DataSourceData = config.DataSource{
@@ -512,27 +914,54 @@ func (p *proxmoxProvider) Configure(
 func (p *proxmoxProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		access.NewACLResource,
+		access.NewGroupMembershipResource,
+		access.NewRealmADResource,
+		access.NewRealmLDAPResource,
+		access.NewRealmOpenIDResource,
 		access.NewUserTokenResource,
 		acme.NewACMEAccountResource,
 		acme.NewACMEPluginResource,
+		agent.NewExecResource,
+		agent.NewFileResource,
 		apt.NewRepositoryResource,
 		apt.NewStandardRepositoryResource,
+		backup.NewJobResource,
+		disks.NewLVMThinPoolResource,
+		disks.NewZFSPoolResource,
 		ha.NewHAGroupResource,
 		ha.NewHAResourceResource,
 		hardwaremapping.NewDirResource,
 		hardwaremapping.NewPCIResource,
 		hardwaremapping.NewUSBResource,
+		join.NewNodeJoinResource,
 		metrics.NewMetricsServerResource,
+		network.NewLinuxBondResource,
 		network.NewLinuxBridgeResource,
+		notification.NewGotifyEndpointResource,
+		notification.NewMatcherResource,
+		notification.NewSMTPEndpointResource,
+		notification.NewWebhookEndpointResource,
 		network.NewLinuxVLANResource,
+		network.NewOVSBondResource,
+		network.NewOVSBridgeResource,
+		network.NewOVSIntPortResource,
+		nodes.NewConfigResource,
 		nodes.NewDownloadFileResource,
 		options.NewClusterOptionsResource,
+		replication.NewJobResource,
 		vm.NewResource,
 		sdnzone.NewSimpleResource,
 		sdnzone.NewVLANResource,
 		sdnzone.NewQinQResource,
 		sdnzone.NewVXLANResource,
 		sdnzone.NewEVPNResource,
+		sdnvnet.NewResource,
+		sdnsubnet.NewResource,
+		storage.NewCIFSResource,
+		storage.NewDirectoryResource,
+		storage.NewNFSResource,
+		storage.NewPBSResource,
+		tags.NewTagsResource,
 	}
 }
 
@@ -546,22 +975,32 @@ func (p *proxmoxProvider) DataSources(_ context.Context) []func() datasource.Dat
 		apt.NewRepositoryDataSource,
 		apt.NewStandardRepositoryDataSource,
 		datastores.NewDataSource,
+		disks.NewDisksDataSource,
+		firewall.NewLogDataSource,
 		ha.NewHAGroupDataSource,
 		ha.NewHAGroupsDataSource,
 		ha.NewHAResourceDataSource,
 		ha.NewHAResourcesDataSource,
+		hardware.NewPCIDevicesDataSource,
+		hardware.NewUSBDevicesDataSource,
 		hardwaremapping.NewDataSource,
 		hardwaremapping.NewDirDataSource,
 		hardwaremapping.NewPCIDataSource,
 		hardwaremapping.NewUSBDataSource,
+		join.NewJoinInfoDataSource,
 		metrics.NewMetricsServerDatasource,
+		nodes.NewCPUModelsDataSource,
+		nodes.NewRemoteFileDataSource,
+		nodes.NewStatusDataSource,
 		sdnzone.NewSimpleDataSource,
 		sdnzone.NewVLANDataSource,
 		sdnzone.NewQinQDataSource,
 		sdnzone.NewVXLANDataSource,
 		sdnzone.NewEVPNDataSource,
 		sdnzone.NewZonesDataSource,
+		task.NewDataSource,
 		vm.NewDataSource,
+		vmid.NewDataSource,
 	}
 }
 
@@ -0,0 +1,182 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+// pruneBackupsKeys are the retention options of the "prune-backups" parameter, in the order they are sent to the API.
+var pruneBackupsKeys = []string{
+	"keep-all",
+	"keep-last",
+	"keep-hourly",
+	"keep-daily",
+	"keep-weekly",
+	"keep-monthly",
+	"keep-yearly",
+}
+
+type pruneBackupsModel struct {
+	KeepAll     types.Bool  `tfsdk:"keep_all"`
+	KeepLast    types.Int64 `tfsdk:"keep_last"`
+	KeepHourly  types.Int64 `tfsdk:"keep_hourly"`
+	KeepDaily   types.Int64 `tfsdk:"keep_daily"`
+	KeepWeekly  types.Int64 `tfsdk:"keep_weekly"`
+	KeepMonthly types.Int64 `tfsdk:"keep_monthly"`
+	KeepYearly  types.Int64 `tfsdk:"keep_yearly"`
+}
+
+// newPruneBackupsModel parses the "prune-backups" parameter returned by the API. The options are matched by name,
+// so the order they are returned in does not cause a diff.
+func newPruneBackupsModel(data *string, diags *diag.Diagnostics) *pruneBackupsModel {
+	if data == nil || strings.TrimSpace(*data) == "" {
+		return nil
+	}
+
+	m := &pruneBackupsModel{
+		KeepAll:     types.BoolNull(),
+		KeepLast:    types.Int64Null(),
+		KeepHourly:  types.Int64Null(),
+		KeepDaily:   types.Int64Null(),
+		KeepWeekly:  types.Int64Null(),
+		KeepMonthly: types.Int64Null(),
+		KeepYearly:  types.Int64Null(),
+	}
+
+	for _, param := range strings.Split(*data, ",") {
+		k, v, found := strings.Cut(strings.TrimSpace(param), "=")
+		if k = strings.TrimSpace(k); !found || !slices.Contains(pruneBackupsKeys, k) {
+			continue
+		}
+
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			diags.AddError(
+				"Unable to Parse Backup Retention Options",
+				fmt.Sprintf("Invalid value %q for %q: %s", v, k, err.Error()),
+			)
+
+			return nil
+		}
+
+		switch k {
+		case "keep-all":
+			m.KeepAll = types.BoolValue(n != 0)
+		case "keep-last":
+			m.KeepLast = types.Int64Value(n)
+		case "keep-hourly":
+			m.KeepHourly = types.Int64Value(n)
+		case "keep-daily":
+			m.KeepDaily = types.Int64Value(n)
+		case "keep-weekly":
+			m.KeepWeekly = types.Int64Value(n)
+		case "keep-monthly":
+			m.KeepMonthly = types.Int64Value(n)
+		case "keep-yearly":
+			m.KeepYearly = types.Int64Value(n)
+		}
+	}
+
+	return m
+}
+
+// valueStringPointer returns the "prune-backups" parameter for the API, or nil if no retention options are set.
+func (m *pruneBackupsModel) valueStringPointer() *string {
+	if m == nil {
+		return nil
+	}
+
+	values := map[string]*int64{
+		"keep-last":    m.KeepLast.ValueInt64Pointer(),
+		"keep-hourly":  m.KeepHourly.ValueInt64Pointer(),
+		"keep-daily":   m.KeepDaily.ValueInt64Pointer(),
+		"keep-weekly":  m.KeepWeekly.ValueInt64Pointer(),
+		"keep-monthly": m.KeepMonthly.ValueInt64Pointer(),
+		"keep-yearly":  m.KeepYearly.ValueInt64Pointer(),
+	}
+
+	if !m.KeepAll.IsNull() && !m.KeepAll.IsUnknown() {
+		keepAll := int64(0)
+		if m.KeepAll.ValueBool() {
+			keepAll = 1
+		}
+
+		values["keep-all"] = &keepAll
+	}
+
+	var params []string
+
+	for _, k := range pruneBackupsKeys {
+		if v := values[k]; v != nil {
+			params = append(params, fmt.Sprintf("%s=%d", k, *v))
+		}
+	}
+
+	if len(params) == 0 {
+		return nil
+	}
+
+	return ptr.Ptr(strings.Join(params, ","))
+}
+
+func pruneBackupsAttribute() schema.Attribute {
+	keepAttribute := func(desc string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Description: desc,
+			Optional:    true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+				int64validator.ConflictsWith(path.MatchRelative().AtParent().AtName("keep_all")),
+			},
+		}
+	}
+
+	return schema.SingleNestedAttribute{
+		Description: "The retention options for backups stored on the datastore.",
+		MarkdownDescription: "The retention options for backups stored on the datastore. See the " +
+			"[Proxmox documentation](https://pve.proxmox.com/pve-docs/chapter-vzdump.html#vzdump_retention) " +
+			"for the details of the retention settings.",
+		Optional: true,
+		Validators: []validator.Object{
+			objectvalidator.AtLeastOneOf(
+				path.MatchRelative().AtName("keep_all"),
+				path.MatchRelative().AtName("keep_last"),
+				path.MatchRelative().AtName("keep_hourly"),
+				path.MatchRelative().AtName("keep_daily"),
+				path.MatchRelative().AtName("keep_weekly"),
+				path.MatchRelative().AtName("keep_monthly"),
+				path.MatchRelative().AtName("keep_yearly"),
+			),
+		},
+		Attributes: map[string]schema.Attribute{
+			"keep_all": schema.BoolAttribute{
+				Description: "Keep all backups. Conflicts with the other options.",
+				Optional:    true,
+			},
+			"keep_daily":   keepAttribute("The number of daily backups to keep."),
+			"keep_hourly":  keepAttribute("The number of hourly backups to keep."),
+			"keep_last":    keepAttribute("The number of most recent backups to keep."),
+			"keep_monthly": keepAttribute("The number of monthly backups to keep."),
+			"keep_weekly":  keepAttribute("The number of weekly backups to keep."),
+			"keep_yearly":  keepAttribute("The number of yearly backups to keep."),
+		},
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+func TestPruneBackupsModel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    *string
+		want    *string
+		wantErr bool
+	}{
+		{"nil", nil, nil, false},
+		{"empty", ptr.Ptr(""), nil, false},
+		{"single option", ptr.Ptr("keep-last=3"), ptr.Ptr("keep-last=3"), false},
+		{
+			"options are normalized",
+			ptr.Ptr("keep-yearly=1, keep-daily=7,keep-last=3"),
+			ptr.Ptr("keep-last=3,keep-daily=7,keep-yearly=1"),
+			false,
+		},
+		{"keep all", ptr.Ptr("keep-all=1"), ptr.Ptr("keep-all=1"), false},
+		{"unknown options are ignored", ptr.Ptr("keep-last=3,foo=bar"), ptr.Ptr("keep-last=3"), false},
+		{"invalid value", ptr.Ptr("keep-last=three"), nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := diag.Diagnostics{}
+			m := newPruneBackupsModel(tt.data, &diags)
+
+			if tt.wantErr {
+				require.True(t, diags.HasError())
+
+				return
+			}
+
+			require.False(t, diags.HasError())
+			assert.Equal(t, tt.want, m.valueStringPointer())
+		})
+	}
+}
+
+func TestPruneBackupsModelValueStringPointer(t *testing.T) {
+	t.Parallel()
+
+	m := &pruneBackupsModel{
+		KeepAll:     types.BoolNull(),
+		KeepLast:    types.Int64Null(),
+		KeepHourly:  types.Int64Null(),
+		KeepDaily:   types.Int64Value(7),
+		KeepWeekly:  types.Int64Value(4),
+		KeepMonthly: types.Int64Null(),
+		KeepYearly:  types.Int64Null(),
+	}
+
+	assert.Equal(t, ptr.Ptr("keep-daily=7,keep-weekly=4"), m.valueStringPointer())
+
+	m = &pruneBackupsModel{}
+	assert.Nil(t, m.valueStringPointer())
+
+	var nilModel *pruneBackupsModel
+	assert.Nil(t, nilModel.valueStringPointer())
+}
@@ -0,0 +1,136 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	proxmoxstorage "github.com/bpg/terraform-provider-proxmox/proxmox/storage"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &cifsResource{}
+	_ resource.ResourceWithImportState = &cifsResource{}
+)
+
+type cifsModel struct {
+	storageGenericModel
+
+	Domain   types.String `tfsdk:"domain"`
+	Password types.String `tfsdk:"password"`
+	Path     types.String `tfsdk:"path"`
+	Server   types.String `tfsdk:"server"`
+	Share    types.String `tfsdk:"share"`
+	Username types.String `tfsdk:"username"`
+}
+
+func (m *cifsModel) importFromAPI(
+	id string,
+	data *proxmoxstorage.DatastoreGetResponseData,
+	diags *diag.Diagnostics,
+) {
+	m.storageGenericModel.importFromAPI(id, data, diags)
+
+	m.Domain = types.StringPointerValue(data.Domain)
+	m.Path = types.StringPointerValue(data.Path)
+	m.Server = types.StringPointerValue(data.Server)
+	m.Share = types.StringPointerValue(data.Share)
+	m.Username = types.StringPointerValue(data.Username)
+}
+
+func (m *cifsModel) toAPIRequestBody(ctx context.Context, diags *diag.Diagnostics) *proxmoxstorage.DatastoreData {
+	data := m.storageGenericModel.toAPIRequestBody(ctx, diags)
+
+	data.Domain = m.Domain.ValueStringPointer()
+	data.Path = m.Path.ValueStringPointer()
+	data.Server = m.Server.ValueStringPointer()
+	data.Share = m.Share.ValueStringPointer()
+	data.Username = m.Username.ValueStringPointer()
+
+	return data
+}
+
+func (m *cifsModel) secrets() (*string, *string) {
+	return m.Password.ValueStringPointer(), nil
+}
+
+func (m *cifsModel) copyWriteOnly(other storageModel) {
+	if o, ok := other.(*cifsModel); ok {
+		m.Password = o.Password
+	}
+}
+
+type cifsResource struct {
+	*genericStorageResource
+}
+
+// NewCIFSResource creates a new CIFS datastore resource.
+func NewCIFSResource() resource.Resource {
+	return &cifsResource{
+		genericStorageResource: newGenericStorageResource(storageResourceConfig{
+			typeNameSuffix: "_storage_cifs",
+			storageType:    proxmoxstorage.DatastoreTypeCIFS,
+			modelFunc:      func() storageModel { return &cifsModel{} },
+		}),
+	}
+}
+
+func (r *cifsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a CIFS/SMB datastore.",
+		MarkdownDescription: "Manages a CIFS/SMB datastore. The datastore is only removed from the storage " +
+			"configuration on destroy, the content of the share is left untouched.",
+		Attributes: storageAttributesWith(map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Description: "The domain of the user.",
+				Optional:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "The password of the user.",
+				MarkdownDescription: "The password of the user. The password is not returned by the API, " +
+					"so changes made outside of Terraform are not detected.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"path": schema.StringAttribute{
+				Description:         "The path the share is mounted on the nodes.",
+				MarkdownDescription: "The path the share is mounted on the nodes, defaults to `/mnt/pve/<id>`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"server": schema.StringAttribute{
+				Description: "The name or IP address of the CIFS server.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"share": schema.StringAttribute{
+				Description: "The name of the CIFS share.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "The username to access the share, the share is accessed as guest if not set.",
+				Optional:    true,
+			},
+		}),
+	}
+}
@@ -0,0 +1,92 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	proxmoxstorage "github.com/bpg/terraform-provider-proxmox/proxmox/storage"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &directoryResource{}
+	_ resource.ResourceWithImportState = &directoryResource{}
+)
+
+type directoryModel struct {
+	storageGenericModel
+
+	Path   types.String `tfsdk:"path"`
+	Shared types.Bool   `tfsdk:"shared"`
+}
+
+func (m *directoryModel) importFromAPI(
+	id string,
+	data *proxmoxstorage.DatastoreGetResponseData,
+	diags *diag.Diagnostics,
+) {
+	m.storageGenericModel.importFromAPI(id, data, diags)
+
+	m.Path = types.StringPointerValue(data.Path)
+	m.Shared = storageBoolValue(data.Shared)
+}
+
+func (m *directoryModel) toAPIRequestBody(ctx context.Context, diags *diag.Diagnostics) *proxmoxstorage.DatastoreData {
+	data := m.storageGenericModel.toAPIRequestBody(ctx, diags)
+
+	data.Path = m.Path.ValueStringPointer()
+	data.Shared = proxmoxtypes.CustomBoolPtr(m.Shared.ValueBoolPointer())
+
+	return data
+}
+
+type directoryResource struct {
+	*genericStorageResource
+}
+
+// NewDirectoryResource creates a new directory datastore resource.
+func NewDirectoryResource() resource.Resource {
+	return &directoryResource{
+		genericStorageResource: newGenericStorageResource(storageResourceConfig{
+			typeNameSuffix: "_storage_directory",
+			storageType:    proxmoxstorage.DatastoreTypeDir,
+			modelFunc:      func() storageModel { return &directoryModel{} },
+		}),
+	}
+}
+
+func (r *directoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a directory datastore.",
+		MarkdownDescription: "Manages a directory datastore. The datastore is only removed from the storage " +
+			"configuration on destroy, the content of the directory is left untouched.",
+		Attributes: storageAttributesWith(map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				Description: "The path to the directory on the nodes.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"shared": schema.BoolAttribute{
+				Description: "Whether the directory is shared between the nodes, e.g. a mounted network filesystem.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		}),
+	}
+}
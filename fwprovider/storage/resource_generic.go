@@ -0,0 +1,444 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/go-querystring/query"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/types/stringset"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	proxmoxstorage "github.com/bpg/terraform-provider-proxmox/proxmox/storage"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// contentTypes are the content types supported by the file based datastores.
+var contentTypes = []string{"backup", "images", "import", "iso", "rootdir", "snippets", "vztmpl"}
+
+type storageModel interface {
+	importFromAPI(id string, data *proxmoxstorage.DatastoreGetResponseData, diags *diag.Diagnostics)
+	toAPIRequestBody(ctx context.Context, diags *diag.Diagnostics) *proxmoxstorage.DatastoreData
+	// secrets returns the write-only values, i.e. password and encryption key, which are never returned by the API.
+	secrets() (password *string, encryptionKey *string)
+	// copyWriteOnly copies the attributes that can't be read back from the API from the other model.
+	copyWriteOnly(other storageModel)
+	getID() string
+}
+
+type storageGenericModel struct {
+	ID           types.String       `tfsdk:"id"`
+	Content      stringset.Value    `tfsdk:"content"`
+	Disable      types.Bool         `tfsdk:"disable"`
+	Nodes        stringset.Value    `tfsdk:"nodes"`
+	PruneBackups *pruneBackupsModel `tfsdk:"prune_backups"`
+}
+
+func (m *storageGenericModel) importFromAPI(
+	id string,
+	data *proxmoxstorage.DatastoreGetResponseData,
+	diags *diag.Diagnostics,
+) {
+	m.ID = types.StringValue(id)
+
+	var content []string
+
+	for _, c := range data.Content {
+		if c = strings.TrimSpace(c); c != "" {
+			content = append(content, c)
+		}
+	}
+
+	m.Content = stringset.NewValueList(content, diags)
+	m.Disable = storageBoolValue(data.Disable)
+	m.Nodes = stringset.NewValueString(data.Nodes, diags, stringset.WithSeparator(","))
+	m.PruneBackups = newPruneBackupsModel(data.PruneBackups, diags)
+}
+
+func (m *storageGenericModel) toAPIRequestBody(
+	ctx context.Context,
+	diags *diag.Diagnostics,
+) *proxmoxstorage.DatastoreData {
+	return &proxmoxstorage.DatastoreData{
+		Content:      m.Content.ValueList(ctx, diags),
+		Disable:      proxmoxtypes.CustomBoolPtr(m.Disable.ValueBoolPointer()),
+		Nodes:        m.Nodes.ValueStringPointer(ctx, diags, stringset.WithSeparator(",")),
+		PruneBackups: m.PruneBackups.valueStringPointer(),
+	}
+}
+
+func (m *storageGenericModel) secrets() (*string, *string) {
+	return nil, nil
+}
+
+func (m *storageGenericModel) copyWriteOnly(_ storageModel) {}
+
+func (m *storageGenericModel) getID() string {
+	return m.ID.ValueString()
+}
+
+// storageBoolValue converts a boolean flag returned by the API, which is omitted when it is not set.
+func storageBoolValue(v *proxmoxtypes.CustomBool) types.Bool {
+	return types.BoolValue(v != nil && bool(*v))
+}
+
+func storageAttributesWith(extraAttributes map[string]schema.Attribute) map[string]schema.Attribute {
+	result := map[string]schema.Attribute{
+		"content": stringset.ResourceAttribute(
+			"The content types that can be stored on the datastore.",
+			"The content types that can be stored on the datastore, any combination of `backup`, `images`, "+
+				"`import`, `iso`, `rootdir`, `snippets` and `vztmpl`. Defaults to the content types "+
+				"PVE enables for the storage type.",
+			func(a *schema.SetAttribute) {
+				a.PlanModifiers = []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				}
+				a.Validators = append(a.Validators, setvalidator.ValueStringsAre(
+					stringvalidator.OneOf(contentTypes...),
+				))
+			},
+		),
+		"disable": schema.BoolAttribute{
+			Description: "Whether the datastore is disabled.",
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(false),
+		},
+		"id": schema.StringAttribute{
+			Description: "The identifier of the datastore.",
+			Required:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(
+					regexp.MustCompile(`^[A-Za-z][A-Za-z0-9\-_.]*[A-Za-z0-9]$`),
+					"must be a valid datastore identifier",
+				),
+			},
+		},
+		"nodes": stringset.ResourceAttribute(
+			"The nodes the datastore is available on.",
+			"The nodes the datastore is available on. The datastore is available on all nodes "+
+				"if not specified.",
+			func(a *schema.SetAttribute) {
+				a.Default = setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{}))
+			},
+		),
+		"prune_backups": pruneBackupsAttribute(),
+	}
+
+	maps.Copy(result, extraAttributes)
+
+	return result
+}
+
+type storageResourceConfig struct {
+	typeNameSuffix string
+	storageType    string
+	modelFunc      func() storageModel
+}
+
+type genericStorageResource struct {
+	client *proxmoxstorage.Client
+	config storageResourceConfig
+}
+
+func newGenericStorageResource(cfg storageResourceConfig) *genericStorageResource {
+	return &genericStorageResource{config: cfg}
+}
+
+func (r *genericStorageResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + r.config.typeNameSuffix
+}
+
+func (r *genericStorageResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Storage()
+}
+
+func (r *genericStorageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	plan := r.config.modelFunc()
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := &diag.Diagnostics{}
+	data := plan.toAPIRequestBody(ctx, diags)
+	resp.Diagnostics.Append(*diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := &proxmoxstorage.DatastoreCreateRequestBody{
+		DatastoreData: *data,
+		ID:            plan.getID(),
+		Type:          r.config.storageType,
+	}
+	body.Password, body.EncryptionKey = plan.secrets()
+
+	if err := r.client.CreateDatastore(ctx, body); err != nil {
+		resp.Diagnostics.AddError("Unable to Create Datastore", err.Error())
+
+		return
+	}
+
+	readModel := r.read(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+}
+
+func (r *genericStorageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state := r.config.modelFunc()
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	datastore, err := r.client.GetDatastore(ctx, state.getID())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Unable to Read Datastore", err.Error())
+
+		return
+	}
+
+	readModel := r.config.modelFunc()
+	diags := &diag.Diagnostics{}
+	readModel.importFromAPI(state.getID(), datastore, diags)
+	readModel.copyWriteOnly(state)
+	resp.Diagnostics.Append(*diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+}
+
+func (r *genericStorageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := r.config.modelFunc()
+	state := r.config.modelFunc()
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := &diag.Diagnostics{}
+	planData := plan.toAPIRequestBody(ctx, diags)
+	stateData := state.toAPIRequestBody(ctx, diags)
+	resp.Diagnostics.Append(*diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the attributes identifying the storage location can be set only at creation
+	for _, data := range []*proxmoxstorage.DatastoreData{planData, stateData} {
+		data.Datastore = nil
+		data.Export = nil
+		data.Path = nil
+		data.Server = nil
+		data.Share = nil
+	}
+
+	body := &proxmoxstorage.DatastoreUpdateRequestBody{
+		DatastoreData: *planData,
+	}
+
+	toDelete, err := storageDeletedKeys(planData, stateData)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Update Datastore", err.Error())
+
+		return
+	}
+
+	// the secrets are only sent when changed, as sending "autogen" again would replace the encryption key
+	planPassword, planEncryptionKey := plan.secrets()
+	statePassword, stateEncryptionKey := state.secrets()
+
+	toDelete = append(toDelete, updateSecret(&body.Password, planPassword, statePassword, "password")...)
+	toDelete = append(toDelete, updateSecret(&body.EncryptionKey, planEncryptionKey, stateEncryptionKey, "encryption-key")...)
+
+	if len(toDelete) > 0 {
+		d := strings.Join(toDelete, ",")
+		body.Delete = &d
+	}
+
+	if err = r.client.UpdateDatastore(ctx, plan.getID(), body); err != nil {
+		resp.Diagnostics.AddError("Unable to Update Datastore", err.Error())
+
+		return
+	}
+
+	readModel := r.read(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+}
+
+func (r *genericStorageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state := r.config.modelFunc()
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the storage definition is removed, the data stored on it is left untouched
+	if err := r.client.DeleteDatastore(ctx, state.getID()); err != nil &&
+		!errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError("Unable to Delete Datastore", err.Error())
+	}
+}
+
+func (r *genericStorageResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	datastore, err := r.client.GetDatastore(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.Diagnostics.AddError(fmt.Sprintf("Datastore %s does not exist", req.ID), err.Error())
+
+			return
+		}
+
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to Import Datastore %s", req.ID), err.Error())
+
+		return
+	}
+
+	if datastore.Type == nil || *datastore.Type != r.config.storageType {
+		resp.Diagnostics.AddError(
+			"Unexpected Datastore Type",
+			fmt.Sprintf("Datastore %q has type %q, expected %q", req.ID, ptr.Or(datastore.Type, ""), r.config.storageType),
+		)
+
+		return
+	}
+
+	readModel := r.config.modelFunc()
+	diags := &diag.Diagnostics{}
+	readModel.importFromAPI(req.ID, datastore, diags)
+	resp.Diagnostics.Append(*diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
+}
+
+// read reads the datastore back from the API, keeping the write-only attributes of the given model.
+func (r *genericStorageResource) read(ctx context.Context, model storageModel, diags *diag.Diagnostics) storageModel {
+	datastore, err := r.client.GetDatastore(ctx, model.getID())
+	if err != nil {
+		diags.AddError("Unable to Read Datastore", err.Error())
+
+		return nil
+	}
+
+	readModel := r.config.modelFunc()
+	readModel.importFromAPI(model.getID(), datastore, diags)
+	readModel.copyWriteOnly(model)
+
+	return readModel
+}
+
+// updateSecret sets the write-only value in the request if it has been changed, and returns the API key
+// to delete if it has been removed.
+func updateSecret(dst **string, plan, state *string, key string) []string {
+	switch {
+	case plan == nil && state != nil:
+		return []string{key}
+	case !ptr.Eq(plan, state):
+		*dst = plan
+	}
+
+	return nil
+}
+
+// storageDeletedKeys returns the API keys which are set in the state, but not in the plan.
+func storageDeletedKeys(plan, state *proxmoxstorage.DatastoreData) ([]string, error) {
+	planValues, err := query.Values(plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode datastore data: %w", err)
+	}
+
+	stateValues, err := query.Values(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode datastore data: %w", err)
+	}
+
+	var toDelete []string
+
+	for k := range stateValues {
+		if !planValues.Has(k) {
+			toDelete = append(toDelete, k)
+		}
+	}
+
+	slices.Sort(toDelete)
+
+	return toDelete, nil
+}
+
+// Schema is required to satisfy the resource.Resource interface. It should be implemented by the specific resource.
+func (r *genericStorageResource) Schema(_ context.Context, _ resource.SchemaRequest, _ *resource.SchemaResponse) {
+	// Intentionally left blank. Should be set by the specific resource.
+}
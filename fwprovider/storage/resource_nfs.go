@@ -0,0 +1,104 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	proxmoxstorage "github.com/bpg/terraform-provider-proxmox/proxmox/storage"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &nfsResource{}
+	_ resource.ResourceWithImportState = &nfsResource{}
+)
+
+type nfsModel struct {
+	storageGenericModel
+
+	Export types.String `tfsdk:"export"`
+	Path   types.String `tfsdk:"path"`
+	Server types.String `tfsdk:"server"`
+}
+
+func (m *nfsModel) importFromAPI(
+	id string,
+	data *proxmoxstorage.DatastoreGetResponseData,
+	diags *diag.Diagnostics,
+) {
+	m.storageGenericModel.importFromAPI(id, data, diags)
+
+	m.Export = types.StringPointerValue(data.Export)
+	m.Path = types.StringPointerValue(data.Path)
+	m.Server = types.StringPointerValue(data.Server)
+}
+
+func (m *nfsModel) toAPIRequestBody(ctx context.Context, diags *diag.Diagnostics) *proxmoxstorage.DatastoreData {
+	data := m.storageGenericModel.toAPIRequestBody(ctx, diags)
+
+	data.Export = m.Export.ValueStringPointer()
+	data.Path = m.Path.ValueStringPointer()
+	data.Server = m.Server.ValueStringPointer()
+
+	return data
+}
+
+type nfsResource struct {
+	*genericStorageResource
+}
+
+// NewNFSResource creates a new NFS datastore resource.
+func NewNFSResource() resource.Resource {
+	return &nfsResource{
+		genericStorageResource: newGenericStorageResource(storageResourceConfig{
+			typeNameSuffix: "_storage_nfs",
+			storageType:    proxmoxstorage.DatastoreTypeNFS,
+			modelFunc:      func() storageModel { return &nfsModel{} },
+		}),
+	}
+}
+
+func (r *nfsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an NFS datastore.",
+		MarkdownDescription: "Manages an NFS datastore. The datastore is only removed from the storage " +
+			"configuration on destroy, the content of the NFS export is left untouched.",
+		Attributes: storageAttributesWith(map[string]schema.Attribute{
+			"export": schema.StringAttribute{
+				Description: "The path of the NFS export.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Description:         "The path the export is mounted on the nodes.",
+				MarkdownDescription: "The path the export is mounted on the nodes, defaults to `/mnt/pve/<id>`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"server": schema.StringAttribute{
+				Description: "The name or IP address of the NFS server.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		}),
+	}
+}
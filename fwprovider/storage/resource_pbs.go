@@ -0,0 +1,150 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	proxmoxstorage "github.com/bpg/terraform-provider-proxmox/proxmox/storage"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &pbsResource{}
+	_ resource.ResourceWithImportState = &pbsResource{}
+)
+
+type pbsModel struct {
+	storageGenericModel
+
+	Datastore                types.String `tfsdk:"datastore"`
+	EncryptionKey            types.String `tfsdk:"encryption_key"`
+	EncryptionKeyFingerprint types.String `tfsdk:"encryption_key_fingerprint"`
+	Fingerprint              types.String `tfsdk:"fingerprint"`
+	Namespace                types.String `tfsdk:"namespace"`
+	Password                 types.String `tfsdk:"password"`
+	Server                   types.String `tfsdk:"server"`
+	Username                 types.String `tfsdk:"username"`
+}
+
+func (m *pbsModel) importFromAPI(
+	id string,
+	data *proxmoxstorage.DatastoreGetResponseData,
+	diags *diag.Diagnostics,
+) {
+	m.storageGenericModel.importFromAPI(id, data, diags)
+
+	m.Datastore = types.StringPointerValue(data.Datastore)
+	m.EncryptionKeyFingerprint = types.StringPointerValue(data.EncryptionKey)
+	m.Fingerprint = types.StringPointerValue(data.Fingerprint)
+	m.Namespace = types.StringPointerValue(data.Namespace)
+	m.Server = types.StringPointerValue(data.Server)
+	m.Username = types.StringPointerValue(data.Username)
+}
+
+func (m *pbsModel) toAPIRequestBody(ctx context.Context, diags *diag.Diagnostics) *proxmoxstorage.DatastoreData {
+	data := m.storageGenericModel.toAPIRequestBody(ctx, diags)
+
+	data.Datastore = m.Datastore.ValueStringPointer()
+	data.Fingerprint = m.Fingerprint.ValueStringPointer()
+	data.Namespace = m.Namespace.ValueStringPointer()
+	data.Server = m.Server.ValueStringPointer()
+	data.Username = m.Username.ValueStringPointer()
+
+	return data
+}
+
+func (m *pbsModel) secrets() (*string, *string) {
+	return m.Password.ValueStringPointer(), m.EncryptionKey.ValueStringPointer()
+}
+
+func (m *pbsModel) copyWriteOnly(other storageModel) {
+	if o, ok := other.(*pbsModel); ok {
+		m.EncryptionKey = o.EncryptionKey
+		m.Password = o.Password
+	}
+}
+
+type pbsResource struct {
+	*genericStorageResource
+}
+
+// NewPBSResource creates a new Proxmox Backup Server datastore resource.
+func NewPBSResource() resource.Resource {
+	return &pbsResource{
+		genericStorageResource: newGenericStorageResource(storageResourceConfig{
+			typeNameSuffix: "_storage_pbs",
+			storageType:    proxmoxstorage.DatastoreTypePBS,
+			modelFunc:      func() storageModel { return &pbsModel{} },
+		}),
+	}
+}
+
+func (r *pbsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Proxmox Backup Server datastore.",
+		MarkdownDescription: "Manages a Proxmox Backup Server datastore. The datastore is only removed from the " +
+			"storage configuration on destroy, the backups stored on the Proxmox Backup Server are left untouched.",
+		Attributes: storageAttributesWith(map[string]schema.Attribute{
+			"datastore": schema.StringAttribute{
+				Description: "The name of the datastore on the Proxmox Backup Server.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"encryption_key": schema.StringAttribute{
+				Description: "The client-side encryption key in JSON format, or `autogen` to generate a new key.",
+				MarkdownDescription: "The client-side encryption key in JSON format, or `autogen` to generate a " +
+					"new key. The key is not returned by the API, use `encryption_key_fingerprint` to detect changes " +
+					"made outside of Terraform. Make sure to keep a copy of the key, the backups can't be restored " +
+					"without it.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"encryption_key_fingerprint": schema.StringAttribute{
+				Description: "The fingerprint of the client-side encryption key.",
+				Computed:    true,
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "The SHA-256 fingerprint of the Proxmox Backup Server certificate.",
+				MarkdownDescription: "The SHA-256 fingerprint of the Proxmox Backup Server certificate, required " +
+					"if the certificate is not trusted by the nodes.",
+				Optional: true,
+			},
+			"namespace": schema.StringAttribute{
+				Description: "The namespace in the datastore to store the backups in.",
+				Optional:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "The password or API token secret of the user.",
+				MarkdownDescription: "The password or API token secret of the user. The password is not returned " +
+					"by the API, so changes made outside of Terraform are not detected.",
+				Required:  true,
+				Sensitive: true,
+			},
+			"server": schema.StringAttribute{
+				Description: "The name or IP address of the Proxmox Backup Server.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description:         "The user or API token to access the Proxmox Backup Server.",
+				MarkdownDescription: "The user or API token to access the Proxmox Backup Server, e.g. `backup@pbs`.",
+				Required:            true,
+			},
+		}),
+	}
+}
@@ -0,0 +1,101 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceStorageDirectory(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitEnvironment(t)
+
+	storageID := "s" + gofakeit.LetterN(8)
+
+	te.AddTemplateVars(map[string]any{
+		"StorageID": storageID,
+	})
+
+	tests := []struct {
+		name  string
+		steps []resource.TestStep
+	}{
+		{"invalid content type is rejected", []resource.TestStep{{
+			Config: te.RenderConfig(`resource "proxmox_virtual_environment_storage_directory" "dir" {
+				id      = "{{.StorageID}}"
+				path    = "/var/tmp/{{.StorageID}}"
+				content = ["invalid"]
+			}`),
+			ExpectError: regexp.MustCompile(`value must be one of`),
+		}}},
+		{"create and update directory datastore", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`resource "proxmox_virtual_environment_storage_directory" "dir" {
+					id      = "{{.StorageID}}"
+					path    = "/var/tmp/{{.StorageID}}"
+					content = ["snippets", "iso"]
+					nodes   = ["{{.NodeName}}"]
+					prune_backups = {
+						keep_last  = 3
+						keep_daily = 7
+					}
+				}`),
+				Check: test.ResourceAttributes("proxmox_virtual_environment_storage_directory.dir", map[string]string{
+					"content.#":                "2",
+					"disable":                  "false",
+					"nodes.#":                  "1",
+					"path":                     "/var/tmp/" + storageID,
+					"prune_backups.keep_daily": "7",
+					"prune_backups.keep_last":  "3",
+					"shared":                   "false",
+				}),
+			},
+			{
+				Config: te.RenderConfig(`resource "proxmox_virtual_environment_storage_directory" "dir" {
+					id      = "{{.StorageID}}"
+					path    = "/var/tmp/{{.StorageID}}"
+					content = ["iso", "snippets", "vztmpl"]
+					disable = true
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_storage_directory.dir", map[string]string{
+						"content.#": "3",
+						"disable":   "true",
+						"nodes.#":   "0",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_storage_directory.dir", []string{
+						"prune_backups.keep_last",
+					}),
+				),
+			},
+			{
+				ResourceName:      "proxmox_virtual_environment_storage_directory.dir",
+				ImportState:       true,
+				ImportStateId:     storageID,
+				ImportStateVerify: true,
+			},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: te.AccProviders,
+				Steps:                    tt.steps,
+			})
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fakepve
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+// aptSourcesFile is the APT sources file of the node which the standard repositories are added to.
+const aptSourcesFile = "/etc/apt/sources.list"
+
+// aptRepo is a repository of the APT sources file.
+type aptRepo struct {
+	component string
+	enabled   bool
+}
+
+// aptStandardRepo is an APT standard repository which can be added to the sources file.
+type aptStandardRepo struct {
+	handle      string
+	name        string
+	description string
+}
+
+// aptStandardRepos are the APT standard repositories of the node, which are not configured initially.
+var aptStandardRepos = []aptStandardRepo{
+	{handle: "no-subscription", name: "No-Subscription", description: "Not recommended for production use."},
+	{handle: "test", name: "Test", description: "Used to test new features."},
+}
+
+// component returns the component of the repository in the sources file.
+func (r aptStandardRepo) component() string {
+	if r.handle == "test" {
+		return "pvetest"
+	}
+
+	return "pve-" + r.handle
+}
+
+func (s *Server) handleGetAPTRepositories(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repos := make([]map[string]interface{}, 0, len(s.aptRepos))
+
+	for _, r := range s.aptRepos {
+		repos = append(repos, map[string]interface{}{
+			"Components": []string{r.component},
+			"Enabled":    boolInt(r.enabled),
+			"FileType":   "list",
+			"Suites":     []string{"bookworm"},
+			"Types":      []string{"deb"},
+			"URIs":       []string{"http://download.proxmox.com/debian/pve"},
+		})
+	}
+
+	standard := make([]map[string]interface{}, 0, len(aptStandardRepos))
+
+	for _, sr := range aptStandardRepos {
+		data := map[string]interface{}{
+			"handle":      sr.handle,
+			"name":        sr.name,
+			"description": sr.description,
+		}
+
+		if i := s.aptRepoIndex(sr.component()); i >= 0 {
+			data["status"] = boolInt(s.aptRepos[i].enabled)
+		}
+
+		standard = append(standard, data)
+	}
+
+	writeData(w, map[string]interface{}{
+		"digest": strconv.Itoa(s.aptDigest),
+		"files": []map[string]interface{}{
+			{"file-type": "list", "path": aptSourcesFile, "repositories": repos},
+		},
+		"standard-repos": standard,
+	})
+}
+
+func (s *Server) handleAddAPTRepository(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	handle := r.Form.Get("handle")
+
+	i := slices.IndexFunc(aptStandardRepos, func(sr aptStandardRepo) bool { return sr.handle == handle })
+	if i < 0 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("handle '%s' is not known", handle))
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// like Proxmox VE, a repository which is already configured is added once more
+	s.aptRepos = append(s.aptRepos, &aptRepo{component: aptStandardRepos[i].component(), enabled: true})
+	s.aptDigest++
+
+	writeData(w, nil)
+}
+
+func (s *Server) handleChangeAPTRepository(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if digest := r.Form.Get("digest"); digest != "" && digest != strconv.Itoa(s.aptDigest) {
+		writeError(w, http.StatusInternalServerError, "detected modified configuration - file changed by other user?")
+
+		return
+	}
+
+	index, err := strconv.Atoi(r.Form.Get("index"))
+	if r.Form.Get("path") != aptSourcesFile || err != nil || index < 0 || index >= len(s.aptRepos) {
+		writeError(w, http.StatusInternalServerError,
+			fmt.Sprintf("unable to find repository with index '%s' in '%s'", r.Form.Get("index"), r.Form.Get("path")))
+
+		return
+	}
+
+	s.aptRepos[index].enabled = r.Form.Get("enabled") == "1"
+	s.aptDigest++
+
+	writeData(w, nil)
+}
+
+// aptRepoIndex returns the index of the first repository with the component in the sources file, or -1.
+func (s *Server) aptRepoIndex(component string) int {
+	return slices.IndexFunc(s.aptRepos, func(r *aptRepo) bool { return r.component == component })
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,411 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fakepve
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types/optionstring"
+)
+
+const (
+	guestVM        = "qemu"
+	guestContainer = "lxc"
+)
+
+// diskKeyRegex matches the config keys of the disks, whose new volumes are allocated, e.g. `scsi0=local-lvm:8`.
+var diskKeyRegex = regexp.MustCompile(`^((ide|sata|scsi|virtio|mp)\d+|rootfs|efidisk0|tpmstate0)$`)
+
+// transientParams are the parameters of the create requests which are not stored in the config.
+var transientParams = []string{
+	"archive", "force", "ignore-unpack-errors", "ostemplate", "password", "pool", "restore",
+	"ssh-public-keys", "start", "storage", "unique", "vmid",
+}
+
+// stringParams are the config keys whose values are strings, even if they look like numbers.
+var stringParams = []string{"description", "hostname", "name", "tags"}
+
+// guest is a VM or a container of the node.
+type guest struct {
+	kind   string
+	vmid   int
+	config map[string]string
+	status string
+}
+
+func (g *guest) name() string {
+	if g.kind == guestContainer {
+		return g.config["hostname"]
+	}
+
+	return g.config["name"]
+}
+
+func (g *guest) label() string {
+	if g.kind == guestContainer {
+		return "CT"
+	}
+
+	return "VM"
+}
+
+// configData returns the config as Proxmox VE reports it, with the numbers as JSON numbers.
+func (g *guest) configData() map[string]interface{} {
+	data := map[string]interface{}{"digest": fmt.Sprintf("%040x", len(g.config))}
+
+	for k, v := range g.config {
+		data[k] = v
+
+		if slices.Contains(stringParams, k) {
+			continue
+		}
+
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			data[k] = i
+		} else if f, err := strconv.ParseFloat(v, 64); err == nil {
+			data[k] = f
+		}
+	}
+
+	return data
+}
+
+// guest returns the guest of the request, or writes an error if it does not exist.
+func (s *Server) guest(w http.ResponseWriter, r *http.Request, kind string) (*guest, bool) {
+	vmid, err := strconv.Atoi(r.PathValue("vmid"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid vmid")
+
+		return nil, false
+	}
+
+	g, ok := s.guests[vmid]
+	if !ok || g.kind != kind {
+		dir := "qemu-server"
+		if kind == guestContainer {
+			dir = "lxc"
+		}
+
+		writeError(w, http.StatusInternalServerError,
+			fmt.Sprintf("Configuration file 'nodes/%s/%s/%d.conf' does not exist", s.NodeName, dir, vmid))
+
+		return nil, false
+	}
+
+	return g, true
+}
+
+// applyConfig applies the parameters of a create or an update request to the config of the guest, allocating
+// the new disks.
+func (s *Server) applyConfig(g *guest, params url.Values) error {
+	for _, key := range strings.Split(params.Get("delete"), ",") {
+		delete(g.config, strings.TrimSpace(key))
+	}
+
+	for key := range params {
+		if key == "delete" || key == "digest" || key == "skiplock" || slices.Contains(transientParams, key) {
+			continue
+		}
+
+		value := params.Get(key)
+
+		if diskKeyRegex.MatchString(key) {
+			allocated, err := s.allocateDisk(g, value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+
+			value = allocated
+		}
+
+		// the description is stored as comment lines, which a container reads back with a trailing newline
+		if key == "description" {
+			value = strings.TrimRightFunc(value, unicode.IsSpace)
+
+			if g.kind == guestContainer && value != "" {
+				value += "\n"
+			}
+		}
+
+		g.config[key] = value
+	}
+
+	return nil
+}
+
+// allocateDisk allocates the volume of a disk given as `STORAGE_ID:SIZE_IN_GiB`, and returns the disk options
+// with the allocated volume and its size, as Proxmox VE does.
+func (s *Server) allocateDisk(g *guest, value string) (string, error) {
+	options, err := optionstring.Unmarshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	m := options.Map()
+
+	datastoreID, size, ok := strings.Cut(m[""], ":")
+	if !ok {
+		return value, nil
+	}
+
+	d, ok := s.datastores[datastoreID]
+	if !ok {
+		return "", fmt.Errorf("storage '%s' does not exist", datastoreID)
+	}
+
+	name := ""
+	sizeGB, err := strconv.Atoi(size)
+
+	switch {
+	case size == "cloudinit":
+		name = fmt.Sprintf("vm-%d-cloudinit", g.vmid)
+		m["media"] = "cdrom"
+	case err == nil:
+		for n := 0; name == ""; n++ {
+			if _, taken := d.volumes[fmt.Sprintf("%s:vm-%d-disk-%d", d.id, g.vmid, n)]; !taken {
+				name = fmt.Sprintf("vm-%d-disk-%d", g.vmid, n)
+			}
+		}
+
+		m["size"] = fmt.Sprintf("%dG", sizeGB)
+	default:
+		return value, nil
+	}
+
+	content := "images"
+	if g.kind == guestContainer {
+		content = "rootdir"
+	}
+
+	v := &volume{
+		id:      d.id + ":" + name,
+		content: content,
+		format:  "raw",
+		size:    int64(sizeGB) * 1024 * 1024 * 1024,
+		vmid:    g.vmid,
+		ctime:   time.Now().Unix(),
+	}
+	d.volumes[v.id] = v
+	m[""] = v.id
+
+	return optionstring.MarshalMap(m), nil
+}
+
+func (s *Server) handleListGuests(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		list := []map[string]interface{}{}
+
+		for _, vmid := range sortedKeys(s.guests) {
+			g := s.guests[vmid]
+
+			if g.kind == kind {
+				list = append(list, map[string]interface{}{"vmid": vmid, "name": g.name(), "status": g.status})
+			}
+		}
+
+		writeData(w, list)
+	}
+}
+
+func (s *Server) handleCreateGuest(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		vmid, err := strconv.Atoi(r.PostForm.Get("vmid"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid vmid")
+
+			return
+		}
+
+		g := &guest{kind: kind, vmid: vmid, config: map[string]string{}, status: "stopped"}
+
+		if existing, ok := s.guests[vmid]; ok {
+			writeError(w, http.StatusInternalServerError,
+				fmt.Sprintf("unable to create %s %d - %s %d already exists on node '%s'",
+					g.label(), vmid, existing.label(), vmid, s.NodeName))
+
+			return
+		}
+
+		if err := s.applyConfig(g, r.PostForm); err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("unable to create %s %d - %s", g.label(), vmid, err))
+
+			return
+		}
+
+		s.guests[vmid] = g
+
+		start := r.PostForm.Get("start") == "1"
+
+		writeData(w, s.startTask(kind+"create", strconv.Itoa(vmid), func() {
+			if start {
+				g.status = "running"
+			}
+		}))
+	}
+}
+
+func (s *Server) handleGetGuestConfig(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if g, ok := s.guest(w, r, kind); ok {
+			writeData(w, g.configData())
+		}
+	}
+}
+
+func (s *Server) handleUpdateGuestConfig(kind string, async bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		g, ok := s.guest(w, r, kind)
+		if !ok {
+			return
+		}
+
+		if err := s.applyConfig(g, r.PostForm); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+
+			return
+		}
+
+		if !async {
+			writeData(w, nil)
+
+			return
+		}
+
+		writeData(w, s.startTask(kind+"config", strconv.Itoa(g.vmid), nil))
+	}
+}
+
+func (s *Server) handleGuestStatus(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		g, ok := s.guest(w, r, kind)
+		if !ok {
+			return
+		}
+
+		data := map[string]interface{}{
+			"vmid":   g.vmid,
+			"name":   g.name(),
+			"status": g.status,
+			"uptime": 0,
+		}
+
+		if kind == guestVM {
+			data["qmpstatus"] = g.status
+		}
+
+		writeData(w, data)
+	}
+}
+
+func (s *Server) handleGuestAction(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		g, ok := s.guest(w, r, kind)
+		if !ok {
+			return
+		}
+
+		status := map[string]string{
+			"reboot":   "running",
+			"resume":   "running",
+			"shutdown": "stopped",
+			"start":    "running",
+			"stop":     "stopped",
+		}[r.PathValue("action")]
+
+		if status == "" {
+			writeError(w, http.StatusNotImplemented, fmt.Sprintf("Method '%s %s' not implemented", r.Method, r.URL.Path))
+
+			return
+		}
+
+		writeData(w, s.startTask(kind+r.PathValue("action"), strconv.Itoa(g.vmid), func() {
+			g.status = status
+		}))
+	}
+}
+
+func (s *Server) handleDeleteGuest(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		g, ok := s.guest(w, r, kind)
+		if !ok {
+			return
+		}
+
+		if g.status == "running" {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("%s %d is running - destroy failed", g.label(), g.vmid))
+
+			return
+		}
+
+		writeData(w, s.startTask(kind+"destroy", strconv.Itoa(g.vmid), func() {
+			delete(s.guests, g.vmid)
+
+			for _, d := range s.datastores {
+				for id, v := range d.volumes {
+					if v.vmid == g.vmid {
+						delete(d.volumes, id)
+					}
+				}
+			}
+		}))
+	}
+}
+
+// sortedKeys returns the keys of a map in order.
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
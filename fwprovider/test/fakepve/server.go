@@ -0,0 +1,396 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package fakepve is a fake Proxmox VE API server for the tests, which implements the endpoints used by the file,
+// VM, container and APT standard repository resources with an in-memory state. It can delay the tasks and fail the requests with lock
+// errors on demand, so that the retries of the provider can be tested deterministically.
+package fakepve
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// Username is the user which the server accepts for the ticket login.
+	Username = "root@pam"
+
+	// Password is the password of the user.
+	Password = "fake-password"
+
+	// APIToken is the API token which the server accepts.
+	APIToken = "root@pam!fake=00000000-0000-0000-0000-000000000000"
+
+	// Version is the Proxmox VE version reported by the server.
+	Version = "8.4.1"
+
+	// CustomCPUModel is the name, without the `custom-` prefix, of the custom CPU model defined on the node.
+	CustomCPUModel = "fake"
+
+	apiPrefix = "/api2/json"
+	ticket    = "PVE:root@pam:00000000::fake-ticket"
+	csrfToken = "00000000:fake-csrf-prevention-token"
+)
+
+// Server is a fake Proxmox VE API server, with a single node.
+type Server struct {
+	*httptest.Server
+
+	// NodeName is the name of the node.
+	NodeName string
+
+	mu sync.Mutex
+
+	datastores map[string]*datastore
+	guests     map[int]*guest
+	tasks      map[string]*task
+	taskDelay  int
+	faults     []*fault
+	requests   int
+
+	aptRepos  []*aptRepo
+	aptDigest int
+}
+
+// fault fails the next requests matching the method and the path with a lock error.
+type fault struct {
+	method string
+	path   *regexp.Regexp
+	times  int
+}
+
+// NewServer starts a fake Proxmox VE API server over TLS, with the `local` and `local-lvm` datastores
+// of a fresh installation. The server must be closed when done.
+func NewServer(nodeName string) *Server {
+	s := &Server{
+		NodeName:   nodeName,
+		datastores: defaultDatastores(),
+		guests:     map[int]*guest{},
+		tasks:      map[string]*task{},
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST "+apiPrefix+"/access/ticket", s.handleTicket)
+	mux.Handle(apiPrefix+"/", s.authenticated(s.routes()))
+
+	s.Server = httptest.NewTLSServer(mux)
+
+	return s
+}
+
+// SetTaskDelay makes the tasks started from now on report as running for the given number of status polls.
+func (s *Server) SetTaskDelay(polls int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.taskDelay = polls
+}
+
+// InjectLockErrors fails the next requests with the given method and a path, relative to `/api2/json/`,
+// matching the pattern, with the `can't lock file` error which Proxmox VE returns when a config is locked.
+func (s *Server) InjectLockErrors(method string, pattern string, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault{method: method, path: regexp.MustCompile(pattern), times: times})
+}
+
+// Requests returns the number of API requests served, including the failed ones.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	handle := func(pattern string, h http.HandlerFunc) {
+		method, path, _ := strings.Cut(pattern, " ")
+		mux.HandleFunc(method+" "+apiPrefix+path, h)
+	}
+
+	handle("GET /version", s.handleVersion)
+	handle("GET /nodes", s.handleListNodes)
+	handle("GET /cluster/resources", s.handleClusterResources)
+	handle("GET /cluster/nextid", s.handleNextID)
+
+	handle("GET /storage/{storage}", s.handleGetDatastore)
+	handle("GET /nodes/{node}/capabilities/qemu/cpu", s.handleListCPUModels)
+
+	handle("GET /nodes/{node}/apt/repositories", s.handleGetAPTRepositories)
+	handle("PUT /nodes/{node}/apt/repositories", s.handleAddAPTRepository)
+	handle("POST /nodes/{node}/apt/repositories", s.handleChangeAPTRepository)
+
+	handle("GET /nodes/{node}/storage", s.handleListDatastores)
+	handle("GET /nodes/{node}/storage/{storage}/status", s.handleDatastoreStatus)
+	handle("GET /nodes/{node}/storage/{storage}/content", s.handleListContent)
+	handle("GET /nodes/{node}/storage/{storage}/content/{volume}", s.handleGetContent)
+	handle("DELETE /nodes/{node}/storage/{storage}/content/{volume}", s.handleDeleteContent)
+	handle("POST /nodes/{node}/storage/{storage}/upload", s.handleUpload)
+	handle("POST /nodes/{node}/storage/{storage}/download-url", s.handleDownloadURL)
+
+	handle("GET /nodes/{node}/tasks", s.handleListTasks)
+	handle("GET /nodes/{node}/tasks/{upid}/status", s.handleTaskStatus)
+	handle("GET /nodes/{node}/tasks/{upid}/log", s.handleTaskLog)
+
+	for _, kind := range []string{guestVM, guestContainer} {
+		handle("GET /nodes/{node}/"+kind, s.handleListGuests(kind))
+		handle("POST /nodes/{node}/"+kind, s.handleCreateGuest(kind))
+		handle("GET /nodes/{node}/"+kind+"/{vmid}/config", s.handleGetGuestConfig(kind))
+		handle("PUT /nodes/{node}/"+kind+"/{vmid}/config", s.handleUpdateGuestConfig(kind, false))
+		handle("POST /nodes/{node}/"+kind+"/{vmid}/config", s.handleUpdateGuestConfig(kind, true))
+		handle("GET /nodes/{node}/"+kind+"/{vmid}/status/current", s.handleGuestStatus(kind))
+		handle("POST /nodes/{node}/"+kind+"/{vmid}/status/{action}", s.handleGuestAction(kind))
+		handle("DELETE /nodes/{node}/"+kind+"/{vmid}", s.handleDeleteGuest(kind))
+		handle("DELETE /nodes/{node}/"+kind+"/{vmid}/{$}", s.handleDeleteGuest(kind))
+	}
+
+	mux.HandleFunc(apiPrefix+"/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotImplemented,
+			fmt.Sprintf("Method '%s %s' not implemented", r.Method, strings.TrimPrefix(r.URL.Path, apiPrefix)))
+	})
+
+	return mux
+}
+
+// authenticated serves the requests authenticated with the API token, or with the ticket and, for the requests
+// changing the state, the CSRF prevention token. The injected faults are served before the request.
+func (s *Server) authenticated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		s.mu.Unlock()
+
+		if !isAuthenticated(r) {
+			writeError(w, http.StatusUnauthorized, "authentication failure")
+
+			return
+		}
+
+		if s.fault(r) {
+			writeError(w, http.StatusInternalServerError,
+				fmt.Sprintf("can't lock file '%s' - got timeout", lockFile(r.URL.Path)))
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isAuthenticated(r *http.Request) bool {
+	if r.Header.Get("Authorization") == "PVEAPIToken="+APIToken {
+		return true
+	}
+
+	cookie, err := r.Cookie("PVEAuthCookie")
+	if err != nil || cookie.Value != ticket {
+		return false
+	}
+
+	return r.Method == http.MethodGet || r.Header.Get("CSRFPreventionToken") == csrfToken
+}
+
+// fault returns true if the request must fail, as a fault matches it.
+func (s *Server) fault(r *http.Request) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, apiPrefix+"/")
+
+	for _, f := range s.faults {
+		if f.times > 0 && f.method == r.Method && f.path.MatchString(path) {
+			f.times--
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// lockFile returns the lock file of the guest or the datastore of the request, as Proxmox VE reports it.
+func lockFile(path string) string {
+	if m := regexp.MustCompile(`/(qemu|lxc)/(\d+)`).FindStringSubmatch(path); m != nil {
+		dir := "qemu-server"
+		if m[1] == guestContainer {
+			dir = "lxc"
+		}
+
+		return fmt.Sprintf("/var/lock/%s/lock-%s.conf", dir, m[2])
+	}
+
+	if m := regexp.MustCompile(`/storage/([^/]+)`).FindStringSubmatch(path); m != nil {
+		return fmt.Sprintf("/var/lock/pve-manager/pve-storage-%s", m[1])
+	}
+
+	return "/var/lock/pve-manager/pve-config.lck"
+}
+
+func (s *Server) handleTicket(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if r.PostForm.Get("username") != Username || r.PostForm.Get("password") != Password {
+		writeError(w, http.StatusUnauthorized, "authentication failure")
+
+		return
+	}
+
+	writeData(w, map[string]interface{}{
+		"ticket":              ticket,
+		"CSRFPreventionToken": csrfToken,
+		"username":            Username,
+	})
+}
+
+func (s *Server) handleVersion(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, map[string]interface{}{
+		"release": Version[:strings.LastIndex(Version, ".")],
+		"repoid":  "00000000",
+		"version": Version,
+	})
+}
+
+func (s *Server) handleListNodes(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, []map[string]interface{}{
+		{"node": s.NodeName, "status": "online", "type": "node", "id": "node/" + s.NodeName},
+	})
+}
+
+func (s *Server) handleListCPUModels(w http.ResponseWriter, _ *http.Request) {
+	models := []map[string]interface{}{
+		{"name": "custom-" + CustomCPUModel, "vendor": "GenuineIntel", "custom": 1},
+	}
+
+	for _, name := range []string{"host", "kvm64", "max", "qemu64", "x86-64-v2-AES", "x86-64-v3", "x86-64-v4"} {
+		models = append(models, map[string]interface{}{"name": name, "vendor": "default", "custom": 0})
+	}
+
+	writeData(w, models)
+}
+
+func (s *Server) handleClusterResources(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resources := []map[string]interface{}{
+		{"id": "node/" + s.NodeName, "type": "node", "node": s.NodeName, "status": "online"},
+	}
+
+	for _, id := range sortedKeys(s.datastores) {
+		resources = append(resources, map[string]interface{}{
+			"id":      fmt.Sprintf("storage/%s/%s", s.NodeName, id),
+			"type":    "storage",
+			"node":    s.NodeName,
+			"storage": id,
+			"status":  "available",
+		})
+	}
+
+	for _, vmid := range sortedKeys(s.guests) {
+		g := s.guests[vmid]
+
+		resources = append(resources, map[string]interface{}{
+			"id":     fmt.Sprintf("%s/%d", g.kind, vmid),
+			"type":   g.kind,
+			"node":   s.NodeName,
+			"vmid":   vmid,
+			"name":   g.name(),
+			"status": g.status,
+		})
+	}
+
+	if t := r.URL.Query().Get("type"); t != "" {
+		filtered := resources[:0]
+
+		for _, res := range resources {
+			if res["type"] == t || (t == "vm" && (res["type"] == guestVM || res["type"] == guestContainer)) {
+				filtered = append(filtered, res)
+			}
+		}
+
+		resources = filtered
+	}
+
+	writeData(w, resources)
+}
+
+func (s *Server) handleNextID(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v := r.URL.Query().Get("vmid"); v != "" {
+		vmid, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid vmid")
+
+			return
+		}
+
+		if _, ok := s.guests[vmid]; ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("VM %d already exists", vmid))
+
+			return
+		}
+
+		writeData(w, strconv.Itoa(vmid))
+
+		return
+	}
+
+	vmid := 100
+	for s.guests[vmid] != nil {
+		vmid++
+	}
+
+	writeData(w, strconv.Itoa(vmid))
+}
+
+// writeData writes the data of a successful response.
+func writeData(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+// writeError writes an error response. Proxmox VE reports the error in the status line, e.g.
+// `500 can't lock file ... - got timeout`, which requires to take over the connection.
+func writeError(w http.ResponseWriter, code int, message string) {
+	body := `{"data":null}`
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, message, code)
+
+		return
+	}
+
+	conn, buf, err := hj.Hijack()
+	if err != nil {
+		return
+	}
+
+	defer conn.Close()
+
+	_, _ = fmt.Fprintf(buf,
+		"HTTP/1.1 %d %s\r\nContent-Type: application/json\r\nContent-Length: %d\r\nConnection: close\r\n\r\n%s",
+		code, strings.NewReplacer("\r", " ", "\n", " ").Replace(message), len(body), body,
+	)
+	_ = buf.Flush()
+}
@@ -0,0 +1,148 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fakepve
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/version"
+)
+
+func newClient(t *testing.T, s *Server, creds api.Credentials) *nodes.Client {
+	t.Helper()
+
+	conn, err := api.NewConnection(s.URL, true, "")
+	require.NoError(t, err)
+
+	c, err := api.NewClient(creds, conn,
+		api.WithRetryPolicy(api.RetryPolicy{Retries: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+		api.WithTaskPolling(api.TaskPolling{Interval: time.Millisecond, Backoff: 1}),
+	)
+	require.NoError(t, err)
+
+	return &nodes.Client{Client: c, NodeName: s.NodeName}
+}
+
+func TestTicketLogin(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer("pve")
+	t.Cleanup(s.Close)
+
+	creds, err := api.NewCredentials(Username, Password, "", "", "", "")
+	require.NoError(t, err)
+
+	v, err := (&version.Client{Client: newClient(t, s, creds)}).Version(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Version, v.Version.String())
+
+	creds, err = api.NewCredentials(Username, "wrong", "", "", "", "")
+	require.NoError(t, err)
+
+	_, err = (&version.Client{Client: newClient(t, s, creds)}).Version(context.Background())
+	require.Error(t, err)
+}
+
+func TestUpload(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer("pve")
+	t.Cleanup(s.Close)
+
+	creds, err := api.NewCredentials("", "", "", APIToken, "", "")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	storage := newClient(t, s, creds).Storage("local")
+
+	name := filepath.Join(t.TempDir(), "image.iso")
+	require.NoError(t, os.WriteFile(name, []byte("iso image"), 0o600))
+
+	f, err := os.Open(name)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = f.Close() })
+
+	s.SetTaskDelay(3)
+
+	requests := s.Requests()
+
+	_, err = storage.APIUpload(ctx, &api.FileUploadRequest{ContentType: "iso", FileName: "image.iso", File: f}, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, 5, s.Requests()-requests, "the upload, and the polls of the task until it completes")
+
+	files, err := storage.ListDatastoreFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "local:iso/image.iso", files[0].VolumeID)
+	assert.Equal(t, int64(len("iso image")), files[0].FileSize)
+
+	s.SetTaskDelay(0)
+
+	require.NoError(t, storage.DeleteDatastoreFile(ctx, "local:iso/image.iso"))
+
+	files, err = storage.ListDatastoreFiles(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestGuests(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer("pve")
+	t.Cleanup(s.Close)
+
+	creds, err := api.NewCredentials("", "", "", APIToken, "", "")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	vm := newClient(t, s, creds).VM(100)
+
+	body := &vms.CreateRequestBody{VMID: 100, Name: ptr.Ptr("test")}
+	body.AddCustomStorageDevice("scsi0", vms.CustomStorageDevice{FileVolume: "local-lvm:8"})
+
+	require.NoError(t, vm.CreateVM(ctx, body))
+
+	config, err := vm.GetVM(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "test", *config.Name)
+	require.Contains(t, config.StorageDevices, "scsi0")
+	assert.Equal(t, "local-lvm:vm-100-disk-0", config.StorageDevices["scsi0"].FileVolume)
+	assert.Equal(t, int64(8), config.StorageDevices["scsi0"].Size.InGigabytes())
+
+	err = vm.CreateVM(ctx, body)
+	require.ErrorContains(t, err, "already exists")
+
+	// the config is locked for the first two attempts, which are retried
+	s.InjectLockErrors(http.MethodPut, `^nodes/pve/qemu/100/config$`, 2)
+
+	requests := s.Requests()
+
+	require.NoError(t, vm.UpdateVM(ctx, &vms.UpdateRequestBody{Name: ptr.Ptr("renamed")}))
+	assert.Equal(t, 3, s.Requests()-requests)
+
+	config, err = vm.GetVM(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", *config.Name)
+
+	require.NoError(t, vm.DeleteVM(ctx))
+
+	_, err = vm.GetVM(ctx)
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+}
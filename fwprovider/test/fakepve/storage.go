@@ -0,0 +1,434 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fakepve
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DownloadSize is the size of the files downloaded from a URL, which are not actually downloaded.
+const DownloadSize = 1024 * 1024
+
+const datastoreSize = 100 * 1024 * 1024 * 1024
+
+// datastore is a datastore of the node, with its volumes by ID.
+type datastore struct {
+	id      string
+	kind    string
+	path    string
+	content []string
+	volumes map[string]*volume
+}
+
+// volume is a file or a disk of a datastore.
+type volume struct {
+	id      string
+	content string
+	format  string
+	size    int64
+	vmid    int
+	ctime   int64
+}
+
+// contentDirs are the directories of the content types in a directory datastore.
+var contentDirs = map[string]string{
+	"backup":   "dump",
+	"import":   "import",
+	"iso":      "template/iso",
+	"snippets": "snippets",
+	"vztmpl":   "template/cache",
+}
+
+func defaultDatastores() map[string]*datastore {
+	return map[string]*datastore{
+		"local": {
+			id:      "local",
+			kind:    "dir",
+			path:    "/var/lib/vz",
+			content: []string{"backup", "import", "iso", "snippets", "vztmpl"},
+			volumes: map[string]*volume{},
+		},
+		"local-lvm": {
+			id:      "local-lvm",
+			kind:    "lvmthin",
+			content: []string{"images", "rootdir"},
+			volumes: map[string]*volume{},
+		},
+	}
+}
+
+func (d *datastore) used() int64 {
+	var used int64
+
+	for _, v := range d.volumes {
+		used += v.size
+	}
+
+	return used
+}
+
+// volumePath returns the path of a volume on the node.
+func (d *datastore) volumePath(v *volume) string {
+	_, name, _ := strings.Cut(v.id, ":")
+
+	if d.kind != "dir" {
+		return fmt.Sprintf("/dev/pve/%s", name)
+	}
+
+	if dir, ok := contentDirs[v.content]; ok {
+		return filepath.Join(d.path, dir, filepath.Base(name))
+	}
+
+	return filepath.Join(d.path, "images", name)
+}
+
+func (d *datastore) data() map[string]interface{} {
+	used := d.used()
+
+	return map[string]interface{}{
+		"storage":       d.id,
+		"type":          d.kind,
+		"content":       strings.Join(d.content, ","),
+		"active":        1,
+		"enabled":       1,
+		"shared":        0,
+		"total":         datastoreSize,
+		"used":          used,
+		"avail":         datastoreSize - used,
+		"used_fraction": float64(used) / datastoreSize,
+	}
+}
+
+// datastore returns the datastore of the request, or writes an error if it does not exist.
+func (s *Server) datastore(w http.ResponseWriter, r *http.Request) (*datastore, bool) {
+	d, ok := s.datastores[r.PathValue("storage")]
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("storage '%s' does not exist", r.PathValue("storage")))
+	}
+
+	return d, ok
+}
+
+// volumeID returns the full ID of the volume of the request, which may be relative to the datastore,
+// e.g. `iso/image.iso`.
+func (d *datastore) volumeID(r *http.Request) string {
+	id := r.PathValue("volume")
+	if !strings.Contains(id, ":") {
+		id = d.id + ":" + id
+	}
+
+	return id
+}
+
+// volumeFormat returns the format of a file, as Proxmox VE reports it in the content of a datastore.
+func volumeFormat(content string, name string) string {
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"):
+		return "tgz"
+	case strings.HasSuffix(name, ".tar.zst"):
+		return "tzst"
+	case strings.HasSuffix(name, ".tar.xz"):
+		return "txz"
+	case content == "snippets":
+		return "snippet"
+	}
+
+	return strings.TrimPrefix(filepath.Ext(name), ".")
+}
+
+func (s *Server) handleGetDatastore(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.datastore(w, r)
+	if !ok {
+		return
+	}
+
+	data := map[string]interface{}{
+		"storage": d.id,
+		"type":    d.kind,
+		"content": strings.Join(d.content, ","),
+		"digest":  "0000000000000000000000000000000000000000",
+	}
+
+	if d.path != "" {
+		data["path"] = d.path
+	}
+
+	writeData(w, data)
+}
+
+func (s *Server) handleListDatastores(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content := r.URL.Query().Get("content")
+	list := []map[string]interface{}{}
+
+	for _, id := range sortedKeys(s.datastores) {
+		d := s.datastores[id]
+
+		if content == "" || slices.Contains(d.content, content) {
+			list = append(list, d.data())
+		}
+	}
+
+	writeData(w, list)
+}
+
+func (s *Server) handleDatastoreStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d, ok := s.datastore(w, r); ok {
+		writeData(w, d.data())
+	}
+}
+
+func (s *Server) handleListContent(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.datastore(w, r)
+	if !ok {
+		return
+	}
+
+	content := r.URL.Query().Get("content")
+	list := []map[string]interface{}{}
+
+	for _, id := range sortedKeys(d.volumes) {
+		v := d.volumes[id]
+
+		if content != "" && v.content != content {
+			continue
+		}
+
+		entry := map[string]interface{}{
+			"volid":   v.id,
+			"content": v.content,
+			"format":  v.format,
+			"size":    v.size,
+			"ctime":   v.ctime,
+		}
+
+		if v.vmid != 0 {
+			entry["vmid"] = v.vmid
+		}
+
+		list = append(list, entry)
+	}
+
+	writeData(w, list)
+}
+
+func (s *Server) handleGetContent(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.datastore(w, r)
+	if !ok {
+		return
+	}
+
+	v, ok := d.volumes[d.volumeID(r)]
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("volume '%s' does not exist", d.volumeID(r)))
+
+		return
+	}
+
+	writeData(w, map[string]interface{}{
+		"path":   d.volumePath(v),
+		"format": v.format,
+		"size":   v.size,
+		"used":   v.size,
+	})
+}
+
+func (s *Server) handleDeleteContent(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.datastore(w, r)
+	if !ok {
+		return
+	}
+
+	id := d.volumeID(r)
+	if _, ok := d.volumes[id]; !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("volume '%s' does not exist", id))
+
+		return
+	}
+
+	writeData(w, s.startTask("imgdel", id, func() {
+		delete(d.volumes, id)
+	}))
+}
+
+func (s *Server) handleUpload(w http.ResponseWriter, r *http.Request) {
+	content, name, size, err := readUpload(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.datastore(w, r)
+	if !ok {
+		return
+	}
+
+	s.addFile(w, d, "imgcopy", content, name, size)
+}
+
+// handleDownloadURL simulates the download of a file from a URL, without downloading it.
+func (s *Server) handleDownloadURL(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.datastore(w, r)
+	if !ok {
+		return
+	}
+
+	content, name := r.PostForm.Get("content"), r.PostForm.Get("filename")
+	if content == "" || name == "" || r.PostForm.Get("url") == "" {
+		writeError(w, http.StatusBadRequest, "missing content, filename or url")
+
+		return
+	}
+
+	// like Proxmox VE, the node doesn't download over an existing file
+	if _, ok := d.volumes[fmt.Sprintf("%s:%s/%s", d.id, content, name)]; ok {
+		writeError(w, http.StatusInternalServerError,
+			fmt.Sprintf("refusing to override existing file '%s'", filepath.Join(d.path, contentDirs[content], name)))
+
+		return
+	}
+
+	s.addFile(w, d, "download", content, name, DownloadSize)
+}
+
+// AddFile adds a file of the given size to a datastore, as if it had been uploaded out-of-band.
+func (s *Server) AddFile(datastoreID string, content string, name string, size int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := fmt.Sprintf("%s:%s/%s", datastoreID, content, name)
+
+	s.datastores[datastoreID].volumes[id] = &volume{
+		id:      id,
+		content: content,
+		format:  volumeFormat(content, name),
+		size:    size,
+		ctime:   time.Now().Unix(),
+	}
+}
+
+// Files returns the volume IDs of the files of a datastore, sorted.
+func (s *Server) Files(datastoreID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.datastores[datastoreID].volumes))
+
+	for id := range s.datastores[datastoreID].volumes {
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+
+	return ids
+}
+
+// addFile adds a file to the datastore once the task uploading or downloading it completes.
+func (s *Server) addFile(w http.ResponseWriter, d *datastore, kind string, content string, name string, size int64) {
+	if !slices.Contains(d.content, content) {
+		writeError(w, http.StatusInternalServerError,
+			fmt.Sprintf("storage '%s' does not support content-type '%s'", d.id, content))
+
+		return
+	}
+
+	v := &volume{
+		id:      fmt.Sprintf("%s:%s/%s", d.id, content, name),
+		content: content,
+		format:  volumeFormat(content, name),
+		size:    size,
+		ctime:   time.Now().Unix(),
+	}
+
+	writeData(w, s.startTask(kind, d.id, func() {
+		d.volumes[v.id] = v
+	}))
+}
+
+// readUpload reads the content type and the file of a multipart upload, and returns the name and the size
+// of the file.
+func readUpload(r *http.Request) (string, string, int64, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid upload: %w", err)
+	}
+
+	var (
+		content string
+		name    string
+		size    int64
+	)
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return "", "", 0, fmt.Errorf("invalid upload: %w", err)
+		}
+
+		switch part.FormName() {
+		case "content":
+			b, e := io.ReadAll(part)
+			if e != nil {
+				return "", "", 0, fmt.Errorf("invalid upload: %w", e)
+			}
+
+			content = string(b)
+		case "filename":
+			name = part.FileName()
+
+			if size, err = io.Copy(io.Discard, part); err != nil {
+				return "", "", 0, fmt.Errorf("invalid upload: %w", err)
+			}
+		}
+	}
+
+	if content == "" || name == "" {
+		return "", "", 0, fmt.Errorf("invalid upload: missing content or file")
+	}
+
+	return content, name, size, nil
+}
@@ -0,0 +1,152 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fakepve
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// task is a task of the node, which runs for a number of status polls.
+type task struct {
+	upid      string
+	kind      string
+	id        string
+	start     time.Time
+	polls     int
+	exitCode  string
+	log       []string
+	completed func()
+	done      bool
+}
+
+// startTask starts a task, which completes once polled as many times as the task delay, or immediately if there
+// is no delay. The completion function, if any, is called when the task completes, with the server locked.
+func (s *Server) startTask(kind string, id string, completed func()) string {
+	t := &task{
+		kind:      kind,
+		id:        id,
+		start:     time.Now(),
+		polls:     s.taskDelay,
+		exitCode:  "OK",
+		log:       []string{fmt.Sprintf("%s %s", kind, id), "TASK OK"},
+		completed: completed,
+	}
+
+	t.upid = fmt.Sprintf("UPID:%s:%08X:%08X:%08X:%s:%s:%s:",
+		s.NodeName, 1000+len(s.tasks), 0, t.start.Unix(), kind, id, Username)
+	s.tasks[t.upid] = t
+
+	if t.polls == 0 {
+		s.completeTask(t)
+	}
+
+	return t.upid
+}
+
+func (s *Server) completeTask(t *task) {
+	if t.completed != nil {
+		t.completed()
+	}
+
+	t.done = true
+}
+
+func (t *task) status() string {
+	if t.done {
+		return "stopped"
+	}
+
+	return "running"
+}
+
+func (s *Server) handleTaskStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tasks[r.PathValue("upid")]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "no such task")
+
+		return
+	}
+
+	if t.polls > 0 {
+		t.polls--
+	} else if !t.done {
+		s.completeTask(t)
+	}
+
+	status := t.status()
+
+	data := map[string]interface{}{
+		"upid":   t.upid,
+		"node":   s.NodeName,
+		"pid":    1000,
+		"type":   t.kind,
+		"id":     t.id,
+		"status": status,
+	}
+
+	if status == "stopped" {
+		data["exitstatus"] = t.exitCode
+	}
+
+	writeData(w, data)
+}
+
+func (s *Server) handleTaskLog(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tasks[r.PathValue("upid")]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "no such task")
+
+		return
+	}
+
+	lines := make([]map[string]interface{}, 0, len(t.log))
+
+	for i, l := range t.log {
+		lines = append(lines, map[string]interface{}{"n": i + 1, "t": l})
+	}
+
+	writeData(w, lines)
+}
+
+func (s *Server) handleListTasks(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]map[string]interface{}, 0, len(s.tasks))
+
+	for _, t := range s.tasks {
+		entry := map[string]interface{}{
+			"upid":      t.upid,
+			"node":      s.NodeName,
+			"type":      t.kind,
+			"id":        t.id,
+			"user":      Username,
+			"starttime": t.start.Unix(),
+		}
+
+		if t.status() == "stopped" {
+			entry["status"] = t.exitCode
+		}
+
+		list = append(list, entry)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i]["upid"].(string) > list[j]["upid"].(string)
+	})
+
+	writeData(w, list)
+}
@@ -10,7 +10,10 @@ package test
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -21,8 +24,10 @@ import (
 	"github.com/brianvoe/gofakeit/v7"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test/fakepve"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
 
@@ -37,6 +42,208 @@ func (c *nodeResolver) Resolve(_ context.Context, _ string) (ssh.ProxmoxNode, er
 	return c.node, nil
 }
 
+func TestAccResourceFileFakeRetries(t *testing.T) {
+	te := InitEnvironment(t)
+	if te.Fake == nil {
+		t.Skip("requires the fake Proxmox VE API server, set TF_ACC_FAKE=1")
+	}
+
+	fileISO := strings.ReplaceAll(CreateTempFile(t, "file-*.iso", "pretend this is an ISO").Name(), `\`, `/`)
+
+	te.AddTemplateVars(map[string]interface{}{
+		"FileISO": fileISO,
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		PreCheck: func() {
+			// the upload task runs for a few polls, and the listing of the datastore content is locked twice
+			te.Fake.SetTaskDelay(3)
+			te.Fake.InjectLockErrors(http.MethodGet, `^nodes/[^/]+/storage/local/content$`, 2)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_file" "test_fake" {
+					content_type = "iso"
+					datastore_id = "local"
+					node_name    = "{{.NodeName}}"
+					source_file {
+					  path = "{{.FileISO}}"
+					}
+				}`),
+				Check: ResourceAttributes("proxmox_virtual_environment_file.test_fake", map[string]string{
+					"content_type": "iso",
+					"file_name":    filepath.Base(fileISO),
+					"id":           fmt.Sprintf("local:iso/%s", filepath.Base(fileISO)),
+				}),
+			},
+		},
+	})
+}
+
+func TestAccResourceFileFakeOverwrite(t *testing.T) {
+	te := InitEnvironment(t)
+	if te.Fake == nil {
+		t.Skip("requires the fake Proxmox VE API server, set TF_ACC_FAKE=1")
+	}
+
+	content := "pretend this is an ISO"
+	fileISO := strings.ReplaceAll(CreateTempFile(t, "file-*.iso", content).Name(), `\`, `/`)
+
+	te.AddTemplateVars(map[string]interface{}{
+		"FileISO": fileISO,
+	})
+
+	config := te.RenderConfig(`
+	resource "proxmox_virtual_environment_file" "test_fake_overwrite" {
+		content_type = "iso"
+		datastore_id = "local"
+		node_name    = "{{.NodeName}}"
+		source_file {
+		  path = "{{.FileISO}}"
+		}
+	}`)
+
+	// the fake server has no SSH access to compute the checksum of the existing file, so the sizes are compared
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					te.Fake.AddFile("local", "iso", filepath.Base(fileISO), int64(len(content)+1))
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile(`already exists and does not match the source`),
+			},
+			{
+				PreConfig: func() {
+					te.Fake.AddFile("local", "iso", filepath.Base(fileISO), int64(len(content)))
+				},
+				Config: config,
+				Check: ResourceAttributes("proxmox_virtual_environment_file.test_fake_overwrite", map[string]string{
+					"overwritten": "true",
+				}),
+			},
+		},
+	})
+}
+
+func TestAccResourceFileFakeServerSideOverwrite(t *testing.T) {
+	te := InitEnvironment(t)
+	if te.Fake == nil {
+		t.Skip("requires the fake Proxmox VE API server, set TF_ACC_FAKE=1")
+	}
+
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("pretend this is an ISO"))
+	}))
+	t.Cleanup(source.Close)
+
+	fileName := fmt.Sprintf("server-side-%s.iso", gofakeit.Word())
+	volumeID := "local:iso/" + fileName
+
+	te.AddTemplateVars(map[string]interface{}{
+		"FileName": fileName,
+		"URL":      source.URL + "/" + fileName,
+	})
+
+	config := te.RenderConfig(`
+	resource "proxmox_virtual_environment_file" "test_fake_server_side" {
+		content_type = "iso"
+		datastore_id = "local"
+		node_name    = "{{.NodeName}}"
+		source_file {
+		  path                 = "{{.URL}}"
+		  server_side_download = true
+		}
+	}`)
+
+	// only the existing file is left, without the temporary file of the download
+	checkFiles := func() error {
+		var files []string
+
+		for _, id := range te.Fake.Files("local") {
+			if strings.HasSuffix(id, fileName) {
+				files = append(files, id)
+			}
+		}
+
+		if len(files) != 1 || files[0] != volumeID {
+			return fmt.Errorf("expected only the file %q, got %v", volumeID, files)
+		}
+
+		return nil
+	}
+
+	// the fake server has no SSH access to compute the checksums, so the sizes are compared, and the downloaded file
+	// can't be moved over the existing one, so it is downloaded once more after the existing one is deleted
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					te.Fake.AddFile("local", "iso", fileName, 1)
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile(`already exists and does not match the source`),
+			},
+			{
+				PreConfig: func() {
+					require.NoError(t, checkFiles())
+
+					te.Fake.AddFile("local", "iso", fileName, fakepve.DownloadSize)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes("proxmox_virtual_environment_file.test_fake_server_side", map[string]string{
+						"id":          volumeID,
+						"overwritten": "true",
+					}),
+					func(*terraform.State) error { return checkFiles() },
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceFileDisabled(t *testing.T) {
+	te := InitEnvironment(t)
+
+	te.AddTemplateVars(map[string]interface{}{
+		"SnippetRaw": fmt.Sprintf("snippet-disabled-%s.txt", gofakeit.Word()),
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_file" "test_disabled" {
+					content_type = "snippets"
+					datastore_id = "local"
+					enabled      = false
+					node_name    = "{{.NodeName}}"
+					source_raw {
+						data      = "test snippet"
+						file_name = "{{.SnippetRaw}}"
+					}
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					ResourceAttributes("proxmox_virtual_environment_file.test_disabled", map[string]string{
+						"enabled": "false",
+						"id":      "disabled",
+					}),
+					NoResourceAttributesSet("proxmox_virtual_environment_file.test_disabled", []string{
+						"file_name",
+						"uploaded_checksum",
+					}),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceFile(t *testing.T) {
 	te := InitEnvironment(t)
 
@@ -45,6 +252,7 @@ func TestAccResourceFile(t *testing.T) {
 	snippetFile1 := strings.ReplaceAll(CreateTempFile(t, "snippet-file-1-*.yaml", "test snippet 1 - file").Name(), `\`, `/`)
 	snippetFile2 := strings.ReplaceAll(CreateTempFile(t, "snippet-file-2-*.yaml", "test snippet 2 - file").Name(), `\`, `/`)
 	fileISO := strings.ReplaceAll(CreateTempFile(t, "file-*.iso", "pretend this is an ISO").Name(), `\`, `/`)
+	snippetFile1Checksum := fmt.Sprintf("%x", sha256.Sum256([]byte("test snippet 1 - file")))
 
 	te.AddTemplateVars(map[string]interface{}{
 		"SnippetRaw":   snippetRaw,
@@ -71,9 +279,10 @@ func TestAccResourceFile(t *testing.T) {
 			{
 				Config: te.RenderConfig(`
 				resource "proxmox_virtual_environment_file" "test_raw" {
-				content_type = "snippets"
-				datastore_id = "local"
-				node_name    = "{{.NodeName}}"
+				content_type      = "snippets"
+				datastore_id      = "local"
+				node_name         = "{{.NodeName}}"
+				read_back_content = true
 				source_raw {
 					data = <<EOF
 				test snippet
@@ -86,6 +295,7 @@ func TestAccResourceFile(t *testing.T) {
 					"file_name":              snippetRaw,
 					"source_raw.0.file_name": snippetRaw,
 					"source_raw.0.data":      "test snippet\n",
+					"stored_content":         "test snippet\n",
 					"id":                     fmt.Sprintf("local:snippets/%s", snippetRaw),
 				}),
 			},
@@ -234,11 +444,29 @@ func TestAccResourceFile(t *testing.T) {
 				  }
 				}`),
 				Check: ResourceAttributes("proxmox_virtual_environment_file.test", map[string]string{
-					"content_type": "snippets",
-					"file_name":    filepath.Base(snippetFile1),
-					"id":           fmt.Sprintf("local:snippets/%s", filepath.Base(snippetFile1)),
+					"content_type":      "snippets",
+					"file_name":         filepath.Base(snippetFile1),
+					"id":                fmt.Sprintf("local:snippets/%s", filepath.Base(snippetFile1)),
+					"uploaded_checksum": snippetFile1Checksum,
 				}),
 			},
+			// Import testing: the checksum of the remote file is read
+			{
+				ResourceName:  "proxmox_virtual_environment_file.test",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("%s/local:snippets/%s", te.NodeName, filepath.Base(snippetFile1)),
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported resource, got %d", len(states))
+					}
+
+					if checksum := states[0].Attributes["uploaded_checksum"]; checksum != snippetFile1Checksum {
+						return fmt.Errorf("expected the uploaded_checksum %q, got %q", snippetFile1Checksum, checksum)
+					}
+
+					return nil
+				},
+			},
 		},
 	})
 }
@@ -259,7 +487,7 @@ func uploadSnippetFile(t *testing.T, fileName string) {
 	sshPort := utils.GetAnyIntEnv("PROXMOX_VE_ACC_NODE_SSH_PORT")
 	sshClient, err := ssh.NewClient(
 		sshUsername, sshPassword, sshAgent, sshAgentSocket, sshAgentForwarding, sshPrivateKey,
-		"", "", "",
+		"", "", "", "", "", "", 0,
 		&nodeResolver{
 			node: ssh.ProxmoxNode{
 				Address: u.Hostname(),
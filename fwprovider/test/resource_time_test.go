@@ -9,6 +9,7 @@
 package test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -23,6 +24,15 @@ func TestAccResourceTime(t *testing.T) {
 		name  string
 		steps []resource.TestStep
 	}{
+		{"invalid timezone", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`resource "proxmox_virtual_environment_time" "node_time" {
+				  node_name = "{{.NodeName}}"
+				  time_zone = "Europe/Atlantis"
+				}`),
+				ExpectError: regexp.MustCompile(`expected time_zone to be a time zone name`),
+			},
+		}},
 		{"change timezone", []resource.TestStep{
 			{
 				Config: te.RenderConfig(`resource "proxmox_virtual_environment_time" "node_time" {
@@ -39,7 +49,8 @@ func TestAccResourceTime(t *testing.T) {
 				  time_zone = "UTC"
 				}`),
 				Check: ResourceAttributes("proxmox_virtual_environment_time.node_time", map[string]string{
-					"time_zone": "UTC",
+					"time_zone":  "UTC",
+					"utc_offset": "+00:00",
 				}),
 			},
 			{
@@ -48,7 +59,8 @@ func TestAccResourceTime(t *testing.T) {
 				  time_zone = "UTC"
 				}`),
 				Check: ResourceAttributes("proxmox_virtual_environment_time.node_time", map[string]string{
-					"time_zone": "UTC",
+					"time_zone":  "UTC",
+					"utc_offset": "+00:00",
 				}),
 			},
 		}},
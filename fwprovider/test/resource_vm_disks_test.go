@@ -246,6 +246,69 @@ func TestAccResourceVMDisks(t *testing.T) {
 				RefreshState: true,
 			},
 		}},
+		{"disk serial and wwn", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_vm" "test_disk" {
+					node_name = "{{.NodeName}}"
+					started   = false
+					name      = "test-disk-serial"
+
+					disk {
+						datastore_id    = "local-lvm"
+						interface       = "scsi0"
+						size            = 8
+						generate_serial = true
+					}
+					disk {
+						datastore_id = "local-lvm"
+						interface    = "scsi1"
+						size         = 8
+						serial       = "data-1"
+						wwn          = "0x5000c500a1b2c3d4"
+					}
+				}`),
+				Check: ResourceAttributes("proxmox_virtual_environment_vm.test_disk", map[string]string{
+					"disk.0.generate_serial": "true",
+					"disk.0.serial":          "",
+					"disk.1.serial":          "data-1",
+					"disk.1.wwn":             "0x5000c500a1b2c3d4",
+				}),
+			},
+			{
+				RefreshState: true,
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_vm" "test_disk" {
+					node_name = "{{.NodeName}}"
+					started   = false
+					name      = "test-disk-serial"
+
+					disk {
+						datastore_id    = "local-lvm"
+						interface       = "scsi0"
+						size            = 8
+						generate_serial = true
+					}
+					disk {
+						datastore_id = "local-lvm"
+						interface    = "scsi1"
+						size         = 8
+						serial       = "data-2"
+					}
+				}`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("proxmox_virtual_environment_vm.test_disk", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: ResourceAttributes("proxmox_virtual_environment_vm.test_disk", map[string]string{
+					"disk.1.serial": "data-2",
+					"disk.1.wwn":    "",
+				}),
+			},
+		}},
 		{"disk ordering consistency", []resource.TestStep{
 			{
 				Config: te.RenderConfig(`
@@ -149,6 +149,21 @@ func TestAccResourceVM(t *testing.T) {
 				),
 			},
 		}},
+		{"undefined custom cpu.type doesn't fail the plan", []resource.TestStep{
+			{
+				// the warning, which is reported on create, is tested by Test_vmCPUModelWarning
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_vm" "test_cpu_custom" {
+					node_name = "{{.NodeName}}"
+					started   = false
+					cpu {
+						type = "custom-not-defined-yet"
+					}
+				}`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		}},
 		{"set cpu.architecture as non root is not supported", []resource.TestStep{
 			{
 				Config: te.RenderConfig(`
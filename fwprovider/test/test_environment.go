@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"fmt"
 	"net/url"
+	"os"
 	"sync"
 	"testing"
 	"text/template"
@@ -23,6 +24,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test/fakepve"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
@@ -44,6 +46,39 @@ type Environment struct {
 	c                     api.Client
 	CloudImagesServer     string
 	ContainerImagesServer string
+
+	// Fake is the fake Proxmox VE API server the tests run against when `TF_ACC_FAKE=1` is set, nil otherwise.
+	Fake *fakepve.Server
+}
+
+var (
+	fakeServer     *fakepve.Server
+	fakeServerOnce sync.Once
+)
+
+// startFakeServer starts the fake Proxmox VE API server, shared by the tests of the package as the provider
+// is configured from the environment, and points the provider and the clients of the tests at it.
+// The server lives as long as the test binary.
+func startFakeServer(nodeName string) *fakepve.Server {
+	fakeServerOnce.Do(func() {
+		fakeServer = fakepve.NewServer(nodeName)
+
+		env := map[string]string{
+			"PROXMOX_VE_ENDPOINT":  fakeServer.URL,
+			"PROXMOX_VE_INSECURE":  "true",
+			"PROXMOX_VE_API_TOKEN": fakepve.APIToken,
+			"PROXMOX_VE_USERNAME":  fakepve.Username,
+			"PROXMOX_VE_PASSWORD":  fakepve.Password,
+		}
+
+		for k, v := range env {
+			if err := os.Setenv(k, v); err != nil {
+				panic(err)
+			}
+		}
+	})
+
+	return fakeServer
 }
 
 // RenderConfigOption is a configuration option for rendering the provider configuration.
@@ -153,6 +188,11 @@ func InitEnvironment(t *testing.T) *Environment {
 		containerImagesServer = "http://download.proxmox.com"
 	}
 
+	var fake *fakepve.Server
+	if utils.GetAnyBoolEnv("TF_ACC_FAKE") {
+		fake = startFakeServer(nodeName)
+	}
+
 	return &Environment{
 		t: t,
 		templateVars: map[string]any{
@@ -165,6 +205,7 @@ func InitEnvironment(t *testing.T) *Environment {
 		DatastoreID:           datastoreID,
 		CloudImagesServer:     cloudImagesServer,
 		ContainerImagesServer: containerImagesServer,
+		Fake:                  fake,
 
 		AccProviders: muxProviders(t),
 	}
@@ -0,0 +1,21 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package validators
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// CalendarEventValidator validates a PVE calendar event, e.g. the schedule of a job.
+func CalendarEventValidator() validator.String {
+	return NewParseValidator(
+		proxmoxtypes.ParseCalendarEvent,
+		"value must be a valid calendar event, e.g. `daily`, `sat 02:00` or `mon..fri 21:30`",
+	)
+}
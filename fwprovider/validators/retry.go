@@ -0,0 +1,22 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package validators
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// RetryBackoffValidator validates the delay between the retries of a request, accepting the same values
+// as the client does.
+func RetryBackoffValidator() validator.String {
+	return NewParseValidator(
+		api.ParseRetryBackoff,
+		"value must be a positive duration, e.g. `500ms` or `2s`",
+	)
+}
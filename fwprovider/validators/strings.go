@@ -37,3 +37,25 @@ func NonEmptyString() validator.String {
 		stringvalidator.RegexMatches(regexp.MustCompile(`\S$|^$`), "must not end with whitespace"),
 	)
 }
+
+// TagNameValidator returns a new validator to ensure a string is a valid guest tag.
+func TagNameValidator() validator.String {
+	return stringvalidator.RegexMatches(
+		regexp.MustCompile(`^(?i)[a-z0-9_][a-z0-9_\-+.]*$`),
+		"must be a valid tag",
+	)
+}
+
+// TagColorValidator returns a new validator to ensure a string is a tag color, i.e. a hex RGB background color,
+// optionally followed by `:` and a hex RGB text color.
+func TagColorValidator() validator.String {
+	return stringvalidator.RegexMatches(
+		regexp.MustCompile(`^[0-9a-fA-F]{6}(:[0-9a-fA-F]{6})?$`),
+		"must be a hex RGB color, optionally followed by ':' and a hex RGB text color",
+	)
+}
+
+// RegexValidator returns a new validator to ensure a string is a valid regular expression.
+func RegexValidator() validator.String {
+	return NewParseValidator(regexp.Compile, "must be a valid regular expression")
+}
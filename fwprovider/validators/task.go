@@ -0,0 +1,22 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package validators
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// TaskPollIntervalValidator validates the interval of the polls of a task status, accepting the same values
+// as the client does.
+func TaskPollIntervalValidator() validator.String {
+	return NewParseValidator(
+		api.ParseTaskPollInterval,
+		"value must be a duration of at least `100ms`, e.g. `500ms` or `2s`",
+	)
+}
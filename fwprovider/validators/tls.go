@@ -0,0 +1,23 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package validators
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// TLSVersionValidator validates a minimal TLS version, accepting the same values as the client does.
+// The deprecated versions are only warned about by the SDK provider, as the muxed providers validate
+// the same provider configuration and the warning would be reported twice.
+func TLSVersionValidator() validator.String {
+	return NewParseValidator(
+		api.GetMinTLSVersion,
+		"value must be a supported TLS version: `1.0`, `1.1`, `1.2` or `1.3`",
+	)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/provider"
 )
 
@@ -39,7 +40,14 @@ import (
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_acme_plugins.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_apt_repository.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_apt_standard_repository.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_cluster_join_info.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_datastores.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_node_cpu_models.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_node_disks.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_node_pci_devices.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_node_status.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_node_usb_devices.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_firewall_log.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_hagroup.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_hagroups.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_hardware_mapping_dir.md ./docs/data-sources/
@@ -48,35 +56,65 @@ import (
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_hardware_mappings.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_haresource.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_haresources.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_remote_file.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_sdn_zones.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_sdn_zone_simple.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_sdn_zone_vlan.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_sdn_zone_qinq.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_sdn_zone_vxlan.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_sdn_zone_evpn.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_task.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_version.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_vm2.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_vmid.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_metrics_server.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_acl.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_acme_account.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_acme_dns_plugin.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_apt_repository.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_apt_standard_repository.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_backup_job.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_cluster_node_join.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_cluster_options.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_download_file.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_group_membership.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_hagroup.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_hardware_mapping_dir.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_hardware_mapping_pci.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_hardware_mapping_usb.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_haresource.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_network_linux_bond.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_network_linux_bridge.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_node_config.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_node_lvm_thinpool.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_node_zfs_pool.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_network_linux_vlan.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_network_ovs_bond.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_network_ovs_bridge.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_network_ovs_intport.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_notification_endpoint_gotify.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_notification_endpoint_smtp.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_notification_endpoint_webhook.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_notification_matcher.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_realm_ad.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_realm_ldap.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_realm_openid.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_replication_job.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_sdn_zone_simple.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_sdn_zone_vlan.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_sdn_zone_qinq.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_sdn_zone_vxlan.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_sdn_zone_evpn.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_sdn_vnet.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_sdn_subnet.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_storage_cifs.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_storage_directory.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_storage_nfs.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_storage_pbs.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_tags.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_user_token.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_vm_agent_exec.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_vm_agent_file.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_vm2.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_metrics_server.md ./docs/resources/
 
@@ -131,6 +169,10 @@ func main() {
 		muxServer.ProviderServer,
 		serveOpts...,
 	)
+
+	// the SSH connections are pooled for the lifetime of the provider
+	ssh.ClosePools()
+
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,32 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// GetPermissions retrieves the privileges of the current user on the given path,
+// as a map of paths to the privileges granted on them.
+func (c *Client) GetPermissions(ctx context.Context, path string) (map[string]map[string]int, error) {
+	resBody := &PermissionsGetResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("permissions"), &PermissionsGetRequestBody{Path: &path}, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permissions on path %q: %w", path, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
@@ -0,0 +1,17 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+// PermissionsGetRequestBody contains the body for a permissions request.
+type PermissionsGetRequestBody struct {
+	Path *string `url:"path,omitempty"`
+}
+
+// PermissionsGetResponseBody contains the body from a permissions response.
+type PermissionsGetResponseBody struct {
+	Data map[string]map[string]int `json:"data,omitempty"`
+}
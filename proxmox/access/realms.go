@@ -0,0 +1,115 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/tasks"
+)
+
+func (c *Client) realmsPath() string {
+	return c.ExpandPath("domains")
+}
+
+func (c *Client) realmPath(id string) string {
+	return fmt.Sprintf("%s/%s", c.realmsPath(), url.PathEscape(id))
+}
+
+// CreateRealm creates an authentication realm.
+func (c *Client) CreateRealm(ctx context.Context, d *RealmCreateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.realmsPath(), d, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create authentication realm: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRealm deletes an authentication realm.
+func (c *Client) DeleteRealm(ctx context.Context, id string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.realmPath(id), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete authentication realm: %w", err)
+	}
+
+	return nil
+}
+
+// GetRealm retrieves an authentication realm.
+func (c *Client) GetRealm(ctx context.Context, id string) (*RealmGetResponseData, error) {
+	resBody := &RealmGetResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.realmPath(id), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authentication realm: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// ListRealms retrieves a list of authentication realms.
+func (c *Client) ListRealms(ctx context.Context) ([]*RealmListResponseData, error) {
+	resBody := &RealmListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.realmsPath(), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list authentication realms: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	sort.Slice(resBody.Data, func(i, j int) bool {
+		return resBody.Data[i].ID < resBody.Data[j].ID
+	})
+
+	return resBody.Data, nil
+}
+
+// UpdateRealm updates an authentication realm.
+func (c *Client) UpdateRealm(ctx context.Context, id string, d *RealmUpdateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPut, c.realmPath(id), d, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update authentication realm: %w", err)
+	}
+
+	return nil
+}
+
+// SyncRealm synchronizes users and groups of an authentication realm, and waits for the sync task to complete.
+func (c *Client) SyncRealm(ctx context.Context, id string, d *RealmSyncRequestBody) error {
+	resBody := &RealmSyncResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, fmt.Sprintf("%s/sync", c.realmPath(id)), d, resBody)
+	if err != nil {
+		return fmt.Errorf("failed to sync authentication realm: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return api.ErrNoDataObjectInResponse
+	}
+
+	tc := &tasks.Client{Client: c.Client}
+
+	err = tc.WaitForTask(ctx, *resBody.Data)
+	if err != nil {
+		return fmt.Errorf("failed to wait for authentication realm sync: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+const (
+	// RealmTypeAD is the type of Active Directory realms.
+	RealmTypeAD = "ad"
+	// RealmTypeLDAP is the type of LDAP realms.
+	RealmTypeLDAP = "ldap"
+	// RealmTypeOpenID is the type of OpenID Connect realms.
+	RealmTypeOpenID = "openid"
+)
+
+// RealmData contains the attributes of an authentication realm.
+type RealmData struct {
+	Comment *string           `json:"comment,omitempty" url:"comment,omitempty"`
+	Default *types.CustomBool `json:"default,omitempty" url:"default,omitempty,int"`
+	TFA     *string           `json:"tfa,omitempty"     url:"tfa,omitempty"`
+
+	// LDAP and Active Directory.
+	BaseDN              *string           `json:"base_dn,omitempty"               url:"base_dn,omitempty"`
+	BindDN              *string           `json:"bind_dn,omitempty"               url:"bind_dn,omitempty"`
+	CAPath              *string           `json:"capath,omitempty"                url:"capath,omitempty"`
+	CaseSensitive       *types.CustomBool `json:"case-sensitive,omitempty"        url:"case-sensitive,omitempty,int"`
+	Cert                *string           `json:"cert,omitempty"                  url:"cert,omitempty"`
+	CertKey             *string           `json:"certkey,omitempty"               url:"certkey,omitempty"`
+	Domain              *string           `json:"domain,omitempty"                url:"domain,omitempty"`
+	Filter              *string           `json:"filter,omitempty"                url:"filter,omitempty"`
+	GroupClasses        *string           `json:"group_classes,omitempty"         url:"group_classes,omitempty"`
+	GroupDN             *string           `json:"group_dn,omitempty"              url:"group_dn,omitempty"`
+	GroupFilter         *string           `json:"group_filter,omitempty"          url:"group_filter,omitempty"`
+	GroupNameAttr       *string           `json:"group_name_attr,omitempty"       url:"group_name_attr,omitempty"`
+	Mode                *string           `json:"mode,omitempty"                  url:"mode,omitempty"`
+	Port                *int64            `json:"port,omitempty"                  url:"port,omitempty"`
+	Server1             *string           `json:"server1,omitempty"               url:"server1,omitempty"`
+	Server2             *string           `json:"server2,omitempty"               url:"server2,omitempty"`
+	SSLVersion          *string           `json:"sslversion,omitempty"            url:"sslversion,omitempty"`
+	SyncAttributes      *string           `json:"sync_attributes,omitempty"       url:"sync_attributes,omitempty"`
+	SyncDefaultsOptions *string           `json:"sync-defaults-options,omitempty" url:"sync-defaults-options,omitempty"`
+	UserAttr            *string           `json:"user_attr,omitempty"             url:"user_attr,omitempty"`
+	UserClasses         *string           `json:"user_classes,omitempty"          url:"user_classes,omitempty"`
+	Verify              *types.CustomBool `json:"verify,omitempty"                url:"verify,omitempty,int"`
+
+	// OpenID Connect.
+	ACRValues        *string           `json:"acr-values,omitempty"        url:"acr-values,omitempty"`
+	Autocreate       *types.CustomBool `json:"autocreate,omitempty"        url:"autocreate,omitempty,int"`
+	ClientID         *string           `json:"client-id,omitempty"         url:"client-id,omitempty"`
+	GroupsAutocreate *types.CustomBool `json:"groups-autocreate,omitempty" url:"groups-autocreate,omitempty,int"`
+	GroupsClaim      *string           `json:"groups-claim,omitempty"      url:"groups-claim,omitempty"`
+	GroupsOverwrite  *types.CustomBool `json:"groups-overwrite,omitempty"  url:"groups-overwrite,omitempty,int"`
+	IssuerURL        *string           `json:"issuer-url,omitempty"        url:"issuer-url,omitempty"`
+	Prompt           *string           `json:"prompt,omitempty"            url:"prompt,omitempty"`
+	QueryUserinfo    *types.CustomBool `json:"query-userinfo,omitempty"    url:"query-userinfo,omitempty,int"`
+	Scopes           *string           `json:"scopes,omitempty"            url:"scopes,omitempty"`
+	UsernameClaim    *string           `json:"username-claim,omitempty"    url:"username-claim,omitempty"`
+}
+
+// RealmCreateRequestBody contains the data for a realm create request.
+type RealmCreateRequestBody struct {
+	RealmData
+
+	ID   string `url:"realm"`
+	Type string `url:"type"`
+
+	// Secrets are write-only and never returned by the API.
+	ClientKey *string `url:"client-key,omitempty"`
+	Password  *string `url:"password,omitempty"`
+}
+
+// RealmUpdateRequestBody contains the data for a realm update request.
+type RealmUpdateRequestBody struct {
+	RealmData
+
+	ClientKey *string `url:"client-key,omitempty"`
+	Password  *string `url:"password,omitempty"`
+
+	Delete *string `url:"delete,omitempty"`
+}
+
+// RealmGetResponseBody contains the body from a realm get response.
+type RealmGetResponseBody struct {
+	Data *RealmGetResponseData `json:"data,omitempty"`
+}
+
+// RealmGetResponseData contains the data from a realm get response.
+type RealmGetResponseData struct {
+	RealmData
+
+	Type string `json:"type"`
+}
+
+// RealmListResponseBody contains the body from a realm list response.
+type RealmListResponseBody struct {
+	Data []*RealmListResponseData `json:"data,omitempty"`
+}
+
+// RealmListResponseData contains the data from a realm list response.
+type RealmListResponseData struct {
+	Comment *string `json:"comment,omitempty"`
+	ID      string  `json:"realm"`
+	Type    string  `json:"type"`
+}
+
+// RealmSyncRequestBody contains the data for a realm sync request.
+type RealmSyncRequestBody struct {
+	DryRun         *types.CustomBool `url:"dry-run,omitempty,int"`
+	EnableNew      *types.CustomBool `url:"enable-new,omitempty,int"`
+	RemoveVanished *string           `url:"remove-vanished,omitempty"`
+	Scope          *string           `url:"scope,omitempty"`
+}
+
+// RealmSyncResponseBody contains the body from a realm sync response.
+type RealmSyncResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
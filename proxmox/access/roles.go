@@ -89,6 +89,36 @@ func (c *Client) ListRoles(ctx context.Context) ([]*RoleListResponseData, error)
 	return resBody.Data, nil
 }
 
+// ListPrivileges retrieves the privileges known to the server. PVE doesn't expose the list
+// directly, but the built-in roles grant all of them, e.g. the `Administrator` role.
+func (c *Client) ListPrivileges(ctx context.Context) ([]string, error) {
+	roles, err := c.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	privileges := map[string]struct{}{}
+
+	for _, role := range roles {
+		if role.Special == nil || !bool(*role.Special) || role.Privileges == nil {
+			continue
+		}
+
+		for _, privilege := range *role.Privileges {
+			privileges[privilege] = struct{}{}
+		}
+	}
+
+	list := make([]string, 0, len(privileges))
+	for privilege := range privileges {
+		list = append(list, privilege)
+	}
+
+	sort.Strings(list)
+
+	return list, nil
+}
+
 // UpdateRole updates an access role.
 func (c *Client) UpdateRole(ctx context.Context, id string, d *RoleUpdateRequestBody) error {
 	err := c.DoRequest(ctx, http.MethodPut, c.rolePath(id), d, nil)
@@ -8,9 +8,11 @@ package access
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 )
@@ -56,6 +58,10 @@ func (c *Client) GetUserToken(ctx context.Context, userid string, id string) (*U
 
 	err := c.DoRequest(ctx, http.MethodGet, c.userTokenPath(userid, id), nil, resBody)
 	if err != nil {
+		if userTokenMissing(err) {
+			err = errors.Join(api.ErrResourceDoesNotExist, err)
+		}
+
 		return nil, fmt.Errorf("error retrieving user token: %w", err)
 	}
 
@@ -66,6 +72,21 @@ func (c *Client) GetUserToken(ctx context.Context, userid string, id string) (*U
 	return resBody.Data, nil
 }
 
+// userTokenMissing reports whether the error is the HTTP 500 which PVE returns for a token, or the user owning it,
+// that does not exist ("no such token 'x' for user 'y'", "no such user ('y')").
+func userTokenMissing(err error) bool {
+	if errors.Is(err, api.ErrResourceDoesNotExist) {
+		return false
+	}
+
+	var httpErr *api.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusInternalServerError {
+		return false
+	}
+
+	return strings.Contains(httpErr.Message, "no such token") || strings.Contains(httpErr.Message, "no such user")
+}
+
 // ListUserTokens retrieves a list of user tokens.
 func (c *Client) ListUserTokens(ctx context.Context, userid string) ([]*UserTokenListResponseData, error) {
 	resBody := &UserTokenListResponseBody{}
@@ -0,0 +1,76 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+type errorClient struct {
+	api.Client
+
+	err error
+}
+
+func (c errorClient) DoRequest(context.Context, string, string, interface{}, interface{}) error {
+	return c.err
+}
+
+func TestGetUserTokenMissing(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		err     error
+		missing bool
+	}{
+		{
+			"not found",
+			errors.Join(api.ErrResourceDoesNotExist, &api.HTTPError{Code: http.StatusNotFound, Message: "Not Found"}),
+			true,
+		},
+		{
+			"no such token",
+			&api.HTTPError{Code: http.StatusInternalServerError, Message: "no such token 'test' for user 'user@pve'"},
+			true,
+		},
+		{
+			"no such user",
+			&api.HTTPError{Code: http.StatusInternalServerError, Message: "no such user ('user@pve')"},
+			true,
+		},
+		{
+			"other server error",
+			&api.HTTPError{Code: http.StatusInternalServerError, Message: "unable to open file"},
+			false,
+		},
+		{
+			"permission denied",
+			&api.HTTPError{Code: http.StatusForbidden, Message: "Permission check failed"},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &Client{Client: errorClient{err: tt.err}}
+
+			_, err := c.GetUserToken(context.Background(), "user@pve", "test")
+			require.Error(t, err)
+			require.Equal(t, tt.missing, errors.Is(err, api.ErrResourceDoesNotExist))
+		})
+	}
+}
@@ -11,9 +11,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"sort"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
 )
 
 func (c *Client) usersPath() string {
@@ -24,6 +26,21 @@ func (c *Client) userPath(id string) string {
 	return fmt.Sprintf("%s/%s", c.usersPath(), url.PathEscape(id))
 }
 
+// AddUserToGroup adds a user to a group, keeping the user's other group memberships.
+func (c *Client) AddUserToGroup(ctx context.Context, userID string, groupID string) error {
+	d := &UserUpdateRequestBody{
+		Append: types.CustomBool(true).Pointer(),
+		Groups: &[]string{groupID},
+	}
+
+	err := c.DoRequest(ctx, http.MethodPut, c.userPath(userID), d, nil)
+	if err != nil {
+		return fmt.Errorf("error adding user %s to group %s: %w", userID, groupID, err)
+	}
+
+	return nil
+}
+
 // ChangeUserPassword changes a user's password.
 func (c *Client) ChangeUserPassword(ctx context.Context, id, password string) error {
 	d := UserChangePasswordRequestBody{
@@ -105,6 +122,38 @@ func (c *Client) ListUsers(ctx context.Context) ([]*UserListResponseData, error)
 	return resBody.Data, nil
 }
 
+// RemoveUserFromGroup removes a user from a group, keeping the user's other group memberships.
+// The API has no way to remove a single group, so the remaining groups are sent as the new list.
+func (c *Client) RemoveUserFromGroup(ctx context.Context, userID string, groupID string) error {
+	user, err := c.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Groups == nil || !slices.Contains(*user.Groups, groupID) {
+		return nil
+	}
+
+	groups := []string{}
+
+	for _, g := range *user.Groups {
+		if g != groupID {
+			groups = append(groups, g)
+		}
+	}
+
+	d := &UserUpdateRequestBody{
+		Groups: &groups,
+	}
+
+	err = c.DoRequest(ctx, http.MethodPut, c.userPath(userID), d, nil)
+	if err != nil {
+		return fmt.Errorf("error removing user %s from group %s: %w", userID, groupID, err)
+	}
+
+	return nil
+}
+
 // UpdateUser updates a user.
 func (c *Client) UpdateUser(ctx context.Context, id string, d *UserUpdateRequestBody) error {
 	err := c.DoRequest(ctx, http.MethodPut, c.userPath(id), d, nil)
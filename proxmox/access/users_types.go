@@ -73,7 +73,7 @@ type UserUpdateRequestBody struct {
 	Enabled        *types.CustomBool `json:"enable,omitempty"    url:"enable,omitempty,int"`
 	ExpirationDate *int64            `json:"expire,omitempty"    url:"expire,omitempty,int"`
 	FirstName      *string           `json:"firstname,omitempty" url:"firstname,omitempty"`
-	Groups         []string          `json:"groups,omitempty"    url:"groups,omitempty,comma"`
+	Groups         *[]string         `json:"groups,omitempty"    url:"groups,omitempty,comma"`
 	Keys           *string           `json:"keys,omitempty"      url:"keys,omitempty"`
 	LastName       *string           `json:"lastname,omitempty"  url:"lastname,omitempty"`
 }
@@ -25,3 +25,10 @@ type Authenticator interface {
 	// AuthenticateRequest adds authentication data to a new request.
 	AuthenticateRequest(ctx context.Context, req *http.Request) error
 }
+
+// expiringAuthenticator is implemented by the authenticators whose credentials can't be renewed,
+// so that the client stops sending requests once the server rejects them.
+type expiringAuthenticator interface {
+	// Expire marks the credentials as expired.
+	Expire()
+}
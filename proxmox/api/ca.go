@@ -0,0 +1,84 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ConnectionOption is an option to customize the TLS settings of a Connection.
+type ConnectionOption func(*tls.Config)
+
+// WithRootCAs is an option to verify the certificates of the endpoints against the given CA certificates, e.g. for
+// a cluster using an internal CA. The certificates are verified even if the connection is insecure.
+func WithRootCAs(pool *x509.CertPool) ConnectionOption {
+	return func(c *tls.Config) {
+		if pool == nil {
+			return
+		}
+
+		c.RootCAs = pool
+		c.InsecureSkipVerify = false
+	}
+}
+
+// LoadCACertificates parses the PEM encoded CA certificates, given either as the content of a PEM file, or as the
+// path to the file, and adds them to the CAs of the system. The system CAs are kept, as the HTTP client of the
+// connection is also used for third-party URLs, e.g. the source files of the file resources.
+func LoadCACertificates(caCertificate string) (*x509.CertPool, error) {
+	data := []byte(caCertificate)
+
+	if !strings.Contains(caCertificate, "-----BEGIN") {
+		var err error
+
+		data, err = os.ReadFile(strings.TrimSpace(caCertificate))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA certificate file: %w", err)
+		}
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	count := 0
+
+	for {
+		var block *pem.Block
+
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the CA certificate: %w", err)
+		}
+
+		pool.AddCert(cert)
+
+		count++
+	}
+
+	if count == 0 {
+		return nil, errors.New("failed to parse the CA certificate: no PEM encoded certificate found")
+	}
+
+	return pool, nil
+}
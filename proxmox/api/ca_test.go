@@ -0,0 +1,85 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCACertificates(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte(caPEM), 0o600))
+
+	for name, caCertificate := range map[string]string{"content": caPEM, "file": caFile} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			pool, err := LoadCACertificates(caCertificate)
+			require.NoError(t, err)
+
+			// the system CAs are kept, for the third-party URLs fetched with the same client
+			if system, err := x509.SystemCertPool(); err == nil {
+				system.AddCert(server.Certificate())
+				assert.True(t, system.Equal(pool))
+			}
+
+			// the CA wins over insecure, so the server certificate is verified
+			conn, err := NewConnection(server.URL, true, "", WithRootCAs(pool))
+			require.NoError(t, err)
+
+			res, err := conn.httpClient.Get(server.URL)
+			require.NoError(t, err)
+
+			_ = res.Body.Close()
+
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+		})
+	}
+
+	t.Run("untrusted", func(t *testing.T) {
+		t.Parallel()
+
+		// the server certificate is not signed by any of the CAs, so the request fails even if insecure
+		conn, err := NewConnection(server.URL, true, "", WithRootCAs(x509.NewCertPool()))
+		require.NoError(t, err)
+
+		res, err := conn.httpClient.Get(server.URL) //nolint:bodyclose
+		require.ErrorContains(t, err, "certificate")
+		assert.Nil(t, res)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := LoadCACertificates("-----BEGIN CERTIFICATE-----\nbm90IGEgY2VydGlmaWNhdGU=\n-----END CERTIFICATE-----\n")
+		require.ErrorContains(t, err, "failed to parse the CA certificate")
+
+		_, err = LoadCACertificates("-----BEGIN garbage")
+		require.ErrorContains(t, err, "no PEM encoded certificate found")
+
+		_, err = LoadCACertificates(filepath.Join(t.TempDir(), "missing.pem"))
+		require.ErrorContains(t, err, "failed to read the CA certificate file")
+	})
+}
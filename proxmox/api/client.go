@@ -9,7 +9,10 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,6 +23,8 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/avast/retry-go/v4"
 	"github.com/google/go-querystring/query"
@@ -57,27 +62,63 @@ type Client interface {
 
 	// HTTP returns a lower-level HTTP client.
 	HTTP() *http.Client
+
+	// TaskPolling returns how the tasks are polled while waiting for them to complete.
+	TaskPolling() TaskPolling
 }
 
-// Connection represents a connection to the Proxmox Virtual Environment API.
+// Connection represents a connection to the Proxmox Virtual Environment API. The connection may
+// have the endpoints of several nodes of a cluster, which are used in turn when one can't be reached.
 type Connection struct {
-	endpoint   string
+	endpoints  []string
+	current    int
 	httpClient *http.Client
+
+	mu sync.RWMutex
 }
 
 // NewConnection creates and initializes a Connection instance.
-func NewConnection(endpoint string, insecure bool, minTLS string) (*Connection, error) {
-	u, err := url.ParseRequestURI(endpoint)
+func NewConnection(endpoint string, insecure bool, minTLS string, opts ...ConnectionOption) (*Connection, error) {
+	version, err := GetMinTLSVersion(minTLS)
 	if err != nil {
-		return nil, errors.New(
-			"you must specify a valid endpoint for the Proxmox Virtual Environment API (valid: https://host:port/)",
-		)
+		return nil, err
 	}
 
-	if u.Scheme != "https" {
-		return nil, errors.New(
-			"you must specify a secure endpoint for the Proxmox Virtual Environment API (valid: https://host:port/)",
-		)
+	tlsConfig := &tls.Config{
+		// deepcode ignore InsecureTLSConfig: the min TLS version is configurable
+		MinVersion:         version,
+		InsecureSkipVerify: insecure, //nolint:gosec
+	}
+
+	for _, opt := range opts {
+		opt(tlsConfig)
+	}
+
+	return newConnection([]string{endpoint}, tlsConfig)
+}
+
+// NewFailoverConnection creates a Connection instance for the endpoints of several nodes of a cluster,
+// which share the TLS settings. The first reachable endpoint is used, until it can't be reached anymore.
+// The empty and the duplicate endpoints are ignored.
+func NewFailoverConnection(
+	ctx context.Context,
+	endpoints []string,
+	insecure bool,
+	minTLS string,
+	opts ...ConnectionOption,
+) (*Connection, error) {
+	var unique []string
+
+	for _, endpoint := range endpoints {
+		if endpoint != "" && !slices.Contains(unique, endpoint) {
+			unique = append(unique, endpoint)
+		}
+	}
+
+	endpoints = unique
+
+	if len(endpoints) == 0 {
+		return nil, errors.New("you must specify at least one endpoint for the Proxmox Virtual Environment API")
 	}
 
 	version, err := GetMinTLSVersion(minTLS)
@@ -85,24 +126,87 @@ func NewConnection(endpoint string, insecure bool, minTLS string) (*Connection,
 		return nil, err
 	}
 
-	var transport http.RoundTripper = &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		TLSClientConfig: &tls.Config{
-			// deepcode ignore InsecureTLSConfig: the min TLS version is configurable
-			MinVersion:         version,
-			InsecureSkipVerify: insecure, //nolint:gosec
+	tlsConfig := &tls.Config{
+		// deepcode ignore InsecureTLSConfig: the min TLS version is configurable
+		MinVersion:         version,
+		InsecureSkipVerify: insecure, //nolint:gosec
+	}
+
+	for _, opt := range opts {
+		opt(tlsConfig)
+	}
+
+	conn, err := newConnection(endpoints, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(conn.endpoints) > 1 {
+		conn.selectEndpoint(ctx)
+	}
+
+	return conn, nil
+}
+
+// NewPinnedConnection creates a Connection instance that only trusts the server certificate with the given
+// SHA-256 fingerprint, in the "AA:BB:..." format shown by PVE. It's used to connect to the other nodes of a
+// cluster, whose certificates are usually signed by the cluster's own CA.
+func NewPinnedConnection(endpoint string, fingerprint string) (*Connection, error) {
+	expected := strings.ToUpper(strings.ReplaceAll(fingerprint, ":", ""))
+
+	return newConnection([]string{endpoint}, &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// the chain is not verified, the certificate is matched against the fingerprint instead
+		InsecureSkipVerify: true, //nolint:gosec
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("the server did not present a certificate")
+			}
+
+			sum := sha256.Sum256(rawCerts[0])
+			if strings.ToUpper(hex.EncodeToString(sum[:])) != expected {
+				return fmt.Errorf("the server certificate does not match the fingerprint %q", fingerprint)
+			}
+
+			return nil
 		},
+	})
+}
+
+func newConnection(endpoints []string, tlsConfig *tls.Config) (*Connection, error) {
+	parsed := make([]string, 0, len(endpoints))
+
+	for _, endpoint := range endpoints {
+		u, err := url.ParseRequestURI(endpoint)
+		if err != nil {
+			return nil, errors.New(
+				"you must specify a valid endpoint for the Proxmox Virtual Environment API (valid: https://host:port/)",
+			)
+		}
+
+		if u.Scheme != "https" {
+			return nil, errors.New(
+				"you must specify a secure endpoint for the Proxmox Virtual Environment API (valid: https://host:port/)",
+			)
+		}
+
+		// make sure the path does not contain "/api2/json"
+		u.Path = ""
+
+		parsed = append(parsed, strings.TrimRight(u.String(), "/"))
+	}
+
+	var transport http.RoundTripper = &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
 	}
 
 	if logging.IsDebugOrHigher() {
 		transport = logging.NewLoggingHTTPTransport(transport)
 	}
 
-	// make sure the path does not contain "/api2/json"
-	u.Path = ""
-
 	return &Connection{
-		endpoint: strings.TrimRight(u.String(), "/"),
+		endpoints: parsed,
 		httpClient: &http.Client{
 			Transport: transport,
 		},
@@ -111,12 +215,46 @@ func NewConnection(endpoint string, insecure bool, minTLS string) (*Connection,
 
 // VirtualEnvironmentClient implements an API client for the Proxmox Virtual Environment API.
 type client struct {
-	conn *Connection
-	auth Authenticator
+	conn        *Connection
+	nodeConns   map[string]*Connection
+	nodeConnsMu sync.RWMutex
+	auth        Authenticator
+	taskPolling TaskPolling
+	retryPolicy RetryPolicy
+	limiter     *requestLimiter
+
+	debugLogging bool
+}
+
+// ClientOption is an option for creating a client.
+type ClientOption func(c *client)
+
+// WithMaxParallelism is an option to limit the number of the API requests sent concurrently, and the number
+// of the heavy ones, e.g. clones, migrations and backups, differently than DefaultMaxParallelism
+// and DefaultMaxHeavyParallelism.
+func WithMaxParallelism(maxRequests, maxHeavyRequests int) ClientOption {
+	return func(c *client) {
+		c.limiter = newRequestLimiter(maxRequests, maxHeavyRequests)
+	}
+}
+
+// WithRetryPolicy is an option to retry the requests failing with a transient server error differently
+// than DefaultRetryPolicy.
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *client) {
+		c.retryPolicy = p
+	}
+}
+
+// WithTaskPolling is an option to poll the tasks differently than DefaultTaskPolling.
+func WithTaskPolling(p TaskPolling) ClientOption {
+	return func(c *client) {
+		c.taskPolling = p
+	}
 }
 
 // NewClient creates and initializes a VirtualEnvironmentClient instance.
-func NewClient(creds Credentials, conn *Connection) (Client, error) {
+func NewClient(creds Credentials, conn *Connection, opts ...ClientOption) (Client, error) {
 	if conn == nil {
 		return nil, errors.New("connection must not be nil")
 	}
@@ -140,10 +278,19 @@ func NewClient(creds Credentials, conn *Connection) (Client, error) {
 		return nil, fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	return &client{
-		conn: conn,
-		auth: auth,
-	}, nil
+	c := &client{
+		conn:        conn,
+		auth:        auth,
+		taskPolling: DefaultTaskPolling,
+		retryPolicy: DefaultRetryPolicy,
+		limiter:     newRequestLimiter(DefaultMaxParallelism, DefaultMaxHeavyParallelism),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
 }
 
 // DoRequest performs a HTTP request against a JSON API endpoint.
@@ -158,6 +305,7 @@ func (c *client) DoRequest(
 
 	modifiedPath := path
 	reqBodyType := ""
+	logBody := ""
 
 	//nolint:nestif
 	if requestBody != nil {
@@ -192,6 +340,7 @@ func (c *client) DoRequest(
 				} else {
 					reqBodyReader = bytes.NewBufferString(encodedValues)
 					reqBodyType = "application/x-www-form-urlencoded"
+					logBody = encodedValues
 				}
 			}
 		}
@@ -199,10 +348,21 @@ func (c *client) DoRequest(
 		reqBodyReader = new(bytes.Buffer)
 	}
 
+	// the slot is held during the retries, so that an overloaded node is not sent more requests meanwhile
+	release, err := c.limiter.acquire(ctx, method, path)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	conn := c.connectionFor(path)
+	endpoint, endpointURL := conn.currentEndpoint()
+
 	req, err := http.NewRequestWithContext(
 		ctx,
 		method,
-		fmt.Sprintf("%s/%s/%s", c.conn.endpoint, basePathJSONAPI, modifiedPath),
+		fmt.Sprintf("%s/%s/%s", endpointURL, basePathJSONAPI, modifiedPath),
 		reqBodyReader,
 	)
 	if err != nil {
@@ -233,48 +393,73 @@ func (c *client) DoRequest(
 		)
 	}
 
+	var reqLog *requestLog
+	if c.debugLogging {
+		reqLog = newRequestLog(ctx, method, modifiedPath, logBody)
+	}
+
 	//nolint:bodyclose
-	res, err := retry.DoWithData(
-		func() (*http.Response, error) {
-			return c.conn.httpClient.Do(req)
-		},
-		retry.Context(ctx),
-		retry.RetryIf(func(err error) bool {
-			var urlErr *url.Error
-			if errors.As(err, &urlErr) {
-				return strings.ToUpper(urlErr.Op) == http.MethodGet
-			}
+	res, err := c.doWithRetries(ctx, req, path, func(r *http.Request) (*http.Response, error) {
+		//nolint:bodyclose
+		res, err := retry.DoWithData(
+			func() (*http.Response, error) {
+				return c.doThrough(r, conn, endpoint)
+			},
+			retry.Context(ctx),
+			retry.RetryIf(func(err error) bool {
+				var urlErr *url.Error
+				if errors.As(err, &urlErr) {
+					return strings.ToUpper(urlErr.Op) == http.MethodGet
+				}
 
-			return false
-		}),
-		retry.LastErrorOnly(true),
-		retry.Attempts(3),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to perform HTTP %s request (path: %s) - Reason: %w",
-			method,
-			modifiedPath,
-			err,
+				return false
+			}),
+			retry.LastErrorOnly(true),
+			retry.Attempts(3),
 		)
-	}
+		if err != nil {
+			reqLog.attempt(ctx, r, nil, err)
 
-	defer utils.CloseOrLogError(ctx)(res.Body)
+			return nil, fmt.Errorf("failed to perform HTTP %s request (path: %s) - Reason: %w",
+				method,
+				modifiedPath,
+				err,
+			)
+		}
+
+		err = validateResponseCode(res)
+		reqLog.attempt(ctx, r, res, err)
+
+		if err != nil {
+			utils.CloseOrLogError(ctx)(res.Body)
+
+			if auth, ok := c.auth.(expiringAuthenticator); ok && res.StatusCode == http.StatusUnauthorized {
+				auth.Expire()
+
+				return nil, errors.Join(ErrTicketExpired, err)
+			}
 
-	err = validateResponseCode(res)
+			return nil, err
+		}
+
+		return res, nil
+	})
 	if err != nil {
-		return err
+		return reqLog.wrap(err)
 	}
 
+	defer utils.CloseOrLogError(ctx)(res.Body)
+
 	//nolint:nestif
 	if responseBody != nil {
 		err = json.NewDecoder(res.Body).Decode(responseBody)
 		if err != nil {
-			return fmt.Errorf(
+			return reqLog.wrap(fmt.Errorf(
 				"failed to decode HTTP %s response (path: %s) - Reason: %w",
 				method,
 				modifiedPath,
 				err,
-			)
+			))
 		}
 	} else {
 		data, err := io.ReadAll(res.Body)
@@ -326,6 +511,10 @@ func (c *client) HTTP() *http.Client {
 	return c.conn.httpClient
 }
 
+func (c *client) TaskPolling() TaskPolling {
+	return c.taskPolling
+}
+
 // validateResponseCode ensures that a response is valid.
 func validateResponseCode(res *http.Response) error {
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
@@ -393,3 +582,49 @@ func GetMinTLSVersion(version string) (uint16, error) {
 
 	return 0, fmt.Errorf("unsupported minimal TLS version %s, must be one of: %s", version, strings.Join(valid, ", "))
 }
+
+const (
+	// minTaskPollInterval prevents a configuration from hammering the API with the polls of a task status.
+	minTaskPollInterval = 100 * time.Millisecond
+
+	// MaxTaskPollBackoff is the maximum factor by which the delay between the polls of a task status grows.
+	MaxTaskPollBackoff = 10
+)
+
+// ParseTaskPollInterval parses the interval of the polls of a task status, e.g. `500ms` or `2s`.
+// An empty interval is the interval of DefaultTaskPolling.
+func ParseTaskPollInterval(interval string) (time.Duration, error) {
+	if strings.TrimSpace(interval) == "" {
+		return DefaultTaskPolling.Interval, nil
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(interval))
+	if err != nil {
+		return 0, fmt.Errorf("invalid task poll interval %q: %w", interval, err)
+	}
+
+	if d < minTaskPollInterval {
+		return 0, fmt.Errorf("invalid task poll interval %q, must be at least %s", interval, minTaskPollInterval)
+	}
+
+	return d, nil
+}
+
+// NewTaskPolling creates a TaskPolling from the settings of the provider. The empty interval and
+// the zero backoff are the ones of DefaultTaskPolling.
+func NewTaskPolling(interval string, backoff float64) (TaskPolling, error) {
+	d, err := ParseTaskPollInterval(interval)
+	if err != nil {
+		return TaskPolling{}, err
+	}
+
+	if backoff == 0 {
+		backoff = DefaultTaskPolling.Backoff
+	}
+
+	if backoff < 1 || backoff > MaxTaskPollBackoff {
+		return TaskPolling{}, fmt.Errorf("invalid task poll backoff %v, must be between 1 and %d", backoff, MaxTaskPollBackoff)
+	}
+
+	return TaskPolling{Interval: d, Backoff: backoff}, nil
+}
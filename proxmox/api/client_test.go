@@ -8,13 +8,18 @@ package api
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"os"
 	"reflect"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -81,7 +86,7 @@ func TestClientDoRequest(t *testing.T) {
 
 			c := client{
 				conn: &Connection{
-					endpoint: "http://localhost",
+					endpoints: []string{"http://localhost"},
 					httpClient: newTestClient(func(_ *http.Request) *http.Response {
 						sc, err := strconv.Atoi(strings.Fields(tt.status)[0])
 						require.NoError(t, err)
@@ -120,3 +125,181 @@ func TestClientDoRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestClientDoRequestTicketExpired(t *testing.T) {
+	t.Parallel()
+
+	auth, err := NewTicketAuthenticator(TicketCredentials{
+		AuthTicket:          "PVE:root@pam:12345678::payload",
+		CSRFPreventionToken: "12345678:token",
+	})
+	require.NoError(t, err)
+
+	requests := 0
+
+	c := client{
+		conn: &Connection{
+			endpoints: []string{"http://localhost"},
+			httpClient: newTestClient(func(_ *http.Request) *http.Response {
+				requests++
+
+				return &http.Response{
+					Status:     "401 No ticket",
+					StatusCode: http.StatusUnauthorized,
+					Body:       io.NopCloser(strings.NewReader("")),
+				}
+			}),
+		},
+		auth: auth,
+	}
+
+	err = c.DoRequest(t.Context(), "GET", "any", nil, nil)
+	require.ErrorIs(t, err, ErrTicketExpired)
+
+	// the next requests fail without reaching the server
+	err = c.DoRequest(t.Context(), "GET", "any", nil, nil)
+	require.ErrorIs(t, err, ErrTicketExpired)
+	require.Equal(t, 1, requests)
+}
+
+func TestFileUploadRequestReader(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.CreateTemp(t.TempDir(), "upload")
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	_, err = f.WriteString("hello world")
+	require.NoError(t, err)
+
+	req := &FileUploadRequest{File: f, BytesUploaded: 42}
+
+	_, err = f.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(req.Reader())
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(data))
+	require.Equal(t, int64(11), req.BytesUploaded)
+}
+
+func TestNewPinnedConnection(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	sum := sha256.Sum256(server.Certificate().Raw)
+	fingerprint := make([]string, len(sum))
+
+	for i, b := range sum {
+		fingerprint[i] = fmt.Sprintf("%02X", b)
+	}
+
+	tests := []struct {
+		name        string
+		fingerprint string
+		wantErr     bool
+	}{
+		{"matching fingerprint", strings.Join(fingerprint, ":"), false},
+		{"matching lowercase fingerprint", strings.ToLower(strings.Join(fingerprint, ":")), false},
+		{"other fingerprint", strings.Repeat("00:", 31) + "00", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn, err := NewPinnedConnection(server.URL, tt.fingerprint)
+			require.NoError(t, err)
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+			require.NoError(t, err)
+
+			res, err := conn.httpClient.Do(req)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+		})
+	}
+}
+
+func TestParseTaskPollInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		interval string
+		want     time.Duration
+		wantErr  bool
+	}{
+		{"", time.Second, false},
+		{"500ms", 500 * time.Millisecond, false},
+		{" 2s ", 2 * time.Second, false},
+		{"10ms", 0, true},
+		{"-1s", 0, true},
+		{"1", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.interval, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseTaskPollInterval(tt.interval)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewTaskPolling(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewTaskPolling("", 0)
+	require.NoError(t, err)
+	require.Equal(t, DefaultTaskPolling, p)
+
+	p, err = NewTaskPolling("250ms", 1.5)
+	require.NoError(t, err)
+	require.Equal(t, TaskPolling{Interval: 250 * time.Millisecond, Backoff: 1.5}, p)
+
+	_, err = NewTaskPolling("1s", 0.5)
+	require.Error(t, err)
+
+	_, err = NewTaskPolling("1s", 11)
+	require.Error(t, err)
+
+	_, err = NewTaskPolling("1ms", 2)
+	require.Error(t, err)
+}
+
+func TestNewClientTaskPolling(t *testing.T) {
+	t.Parallel()
+
+	conn, err := NewConnection("https://localhost:8006", true, "")
+	require.NoError(t, err)
+
+	creds := Credentials{TokenCredentials: &TokenCredentials{APIToken: "root@pam!test=00000000-0000-0000-0000-000000000000"}}
+
+	c, err := NewClient(creds, conn)
+	require.NoError(t, err)
+	require.Equal(t, DefaultTaskPolling, c.TaskPolling())
+
+	polling := TaskPolling{Interval: 500 * time.Millisecond, Backoff: 1.5}
+
+	c, err = NewClient(creds, conn, WithTaskPolling(polling))
+	require.NoError(t, err)
+	require.Equal(t, polling, c.TaskPolling())
+}
@@ -9,6 +9,7 @@ package api
 import (
 	"io"
 	"os"
+	"time"
 )
 
 // MultiPartData enables multipart uploads in DoRequest.
@@ -25,6 +26,30 @@ type ErrorResponseBody struct {
 	Errors  *map[string]string `json:"errors"`
 }
 
+// DefaultTaskPolling polls the status of a task every second.
+var DefaultTaskPolling = TaskPolling{Interval: time.Second, Backoff: 1}
+
+// TaskPolling configures how the status of a task is polled while waiting for it to complete.
+type TaskPolling struct {
+	// Interval is the delay between the first two polls.
+	Interval time.Duration
+	// Backoff multiplies the delay after each poll, e.g. `2` doubles it. `1` polls at a fixed interval.
+	Backoff float64
+}
+
+// DefaultRetryPolicy retries a request failing with a transient server error 3 times, after 1, 2 and 4 seconds.
+var DefaultRetryPolicy = RetryPolicy{Retries: 3, MinBackoff: time.Second, MaxBackoff: 30 * time.Second}
+
+// RetryPolicy configures how the requests failing with a transient server error are retried.
+type RetryPolicy struct {
+	// Retries is the number of times a request is retried, `0` disables the retries.
+	Retries int
+	// MinBackoff is the delay before the first retry, which doubles with each retry.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between the retries.
+	MaxBackoff time.Duration
+}
+
 // FileUploadRequest is a request for uploading a file.
 type FileUploadRequest struct {
 	ContentType string
@@ -35,4 +60,32 @@ type FileUploadRequest struct {
 	// References:
 	//   1. https://en.wikipedia.org/wiki/Chmod#Special_modes
 	Mode string
+	// MIMEType is the Content-Type of the file part of an API upload, which defaults to `application/octet-stream`.
+	MIMEType string
+	// Staged makes an SSH upload write the file to a temporary file on the node first, and move it into the
+	// datastore once it has been transferred completely, instead of streaming it into the datastore directly.
+	Staged bool
+	// BytesUploaded is set by the upload to the number of bytes read from File and transferred.
+	BytesUploaded int64
+}
+
+// Reader returns a reader of File, which counts the bytes read from it in BytesUploaded.
+// The count is reset, so a retried upload doesn't add up the bytes of the failed attempts.
+func (r *FileUploadRequest) Reader() io.Reader {
+	r.BytesUploaded = 0
+
+	return &countingReader{reader: r.File, count: &r.BytesUploaded}
+}
+
+type countingReader struct {
+	reader io.Reader
+	count  *int64
+}
+
+// Read implements io.Reader.
+func (c *countingReader) Read(b []byte) (int, error) {
+	n, err := c.reader.Read(b)
+	*c.count += int64(n)
+
+	return n, err //nolint:wrapcheck
 }
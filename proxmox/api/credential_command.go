@@ -0,0 +1,73 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// credentialCommandTimeout limits the time a credential command may take to return the credentials.
+const credentialCommandTimeout = time.Minute
+
+// CommandCredentials contains the credentials returned by a credential command, as a JSON object, e.g.
+// `{"auth_ticket": "PVE:...", "csrf_prevention_token": "..."}`.
+type CommandCredentials struct {
+	Username            string `json:"username,omitempty"`
+	Password            string `json:"password,omitempty"`
+	APIToken            string `json:"api_token,omitempty"`
+	AuthTicket          string `json:"auth_ticket,omitempty"`
+	CSRFPreventionToken string `json:"csrf_prevention_token,omitempty"`
+}
+
+// RunCredentialCommand executes the command with the system shell and parses the credentials from its output.
+func RunCredentialCommand(ctx context.Context, command string) (*CommandCredentials, error) {
+	ctx, cancel := context.WithTimeout(ctx, credentialCommandTimeout)
+	defer cancel()
+
+	//nolint:gosec
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	if runtime.GOOS == "windows" {
+		//nolint:gosec
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to run the credential command: %w: %s", err, msg)
+		}
+
+		return nil, fmt.Errorf("failed to run the credential command: %w", err)
+	}
+
+	creds := &CommandCredentials{}
+
+	err = json.Unmarshal(stdout.Bytes(), creds)
+	if err != nil {
+		// the output is not included in the error, as it may contain secrets
+		return nil, fmt.Errorf("failed to parse the output of the credential command as JSON: %w", err)
+	}
+
+	if *creds == (CommandCredentials{}) {
+		return nil, errors.New("the credential command did not return any credentials")
+	}
+
+	return creds, nil
+}
@@ -0,0 +1,72 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCredentialCommand(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the test commands require a POSIX shell")
+	}
+
+	tests := []struct {
+		name    string
+		command string
+		want    *CommandCredentials
+		wantErr string
+	}{
+		{
+			name:    "ticket",
+			command: `echo '{"auth_ticket": "PVE:root@pam:1234::x", "csrf_prevention_token": "1234:y"}'`,
+			want:    &CommandCredentials{AuthTicket: "PVE:root@pam:1234::x", CSRFPreventionToken: "1234:y"},
+		},
+		{
+			name:    "api token",
+			command: `echo '{"api_token": "root@pam!ci=00000000-0000-0000-0000-000000000000"}'`,
+			want:    &CommandCredentials{APIToken: "root@pam!ci=00000000-0000-0000-0000-000000000000"},
+		},
+		{
+			name:    "failure",
+			command: `echo "broker unavailable" >&2; exit 1`,
+			wantErr: "broker unavailable",
+		},
+		{
+			name:    "not JSON",
+			command: `echo "secret"`,
+			wantErr: "failed to parse the output of the credential command as JSON",
+		},
+		{
+			name:    "no credentials",
+			command: `echo '{}'`,
+			wantErr: "did not return any credentials",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			creds, err := RunCredentialCommand(t.Context(), tt.command)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				require.NotContains(t, err.Error(), "secret")
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, creds)
+		})
+	}
+}
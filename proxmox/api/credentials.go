@@ -35,6 +35,25 @@ type UserCredentials struct {
 	Username string
 	Password string
 	OTP      string
+
+	// OTPSecret is the base32 encoded TOTP secret used to compute the code of the second factor.
+	OTPSecret string
+	// OTPCode is a static code of the second factor, used when OTPSecret is not set.
+	OTPCode string
+}
+
+// CredentialsOption is an option for creating the credentials.
+type CredentialsOption func(c *Credentials)
+
+// WithTOTP is an option to complete the TOTP second factor of the user login, using either
+// the TOTP secret to compute the codes, or a static code.
+func WithTOTP(secret, code string) CredentialsOption {
+	return func(c *Credentials) {
+		if c.UserCredentials != nil {
+			c.UserCredentials.OTPSecret = secret
+			c.UserCredentials.OTPCode = code
+		}
+	}
 }
 
 // TokenCredentials contains the API token for authenticating with the Proxmox VE API.
@@ -53,7 +72,10 @@ type TicketCredentials struct {
 // 1. API token
 // 2. Ticket
 // 3. User credentials.
-func NewCredentials(username, password, otp, apiToken, authTicket, csrfPreventionToken string) (Credentials, error) {
+func NewCredentials(
+	username, password, otp, apiToken, authTicket, csrfPreventionToken string,
+	opts ...CredentialsOption,
+) (Credentials, error) {
 	if tok, err := newTokenCredentials(apiToken); err == nil {
 		return Credentials{TokenCredentials: &tok}, nil
 	} else if errors.Is(err, ErrInvalidAPIToken) {
@@ -65,7 +87,19 @@ func NewCredentials(username, password, otp, apiToken, authTicket, csrfPreventio
 	}
 
 	if usr, err := newUserCredentials(username, password, otp); err == nil {
-		return Credentials{UserCredentials: &usr}, nil
+		creds := Credentials{UserCredentials: &usr}
+
+		for _, opt := range opts {
+			opt(&creds)
+		}
+
+		if creds.UserCredentials.OTPSecret != "" {
+			if _, err := parseTOTPSecret(creds.UserCredentials.OTPSecret); err != nil {
+				return Credentials{}, err
+			}
+		}
+
+		return creds, nil
 	} else if errors.Is(err, ErrInvalidUsernameFormat) {
 		return Credentials{}, err
 	}
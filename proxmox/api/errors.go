@@ -23,6 +23,10 @@ const ErrNoDataObjectInResponse Error = "the server did not include a data objec
 // ErrResourceDoesNotExist is returned when the requested resource does not exist.
 const ErrResourceDoesNotExist Error = "the requested resource does not exist"
 
+// ErrTicketExpired is returned when the server rejects the authentication ticket.
+const ErrTicketExpired Error = "the authentication ticket has expired or is no longer valid, " +
+	"a new ticket is required to continue"
+
 // HTTPError is a generic error type for HTTP errors.
 type HTTPError struct {
 	Code    int
@@ -0,0 +1,180 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// healthCheckTimeout limits the time to wait for an endpoint to respond to the health check.
+const healthCheckTimeout = 5 * time.Second
+
+// currentEndpoint returns the index and the URL of the endpoint in use.
+func (c *Connection) currentEndpoint() (int, string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.current, c.endpoints[c.current]
+}
+
+// selectEndpoint uses the first reachable endpoint, or the first endpoint if none is reachable,
+// so that the requests fail with the error of the first endpoint.
+func (c *Connection) selectEndpoint(ctx context.Context) {
+	// the endpoints are probed without holding the lock, so the health checks don't block the requests
+	for i, endpoint := range c.endpoints {
+		if c.reachable(ctx, endpoint) {
+			c.setCurrentEndpoint(i)
+
+			return
+		}
+
+		tflog.Warn(ctx, "The Proxmox VE API endpoint is not reachable, trying the next one", map[string]interface{}{
+			"endpoint": endpoint,
+		})
+	}
+
+	c.setCurrentEndpoint(0)
+}
+
+func (c *Connection) setCurrentEndpoint(i int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.current = i
+}
+
+// failover switches to the next reachable endpoint once the given one can't be reached, and returns it.
+// It returns false if no other endpoint is reachable.
+func (c *Connection) failover(ctx context.Context, failed int) (int, bool) {
+	// a concurrent request has already switched to another endpoint
+	if current, _ := c.currentEndpoint(); current != failed {
+		return current, true
+	}
+
+	// the endpoints are probed without holding the lock, so the health checks don't block the requests
+	// using the current endpoint, and the lock is only taken to switch to the reachable one
+	for i := 1; i < len(c.endpoints); i++ {
+		next := (failed + i) % len(c.endpoints)
+
+		if c.reachable(ctx, c.endpoints[next]) {
+			return c.switchEndpoint(ctx, failed, next), true
+		}
+	}
+
+	return failed, false
+}
+
+// switchEndpoint switches from the failed endpoint to the next one, unless a concurrent request has already
+// failed over while the endpoints were probed, and returns the endpoint in use.
+func (c *Connection) switchEndpoint(ctx context.Context, failed int, next int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.current != failed {
+		return c.current
+	}
+
+	tflog.Warn(ctx, "The Proxmox VE API endpoint can't be reached, failing over to the next one", map[string]interface{}{
+		"failed_endpoint": c.endpoints[failed],
+		"endpoint":        c.endpoints[next],
+	})
+
+	c.current = next
+
+	return next
+}
+
+// reachable checks whether the endpoint responds to an unauthenticated version request. Any HTTP
+// response means that the endpoint is reachable, as the request is expected to be rejected.
+func (c *Connection) reachable(ctx context.Context, endpoint string) bool {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s/version", endpoint, basePathJSONAPI), nil)
+	if err != nil {
+		return false
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+
+	_ = res.Body.Close()
+
+	return true
+}
+
+// do performs the request created for the endpoint with the given index. When the endpoint can't be
+// reached, the connection fails over to the next reachable endpoint, and the request is performed again
+// through it, unless its body is streamed and can't be sent again.
+func (c *Connection) do(req *http.Request, endpoint int) (*http.Response, error) {
+	res, err := c.httpClient.Do(req)
+	if err == nil || len(c.endpoints) < 2 || !isUnreachable(req, err) {
+		return res, err
+	}
+
+	next, ok := c.failover(req.Context(), endpoint)
+	if !ok || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return res, err
+	}
+
+	retried, e := cloneRequest(req, c.endpoints[next])
+	if e != nil {
+		return res, err
+	}
+
+	return c.httpClient.Do(retried)
+}
+
+// isUnreachable checks whether the request failed because the endpoint can't be reached. The server
+// may have processed a request which timed out, so the timeouts are only considered for the reads.
+func isUnreachable(req *http.Request, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout() &&
+		(req.Method == http.MethodGet || req.Method == http.MethodHead)
+}
+
+// cloneRequest copies the request for another endpoint.
+func cloneRequest(req *http.Request, endpoint string) (*http.Request, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the endpoint %q: %w", endpoint, err)
+	}
+
+	r := req.Clone(req.Context())
+	r.URL.Scheme = u.Scheme
+	r.URL.Host = u.Host
+	r.Host = ""
+
+	if req.GetBody != nil {
+		r.Body, err = req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy the request body: %w", err)
+		}
+	}
+
+	return r, nil
+}
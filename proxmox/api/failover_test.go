@@ -0,0 +1,145 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFailoverServers returns the URL of an endpoint which can't be reached, and a live server
+// which records the bodies of the API requests it receives.
+func newFailoverServers(t *testing.T, bodies *[]string) (string, *httptest.Server) {
+	t.Helper()
+
+	dead := httptest.NewTLSServer(http.NotFoundHandler())
+	dead.Close()
+
+	live := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		// the health checks are not recorded
+		if r.URL.Path != "/api2/json/version" {
+			*bodies = append(*bodies, string(body))
+		}
+
+		_, _ = w.Write([]byte(`{"data":null}`))
+	}))
+
+	t.Cleanup(live.Close)
+
+	return dead.URL, live
+}
+
+func TestNewFailoverConnection(t *testing.T) {
+	t.Parallel()
+
+	var bodies []string
+
+	dead, live := newFailoverServers(t, &bodies)
+
+	conn, err := NewFailoverConnection(t.Context(), []string{dead, live.URL}, true, "")
+	require.NoError(t, err)
+
+	current, endpoint := conn.currentEndpoint()
+	assert.Equal(t, 1, current)
+	assert.Equal(t, live.URL, endpoint)
+
+	conn, err = NewFailoverConnection(t.Context(), []string{dead}, true, "")
+	require.NoError(t, err)
+
+	current, _ = conn.currentEndpoint()
+	assert.Equal(t, 0, current)
+
+	_, err = NewFailoverConnection(t.Context(), []string{live.URL, "http://insecure"}, true, "")
+	require.Error(t, err)
+}
+
+func TestConnectionFailover(t *testing.T) {
+	t.Parallel()
+
+	var bodies []string
+
+	dead, live := newFailoverServers(t, &bodies)
+
+	conn, err := NewConnection(dead, true, "")
+	require.NoError(t, err)
+
+	// the dead endpoint is in use, e.g. as the node went down after the connection was created
+	conn.endpoints = append(conn.endpoints, live.URL)
+
+	c := client{conn: conn, auth: dummyAuthenticator{}}
+
+	err = c.DoRequest(t.Context(), http.MethodPost, "nodes/pve/qemu", &struct {
+		Name string `url:"name"`
+	}{Name: "vm"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name=vm"}, bodies)
+
+	current, _ := conn.currentEndpoint()
+	assert.Equal(t, 1, current)
+
+	err = c.DoRequest(t.Context(), http.MethodGet, "nodes", nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, bodies, 2)
+}
+
+func TestConnectionFailoverDoesNotBlock(t *testing.T) {
+	t.Parallel()
+
+	probing := make(chan struct{})
+	release := make(chan struct{})
+	releaseOnce := sync.OnceFunc(func() { close(release) })
+
+	slow := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(probing)
+		<-release
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(slow.Close)
+	t.Cleanup(releaseOnce)
+
+	conn, err := NewConnection("https://dead.invalid", true, "")
+	require.NoError(t, err)
+
+	conn.endpoints = append(conn.endpoints, slow.URL)
+
+	done := make(chan int)
+
+	go func() {
+		next, _ := conn.failover(t.Context(), 0)
+		done <- next
+	}()
+
+	<-probing
+
+	// the requests keep using the current endpoint while the next one is probed
+	current := make(chan int)
+
+	go func() {
+		i, _ := conn.currentEndpoint()
+		current <- i
+	}()
+
+	select {
+	case i := <-current:
+		assert.Equal(t, 0, i)
+	case <-time.After(time.Second):
+		t.Fatal("the current endpoint is locked while the endpoints are probed")
+	}
+
+	releaseOnce()
+	assert.Equal(t, 1, <-done)
+}
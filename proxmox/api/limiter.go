@@ -0,0 +1,102 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// DefaultMaxParallelism is the default number of the API requests sent concurrently.
+	DefaultMaxParallelism = 4
+	// DefaultMaxHeavyParallelism is the default number of the heavy API requests, e.g. clones, sent concurrently.
+	DefaultMaxHeavyParallelism = 2
+)
+
+// heavyRequests matches the requests starting the operations which put a heavy load on the nodes.
+var heavyRequests = regexp.MustCompile(`^nodes/[^/]+/((qemu|lxc)/\d+/(clone|migrate|remote_migrate)|vzdump)$`)
+
+// requestLimiter limits the number of the API requests sent concurrently, with a lower limit for the heavy ones.
+type requestLimiter struct {
+	requests      chan struct{}
+	heavyRequests chan struct{}
+	waiting       atomic.Int64
+}
+
+func newRequestLimiter(maxRequests, maxHeavyRequests int) *requestLimiter {
+	return &requestLimiter{
+		requests:      make(chan struct{}, max(maxRequests, 1)),
+		heavyRequests: make(chan struct{}, max(maxHeavyRequests, 1)),
+	}
+}
+
+// isHeavyRequest checks whether the request starts an operation which puts a heavy load on the nodes.
+func isHeavyRequest(method, path string) bool {
+	path, _, _ = strings.Cut(strings.TrimPrefix(path, "/"), "?")
+
+	return method == http.MethodPost && heavyRequests.MatchString(path)
+}
+
+// acquire waits for a slot to send the request, and returns the function releasing it. A heavy request
+// waits for a heavy slot first, then for a regular one. The wait is interrupted when the context is done.
+func (l *requestLimiter) acquire(ctx context.Context, method, path string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	heavy := isHeavyRequest(method, path)
+
+	slots := []chan struct{}{l.requests}
+	if heavy {
+		slots = []chan struct{}{l.heavyRequests, l.requests}
+	}
+
+	release := func(acquired []chan struct{}) func() {
+		return func() {
+			for _, slot := range acquired {
+				<-slot
+			}
+		}
+	}
+
+	for i, slot := range slots {
+		select {
+		case slot <- struct{}{}:
+			continue
+		default:
+		}
+
+		waiting := l.waiting.Add(1)
+
+		tflog.Debug(ctx, "Waiting for a slot to send the API request", map[string]interface{}{
+			"method":      method,
+			"path":        path,
+			"heavy":       heavy,
+			"queue_depth": waiting,
+		})
+
+		select {
+		case slot <- struct{}{}:
+			l.waiting.Add(-1)
+		case <-ctx.Done():
+			l.waiting.Add(-1)
+			release(slots[:i])()
+
+			return nil, fmt.Errorf("interrupted while waiting to send the HTTP %s request (path: %s): %w",
+				method, path, ctx.Err())
+		}
+	}
+
+	return release(slots), nil
+}
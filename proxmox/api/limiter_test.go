@@ -0,0 +1,71 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsHeavyRequest(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isHeavyRequest(http.MethodPost, "nodes/pve/qemu/100/clone"))
+	assert.True(t, isHeavyRequest(http.MethodPost, "/nodes/pve/lxc/100/migrate"))
+	assert.True(t, isHeavyRequest(http.MethodPost, "nodes/pve/vzdump"))
+	assert.False(t, isHeavyRequest(http.MethodGet, "nodes/pve/qemu/100/migrate"))
+	assert.False(t, isHeavyRequest(http.MethodPost, "nodes/pve/qemu/100/status/start"))
+}
+
+func TestRequestLimiter(t *testing.T) {
+	t.Parallel()
+
+	l := newRequestLimiter(2, 1)
+
+	releaseHeavy, err := l.acquire(t.Context(), http.MethodPost, "nodes/pve/qemu/100/clone")
+	require.NoError(t, err)
+
+	// a second heavy request waits for the first one, while a regular request is sent
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = l.acquire(ctx, http.MethodPost, "nodes/pve/qemu/101/clone")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	releaseRegular, err := l.acquire(t.Context(), http.MethodGet, "nodes")
+	require.NoError(t, err)
+
+	// all the slots are taken
+	ctx, cancel = context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = l.acquire(ctx, http.MethodGet, "nodes")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the cancelled requests did not leak their slots
+	releaseHeavy()
+	releaseRegular()
+
+	assert.Empty(t, l.requests)
+	assert.Empty(t, l.heavyRequests)
+	assert.Zero(t, l.waiting.Load())
+
+	release, err := l.acquire(t.Context(), http.MethodPost, "nodes/pve/qemu/101/clone")
+	require.NoError(t, err)
+	release()
+
+	var nilLimiter *requestLimiter
+
+	release, err = nilLimiter.acquire(t.Context(), http.MethodGet, "nodes")
+	require.NoError(t, err)
+	release()
+}
@@ -0,0 +1,232 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redacted = "<redacted>"
+
+// sensitiveHeaders are the headers whose values are redacted from the logs. The names of the cookies are kept.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "CSRFPreventionToken"}
+
+// WithDebugLogging is an option to log the method, path, status and duration of every API request, along with
+// its parameters and response, with the credentials and the secrets redacted. Each request is given an ID, which
+// is added to the error of a failed request, so that the request can be found in the logs. The requests are logged
+// at the INFO level, as the HTTP transport logs them unredacted at the DEBUG level.
+func WithDebugLogging() ClientOption {
+	return func(c *client) {
+		c.debugLogging = true
+	}
+}
+
+// requestLog logs an API request and its attempts. A nil requestLog logs nothing.
+type requestLog struct {
+	id     string
+	method string
+	path   string
+	start  time.Time
+}
+
+func newRequestLog(ctx context.Context, method string, path string, body string) *requestLog {
+	l := &requestLog{
+		id:     uuid.NewString(),
+		method: method,
+		path:   redactPath(path),
+		start:  time.Now(),
+	}
+
+	fields := map[string]interface{}{
+		"correlation_id": l.id,
+		"method":         l.method,
+		"path":           l.path,
+	}
+
+	if body != "" {
+		fields["request_body"] = redactQuery(body)
+	}
+
+	tflog.Info(ctx, "Sending the API request", fields)
+
+	return l
+}
+
+// attempt logs the response of an attempt to send the request. The body of a successful response is read,
+// so that it can be logged, and replaced with the data read.
+func (l *requestLog) attempt(ctx context.Context, r *http.Request, res *http.Response, err error) {
+	if l == nil {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"correlation_id":  l.id,
+		"method":          l.method,
+		"path":            l.path,
+		"duration":        time.Since(l.start).String(),
+		"request_headers": redactHeaders(r.Header),
+	}
+
+	if res != nil {
+		fields["status"] = res.StatusCode
+		fields["response_headers"] = redactHeaders(res.Header)
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+
+		tflog.Info(ctx, "The API request failed", fields)
+
+		return
+	}
+
+	if res != nil && res.Body != nil {
+		data, e := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+
+		res.Body = io.NopCloser(bytes.NewReader(data))
+
+		if e == nil {
+			fields["response_body"] = redactJSON(data, strings.Contains(l.path, "/token/"))
+		}
+	}
+
+	tflog.Info(ctx, "Received the API response", fields)
+}
+
+// wrap adds the correlation ID to the error of the request.
+func (l *requestLog) wrap(err error) error {
+	if l == nil || err == nil {
+		return err
+	}
+
+	return fmt.Errorf("%w (correlation ID: %s)", err, l.id)
+}
+
+// isSensitiveKey returns true if the parameter or the field with the given name holds a secret.
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+
+	return strings.Contains(key, "password") ||
+		strings.Contains(key, "secret") ||
+		key == "ticket" ||
+		key == "csrfpreventiontoken" ||
+		key == "otp"
+}
+
+func redactHeaders(h http.Header) map[string]string {
+	headers := make(map[string]string, len(h))
+
+	for name, values := range h {
+		value := strings.Join(values, ", ")
+
+		for _, sensitive := range sensitiveHeaders {
+			if !strings.EqualFold(name, sensitive) {
+				continue
+			}
+
+			value = redacted
+
+			if strings.Contains(strings.ToLower(name), "cookie") {
+				value = redactCookies(values)
+			}
+		}
+
+		headers[name] = value
+	}
+
+	return headers
+}
+
+// redactCookies keeps the names of the cookies, and redacts their values.
+func redactCookies(values []string) string {
+	cookies := make([]string, 0, len(values))
+
+	for _, v := range values {
+		for _, cookie := range strings.Split(v, ";") {
+			name, _, _ := strings.Cut(strings.TrimSpace(cookie), "=")
+			if name != "" {
+				cookies = append(cookies, name+"="+redacted)
+			}
+		}
+	}
+
+	return strings.Join(cookies, "; ")
+}
+
+// redactPath redacts the sensitive parameters of the query of the path.
+func redactPath(path string) string {
+	p, query, ok := strings.Cut(path, "?")
+	if !ok {
+		return path
+	}
+
+	return p + "?" + redactQuery(query)
+}
+
+// redactQuery redacts the sensitive parameters of a URL encoded query or form.
+func redactQuery(query string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return redacted
+	}
+
+	for key := range values {
+		if isSensitiveKey(key) {
+			values[key] = []string{redacted}
+		}
+	}
+
+	return values.Encode()
+}
+
+// redactJSON redacts the sensitive fields of a JSON response, and the `value` fields too when the response
+// is the one of an API token, i.e. the secret of the token.
+func redactJSON(data []byte, token bool) string {
+	var v interface{}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Sprintf("<%d bytes>", len(data))
+	}
+
+	out, err := json.Marshal(redactValue(v, token))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(data))
+	}
+
+	return string(out)
+}
+
+func redactValue(v interface{}, token bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isSensitiveKey(key) || (token && key == "value") {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(value, token)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value, token)
+		}
+	}
+
+	return v
+}
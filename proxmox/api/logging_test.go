@@ -0,0 +1,97 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedaction(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "nodes/pve/qemu/100/config?cipassword=%3Credacted%3E&name=vm",
+		redactPath("nodes/pve/qemu/100/config?cipassword=secret&name=vm"))
+	assert.Equal(t, "password=%3Credacted%3E&username=root%40pam", redactQuery("username=root@pam&password=secret"))
+
+	assert.Equal(t, map[string]string{
+		"Authorization": redacted,
+		"Cookie":        "PVEAuthCookie=<redacted>; other=<redacted>",
+		"Accept":        "application/json",
+	}, redactHeaders(http.Header{
+		"Authorization": {"PVEAPIToken=root@pam!test=00000000-0000-0000-0000-000000000000"},
+		"Cookie":        {"PVEAuthCookie=PVE:root@pam:ticket; other=value"},
+		"Accept":        {"application/json"},
+	}))
+
+	assert.JSONEq(t,
+		`{"data":{"ticket":"<redacted>","CSRFPreventionToken":"<redacted>","username":"root@pam"}}`,
+		redactJSON([]byte(`{"data":{"ticket":"PVE:root@pam:x","CSRFPreventionToken":"y","username":"root@pam"}}`), false),
+	)
+	assert.JSONEq(t,
+		`{"data":{"full-tokenid":"root@pam!test","value":"<redacted>"}}`,
+		redactJSON([]byte(`{"data":{"full-tokenid":"root@pam!test","value":"00000000"}}`), true),
+	)
+}
+
+func TestDebugLogging(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":{"cipassword":"invalid"}}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"password":"hunter2","name":"vm"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	conn, err := NewConnection(server.URL, true, "")
+	require.NoError(t, err)
+
+	creds := Credentials{TokenCredentials: &TokenCredentials{APIToken: "root@pam!test=00000000-0000-0000-0000-000000000000"}}
+
+	c, err := NewClient(creds, conn, WithDebugLogging())
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+
+	var res map[string]interface{}
+
+	require.NoError(t, c.DoRequest(ctx, http.MethodGet, "nodes/pve/qemu/100/config", nil, &res))
+	assert.Equal(t, map[string]interface{}{"password": "hunter2", "name": "vm"}, res["data"])
+
+	body := struct {
+		CIPassword string `url:"cipassword"`
+	}{CIPassword: "hunter2"}
+
+	err = c.DoRequest(ctx, http.MethodPost, "nodes/pve/qemu/100/config", body, nil)
+	require.Error(t, err)
+
+	m := regexp.MustCompile(`correlation ID: ([0-9a-f-]+)`).FindStringSubmatch(err.Error())
+	require.Len(t, m, 2)
+
+	logs := out.String()
+
+	assert.Contains(t, logs, m[1])
+	assert.Contains(t, logs, `"status":400`)
+	assert.NotContains(t, logs, "hunter2")
+	assert.NotContains(t, logs, "00000000-0000-0000-0000-000000000000")
+}
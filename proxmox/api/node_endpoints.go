@@ -0,0 +1,81 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// nodePathRegex matches the paths of the requests scoped to a node, and captures the name of the node.
+var nodePathRegex = regexp.MustCompile(`^/?nodes/([^/?]+)`)
+
+// WithNodeEndpoints is an option to send the requests scoped to a node, i.e. `nodes/{node}/...`, directly to
+// the endpoint of the node instead of proxying them through the cluster endpoint, e.g. to upload files faster.
+// The connections are keyed by the name of the node, and share the credentials of the client.
+func WithNodeEndpoints(conns map[string]*Connection) ClientOption {
+	return func(c *client) {
+		c.nodeConns = conns
+	}
+}
+
+// connectionFor returns the connection to send the request with the given path through.
+func (c *client) connectionFor(path string) *Connection {
+	m := nodePathRegex.FindStringSubmatch(path)
+	if m == nil {
+		return c.conn
+	}
+
+	c.nodeConnsMu.RLock()
+	defer c.nodeConnsMu.RUnlock()
+
+	if conn, ok := c.nodeConns[m[1]]; ok {
+		return conn
+	}
+
+	return c.conn
+}
+
+// doThrough performs the request created for the endpoint with the given index of the connection. When the
+// connection is the one of a node, and the node can't be reached, the request is performed again through
+// the cluster endpoint, which is used for the later requests to the node too. The node is only considered
+// unreachable when the request could not be dialed, or a read request timed out, so the body of the request
+// has not been sent yet, and is sent again even if it can't be copied.
+func (c *client) doThrough(r *http.Request, conn *Connection, endpoint int) (*http.Response, error) {
+	res, err := conn.do(r, endpoint)
+	if err == nil || conn == c.conn || !isUnreachable(r, err) {
+		return res, err
+	}
+
+	c.nodeConnsMu.Lock()
+
+	for node, nodeConn := range c.nodeConns {
+		if nodeConn == conn {
+			delete(c.nodeConns, node)
+		}
+	}
+
+	c.nodeConnsMu.Unlock()
+
+	next, endpointURL := c.conn.currentEndpoint()
+
+	tflog.Warn(r.Context(), "The Proxmox VE API endpoint of the node can't be reached, falling back to the "+
+		"cluster endpoint", map[string]interface{}{
+		"failed_endpoint": r.URL.Scheme + "://" + r.URL.Host,
+		"endpoint":        endpointURL,
+		"error":           err,
+	})
+
+	retried, e := cloneRequest(r, endpointURL)
+	if e != nil {
+		return res, err
+	}
+
+	return c.conn.do(retried, next)
+}
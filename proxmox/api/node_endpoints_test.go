@@ -0,0 +1,110 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRecordingServer returns a server which records the paths of the API requests it receives.
+func newRecordingServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"data":null}`))
+	}))
+
+	t.Cleanup(s.Close)
+
+	return s, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), paths...)
+	}
+}
+
+func newNodeEndpointsClient(t *testing.T, cluster string, nodes map[string]string) Client {
+	t.Helper()
+
+	conn, err := NewConnection(cluster, true, "")
+	require.NoError(t, err)
+
+	nodeConns := map[string]*Connection{}
+
+	for node, endpoint := range nodes {
+		nodeConns[node], err = NewConnection(endpoint, true, "")
+		require.NoError(t, err)
+	}
+
+	creds := Credentials{TokenCredentials: &TokenCredentials{APIToken: "root@pam!test=00000000-0000-0000-0000-000000000000"}}
+
+	c, err := NewClient(creds, conn, WithNodeEndpoints(nodeConns))
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestNodeEndpoints(t *testing.T) {
+	t.Parallel()
+
+	cluster, clusterPaths := newRecordingServer(t)
+	node, nodePaths := newRecordingServer(t)
+
+	c := newNodeEndpointsClient(t, cluster.URL, map[string]string{"pve1": node.URL})
+
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "nodes/pve1/status", nil, nil))
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "nodes/pve2/status", nil, nil))
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "nodes", nil, nil))
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "cluster/resources", nil, nil))
+
+	assert.Equal(t, []string{"/api2/json/nodes/pve1/status"}, nodePaths())
+	assert.Equal(t, []string{
+		"/api2/json/nodes/pve2/status",
+		"/api2/json/nodes",
+		"/api2/json/cluster/resources",
+	}, clusterPaths())
+}
+
+func TestNodeEndpointsFallback(t *testing.T) {
+	t.Parallel()
+
+	cluster, clusterPaths := newRecordingServer(t)
+
+	dead := httptest.NewTLSServer(http.NotFoundHandler())
+	dead.Close()
+
+	c := newNodeEndpointsClient(t, cluster.URL, map[string]string{"pve1": dead.URL})
+
+	// the streamed body of an upload can't be copied, but is sent again as the node could not be dialed
+	size := int64(4)
+	body := &MultiPartData{Boundary: "boundary", Reader: bytes.NewBufferString("file"), Size: &size}
+
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodPost, "nodes/pve1/storage/local/upload", body, nil))
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "nodes/pve1/status", nil, nil))
+
+	assert.Equal(t, []string{
+		"/api2/json/nodes/pve1/storage/local/upload",
+		"/api2/json/nodes/pve1/status",
+	}, clusterPaths())
+}
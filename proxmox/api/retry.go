@@ -0,0 +1,202 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// MaxRetries is the maximum number of times a request may be retried.
+const MaxRetries = 10
+
+// retryableStatusCodes are the status codes of the transient server errors, including the 596 and 599
+// status codes of pveproxy when it fails to proxy a request to another node.
+var retryableStatusCodes = []int{
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+	596,
+	599,
+}
+
+// idempotentRequests lists the POST and PUT requests which can be sent again without side effects,
+// as the GET and DELETE requests are always retried.
+var idempotentRequests = []struct {
+	method string
+	path   *regexp.Regexp
+}{
+	{http.MethodPut, regexp.MustCompile(`^nodes/[^/]+/(qemu|lxc)/\d+/config$`)},
+	{http.MethodPut, regexp.MustCompile(`(^|/)firewall/options$`)},
+	{http.MethodPut, regexp.MustCompile(`^cluster/options$`)},
+	{http.MethodPut, regexp.MustCompile(`^nodes/[^/]+/(config|dns)$`)},
+	{http.MethodPost, regexp.MustCompile(`^nodes/[^/]+/qemu/\d+/agent/ping$`)},
+}
+
+// isIdempotentRequest checks whether the request can be sent again without side effects.
+func isIdempotentRequest(method, path string) bool {
+	if method == http.MethodGet || method == http.MethodHead || method == http.MethodDelete {
+		return true
+	}
+
+	path, _, _ = strings.Cut(strings.TrimPrefix(path, "/"), "?")
+
+	for _, r := range idempotentRequests {
+		if r.method == method && r.path.MatchString(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRetryableError checks whether the request failed with a transient server error.
+func isRetryableError(err error) bool {
+	if errors.Is(err, ErrResourceDoesNotExist) {
+		return false
+	}
+
+	var httpErr *HTTPError
+
+	return errors.As(err, &httpErr) && slices.Contains(retryableStatusCodes, httpErr.Code)
+}
+
+// delay returns the delay before the given retry, starting with 1.
+func (p RetryPolicy) delay(retry int) time.Duration {
+	d := p.MinBackoff
+
+	for i := 1; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	return min(d, p.MaxBackoff)
+}
+
+// doWithRetries sends the request, and sends it again after a delay when it fails with a transient
+// server error, if it is idempotent and its body can be sent again. A retry which would end after the
+// deadline of the request context is not attempted.
+func (c *client) doWithRetries(
+	ctx context.Context,
+	req *http.Request,
+	path string,
+	send func(r *http.Request) (*http.Response, error),
+) (*http.Response, error) {
+	retryable := isIdempotentRequest(req.Method, path) &&
+		(req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+
+	r := req
+
+	for retry := 1; ; retry++ {
+		//nolint:bodyclose
+		res, err := send(r)
+		if err == nil {
+			return res, nil
+		}
+
+		if !retryable || retry > c.retryPolicy.Retries || !isRetryableError(err) {
+			return nil, err
+		}
+
+		delay := c.retryPolicy.delay(retry)
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return nil, err
+		}
+
+		tflog.Debug(ctx, "Retrying the HTTP request after a transient server error", map[string]interface{}{
+			"method":  req.Method,
+			"path":    path,
+			"attempt": retry + 1,
+			"delay":   delay.String(),
+			"error":   err.Error(),
+		})
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, err
+		}
+
+		r = req.Clone(ctx)
+
+		if req.GetBody != nil {
+			r.Body, err = req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to copy the body of the HTTP %s request (path: %s): %w", req.Method, path, err)
+			}
+		}
+	}
+}
+
+// ParseRetryBackoff parses the delay between the retries of a request, e.g. `500ms` or `2s`.
+// An empty delay is returned as 0.
+func ParseRetryBackoff(backoff string) (time.Duration, error) {
+	if strings.TrimSpace(backoff) == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(backoff))
+	if err != nil {
+		return 0, fmt.Errorf("invalid retry backoff %q: %w", backoff, err)
+	}
+
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid retry backoff %q, must be positive", backoff)
+	}
+
+	return d, nil
+}
+
+// NewRetryPolicy creates a RetryPolicy from the settings of the provider. The empty retries and
+// backoffs are the ones of DefaultRetryPolicy.
+func NewRetryPolicy(retries string, minBackoff, maxBackoff string) (RetryPolicy, error) {
+	p := DefaultRetryPolicy
+
+	if strings.TrimSpace(retries) != "" {
+		n, err := strconv.Atoi(strings.TrimSpace(retries))
+		if err != nil || n < 0 || n > MaxRetries {
+			return RetryPolicy{}, fmt.Errorf("invalid number of retries %q, must be between 0 and %d", retries, MaxRetries)
+		}
+
+		p.Retries = n
+	}
+
+	minDelay, err := ParseRetryBackoff(minBackoff)
+	if err != nil {
+		return RetryPolicy{}, err
+	}
+
+	maxDelay, err := ParseRetryBackoff(maxBackoff)
+	if err != nil {
+		return RetryPolicy{}, err
+	}
+
+	if minDelay > 0 {
+		p.MinBackoff = minDelay
+	}
+
+	if maxDelay > 0 {
+		p.MaxBackoff = maxDelay
+	}
+
+	if p.MaxBackoff < p.MinBackoff {
+		return RetryPolicy{}, fmt.Errorf("the maximum retry backoff %s must not be less than the minimum %s",
+			p.MaxBackoff, p.MinBackoff)
+	}
+
+	return p, nil
+}
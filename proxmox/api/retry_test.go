@@ -0,0 +1,168 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRetryTestClient returns a client whose requests fail with the given status codes in order,
+// then succeed, along with the bodies of the requests it receives.
+func newRetryTestClient(policy RetryPolicy, codes ...int) (*client, *[]string) {
+	bodies := []string{}
+
+	c := &client{
+		conn: &Connection{
+			endpoints: []string{"http://localhost"},
+			httpClient: newTestClient(func(req *http.Request) *http.Response {
+				body, _ := io.ReadAll(req.Body)
+				bodies = append(bodies, string(body))
+
+				code := http.StatusOK
+				if len(bodies) <= len(codes) {
+					code = codes[len(bodies)-1]
+				}
+
+				return &http.Response{
+					Status:     fmt.Sprintf("%d %s", code, http.StatusText(code)),
+					StatusCode: code,
+					Body:       io.NopCloser(strings.NewReader(`{"data":null}`)),
+				}
+			}),
+		},
+		auth:        dummyAuthenticator{},
+		retryPolicy: policy,
+	}
+
+	return c, &bodies
+}
+
+func TestClientDoRequestRetries(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{Retries: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	type body struct {
+		Name string `url:"name"`
+	}
+
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		method   string
+		path     string
+		body     interface{}
+		codes    []int
+		requests int
+		wantErr  bool
+	}{
+		{"read retried", policy, http.MethodGet, "nodes", nil, []int{503, 599}, 3, false},
+		{"delete retried", policy, http.MethodDelete, "nodes/pve/qemu/100", nil, []int{502}, 2, false},
+		{"retries exhausted", policy, http.MethodGet, "nodes", nil, []int{500, 500, 500, 500}, 4, true},
+		{"retries disabled", RetryPolicy{}, http.MethodGet, "nodes", nil, []int{503}, 1, true},
+		{"client error", policy, http.MethodGet, "nodes", nil, []int{400}, 1, true},
+		{"not found", policy, http.MethodGet, "nodes", nil, []int{404}, 1, true},
+		{"unsafe create", policy, http.MethodPost, "nodes/pve/qemu", &body{Name: "vm"}, []int{503}, 1, true},
+		{"config update", policy, http.MethodPut, "nodes/pve/qemu/100/config", &body{Name: "vm"}, []int{596}, 2, false},
+		{"agent ping", policy, http.MethodPost, "nodes/pve/qemu/100/agent/ping", nil, []int{500}, 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, bodies := newRetryTestClient(tt.policy, tt.codes...)
+
+			err := c.DoRequest(t.Context(), tt.method, tt.path, tt.body, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Len(t, *bodies, tt.requests)
+
+			// the body is sent again with each retry
+			for _, b := range *bodies {
+				assert.Equal(t, (*bodies)[0], b)
+			}
+		})
+	}
+}
+
+func TestClientDoRequestRetriesDeadline(t *testing.T) {
+	t.Parallel()
+
+	c, bodies := newRetryTestClient(RetryPolicy{Retries: 3, MinBackoff: time.Minute, MaxBackoff: time.Minute}, 503)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	// the retry is not attempted, as it would end after the deadline
+	err := c.DoRequest(ctx, http.MethodGet, "nodes", nil, nil)
+	require.ErrorContains(t, err, "503")
+	require.Len(t, *bodies, 1)
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	t.Parallel()
+
+	p := RetryPolicy{Retries: 10, MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	assert.Equal(t, time.Second, p.delay(1))
+	assert.Equal(t, 2*time.Second, p.delay(2))
+	assert.Equal(t, 8*time.Second, p.delay(4))
+	assert.Equal(t, 10*time.Second, p.delay(5))
+	assert.Equal(t, 10*time.Second, p.delay(100))
+}
+
+func TestIsIdempotentRequest(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isIdempotentRequest(http.MethodGet, "nodes/pve/tasks/UPID:pve/status"))
+	assert.True(t, isIdempotentRequest(http.MethodDelete, "nodes/pve/lxc/100?purge=1"))
+	assert.True(t, isIdempotentRequest(http.MethodPut, "nodes/pve/lxc/100/config"))
+	assert.True(t, isIdempotentRequest(http.MethodPut, "nodes/pve/qemu/100/firewall/options"))
+	assert.True(t, isIdempotentRequest(http.MethodPut, "cluster/options"))
+	assert.True(t, isIdempotentRequest(http.MethodPut, "nodes/pve/dns"))
+	assert.False(t, isIdempotentRequest(http.MethodPost, "nodes/pve/qemu/100/config"))
+	assert.False(t, isIdempotentRequest(http.MethodPost, "nodes/pve/qemu/100/clone"))
+	assert.False(t, isIdempotentRequest(http.MethodPut, "nodes/pve/qemu/100/resize"))
+}
+
+func TestNewRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewRetryPolicy("", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRetryPolicy, p)
+
+	p, err = NewRetryPolicy("0", "500ms", "1m")
+	require.NoError(t, err)
+	assert.Equal(t, RetryPolicy{Retries: 0, MinBackoff: 500 * time.Millisecond, MaxBackoff: time.Minute}, p)
+
+	for _, tt := range [][3]string{
+		{"-1", "", ""},
+		{"11", "", ""},
+		{"many", "", ""},
+		{"", "soon", ""},
+		{"", "0s", ""},
+		{"", "1m", ""},
+		{"", "", "500ms"},
+	} {
+		_, err = NewRetryPolicy(tt[0], tt[1], tt[2])
+		require.Error(t, err, tt)
+	}
+}
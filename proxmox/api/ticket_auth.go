@@ -11,10 +11,12 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"sync/atomic"
 )
 
 type ticketAuthenticator struct {
 	authData *AuthenticationResponseData
+	expired  atomic.Bool
 }
 
 // NewTicketAuthenticator returns a new ticket authenticator.
@@ -50,6 +52,11 @@ func (t *ticketAuthenticator) IsRootTicket(ctx context.Context) bool {
 
 // AuthenticateRequest adds authentication data to a new request.
 func (t *ticketAuthenticator) AuthenticateRequest(_ context.Context, req *http.Request) error {
+	// the ticket can't be renewed, so there is no point in sending the request
+	if t.expired.Load() {
+		return ErrTicketExpired
+	}
+
 	req.AddCookie(&http.Cookie{
 		HttpOnly: true,
 		Name:     "PVEAuthCookie",
@@ -63,3 +70,8 @@ func (t *ticketAuthenticator) AuthenticateRequest(_ context.Context, req *http.R
 
 	return nil
 }
+
+// Expire marks the ticket as expired, once the server rejects it.
+func (t *ticketAuthenticator) Expire() {
+	t.expired.Store(true)
+}
@@ -0,0 +1,62 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// totpPeriod is the validity of a TOTP code, as used by PVE.
+	totpPeriod = 30 * time.Second
+
+	// totpDigits is the number of digits of a TOTP code, as used by PVE.
+	totpDigits = 6
+)
+
+// ErrInvalidOTPSecret is returned when the TOTP secret is not a valid base32 string.
+var ErrInvalidOTPSecret = errors.New("the OTP secret must be a base32 encoded string")
+
+// parseTOTPSecret decodes a base32 TOTP secret, ignoring the case, the spaces and the padding.
+func parseTOTPSecret(secret string) ([]byte, error) {
+	s := strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "="))
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(s)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidOTPSecret
+	}
+
+	return key, nil
+}
+
+// totpCode computes the TOTP code (RFC 6238) of the secret at the given time.
+func totpCode(secret string, t time.Time) (string, error) {
+	key, err := parseTOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	var counter [8]byte
+
+	binary.BigEndian.PutUint64(counter[:], uint64(t.Unix())/uint64(totpPeriod.Seconds())) //nolint:gosec
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, code%1_000_000), nil
+}
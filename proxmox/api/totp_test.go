@@ -0,0 +1,45 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTPCode(t *testing.T) {
+	t.Parallel()
+
+	// the SHA-1 test vectors of RFC 6238, truncated to 6 digits
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	tests := []struct {
+		time int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := totpCode(secret, time.Unix(tt.time, 0))
+		require.NoError(t, err)
+		require.Equal(t, tt.want, code)
+	}
+
+	code, err := totpCode("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", time.Unix(59, 0))
+	require.NoError(t, err)
+	require.Equal(t, "287082", code)
+
+	_, err = totpCode("not base32!", time.Unix(59, 0))
+	require.ErrorIs(t, err, ErrInvalidOTPSecret)
+}
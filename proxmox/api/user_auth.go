@@ -14,21 +14,37 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/bpg/terraform-provider-proxmox/utils"
 )
 
+// ticketRefreshAge is the age after which a ticket is renewed, before it expires after 2 hours.
+const ticketRefreshAge = 110 * time.Minute
+
 type userAuthenticator struct {
 	conn        *Connection
+	creds       UserCredentials
 	authRequest string
 	authData    *AuthenticationResponseData
+	authTime    time.Time
 
 	mu sync.Mutex
 }
 
+// tfaChallenge lists the second factors accepted to complete a login, as encoded in the challenge ticket.
+type tfaChallenge struct {
+	TOTP     bool        `json:"totp"`
+	Yubico   bool        `json:"yubico"`
+	Recovery interface{} `json:"recovery"`
+	WebAuthn interface{} `json:"webauthn"`
+	U2F      interface{} `json:"u2f"`
+}
+
 // NewUserAuthenticator creates a new authenticator that uses a username and password for authentication.
 func NewUserAuthenticator(creds UserCredentials, conn *Connection) Authenticator {
 	authRequest := fmt.Sprintf(
@@ -37,19 +53,16 @@ func NewUserAuthenticator(creds UserCredentials, conn *Connection) Authenticator
 		url.QueryEscape(creds.Password),
 	)
 
-	// OTP is optional, and probably doesn't make much sense for most provider users.
-	//   TOTP uses 2x requests; one with payloads `username=` and `password=`,
-	//     (this returns a payload including: 'NeedTFA=1')
-	//   followed by a 2nd request with payloads:
-	//     `username=`, `tfa-challenge=<firsts response ticket>`, `password=totp:######`,
-	//   and header: `CSRFPreventionToken: <first response CSRF>`
-	//   Ticket generated lasts for ~2hours (to verify)
+	// The deprecated OTP is sent along with the password, as expected by PVE before v7.
+	// The TOTP second factor of the later versions is completed by a second request instead,
+	// see completeTFA.
 	if creds.OTP != "" {
 		authRequest = fmt.Sprintf("%s&otp=%s", authRequest, url.QueryEscape(creds.OTP))
 	}
 
 	return &userAuthenticator{
 		conn:        conn,
+		creds:       creds,
 		authRequest: authRequest,
 	}
 }
@@ -58,15 +71,132 @@ func (t *userAuthenticator) authenticate(ctx context.Context) (*AuthenticationRe
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	if t.authData != nil {
+	if t.authData != nil && time.Since(t.authTime) < ticketRefreshAge {
 		return t.authData, nil
 	}
 
+	if t.authData != nil {
+		tflog.Debug(ctx, "Renewing the authentication ticket")
+	}
+
+	data, err := t.requestTicket(ctx, t.authRequest, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if data.NeedTFA == 1 {
+		data, err = t.completeTFA(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if data.CSRFPreventionToken == nil {
+		return nil, errors.New(
+			"the server did not include a CSRF prevention token in the authentication response",
+		)
+	}
+
+	if data.Ticket == nil {
+		return nil, errors.New("the server did not include a ticket in the authentication response")
+	}
+
+	if data.Username == "" {
+		return nil, errors.New("the server did not include the username in the authentication response")
+	}
+
+	t.authData = data
+	t.authTime = time.Now()
+
+	return data, nil
+}
+
+// completeTFA answers the second factor challenge of a login with a TOTP code, which is computed
+// for every login when the secret is known.
+func (t *userAuthenticator) completeTFA(
+	ctx context.Context,
+	challenge *AuthenticationResponseData,
+) (*AuthenticationResponseData, error) {
+	if challenge.Ticket == nil || challenge.CSRFPreventionToken == nil {
+		return nil, errors.New("the server did not include a two-factor challenge in the authentication response")
+	}
+
+	if c, ok := parseTFAChallenge(*challenge.Ticket); ok && !c.TOTP {
+		var methods []string
+
+		if c.WebAuthn != nil || c.U2F != nil {
+			methods = append(methods, "WebAuthn")
+		}
+
+		if c.Yubico {
+			methods = append(methods, "Yubico OTP")
+		}
+
+		if c.Recovery != nil {
+			methods = append(methods, "recovery keys")
+		}
+
+		return nil, fmt.Errorf(
+			"the user %q requires a second factor which is not supported by the provider (%s), "+
+				"only TOTP is supported; use an API token instead",
+			t.creds.Username, strings.Join(methods, ", "),
+		)
+	}
+
+	code := t.creds.OTPCode
+
+	switch {
+	case t.creds.OTPSecret != "":
+		var err error
+
+		code, err = totpCode(t.creds.OTPSecret, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	case code == "":
+		return nil, fmt.Errorf(
+			"the user %q requires a TOTP second factor, set either the OTP secret or the OTP code",
+			t.creds.Username,
+		)
+	case t.authData != nil:
+		return nil, errors.New(
+			"the authentication ticket must be renewed, but the static OTP code can't be used again; " +
+				"set the OTP secret to compute new codes instead",
+		)
+	}
+
+	authRequest := fmt.Sprintf(
+		"username=%s&tfa-challenge=%s&password=%s",
+		url.QueryEscape(t.creds.Username),
+		url.QueryEscape(*challenge.Ticket),
+		url.QueryEscape("totp:"+code),
+	)
+
+	data, err := t.requestTicket(ctx, authRequest, *challenge.CSRFPreventionToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete the TOTP second factor: %w", err)
+	}
+
+	if data.NeedTFA == 1 {
+		return nil, errors.New("the server did not accept the TOTP second factor")
+	}
+
+	return data, nil
+}
+
+// requestTicket sends a login request, and returns the response data.
+func (t *userAuthenticator) requestTicket(
+	ctx context.Context,
+	authRequest string,
+	csrfPreventionToken string,
+) (*AuthenticationResponseData, error) {
+	endpoint, endpointURL := t.conn.currentEndpoint()
+
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
-		fmt.Sprintf("%s/%s/access/ticket", t.conn.endpoint, basePathJSONAPI),
-		bytes.NewBufferString(t.authRequest),
+		fmt.Sprintf("%s/%s/access/ticket", endpointURL, basePathJSONAPI),
+		bytes.NewBufferString(authRequest),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authentication request: %w", err)
@@ -74,12 +204,16 @@ func (t *userAuthenticator) authenticate(ctx context.Context) (*AuthenticationRe
 
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
+	if csrfPreventionToken != "" {
+		req.Header.Add("CSRFPreventionToken", csrfPreventionToken)
+	}
+
 	tflog.Debug(ctx, "Sending authentication request", map[string]interface{}{
 		"path": req.URL.Path,
 	})
 
 	//nolint:bodyclose
-	res, err := t.conn.httpClient.Do(req)
+	res, err := t.conn.do(req, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve authentication response: %w", err)
 	}
@@ -102,23 +236,31 @@ func (t *userAuthenticator) authenticate(ctx context.Context) (*AuthenticationRe
 		return nil, errors.New("the server did not include a data object in the authentication response")
 	}
 
-	if resBody.Data.CSRFPreventionToken == nil {
-		return nil, errors.New(
-			"the server did not include a CSRF prevention token in the authentication response",
-		)
-	}
+	return resBody.Data, nil
+}
 
-	if resBody.Data.Ticket == nil {
-		return nil, errors.New("the server did not include a ticket in the authentication response")
+// parseTFAChallenge decodes the second factors listed in a challenge ticket,
+// e.g. `PVE:!tfa!%7B%22totp%22%3Atrue%7D:...`.
+func parseTFAChallenge(ticket string) (*tfaChallenge, bool) {
+	_, challenge, found := strings.Cut(ticket, "!tfa!")
+	if !found {
+		return nil, false
 	}
 
-	if resBody.Data.Username == "" {
-		return nil, errors.New("the server did not include the username in the authentication response")
+	challenge, _, _ = strings.Cut(challenge, ":")
+
+	decoded, err := url.QueryUnescape(challenge)
+	if err != nil {
+		return nil, false
 	}
 
-	t.authData = resBody.Data
+	c := &tfaChallenge{}
 
-	return resBody.Data, nil
+	if err := json.Unmarshal([]byte(decoded), c); err != nil {
+		return nil, false
+	}
+
+	return c, true
 }
 
 func (t *userAuthenticator) IsRoot(ctx context.Context) bool {
@@ -0,0 +1,156 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// newTFAServer returns a server whose logins require the second factors of the given challenge.
+func newTFAServer(t *testing.T, challenge string, logins *int) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		var data map[string]interface{}
+
+		if r.PostForm.Get("tfa-challenge") == "" {
+			data = map[string]interface{}{
+				"username":            r.PostForm.Get("username"),
+				"ticket":              "PVE:!tfa!" + url.QueryEscape(challenge) + ":65F1A2B3::sig",
+				"CSRFPreventionToken": "65F1A2B3:challenge",
+				"NeedTFA":             1,
+			}
+		} else {
+			code, err := totpCode(testOTPSecret, time.Now())
+			require.NoError(t, err)
+
+			if r.Header.Get("CSRFPreventionToken") != "65F1A2B3:challenge" || r.PostForm.Get("password") != "totp:"+code {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			*logins++
+
+			data = map[string]interface{}{
+				"username":            r.PostForm.Get("username"),
+				"ticket":              "PVE:user@pve:65F1A2B3::sig",
+				"CSRFPreventionToken": "65F1A2B3:token",
+			}
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"data": data}))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestUserAuthenticatorTOTP(t *testing.T) {
+	t.Parallel()
+
+	logins := 0
+	server := newTFAServer(t, `{"totp":true,"recovery":["available"]}`, &logins)
+
+	conn, err := NewConnection(server.URL, true, "")
+	require.NoError(t, err)
+
+	auth := NewUserAuthenticator(UserCredentials{
+		Username:  "user@pve",
+		Password:  "password",
+		OTPSecret: testOTPSecret,
+	}, conn).(*userAuthenticator)
+
+	data, err := auth.authenticate(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, "PVE:user@pve:65F1A2B3::sig", *data.Ticket)
+
+	// an old ticket is renewed with a new code
+	auth.authTime = time.Now().Add(-ticketRefreshAge)
+
+	_, err = auth.authenticate(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 2, logins)
+}
+
+func TestUserAuthenticatorStaticOTPCode(t *testing.T) {
+	t.Parallel()
+
+	logins := 0
+	server := newTFAServer(t, `{"totp":true}`, &logins)
+
+	conn, err := NewConnection(server.URL, true, "")
+	require.NoError(t, err)
+
+	code, err := totpCode(testOTPSecret, time.Now())
+	require.NoError(t, err)
+
+	auth := NewUserAuthenticator(UserCredentials{
+		Username: "user@pve",
+		Password: "password",
+		OTPCode:  code,
+	}, conn).(*userAuthenticator)
+
+	_, err = auth.authenticate(t.Context())
+	require.NoError(t, err)
+
+	auth.authTime = time.Now().Add(-ticketRefreshAge)
+
+	_, err = auth.authenticate(t.Context())
+	require.ErrorContains(t, err, "the static OTP code can't be used again")
+}
+
+func TestUserAuthenticatorUnsupportedTFA(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		challenge string
+		creds     UserCredentials
+		wantErr   string
+	}{
+		{
+			name:      "no code",
+			challenge: `{"totp":true}`,
+			creds:     UserCredentials{Username: "user@pve", Password: "password"},
+			wantErr:   "requires a TOTP second factor",
+		},
+		{
+			name:      "webauthn",
+			challenge: `{"webauthn":{"challenge":"x"},"recovery":["available"]}`,
+			creds:     UserCredentials{Username: "user@pve", Password: "password", OTPSecret: testOTPSecret},
+			wantErr:   "not supported by the provider (WebAuthn, recovery keys)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logins := 0
+			server := newTFAServer(t, tt.challenge, &logins)
+
+			conn, err := NewConnection(server.URL, true, "")
+			require.NoError(t, err)
+
+			_, err = NewUserAuthenticator(tt.creds, conn).(*userAuthenticator).authenticate(t.Context())
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 )
 
@@ -84,7 +85,8 @@ type ACMEPluginsUpdateRequestBody struct {
 // DNSPluginData is a map of DNS plugin data.
 type DNSPluginData map[string]string
 
-// EncodeValues encodes the DNSPluginData into the URL values.
+// EncodeValues encodes the DNSPluginData into the URL values, as a base64 encoded blob of `key=value` lines.
+// The lines are sorted by key, so the same data is always encoded the same way.
 func (d DNSPluginData) EncodeValues(key string, v *url.Values) error {
 	values := make([]string, 0, len(d))
 
@@ -92,6 +94,8 @@ func (d DNSPluginData) EncodeValues(key string, v *url.Values) error {
 		values = append(values, fmt.Sprintf("%s=%s", key, value))
 	}
 
+	sort.Strings(values)
+
 	v.Add(key, base64.StdEncoding.EncodeToString([]byte(strings.Join(values, "\n"))))
 
 	return nil
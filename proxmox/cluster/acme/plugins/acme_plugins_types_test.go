@@ -0,0 +1,42 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package plugins
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSPluginData_EncodeValues(t *testing.T) {
+	t.Parallel()
+
+	data := DNSPluginData{
+		"CF_Token":      "token",
+		"CF_Account_ID": "account",
+		"CF_Zone_ID":    "zone=1",
+	}
+
+	v := url.Values{}
+	require.NoError(t, data.EncodeValues("data", &v))
+
+	blob, err := base64.StdEncoding.DecodeString(v.Get("data"))
+	require.NoError(t, err)
+	assert.Equal(t, "CF_Account_ID=account\nCF_Token=token\nCF_Zone_ID=zone=1", string(blob))
+
+	// the blob is read back as a JSON string
+	encoded, err := json.Marshal(string(blob))
+	require.NoError(t, err)
+
+	decoded := DNSPluginData{}
+	require.NoError(t, decoded.UnmarshalJSON(encoded))
+	assert.Equal(t, data, decoded)
+}
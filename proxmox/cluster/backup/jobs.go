@@ -0,0 +1,67 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// GetJob retrieves a backup job.
+func (c *Client) GetJob(ctx context.Context, id string) (*JobData, error) {
+	resBody := &JobResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(url.PathEscape(id)), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error reading backup job %s: %w", id, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// CreateJob creates a backup job.
+func (c *Client) CreateJob(ctx context.Context, data *JobRequestData) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath(""), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating backup job: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateJob updates a backup job.
+func (c *Client) UpdateJob(ctx context.Context, data *JobRequestData) error {
+	id := data.ID
+
+	// the ID is part of the path, PVE does not accept it in PUT requests
+	data.ID = ""
+
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath(url.PathEscape(id)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating backup job %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// DeleteJob deletes a backup job. The backups created by the job are not affected.
+func (c *Client) DeleteJob(ctx context.Context, id string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath(url.PathEscape(id)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting backup job %s: %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// JobData contains the data of a backup job.
+type JobData struct {
+	ID               string            `json:"id"                          url:"id,omitempty"`
+	All              *types.CustomBool `json:"all,omitempty"               url:"all,omitempty,int"`
+	BandwidthLimit   *int64            `json:"bwlimit,omitempty"           url:"bwlimit,omitempty"`
+	Comment          *string           `json:"comment,omitempty"           url:"comment,omitempty"`
+	Compress         *string           `json:"compress,omitempty"          url:"compress,omitempty"`
+	Enabled          *types.CustomBool `json:"enabled,omitempty"           url:"enabled,omitempty,int"`
+	MailNotification *string           `json:"mailnotification,omitempty"  url:"mailnotification,omitempty"`
+	MailTo           *string           `json:"mailto,omitempty"            url:"mailto,omitempty"`
+	Mode             *string           `json:"mode,omitempty"              url:"mode,omitempty"`
+	Node             *string           `json:"node,omitempty"              url:"node,omitempty"`
+	NotesTemplate    *string           `json:"notes-template,omitempty"    url:"notes-template,omitempty"`
+	NotificationMode *string           `json:"notification-mode,omitempty" url:"notification-mode,omitempty"`
+	Pool             *string           `json:"pool,omitempty"              url:"pool,omitempty"`
+	PruneBackups     *PruneBackups     `json:"prune-backups,omitempty"     url:"prune-backups,omitempty"`
+	Schedule         string            `json:"schedule"                    url:"schedule,omitempty"`
+	Storage          *string           `json:"storage,omitempty"           url:"storage,omitempty"`
+	VMIDs            *string           `json:"vmid,omitempty"              url:"vmid,omitempty"`
+}
+
+// JobResponseBody contains the body from a backup job response.
+type JobResponseBody struct {
+	Data *JobData `json:"data,omitempty"`
+}
+
+// JobRequestData contains the data for a backup job post/put request.
+type JobRequestData struct {
+	JobData
+
+	Delete []string `url:"delete,omitempty,comma"`
+}
+
+// PruneBackups contains the retention options of a backup job, e.g. `keep-last` => `3`.
+type PruneBackups map[string]string
+
+// EncodeValues encodes the retention options into the `keep-last=3,keep-daily=7` format.
+func (p PruneBackups) EncodeValues(key string, v *url.Values) error {
+	if len(p) == 0 {
+		return nil
+	}
+
+	v.Add(key, p.String())
+
+	return nil
+}
+
+// String returns the retention options in the `keep-last=3,keep-daily=7` format, ordered by key.
+func (p PruneBackups) String() string {
+	options := make([]string, 0, len(p))
+
+	for k, v := range p {
+		options = append(options, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	sort.Strings(options)
+
+	return strings.Join(options, ",")
+}
+
+// UnmarshalJSON parses the retention options, which PVE returns either as a string or as an object.
+func (p *PruneBackups) UnmarshalJSON(b []byte) error {
+	result := PruneBackups{}
+
+	var s string
+
+	if err := json.Unmarshal(b, &s); err == nil {
+		for _, option := range strings.Split(s, ",") {
+			if strings.TrimSpace(option) == "" {
+				continue
+			}
+
+			k, v, found := strings.Cut(option, "=")
+			if !found {
+				return fmt.Errorf("invalid prune-backups option %q", option)
+			}
+
+			result[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+
+		*p = result
+
+		return nil
+	}
+
+	var m map[string]json.RawMessage
+
+	if err := json.Unmarshal(b, &m); err != nil {
+		return fmt.Errorf("error unmarshalling prune-backups: %w", err)
+	}
+
+	for k, raw := range m {
+		var v string
+
+		if err := json.Unmarshal(raw, &v); err != nil {
+			// numeric values
+			v = string(raw)
+		}
+
+		result[k] = v
+	}
+
+	*p = result
+
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backup
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneBackupsUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    PruneBackups
+		wantErr bool
+	}{
+		{"string", `"keep-last=3,keep-daily=7"`, PruneBackups{"keep-last": "3", "keep-daily": "7"}, false},
+		{"object", `{"keep-last":3,"keep-all":"0"}`, PruneBackups{"keep-last": "3", "keep-all": "0"}, false},
+		{"empty string", `""`, PruneBackups{}, false},
+		{"invalid", `"keep-last"`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var p PruneBackups
+
+			err := json.Unmarshal([]byte(tt.input), &p)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, p)
+		})
+	}
+}
+
+func TestPruneBackupsEncodeValues(t *testing.T) {
+	t.Parallel()
+
+	v := url.Values{}
+
+	require.NoError(t, PruneBackups{"keep-weekly": "4", "keep-last": "3"}.EncodeValues("prune-backups", &v))
+	assert.Equal(t, "keep-last=3,keep-weekly=4", v.Get("prune-backups"))
+}
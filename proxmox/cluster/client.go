@@ -11,10 +11,17 @@ import (
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/acme"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/backup"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/config"
 	clusterfirewall "github.com/bpg/terraform-provider-proxmox/proxmox/cluster/firewall"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/ha"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/mapping"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/metrics"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/notifications"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/replication"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/subnets"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/vnets"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/zones"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/firewall"
 )
@@ -51,22 +58,47 @@ func (c *Client) ACME() *acme.Client {
 	return &acme.Client{Client: c}
 }
 
+// Backup returns a client for managing the cluster's backup jobs.
+func (c *Client) Backup() *backup.Client {
+	return &backup.Client{Client: c}
+}
+
+// Config returns a client for managing the cluster configuration and joining nodes to the cluster.
+func (c *Client) Config() *config.Client {
+	return &config.Client{Client: c}
+}
+
 // Metrics returns a client for managing the cluster's metrics features.
 func (c *Client) Metrics() *metrics.Client {
 	return &metrics.Client{Client: c}
 }
 
+// Notifications returns a client for managing the cluster's notification endpoints and matchers.
+func (c *Client) Notifications() *notifications.Client {
+	return &notifications.Client{Client: c}
+}
+
+// Replication returns a client for managing the cluster's storage replication jobs.
+func (c *Client) Replication() *replication.Client {
+	return &replication.Client{Client: c}
+}
+
 // SDNZones returns a client for managing the cluster's SDN zones.
 func (c *Client) SDNZones() *zones.Client {
 	return &zones.Client{Client: c}
 }
 
-// // SDNVnets returns a client for managing the cluster's SDN Vnets.
-// func (c *Client) SDNVnets() *vnets.Client {
-// 	return &vnets.Client{Client: c}
-// }
+// SDN returns a client for applying the cluster's SDN configuration.
+func (c *Client) SDN() *sdn.Client {
+	return &sdn.Client{Client: c}
+}
+
+// SDNVnets returns a client for managing the cluster's SDN VNets.
+func (c *Client) SDNVnets() *vnets.Client {
+	return &vnets.Client{Client: c}
+}
 
-// // SDNSubnets returns a client for managing the cluster's SDN Subnets.
-// func (c *Client) SDNSubnets() *subnets.Client {
-// 	return &subnets.Client{Client: c}
-// }
+// SDNSubnets returns a client for managing the SDN subnets of the VNet.
+func (c *Client) SDNSubnets(vnetID string) *subnets.Client {
+	return &subnets.Client{Client: c, VnetID: vnetID}
+}
@@ -11,12 +11,22 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 )
 
-// ErrVMDoesNotExist is returned when the VM identifier cannot be found on any cluster node.
-var ErrVMDoesNotExist = errors.New("unable to find VM identifier on any cluster node")
+var (
+	// ErrVMDoesNotExist is returned when the VM identifier cannot be found on any cluster node.
+	ErrVMDoesNotExist = errors.New("unable to find VM identifier on any cluster node")
+
+	// ErrDatastoreNotAvailable is returned when the datastore is not available on any online cluster node.
+	ErrDatastoreNotAvailable = errors.New("unable to find the datastore on any online cluster node")
+
+	// ErrNodeDoesNotExist is returned when the node identifier cannot be found among the cluster nodes.
+	ErrNodeDoesNotExist = errors.New("unable to find the node identifier among the cluster nodes")
+)
 
 // GetNextID retrieves the next free VM identifier for the cluster.
 func (c *Client) GetNextID(ctx context.Context, vmID *int) (*int, error) {
@@ -62,6 +72,60 @@ func (c *Client) GetClusterResourcesVM(ctx context.Context) ([]*ResourcesListRes
 	return c.GetClusterResources(ctx, "vm")
 }
 
+// GetClusterStatus retrieves the status of the cluster and its nodes. A standalone node
+// only reports itself, without an entry of the "cluster" type.
+func (c *Client) GetClusterStatus(ctx context.Context) ([]*StatusResponseData, error) {
+	resBody := &StatusResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, "cluster/status", nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster status: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// ResolveNodeName returns the name of the node, which is either given by its name, or by its cluster node ID.
+// The cluster nodes are only listed when the node is a number, which is then matched against the node names
+// first, so that a node named e.g. "1" is still resolved by its name.
+func (c *Client) ResolveNodeName(ctx context.Context, node string) (string, error) {
+	nodeID, err := strconv.Atoi(node)
+	if err != nil {
+		return node, nil
+	}
+
+	status, err := c.GetClusterStatus(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the node %q: %w", node, err)
+	}
+
+	var name string
+
+	for _, s := range status {
+		if s.Type != "node" {
+			continue
+		}
+
+		if s.Name == node {
+			return s.Name, nil
+		}
+
+		if s.NodeID != nil && int(*s.NodeID) == nodeID {
+			name = s.Name
+		}
+	}
+
+	if name == "" {
+		return "", fmt.Errorf("%w: %q", ErrNodeDoesNotExist, node)
+	}
+
+	return name, nil
+}
+
 // GetVMNodeName gets node for specified vmID.
 func (c *Client) GetVMNodeName(ctx context.Context, vmID int) (*string, error) {
 	allClusterVM, err := c.GetClusterResourcesVM(ctx)
@@ -81,3 +145,62 @@ func (c *Client) GetVMNodeName(ctx context.Context, vmID int) (*string, error) {
 
 	return nil, ErrVMDoesNotExist
 }
+
+// GetDatastoreNodeName gets the least busy online node hosting the specified datastore. The datastore
+// must be shared, unless only a single node hosts it, as the content of a local datastore depends on the node.
+func (c *Client) GetDatastoreNodeName(ctx context.Context, datastoreID string) (string, error) {
+	nodeResources, err := c.GetClusterResources(ctx, "node")
+	if err != nil {
+		return "", err
+	}
+
+	storageResources, err := c.GetClusterResources(ctx, "storage")
+	if err != nil {
+		return "", err
+	}
+
+	nodes := map[string]*ResourcesListResponseData{}
+
+	for _, r := range nodeResources {
+		if r.Status == "online" {
+			nodes[r.NodeName] = r
+		}
+	}
+
+	var (
+		candidates []*ResourcesListResponseData
+		shared     bool
+		hosts      int
+	)
+
+	for _, r := range storageResources {
+		if r.Storage != datastoreID {
+			continue
+		}
+
+		hosts++
+		shared = shared || r.Shared == 1
+
+		if node, ok := nodes[r.NodeName]; ok && r.Status == "available" {
+			candidates = append(candidates, node)
+		}
+	}
+
+	if hosts > 1 && !shared {
+		return "", fmt.Errorf("the datastore %q is not shared, and is hosted by multiple nodes", datastoreID)
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrDatastoreNotAvailable, datastoreID)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].CPU != candidates[j].CPU {
+			return candidates[i].CPU < candidates[j].CPU
+		}
+
+		return candidates[i].NodeName < candidates[j].NodeName
+	})
+
+	return candidates[0].NodeName, nil
+}
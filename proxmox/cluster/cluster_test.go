@@ -0,0 +1,63 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// statusClient serves the cluster status of a cluster whose nodes are named by their IDs.
+type statusClient struct {
+	api.Client
+
+	nodes map[int]string
+	calls int
+}
+
+func (c *statusClient) DoRequest(_ context.Context, _, _ string, _, responseBody interface{}) error {
+	c.calls++
+
+	res := responseBody.(*StatusResponseBody)
+	res.Data = []*StatusResponseData{{Type: "cluster", ID: "cluster", Name: "lab"}}
+
+	for id, name := range c.nodes {
+		nodeID := types.CustomInt(id)
+		res.Data = append(res.Data, &StatusResponseData{Type: "node", ID: "node/" + name, Name: name, NodeID: &nodeID})
+	}
+
+	return nil
+}
+
+func TestResolveNodeName(t *testing.T) {
+	t.Parallel()
+
+	sc := &statusClient{nodes: map[int]string{1: "pve-a", 2: "pve-b", 3: "2"}}
+	c := &Client{Client: sc}
+
+	name, err := c.ResolveNodeName(context.Background(), "pve-a")
+	require.NoError(t, err)
+	assert.Equal(t, "pve-a", name)
+	assert.Equal(t, 0, sc.calls, "a node name is not resolved")
+
+	name, err = c.ResolveNodeName(context.Background(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, "pve-a", name)
+
+	name, err = c.ResolveNodeName(context.Background(), "2")
+	require.NoError(t, err)
+	assert.Equal(t, "2", name, "a node name takes precedence over a node ID")
+
+	_, err = c.ResolveNodeName(context.Background(), "7")
+	require.ErrorIs(t, err, ErrNodeDoesNotExist)
+}
@@ -48,8 +48,29 @@ type ResourcesListResponseData struct {
 	NodeName   string  `json:"node,omitempty"`
 	PluginType string  `json:"plugintype,omitempty"`
 	PoolName   string  `json:"poolname,omitempty"`
+	Shared     int     `json:"shared,omitempty"`
 	Status     string  `json:"status,omitempty"`
 	Storage    string  `json:"storage,omitempty"`
 	Uptime     int     `json:"uptime,omitempty"`
 	VMID       int     `json:"vmid,omitempty"`
 }
+
+// StatusResponseBody contains the body from a cluster status response.
+type StatusResponseBody struct {
+	Data []*StatusResponseData `json:"data,omitempty"`
+}
+
+// StatusResponseData contains the data of a cluster or node entry from a cluster status response.
+type StatusResponseData struct {
+	Type    string            `json:"type"`
+	ID      string            `json:"id"`
+	Name    string            `json:"name"`
+	IP      *string           `json:"ip,omitempty"`
+	Level   *string           `json:"level,omitempty"`
+	Local   *types.CustomBool `json:"local,omitempty"`
+	NodeID  *types.CustomInt  `json:"nodeid,omitempty"`
+	Nodes   *types.CustomInt  `json:"nodes,omitempty"`
+	Online  *types.CustomBool `json:"online,omitempty"`
+	Quorate *types.CustomBool `json:"quorate,omitempty"`
+	Version *types.CustomInt  `json:"version,omitempty"`
+}
@@ -0,0 +1,64 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// GetJoinInfo retrieves the information required to join a node to the cluster.
+// The node is the cluster node the information is about, or the node serving the request if nil.
+func (c *Client) GetJoinInfo(ctx context.Context, node *string) (*JoinInfoResponseData, error) {
+	resBody := &JoinInfoResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("join"), &JoinInfoRequestBody{Node: node}, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving cluster join information: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// Join joins the node serving the request to an existing cluster, and waits for the join task to complete.
+func (c *Client) Join(ctx context.Context, d *JoinRequestBody) error {
+	resBody := &JoinResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("join"), d, resBody)
+	if err != nil {
+		return fmt.Errorf("error joining the cluster: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return api.ErrNoDataObjectInResponse
+	}
+
+	err = c.Tasks().WaitForTask(ctx, *resBody.Data)
+	if err != nil {
+		return fmt.Errorf("error joining the cluster: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteNode removes a node from the cluster configuration.
+func (c *Client) DeleteNode(ctx context.Context, node string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath("nodes/"+url.PathEscape(node)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error removing node %q from the cluster: %w", node, err)
+	}
+
+	return nil
+}
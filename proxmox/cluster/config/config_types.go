@@ -0,0 +1,73 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// JoinInfoRequestBody contains the data for a cluster join information request.
+type JoinInfoRequestBody struct {
+	Node *string `url:"node,omitempty"`
+}
+
+// JoinInfoResponseBody contains the body from a cluster join information response.
+type JoinInfoResponseBody struct {
+	Data *JoinInfoResponseData `json:"data,omitempty"`
+}
+
+// JoinInfoResponseData contains the data from a cluster join information response.
+type JoinInfoResponseData struct {
+	ConfigDigest  string                     `json:"config_digest"`
+	NodeList      []JoinInfoNode             `json:"nodelist"`
+	PreferredNode string                     `json:"preferred_node"`
+	Totem         map[string]json.RawMessage `json:"totem"`
+}
+
+// JoinInfoNode contains the data of a cluster node from a cluster join information response.
+type JoinInfoNode struct {
+	Name           string           `json:"name"`
+	NodeID         *types.CustomInt `json:"nodeid,omitempty"`
+	PVEAddress     *string          `json:"pve_addr,omitempty"`
+	PVEFingerprint *string          `json:"pve_fp,omitempty"`
+	QuorumVotes    *types.CustomInt `json:"quorum_votes,omitempty"`
+	Ring0Address   *string          `json:"ring0_addr,omitempty"`
+}
+
+// JoinLinks contains the addresses of the corosync links of a joining node, indexed by the link number.
+type JoinLinks []string
+
+// EncodeValues converts the links to the "link<n>" URL parameters. The empty links are skipped.
+func (l JoinLinks) EncodeValues(_ string, v *url.Values) error {
+	for i, link := range l {
+		if link != "" {
+			v.Add(fmt.Sprintf("link%d", i), link)
+		}
+	}
+
+	return nil
+}
+
+// JoinRequestBody contains the data for a cluster join request.
+type JoinRequestBody struct {
+	Fingerprint string            `url:"fingerprint"`
+	Force       *types.CustomBool `url:"force,omitempty,int"`
+	Hostname    string            `url:"hostname"`
+	Links       JoinLinks         `url:"link,omitempty"`
+	NodeID      *int64            `url:"nodeid,omitempty"`
+	Password    string            `url:"password"`
+	Votes       *int64            `url:"votes,omitempty"`
+}
+
+// JoinResponseBody contains the body from a cluster join response.
+type JoinResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
@@ -0,0 +1,48 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-querystring/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinRequestBodyLinks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		links JoinLinks
+		want  string
+	}{
+		{"no links", nil, "fingerprint=AA&hostname=10.0.0.1&password=secret"},
+		{
+			"links",
+			JoinLinks{"address=10.0.0.3", "address=10.1.0.3,priority=10"},
+			"fingerprint=AA&hostname=10.0.0.1&link0=address%3D10.0.0.3&link1=address%3D10.1.0.3%2Cpriority%3D10&password=secret",
+		},
+		{"skipped link", JoinLinks{"", "address=10.1.0.3"}, "fingerprint=AA&hostname=10.0.0.1&link1=address%3D10.1.0.3&password=secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := query.Values(&JoinRequestBody{
+				Fingerprint: "AA",
+				Hostname:    "10.0.0.1",
+				Links:       tt.links,
+				Password:    "secret",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, v.Encode())
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+const (
+	// MinVMID is the lowest VM identifier accepted by PVE.
+	MinVMID = 100
+	// MaxVMID is the highest VM identifier accepted by PVE.
+	MaxVMID = 999999999
+
+	freeIDVerifyAttempts = 10
+)
+
+// ErrNoFreeID is returned when there is no free VM identifier in the requested range.
+var ErrNoFreeID = errors.New("no free VM identifier in range")
+
+// FindFreeID returns a free VM identifier in the [lower, upper] range. The identifiers in use are taken from
+// the cluster resources, and the candidate is confirmed with `cluster/nextid`, which also rejects the identifiers
+// reserved by VMs and containers that are being created. The search starts at a random offset of the range,
+// so concurrent callers are unlikely to pick the same identifier.
+func (c *Client) FindFreeID(ctx context.Context, lower int, upper int) (int, error) {
+	if lower < MinVMID || upper > MaxVMID || lower > upper {
+		return -1, fmt.Errorf("invalid VM identifier range [%d, %d]", lower, upper)
+	}
+
+	resources, err := c.GetClusterResourcesVM(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	used := make(map[int]struct{}, len(resources))
+	for _, r := range resources {
+		used[r.VMID] = struct{}{}
+	}
+
+	size := upper - lower + 1
+	offset := rand.Intn(size) //nolint:gosec
+	attempts := 0
+
+	for i := 0; i < size; i++ {
+		id := lower + (offset+i)%size
+		if _, ok := used[id]; ok {
+			continue
+		}
+
+		_, err = c.GetNextID(ctx, &id)
+		if err == nil {
+			return id, nil
+		}
+
+		if !IsIDTaken(err) {
+			return -1, err
+		}
+
+		attempts++
+		if attempts >= freeIDVerifyAttempts {
+			return -1, fmt.Errorf("unable to find a free VM identifier in range [%d, %d] after %d attempts: %w",
+				lower, upper, attempts, err)
+		}
+	}
+
+	return -1, fmt.Errorf("%w [%d, %d]", ErrNoFreeID, lower, upper)
+}
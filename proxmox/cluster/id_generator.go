@@ -14,11 +14,12 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/avast/retry-go/v4"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/rogpeppe/go-internal/lockedfile"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
@@ -28,6 +29,8 @@ const (
 	idGeneratorLockFile         = "terraform-provider-proxmox-id-gen.lock"
 	idGeneratorSequenceFile     = "terraform-provider-proxmox-id-gen.seq"
 	idGeneratorContentionWindow = 5 * time.Second
+	idGeneratorCreateAttempts   = 5
+	idGeneratorCreateMaxJitter  = 2 * time.Second
 )
 
 // IDGenerator is responsible for generating unique identifiers for VMs and Containers.
@@ -76,7 +79,8 @@ func NewIDGenerator(client *Client, config IDGeneratorConfig) IDGenerator {
 	return IDGenerator{client, config}
 }
 
-// NextID returns the next available VM identifier.
+// NextID returns the next available VM identifier. In the random mode, a random identifier in the configured
+// range is picked among the ones which are not used by any VM or container of the cluster.
 func (g IDGenerator) NextID(ctx context.Context) (int, error) {
 	// lock the ID generator to prevent concurrent access
 	// it should be unlocked only when the new ID is successfully
@@ -91,15 +95,21 @@ func (g IDGenerator) NextID(ctx context.Context) (int, error) {
 	ctx, cancel := context.WithTimeout(ctx, idGeneratorContentionWindow+time.Second)
 	defer cancel()
 
+	if g.config.RandomIDs {
+		id, err := g.client.FindFreeID(ctx, g.config.RandomIDStat, g.config.RandomIDEnd)
+		if err != nil {
+			return -1, fmt.Errorf("unable to retrieve a random free VM identifier: %w", err)
+		}
+
+		return id, nil
+	}
+
 	var newID *int
 
 	var errs []error
 
 	id, err := retry.DoWithData(func() (*int, error) {
-		if g.config.RandomIDs {
-			//nolint:gosec
-			newID = ptr.Ptr(rand.Intn(g.config.RandomIDEnd-g.config.RandomIDStat) + g.config.RandomIDStat)
-		} else if newID == nil {
+		if newID == nil {
 			newID, err = nextSequentialID(g.config.seqFName)
 			if err != nil {
 				return nil, err
@@ -109,7 +119,7 @@ func (g IDGenerator) NextID(ctx context.Context) (int, error) {
 		return g.client.GetNextID(ctx, newID)
 	},
 		retry.OnRetry(func(_ uint, err error) {
-			if strings.Contains(err.Error(), "already exists") && newID != nil {
+			if IsIDTaken(err) && newID != nil {
 				newID, err = g.client.GetNextID(ctx, nil)
 			}
 
@@ -125,14 +135,12 @@ func (g IDGenerator) NextID(ctx context.Context) (int, error) {
 		return -1, fmt.Errorf("unable to retrieve the next available VM identifier: %w", errors.Join(errs...))
 	}
 
-	if !g.config.RandomIDs {
-		var b bytes.Buffer
+	var b bytes.Buffer
 
-		_, _ = fmt.Fprintf(&b, "%d", *id)
+	_, _ = fmt.Fprintf(&b, "%d", *id)
 
-		if err := lockedfile.Write(g.config.seqFName, &b, 0o666); err != nil {
-			return -1, fmt.Errorf("unable to write the ID generator file: %w", err)
-		}
+	if err := lockedfile.Write(g.config.seqFName, &b, 0o666); err != nil {
+		return -1, fmt.Errorf("unable to write the ID generator file: %w", err)
 	}
 
 	return *id, nil
@@ -155,3 +163,50 @@ func nextSequentialID(seqFName string) (*int, error) {
 
 	return ptr.Ptr(id + 1), nil
 }
+
+// idTakenRegex matches the errors of Proxmox VE when the VM identifier is used, e.g. `VM 100 already exists on
+// node 'pve'` or `unable to create VM 100 - config file already exists`, but not the collisions of volumes.
+var idTakenRegex = regexp.MustCompile(`\b(?:VM|CT) \d+ already exists|config file already exists`)
+
+// IsIDTaken returns true if the error reports that the VM identifier is already used by another VM or container.
+func IsIDTaken(err error) bool {
+	return err != nil && idTakenRegex.MatchString(err.Error())
+}
+
+// CreateWithNextID creates a VM or container with the next available identifier using the given function,
+// and returns the identifier. If the creation fails because the identifier has been taken in the meantime,
+// e.g. by another Terraform run creating VMs concurrently, a new identifier is allocated and the creation
+// is retried, up to a bounded number of attempts.
+func (g IDGenerator) CreateWithNextID(ctx context.Context, create func(ctx context.Context, id int) error) (int, error) {
+	for attempt := 1; ; attempt++ {
+		id, err := g.NextID(ctx)
+		if err != nil {
+			return -1, err
+		}
+
+		err = create(ctx, id)
+		if err == nil {
+			return id, nil
+		}
+
+		if !IsIDTaken(err) || attempt >= idGeneratorCreateAttempts {
+			return -1, err
+		}
+
+		tflog.Warn(ctx, "VM identifier has been taken concurrently, retrying with a new one", map[string]interface{}{
+			"id":      id,
+			"attempt": attempt,
+			"error":   err.Error(),
+		})
+
+		// back off for a random period, so the concurrent runs diverge
+		//nolint:gosec
+		jitter := time.Duration(rand.Int63n(int64(idGeneratorCreateMaxJitter)))
+
+		select {
+		case <-ctx.Done():
+			return -1, fmt.Errorf("unable to create with a new VM identifier: %w", ctx.Err())
+		case <-time.After(jitter):
+		}
+	}
+}
@@ -0,0 +1,124 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// resourcesClient serves the cluster resources and the next ID of a cluster with the given VMs.
+type resourcesClient struct {
+	api.Client
+
+	used map[int]bool
+}
+
+func (c *resourcesClient) DoRequest(_ context.Context, _, path string, requestBody, responseBody interface{}) error {
+	switch path {
+	case "cluster/resources":
+		res := responseBody.(*ResourcesListBody)
+		res.Data = []*ResourcesListResponseData{}
+
+		for id := range c.used {
+			res.Data = append(res.Data, &ResourcesListResponseData{Type: "qemu", VMID: id})
+		}
+	case "cluster/nextid":
+		id := requestBody.(*NextIDRequestBody).VMID
+		if c.used[*id] {
+			return fmt.Errorf("VM %d already exists", *id)
+		}
+
+		res := responseBody.(*NextIDResponseBody)
+		res.Data = (*types.CustomInt)(id)
+	default:
+		return fmt.Errorf("unexpected path %q", path)
+	}
+
+	return nil
+}
+
+func TestIDGeneratorRandomIDs(t *testing.T) {
+	t.Parallel()
+
+	rc := &resourcesClient{used: map[int]bool{1000: true, 1001: true, 1002: true, 1004: true}}
+	g := NewIDGenerator(&Client{Client: rc}, IDGeneratorConfig{RandomIDs: true, RandomIDStat: 1000, RandomIDEnd: 1004})
+
+	id, err := g.NextID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1003, id, "the only free ID of the range is picked")
+
+	rc.used[1003] = true
+
+	_, err = g.NextID(context.Background())
+	require.ErrorIs(t, err, ErrNoFreeID)
+}
+
+func TestIDGeneratorCreateWithNextID(t *testing.T) {
+	t.Parallel()
+
+	rc := &resourcesClient{used: map[int]bool{}}
+	g := NewIDGenerator(&Client{Client: rc}, IDGeneratorConfig{RandomIDs: true, RandomIDStat: 2000, RandomIDEnd: 2001})
+
+	var attempts []int
+
+	id, err := g.CreateWithNextID(context.Background(), func(_ context.Context, id int) error {
+		attempts = append(attempts, id)
+
+		// the first ID is taken concurrently, after it has been allocated
+		if len(attempts) == 1 {
+			rc.used[id] = true
+
+			return fmt.Errorf("unable to create VM %d: config file already exists", id)
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, attempts, 2)
+	assert.NotEqual(t, attempts[0], id)
+	assert.Equal(t, attempts[1], id)
+
+	_, err = g.CreateWithNextID(context.Background(), func(_ context.Context, _ int) error {
+		return fmt.Errorf("storage 'local-lvm' does not exist")
+	})
+	require.ErrorContains(t, err, "does not exist")
+	assert.False(t, IsIDTaken(err))
+}
+
+func TestIsIDTaken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		err   error
+		taken bool
+	}{
+		{"nil", nil, false},
+		{"VM exists", errors.New("unable to create VM 100 - VM 100 already exists on node 'pve'"), true},
+		{"CT exists", errors.New("unable to create CT 100 - CT 100 already exists on node 'pve'"), true},
+		{"config exists", errors.New("unable to create VM 100: config file already exists"), true},
+		{"volume exists", errors.New("unable to create VM 100 - volume 'local-lvm:vm-100-disk-0' already exists"), false},
+		{"disk exists", errors.New("lvcreate 'pve/vm-100-disk-0' error: Logical Volume already exists"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.taken, IsIDTaken(tt.err))
+		})
+	}
+}
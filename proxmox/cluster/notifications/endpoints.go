@@ -0,0 +1,71 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+func (c *Client) endpointPath(endpointType string, name string) string {
+	return c.ExpandPath(fmt.Sprintf("endpoints/%s/%s", endpointType, url.PathEscape(name)))
+}
+
+// GetEndpoint retrieves a notification endpoint of the given type.
+func (c *Client) GetEndpoint(ctx context.Context, endpointType string, name string) (*EndpointData, error) {
+	resBody := &EndpointResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.endpointPath(endpointType, name), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s notification endpoint %s: %w", endpointType, name, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// CreateEndpoint creates a notification endpoint of the given type.
+func (c *Client) CreateEndpoint(ctx context.Context, endpointType string, data *EndpointRequestData) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("endpoints/"+endpointType), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating %s notification endpoint: %w", endpointType, err)
+	}
+
+	return nil
+}
+
+// UpdateEndpoint updates a notification endpoint of the given type.
+func (c *Client) UpdateEndpoint(ctx context.Context, endpointType string, data *EndpointRequestData) error {
+	name := data.Name
+
+	// the name is part of the path, PVE does not accept it in PUT requests
+	data.Name = ""
+
+	err := c.DoRequest(ctx, http.MethodPut, c.endpointPath(endpointType, name), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating %s notification endpoint %s: %w", endpointType, name, err)
+	}
+
+	return nil
+}
+
+// DeleteEndpoint deletes a notification endpoint of the given type.
+func (c *Client) DeleteEndpoint(ctx context.Context, endpointType string, name string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.endpointPath(endpointType, name), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting %s notification endpoint %s: %w", endpointType, name, err)
+	}
+
+	return nil
+}
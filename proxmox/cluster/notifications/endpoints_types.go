@@ -0,0 +1,63 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notifications
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+const (
+	// EndpointTypeSMTP is the type of SMTP notification endpoints.
+	EndpointTypeSMTP = "smtp"
+	// EndpointTypeGotify is the type of Gotify notification endpoints.
+	EndpointTypeGotify = "gotify"
+	// EndpointTypeWebhook is the type of webhook notification endpoints.
+	EndpointTypeWebhook = "webhook"
+)
+
+// EndpointData contains the data of a notification endpoint.
+type EndpointData struct {
+	Name    string            `json:"name"              url:"name,omitempty"`
+	Comment *string           `json:"comment,omitempty" url:"comment,omitempty"`
+	Disable *types.CustomBool `json:"disable,omitempty" url:"disable,omitempty,int"`
+	Origin  *string           `json:"origin,omitempty"  url:"-"`
+
+	// smtp and gotify options
+	Server *string `json:"server,omitempty" url:"server,omitempty"`
+
+	// smtp only options
+	Author      *string   `json:"author,omitempty"       url:"author,omitempty"`
+	FromAddress *string   `json:"from-address,omitempty" url:"from-address,omitempty"`
+	MailTo      *[]string `json:"mailto,omitempty"       url:"mailto,omitempty"`
+	MailToUser  *[]string `json:"mailto-user,omitempty"  url:"mailto-user,omitempty"`
+	Mode        *string   `json:"mode,omitempty"         url:"mode,omitempty"`
+	Password    *string   `json:"password,omitempty"     url:"password,omitempty"`
+	Port        *int64    `json:"port,omitempty"         url:"port,omitempty"`
+	Username    *string   `json:"username,omitempty"     url:"username,omitempty"`
+
+	// gotify only options
+	Token *string `json:"token,omitempty" url:"token,omitempty"`
+
+	// webhook only options, the body and the header / secret values are base64 encoded
+	Body   *string   `json:"body,omitempty"   url:"body,omitempty"`
+	Header *[]string `json:"header,omitempty" url:"header,omitempty"`
+	Method *string   `json:"method,omitempty" url:"method,omitempty"`
+	Secret *[]string `json:"secret,omitempty" url:"secret,omitempty"`
+	URL    *string   `json:"url,omitempty"    url:"url,omitempty"`
+}
+
+// EndpointResponseBody contains the body from a notification endpoint response.
+type EndpointResponseBody struct {
+	Data *EndpointData `json:"data,omitempty"`
+}
+
+// EndpointRequestData contains the data for a notification endpoint post/put request.
+type EndpointRequestData struct {
+	EndpointData
+
+	Delete []string `url:"delete,omitempty,comma"`
+}
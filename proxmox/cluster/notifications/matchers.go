@@ -0,0 +1,67 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// GetMatcher retrieves a notification matcher.
+func (c *Client) GetMatcher(ctx context.Context, name string) (*MatcherData, error) {
+	resBody := &MatcherResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("matchers/"+url.PathEscape(name)), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error reading notification matcher %s: %w", name, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// CreateMatcher creates a notification matcher.
+func (c *Client) CreateMatcher(ctx context.Context, data *MatcherRequestData) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("matchers"), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating notification matcher: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateMatcher updates a notification matcher.
+func (c *Client) UpdateMatcher(ctx context.Context, data *MatcherRequestData) error {
+	name := data.Name
+
+	// the name is part of the path, PVE does not accept it in PUT requests
+	data.Name = ""
+
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath("matchers/"+url.PathEscape(name)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating notification matcher %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// DeleteMatcher deletes a notification matcher.
+func (c *Client) DeleteMatcher(ctx context.Context, name string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath("matchers/"+url.PathEscape(name)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting notification matcher %s: %w", name, err)
+	}
+
+	return nil
+}
@@ -7,19 +7,198 @@
 package cluster
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
 )
 
-type userTagAccess struct {
+// UserTagAccess contains the `user-tag-access` cluster option.
+type UserTagAccess struct {
 	UserAllowList *[]string `json:"user-allow-list,omitempty"`
 	UserAllow     *string   `json:"user-allow,omitempty"`
 }
-type tagStyle struct {
+
+// String returns the property string representation of the user tag access option.
+func (u *UserTagAccess) String() string {
+	var params []string
+
+	if u.UserAllow != nil {
+		params = append(params, fmt.Sprintf("user-allow=%s", *u.UserAllow))
+	}
+
+	if u.UserAllowList != nil && len(*u.UserAllowList) > 0 {
+		params = append(params, fmt.Sprintf("user-allow-list=%s", strings.Join(*u.UserAllowList, ";")))
+	}
+
+	return strings.Join(params, ",")
+}
+
+// TagStyle contains the `tag-style` cluster option.
+type TagStyle struct {
 	Shape         *string           `json:"shape,omitempty"`
 	CaseSensitive *types.CustomBool `json:"case-sensitive,omitempty"`
 	Ordering      *string           `json:"ordering,omitempty"`
 	ColorMap      *string           `json:"color-map,omitempty"`
 }
+
+// String returns the property string representation of the tag style option.
+func (t *TagStyle) String() string {
+	var params []string
+
+	if t.CaseSensitive != nil {
+		params = append(params, fmt.Sprintf("case-sensitive=%d", boolToInt(bool(*t.CaseSensitive))))
+	}
+
+	if t.ColorMap != nil && *t.ColorMap != "" {
+		params = append(params, fmt.Sprintf("color-map=%s", *t.ColorMap))
+	}
+
+	if t.Ordering != nil {
+		params = append(params, fmt.Sprintf("ordering=%s", *t.Ordering))
+	}
+
+	if t.Shape != nil {
+		params = append(params, fmt.Sprintf("shape=%s", *t.Shape))
+	}
+
+	return strings.Join(params, ",")
+}
+
+// ParseTagColorMap parses the color map of the tag style option, i.e. `tag:background[:text];...`,
+// into a map of tag names to `background[:text]` colors.
+func ParseTagColorMap(colorMap string) map[string]string {
+	res := map[string]string{}
+
+	for _, entry := range strings.Split(colorMap, ";") {
+		tag, colors, found := strings.Cut(entry, ":")
+		if !found || tag == "" {
+			continue
+		}
+
+		res[tag] = colors
+	}
+
+	return res
+}
+
+// FormatTagColorMap returns the color map of the tag style option for the given map of tag names to
+// `background[:text]` colors. The tags are sorted to keep the result stable.
+func FormatTagColorMap(colors map[string]string) string {
+	tags := make([]string, 0, len(colors))
+	for tag := range colors {
+		tags = append(tags, tag)
+	}
+
+	sort.Strings(tags)
+
+	entries := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		entries = append(entries, fmt.Sprintf("%s:%s", tag, colors[tag]))
+	}
+
+	return strings.Join(entries, ";")
+}
+
+// BandwidthLimit contains the I/O bandwidth limits in KiB/s of the `bwlimit` cluster option.
+type BandwidthLimit struct {
+	Clone     *int64
+	Default   *int64
+	Migration *int64
+	Move      *int64
+	Restore   *int64
+
+	// Other contains the limits that are not known to the provider, they are kept as is,
+	// so an update doesn't discard limits set outside the provider.
+	Other map[string]string
+}
+
+// ParseBandwidthLimit parses the property string of the bandwidth limit option.
+func ParseBandwidthLimit(value string) (*BandwidthLimit, error) {
+	res := &BandwidthLimit{Other: map[string]string{}}
+
+	if value == "" {
+		return res, nil
+	}
+
+	for _, param := range strings.Split(value, ",") {
+		key, val, found := strings.Cut(param, "=")
+		if !found {
+			return nil, fmt.Errorf("failed to parse bandwidth limit: %s", value)
+		}
+
+		var target **int64
+
+		switch key {
+		case "clone":
+			target = &res.Clone
+		case "default":
+			target = &res.Default
+		case "migration":
+			target = &res.Migration
+		case "move":
+			target = &res.Move
+		case "restore":
+			target = &res.Restore
+		default:
+			res.Other[key] = val
+			continue
+		}
+
+		limit, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse bandwidth limit: %s", value)
+		}
+
+		*target = &limit
+	}
+
+	return res, nil
+}
+
+// String returns the property string representation of the bandwidth limit option.
+func (b *BandwidthLimit) String() string {
+	var params []string
+
+	for _, l := range []struct {
+		key   string
+		value *int64
+	}{
+		{"clone", b.Clone},
+		{"default", b.Default},
+		{"migration", b.Migration},
+		{"move", b.Move},
+		{"restore", b.Restore},
+	} {
+		if l.value != nil {
+			params = append(params, fmt.Sprintf("%s=%d", l.key, *l.value))
+		}
+	}
+
+	keys := make([]string, 0, len(b.Other))
+	for key := range b.Other {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		params = append(params, fmt.Sprintf("%s=%s", key, b.Other[key]))
+	}
+
+	return strings.Join(params, ",")
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
 type crs struct {
 	HaRebalanceOnStart *types.CustomBool `json:"ha-rebalance-on-start,omitempty"`
 	HA                 *string           `json:"ha,omitempty"`
@@ -72,12 +251,12 @@ type OptionsResponseData struct {
 	MaxWorkers                *types.CustomInt64 `json:"max_workers,omitempty"`
 	ClusterResourceScheduling *crs               `json:"crs,omitempty"`
 	HASettings                *haSettings        `json:"ha,omitempty"`
-	TagStyle                  *tagStyle          `json:"tag-style,omitempty"`
+	TagStyle                  *TagStyle          `json:"tag-style,omitempty"`
 	Migration                 *migration         `json:"migration,omitempty"`
 	Webauthn                  *webauthn          `json:"webauthn,omitempty"`
 	NextID                    *nextID            `json:"next-id,omitempty"`
 	Notify                    *notify            `json:"notify,omitempty"`
-	UserTagAccess             *userTagAccess     `json:"user-tag-access,omitempty"`
+	UserTagAccess             *UserTagAccess     `json:"user-tag-access,omitempty"`
 	RegisteredTags            *[]string          `json:"registered-tags,omitempty"`
 }
 
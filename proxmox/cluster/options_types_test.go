@@ -0,0 +1,67 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+func TestBandwidthLimit(t *testing.T) {
+	t.Parallel()
+
+	limit, err := ParseBandwidthLimit("migration=100,clone=200,future=300")
+	require.NoError(t, err)
+
+	assert.Equal(t, ptr.Ptr(int64(200)), limit.Clone)
+	assert.Equal(t, ptr.Ptr(int64(100)), limit.Migration)
+	assert.Nil(t, limit.Default)
+	assert.Equal(t, map[string]string{"future": "300"}, limit.Other)
+
+	// unknown limits are passed through
+	limit.Migration = nil
+	limit.Restore = ptr.Ptr(int64(400))
+	assert.Equal(t, "clone=200,restore=400,future=300", limit.String())
+
+	_, err = ParseBandwidthLimit("clone")
+	require.Error(t, err)
+
+	_, err = ParseBandwidthLimit("clone=fast")
+	require.Error(t, err)
+}
+
+func TestTagStyle(t *testing.T) {
+	t.Parallel()
+
+	colorMap := FormatTagColorMap(map[string]string{
+		"prod": "FF0000:FFFFFF",
+		"dev":  "00FF00",
+	})
+	assert.Equal(t, "dev:00FF00;prod:FF0000:FFFFFF", colorMap)
+	assert.Equal(t, map[string]string{
+		"prod": "FF0000:FFFFFF",
+		"dev":  "00FF00",
+	}, ParseTagColorMap(colorMap))
+
+	style := &TagStyle{
+		CaseSensitive: types.CustomBool(false).Pointer(),
+		ColorMap:      &colorMap,
+		Shape:         ptr.Ptr("dense"),
+	}
+	assert.Equal(t, "case-sensitive=0,color-map=dev:00FF00;prod:FF0000:FFFFFF,shape=dense", style.String())
+
+	access := &UserTagAccess{
+		UserAllow:     ptr.Ptr("list"),
+		UserAllowList: &[]string{"a", "b"},
+	}
+	assert.Equal(t, "user-allow=list,user-allow-list=a;b", access.String())
+}
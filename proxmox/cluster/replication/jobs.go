@@ -0,0 +1,87 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// ListJobs retrieves the replication jobs of the cluster.
+func (c *Client) ListJobs(ctx context.Context) ([]*JobData, error) {
+	resBody := &JobListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(""), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing replication jobs: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// GetJob retrieves a replication job.
+func (c *Client) GetJob(ctx context.Context, id string) (*JobData, error) {
+	resBody := &JobResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(url.PathEscape(id)), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error reading replication job %s: %w", id, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// CreateJob creates a replication job. PVE rejects the job if the guest has volumes
+// on a storage that does not support replication, i.e. anything but ZFS.
+func (c *Client) CreateJob(ctx context.Context, data *JobRequestData) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath(""), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating replication job %s: %w", data.ID, err)
+	}
+
+	return nil
+}
+
+// UpdateJob updates a replication job.
+func (c *Client) UpdateJob(ctx context.Context, data *JobRequestData) error {
+	id := data.ID
+
+	// the ID, type and target are fixed, PVE does not accept them in PUT requests
+	data.ID = ""
+	data.Type = ""
+	data.Target = ""
+
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath(url.PathEscape(id)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating replication job %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// DeleteJob marks a replication job for removal. The job is removed by the replication runner,
+// which also removes the replicated volumes from the target node unless they are kept.
+func (c *Client) DeleteJob(ctx context.Context, id string, data *JobDeleteRequestData) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath(url.PathEscape(id)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting replication job %s: %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// JobTypeLocal is the only replication job type supported by PVE.
+const JobTypeLocal = "local"
+
+// JobData contains the data of a replication job.
+// RemoveJob is set once the job has been marked for removal, until the replication runner removes it.
+type JobData struct {
+	ID        string            `json:"id"                   url:"id,omitempty"`
+	Comment   *string           `json:"comment,omitempty"    url:"comment,omitempty"`
+	Disable   *types.CustomBool `json:"disable,omitempty"    url:"disable,omitempty,int"`
+	Guest     *int              `json:"guest,omitempty"      url:"-"`
+	JobNum    *int              `json:"jobnum,omitempty"     url:"-"`
+	Rate      *float64          `json:"rate,omitempty"       url:"rate,omitempty"`
+	RemoveJob *string           `json:"remove_job,omitempty" url:"-"`
+	Schedule  *string           `json:"schedule,omitempty"   url:"schedule,omitempty"`
+	Source    *string           `json:"source,omitempty"     url:"source,omitempty"`
+	Target    string            `json:"target"               url:"target,omitempty"`
+	Type      string            `json:"type"                 url:"type,omitempty"`
+}
+
+// JobResponseBody contains the body from a replication job response.
+type JobResponseBody struct {
+	Data *JobData `json:"data,omitempty"`
+}
+
+// JobListResponseBody contains the body from a replication job list response.
+type JobListResponseBody struct {
+	Data []*JobData `json:"data,omitempty"`
+}
+
+// JobRequestData contains the data for a replication job post/put request.
+type JobRequestData struct {
+	JobData
+
+	Delete []string `url:"delete,omitempty,comma"`
+}
+
+// JobDeleteRequestData contains the data for a replication job delete request.
+type JobDeleteRequestData struct {
+	// Force removes the job configuration immediately, without cleaning up the replicated volumes.
+	Force *types.CustomBool `url:"force,omitempty,int"`
+	// Keep keeps the replicated volumes on the target node when the job is removed.
+	Keep *types.CustomBool `url:"keep,omitempty,int"`
+}
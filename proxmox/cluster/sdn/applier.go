@@ -0,0 +1,95 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultApplyDelay is the default time to wait for further changes before applying the SDN configuration.
+const DefaultApplyDelay = 3 * time.Second
+
+// Applier applies the pending SDN configuration. The apply is debounced, i.e. the changes requested
+// within the delay of each other, e.g. by several SDN resources of the same plan, are applied together,
+// so the network configuration of the nodes is only reloaded once.
+type Applier struct {
+	apply func(ctx context.Context) error
+	delay time.Duration
+
+	mu   sync.Mutex
+	next *applyRun
+
+	// only one apply may run at a time
+	running sync.Mutex
+}
+
+type applyRun struct {
+	ctx   context.Context //nolint:containedctx
+	timer *time.Timer
+	done  chan struct{}
+	err   error
+}
+
+// NewApplier creates a new Applier which applies the SDN configuration using the given client.
+func NewApplier(client *Client, delay time.Duration) *Applier {
+	return &Applier{
+		apply: client.ApplyConfig,
+		delay: delay,
+	}
+}
+
+// Apply requests the pending SDN configuration to be applied, and waits until an apply that started
+// after the request has completed.
+func (a *Applier) Apply(ctx context.Context) error {
+	a.mu.Lock()
+
+	run := a.next
+
+	// the timer of the scheduled run has already fired, the run can't include this request anymore
+	if run != nil && !run.timer.Stop() {
+		run = nil
+	}
+
+	if run == nil {
+		run = &applyRun{
+			ctx:  context.WithoutCancel(ctx),
+			done: make(chan struct{}),
+		}
+		run.timer = time.AfterFunc(a.delay, func() { a.start(run) })
+		a.next = run
+	} else {
+		run.timer.Reset(a.delay)
+	}
+
+	a.mu.Unlock()
+
+	select {
+	case <-run.done:
+		return run.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *Applier) start(run *applyRun) {
+	a.mu.Lock()
+
+	if a.next == run {
+		a.next = nil
+	}
+
+	a.mu.Unlock()
+
+	a.running.Lock()
+	defer a.running.Unlock()
+
+	run.err = a.apply(run.ctx)
+
+	close(run.done)
+}
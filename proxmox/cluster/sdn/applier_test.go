@@ -0,0 +1,67 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplierDebounce(t *testing.T) {
+	t.Parallel()
+
+	var applied atomic.Int32
+
+	a := &Applier{
+		apply: func(_ context.Context) error {
+			applied.Add(1)
+
+			return nil
+		},
+		delay: 50 * time.Millisecond,
+	}
+
+	var wg sync.WaitGroup
+
+	for i := range 5 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			time.Sleep(time.Duration(i) * 10 * time.Millisecond)
+			assert.NoError(t, a.Apply(context.Background()))
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, int32(1), applied.Load())
+
+	// a request after the apply triggers another apply
+	require.NoError(t, a.Apply(context.Background()))
+	assert.Equal(t, int32(2), applied.Load())
+}
+
+func TestApplierError(t *testing.T) {
+	t.Parallel()
+
+	a := &Applier{
+		apply: func(_ context.Context) error {
+			return errors.New("reload failed")
+		},
+		delay: time.Millisecond,
+	}
+
+	require.EqualError(t, a.Apply(context.Background()), "reload failed")
+}
@@ -0,0 +1,116 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/tasks"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// GetPendingState retrieves the pending state of the SDN object at the given path, e.g. `zones/zone1`.
+// An empty state is returned if the object has no pending changes.
+func (c *Client) GetPendingState(ctx context.Context, path string) (string, error) {
+	resBody := &PendingResponseBody{}
+
+	reqData := &PendingRequestData{Pending: types.CustomBool(true).Pointer()}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(path), reqData, resBody)
+	if err != nil {
+		return "", fmt.Errorf("error reading the pending state of SDN object %s: %w", path, err)
+	}
+
+	if resBody.Data == nil {
+		return "", api.ErrNoDataObjectInResponse
+	}
+
+	if resBody.Data.State == nil {
+		return "", nil
+	}
+
+	return *resBody.Data.State, nil
+}
+
+// ApplyConfig applies the pending SDN configuration and waits for the network reload tasks
+// of all nodes to complete.
+func (c *Client) ApplyConfig(ctx context.Context) error {
+	resBody := &ApplyResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath(""), nil, resBody)
+	if err != nil {
+		return fmt.Errorf("error applying SDN configuration: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return api.ErrNoDataObjectInResponse
+	}
+
+	applyTaskID, err := tasks.ParseTaskID(*resBody.Data)
+	if err != nil {
+		return fmt.Errorf("error applying SDN configuration: %w", err)
+	}
+
+	if err = c.Tasks().WaitForTask(ctx, *resBody.Data); err != nil {
+		return fmt.Errorf("error applying SDN configuration: %w", err)
+	}
+
+	// the apply task only triggers the reload of the network configuration on each node,
+	// which runs as a separate task on the node
+	reloadTasks, err := c.getReloadTasks(ctx, applyTaskID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, upid := range reloadTasks {
+		tflog.Debug(ctx, "Waiting for the SDN reload task", map[string]interface{}{
+			"upid": upid,
+		})
+
+		if err = c.Tasks().WaitForTask(ctx, upid); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("error reloading the network configuration: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// getReloadTasks returns the network reload tasks started by the given apply task.
+func (c *Client) getReloadTasks(ctx context.Context, applyTaskID tasks.TaskID) ([]string, error) {
+	resBody := &ClusterTasksResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, "cluster/tasks", nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing the SDN reload tasks: %w", err)
+	}
+
+	var reloadTasks []string
+
+	for _, task := range resBody.Data {
+		taskID, err := tasks.ParseTaskID(task.UPID)
+		if err != nil || taskID.Type != reloadTaskType || taskID.ID != reloadTaskID ||
+			taskID.StartTime.Before(applyTaskID.StartTime) {
+			continue
+		}
+
+		reloadTasks = append(reloadTasks, task.UPID)
+	}
+
+	return reloadTasks, nil
+}
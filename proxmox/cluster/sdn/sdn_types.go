@@ -0,0 +1,51 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+
+const (
+	// StateNew is the pending state of an SDN object that has not been applied yet.
+	StateNew = "new"
+	// StateChanged is the pending state of an SDN object with changes that have not been applied yet.
+	StateChanged = "changed"
+	// StateDeleted is the pending state of an SDN object whose removal has not been applied yet.
+	StateDeleted = "deleted"
+
+	reloadTaskType = "srvreload"
+	reloadTaskID   = "networking"
+)
+
+// PendingRequestData contains the query of a request for the pending state of SDN objects.
+type PendingRequestData struct {
+	Pending *types.CustomBool `url:"pending,omitempty,int"`
+}
+
+// PendingData contains the pending state of an SDN object. The state is not set if the object is applied.
+type PendingData struct {
+	State *string `json:"state,omitempty"`
+}
+
+// PendingResponseBody contains the body from a request for the pending state of an SDN object.
+type PendingResponseBody struct {
+	Data *PendingData `json:"data,omitempty"`
+}
+
+// ApplyResponseBody contains the body from an SDN apply response.
+type ApplyResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
+
+// ClusterTaskData contains the data of a cluster task.
+type ClusterTaskData struct {
+	UPID string `json:"upid"`
+}
+
+// ClusterTasksResponseBody contains the body from a cluster tasks response.
+type ClusterTasksResponseBody struct {
+	Data []*ClusterTaskData `json:"data,omitempty"`
+}
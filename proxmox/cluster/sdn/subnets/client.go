@@ -0,0 +1,26 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package subnets
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// Client is a client for accessing the Proxmox SDN subnets API of a VNet.
+type Client struct {
+	api.Client
+
+	VnetID string
+}
+
+// ExpandPath returns the API path for the SDN subnets of the VNet.
+func (c *Client) ExpandPath(path string) string {
+	return fmt.Sprintf("cluster/sdn/vnets/%s/subnets/%s", url.PathEscape(c.VnetID), path)
+}
@@ -0,0 +1,84 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package subnets
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// GetSubnet retrieves a single SDN subnet by ID.
+func (c *Client) GetSubnet(ctx context.Context, id string) (*SubnetData, error) {
+	resBody := &SubnetResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(url.PathEscape(id)), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SDN subnet %s: %w", id, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// GetSubnets lists all SDN subnets of the VNet.
+func (c *Client) GetSubnets(ctx context.Context) ([]SubnetData, error) {
+	resBody := &SubnetsResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(""), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing SDN subnets of VNet %s: %w", c.VnetID, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return *resBody.Data, nil
+}
+
+// CreateSubnet creates a new SDN subnet. The ID of the request data must be the CIDR of the subnet.
+func (c *Client) CreateSubnet(ctx context.Context, data *SubnetRequestData) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath(""), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating SDN subnet %s: %w", data.ID, err)
+	}
+
+	return nil
+}
+
+// UpdateSubnet updates an existing SDN subnet.
+func (c *Client) UpdateSubnet(ctx context.Context, data *SubnetRequestData) error {
+	id := data.ID
+
+	// the ID and type are fixed, PVE does not accept them in PUT requests
+	data.ID = ""
+	data.Type = nil
+
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath(url.PathEscape(id)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating SDN subnet %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// DeleteSubnet deletes an SDN subnet by ID.
+func (c *Client) DeleteSubnet(ctx context.Context, id string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath(url.PathEscape(id)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting SDN subnet %s: %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package subnets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// TypeSubnet is the only SDN subnet type supported by PVE.
+const TypeSubnet = "subnet"
+
+// SubnetData contains the data of an SDN subnet.
+// The ID of a subnet is `<zone>-<network>-<mask>`, e.g. `zone1-10.0.0.0-24`, but subnets are created by CIDR.
+type SubnetData struct {
+	ID            string            `json:"subnet"                    url:"subnet,omitempty"`
+	CIDR          *string           `json:"cidr,omitempty"            url:"-"`
+	DHCPDNSServer *string           `json:"dhcp-dns-server,omitempty" url:"dhcp-dns-server,omitempty"`
+	DHCPRanges    DHCPRanges        `json:"dhcp-range,omitempty"      url:"dhcp-range,omitempty"`
+	DNSZonePrefix *string           `json:"dnszoneprefix,omitempty"   url:"dnszoneprefix,omitempty"`
+	Gateway       *string           `json:"gateway,omitempty"         url:"gateway,omitempty"`
+	SNAT          *types.CustomBool `json:"snat,omitempty"            url:"snat,omitempty,int"`
+	Type          *string           `json:"type,omitempty"            url:"type,omitempty"`
+	Vnet          *string           `json:"vnet,omitempty"            url:"-"`
+	Zone          *string           `json:"zone,omitempty"            url:"-"`
+}
+
+// SubnetRequestData wraps a SubnetData struct with optional delete instructions.
+type SubnetRequestData struct {
+	SubnetData
+
+	Delete []string `url:"delete,omitempty,comma"`
+}
+
+// SubnetResponseBody represents the response for a single subnet.
+type SubnetResponseBody struct {
+	Data *SubnetData `json:"data"`
+}
+
+// SubnetsResponseBody represents the response for a list of subnets.
+type SubnetsResponseBody struct {
+	Data *[]SubnetData `json:"data"`
+}
+
+// DHCPRange is a range of addresses to lease to the guests via DHCP.
+type DHCPRange struct {
+	StartAddress string `json:"start-address"`
+	EndAddress   string `json:"end-address"`
+}
+
+// String returns the range in the `start-address=<ip>,end-address=<ip>` format.
+func (r DHCPRange) String() string {
+	return fmt.Sprintf("start-address=%s,end-address=%s", r.StartAddress, r.EndAddress)
+}
+
+// DHCPRanges is a list of DHCP ranges.
+type DHCPRanges []DHCPRange
+
+// EncodeValues encodes each range as a separate value of the key.
+func (r DHCPRanges) EncodeValues(key string, v *url.Values) error {
+	for _, dr := range r {
+		v.Add(key, dr.String())
+	}
+
+	return nil
+}
+
+// UnmarshalJSON parses the DHCP ranges, which PVE returns either as a list of strings, as a list of objects,
+// or as a single comma-separated string.
+func (r *DHCPRanges) UnmarshalJSON(b []byte) error {
+	var ranges []json.RawMessage
+
+	if err := json.Unmarshal(b, &ranges); err != nil {
+		var s string
+
+		if err = json.Unmarshal(b, &s); err != nil {
+			return fmt.Errorf("failed to unmarshal DHCP ranges: %w", err)
+		}
+
+		*r, err = parseDHCPRanges(s)
+
+		return err
+	}
+
+	result := make(DHCPRanges, 0, len(ranges))
+
+	for _, raw := range ranges {
+		var s string
+
+		if err := json.Unmarshal(raw, &s); err == nil {
+			parsed, err := parseDHCPRanges(s)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, parsed...)
+
+			continue
+		}
+
+		var dr DHCPRange
+
+		if err := json.Unmarshal(raw, &dr); err != nil {
+			return fmt.Errorf("failed to unmarshal DHCP range: %w", err)
+		}
+
+		result = append(result, dr)
+	}
+
+	*r = result
+
+	return nil
+}
+
+// parseDHCPRanges parses one or more DHCP ranges in the `start-address=<ip>,end-address=<ip>` format,
+// separated by commas.
+func parseDHCPRanges(s string) (DHCPRanges, error) {
+	var (
+		result  DHCPRanges
+		current *DHCPRange
+	)
+
+	for _, part := range strings.Split(s, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			return nil, fmt.Errorf("invalid DHCP range %q", s)
+		}
+
+		switch key {
+		case "start-address":
+			result = append(result, DHCPRange{StartAddress: value})
+			current = &result[len(result)-1]
+		case "end-address":
+			if current == nil || current.EndAddress != "" {
+				return nil, fmt.Errorf("invalid DHCP range %q: end address without start address", s)
+			}
+
+			current.EndAddress = value
+		default:
+			return nil, fmt.Errorf("invalid DHCP range %q: unexpected key %q", s, key)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package subnets
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDHCPRangesUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	want := DHCPRanges{
+		{StartAddress: "10.0.0.10", EndAddress: "10.0.0.20"},
+		{StartAddress: "10.0.0.100", EndAddress: "10.0.0.200"},
+	}
+
+	tests := map[string]string{
+		"list of strings": `["start-address=10.0.0.10,end-address=10.0.0.20","start-address=10.0.0.100,end-address=10.0.0.200"]`,
+		"list of objects": `[{"start-address":"10.0.0.10","end-address":"10.0.0.20"},` +
+			`{"start-address":"10.0.0.100","end-address":"10.0.0.200"}]`,
+		"string": `"start-address=10.0.0.10,end-address=10.0.0.20,start-address=10.0.0.100,end-address=10.0.0.200"`,
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var r DHCPRanges
+
+			require.NoError(t, json.Unmarshal([]byte(input), &r))
+			assert.Equal(t, want, r)
+		})
+	}
+
+	var r DHCPRanges
+
+	require.Error(t, json.Unmarshal([]byte(`"end-address=10.0.0.20"`), &r))
+}
+
+func TestDHCPRangesEncodeValues(t *testing.T) {
+	t.Parallel()
+
+	v := url.Values{}
+
+	r := DHCPRanges{
+		{StartAddress: "10.0.0.10", EndAddress: "10.0.0.20"},
+		{StartAddress: "10.0.0.100", EndAddress: "10.0.0.200"},
+	}
+
+	require.NoError(t, r.EncodeValues("dhcp-range", &v))
+	assert.Equal(t, []string{
+		"start-address=10.0.0.10,end-address=10.0.0.20",
+		"start-address=10.0.0.100,end-address=10.0.0.200",
+	}, v["dhcp-range"])
+}
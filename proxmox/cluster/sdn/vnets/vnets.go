@@ -0,0 +1,83 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vnets
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// GetVnet retrieves a single SDN VNet by ID.
+func (c *Client) GetVnet(ctx context.Context, id string) (*VnetData, error) {
+	resBody := &VnetResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(url.PathEscape(id)), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SDN VNet %s: %w", id, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// GetVnets lists all SDN VNets.
+func (c *Client) GetVnets(ctx context.Context) ([]VnetData, error) {
+	resBody := &VnetsResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(""), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing SDN VNets: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return *resBody.Data, nil
+}
+
+// CreateVnet creates a new SDN VNet.
+func (c *Client) CreateVnet(ctx context.Context, data *VnetRequestData) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath(""), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating SDN VNet: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateVnet updates an existing SDN VNet.
+func (c *Client) UpdateVnet(ctx context.Context, data *VnetRequestData) error {
+	id := data.ID
+
+	// the ID is part of the path, PVE does not accept it in PUT requests
+	data.ID = ""
+
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath(url.PathEscape(id)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating SDN VNet %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// DeleteVnet deletes an SDN VNet by ID.
+func (c *Client) DeleteVnet(ctx context.Context, id string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath(url.PathEscape(id)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting SDN VNet %s: %w", id, err)
+	}
+
+	return nil
+}
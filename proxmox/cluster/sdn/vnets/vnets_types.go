@@ -0,0 +1,35 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vnets
+
+import "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+
+// VnetData contains the data of an SDN VNet.
+type VnetData struct {
+	ID        string            `json:"vnet"                url:"vnet,omitempty"`
+	Zone      *string           `json:"zone,omitempty"      url:"zone,omitempty"`
+	Alias     *string           `json:"alias,omitempty"     url:"alias,omitempty"`
+	Tag       *int64            `json:"tag,omitempty"       url:"tag,omitempty"`
+	VLANAware *types.CustomBool `json:"vlanaware,omitempty" url:"vlanaware,omitempty,int"`
+}
+
+// VnetRequestData wraps a VnetData struct with optional delete instructions.
+type VnetRequestData struct {
+	VnetData
+
+	Delete []string `url:"delete,omitempty,comma"`
+}
+
+// VnetResponseBody represents the response for a single VNet.
+type VnetResponseBody struct {
+	Data *VnetData `json:"data"`
+}
+
+// VnetsResponseBody represents the response for a list of VNets.
+type VnetsResponseBody struct {
+	Data *[]VnetData `json:"data"`
+}
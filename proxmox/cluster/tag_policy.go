@@ -0,0 +1,211 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+)
+
+// TagPermission is the outcome of checking a tag change against the cluster tag policy.
+type TagPermission int
+
+const (
+	// TagAllowed means the tag can be set or removed.
+	TagAllowed TagPermission = iota
+	// TagRejected means PVE rejects setting or removing the tag.
+	TagRejected
+	// TagUnverified means PVE accepts the tag only if it is already used by another guest,
+	// which is not checked by the provider.
+	TagUnverified
+)
+
+// TagPolicy is the tag policy of the cluster, as defined by the `registered-tags` and `user-tag-access` options,
+// and the privileges of the API user.
+type TagPolicy struct {
+	// Privileged is set if the API user has `Sys.Modify` on `/`, which allows to set any tag.
+	Privileged     bool
+	RegisteredTags []string
+	UserAllow      string
+	UserAllowList  []string
+}
+
+// NewTagPolicy returns the tag policy defined by the cluster options.
+func NewTagPolicy(opts *OptionsResponseData, privileged bool) *TagPolicy {
+	p := &TagPolicy{
+		Privileged: privileged,
+		UserAllow:  "free",
+	}
+
+	if opts.RegisteredTags != nil {
+		p.RegisteredTags = *opts.RegisteredTags
+	}
+
+	if opts.UserTagAccess != nil {
+		if opts.UserTagAccess.UserAllow != nil && *opts.UserTagAccess.UserAllow != "" {
+			p.UserAllow = *opts.UserTagAccess.UserAllow
+		}
+
+		if opts.UserTagAccess.UserAllowList != nil {
+			p.UserAllowList = *opts.UserTagAccess.UserAllowList
+		}
+	}
+
+	return p
+}
+
+// CheckTag checks whether the API user can set or remove the tag, following the rules PVE applies on guest updates.
+func (p *TagPolicy) CheckTag(tag string) TagPermission {
+	if p.Privileged {
+		return TagAllowed
+	}
+
+	if slices.Contains(p.RegisteredTags, tag) {
+		return TagRejected
+	}
+
+	switch p.UserAllow {
+	case "none":
+		return TagRejected
+	case "list":
+		if slices.Contains(p.UserAllowList, tag) {
+			return TagAllowed
+		}
+
+		return TagRejected
+	case "existing":
+		if slices.Contains(p.UserAllowList, tag) {
+			return TagAllowed
+		}
+
+		return TagUnverified
+	default:
+		return TagAllowed
+	}
+}
+
+// TagValidator checks guest tag changes against the cluster tag policy. The policy is fetched once
+// and cached, so the validation doesn't add API calls for every guest in a plan.
+type TagValidator struct {
+	client *Client
+
+	once   sync.Once
+	policy *TagPolicy
+	err    error
+}
+
+// NewTagValidator creates a new TagValidator.
+func NewTagValidator(client *Client) *TagValidator {
+	return &TagValidator{client: client}
+}
+
+// Policy returns the cached tag policy of the cluster, fetching it on the first call.
+func (v *TagValidator) Policy(ctx context.Context) (*TagPolicy, error) {
+	v.once.Do(func() {
+		opts, err := v.client.GetOptions(ctx)
+		if err != nil {
+			v.err = fmt.Errorf("failed to read the cluster tag policy: %w", err)
+			return
+		}
+
+		accessClient := &access.Client{Client: v.client.Client}
+
+		perms, err := accessClient.GetPermissions(ctx, "/")
+		if err != nil {
+			v.err = fmt.Errorf("failed to read the API user permissions: %w", err)
+			return
+		}
+
+		_, privileged := perms["/"]["Sys.Modify"]
+
+		v.policy = NewTagPolicy(opts, privileged)
+	})
+
+	return v.policy, v.err
+}
+
+// Validate checks the tags added or removed by a guest update against the cluster tag policy. It returns
+// an error listing the tags PVE would reject, and a warning listing the tags that can't be verified.
+// If the policy can't be read, e.g. because the API user lacks `Sys.Audit` on `/`, the validation is skipped.
+func (v *TagValidator) Validate(ctx context.Context, oldTags, newTags []string) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+
+	changed := tagsSymmetricDifference(oldTags, newTags)
+	if len(changed) == 0 {
+		return "", nil
+	}
+
+	policy, err := v.Policy(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "skipping the validation of tags", map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return "", nil
+	}
+
+	var rejected, unverified []string
+
+	for _, tag := range changed {
+		switch policy.CheckTag(tag) {
+		case TagRejected:
+			rejected = append(rejected, tag)
+		case TagUnverified:
+			unverified = append(unverified, tag)
+		case TagAllowed:
+		}
+	}
+
+	var warning string
+
+	if len(unverified) > 0 {
+		warning = fmt.Sprintf(
+			"the cluster only allows tags already used by other guests, the tags %s may be rejected",
+			strings.Join(unverified, ", "),
+		)
+	}
+
+	if len(rejected) > 0 {
+		return warning, fmt.Errorf(
+			"the cluster tag policy (user-allow=%s) doesn't allow the API user to set or remove the tags %s, "+
+				"registered tags and tags not in the user allow list require Sys.Modify on /",
+			policy.UserAllow, strings.Join(rejected, ", "),
+		)
+	}
+
+	return warning, nil
+}
+
+func tagsSymmetricDifference(a, b []string) []string {
+	var res []string
+
+	for _, tag := range a {
+		if tag != "" && !slices.Contains(b, tag) && !slices.Contains(res, tag) {
+			res = append(res, tag)
+		}
+	}
+
+	for _, tag := range b {
+		if tag != "" && !slices.Contains(a, tag) && !slices.Contains(res, tag) {
+			res = append(res, tag)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
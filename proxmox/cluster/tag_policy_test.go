@@ -0,0 +1,62 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+func TestTagPolicyCheckTag(t *testing.T) {
+	t.Parallel()
+
+	opts := func(userAllow string) *OptionsResponseData {
+		return &OptionsResponseData{
+			RegisteredTags: &[]string{"prod"},
+			UserTagAccess: &UserTagAccess{
+				UserAllow:     ptr.Ptr(userAllow),
+				UserAllowList: &[]string{"web"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		opts       *OptionsResponseData
+		privileged bool
+		tag        string
+		want       TagPermission
+	}{
+		{"default policy", &OptionsResponseData{}, false, "any", TagAllowed},
+		{"privileged user", opts("none"), true, "prod", TagAllowed},
+		{"registered tag", opts("free"), false, "prod", TagRejected},
+		{"free", opts("free"), false, "any", TagAllowed},
+		{"none", opts("none"), false, "web", TagRejected},
+		{"list, allowed", opts("list"), false, "web", TagAllowed},
+		{"list, not allowed", opts("list"), false, "any", TagRejected},
+		{"existing, allowed", opts("existing"), false, "web", TagAllowed},
+		{"existing, not in list", opts("existing"), false, "any", TagUnverified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, NewTagPolicy(tt.opts, tt.privileged).CheckTag(tt.tag))
+		})
+	}
+}
+
+func TestTagsSymmetricDifference(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"a", "d"}, tagsSymmetricDifference([]string{"a", "b", "c"}, []string{"b", "c", "d", ""}))
+	assert.Nil(t, tagsSymmetricDifference([]string{"a"}, []string{"a"}))
+}
@@ -0,0 +1,98 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// logPageSize is the number of lines requested at once when reading the firewall log.
+const logPageSize = 500
+
+// Log is an interface for the Proxmox firewall log API.
+// The log is only available for nodes, VMs and containers, but not for the cluster.
+type Log interface {
+	GetLog(ctx context.Context, d *LogRequestParams) ([]*LogEntry, error)
+}
+
+// GetLog retrieves the entries of the firewall log, oldest first.
+func (c *Client) GetLog(ctx context.Context, d *LogRequestParams) ([]*LogEntry, error) {
+	reqBody := &logPageRequestBody{
+		Limit: logPageSize,
+	}
+
+	if d.Since != nil {
+		since := d.Since.Unix()
+		reqBody.Since = &since
+	}
+
+	lines := make([]*logPageResponseData, 0, logPageSize)
+	lastLineNumber := 0
+
+	for {
+		resBody := &logPageResponseBody{}
+
+		err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("firewall/log"), reqBody, resBody)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving firewall log: %w", err)
+		}
+
+		if resBody.Data == nil {
+			return nil, api.ErrNoDataObjectInResponse
+		}
+
+		total := 0
+		if resBody.Total != nil {
+			total = *resBody.Total
+		}
+
+		if total == 0 {
+			break
+		}
+
+		// the line numbers must advance, otherwise the server ignored the start offset
+		// and we would keep reading the same page
+		if len(resBody.Data) == 0 || resBody.Data[0].LineNumber <= lastLineNumber {
+			break
+		}
+
+		lines = append(lines, resBody.Data...)
+		lastLineNumber = resBody.Data[len(resBody.Data)-1].LineNumber
+		reqBody.Start += len(resBody.Data)
+
+		// skip straight to the requested number of lines at the end of the log
+		if d.Limit > 0 && total-d.Limit > reqBody.Start {
+			lines = lines[:0]
+			reqBody.Start = total - d.Limit
+		}
+
+		if len(resBody.Data) < logPageSize || reqBody.Start >= total {
+			break
+		}
+	}
+
+	if d.Limit > 0 && len(lines) > d.Limit {
+		lines = lines[len(lines)-d.Limit:]
+	}
+
+	entries := make([]*LogEntry, 0, len(lines))
+
+	for _, line := range lines {
+		// an empty log is reported as a single "no content" line
+		if line.LineText == "no content" {
+			continue
+		}
+
+		entries = append(entries, ParseLogLine(line.LineNumber, line.LineText))
+	}
+
+	return entries, nil
+}
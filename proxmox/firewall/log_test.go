@@ -0,0 +1,95 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// logClient serves a firewall log with the given number of lines, the way PVE pages it.
+type logClient struct {
+	api.Client
+
+	lines    int
+	requests int
+}
+
+func (c *logClient) ExpandPath(path string) string {
+	return "nodes/pve/" + path
+}
+
+func (c *logClient) DoRequest(_ context.Context, _, _ string, requestBody, responseBody interface{}) error {
+	c.requests++
+
+	req := requestBody.(*logPageRequestBody)
+	res := responseBody.(*logPageResponseBody)
+
+	total := c.lines
+	res.Total = &total
+	res.Data = []*logPageResponseData{}
+
+	if c.lines == 0 {
+		res.Data = append(res.Data, &logPageResponseData{LineNumber: 1, LineText: "no content"})
+
+		return nil
+	}
+
+	for n := req.Start + 1; n <= c.lines && n <= req.Start+req.Limit; n++ {
+		res.Data = append(res.Data, &logPageResponseData{
+			LineNumber: n,
+			LineText:   fmt.Sprintf("0 6 PVEFW-HOST-IN 17/Oct/2026:10:00:00 +0000 ACCEPT: SRC=10.0.0.%d", n%256),
+		})
+	}
+
+	return nil
+}
+
+func TestClientGetLog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		lines         int
+		limit         int
+		wantFirstLine int
+		wantEntries   int
+		wantRequests  int
+	}{
+		{"empty log", 0, 50, 0, 0, 1},
+		{"short log", 10, 50, 1, 10, 1},
+		{"tail of a long log", 1234, 50, 1185, 50, 2},
+		{"tail spanning pages", 1234, 800, 435, 800, 3},
+		{"whole log", 1234, 0, 1, 1234, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &logClient{lines: tt.lines}
+
+			entries, err := (&Client{Client: c}).GetLog(context.Background(), &LogRequestParams{Limit: tt.limit})
+			require.NoError(t, err)
+
+			require.Len(t, entries, tt.wantEntries)
+			assert.Equal(t, tt.wantRequests, c.requests)
+
+			if tt.wantEntries > 0 {
+				assert.Equal(t, tt.wantFirstLine, entries[0].LineNumber)
+				assert.Equal(t, tt.lines, entries[len(entries)-1].LineNumber)
+				assert.Equal(t, "ACCEPT", entries[0].Action)
+			}
+		})
+	}
+}
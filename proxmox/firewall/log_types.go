@@ -0,0 +1,159 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package firewall
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logTimestampLayout is the layout of the timestamps written by the PVE firewall logger.
+const logTimestampLayout = "02/Jan/2006:15:04:05 -0700"
+
+// LogRequestParams contains the parameters of a firewall log request.
+type LogRequestParams struct {
+	// Limit is the maximum number of entries to return, counted from the end of the log.
+	// All entries are returned if it is zero.
+	Limit int
+	// Since only includes the entries logged at or after this point in time, if set.
+	Since *time.Time
+}
+
+// logPageRequestBody contains the body for a request of a single page of the firewall log.
+type logPageRequestBody struct {
+	Limit int    `url:"limit"`
+	Since *int64 `url:"since,omitempty"`
+	Start int    `url:"start"`
+}
+
+// logPageResponseBody contains the body from a firewall log response.
+type logPageResponseBody struct {
+	Data  []*logPageResponseData `json:"data,omitempty"`
+	Total *int                   `json:"total,omitempty"`
+}
+
+// logPageResponseData contains a single line of a firewall log response.
+type logPageResponseData struct {
+	LineNumber int    `json:"n"`
+	LineText   string `json:"t"`
+}
+
+// LogEntry is a parsed line of the firewall log.
+type LogEntry struct {
+	// Action is the action applied to the packet, e.g. `ACCEPT`, `DROP` or `REJECT`.
+	Action string
+	// Chain is the firewall chain which logged the packet, e.g. `tap100i0-IN`.
+	Chain string
+	// Destination is the destination address of the packet.
+	Destination string
+	// DestinationPort is the destination port of the packet.
+	DestinationPort string
+	// Level is the syslog level of the entry.
+	Level int
+	// LineNumber is the number of the line in the log.
+	LineNumber int
+	// Message is the text of the entry following the timestamp.
+	Message string
+	// Policy indicates whether the action was applied by the chain policy rather than by a rule.
+	Policy bool
+	// Protocol is the protocol of the packet, e.g. `TCP`, `UDP` or `ICMP`.
+	Protocol string
+	// Source is the source address of the packet.
+	Source string
+	// SourcePort is the source port of the packet.
+	SourcePort string
+	// Text is the raw text of the line.
+	Text string
+	// Timestamp is the time at which the entry was logged.
+	Timestamp *time.Time
+	// VMID is the identifier of the guest the entry belongs to, or 0 for the host.
+	VMID int
+}
+
+// ParseLogLine parses a line of the firewall log.
+//
+// The PVE firewall logger writes lines in the following format:
+//
+//	101 6 tap101i0-IN 24/Oct/2016:12:06:48 +0200 policy DROP: IN=fwbr101i0 ... SRC=10.0.0.1 DST=10.0.0.101 ...
+//
+// Lines which don't follow the format, e.g. "no content", are returned with the raw text only.
+func ParseLogLine(lineNumber int, text string) *LogEntry {
+	entry := &LogEntry{
+		LineNumber: lineNumber,
+		Text:       text,
+		Message:    text,
+	}
+
+	fields := strings.SplitN(text, " ", 6)
+	if len(fields) < 5 {
+		return entry
+	}
+
+	vmID, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return entry
+	}
+
+	level, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return entry
+	}
+
+	timestamp, err := time.Parse(logTimestampLayout, fields[3]+" "+fields[4])
+	if err != nil {
+		return entry
+	}
+
+	entry.VMID = vmID
+	entry.Level = level
+	entry.Timestamp = &timestamp
+	entry.Message = ""
+
+	if fields[2] != "-" {
+		entry.Chain = fields[2]
+	}
+
+	if len(fields) == 6 {
+		entry.Message = fields[5]
+	}
+
+	prefix, packet, found := strings.Cut(entry.Message, ": ")
+	if !found {
+		return entry
+	}
+
+	words := strings.Fields(prefix)
+	if len(words) == 0 {
+		return entry
+	}
+
+	entry.Action = words[len(words)-1]
+	entry.Policy = len(words) > 1 && words[0] == "policy"
+
+	for _, field := range strings.Fields(packet) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "PROTO":
+			entry.Protocol = value
+		case "SRC":
+			entry.Source = value
+		case "DST":
+			entry.Destination = value
+		case "SPT":
+			entry.SourcePort = value
+		case "DPT":
+			entry.DestinationPort = value
+		}
+	}
+
+	return entry
+}
@@ -0,0 +1,80 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package firewall
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogLine(t *testing.T) {
+	t.Parallel()
+
+	t.Run("policy drop", func(t *testing.T) {
+		t.Parallel()
+
+		line := "101 6 tap101i0-IN 24/Oct/2016:12:06:48 +0200 policy DROP: IN=fwbr101i0 OUT=fwbr101i0 " +
+			"PHYSIN=fwln101o0 PHYSOUT=tap101i0 MAC=8a:1d:0e:c9:a3:3b:36:4d:c1:8f:31:f2:08:00 SRC=10.0.0.1 " +
+			"DST=10.0.0.101 LEN=60 TOS=0x00 PREC=0x00 TTL=64 ID=2417 DF PROTO=TCP SPT=43346 DPT=22 WINDOW=29200 " +
+			"RES=0x00 SYN URGP=0"
+
+		entry := ParseLogLine(7, line)
+
+		require.NotNil(t, entry.Timestamp)
+		assert.True(t, entry.Timestamp.Equal(time.Date(2016, time.October, 24, 10, 6, 48, 0, time.UTC)))
+		assert.Equal(t, 7, entry.LineNumber)
+		assert.Equal(t, 101, entry.VMID)
+		assert.Equal(t, 6, entry.Level)
+		assert.Equal(t, "tap101i0-IN", entry.Chain)
+		assert.Equal(t, "DROP", entry.Action)
+		assert.True(t, entry.Policy)
+		assert.Equal(t, "TCP", entry.Protocol)
+		assert.Equal(t, "10.0.0.1", entry.Source)
+		assert.Equal(t, "43346", entry.SourcePort)
+		assert.Equal(t, "10.0.0.101", entry.Destination)
+		assert.Equal(t, "22", entry.DestinationPort)
+		assert.Equal(t, line, entry.Text)
+	})
+
+	t.Run("rule accept without ports", func(t *testing.T) {
+		t.Parallel()
+
+		entry := ParseLogLine(1, "0 6 PVEFW-HOST-IN 17/Oct/2026:10:00:00 +0000 ACCEPT: IN=vmbr0 "+
+			"SRC=192.168.1.10 DST=192.168.1.1 LEN=84 PROTO=ICMP TYPE=8 CODE=0 ID=1 SEQ=1")
+
+		assert.Equal(t, 0, entry.VMID)
+		assert.Equal(t, "PVEFW-HOST-IN", entry.Chain)
+		assert.Equal(t, "ACCEPT", entry.Action)
+		assert.False(t, entry.Policy)
+		assert.Equal(t, "ICMP", entry.Protocol)
+		assert.Empty(t, entry.DestinationPort)
+	})
+
+	t.Run("logger message", func(t *testing.T) {
+		t.Parallel()
+
+		entry := ParseLogLine(1, "0 5 - 13/Oct/2016:11:16:45 +0200 starting pvefw logger")
+
+		require.NotNil(t, entry.Timestamp)
+		assert.Empty(t, entry.Chain)
+		assert.Empty(t, entry.Action)
+		assert.Equal(t, "starting pvefw logger", entry.Message)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		entry := ParseLogLine(1, "no content")
+
+		assert.Nil(t, entry.Timestamp)
+		assert.Equal(t, "no content", entry.Message)
+		assert.Equal(t, "no content", entry.Text)
+	})
+}
@@ -10,10 +10,16 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 )
 
+// digestRetries is the number of attempts to modify the repositories when they're modified concurrently.
+const digestRetries = 5
+
 // Client is an interface for accessing the Proxmox node APT repositories API.
 type Client struct {
 	api.Client
@@ -26,7 +32,9 @@ func (c *Client) basePath() string {
 
 // Add adds an APT standard repository entry.
 func (c *Client) Add(ctx context.Context, data *AddRequestBody) error {
-	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath(), data, nil)
+	err := c.withDigest(ctx, &data.baseData, func() error {
+		return c.DoRequest(ctx, http.MethodPut, c.ExpandPath(), data, nil)
+	})
 	if err != nil {
 		return fmt.Errorf("adding APT standard repository: %w", err)
 	}
@@ -57,7 +65,9 @@ func (c *Client) Get(ctx context.Context) (*GetResponseData, error) {
 
 // Modify modifies the activation status of an APT repository.
 func (c *Client) Modify(ctx context.Context, data *ModifyRequestBody) error {
-	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath(), data, nil)
+	err := c.withDigest(ctx, &data.baseData, func() error {
+		return c.DoRequest(ctx, http.MethodPost, c.ExpandPath(), data, nil)
+	})
 	if err != nil {
 		return fmt.Errorf(
 			`modifying APT repository in file %s at index %d to activation state %v: %w`,
@@ -70,3 +80,31 @@ func (c *Client) Modify(ctx context.Context, data *ModifyRequestBody) error {
 
 	return nil
 }
+
+// withDigest runs the request with the digest of the current repositories configuration, so that concurrent
+// modifications are detected by the API instead of being overwritten. The configuration is read again, and the
+// request is retried, when it was modified in the meantime.
+func (c *Client) withDigest(ctx context.Context, data *baseData, request func() error) error {
+	var err error
+
+	for attempt := 1; attempt <= digestRetries; attempt++ {
+		current, e := c.Get(ctx)
+		if e != nil {
+			return e
+		}
+
+		data.Digest = current.Digest
+
+		err = request()
+		if err == nil || !strings.Contains(err.Error(), "detected modified configuration") {
+			return err
+		}
+
+		tflog.Warn(ctx, "APT repositories were modified concurrently, retrying", map[string]interface{}{
+			"attempt": attempt,
+			"error":   err.Error(),
+		})
+	}
+
+	return err
+}
@@ -0,0 +1,32 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// ListCPUModels retrieves the list of the CPU models supported by the QEMU version of the node, including
+// the custom models defined in `/etc/pve/virtual-guest/cpu-models.conf`.
+func (c *Client) ListCPUModels(ctx context.Context) ([]*CPUModelListResponseData, error) {
+	resBody := &CPUModelListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("capabilities/qemu/cpu"), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CPU models of the node \"%s\": %w", c.NodeName, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
@@ -0,0 +1,25 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// CPUModelListResponseBody contains the body from a CPU model list response.
+type CPUModelListResponseBody struct {
+	Data []*CPUModelListResponseData `json:"data,omitempty"`
+}
+
+// CPUModelListResponseData contains the data from a CPU model list response.
+type CPUModelListResponseData struct {
+	// Custom is true for the models defined in `/etc/pve/virtual-guest/cpu-models.conf`, whose names are
+	// prefixed with `custom-`.
+	Custom types.CustomBool `json:"custom"`
+	Name   string           `json:"name"`
+	Vendor string           `json:"vendor"`
+}
@@ -40,6 +40,28 @@ func (c *Client) ListCertificates(ctx context.Context) (*[]CertificateListRespon
 	return resBody.Data, nil
 }
 
+// OrderACMECertificate orders a new certificate from the ACME account configured for a node, and waits for
+// the order to complete. The domains of the certificate are taken from the ACME settings of the node.
+func (c *Client) OrderACMECertificate(ctx context.Context, d *CertificateOrderACMERequestBody) error {
+	resBody := &CertificateOrderACMEResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("certificates/acme/certificate"), d, resBody)
+	if err != nil {
+		return fmt.Errorf("error ordering ACME certificate: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return api.ErrNoDataObjectInResponse
+	}
+
+	err = c.Tasks().WaitForTask(ctx, *resBody.Data)
+	if err != nil {
+		return fmt.Errorf("error waiting for ACME certificate order: %w", err)
+	}
+
+	return nil
+}
+
 // UpdateCertificate updates the custom certificate for a node.
 func (c *Client) UpdateCertificate(ctx context.Context, d *CertificateUpdateRequestBody) error {
 	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("certificates/custom"), d, nil)
@@ -34,6 +34,16 @@ type CertificateListResponseData struct {
 	SubjectAlternativeNames *[]string              `json:"san,omitempty"`
 }
 
+// CertificateOrderACMERequestBody contains the body for an ACME certificate order request.
+type CertificateOrderACMERequestBody struct {
+	Force *types.CustomBool `json:"force,omitempty" url:"force,omitempty,int"`
+}
+
+// CertificateOrderACMEResponseBody contains the body from an ACME certificate order response.
+type CertificateOrderACMEResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
+
 // CertificateUpdateRequestBody contains the body for a custom certificate update request.
 type CertificateUpdateRequestBody struct {
 	Certificates string            `json:"certificates"      url:"certificates"`
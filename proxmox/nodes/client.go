@@ -11,8 +11,10 @@ import (
 	"net/url"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/firewall"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/apt"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/containers"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/disks"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/storage"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/tasks"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
@@ -45,6 +47,20 @@ func (c *Client) Container(vmID int) *containers.Client {
 	}
 }
 
+// Disks returns a client for managing the local disks.
+func (c *Client) Disks() *disks.Client {
+	return &disks.Client{
+		Client: c,
+	}
+}
+
+// FirewallLog returns a client for reading the host firewall log.
+func (c *Client) FirewallLog() firewall.Log {
+	return &firewall.Client{
+		Client: c,
+	}
+}
+
 // VM returns a client for managing a specific VM.
 func (c *Client) VM(vmID int) *vms.Client {
 	return &vms.Client{
@@ -15,7 +15,7 @@ import (
 
 // ConfigGetResponseBody contains the body from a config get response.
 type ConfigGetResponseBody struct {
-	Data *[]ConfigGetResponseData `json:"data,omitempty"`
+	Data *ConfigGetResponseData `json:"data,omitempty"`
 }
 
 // ConfigGetResponseData contains the data from a config get response.
@@ -44,29 +44,53 @@ type ConfigGetResponseData struct {
 	WakeOnLan *WakeOnLandConfig `json:"wakeonlan,omitempty"`
 }
 
+// ACMEAccountName returns the name of the ACME account used by the node to order certificates, or an empty
+// string if no ACME domains are configured for the node. PVE falls back to the "default" account.
+func (d *ConfigGetResponseData) ACMEAccountName() string {
+	configured := d.ACME != nil && len(d.ACME.Domains) > 0
+
+	for _, domain := range []*ACMEDomainConfig{
+		d.ACMEDomain0, d.ACMEDomain1, d.ACMEDomain2, d.ACMEDomain3, d.ACMEDomain4, d.ACMEDomain5,
+	} {
+		if domain != nil {
+			configured = true
+		}
+	}
+
+	if !configured {
+		return ""
+	}
+
+	if d.ACME != nil && d.ACME.Account != nil {
+		return *d.ACME.Account
+	}
+
+	return "default"
+}
+
 // ConfigUpdateRequestBody contains the body for a config update request.
 type ConfigUpdateRequestBody struct {
 	// Node specific ACME settings.
-	ACME *ACMEConfig `json:"acme,omitempty"`
+	ACME *ACMEConfig `json:"acme,omitempty" url:"acme,omitempty"`
 	// ACME domain and validation plugin
-	ACMEDomain0 *ACMEDomainConfig `json:"acmedomain0,omitempty"`
+	ACMEDomain0 *ACMEDomainConfig `json:"acmedomain0,omitempty" url:"acmedomain0,omitempty"`
 	// ACME domain and validation plugin
-	ACMEDomain1 *ACMEDomainConfig `json:"acmedomain1,omitempty"`
+	ACMEDomain1 *ACMEDomainConfig `json:"acmedomain1,omitempty" url:"acmedomain1,omitempty"`
 	// ACME domain and validation plugin
-	ACMEDomain2 *ACMEDomainConfig `json:"acmedomain2,omitempty"`
+	ACMEDomain2 *ACMEDomainConfig `json:"acmedomain2,omitempty" url:"acmedomain2,omitempty"`
 	// ACME domain and validation plugin
-	ACMEDomain3 *ACMEDomainConfig `json:"acmedomain3,omitempty"`
+	ACMEDomain3 *ACMEDomainConfig `json:"acmedomain3,omitempty" url:"acmedomain3,omitempty"`
 	// ACME domain and validation plugin
-	ACMEDomain4 *ACMEDomainConfig `json:"acmedomain4,omitempty"`
-	Delete      *string           `json:"delete,omitempty"`
+	ACMEDomain4 *ACMEDomainConfig `json:"acmedomain4,omitempty" url:"acmedomain4,omitempty"`
+	Delete      *string           `json:"delete,omitempty" url:"delete,omitempty"`
 	// Description for the Node. Shown in the web-interface node notes panel. This is saved as comment inside the configuration file.
-	Description *string `json:"description,omitempty"`
+	Description *string `json:"description,omitempty" url:"description,omitempty"`
 	// Prevent changes if current configuration file has different SHA1 digest. This can be used to prevent concurrent modifications.
-	Digest *string `json:"digest,omitempty"`
+	Digest *string `json:"digest,omitempty" url:"digest,omitempty"`
 	// Initial delay in seconds, before starting all the Virtual Guests with on-boot enabled.
-	StartAllOnbootDelay *int `json:"startall-onboot-delay,omitempty"`
+	StartAllOnbootDelay *int `json:"startall-onboot-delay,omitempty" url:"startall-onboot-delay,omitempty"`
 	// Node specific wake on LAN settings.
-	WakeOnLan *WakeOnLandConfig `json:"wakeonlan,omitempty"`
+	WakeOnLan *WakeOnLandConfig `json:"wakeonlan,omitempty" url:"wakeonlan,omitempty"`
 }
 
 // ACMEConfig contains the ACME account / domains configuration that use the "standalone" plugin (http challenge).
@@ -10,6 +10,8 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/google/go-querystring/query"
+
 	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
 )
 
@@ -407,3 +409,58 @@ func TestWakeOnLandConfig_EncodeValues(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigGetResponseData_ACMEAccountName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config ConfigGetResponseData
+		want   string
+	}{
+		{"not configured", ConfigGetResponseData{}, ""},
+		{"account without domains", ConfigGetResponseData{ACME: &ACMEConfig{Account: ptr.Ptr("foo")}}, ""},
+		{"standalone domains", ConfigGetResponseData{ACME: &ACMEConfig{Domains: []string{"bar"}}}, "default"},
+		{
+			"dns domain",
+			ConfigGetResponseData{
+				ACME:        &ACMEConfig{Account: ptr.Ptr("foo")},
+				ACMEDomain3: &ACMEDomainConfig{Domain: "bar", Plugin: ptr.Ptr("dns")},
+			},
+			"foo",
+		},
+		{"dns domain with default account", ConfigGetResponseData{ACMEDomain0: &ACMEDomainConfig{Domain: "bar"}}, "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.config.ACMEAccountName(); got != tt.want {
+				t.Errorf("ACMEAccountName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigUpdateRequestBody_Encode(t *testing.T) {
+	t.Parallel()
+
+	body := &ConfigUpdateRequestBody{
+		Delete:              ptr.Ptr("description"),
+		StartAllOnbootDelay: ptr.Ptr(10),
+		WakeOnLan: &WakeOnLandConfig{
+			MACAddress:    "aa:bb:cc:dd:ee:ff",
+			BindInterface: ptr.Ptr("vmbr0"),
+		},
+	}
+
+	v, err := query.Values(body)
+	if err != nil {
+		t.Fatalf("query.Values() error = %v", err)
+	}
+
+	want := "delete=description&startall-onboot-delay=10&wakeonlan=aa%3Abb%3Acc%3Add%3Aee%3Aff%2Cbind-interface%3Dvmbr0"
+	if got := v.Encode(); got != want {
+		t.Errorf("Encode() = %v, want %v", got, want)
+	}
+}
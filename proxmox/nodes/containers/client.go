@@ -49,3 +49,10 @@ func (c *Client) Firewall() firewall.API {
 		Client: firewall.Client{Client: c},
 	}
 }
+
+// FirewallLog returns a client for reading the container firewall log.
+func (c *Client) FirewallLog() firewall.Log {
+	return &containerfirewall.Client{
+		Client: firewall.Client{Client: c},
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// CustomCPUModelPrefix is the prefix of the names of the custom CPU models.
+const CustomCPUModelPrefix = "custom-"
+
+// CPUModelValidator checks the CPU types of the VMs against the CPU models supported by their nodes. The models
+// are fetched once per node and cached, so the validation doesn't add API calls for every VM in a plan. Failures
+// are not cached, so a later call fetches the models again.
+type CPUModelValidator struct {
+	client api.Client
+
+	mu    sync.Mutex
+	nodes map[string]*cpuModels
+}
+
+type cpuModels struct {
+	mu     sync.Mutex
+	models []*CPUModelListResponseData
+}
+
+// NewCPUModelValidator creates a new CPUModelValidator.
+func NewCPUModelValidator(client api.Client) *CPUModelValidator {
+	return &CPUModelValidator{client: client, nodes: map[string]*cpuModels{}}
+}
+
+// Models returns the cached CPU models of the node, fetching them on the first successful call.
+func (v *CPUModelValidator) Models(ctx context.Context, nodeName string) ([]*CPUModelListResponseData, error) {
+	v.mu.Lock()
+
+	m, ok := v.nodes[nodeName]
+	if !ok {
+		m = &cpuModels{}
+		v.nodes[nodeName] = m
+	}
+
+	v.mu.Unlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.models != nil {
+		return m.models, nil
+	}
+
+	models, err := (&Client{Client: v.client, NodeName: nodeName}).ListCPUModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.models = models
+
+	return models, nil
+}
+
+// Validate checks the CPU type of a VM against the CPU models of its node. It returns an error if a built-in model
+// is not supported by the QEMU version of the node, and a warning if a custom model is not defined, as the
+// definitions may be added out-of-band before the VM is created. If the models can't be read, e.g. because
+// the API user lacks `Sys.Audit` on the node, the validation is skipped.
+func (v *CPUModelValidator) Validate(ctx context.Context, nodeName string, cpuType string) (string, error) {
+	if v == nil || nodeName == "" || cpuType == "" {
+		return "", nil
+	}
+
+	models, err := v.Models(ctx, nodeName)
+	if err != nil {
+		tflog.Warn(ctx, "skipping the validation of the CPU type", map[string]interface{}{
+			"node_name": nodeName,
+			"error":     err.Error(),
+		})
+
+		return "", nil
+	}
+
+	return CheckCPUModel(models, nodeName, cpuType)
+}
+
+// CheckCPUModel checks a CPU type against the CPU models of a node, see CPUModelValidator.Validate.
+func CheckCPUModel(models []*CPUModelListResponseData, nodeName string, cpuType string) (string, error) {
+	supported := slices.ContainsFunc(models, func(m *CPUModelListResponseData) bool {
+		return m.Name == cpuType
+	})
+
+	switch {
+	case supported:
+		return "", nil
+	case strings.HasPrefix(cpuType, CustomCPUModelPrefix):
+		return fmt.Sprintf(
+			"the custom CPU model %q is not defined in /etc/pve/virtual-guest/cpu-models.conf on node %q",
+			strings.TrimPrefix(cpuType, CustomCPUModelPrefix), nodeName,
+		), nil
+	}
+
+	names := make([]string, 0, len(models))
+
+	for _, m := range models {
+		if !m.Custom {
+			names = append(names, m.Name)
+		}
+	}
+
+	slices.Sort(names)
+
+	return "", fmt.Errorf("the CPU model %q is not supported by node %q, the supported models are: %s",
+		cpuType, nodeName, strings.Join(names, ", "))
+}
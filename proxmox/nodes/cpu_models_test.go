@@ -0,0 +1,115 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+func TestCheckCPUModel(t *testing.T) {
+	t.Parallel()
+
+	models := []*CPUModelListResponseData{
+		{Name: "host", Vendor: "default"},
+		{Name: "x86-64-v2-AES", Vendor: "default"},
+		{Name: "EPYC-Rome", Vendor: "AuthenticAMD"},
+		{Name: "custom-gpu", Vendor: "AuthenticAMD", Custom: true},
+	}
+
+	tests := []struct {
+		name        string
+		cpuType     string
+		wantWarning bool
+		wantErr     bool
+	}{
+		{"built-in", "x86-64-v2-AES", false, false},
+		{"custom", "custom-gpu", false, false},
+		{"unsupported built-in", "EPYC-Genoa", false, true},
+		{"typo", "x86-64-v2-aes", false, true},
+		{"undefined custom", "custom-missing", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			warning, err := CheckCPUModel(models, "pve", tt.cpuType)
+
+			assert.Equal(t, tt.wantWarning, warning != "", "warning: %q", warning)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "EPYC-Rome, host, x86-64-v2-AES")
+				assert.NotContains(t, err.Error(), "custom-gpu")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCPUModelValidatorNil(t *testing.T) {
+	t.Parallel()
+
+	var v *CPUModelValidator
+
+	warning, err := v.Validate(context.Background(), "pve", "host")
+	require.NoError(t, err)
+	assert.Empty(t, warning)
+}
+
+func TestCPUModelValidatorValidate(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// the first request fails, e.g. as the user lacks `Sys.Audit` until the permission is granted
+		if requests.Add(1) == 1 {
+			http.Error(w, "Permission check failed (/nodes/pve, Sys.Audit)", http.StatusForbidden)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":[{"name":"host","vendor":"default","custom":0}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	conn, err := api.NewConnection(server.URL, true, "")
+	require.NoError(t, err)
+
+	creds := api.Credentials{TokenCredentials: &api.TokenCredentials{
+		APIToken: "root@pam!test=00000000-0000-0000-0000-000000000000",
+	}}
+
+	client, err := api.NewClient(creds, conn)
+	require.NoError(t, err)
+
+	v := NewCPUModelValidator(client)
+
+	warning, err := v.Validate(t.Context(), "pve", "custom-missing")
+	require.NoError(t, err, "the validation is skipped if the models can't be read")
+	assert.Empty(t, warning)
+
+	warning, err = v.Validate(t.Context(), "pve", "custom-missing")
+	require.NoError(t, err)
+	assert.Contains(t, warning, `the custom CPU model "missing" is not defined`)
+	assert.Equal(t, int32(2), requests.Load(), "the failure must not be cached")
+
+	_, err = v.Validate(t.Context(), "pve", "EPYC-Genoa")
+	require.ErrorContains(t, err, `the CPU model "EPYC-Genoa" is not supported by node "pve"`)
+	assert.Equal(t, int32(2), requests.Load(), "the models must be cached")
+}
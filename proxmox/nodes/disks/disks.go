@@ -0,0 +1,147 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package disks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// ListDisks retrieves the local disks of the node.
+func (c *Client) ListDisks(ctx context.Context, d *ListRequestBody) ([]*ListResponseData, error) {
+	resBody := &ListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("list"), d, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing disks: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	sort.Slice(resBody.Data, func(i, j int) bool {
+		return resBody.Data[i].DevPath < resBody.Data[j].DevPath
+	})
+
+	return resBody.Data, nil
+}
+
+// ListZFSPools retrieves the ZFS pools of the node.
+func (c *Client) ListZFSPools(ctx context.Context) ([]*ZFSPoolListResponseData, error) {
+	resBody := &ZFSPoolListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("zfs"), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing ZFS pools: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// CreateZFSPool creates a ZFS pool, and waits for the creation task to complete.
+func (c *Client) CreateZFSPool(ctx context.Context, d *ZFSPoolCreateRequestBody) error {
+	resBody := &TaskResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("zfs"), d, resBody)
+	if err != nil {
+		return fmt.Errorf("error creating ZFS pool %s: %w", d.Name, err)
+	}
+
+	return c.waitForTask(ctx, resBody, fmt.Sprintf("creating ZFS pool %s", d.Name))
+}
+
+// DeleteZFSPool destroys a ZFS pool, and waits for the deletion task to complete.
+func (c *Client) DeleteZFSPool(ctx context.Context, name string, d *PoolDeleteRequestBody) error {
+	resBody := &TaskResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath("zfs/"+url.PathEscape(name)), d, resBody)
+	if err != nil {
+		return fmt.Errorf("error deleting ZFS pool %s: %w", name, err)
+	}
+
+	return c.waitForTask(ctx, resBody, fmt.Sprintf("deleting ZFS pool %s", name))
+}
+
+// ListLVMThinPools retrieves the LVM thin pools of the node.
+func (c *Client) ListLVMThinPools(ctx context.Context) ([]*LVMThinPoolListResponseData, error) {
+	resBody := &LVMThinPoolListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("lvmthin"), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing LVM thin pools: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// CreateLVMThinPool creates an LVM thin pool, and waits for the creation task to complete.
+func (c *Client) CreateLVMThinPool(ctx context.Context, d *LVMThinPoolCreateRequestBody) error {
+	resBody := &TaskResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("lvmthin"), d, resBody)
+	if err != nil {
+		return fmt.Errorf("error creating LVM thin pool %s: %w", d.Name, err)
+	}
+
+	return c.waitForTask(ctx, resBody, fmt.Sprintf("creating LVM thin pool %s", d.Name))
+}
+
+// DeleteLVMThinPool destroys an LVM thin pool, and waits for the deletion task to complete.
+func (c *Client) DeleteLVMThinPool(ctx context.Context, name string, d *PoolDeleteRequestBody) error {
+	resBody := &TaskResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath("lvmthin/"+url.PathEscape(name)), d, resBody)
+	if err != nil {
+		return fmt.Errorf("error deleting LVM thin pool %s: %w", name, err)
+	}
+
+	return c.waitForTask(ctx, resBody, fmt.Sprintf("deleting LVM thin pool %s", name))
+}
+
+// waitForTask waits for the task started by a disk operation to complete.
+// The task log is included in the error if the task fails, as it contains the output of the disk tools.
+func (c *Client) waitForTask(ctx context.Context, resBody *TaskResponseBody, operation string) error {
+	if resBody.Data == nil {
+		return api.ErrNoDataObjectInResponse
+	}
+
+	err := c.Tasks().WaitForTask(ctx, *resBody.Data)
+	if err == nil {
+		return nil
+	}
+
+	log, e := c.Tasks().GetTaskLog(ctx, *resBody.Data)
+	if e != nil {
+		tflog.Error(ctx, "error retrieving task log", map[string]interface{}{
+			"task_id": *resBody.Data,
+			"error":   e.Error(),
+		})
+	}
+
+	if len(log) == 0 {
+		return fmt.Errorf("error %s: %w", operation, err)
+	}
+
+	return fmt.Errorf("error %s: %w\n%s", operation, err, strings.Join(log, "\n"))
+}
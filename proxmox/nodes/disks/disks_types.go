@@ -0,0 +1,96 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package disks
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// ListRequestBody contains the body for a disk list request.
+type ListRequestBody struct {
+	IncludePartitions *types.CustomBool `url:"include-partitions,omitempty,int"`
+	Type              *string           `url:"type,omitempty"`
+}
+
+// ListResponseBody contains the body from a disk list response.
+type ListResponseBody struct {
+	Data []*ListResponseData `json:"data,omitempty"`
+}
+
+// ListResponseData contains the data from a disk list response.
+type ListResponseData struct {
+	DevPath string            `json:"devpath"`
+	GPT     *types.CustomBool `json:"gpt,omitempty"`
+	Health  *string           `json:"health,omitempty"`
+	Model   *string           `json:"model,omitempty"`
+	Serial  *string           `json:"serial,omitempty"`
+	Size    int64             `json:"size"`
+	Type    *string           `json:"type,omitempty"`
+	Used    *string           `json:"used,omitempty"`
+	Vendor  *string           `json:"vendor,omitempty"`
+	WWN     *string           `json:"wwn,omitempty"`
+}
+
+// ZFSPoolCreateRequestBody contains the body for a ZFS pool create request.
+type ZFSPoolCreateRequestBody struct {
+	AddStorage  *types.CustomBool `url:"add_storage,omitempty,int"`
+	Ashift      *int64            `url:"ashift,omitempty"`
+	Compression *string           `url:"compression,omitempty"`
+	Devices     string            `url:"devices"`
+	Name        string            `url:"name"`
+	RAIDLevel   string            `url:"raidlevel"`
+}
+
+// ZFSPoolListResponseBody contains the body from a ZFS pool list response.
+type ZFSPoolListResponseBody struct {
+	Data []*ZFSPoolListResponseData `json:"data,omitempty"`
+}
+
+// ZFSPoolListResponseData contains the data from a ZFS pool list response.
+type ZFSPoolListResponseData struct {
+	Alloc  int64   `json:"alloc"`
+	Free   int64   `json:"free"`
+	Health string  `json:"health"`
+	Name   string  `json:"name"`
+	Size   int64   `json:"size"`
+	Dedup  float64 `json:"dedup"`
+	Frag   int64   `json:"frag"`
+}
+
+// LVMThinPoolCreateRequestBody contains the body for an LVM thin pool create request.
+type LVMThinPoolCreateRequestBody struct {
+	AddStorage *types.CustomBool `url:"add_storage,omitempty,int"`
+	Device     string            `url:"device"`
+	Name       string            `url:"name"`
+}
+
+// LVMThinPoolListResponseBody contains the body from an LVM thin pool list response.
+type LVMThinPoolListResponseBody struct {
+	Data []*LVMThinPoolListResponseData `json:"data,omitempty"`
+}
+
+// LVMThinPoolListResponseData contains the data from an LVM thin pool list response.
+type LVMThinPoolListResponseData struct {
+	LV           string `json:"lv"`
+	LVSize       int64  `json:"lv_size"`
+	MetadataSize int64  `json:"metadata_size"`
+	MetadataUsed int64  `json:"metadata_used"`
+	Used         int64  `json:"used"`
+	VG           string `json:"vg"`
+}
+
+// PoolDeleteRequestBody contains the body for a ZFS or LVM thin pool delete request.
+type PoolDeleteRequestBody struct {
+	CleanupConfig *types.CustomBool `url:"cleanup-config,omitempty,int"`
+	CleanupDisks  *types.CustomBool `url:"cleanup-disks,omitempty,int"`
+	VolumeGroup   *string           `url:"volume-group,omitempty"`
+}
+
+// TaskResponseBody contains the body from a response which starts a task.
+type TaskResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
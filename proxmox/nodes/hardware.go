@@ -0,0 +1,64 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// ListPCIDevices retrieves a list of the PCI devices of the node.
+func (c *Client) ListPCIDevices(ctx context.Context) ([]*PCIDeviceListResponseData, error) {
+	resBody := &PCIDeviceListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("hardware/pci"), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PCI devices of the node \"%s\": %w", c.NodeName, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// ListPCIDeviceMDevTypes retrieves a list of the mediated device types supported by a PCI device of the node.
+func (c *Client) ListPCIDeviceMDevTypes(ctx context.Context, id string) ([]*PCIDeviceMDevTypeListResponseData, error) {
+	resBody := &PCIDeviceMDevTypeListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(fmt.Sprintf("hardware/pci/%s/mdev", url.PathEscape(id))), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mediated device types of PCI device \"%s\": %w", id, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// ListUSBDevices retrieves a list of the USB devices of the node.
+func (c *Client) ListUSBDevices(ctx context.Context) ([]*USBDeviceListResponseData, error) {
+	resBody := &USBDeviceListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("hardware/usb"), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list USB devices of the node \"%s\": %w", c.NodeName, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
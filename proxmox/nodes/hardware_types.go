@@ -0,0 +1,73 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package nodes
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// PCIDeviceListResponseBody contains the body from a PCI device list response.
+type PCIDeviceListResponseBody struct {
+	Data []*PCIDeviceListResponseData `json:"data,omitempty"`
+}
+
+// PCIDeviceListResponseData contains the data from a PCI device list response.
+type PCIDeviceListResponseData struct {
+	Class      string            `json:"class"`
+	Device     string            `json:"device"`
+	DeviceName *string           `json:"device_name,omitempty"`
+	ID         string            `json:"id"`
+	IOMMUGroup int               `json:"iommugroup"`
+	MDev       *types.CustomBool `json:"mdev,omitempty"`
+	Vendor     string            `json:"vendor"`
+	VendorName *string           `json:"vendor_name,omitempty"`
+}
+
+// PCIDeviceMDevTypeListResponseBody contains the body from a PCI device mediated device type list response.
+type PCIDeviceMDevTypeListResponseBody struct {
+	Data []*PCIDeviceMDevTypeListResponseData `json:"data,omitempty"`
+}
+
+// PCIDeviceMDevTypeListResponseData contains the data from a PCI device mediated device type list response.
+type PCIDeviceMDevTypeListResponseData struct {
+	Available   int     `json:"available"`
+	Description string  `json:"description"`
+	Name        *string `json:"name,omitempty"`
+	Type        string  `json:"type"`
+}
+
+// USBDeviceListResponseBody contains the body from a USB device list response.
+type USBDeviceListResponseBody struct {
+	Data []*USBDeviceListResponseData `json:"data,omitempty"`
+}
+
+// USBDeviceListResponseData contains the data from a USB device list response.
+type USBDeviceListResponseData struct {
+	BusNum       int     `json:"busnum"`
+	Class        int     `json:"class"`
+	DevNum       int     `json:"devnum"`
+	Manufacturer *string `json:"manufacturer,omitempty"`
+	Port         int     `json:"port"`
+	ProductID    string  `json:"prodid"`
+	Product      *string `json:"product,omitempty"`
+	Serial       *string `json:"serial,omitempty"`
+	Speed        string  `json:"speed"`
+	USBPath      *string `json:"usbpath,omitempty"`
+	VendorID     string  `json:"vendid"`
+}
+
+// IOMMUActive returns true if the IOMMU is active on the node, which is the case when the PCI devices are assigned
+// to IOMMU groups. PVE reports the group `-1` for all devices otherwise.
+func IOMMUActive(devices []*PCIDeviceListResponseData) bool {
+	for _, d := range devices {
+		if d.IOMMUGroup >= 0 {
+			return true
+		}
+	}
+
+	return false
+}
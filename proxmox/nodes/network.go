@@ -22,14 +22,28 @@ import (
 )
 
 const (
-	networkReloadTimeout = 10 * time.Second
+	networkReloadBatchDelay = 2 * time.Second
+	networkReloadTimeout    = 10 * time.Second
 )
 
-// reloadLock is used to prevent concurrent network reloads.
-// global variable by design.
+// networkReloadBatch is a pending reload of the network configuration of a node, shared by all
+// the changes requesting a reload before it starts.
+type networkReloadBatch struct {
+	done chan struct{}
+	err  error
+}
+
+// reloadLock is used to prevent concurrent network reloads, while reloadBatches holds the pending
+// reload of each node, so the changes of multiple interfaces of a node made during an apply
+// are applied at once.
+// global variables by design.
 //
 //nolint:gochecknoglobals
-var reloadLock sync.Mutex
+var (
+	reloadLock        sync.Mutex
+	reloadBatchesLock sync.Mutex
+	reloadBatches     = map[string]*networkReloadBatch{}
+)
 
 // ListNetworkInterfaces retrieves a list of network interfaces for a specific nodes.
 func (c *Client) ListNetworkInterfaces(ctx context.Context) ([]*NetworkInterfaceListResponseData, error) {
@@ -64,8 +78,63 @@ func (c *Client) CreateNetworkInterface(ctx context.Context, d *NetworkInterface
 	return nil
 }
 
-// ReloadNetworkConfiguration reloads the network configuration for a specific node.
+// GetPendingNetworkChanges retrieves the changes of the network configuration for a specific node,
+// which have not been applied yet. The changes are returned in the unified diff format, and are
+// empty if the configuration has been applied.
+func (c *Client) GetPendingNetworkChanges(ctx context.Context) (string, error) {
+	resBody := &NetworkInterfaceListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("network"), nil, resBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to get pending network changes for node \"%s\": %w", c.NodeName, err)
+	}
+
+	if resBody.Changes == nil {
+		return "", nil
+	}
+
+	return *resBody.Changes, nil
+}
+
+// ReloadNetworkConfiguration reloads the network configuration for a specific node. The reloads requested
+// for the same node within a short delay are batched, so the configuration is only reloaded once.
 func (c *Client) ReloadNetworkConfiguration(ctx context.Context) error {
+	reloadBatchesLock.Lock()
+
+	batch, pending := reloadBatches[c.NodeName]
+	if !pending {
+		batch = &networkReloadBatch{done: make(chan struct{})}
+		reloadBatches[c.NodeName] = batch
+	}
+
+	reloadBatchesLock.Unlock()
+
+	if pending {
+		select {
+		case <-batch.done:
+			return batch.err
+		case <-ctx.Done():
+			return fmt.Errorf("failed to reload network configuration for node \"%s\": %w", c.NodeName, ctx.Err())
+		}
+	}
+
+	select {
+	case <-time.After(networkReloadBatchDelay):
+	case <-ctx.Done():
+	}
+
+	// the changes requested from now on are applied by the next reload
+	reloadBatchesLock.Lock()
+	delete(reloadBatches, c.NodeName)
+	reloadBatchesLock.Unlock()
+
+	batch.err = c.reloadNetworkConfiguration(ctx)
+	close(batch.done)
+
+	return batch.err
+}
+
+func (c *Client) reloadNetworkConfiguration(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, networkReloadTimeout)
 	defer cancel()
 
@@ -12,7 +12,8 @@ import (
 
 // NetworkInterfaceListResponseBody contains the body from a node network interface list response.
 type NetworkInterfaceListResponseBody struct {
-	Data []*NetworkInterfaceListResponseData `json:"data,omitempty"`
+	Changes *string                             `json:"changes,omitempty"`
+	Data    []*NetworkInterfaceListResponseData `json:"data,omitempty"`
 }
 
 // NetworkInterfaceListResponseData contains the data from a node network interface list response.
@@ -25,6 +26,11 @@ type NetworkInterfaceListResponseData struct {
 	Address         *string           `json:"address,omitempty"`
 	Address6        *string           `json:"address6,omitempty"`
 	Autostart       *types.CustomBool `json:"autostart,omitempty"`
+	BondLACPRate    *string           `json:"bond_lacp_rate,omitempty"`
+	BondMIIMon      *string           `json:"bond_miimon,omitempty"`
+	BondMode        *string           `json:"bond_mode,omitempty"`
+	BondPrimary     *string           `json:"bond-primary,omitempty"`
+	BondXmitHash    *string           `json:"bond_xmit_hash_policy,omitempty"`
 	BridgePorts     *string           `json:"bridge_ports,omitempty"`
 	BridgeSTP       *string           `json:"bridge_stp,omitempty"`
 	BridgeVIDs      *string           `json:"bridge_vids,omitempty"`
@@ -41,9 +47,15 @@ type NetworkInterfaceListResponseData struct {
 	MethodIPv6      *string           `json:"method6,omitempty"`
 	MTU             *string           `json:"mtu,omitempty"`
 	Netmask         *string           `json:"netmask,omitempty"`
+	OVSBonds        *string           `json:"ovs_bonds,omitempty"`
+	OVSBridge       *string           `json:"ovs_bridge,omitempty"`
+	OVSOptions      *string           `json:"ovs_options,omitempty"`
+	OVSPorts        *string           `json:"ovs_ports,omitempty"`
+	OVSTag          *string           `json:"ovs_tag,omitempty"`
 	VLANID          *string           `json:"vlan-id,omitempty"`
 	VLANRawDevice   *string           `json:"vlan-raw-device,omitempty"`
 	Priority        int               `json:"priority"`
+	Slaves          *string           `json:"slaves,omitempty"`
 	Type            string            `json:"type"`
 }
 
@@ -59,6 +71,7 @@ type NetworkInterfaceCreateUpdateRequestBody struct {
 	BondMode           *string           `json:"bond_mode,omitempty"             url:"bond_mode,omitempty"`
 	BondXmitHashPolicy *string           `json:"bond_xmit_hash_policy,omitempty" url:"bond_xmit_hash_policy,omitempty"`
 	BridgePorts        *string           `json:"bridge_ports,omitempty"          url:"bridge_ports,omitempty"`
+	BridgeVIDs         *string           `json:"bridge_vids,omitempty"           url:"bridge_vids,omitempty"`
 	BridgeVLANAware    *types.CustomBool `json:"bridge_vlan_aware,omitempty"     url:"bridge_vlan_aware,omitempty,int"`
 	CIDR               *string           `json:"cidr,omitempty"                  url:"cidr,omitempty"`
 	CIDR6              *string           `json:"cidr6,omitempty"                 url:"cidr6,omitempty"`
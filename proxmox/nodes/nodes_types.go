@@ -41,16 +41,43 @@ type GetInfoResponseBody struct {
 
 // GetInfoResponseData contains the data from a node info response.
 type GetInfoResponseData struct {
+	BootInfo *struct {
+		Mode       string            `json:"mode"`
+		SecureBoot *types.CustomBool `json:"secureboot,omitempty"`
+	} `json:"boot-info,omitempty"`
 	CPUInfo struct {
-		CPUCores   *int    `json:"cores,omitempty"`
-		CPUSockets *int    `json:"sockets,omitempty"`
-		CPUModel   *string `json:"model"`
+		CPUCores   *int                 `json:"cores,omitempty"`
+		CPUCount   *int                 `json:"cpus,omitempty"`
+		CPUFlags   *string              `json:"flags,omitempty"`
+		CPUMHz     *types.CustomFloat64 `json:"mhz,omitempty"`
+		CPUSockets *int                 `json:"sockets,omitempty"`
+		CPUModel   *string              `json:"model"`
 	} `json:"cpuinfo"`
-	MemoryInfo struct {
+	CurrentKernel *struct {
+		Machine string `json:"machine"`
+		Release string `json:"release"`
+		SysName string `json:"sysname"`
+		Version string `json:"version"`
+	} `json:"current-kernel,omitempty"`
+	KernelVersion *string               `json:"kversion,omitempty"`
+	LoadAverage   []types.CustomFloat64 `json:"loadavg,omitempty"`
+	MemoryInfo    struct {
 		Free  *int `json:"free,omitempty"`
 		Used  *int `json:"used,omitempty"`
 		Total *int `json:"total,omitempty"`
 	} `json:"memory"`
+	PVEVersion *string `json:"pveversion,omitempty"`
+	RootFS     struct {
+		Available *int64 `json:"avail,omitempty"`
+		Free      *int64 `json:"free,omitempty"`
+		Total     *int64 `json:"total,omitempty"`
+		Used      *int64 `json:"used,omitempty"`
+	} `json:"rootfs"`
+	SwapInfo struct {
+		Free  *int64 `json:"free,omitempty"`
+		Used  *int64 `json:"used,omitempty"`
+		Total *int64 `json:"total,omitempty"`
+	} `json:"swap"`
 	Uptime *int `json:"uptime"`
 }
 
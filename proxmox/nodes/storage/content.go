@@ -19,16 +19,17 @@ import (
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 )
 
-// DeleteDatastoreFile deletes a file in a datastore.
+// DeleteDatastoreFile deletes a file in a datastore, and waits for the task deleting it, if any.
 func (c *Client) DeleteDatastoreFile(
 	ctx context.Context,
 	volumeID string,
 ) error {
 	path := c.ExpandPath(fmt.Sprintf("content/%s", url.PathEscape(volumeID)))
+	resBody := &DatastoreFileDeleteResponseBody{}
 
 	err := retry.Do(
 		func() error {
-			return c.DoRequest(ctx, http.MethodDelete, path, nil, nil)
+			return c.DoRequest(ctx, http.MethodDelete, path, nil, resBody)
 		},
 		retry.Context(ctx),
 		retry.RetryIf(func(err error) bool {
@@ -40,6 +41,13 @@ func (c *Client) DeleteDatastoreFile(
 		return fmt.Errorf("error deleting file %s from datastore %s: %w", volumeID, c.StorageName, err)
 	}
 
+	// the file is deleted by a task on most datastores, e.g. on directories
+	if resBody.TaskID != nil {
+		if err = c.Tasks().WaitForTask(ctx, *resBody.TaskID); err != nil {
+			return fmt.Errorf("error waiting for the deletion of file %s from datastore %s: %w", volumeID, c.StorageName, err)
+		}
+	}
+
 	return nil
 }
 
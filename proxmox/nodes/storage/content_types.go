@@ -6,6 +6,11 @@
 
 package storage
 
+// DatastoreFileDeleteResponseBody contains the body from a datastore content delete response.
+type DatastoreFileDeleteResponseBody struct {
+	TaskID *string `json:"data,omitempty"`
+}
+
 // DatastoreFileListResponseBody contains the body from a datastore content list response.
 type DatastoreFileListResponseBody struct {
 	Data []*DatastoreFileListResponseData `json:"data,omitempty"`
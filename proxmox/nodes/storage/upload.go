@@ -7,11 +7,14 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"os"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/utils"
 )
 
 // APIUpload uploads a file to a datastore using the Proxmox API.
@@ -20,11 +23,20 @@ func (c *Client) APIUpload(
 	d *api.FileUploadRequest,
 	tempDir string,
 ) (*DatastoreUploadResponseBody, error) {
+	sourceFileInfo, err := d.File.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source file info: %w", err)
+	}
+
 	tflog.Debug(ctx, "uploading file to datastore using PVE API", map[string]interface{}{
 		"file_name":    d.FileName,
+		"file_size":    sourceFileInfo.Size(),
 		"content_type": d.ContentType,
+		"mime_type":    d.MIMEType,
 	})
 
+	sourceReader := d.Reader()
+
 	r, w := io.Pipe()
 
 	defer func(r *io.PipeReader) {
@@ -65,12 +77,12 @@ func (c *Client) APIUpload(
 			return
 		}
 
-		part, err := m.CreateFormFile("filename", d.FileName)
+		part, err := createFilePart(m, d)
 		if err != nil {
 			return
 		}
 
-		_, err = io.Copy(part, d.File)
+		_, err = io.Copy(part, sourceReader)
 		if err != nil {
 			return
 		}
@@ -131,10 +143,14 @@ func (c *Client) APIUpload(
 
 	fileSize := fileInfo.Size()
 
+	// The multipart payload is the source file plus a few hundred bytes of headers,
+	// so its size gives an accurate percentage and ETA of the upload.
 	reqBody := &api.MultiPartData{
 		Boundary: m.Boundary(),
-		Reader:   fileReader,
-		Size:     &fileSize,
+		Reader: utils.NewProgressReader(
+			ctx, fileReader, fileSize, fmt.Sprintf("uploading file %q to datastore %s", d.FileName, c.StorageName),
+		),
+		Size: &fileSize,
 	}
 
 	resBody := &DatastoreUploadResponseBody{}
@@ -161,3 +177,20 @@ func (c *Client) APIUpload(
 
 	return resBody, nil
 }
+
+// quoteEscaper escapes the quoted parameters of a Content-Disposition header, as multipart.Writer does.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// createFilePart creates the part of the file in the multipart upload. The part declares the MIME type
+// of the request if set, as some storage backends validate it, instead of `application/octet-stream`.
+func createFilePart(m *multipart.Writer, d *api.FileUploadRequest) (io.Writer, error) {
+	if d.MIMEType == "" {
+		return m.CreateFormFile("filename", d.FileName) //nolint:wrapcheck
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="filename"; filename="%s"`, quoteEscaper.Replace(d.FileName)))
+	h.Set("Content-Type", d.MIMEType)
+
+	return m.CreatePart(h) //nolint:wrapcheck
+}
@@ -0,0 +1,97 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	uploadTaskType      = "imgcopy"
+	uploadTaskListLimit = 50
+)
+
+// FindUploadTask returns the most recent task importing an uploaded file with the given name into the given
+// datastore directory, or nil if there is none. PVE starts the task once the upload has been received completely,
+// so the task may still be running, or have completed, after the client that started the upload went away.
+func (c *Client) FindUploadTask(ctx context.Context, datastorePath string, fileName string) (*UploadTask, error) {
+	reqBody := &UploadTaskListRequestData{
+		Limit:      uploadTaskListLimit,
+		Source:     "all",
+		TypeFilter: uploadTaskType,
+	}
+	resBody := &UploadTaskListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.Client.ExpandPath("tasks"), reqBody, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing upload tasks: %w", err)
+	}
+
+	sort.SliceStable(resBody.Data, func(i, j int) bool {
+		return resBody.Data[i].StartTime > resBody.Data[j].StartTime
+	})
+
+	for _, t := range resBody.Data {
+		lines, err := c.Tasks().GetTaskLog(ctx, t.UPID)
+		if err != nil {
+			// the log of an old task may have been rotated away
+			tflog.Debug(ctx, "failed to read upload task log", map[string]interface{}{
+				"upid":  t.UPID,
+				"error": err,
+			})
+
+			continue
+		}
+
+		task := parseUploadTaskLog(t.UPID, lines)
+		if task == nil {
+			continue
+		}
+
+		if path.Base(task.TargetFile) == fileName &&
+			strings.HasPrefix(task.TargetFile, strings.TrimSuffix(datastorePath, "/")+"/") {
+			return task, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// parseUploadTaskLog extracts the target file and the size of the upload from the log of an upload task.
+// It returns nil if the log does not contain both.
+func parseUploadTaskLog(upid string, lines []string) *UploadTask {
+	task := &UploadTask{
+		UPID:     upid,
+		FileSize: -1,
+	}
+
+	for _, line := range lines {
+		if v, ok := strings.CutPrefix(line, "target file: "); ok {
+			task.TargetFile = strings.TrimSpace(v)
+		}
+
+		if v, ok := strings.CutPrefix(line, "file size is: "); ok {
+			if size, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+				task.FileSize = size
+			}
+		}
+	}
+
+	if task.TargetFile == "" || task.FileSize < 0 {
+		return nil
+	}
+
+	return task
+}
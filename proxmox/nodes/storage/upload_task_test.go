@@ -0,0 +1,62 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUploadTaskLog(t *testing.T) {
+	t.Parallel()
+
+	const upid = "UPID:pve:00061CB3:010BA69C:64EFECB0:imgcopy::root@pam:"
+
+	tests := []struct {
+		name  string
+		lines []string
+		want  *UploadTask
+	}{
+		{
+			name: "completed upload",
+			lines: []string{
+				"starting file import from: /var/tmp/pveupload-4d1b4f3d0f3e9c3b",
+				"target node: pve",
+				"target file: /var/lib/vz/template/iso/alpine.iso",
+				"file size is: 62914560",
+				"command: cp -- /var/tmp/pveupload-4d1b4f3d0f3e9c3b /var/lib/vz/template/iso/alpine.iso",
+				"finished file import successfully",
+				"TASK OK",
+			},
+			want: &UploadTask{
+				UPID:       upid,
+				TargetFile: "/var/lib/vz/template/iso/alpine.iso",
+				FileSize:   62914560,
+			},
+		},
+		{
+			name: "missing size",
+			lines: []string{
+				"starting file import from: /var/tmp/pveupload-4d1b4f3d0f3e9c3b",
+				"target file: /var/lib/vz/template/iso/alpine.iso",
+			},
+		},
+		{
+			name:  "empty log",
+			lines: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, parseUploadTaskLog(upid, tt.lines))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"mime/multipart"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+func TestCreateFilePart(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		mimeType string
+		want     string
+	}{
+		{"default", "", "application/octet-stream"},
+		{"override", "application/x-iso9660-image", "application/x-iso9660-image"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			w := multipart.NewWriter(&buf)
+
+			part, err := createFilePart(w, &api.FileUploadRequest{FileName: `my "image".iso`, MIMEType: tt.mimeType})
+			require.NoError(t, err)
+
+			_, err = part.Write([]byte("data"))
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			p, err := multipart.NewReader(&buf, w.Boundary()).NextPart()
+			require.NoError(t, err)
+
+			assert.Equal(t, "filename", p.FormName())
+			assert.Equal(t, `my "image".iso`, p.FileName())
+			assert.Equal(t, tt.want, p.Header.Get("Content-Type"))
+		})
+	}
+}
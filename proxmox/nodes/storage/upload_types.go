@@ -10,3 +10,28 @@ package storage
 type DatastoreUploadResponseBody struct {
 	UploadID *string `json:"data,omitempty"`
 }
+
+// UploadTaskListRequestData contains the query parameters for listing the upload tasks of a node.
+type UploadTaskListRequestData struct {
+	Limit      int    `url:"limit,omitempty"`
+	Source     string `url:"source,omitempty"`
+	TypeFilter string `url:"typefilter,omitempty"`
+}
+
+// UploadTaskListResponseBody contains the body from a node task list response.
+type UploadTaskListResponseBody struct {
+	Data []*UploadTaskListResponseData `json:"data,omitempty"`
+}
+
+// UploadTaskListResponseData contains the data from a node task list response.
+type UploadTaskListResponseData struct {
+	UPID      string `json:"upid"`
+	StartTime int64  `json:"starttime,omitempty"`
+}
+
+// UploadTask describes a task importing an uploaded file into a datastore.
+type UploadTask struct {
+	UPID       string
+	TargetFile string
+	FileSize   int64
+}
@@ -10,7 +10,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -69,6 +71,78 @@ func (c *Client) GetTaskLog(ctx context.Context, upid string) ([]string, error)
 	return lines, nil
 }
 
+// GetTaskLogTail retrieves the last lines of the log of a task, at most limit.
+func (c *Client) GetTaskLogTail(ctx context.Context, upid string, limit int) ([]string, error) {
+	path, err := c.BuildPath(upid, "log")
+	if err != nil {
+		return nil, fmt.Errorf("error building path for task log: %w", err)
+	}
+
+	// the length of the log is only known from a response, so the first lines are read first,
+	// which are the last ones if the log is not longer than the limit
+	resBody, err := c.getTaskLogPage(ctx, path, 0, limit)
+	if err == nil && resBody.Total > limit {
+		resBody, err = c.getTaskLogPage(ctx, path, resBody.Total-limit, limit)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]string, 0, len(resBody.Data))
+
+	for _, line := range resBody.Data {
+		lines = append(lines, line.LineText)
+	}
+
+	return lines, nil
+}
+
+func (c *Client) getTaskLogPage(ctx context.Context, path string, start int, limit int) (*GetTaskLogResponseBody, error) {
+	resBody := &GetTaskLogResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, path, &GetTaskLogRequestBody{Start: start, Limit: limit}, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving task log: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody, nil
+}
+
+// GetTask retrieves a task from the task list of its node, which unlike its status includes its end time.
+// It returns nil if the task is not listed, e.g. if it was removed from the task archive.
+func (c *Client) GetTask(ctx context.Context, upid string) (*ListResponseData, error) {
+	tid, err := ParseTaskID(upid)
+	if err != nil {
+		return nil, err
+	}
+
+	// the tasks are filtered by their start time, which is part of the ID
+	reqBody := &ListRequestBody{
+		Since:  tid.StartTime.Unix(),
+		Until:  tid.StartTime.Unix(),
+		Source: "all",
+	}
+	resBody := &ListResponseBody{}
+
+	err = c.DoRequest(ctx, http.MethodGet, fmt.Sprintf("nodes/%s/tasks", url.PathEscape(tid.NodeName)), reqBody, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing tasks of node %q: %w", tid.NodeName, err)
+	}
+
+	for _, t := range resBody.Data {
+		if t.UPID == upid {
+			return t, nil
+		}
+	}
+
+	return nil, nil
+}
+
 // DeleteTask deletes specific task.
 func (c *Client) DeleteTask(ctx context.Context, upid string) error {
 	path, err := c.baseTaskPath(upid)
@@ -122,7 +196,28 @@ func (w withIgnoreStatus) apply(opts *taskWaitOptions) {
 	opts.ignoreStatusCode = w.statusCode
 }
 
-// WaitForTask waits for a specific task to complete.
+// maxPollDelay caps the delay between the polls of a task status, when it grows with a backoff.
+const maxPollDelay = 30 * time.Second
+
+// pollDelay returns the delay before the n-th poll of a task status, counting from 1 for the second poll.
+func pollDelay(p api.TaskPolling, n uint) time.Duration {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = api.DefaultTaskPolling.Interval
+	}
+
+	backoff := math.Max(p.Backoff, 1)
+
+	delay := float64(interval) * math.Pow(backoff, float64(n-1))
+	if delay > float64(maxPollDelay) {
+		return maxPollDelay
+	}
+
+	return time.Duration(delay)
+}
+
+// WaitForTask waits for a specific task to complete. The status of the task is polled as configured by
+// the TaskPolling of the client, until the task completes or the context is done.
 func (c *Client) WaitForTask(ctx context.Context, upid string, opts ...TaskWaitOption) error {
 	errStillRunning := errors.New("still running")
 
@@ -132,6 +227,8 @@ func (c *Client) WaitForTask(ctx context.Context, upid string, opts ...TaskWaitO
 		opt.apply(options)
 	}
 
+	polling := c.TaskPolling()
+
 	status, err := retry.DoWithData(
 		func() (*GetTaskStatusResponseData, error) {
 			status, err := c.GetTaskStatus(ctx, upid)
@@ -165,11 +262,18 @@ func (c *Client) WaitForTask(ctx context.Context, upid string, opts ...TaskWaitO
 		}),
 		retry.LastErrorOnly(true),
 		retry.UntilSucceeded(),
-		retry.DelayType(retry.FixedDelay),
-		retry.Delay(time.Second),
+		retry.DelayType(func(n uint, _ error, _ *retry.Config) time.Duration {
+			return pollDelay(polling, n)
+		}),
 	)
-	if errors.Is(err, context.DeadlineExceeded) {
-		return fmt.Errorf("timeout while waiting for task %q to complete", upid)
+
+	// the context may be done while polling, or while waiting for the next poll
+	if err != nil && ctx.Err() != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timeout while waiting for task %q to complete: %w", upid, ctx.Err())
+		}
+
+		return fmt.Errorf("interrupted while waiting for task %q to complete: %w", upid, ctx.Err())
 	}
 
 	if err != nil {
@@ -0,0 +1,136 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+const testUPID = "UPID:pve:000D1F5B:0185C71C:64F0E1B3:qmclone:100:root@pam:"
+
+// taskClient serves a task which keeps running until the given number of polls, with a log of the given length.
+type taskClient struct {
+	api.Client
+
+	polling  api.TaskPolling
+	polls    int
+	runs     int
+	logLines int
+}
+
+func (c *taskClient) TaskPolling() api.TaskPolling {
+	return c.polling
+}
+
+func (c *taskClient) DoRequest(_ context.Context, _, path string, requestBody, responseBody interface{}) error {
+	switch {
+	case strings.HasSuffix(path, "/status"):
+		c.polls++
+
+		res := responseBody.(*GetTaskStatusResponseBody)
+		res.Data = &GetTaskStatusResponseData{Status: "stopped", ExitCode: "OK"}
+
+		if c.runs < 0 || c.polls <= c.runs {
+			res.Data = &GetTaskStatusResponseData{Status: "running"}
+		}
+	case strings.HasSuffix(path, "/log"):
+		req := requestBody.(*GetTaskLogRequestBody)
+		res := responseBody.(*GetTaskLogResponseBody)
+
+		res.Total = c.logLines
+		res.Data = []*GetTaskLogResponseData{}
+
+		for n := req.Start + 1; n <= c.logLines && n <= req.Start+req.Limit; n++ {
+			res.Data = append(res.Data, &GetTaskLogResponseData{LineNumber: n, LineText: fmt.Sprintf("line %d", n)})
+		}
+	}
+
+	return nil
+}
+
+func TestPollDelay(t *testing.T) {
+	t.Parallel()
+
+	fixed := api.TaskPolling{Interval: 2 * time.Second, Backoff: 1}
+	assert.Equal(t, 2*time.Second, pollDelay(fixed, 1))
+	assert.Equal(t, 2*time.Second, pollDelay(fixed, 10))
+
+	backoff := api.TaskPolling{Interval: 500 * time.Millisecond, Backoff: 2}
+	assert.Equal(t, 500*time.Millisecond, pollDelay(backoff, 1))
+	assert.Equal(t, time.Second, pollDelay(backoff, 2))
+	assert.Equal(t, 4*time.Second, pollDelay(backoff, 4))
+	assert.Equal(t, maxPollDelay, pollDelay(backoff, 100))
+
+	assert.Equal(t, api.DefaultTaskPolling.Interval, pollDelay(api.TaskPolling{}, 3))
+}
+
+func TestWaitForTask(t *testing.T) {
+	t.Parallel()
+
+	c := &taskClient{polling: api.TaskPolling{Interval: time.Millisecond, Backoff: 2}, runs: 3}
+	client := &Client{Client: c}
+
+	require.NoError(t, client.WaitForTask(context.Background(), testUPID))
+	assert.Equal(t, 4, c.polls)
+}
+
+func TestWaitForTaskDeadline(t *testing.T) {
+	t.Parallel()
+
+	c := &taskClient{polling: api.TaskPolling{Interval: time.Millisecond, Backoff: 1}, runs: -1}
+	client := &Client{Client: c}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := client.WaitForTask(ctx, testUPID)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "timeout while waiting for task")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	err = client.WaitForTask(ctx, testUPID)
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorContains(t, err, "interrupted while waiting for task")
+}
+
+func TestGetTaskLogTail(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		logLines int
+		limit    int
+		want     []string
+	}{
+		{"short log", 2, 5, []string{"line 1", "line 2"}},
+		{"exact log", 3, 3, []string{"line 1", "line 2", "line 3"}},
+		{"long log", 10, 3, []string{"line 8", "line 9", "line 10"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &Client{Client: &taskClient{logLines: tt.logLines}}
+
+			lines, err := client.GetTaskLogTail(context.Background(), testUPID, tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, lines)
+		})
+	}
+}
@@ -25,9 +25,16 @@ type GetTaskStatusResponseData struct {
 	ExitCode string `json:"exitstatus,omitempty"`
 }
 
+// GetTaskLogRequestBody contains the body for a node get task log request.
+type GetTaskLogRequestBody struct {
+	Start int `url:"start"`
+	Limit int `url:"limit"`
+}
+
 // GetTaskLogResponseBody contains the body from a node get task log response.
 type GetTaskLogResponseBody struct {
-	Data []*GetTaskLogResponseData `json:"data,omitempty"`
+	Data  []*GetTaskLogResponseData `json:"data,omitempty"`
+	Total int                       `json:"total,omitempty"`
 }
 
 // GetTaskLogResponseData contains the data from a node get task log response.
@@ -36,6 +43,31 @@ type GetTaskLogResponseData struct {
 	LineText   string `json:"t,omitempty"`
 }
 
+// ListRequestBody contains the body for a node task list request.
+type ListRequestBody struct {
+	Since  int64  `url:"since,omitempty"`
+	Until  int64  `url:"until,omitempty"`
+	Source string `url:"source,omitempty"`
+}
+
+// ListResponseBody contains the body from a node task list response.
+type ListResponseBody struct {
+	Data []*ListResponseData `json:"data,omitempty"`
+}
+
+// ListResponseData contains the data from a node task list response.
+type ListResponseData struct {
+	UPID      string  `json:"upid"`
+	Node      string  `json:"node"`
+	Type      string  `json:"type"`
+	ID        *string `json:"id,omitempty"`
+	User      string  `json:"user"`
+	StartTime int64   `json:"starttime"`
+	EndTime   *int64  `json:"endtime,omitempty"`
+	// Status is the exit status of the task, which is not set while the task is running.
+	Status *string `json:"status,omitempty"`
+}
+
 // TaskID contains the components of a PVE task ID.
 type TaskID struct {
 	NodeName  string
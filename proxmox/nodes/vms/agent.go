@@ -0,0 +1,133 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// ErrAgentExecRunning is returned when a command executed by the QEMU agent has not exited yet.
+var ErrAgentExecRunning = errors.New("the command has not exited yet")
+
+// AgentExec starts a command in the guest using the QEMU agent, and returns its PID.
+func (c *Client) AgentExec(ctx context.Context, d *AgentExecRequestBody) (int, error) {
+	resBody := &AgentExecResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("agent/exec"), d, resBody)
+	if err != nil {
+		return 0, fmt.Errorf("error executing command using the QEMU agent: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return 0, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data.PID, nil
+}
+
+// GetAgentExecStatus retrieves the status of a command executed by the QEMU agent.
+func (c *Client) GetAgentExecStatus(ctx context.Context, pid int) (*AgentExecStatusResponseData, error) {
+	resBody := &AgentExecStatusResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("agent/exec-status"), &AgentExecStatusRequestBody{PID: pid}, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving the status of command %d from the QEMU agent: %w", pid, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// WaitForAgentExec waits for a command executed by the QEMU agent to exit, and returns its status.
+func (c *Client) WaitForAgentExec(ctx context.Context, pid int) (*AgentExecStatusResponseData, error) {
+	var status *AgentExecStatusResponseData
+
+	err := retry.Do(
+		func() error {
+			var err error
+
+			status, err = c.GetAgentExecStatus(ctx, pid)
+			if err != nil {
+				return retry.Unrecoverable(err)
+			}
+
+			if !bool(status.Exited) {
+				return ErrAgentExecRunning
+			}
+
+			return nil
+		},
+		retry.Context(ctx),
+		retry.Attempts(0),
+		retry.Delay(1*time.Second),
+		retry.DelayType(retry.FixedDelay),
+		retry.LastErrorOnly(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for command %d to exit: %w", pid, err)
+	}
+
+	return status, nil
+}
+
+// AgentFileWrite writes a file in the guest using the QEMU agent.
+func (c *Client) AgentFileWrite(ctx context.Context, d *AgentFileWriteRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("agent/file-write"), d, nil)
+	if err != nil {
+		return fmt.Errorf("error writing file %q using the QEMU agent: %w", d.File, err)
+	}
+
+	return nil
+}
+
+// AgentPing checks whether the QEMU agent of the VM is running and responding.
+func (c *Client) AgentPing(ctx context.Context) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("agent/ping"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error pinging the QEMU agent: %w", err)
+	}
+
+	return nil
+}
+
+// WaitForAgent waits for the QEMU agent of the VM to respond, e.g. while the VM is booting.
+func (c *Client) WaitForAgent(ctx context.Context) error {
+	err := retry.Do(
+		func() error {
+			return c.AgentPing(ctx)
+		},
+		retry.Context(ctx),
+		retry.Attempts(0),
+		retry.Delay(2*time.Second),
+		retry.DelayType(retry.FixedDelay),
+		retry.LastErrorOnly(true),
+		retry.WrapContextErrorWithLastError(true),
+		retry.OnRetry(func(n uint, err error) {
+			tflog.Debug(ctx, "waiting for the QEMU agent", map[string]interface{}{
+				"attempt": n,
+				"error":   err.Error(),
+			})
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("error waiting for the QEMU agent: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vms
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// AgentExecRequestBody contains the body for a QEMU agent exec request.
+type AgentExecRequestBody struct {
+	Command   []string `json:"command"              url:"command"`
+	InputData *string  `json:"input-data,omitempty" url:"input-data,omitempty"`
+}
+
+// AgentExecResponseBody contains the body from a QEMU agent exec response.
+type AgentExecResponseBody struct {
+	Data *AgentExecResponseData `json:"data,omitempty"`
+}
+
+// AgentExecResponseData contains the data from a QEMU agent exec response.
+type AgentExecResponseData struct {
+	PID int `json:"pid"`
+}
+
+// AgentExecStatusRequestBody contains the body for a QEMU agent exec status request.
+type AgentExecStatusRequestBody struct {
+	PID int `json:"pid" url:"pid"`
+}
+
+// AgentExecStatusResponseBody contains the body from a QEMU agent exec status response.
+type AgentExecStatusResponseBody struct {
+	Data *AgentExecStatusResponseData `json:"data,omitempty"`
+}
+
+// AgentExecStatusResponseData contains the data from a QEMU agent exec status response.
+type AgentExecStatusResponseData struct {
+	ErrData      *string           `json:"err-data,omitempty"`
+	ErrTruncated *types.CustomBool `json:"err-truncated,omitempty"`
+	ExitCode     *int              `json:"exitcode,omitempty"`
+	Exited       types.CustomBool  `json:"exited"`
+	OutData      *string           `json:"out-data,omitempty"`
+	OutTruncated *types.CustomBool `json:"out-truncated,omitempty"`
+	Signal       *int              `json:"signal,omitempty"`
+}
+
+// AgentFileWriteRequestBody contains the body for a QEMU agent file write request.
+type AgentFileWriteRequestBody struct {
+	Content string           `json:"content" url:"content"`
+	Encode  types.CustomBool `json:"encode"  url:"encode,int"`
+	File    string           `json:"file"    url:"file"`
+}
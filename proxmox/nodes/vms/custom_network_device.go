@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types/optionstring"
 )
 
 // CustomNetworkDevice handles QEMU network device parameters.
@@ -36,49 +37,25 @@ type CustomNetworkDevices []CustomNetworkDevice
 
 // EncodeValues converts a CustomNetworkDevice struct to a URL value.
 func (r *CustomNetworkDevice) EncodeValues(key string, v *url.Values) error {
-	values := []string{
-		fmt.Sprintf("model=%s", r.Model),
+	b := optionstring.Builder{}
+	leading := "model"
+
+	// Proxmox VE prints the MAC address as the value of the model, e.g. `virtio=BC:24:11:00:00:01`
+	if r.Model != "" && r.MACAddress != nil && *r.MACAddress != "" {
+		b.Add(r.Model, *r.MACAddress)
+		leading = r.Model
+	} else {
+		b.Add("model", r.Model)
+		b.String("macaddr", r.MACAddress)
 	}
 
-	if r.Bridge != nil {
-		values = append(values, fmt.Sprintf("bridge=%s", *r.Bridge))
-	}
-
-	if r.Firewall != nil {
-		if *r.Firewall {
-			values = append(values, "firewall=1")
-		} else {
-			values = append(values, "firewall=0")
-		}
-	}
-
-	if r.LinkDown != nil {
-		if *r.LinkDown {
-			values = append(values, "link_down=1")
-		} else {
-			values = append(values, "link_down=0")
-		}
-	}
-
-	if r.MACAddress != nil {
-		values = append(values, fmt.Sprintf("macaddr=%s", *r.MACAddress))
-	}
-
-	if r.Queues != nil {
-		values = append(values, fmt.Sprintf("queues=%d", *r.Queues))
-	}
-
-	if r.RateLimit != nil {
-		values = append(values, fmt.Sprintf("rate=%f", *r.RateLimit))
-	}
-
-	if r.Tag != nil {
-		values = append(values, fmt.Sprintf("tag=%d", *r.Tag))
-	}
-
-	if r.MTU != nil {
-		values = append(values, fmt.Sprintf("mtu=%d", *r.MTU))
-	}
+	b.String("bridge", r.Bridge)
+	b.Bool("firewall", r.Firewall)
+	b.Bool("link_down", r.LinkDown)
+	b.Int("mtu", r.MTU)
+	b.Int("queues", r.Queues)
+	b.Float("rate", r.RateLimit)
+	b.Int("tag", r.Tag)
 
 	if len(r.Trunks) > 0 {
 		trunks := make([]string, len(r.Trunks))
@@ -87,10 +64,10 @@ func (r *CustomNetworkDevice) EncodeValues(key string, v *url.Values) error {
 			trunks[i] = strconv.Itoa(v)
 		}
 
-		values = append(values, fmt.Sprintf("trunks=%s", strings.Join(trunks, ";")))
+		b.Add("trunks", strings.Join(trunks, ";"))
 	}
 
-	v.Add(key, strings.Join(values, ","))
+	v.Add(key, b.Marshal(leading))
 
 	return nil
 }
@@ -116,72 +93,52 @@ func (r *CustomNetworkDevice) UnmarshalJSON(b []byte) error {
 		return fmt.Errorf("failed to unmarshal CustomNetworkDevice: %w", err)
 	}
 
-	pairs := strings.Split(s, ",")
-
-	for _, p := range pairs {
-		v := strings.Split(strings.TrimSpace(p), "=")
-
-		//nolint:nestif
-		if len(v) == 2 {
-			switch v[0] {
-			case "bridge":
-				r.Bridge = &v[1]
-			case "firewall":
-				bv := types.CustomBool(v[1] == "1")
-				r.Firewall = &bv
-			case "link_down":
-				bv := types.CustomBool(v[1] == "1")
-				r.LinkDown = &bv
-			case "macaddr":
-				r.MACAddress = &v[1]
-			case "model":
-				r.Model = v[1]
-			case "queues":
-				iv, err := strconv.Atoi(v[1])
-				if err != nil {
-					return fmt.Errorf("failed to parse queues: %w", err)
-				}
-
-				r.Queues = &iv
-			case "rate":
-				fv, err := strconv.ParseFloat(v[1], 64)
-				if err != nil {
-					return fmt.Errorf("failed to parse rate: %w", err)
-				}
-
-				r.RateLimit = &fv
-
-			case "mtu":
-				iv, err := strconv.Atoi(v[1])
-				if err != nil {
-					return fmt.Errorf("failed to parse mtu: %w", err)
-				}
-
-				r.MTU = &iv
+	options, err := optionstring.Unmarshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal CustomNetworkDevice: %w", err)
+	}
 
-			case "tag":
-				iv, err := strconv.Atoi(v[1])
-				if err != nil {
-					return fmt.Errorf("failed to parse tag: %w", err)
+	for _, o := range options {
+		switch o.Key {
+		case "":
+			// a network device has no positional value
+		case "bridge":
+			r.Bridge = o.Ptr()
+		case "firewall":
+			r.Firewall = o.Bool()
+		case "link_down":
+			r.LinkDown = o.Bool()
+		case "macaddr":
+			r.MACAddress = o.Ptr()
+		case "model":
+			r.Model = o.Value
+		case "queues":
+			r.Queues, err = o.Int()
+		case "rate":
+			r.RateLimit, err = o.Float()
+		case "mtu":
+			r.MTU, err = o.Int()
+		case "tag":
+			r.Tag, err = o.Int()
+		case "trunks":
+			trunks := strings.Split(o.Value, ";")
+			r.Trunks = make([]int, len(trunks))
+
+			for i, trunk := range trunks {
+				iv, e := strconv.Atoi(trunk)
+				if e != nil {
+					return fmt.Errorf("failed to parse trunk %d: %w", i, e)
 				}
 
-				r.Tag = &iv
-			case "trunks":
-				trunks := strings.Split(v[1], ";")
-				r.Trunks = make([]int, len(trunks))
-
-				for i, trunk := range trunks {
-					iv, err := strconv.Atoi(trunk)
-					if err != nil {
-						return fmt.Errorf("failed to parse trunk %d: %w", i, err)
-					}
-
-					r.Trunks[i] = iv
-				}
-			default:
-				r.MACAddress = &v[1]
-				r.Model = v[0]
+				r.Trunks[i] = iv
 			}
+		default:
+			r.MACAddress = o.Ptr()
+			r.Model = o.Key
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal CustomNetworkDevice: %w", err)
 		}
 	}
 
@@ -0,0 +1,51 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vms
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+func TestCustomNetworkDevice_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, line := range readGolden(t, "testdata/network_devices.golden") {
+		t.Run(line, func(t *testing.T) {
+			t.Parallel()
+
+			d := &CustomNetworkDevice{}
+			require.NoError(t, json.Unmarshal(quote(t, line), d))
+			assert.True(t, d.Enabled)
+
+			v := url.Values{}
+			require.NoError(t, d.EncodeValues("net0", &v))
+			assert.Equal(t, line, v.Get("net0"))
+		})
+	}
+}
+
+func TestCustomNetworkDevice_EncodeValues(t *testing.T) {
+	t.Parallel()
+
+	d := &CustomNetworkDevice{
+		Bridge:    ptr.Ptr("vmbr0"),
+		Model:     "virtio",
+		RateLimit: ptr.Ptr(1.5),
+		Tag:       ptr.Ptr(10),
+	}
+
+	v := url.Values{}
+	require.NoError(t, d.EncodeValues("net0", &v))
+	assert.Equal(t, "model=virtio,bridge=vmbr0,rate=1.5,tag=10", v.Get("net0"))
+}
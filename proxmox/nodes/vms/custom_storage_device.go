@@ -11,11 +11,11 @@ import (
 	"fmt"
 	"net/url"
 	"path/filepath"
-	"strconv"
 	"strings"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types/optionstring"
 )
 
 // StorageInterfaces is a list of supported storage interfaces.
@@ -50,6 +50,7 @@ type CustomStorageDevice struct {
 	Serial                  *string           `json:"serial,omitempty"      url:"serial,omitempty"`
 	Size                    *types.DiskSize   `json:"size,omitempty"        url:"size,omitempty"`
 	SSD                     *types.CustomBool `json:"ssd,omitempty"         url:"ssd,omitempty,int"`
+	WWN                     *string           `json:"wwn,omitempty"         url:"wwn,omitempty"`
 	DatastoreID             *string           `json:"-"                     url:"-"`
 	FileID                  *string           `json:"-"                     url:"-"`
 }
@@ -117,89 +118,32 @@ func (d *CustomStorageDevice) IsCloudInitDrive(vmID int) bool {
 
 // EncodeOptions converts a CustomStorageDevice's common options a URL value.
 func (d *CustomStorageDevice) EncodeOptions() string {
-	var values []string
+	b := optionstring.Builder{}
 
-	if d.AIO != nil {
-		values = append(values, fmt.Sprintf("aio=%s", *d.AIO))
-	}
-
-	if d.Backup != nil {
-		if *d.Backup {
-			values = append(values, "backup=1")
-		} else {
-			values = append(values, "backup=0")
-		}
-	}
-
-	if d.IopsRead != nil {
-		values = append(values, fmt.Sprintf("iops_rd=%d", *d.IopsRead))
-	}
-
-	if d.IopsWrite != nil {
-		values = append(values, fmt.Sprintf("iops_wr=%d", *d.IopsWrite))
-	}
-
-	if d.MaxIopsRead != nil {
-		values = append(values, fmt.Sprintf("iops_rd_max=%d", *d.MaxIopsRead))
-	}
-
-	if d.MaxIopsWrite != nil {
-		values = append(values, fmt.Sprintf("iops_wr_max=%d", *d.MaxIopsWrite))
-	}
-
-	if d.IOThread != nil {
-		if *d.IOThread {
-			values = append(values, "iothread=1")
-		} else {
-			values = append(values, "iothread=0")
-		}
-	}
-
-	if d.Serial != nil && *d.Serial != "" {
-		values = append(values, fmt.Sprintf("serial=%s", *d.Serial))
-	}
-
-	if d.SSD != nil {
-		if *d.SSD {
-			values = append(values, "ssd=1")
-		} else {
-			values = append(values, "ssd=0")
-		}
-	}
-
-	if d.Discard != nil && *d.Discard != "" {
-		values = append(values, fmt.Sprintf("discard=%s", *d.Discard))
-	}
-
-	if d.Cache != nil && *d.Cache != "" {
-		values = append(values, fmt.Sprintf("cache=%s", *d.Cache))
-	}
-
-	if d.BurstableReadSpeedMbps != nil {
-		values = append(values, fmt.Sprintf("mbps_rd_max=%d", *d.BurstableReadSpeedMbps))
-	}
-
-	if d.BurstableWriteSpeedMbps != nil {
-		values = append(values, fmt.Sprintf("mbps_wr_max=%d", *d.BurstableWriteSpeedMbps))
-	}
-
-	if d.MaxReadSpeedMbps != nil {
-		values = append(values, fmt.Sprintf("mbps_rd=%d", *d.MaxReadSpeedMbps))
-	}
+	d.addOptions(&b)
 
-	if d.MaxWriteSpeedMbps != nil {
-		values = append(values, fmt.Sprintf("mbps_wr=%d", *d.MaxWriteSpeedMbps))
-	}
-
-	if d.Replicate != nil {
-		if *d.Replicate {
-			values = append(values, "replicate=1")
-		} else {
-			values = append(values, "replicate=0")
-		}
-	}
+	return b.Marshal()
+}
 
-	return strings.Join(values, ",")
+// addOptions adds the common options of the device, i.e. all but the volume, its format, media and size.
+func (d *CustomStorageDevice) addOptions(b *optionstring.Builder) {
+	b.String("aio", d.AIO)
+	b.Bool("backup", d.Backup)
+	b.String("cache", d.Cache)
+	b.String("discard", d.Discard)
+	b.Int("iops_rd", d.IopsRead)
+	b.Int("iops_rd_max", d.MaxIopsRead)
+	b.Int("iops_wr", d.IopsWrite)
+	b.Int("iops_wr_max", d.MaxIopsWrite)
+	b.Bool("iothread", d.IOThread)
+	b.Int("mbps_rd", d.MaxReadSpeedMbps)
+	b.Int("mbps_rd_max", d.BurstableReadSpeedMbps)
+	b.Int("mbps_wr", d.MaxWriteSpeedMbps)
+	b.Int("mbps_wr_max", d.BurstableWriteSpeedMbps)
+	b.Bool("replicate", d.Replicate)
+	b.String("serial", d.Serial)
+	b.Bool("ssd", d.SSD)
+	b.String("wwn", d.WWN)
 }
 
 // EncodeValues converts a CustomStorageDevice struct to a URL value.
@@ -208,29 +152,25 @@ func (d *CustomStorageDevice) EncodeValues(key string, v *url.Values) error {
 		d.FileVolume = *d.DatastoreID + ":" + "0"
 	}
 
-	values := []string{
-		fmt.Sprintf("file=%s", d.FileVolume),
-	}
+	b := optionstring.Builder{}
 
-	if d.ImportFrom != nil && *d.ImportFrom != "" {
-		values = append(values, fmt.Sprintf("import-from=%s", *d.ImportFrom))
-	}
+	b.Positional(d.FileVolume)
+	b.String("import-from", d.ImportFrom)
 
-	if d.Format != nil {
-		values = append(values, fmt.Sprintf("format=%s", *d.Format))
+	// the format of an allocated volume is implied by its extension, which is how Proxmox VE prints it
+	if d.Format != nil && !strings.EqualFold(filepath.Ext(d.FileVolume), "."+*d.Format) {
+		b.String("format", d.Format)
 	}
 
-	if d.Media != nil {
-		values = append(values, fmt.Sprintf("media=%s", *d.Media))
-	}
+	b.String("media", d.Media)
 
-	if d.Size != nil {
-		values = append(values, fmt.Sprintf("size=%d", *d.Size))
+	if size := types.FormatDiskSizeExact(d.Size); size != "" {
+		b.Add("size", size)
 	}
 
-	values = append(values, d.EncodeOptions())
+	d.addOptions(&b)
 
-	v.Add(key, strings.Join(values, ","))
+	v.Add(key, b.Marshal())
 
 	return nil
 }
@@ -243,17 +183,18 @@ func (d *CustomStorageDevice) UnmarshalJSON(b []byte) error {
 		return fmt.Errorf("failed to unmarshal CustomStorageDevice: %w", err)
 	}
 
-	pairs := strings.Split(s, ",")
-
-	for _, p := range pairs {
-		v := strings.Split(strings.TrimSpace(p), "=")
+	options, err := optionstring.Unmarshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal CustomStorageDevice: %w", err)
+	}
 
-		//nolint:nestif
-		if len(v) == 1 {
-			d.FileVolume = v[0]
+	for _, o := range options {
+		switch o.Key {
+		case "":
+			d.FileVolume = o.Value
 
 			// split file volume into datastore ID and path
-			_, pathInDatastore, hasDatastoreID := strings.Cut(v[0], ":")
+			_, pathInDatastore, hasDatastoreID := strings.Cut(o.Value, ":")
 			if hasDatastoreID {
 				// we don't set them here,... but probably should
 				// d.DatastoreID = &probablyDatastoreID
@@ -264,120 +205,80 @@ func (d *CustomStorageDevice) UnmarshalJSON(b []byte) error {
 					d.Format = &format
 				}
 			}
-		} else if len(v) == 2 {
-			switch v[0] {
-			case "aio":
-				d.AIO = &v[1]
 
-			case "backup":
-				bv := types.CustomBool(v[1] == "1")
-				d.Backup = &bv
+		case "aio":
+			d.AIO = o.Ptr()
 
-			case "cache":
-				d.Cache = &v[1]
+		case "backup":
+			d.Backup = o.Bool()
 
-			case "discard":
-				d.Discard = &v[1]
+		case "cache":
+			d.Cache = o.Ptr()
 
-			case "file":
-				d.FileVolume = v[1]
+		case "discard":
+			d.Discard = o.Ptr()
 
-			case "import_from":
-				d.ImportFrom = &v[1]
+		case "file":
+			d.FileVolume = o.Value
 
-			case "format":
-				d.Format = &v[1]
+		case "import-from", "import_from":
+			d.ImportFrom = o.Ptr()
 
-			case "iops_rd":
-				iv, err := strconv.Atoi(v[1])
-				if err != nil {
-					return fmt.Errorf("failed to convert iops_rd to int: %w", err)
-				}
+		case "format":
+			d.Format = o.Ptr()
 
-				d.IopsRead = &iv
+		case "iops_rd":
+			d.IopsRead, err = o.Int()
 
-			case "iops_rd_max":
-				iv, err := strconv.Atoi(v[1])
-				if err != nil {
-					return fmt.Errorf("failed to convert iops_rd_max to int: %w", err)
-				}
+		case "iops_rd_max":
+			d.MaxIopsRead, err = o.Int()
 
-				d.MaxIopsRead = &iv
+		case "iops_wr":
+			d.IopsWrite, err = o.Int()
 
-			case "iops_wr":
-				iv, err := strconv.Atoi(v[1])
-				if err != nil {
-					return fmt.Errorf("failed to convert iops_wr to int: %w", err)
-				}
+		case "iops_wr_max":
+			d.MaxIopsWrite, err = o.Int()
 
-				d.IopsWrite = &iv
+		case "iothread":
+			d.IOThread = o.Bool()
 
-			case "iops_wr_max":
-				iv, err := strconv.Atoi(v[1])
-				if err != nil {
-					return fmt.Errorf("failed to convert iops_wr_max to int: %w", err)
-				}
-
-				d.MaxIopsWrite = &iv
-
-			case "iothread":
-				bv := types.CustomBool(v[1] == "1")
-				d.IOThread = &bv
-
-			case "mbps_rd":
-				iv, err := strconv.Atoi(v[1])
-				if err != nil {
-					return fmt.Errorf("failed to convert mbps_rd to int: %w", err)
-				}
-
-				d.MaxReadSpeedMbps = &iv
-
-			case "mbps_rd_max":
-				iv, err := strconv.Atoi(v[1])
-				if err != nil {
-					return fmt.Errorf("failed to convert mbps_rd_max to int: %w", err)
-				}
+		case "mbps_rd":
+			d.MaxReadSpeedMbps, err = o.Int()
 
-				d.BurstableReadSpeedMbps = &iv
+		case "mbps_rd_max":
+			d.BurstableReadSpeedMbps, err = o.Int()
 
-			case "mbps_wr":
-				iv, err := strconv.Atoi(v[1])
-				if err != nil {
-					return fmt.Errorf("failed to convert mbps_wr to int: %w", err)
-				}
+		case "mbps_wr":
+			d.MaxWriteSpeedMbps, err = o.Int()
 
-				d.MaxWriteSpeedMbps = &iv
+		case "mbps_wr_max":
+			d.BurstableWriteSpeedMbps, err = o.Int()
 
-			case "mbps_wr_max":
-				iv, err := strconv.Atoi(v[1])
-				if err != nil {
-					return fmt.Errorf("failed to convert mbps_wr_max to int: %w", err)
-				}
+		case "media":
+			d.Media = o.Ptr()
 
-				d.BurstableWriteSpeedMbps = &iv
+		case "replicate":
+			d.Replicate = o.Bool()
 
-			case "media":
-				d.Media = &v[1]
+		case "serial":
+			d.Serial = o.Ptr()
 
-			case "replicate":
-				bv := types.CustomBool(v[1] == "1")
-				d.Replicate = &bv
+		case "size":
+			d.Size = new(types.DiskSize)
 
-			case "serial":
-				d.Serial = &v[1]
+			if e := d.Size.UnmarshalJSON([]byte(o.Value)); e != nil {
+				return fmt.Errorf("failed to unmarshal disk size: %w", e)
+			}
 
-			case "size":
-				d.Size = new(types.DiskSize)
+		case "ssd":
+			d.SSD = o.Bool()
 
-				err := d.Size.UnmarshalJSON([]byte(v[1]))
-				if err != nil {
-					return fmt.Errorf("failed to unmarshal disk size: %w", err)
-				}
+		case "wwn":
+			d.WWN = o.Ptr()
+		}
 
-			case "ssd":
-				bv := types.CustomBool(v[1] == "1")
-				d.SSD = &bv
-			}
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal CustomStorageDevice: %w", err)
 		}
 	}
 
@@ -411,6 +312,7 @@ func (d *CustomStorageDevice) MergeWith(m CustomStorageDevice) bool {
 	updated = ptr.UpdateIfChanged(&d.Replicate, m.Replicate) || updated
 	updated = ptr.UpdateIfChanged(&d.SSD, m.SSD) || updated
 	updated = ptr.UpdateIfChanged(&d.Serial, m.Serial) || updated
+	updated = ptr.UpdateIfChanged(&d.WWN, m.WWN) || updated
 	updated = ptr.UpdateIfChanged(&d.ImportFrom, m.ImportFrom) || updated
 
 	return updated
@@ -469,5 +371,6 @@ func (d *CustomStorageDevice) Equals(other *CustomStorageDevice) bool {
 		ptr.Eq(d.Replicate, other.Replicate) &&
 		ptr.Eq(d.Serial, other.Serial) &&
 		ptr.Eq(d.Size, other.Size) &&
-		ptr.Eq(d.SSD, other.SSD)
+		ptr.Eq(d.SSD, other.SSD) &&
+		ptr.Eq(d.WWN, other.WWN)
 }
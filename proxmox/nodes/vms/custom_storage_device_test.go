@@ -7,6 +7,11 @@
 package vms
 
 import (
+	"bufio"
+	"encoding/json"
+	"net/url"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -131,3 +136,79 @@ func TestCustomStorageDevice_IsCloudInitDrive(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomStorageDevice_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, line := range readGolden(t, "testdata/storage_devices.golden") {
+		t.Run(line, func(t *testing.T) {
+			t.Parallel()
+
+			d := &CustomStorageDevice{}
+			require.NoError(t, json.Unmarshal(quote(t, line), d))
+
+			v := url.Values{}
+			require.NoError(t, d.EncodeValues("scsi0", &v))
+			assert.Equal(t, line, v.Get("scsi0"))
+		})
+	}
+}
+
+func TestCustomStorageDevice_EncodeValues(t *testing.T) {
+	t.Parallel()
+
+	d := &CustomStorageDevice{}
+	require.NoError(t, json.Unmarshal(
+		[]byte(`"local-lvm:vm-2041-disk-0,discard=on,ssd=1,iothread=1,size=8G,cache=writeback"`), d,
+	))
+
+	v := url.Values{}
+	require.NoError(t, d.EncodeValues("scsi0", &v))
+	assert.Equal(t, "local-lvm:vm-2041-disk-0,cache=writeback,discard=on,iothread=1,size=8G,ssd=1", v.Get("scsi0"))
+	assert.Equal(t, "cache=writeback,discard=on,iothread=1,ssd=1", d.EncodeOptions())
+
+	d = &CustomStorageDevice{
+		DatastoreID: ptr.Ptr("local-lvm"),
+		Format:      ptr.Ptr("qcow2"),
+		ImportFrom:  ptr.Ptr("local:import/image.qcow2"),
+	}
+
+	v = url.Values{}
+	require.NoError(t, d.EncodeValues("virtio0", &v))
+	assert.Equal(t, "local-lvm:0,format=qcow2,import-from=local:import/image.qcow2", v.Get("virtio0"))
+}
+
+// readGolden returns the lines of a golden file, without the comments and the blank lines.
+func readGolden(t *testing.T, name string) []string {
+	t.Helper()
+
+	f, err := os.Open(name)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = f.Close() })
+
+	var lines []string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+
+	require.NoError(t, scanner.Err())
+	require.NotEmpty(t, lines)
+
+	return lines
+}
+
+// quote returns the JSON string of a config value, as the API returns it.
+func quote(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+
+	return b
+}
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"strings"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 )
@@ -26,9 +27,22 @@ func (c *Client) CreatePool(ctx context.Context, d *PoolCreateRequestBody) error
 	return nil
 }
 
+// isNestedPool returns true if the pool ID refers to a nested pool, i.e. `parent/child`.
+// Nested pools are only supported by PVE 8.1+, and cannot be addressed by the `pools/{poolid}` endpoints.
+func isNestedPool(id string) bool {
+	return strings.Contains(id, "/")
+}
+
 // DeletePool deletes a pool.
 func (c *Client) DeletePool(ctx context.Context, id string) error {
-	err := c.DoRequest(ctx, http.MethodDelete, fmt.Sprintf("pools/%s", url.PathEscape(id)), nil, nil)
+	var err error
+
+	if isNestedPool(id) {
+		err = c.DoRequest(ctx, http.MethodDelete, "pools", &PoolIDRequestBody{ID: id}, nil)
+	} else {
+		err = c.DoRequest(ctx, http.MethodDelete, fmt.Sprintf("pools/%s", url.PathEscape(id)), nil, nil)
+	}
+
 	if err != nil {
 		return fmt.Errorf("error deleting pool: %w", err)
 	}
@@ -38,6 +52,10 @@ func (c *Client) DeletePool(ctx context.Context, id string) error {
 
 // GetPool retrieves a pool.
 func (c *Client) GetPool(ctx context.Context, id string) (*PoolGetResponseData, error) {
+	if isNestedPool(id) {
+		return c.getNestedPool(ctx, id)
+	}
+
 	resBody := &PoolGetResponseBody{}
 
 	err := c.DoRequest(ctx, http.MethodGet, fmt.Sprintf("pools/%s", url.PathEscape(id)), nil, resBody)
@@ -56,6 +74,30 @@ func (c *Client) GetPool(ctx context.Context, id string) (*PoolGetResponseData,
 	return resBody.Data, nil
 }
 
+func (c *Client) getNestedPool(ctx context.Context, id string) (*PoolGetResponseData, error) {
+	resBody := &PoolListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, "pools", &PoolIDRequestBody{ID: id}, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pool: %w", err)
+	}
+
+	if len(resBody.Data) == 0 {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	data := &PoolGetResponseData{
+		Comment: resBody.Data[0].Comment,
+		Members: resBody.Data[0].Members,
+	}
+
+	sort.Slice(data.Members, func(i, j int) bool {
+		return data.Members[i].ID < data.Members[j].ID
+	})
+
+	return data, nil
+}
+
 // ListPools retrieves a list of pools.
 func (c *Client) ListPools(ctx context.Context) ([]*PoolListResponseData, error) {
 	resBody := &PoolListResponseBody{}
@@ -78,7 +120,15 @@ func (c *Client) ListPools(ctx context.Context) ([]*PoolListResponseData, error)
 
 // UpdatePool updates a pool.
 func (c *Client) UpdatePool(ctx context.Context, id string, d *PoolUpdateRequestBody) error {
-	err := c.DoRequest(ctx, http.MethodPut, fmt.Sprintf("pools/%s", url.PathEscape(id)), d, nil)
+	var err error
+
+	if isNestedPool(id) {
+		d.ID = &id
+		err = c.DoRequest(ctx, http.MethodPut, "pools", d, nil)
+	} else {
+		err = c.DoRequest(ctx, http.MethodPut, fmt.Sprintf("pools/%s", url.PathEscape(id)), d, nil)
+	}
+
 	if err != nil {
 		return fmt.Errorf("error updating pool: %w", err)
 	}
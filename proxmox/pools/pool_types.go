@@ -16,6 +16,11 @@ type PoolCreateRequestBody struct {
 	ID      string  `json:"groupid"           url:"poolid"`
 }
 
+// PoolIDRequestBody contains the pool ID for requests addressing a pool by the `poolid` parameter.
+type PoolIDRequestBody struct {
+	ID string `url:"poolid"`
+}
+
 // PoolGetResponseBody contains the body from a pool get response.
 type PoolGetResponseBody struct {
 	Data *PoolGetResponseData `json:"data,omitempty"`
@@ -43,12 +48,15 @@ type PoolListResponseBody struct {
 
 // PoolListResponseData contains the data from a pool list response.
 type PoolListResponseData struct {
-	Comment *string `json:"comment,omitempty"`
-	ID      string  `json:"poolid"`
+	Comment *string                                    `json:"comment,omitempty"`
+	ID      string                                     `json:"poolid"`
+	Members []VirtualEnvironmentPoolGetResponseMembers `json:"members,omitempty"`
 }
 
 // PoolUpdateRequestBody contains the data for an pool update request.
 type PoolUpdateRequestBody struct {
+	// The pool's ID, only used for nested pools.
+	ID *string `json:"poolid,omitempty" url:"poolid,omitempty"`
 	// The pool's comment
 	Comment *string `json:"comment,omitempty" url:"comment,omitempty"`
 	// If this is set to 1, VMs and datastores will be removed from the pool instead of added.
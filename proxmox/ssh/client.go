@@ -8,6 +8,7 @@ package ssh
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"io"
@@ -24,7 +25,6 @@ import (
 	"github.com/skeema/knownhosts"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/agent"
-	"golang.org/x/net/proxy"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/utils"
@@ -34,6 +34,19 @@ const (
 	// TrySudo is a shell function that tries to execute a command with sudo if the user has sudo permissions.
 	//nolint:lll
 	TrySudo = `try_sudo(){ if [ "$(sudo whoami 2>/dev/null)" = "root" ] || [ $(sudo -n pvesm apiinfo 2>&1 | grep "APIVER" | wc -l) -gt 0 ]; then sudo $1; else $1; fi }`
+
+	// stagingDir is the directory on the node where staged uploads are stored until they are moved into the datastore.
+	stagingDir = "/var/tmp"
+
+	// DefaultSFTPChunkSize is the default size of the SFTP write requests, which all servers support.
+	DefaultSFTPChunkSize = 32 * 1024
+
+	// MinSFTPChunkSize is the minimum size of the SFTP write requests.
+	MinSFTPChunkSize = 1024
+
+	// MaxSFTPChunkSize is the maximum size of the SFTP write requests, i.e. the largest message accepted by the
+	// OpenSSH SFTP server. As up to 64 requests are in flight per file, a chunk uses up to 64 times its size in memory.
+	MaxSFTPChunkSize = 256 * 1024
 )
 
 // NewErrUserHasNoPermission creates a new error indicating that the SSH user does not have required permissions.
@@ -58,6 +71,9 @@ type Client interface {
 	// NodeStreamUpload uploads a file to a node by streaming its content over SSH.
 	NodeStreamUpload(ctx context.Context, nodeName string,
 		remoteFileDir string, fileUploadRequest *api.FileUploadRequest) error
+
+	// Close closes the connections to the nodes.
+	Close() error
 }
 
 type client struct {
@@ -66,19 +82,24 @@ type client struct {
 	agent           bool
 	agentSocket     string
 	agentForwarding bool
-	privateKey      string
+	signer          ssh.Signer
 	socks5Server    string
 	socks5Username  string
 	socks5Password  string
+	proxyJump       string
+	sftpChunkSize   int
 	nodeResolver    NodeResolver
+	pool            *connPool
 }
 
 // NewClient creates a new SSH client.
 func NewClient(
 	username string, password string,
 	agent bool, agentSocket string, agentForwarding bool,
-	privateKey string,
+	privateKey string, privateKeyPassphrase string, certificate string,
 	socks5Server string, socks5Username string, socks5Password string,
+	proxyJump string,
+	sftpChunkSize int,
 	nodeResolver NodeResolver,
 ) (Client, error) {
 	if agent &&
@@ -96,28 +117,69 @@ func NewClient(
 		return nil, errors.New("socks5 server is required when socks5 username or password is set")
 	}
 
+	if _, err := parseJumpHost(proxyJump, username); err != nil {
+		return nil, err
+	}
+
+	if sftpChunkSize == 0 {
+		sftpChunkSize = DefaultSFTPChunkSize
+	}
+
+	if sftpChunkSize < MinSFTPChunkSize || sftpChunkSize > MaxSFTPChunkSize {
+		return nil, fmt.Errorf("the SFTP chunk size must be between %d and %d bytes, got %d",
+			MinSFTPChunkSize, MaxSFTPChunkSize, sftpChunkSize)
+	}
+
+	if certificate != "" && privateKey == "" {
+		return nil, errors.New("private key is required when certificate is set")
+	}
+
+	var signer ssh.Signer
+
+	if privateKey != "" {
+		var err error
+
+		signer, err = parseSigner(privateKey, privateKeyPassphrase, certificate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if nodeResolver == nil {
 		return nil, errors.New("node resolver is required")
 	}
 
-	return &client{
+	c := &client{
 		username:        username,
 		password:        password,
 		agent:           agent,
 		agentSocket:     agentSocket,
 		agentForwarding: agentForwarding,
-		privateKey:      privateKey,
+		signer:          signer,
 		socks5Server:    socks5Server,
 		socks5Username:  socks5Username,
 		socks5Password:  socks5Password,
+		proxyJump:       proxyJump,
+		sftpChunkSize:   sftpChunkSize,
 		nodeResolver:    nodeResolver,
-	}, nil
+	}
+
+	c.pool = newConnPool(c.dialNode)
+
+	return c, nil
 }
 
 func (c *client) Username() string {
 	return c.username
 }
 
+// Close closes the pooled connections to the nodes.
+func (c *client) Close() error {
+	c.pool.close()
+
+	return nil
+}
+
 // ExecuteNodeCommands executes commands on a given node.
 func (c *client) ExecuteNodeCommands(ctx context.Context, nodeName string, commands []string) ([]byte, error) {
 	node, err := c.nodeResolver.Resolve(ctx, nodeName)
@@ -131,19 +193,12 @@ func (c *client) ExecuteNodeCommands(ctx context.Context, nodeName string, comma
 		"commands":     commands,
 	})
 
-	sshClient, err := c.openNodeShell(ctx, node)
+	sshClient, release, err := c.pool.acquire(ctx, node)
 	if err != nil {
 		return nil, err
 	}
 
-	defer func(sshClient *ssh.Client) {
-		e := sshClient.Close()
-		if e != nil {
-			tflog.Warn(ctx, "failed to close SSH client", map[string]interface{}{
-				"error": e,
-			})
-		}
-	}(sshClient)
+	defer release()
 
 	output, err := c.executeCommands(ctx, sshClient, commands)
 	if err != nil {
@@ -175,10 +230,6 @@ func (c *client) openSession(ctx context.Context, sshClient *ssh.Client) (*ssh.S
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to request agent forwarding: %w", err)
 		}
-
-		if err = agent.ForwardToRemote(sshClient, c.agentSocket); err != nil {
-			return nil, nil, fmt.Errorf("failed to forward agent connection to remote: %w", err)
-		}
 	}
 
 	return sshSession, closer, nil
@@ -234,19 +285,12 @@ func (c *client) NodeUpload(
 
 	fileSize := fileInfo.Size()
 
-	sshClient, err := c.openNodeShell(ctx, ip)
+	sshClient, release, err := c.pool.acquire(ctx, ip)
 	if err != nil {
 		return fmt.Errorf("failed to open SSH client: %w", err)
 	}
 
-	defer func(sshClient *ssh.Client) {
-		e := sshClient.Close()
-		if e != nil {
-			tflog.Warn(ctx, "failed to close SSH client", map[string]interface{}{
-				"error": e,
-			})
-		}
-	}(sshClient)
+	defer release()
 
 	if d.ContentType != "" {
 		remoteFileDir = filepath.Join(remoteFileDir, d.ContentType)
@@ -254,7 +298,7 @@ func (c *client) NodeUpload(
 
 	remoteFilePath := strings.ReplaceAll(filepath.Join(remoteFileDir, d.FileName), `\`, "/")
 
-	sftpClient, err := sftp.NewClient(sshClient)
+	sftpClient, err := c.newSFTPClient(sshClient)
 	if err != nil {
 		return fmt.Errorf("failed to create SFTP client: %w", err)
 	}
@@ -287,7 +331,10 @@ func (c *client) NodeUpload(
 		}
 	}(remoteFile)
 
+	// the file is read directly by SFTP, which can then upload it with concurrent writes
 	bytesUploaded, err := remoteFile.ReadFrom(d.File)
+	d.BytesUploaded = bytesUploaded
+
 	if err != nil {
 		return fmt.Errorf("failed to upload file %s: %w", remoteFilePath, err)
 	}
@@ -330,19 +377,12 @@ func (c *client) NodeStreamUpload(
 
 	fileSize := fileInfo.Size()
 
-	sshClient, err := c.openNodeShell(ctx, ip)
+	sshClient, release, err := c.pool.acquire(ctx, ip)
 	if err != nil {
 		return fmt.Errorf("failed to open SSH client: %w", err)
 	}
 
-	defer func(sshClient *ssh.Client) {
-		e := sshClient.Close()
-		if e != nil {
-			tflog.Warn(ctx, "failed to close SSH client", map[string]interface{}{
-				"error": e,
-			})
-		}
-	}(sshClient)
+	defer release()
 
 	if d.ContentType != "" {
 		remoteFileDir = filepath.Join(remoteFileDir, d.ContentType)
@@ -350,12 +390,15 @@ func (c *client) NodeStreamUpload(
 
 	remoteFilePath := strings.ReplaceAll(filepath.Join(remoteFileDir, d.FileName), `\`, "/")
 
-	err = c.uploadFile(ctx, sshClient, d, remoteFilePath)
-	if err != nil {
-		return err
+	if d.Staged {
+		err = c.stagedUploadFile(ctx, sshClient, d, remoteFilePath, fileSize)
+	} else {
+		err = c.uploadFile(ctx, sshClient, d, remoteFilePath)
+		if err == nil {
+			err = c.checkUploadedFile(ctx, sshClient, remoteFilePath, fileSize)
+		}
 	}
 
-	err = c.checkUploadedFile(ctx, sshClient, remoteFilePath, fileSize)
 	if err != nil {
 		return err
 	}
@@ -392,7 +435,7 @@ func (c *client) uploadFile(
 		return fmt.Errorf("failed to open SSH session: %w", err)
 	}
 
-	sshSession.Stdin = req.File
+	sshSession.Stdin = req.Reader()
 
 	output, err := sshSession.CombinedOutput(
 		fmt.Sprintf(`%s; try_sudo "/usr/bin/tee %s"`, TrySudo, remoteFilePath),
@@ -404,13 +447,98 @@ func (c *client) uploadFile(
 	return nil
 }
 
+// sftpUploadFile transfers the file over SFTP, which keeps several write requests in flight, unlike the stream
+// of uploadFile which is limited by the round-trip time of high-latency links. The file is written as the SSH user,
+// so its directory must be writable without sudo.
+func (c *client) sftpUploadFile(
+	ctx context.Context,
+	sshClient *ssh.Client,
+	req *api.FileUploadRequest,
+	remoteFilePath string,
+) error {
+	sftpClient, err := c.newSFTPClient(sshClient)
+	if err != nil {
+		return fmt.Errorf("failed to create SFTP client: %w", err)
+	}
+
+	defer utils.CloseOrLogError(ctx)(sftpClient)
+
+	remoteFile, err := sftpClient.Create(remoteFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", remoteFilePath, err)
+	}
+
+	defer utils.CloseOrLogError(ctx)(remoteFile)
+
+	if _, err = remoteFile.ReadFromWithConcurrency(req.Reader(), 0); err != nil {
+		return fmt.Errorf("error transferring file: %w", err)
+	}
+
+	return nil
+}
+
+// stagedUploadFile transfers the file to a temporary file on the node, and once it has been transferred completely,
+// copies it next to the target file in the datastore and renames it. This way a slow or flaky network storage never
+// holds a partially transferred file under the target name.
+func (c *client) stagedUploadFile(
+	ctx context.Context,
+	sshClient *ssh.Client,
+	req *api.FileUploadRequest,
+	remoteFilePath string,
+	fileSize int64,
+) error {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Errorf("failed to generate staging file name: %w", err)
+	}
+
+	stagingFilePath := fmt.Sprintf("%s/terraform-provider-proxmox-%x", stagingDir, suffix)
+	partialFilePath := path.Join(path.Dir(remoteFilePath), fmt.Sprintf(".%s.%x.partial", path.Base(remoteFilePath), suffix))
+
+	defer func() {
+		_, e := c.executeCommands(ctx, sshClient, []string{
+			TrySudo,
+			fmt.Sprintf(`try_sudo "rm -f -- %s %s"`, stagingFilePath, partialFilePath),
+		})
+		if e != nil {
+			tflog.Warn(ctx, "failed to remove staging files", map[string]interface{}{
+				"error": e,
+				"files": []string{stagingFilePath, partialFilePath},
+			})
+		}
+	}()
+
+	tflog.Debug(ctx, "staging file on the node", map[string]interface{}{
+		"staging_file_path": stagingFilePath,
+	})
+
+	if err := c.sftpUploadFile(ctx, sshClient, req, stagingFilePath); err != nil {
+		return err
+	}
+
+	if err := c.checkUploadedFile(ctx, sshClient, stagingFilePath, fileSize); err != nil {
+		return err
+	}
+
+	_, err := c.executeCommands(ctx, sshClient, []string{
+		TrySudo,
+		fmt.Sprintf(`try_sudo "cp -- %s %s" && try_sudo "mv -f -- %s %s"`,
+			stagingFilePath, partialFilePath, partialFilePath, remoteFilePath),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to move staged file into %s: %w", remoteFilePath, err)
+	}
+
+	return c.checkUploadedFile(ctx, sshClient, remoteFilePath, fileSize)
+}
+
 func (c *client) checkUploadedFile(
 	ctx context.Context,
 	sshClient *ssh.Client,
 	remoteFilePath string,
 	fileSize int64,
 ) error {
-	sftpClient, err := sftp.NewClient(sshClient)
+	sftpClient, err := c.newSFTPClient(sshClient)
 	if err != nil {
 		return fmt.Errorf("failed to create SFTP client: %w", err)
 	}
@@ -449,7 +577,7 @@ func (c *client) changeModeUploadedFile(
 	remoteFilePath string,
 	fileMode os.FileMode,
 ) error {
-	sftpClient, err := sftp.NewClient(sshClient)
+	sftpClient, err := c.newSFTPClient(sshClient)
 	if err != nil {
 		return fmt.Errorf("failed to create SFTP client: %w", err)
 	}
@@ -486,6 +614,31 @@ func (c *client) changeModeUploadedFile(
 	return nil
 }
 
+// newSFTPClient creates an SFTP client sending the write requests in chunks of the configured size.
+func (c *client) newSFTPClient(sshClient *ssh.Client) (*sftp.Client, error) {
+	//nolint:wrapcheck
+	return sftp.NewClient(sshClient, sftp.MaxPacketUnchecked(c.sftpChunkSize))
+}
+
+// dialNode establishes a new SSH connection to a node for the pool. The agent connections requested by the sessions
+// are forwarded once per connection, as a connection only accepts a single handler for them.
+func (c *client) dialNode(ctx context.Context, node ProxmoxNode) (*ssh.Client, error) {
+	sshClient, err := c.openNodeShell(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.agentForwarding {
+		if err = agent.ForwardToRemote(sshClient, c.agentSocket); err != nil {
+			utils.CloseOrLogError(ctx)(sshClient)
+
+			return nil, fmt.Errorf("failed to forward agent connection to remote: %w", err)
+		}
+	}
+
+	return sshClient, nil
+}
+
 // openNodeShell establishes a new SSH connection to a node.
 func (c *client) openNodeShell(ctx context.Context, node ProxmoxNode) (*ssh.Client, error) {
 	homeDir, err := os.UserHomeDir()
@@ -553,11 +706,16 @@ func (c *client) openNodeShell(ctx context.Context, node ProxmoxNode) (*ssh.Clie
 		return khErr
 	})
 
+	r, err := c.route(node)
+	if err != nil {
+		return nil, err
+	}
+
 	tflog.Info(ctx, fmt.Sprintf("agent is set to %t", c.agent))
 
 	var sshClient *ssh.Client
 	if c.agent {
-		sshClient, err = c.createSSHClientAgent(ctx, cb, kh, sshHost)
+		sshClient, err = c.createSSHClientAgent(ctx, cb, kh, sshHost, r)
 		if err == nil {
 			return sshClient, nil
 		}
@@ -568,8 +726,8 @@ func (c *client) openNodeShell(ctx context.Context, node ProxmoxNode) (*ssh.Clie
 			})
 	}
 
-	if c.privateKey != "" {
-		sshClient, err = c.createSSHClientWithPrivateKey(ctx, cb, kh, sshHost)
+	if c.signer != nil {
+		sshClient, err = c.createSSHClientWithPrivateKey(ctx, cb, kh, sshHost, r)
 		if err == nil {
 			return sshClient, nil
 		}
@@ -582,7 +740,7 @@ func (c *client) openNodeShell(ctx context.Context, node ProxmoxNode) (*ssh.Clie
 
 	tflog.Info(ctx, "Falling back to password authentication for SSH connection")
 
-	sshClient, err = c.createSSHClient(ctx, cb, kh, sshHost)
+	sshClient, err = c.createSSHClient(ctx, cb, kh, sshHost, r)
 	if err != nil {
 		return nil, fmt.Errorf("unable to authenticate user %q over SSH to %q. Please verify that ssh-agent is "+
 			"correctly loaded with an authorized key via 'ssh-add -L' (NOTE: configurations in ~/.ssh/config are "+
@@ -597,6 +755,7 @@ func (c *client) createSSHClient(
 	cb ssh.HostKeyCallback,
 	kh *knownhosts.HostKeyDB,
 	sshHost string,
+	r route,
 ) (*ssh.Client, error) {
 	if c.password == "" {
 		tflog.Error(ctx, "Using password authentication fallback for SSH connection, but the SSH password is empty")
@@ -609,7 +768,7 @@ func (c *client) createSSHClient(
 		HostKeyAlgorithms: kh.HostKeyAlgorithms(sshHost),
 	}
 
-	return c.connect(ctx, sshHost, sshConfig)
+	return c.connect(ctx, kh, sshHost, sshConfig, r)
 }
 
 // createSSHClientAgent establishes an ssh connection through the agent authentication mechanism.
@@ -618,6 +777,7 @@ func (c *client) createSSHClientAgent(
 	cb ssh.HostKeyCallback,
 	kh *knownhosts.HostKeyDB,
 	sshHost string,
+	r route,
 ) (*ssh.Client, error) {
 	conn, err := dialSocket(ctx, c.agentSocket)
 	if err != nil {
@@ -633,7 +793,7 @@ func (c *client) createSSHClientAgent(
 		HostKeyAlgorithms: kh.HostKeyAlgorithms(sshHost),
 	}
 
-	return c.connect(ctx, sshHost, sshConfig)
+	return c.connect(ctx, kh, sshHost, sshConfig, r)
 }
 
 func (c *client) createSSHClientWithPrivateKey(
@@ -641,69 +801,106 @@ func (c *client) createSSHClientWithPrivateKey(
 	cb ssh.HostKeyCallback,
 	kh *knownhosts.HostKeyDB,
 	sshHost string,
+	r route,
 ) (*ssh.Client, error) {
-	privateKey, err := ssh.ParsePrivateKey([]byte(c.privateKey))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse private key: %w", err)
-	}
-
 	sshConfig := &ssh.ClientConfig{
 		User:              c.username,
-		Auth:              []ssh.AuthMethod{ssh.PublicKeys(privateKey)},
+		Auth:              []ssh.AuthMethod{ssh.PublicKeys(c.signer)},
 		HostKeyCallback:   cb,
 		HostKeyAlgorithms: kh.HostKeyAlgorithms(sshHost),
 	}
 
-	return c.connect(ctx, sshHost, sshConfig)
+	return c.connect(ctx, kh, sshHost, sshConfig, r)
 }
 
-func (c *client) connect(ctx context.Context, sshHost string, sshConfig *ssh.ClientConfig) (*ssh.Client, error) {
-	if c.socks5Server != "" {
-		sshClient, err := c.socks5SSHClient(sshHost, sshConfig)
-		if err != nil {
-			return nil, fmt.Errorf("failed to dial %s via SOCKS5 proxy %s: %w", sshHost, c.socks5Server, err)
-		}
-
-		tflog.Debug(ctx, "SSH connection via SOCKS5 established", map[string]interface{}{
-			"host":          sshHost,
-			"socks5_server": c.socks5Server,
-			"user":          c.username,
-		})
+func (c *client) connect(
+	ctx context.Context,
+	kh *knownhosts.HostKeyDB,
+	sshHost string,
+	sshConfig *ssh.ClientConfig,
+	r route,
+) (*ssh.Client, error) {
+	if r.jumpHost != nil {
+		return c.connectThroughJumpHost(ctx, kh, sshHost, sshConfig, r)
+	}
 
-		return sshClient, nil
+	conn, err := r.dial(sshHost)
+	if err != nil {
+		return nil, err
 	}
 
-	sshClient, err := ssh.Dial("tcp", sshHost, sshConfig)
+	sshConn, ch, reqs, err := ssh.NewClientConn(conn, sshHost, sshConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to dial %s: %w", sshHost, err)
+		return nil, fmt.Errorf("failed to create SSH client connection to %s: %w", sshHost, err)
 	}
 
 	tflog.Debug(ctx, "SSH connection established", map[string]interface{}{
-		"host": sshHost,
-		"user": c.username,
+		"host":          sshHost,
+		"socks5_server": r.socks5Server,
+		"user":          c.username,
 	})
 
-	return sshClient, nil
+	return ssh.NewClient(sshConn, ch, reqs), nil
 }
 
-func (c *client) socks5SSHClient(sshServerAddress string, sshConfig *ssh.ClientConfig) (*ssh.Client, error) {
-	dialer, err := proxy.SOCKS5("tcp", c.socks5Server, &proxy.Auth{
-		User:     c.socks5Username,
-		Password: c.socks5Password,
-	}, proxy.Direct)
+// connectThroughJumpHost connects to the jump host with the same authentication methods as the node,
+// then to the node through a tunnel opened by the jump host. The errors name the hop which failed.
+func (c *client) connectThroughJumpHost(
+	ctx context.Context,
+	kh *knownhosts.HostKeyDB,
+	sshHost string,
+	sshConfig *ssh.ClientConfig,
+	r route,
+) (*ssh.Client, error) {
+	jumpConn, err := r.dial(r.jumpHost.address)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create SOCKS5 proxy dialer: %w", err)
+		return nil, fmt.Errorf("failed to reach the jump host: %w", err)
+	}
+
+	jumpConfig := &ssh.ClientConfig{
+		User:              r.jumpHost.user,
+		Auth:              sshConfig.Auth,
+		HostKeyCallback:   sshConfig.HostKeyCallback,
+		HostKeyAlgorithms: kh.HostKeyAlgorithms(r.jumpHost.address),
 	}
 
-	conn, err := dialer.Dial("tcp", sshServerAddress)
+	jumpSSHConn, ch, reqs, err := ssh.NewClientConn(jumpConn, r.jumpHost.address, jumpConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to dial %s via SOCKS5 proxy %s: %w", sshServerAddress, c.socks5Server, err)
+		return nil, fmt.Errorf("failed to connect to the jump host %s as %q: %w", r.jumpHost.address, r.jumpHost.user, err)
 	}
 
-	sshConn, ch, reqs, err := ssh.NewClientConn(conn, sshServerAddress, sshConfig)
+	jumpClient := ssh.NewClient(jumpSSHConn, ch, reqs)
+
+	conn, err := jumpClient.Dial("tcp", sshHost)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create SSH client connection: %w", err)
+		utils.CloseOrLogError(ctx)(jumpClient)
+
+		return nil, fmt.Errorf("failed to dial %s from the jump host %s: %w", sshHost, r.jumpHost.address, err)
 	}
 
-	return ssh.NewClient(sshConn, ch, reqs), nil
+	sshConn, ch, reqs, err := ssh.NewClientConn(conn, sshHost, sshConfig)
+	if err != nil {
+		utils.CloseOrLogError(ctx)(jumpClient)
+
+		return nil, fmt.Errorf("failed to create SSH client connection to %s through the jump host %s: %w",
+			sshHost, r.jumpHost.address, err)
+	}
+
+	sshClient := ssh.NewClient(sshConn, ch, reqs)
+
+	// the connection to the jump host only serves the connection to the node
+	go func() {
+		_ = sshClient.Wait()
+
+		utils.CloseOrLogError(ctx)(jumpClient)
+	}()
+
+	tflog.Debug(ctx, "SSH connection through the jump host established", map[string]interface{}{
+		"host":          sshHost,
+		"jump_host":     r.jumpHost.address,
+		"socks5_server": r.socks5Server,
+		"user":          c.username,
+	})
+
+	return sshClient, nil
 }
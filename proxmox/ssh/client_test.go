@@ -0,0 +1,54 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticResolver struct{}
+
+func (staticResolver) Resolve(_ context.Context, _ string) (ProxmoxNode, error) {
+	return ProxmoxNode{Address: "127.0.0.1", Port: 22}, nil
+}
+
+func TestNewClientSFTPChunkSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		chunkSize int
+		want      int
+		wantErr   bool
+	}{
+		{"default", 0, DefaultSFTPChunkSize, false},
+		{"custom", 128 * 1024, 128 * 1024, false},
+		{"too small", 512, 0, true},
+		{"too large", 1024 * 1024, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := NewClient("root", "", false, "", false, "", "", "", "", "", "", "", tt.chunkSize, staticResolver{})
+			if tt.wantErr {
+				require.ErrorContains(t, err, "SFTP chunk size")
+				return
+			}
+
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = c.Close() })
+
+			assert.Equal(t, tt.want, c.(*client).sftpChunkSize)
+		})
+	}
+}
@@ -0,0 +1,339 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// keepAliveInterval is the interval of the keep-alive requests sent over the pooled connections.
+	keepAliveInterval = 30 * time.Second
+
+	// keepAliveTimeout is the time to wait for the reply to a keep-alive request before the connection
+	// is considered broken.
+	keepAliveTimeout = 15 * time.Second
+
+	// idleTimeout is the time after which an unused pooled connection is closed.
+	idleTimeout = 5 * time.Minute
+
+	// maxSessions limits the concurrent operations multiplexed over a pooled connection, as sshd only accepts
+	// 10 sessions per connection by default, and an operation may open a channel for the agent forwarding too.
+	maxSessions = 5
+)
+
+var errPoolClosed = errors.New("the SSH connection pool is closed")
+
+var (
+	poolsMu sync.Mutex
+	pools   = map[*connPool]struct{}{}
+)
+
+// ClosePools closes the pooled connections of all the clients, once the provider is torn down.
+func ClosePools() {
+	poolsMu.Lock()
+
+	all := make([]*connPool, 0, len(pools))
+	for p := range pools {
+		all = append(all, p)
+	}
+
+	poolsMu.Unlock()
+
+	for _, p := range all {
+		p.close()
+	}
+}
+
+// pooledConn is a connection to a node shared by the operations on the node.
+type pooledConn struct {
+	// ready is closed once the connection is dialed, successfully or not.
+	ready  chan struct{}
+	client *ssh.Client
+	err    error
+
+	sessions chan struct{}
+	users    int
+	lastUsed time.Time
+}
+
+// connPool keeps a connection per node, so that the operations on a node don't each pay for a key exchange
+// and an authentication, which is slow and may trip the intrusion prevention of hardened nodes.
+type connPool struct {
+	mu    sync.Mutex
+	conns map[ProxmoxNode]*pooledConn
+	dial  func(ctx context.Context, node ProxmoxNode) (*ssh.Client, error)
+	done  chan struct{}
+
+	keepAliveInterval time.Duration
+	keepAliveTimeout  time.Duration
+	idleTimeout       time.Duration
+}
+
+func newConnPool(dial func(ctx context.Context, node ProxmoxNode) (*ssh.Client, error)) *connPool {
+	p := &connPool{
+		conns:             map[ProxmoxNode]*pooledConn{},
+		dial:              dial,
+		done:              make(chan struct{}),
+		keepAliveInterval: keepAliveInterval,
+		keepAliveTimeout:  keepAliveTimeout,
+		idleTimeout:       idleTimeout,
+	}
+
+	poolsMu.Lock()
+	pools[p] = struct{}{}
+	poolsMu.Unlock()
+
+	return p
+}
+
+// acquire returns the connection to the node, and the function to call once the connection is no longer used.
+// A cached connection is checked before it is returned, and dialed again if it is broken.
+func (p *connPool) acquire(ctx context.Context, node ProxmoxNode) (*ssh.Client, func(), error) {
+	for {
+		p.mu.Lock()
+
+		select {
+		case <-p.done:
+			p.mu.Unlock()
+
+			return nil, nil, errPoolClosed
+		default:
+		}
+
+		pc, ok := p.conns[node]
+		if !ok {
+			pc = &pooledConn{
+				ready:    make(chan struct{}),
+				sessions: make(chan struct{}, maxSessions),
+			}
+			p.conns[node] = pc
+
+			p.mu.Unlock()
+
+			return p.connect(ctx, node, pc)
+		}
+
+		p.mu.Unlock()
+
+		select {
+		case <-pc.ready:
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("interrupted while waiting for the SSH connection: %w", ctx.Err())
+		}
+
+		if pc.err != nil {
+			return nil, nil, pc.err
+		}
+
+		select {
+		case pc.sessions <- struct{}{}:
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("interrupted while waiting for an SSH session: %w", ctx.Err())
+		}
+
+		if err := p.ping(pc.client); err != nil {
+			<-pc.sessions
+
+			tflog.Debug(ctx, "re-dialing the broken SSH connection", map[string]interface{}{
+				"node_address": node.Address,
+				"error":        err,
+			})
+
+			p.evict(node, pc)
+
+			continue
+		}
+
+		p.mu.Lock()
+
+		if p.conns[node] != pc {
+			// the connection was evicted meanwhile
+			p.mu.Unlock()
+
+			<-pc.sessions
+
+			continue
+		}
+
+		pc.users++
+
+		p.mu.Unlock()
+
+		return pc.client, p.releaser(pc), nil
+	}
+}
+
+// connect dials the connection of a new pool entry, which the concurrent callers wait for.
+func (p *connPool) connect(ctx context.Context, node ProxmoxNode, pc *pooledConn) (*ssh.Client, func(), error) {
+	client, err := p.dial(ctx, node)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pc.client, pc.err = client, err
+
+	if err == nil && p.conns[node] != pc {
+		// the pool was closed while dialing
+		_ = client.Close()
+
+		pc.err = errPoolClosed
+	}
+
+	close(pc.ready)
+
+	if pc.err != nil {
+		if p.conns[node] == pc {
+			delete(p.conns, node)
+		}
+
+		return nil, nil, pc.err
+	}
+
+	pc.sessions <- struct{}{}
+	pc.users++
+
+	// the connection outlives the operation which dialed it
+	go p.keepAlive(context.WithoutCancel(ctx), node, pc)
+
+	return client, p.releaser(pc), nil
+}
+
+func (p *connPool) releaser(pc *pooledConn) func() {
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			pc.users--
+			pc.lastUsed = time.Now()
+			p.mu.Unlock()
+
+			<-pc.sessions
+		})
+	}
+}
+
+// keepAlive pings the connection periodically, so that neither the node nor a firewall drops it while it is idle,
+// and closes it once it is broken or unused for too long.
+func (p *connPool) keepAlive(ctx context.Context, node ProxmoxNode, pc *pooledConn) {
+	ticker := time.NewTicker(p.keepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+
+		p.mu.Lock()
+		evicted := p.conns[node] != pc
+		idle := pc.users == 0 && time.Since(pc.lastUsed) > p.idleTimeout
+		p.mu.Unlock()
+
+		if evicted {
+			return
+		}
+
+		if idle {
+			tflog.Debug(ctx, "closing the idle SSH connection", map[string]interface{}{
+				"node_address": node.Address,
+			})
+
+			p.evict(node, pc)
+
+			return
+		}
+
+		if err := p.ping(pc.client); err != nil {
+			tflog.Debug(ctx, "closing the broken SSH connection", map[string]interface{}{
+				"node_address": node.Address,
+				"error":        err,
+			})
+
+			p.evict(node, pc)
+
+			return
+		}
+	}
+}
+
+// ping sends a keep-alive request, and closes the connection if the node does not reply in time.
+func (p *connPool) ping(client *ssh.Client) error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		// the node replies with a failure to the unknown request, which still proves that the connection works
+		_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-time.After(p.keepAliveTimeout):
+		_ = client.Close()
+
+		return errors.New("timeout while waiting for the keep-alive reply")
+	}
+}
+
+// evict removes the connection from the pool and closes it.
+func (p *connPool) evict(node ProxmoxNode, pc *pooledConn) {
+	p.mu.Lock()
+
+	if p.conns[node] == pc {
+		delete(p.conns, node)
+	}
+
+	p.mu.Unlock()
+
+	_ = pc.client.Close()
+}
+
+// close closes all the connections of the pool, and fails the later calls to acquire.
+func (p *connPool) close() {
+	poolsMu.Lock()
+	delete(pools, p)
+	poolsMu.Unlock()
+
+	p.mu.Lock()
+
+	select {
+	case <-p.done:
+		p.mu.Unlock()
+
+		return
+	default:
+	}
+
+	close(p.done)
+
+	conns := p.conns
+	p.conns = map[ProxmoxNode]*pooledConn{}
+
+	p.mu.Unlock()
+
+	for _, pc := range conns {
+		// the connections being dialed are closed once they are established
+		select {
+		case <-pc.ready:
+			if pc.client != nil {
+				_ = pc.client.Close()
+			}
+		default:
+		}
+	}
+}
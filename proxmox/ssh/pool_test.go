@@ -0,0 +1,218 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// testServer is an SSH server which accepts any client, and only replies to the global requests.
+type testServer struct {
+	listener net.Listener
+	dials    atomic.Int32
+
+	mu    sync.Mutex
+	conns []*ssh.ServerConn
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := ssh.NewSignerFromSigner(hostKey)
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &testServer{listener: listener}
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+
+		s.dropConnections()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				sshConn, chans, reqs, err := ssh.NewServerConn(conn, config)
+				if err != nil {
+					return
+				}
+
+				s.mu.Lock()
+				s.conns = append(s.conns, sshConn)
+				s.mu.Unlock()
+
+				go ssh.DiscardRequests(reqs)
+
+				for ch := range chans {
+					_ = ch.Reject(ssh.Prohibited, "no channels")
+				}
+			}()
+		}
+	}()
+
+	return s
+}
+
+func (s *testServer) dial(_ context.Context, _ ProxmoxNode) (*ssh.Client, error) {
+	s.dials.Add(1)
+
+	return ssh.Dial("tcp", s.listener.Addr().String(), &ssh.ClientConfig{
+		User:            "root",
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+	})
+}
+
+// dropConnections closes the established connections on the server side.
+func (s *testServer) dropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.conns {
+		_ = c.Close()
+	}
+
+	s.conns = nil
+}
+
+func TestConnPoolReuse(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer(t)
+	p := newConnPool(s.dial)
+	t.Cleanup(p.close)
+
+	node := ProxmoxNode{Address: "pve1", Port: 22}
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			c, release, err := p.acquire(context.Background(), node)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			defer release()
+
+			_, _, err = c.SendRequest("test", true, nil)
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(1), s.dials.Load())
+
+	_, release, err := p.acquire(context.Background(), ProxmoxNode{Address: "pve2", Port: 22})
+	require.NoError(t, err)
+	release()
+
+	assert.Equal(t, int32(2), s.dials.Load())
+}
+
+func TestConnPoolRedial(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer(t)
+	p := newConnPool(s.dial)
+	t.Cleanup(p.close)
+
+	node := ProxmoxNode{Address: "pve1", Port: 22}
+
+	c1, release, err := p.acquire(context.Background(), node)
+	require.NoError(t, err)
+	release()
+
+	s.dropConnections()
+
+	c2, release, err := p.acquire(context.Background(), node)
+	require.NoError(t, err)
+	release()
+
+	assert.NotSame(t, c1, c2)
+	assert.Equal(t, int32(2), s.dials.Load())
+}
+
+func TestConnPoolIdleTimeout(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer(t)
+	p := newConnPool(s.dial)
+	p.keepAliveInterval = 10 * time.Millisecond
+	p.idleTimeout = 20 * time.Millisecond
+
+	t.Cleanup(p.close)
+
+	node := ProxmoxNode{Address: "pve1", Port: 22}
+
+	_, release, err := p.acquire(context.Background(), node)
+	require.NoError(t, err)
+	release()
+
+	require.Eventually(t, func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		return len(p.conns) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestConnPoolClose(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer(t)
+	p := newConnPool(s.dial)
+
+	node := ProxmoxNode{Address: "pve1", Port: 22}
+
+	c, release, err := p.acquire(context.Background(), node)
+	require.NoError(t, err)
+	release()
+
+	p.close()
+
+	// the connection is closed, so the request fails
+	_, _, err = c.SendRequest("test", true, nil)
+	require.Error(t, err)
+
+	_, _, err = p.acquire(context.Background(), node)
+	require.ErrorIs(t, err, errPoolClosed)
+
+	poolsMu.Lock()
+	defer poolsMu.Unlock()
+
+	assert.NotContains(t, pools, p)
+}
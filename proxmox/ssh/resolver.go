@@ -14,6 +14,13 @@ import (
 type ProxmoxNode struct {
 	Address string
 	Port    int32
+
+	// ProxyJump is the jump host to reach the node through, as `[user@]host[:port]`,
+	// overriding the one of the client.
+	ProxyJump string
+	// Socks5Server is the SOCKS5 proxy server to reach the node, or its jump host, through,
+	// overriding the one of the client.
+	Socks5Server string
 }
 
 // NodeResolver is an interface for resolving node names to IP addresses to use for SSH connection.
@@ -0,0 +1,112 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/proxy"
+)
+
+// jumpHost is a host the nodes are reached through, like the `ProxyJump` option of OpenSSH.
+type jumpHost struct {
+	user    string
+	address string
+}
+
+// route is the way to reach a node: directly or through a SOCKS5 proxy, and possibly through a jump host,
+// in which case the proxy is used to reach the jump host.
+type route struct {
+	socks5Server   string
+	socks5Username string
+	socks5Password string
+	jumpHost       *jumpHost
+}
+
+// parseJumpHost parses a jump host as `[user@]host[:port]`, the user defaulting to the given one
+// and the port to 22. An empty jump host is returned as nil.
+func parseJumpHost(s string, defaultUser string) (*jumpHost, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	user := defaultUser
+	hostPort := s
+
+	if u, h, ok := strings.Cut(s, "@"); ok {
+		user, hostPort = u, h
+	}
+
+	host, port := strings.Trim(hostPort, "[]"), "22"
+
+	if h, p, err := net.SplitHostPort(hostPort); err == nil {
+		host, port = h, p
+	}
+
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 || user == "" || host == "" {
+		return nil, fmt.Errorf("invalid SSH jump host %q, expected [user@]host[:port]", s)
+	}
+
+	return &jumpHost{user: user, address: net.JoinHostPort(host, port)}, nil
+}
+
+// route returns the way to reach the node, its overrides taking precedence over the settings of the client.
+func (c *client) route(node ProxmoxNode) (route, error) {
+	r := route{
+		socks5Server:   c.socks5Server,
+		socks5Username: c.socks5Username,
+		socks5Password: c.socks5Password,
+	}
+
+	if node.Socks5Server != "" {
+		r.socks5Server = node.Socks5Server
+	}
+
+	proxyJump := c.proxyJump
+	if node.ProxyJump != "" {
+		proxyJump = node.ProxyJump
+	}
+
+	jh, err := parseJumpHost(proxyJump, c.username)
+	if err != nil {
+		return route{}, err
+	}
+
+	r.jumpHost = jh
+
+	return r, nil
+}
+
+// dial opens a TCP connection to the address, through the SOCKS5 proxy if any.
+func (r route) dial(address string) (net.Conn, error) {
+	if r.socks5Server == "" {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial %s: %w", address, err)
+		}
+
+		return conn, nil
+	}
+
+	dialer, err := proxy.SOCKS5("tcp", r.socks5Server, &proxy.Auth{
+		User:     r.socks5Username,
+		Password: r.socks5Password,
+	}, proxy.Direct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SOCKS5 proxy dialer: %w", err)
+	}
+
+	conn, err := dialer.Dial("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s via SOCKS5 proxy %s: %w", address, r.socks5Server, err)
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,65 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJumpHost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    *jumpHost
+		wantErr bool
+	}{
+		{"empty", "", nil, false},
+		{"host", "bastion", &jumpHost{user: "root", address: "bastion:22"}, false},
+		{"user and port", "admin@bastion:2222", &jumpHost{user: "admin", address: "bastion:2222"}, false},
+		{"ipv6", "[2001:db8::1]", &jumpHost{user: "root", address: "[2001:db8::1]:22"}, false},
+		{"ipv6 and port", "admin@[2001:db8::1]:2222", &jumpHost{user: "admin", address: "[2001:db8::1]:2222"}, false},
+		{"invalid port", "bastion:ssh", nil, true},
+		{"empty user", "@bastion", nil, true},
+		{"empty host", "admin@", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseJumpHost(tt.value, "root")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClientRoute(t *testing.T) {
+	t.Parallel()
+
+	c := &client{username: "root", socks5Server: "proxy:1080", proxyJump: "bastion"}
+
+	r, err := c.route(ProxmoxNode{Address: "10.0.0.1", Port: 22})
+	require.NoError(t, err)
+	assert.Equal(t, "proxy:1080", r.socks5Server)
+	assert.Equal(t, &jumpHost{user: "root", address: "bastion:22"}, r.jumpHost)
+
+	r, err = c.route(ProxmoxNode{Address: "10.0.0.2", Port: 22, ProxyJump: "admin@other:2222", Socks5Server: "other:1080"})
+	require.NoError(t, err)
+	assert.Equal(t, "other:1080", r.socks5Server)
+	assert.Equal(t, &jumpHost{user: "admin", address: "other:2222"}, r.jumpHost)
+}
@@ -0,0 +1,71 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	// ErrPrivateKeyPassphraseMissing is returned when the private key is encrypted, but no passphrase is set.
+	ErrPrivateKeyPassphraseMissing = errors.New("the SSH private key is encrypted, but no passphrase is set")
+
+	// ErrPrivateKeyPassphraseIncorrect is returned when the passphrase does not decrypt the private key.
+	ErrPrivateKeyPassphraseIncorrect = errors.New("the SSH private key passphrase is incorrect")
+)
+
+// parseSigner parses the private key, decrypting it with the passphrase if it is encrypted. When a certificate
+// is set, the returned signer authenticates with the certificate instead of the bare public key.
+// The passphrase of an unencrypted key is ignored.
+func parseSigner(privateKey string, passphrase string, certificate string) (ssh.Signer, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+
+	var missingErr *ssh.PassphraseMissingError
+	if errors.As(err, &missingErr) {
+		if passphrase == "" {
+			return nil, ErrPrivateKeyPassphraseMissing
+		}
+
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(privateKey), []byte(passphrase))
+		if errors.Is(err, x509.IncorrectPasswordError) {
+			return nil, ErrPrivateKeyPassphraseIncorrect
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unsupported SSH private key format: %w", err)
+	}
+
+	if certificate == "" {
+		return signer, nil
+	}
+
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(certificate))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported SSH certificate format: %w", err)
+	}
+
+	cert, ok := pub.(*ssh.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("the SSH certificate is a bare %s public key, not a signed certificate", pub.Type())
+	}
+
+	if cert.CertType != ssh.UserCert {
+		return nil, errors.New("the SSH certificate is a host certificate, not a user certificate")
+	}
+
+	signer, err = ssh.NewCertSigner(cert, signer)
+	if err != nil {
+		return nil, errors.New("the SSH certificate does not match the private key")
+	}
+
+	return signer, nil
+}
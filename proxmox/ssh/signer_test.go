@@ -0,0 +1,164 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+const testPassphrase = "correct horse battery staple"
+
+// testKey generates a private key, and returns it along with its encoding, encrypted when a passphrase is given.
+func testKey(t *testing.T, keyType string, passphrase string) (crypto.Signer, string) {
+	t.Helper()
+
+	var key crypto.Signer
+
+	switch keyType {
+	case "ed25519":
+		_, k, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		key = k
+	case "rsa":
+		k, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		key = k
+	}
+
+	if passphrase == "" && keyType == "rsa" {
+		// the legacy PKCS #1 format is still common for the RSA keys
+		return key, string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key.(*rsa.PrivateKey)),
+		}))
+	}
+
+	var (
+		block *pem.Block
+		err   error
+	)
+
+	if passphrase == "" {
+		block, err = ssh.MarshalPrivateKey(key, "")
+	} else {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(key, "", []byte(passphrase))
+	}
+
+	require.NoError(t, err)
+
+	return key, string(pem.EncodeToMemory(block))
+}
+
+// testCertificate signs the public key of the given key with a new CA, and returns the certificate
+// in the format of a `-cert.pub` file.
+func testCertificate(t *testing.T, key crypto.Signer, certType uint32) string {
+	t.Helper()
+
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ca, err := ssh.NewSignerFromSigner(caKey)
+	require.NoError(t, err)
+
+	pub, err := ssh.NewPublicKey(key.Public())
+	require.NoError(t, err)
+
+	cert := &ssh.Certificate{
+		Key:             pub,
+		CertType:        certType,
+		KeyId:           "terraform",
+		ValidPrincipals: []string{"root"},
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+
+	require.NoError(t, cert.SignCert(rand.Reader, ca))
+
+	return string(ssh.MarshalAuthorizedKey(cert))
+}
+
+func TestParseSigner(t *testing.T) {
+	t.Parallel()
+
+	for _, keyType := range []string{"ed25519", "rsa"} {
+		t.Run(keyType, func(t *testing.T) {
+			t.Parallel()
+
+			key, plain := testKey(t, keyType, "")
+			encryptedKey, encrypted := testKey(t, keyType, testPassphrase)
+
+			signer, err := parseSigner(plain, "", "")
+			require.NoError(t, err)
+			assert.Equal(t, key.Public(), signer.PublicKey().(ssh.CryptoPublicKey).CryptoPublicKey())
+
+			// the passphrase of an unencrypted key is ignored
+			_, err = parseSigner(plain, testPassphrase, "")
+			require.NoError(t, err)
+
+			signer, err = parseSigner(encrypted, testPassphrase, "")
+			require.NoError(t, err)
+			assert.Equal(t, encryptedKey.Public(), signer.PublicKey().(ssh.CryptoPublicKey).CryptoPublicKey())
+
+			_, err = parseSigner(encrypted, "", "")
+			require.ErrorIs(t, err, ErrPrivateKeyPassphraseMissing)
+
+			_, err = parseSigner(encrypted, "wrong", "")
+			require.ErrorIs(t, err, ErrPrivateKeyPassphraseIncorrect)
+
+			signer, err = parseSigner(encrypted, testPassphrase, testCertificate(t, encryptedKey, ssh.UserCert))
+			require.NoError(t, err)
+
+			cert, ok := signer.PublicKey().(*ssh.Certificate)
+			require.True(t, ok)
+			assert.Equal(t, "terraform", cert.KeyId)
+		})
+	}
+}
+
+func TestParseSignerErrors(t *testing.T) {
+	t.Parallel()
+
+	key, plain := testKey(t, "ed25519", "")
+	otherKey, _ := testKey(t, "ed25519", "")
+
+	pub, err := ssh.NewPublicKey(key.Public())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		privateKey  string
+		certificate string
+		wantErr     string
+	}{
+		{"unsupported key", "not a key", "", "unsupported SSH private key format"},
+		{"unsupported certificate", plain, "not a certificate", "unsupported SSH certificate format"},
+		{"public key", plain, string(ssh.MarshalAuthorizedKey(pub)), "not a signed certificate"},
+		{"host certificate", plain, testCertificate(t, key, ssh.HostCert), "not a user certificate"},
+		{"other key", plain, testCertificate(t, otherKey, ssh.UserCert), "does not match the private key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseSigner(tt.privateKey, "", tt.certificate)
+			require.ErrorContains(t, err, tt.wantErr)
+			require.NotErrorIs(t, err, ErrPrivateKeyPassphraseIncorrect)
+		})
+	}
+}
@@ -7,9 +7,76 @@
 package storage
 
 import (
+	"path"
+
 	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
 )
 
+const (
+	// DatastoreTypeCephFS is the type of CephFS datastores.
+	DatastoreTypeCephFS = "cephfs"
+	// DatastoreTypeCIFS is the type of CIFS/SMB datastores.
+	DatastoreTypeCIFS = "cifs"
+	// DatastoreTypeDir is the type of directory datastores.
+	DatastoreTypeDir = "dir"
+	// DatastoreTypeGlusterFS is the type of GlusterFS datastores.
+	DatastoreTypeGlusterFS = "glusterfs"
+	// DatastoreTypeNFS is the type of NFS datastores.
+	DatastoreTypeNFS = "nfs"
+	// DatastoreTypePBS is the type of Proxmox Backup Server datastores.
+	DatastoreTypePBS = "pbs"
+)
+
+// DatastoreData contains the attributes of a datastore.
+type DatastoreData struct {
+	Content      types.CustomCommaSeparatedList `json:"content,omitempty"       url:"content,omitempty,comma"`
+	Disable      *types.CustomBool              `json:"disable,omitempty"       url:"disable,omitempty,int"`
+	Nodes        *string                        `json:"nodes,omitempty"         url:"nodes,omitempty"`
+	Path         *string                        `json:"path,omitempty"          url:"path,omitempty"`
+	PruneBackups *string                        `json:"prune-backups,omitempty" url:"prune-backups,omitempty"`
+	Shared       *types.CustomBool              `json:"shared,omitempty"        url:"shared,omitempty,int"`
+
+	// NFS, CIFS and PBS.
+	Server *string `json:"server,omitempty" url:"server,omitempty"`
+
+	// NFS.
+	Export *string `json:"export,omitempty" url:"export,omitempty"`
+
+	// CIFS.
+	Domain *string `json:"domain,omitempty" url:"domain,omitempty"`
+	Share  *string `json:"share,omitempty"  url:"share,omitempty"`
+
+	// CIFS and PBS.
+	Username *string `json:"username,omitempty" url:"username,omitempty"`
+
+	// PBS.
+	Datastore   *string `json:"datastore,omitempty"   url:"datastore,omitempty"`
+	Fingerprint *string `json:"fingerprint,omitempty" url:"fingerprint,omitempty"`
+	Namespace   *string `json:"namespace,omitempty"   url:"namespace,omitempty"`
+}
+
+// DatastoreCreateRequestBody contains the data for a datastore create request.
+type DatastoreCreateRequestBody struct {
+	DatastoreData
+
+	ID   string `url:"storage"`
+	Type string `url:"type"`
+
+	// Secrets are write-only and never returned by the API.
+	EncryptionKey *string `url:"encryption-key,omitempty"`
+	Password      *string `url:"password,omitempty"`
+}
+
+// DatastoreUpdateRequestBody contains the data for a datastore update request.
+type DatastoreUpdateRequestBody struct {
+	DatastoreData
+
+	EncryptionKey *string `url:"encryption-key,omitempty"`
+	Password      *string `url:"password,omitempty"`
+
+	Delete *string `url:"delete,omitempty"`
+}
+
 // DatastoreGetResponseBody contains the body from a datastore get response.
 type DatastoreGetResponseBody struct {
 	Data *DatastoreGetResponseData `json:"data,omitempty"`
@@ -17,10 +84,32 @@ type DatastoreGetResponseBody struct {
 
 // DatastoreGetResponseData contains the data from a datastore get response.
 type DatastoreGetResponseData struct {
-	Content types.CustomCommaSeparatedList `json:"content,omitempty" url:"content,omitempty,comma"`
-	Digest  *string                        `json:"digest,omitempty"`
-	Path    *string                        `json:"path,omitempty"`
-	Shared  *types.CustomBool              `json:"shared,omitempty"`
-	Storage *string                        `json:"storage,omitempty"`
-	Type    *string                        `json:"type,omitempty"`
+	DatastoreData
+
+	Digest  *string `json:"digest,omitempty"`
+	Storage *string `json:"storage,omitempty"`
+	Type    *string `json:"type,omitempty"`
+
+	// EncryptionKey is the fingerprint of the encryption key of a PBS datastore, the key itself is not returned.
+	EncryptionKey *string `json:"encryption-key,omitempty"`
+}
+
+// FilePath returns the path of the datastore in the file system of the nodes, or an empty string if the
+// datastore stores its content elsewhere, e.g. in a Ceph RBD pool, a ZFS pool or a Proxmox Backup Server.
+// The network file systems omit the path when they are mounted at the default mount point.
+func (d *DatastoreGetResponseData) FilePath() string {
+	if d.Path != nil && *d.Path != "" {
+		return *d.Path
+	}
+
+	if d.Type == nil || d.Storage == nil {
+		return ""
+	}
+
+	switch *d.Type {
+	case DatastoreTypeCephFS, DatastoreTypeCIFS, DatastoreTypeGlusterFS, DatastoreTypeNFS:
+		return path.Join("/mnt/pve", *d.Storage)
+	default:
+		return ""
+	}
 }
@@ -0,0 +1,48 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+func TestDatastoreGetResponseDataFilePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		storageType string
+		path        *string
+		want        string
+	}{
+		{"directory", DatastoreTypeDir, ptr.Ptr("/var/lib/vz"), "/var/lib/vz"},
+		{"nfs with path", DatastoreTypeNFS, ptr.Ptr("/mnt/nfs"), "/mnt/nfs"},
+		{"cephfs default mount point", DatastoreTypeCephFS, nil, "/mnt/pve/store"},
+		{"cifs default mount point", DatastoreTypeCIFS, ptr.Ptr(""), "/mnt/pve/store"},
+		{"rbd", "rbd", nil, ""},
+		{"zfs pool", "zfspool", nil, ""},
+		{"pbs", DatastoreTypePBS, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &DatastoreGetResponseData{
+				DatastoreData: DatastoreData{Path: tt.path},
+				Storage:       ptr.Ptr("store"),
+				Type:          ptr.Ptr(tt.storageType),
+			}
+
+			assert.Equal(t, tt.want, d.FilePath())
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//nolint:gochecknoglobals
+var (
+	calendarEventSpecialNames = []string{
+		"minutely", "hourly", "daily", "weekly", "monthly", "yearly", "annually", "quarterly", "semiannually",
+	}
+	calendarEventWeekdays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+	calendarEventItem     = regexp.MustCompile(`^(\*|(\d+)(\.\.(\d+))?)(/(\d+))?$`)
+)
+
+// ParseCalendarEvent validates a PVE calendar event, i.e. the schedule of a job, and returns it with
+// normalized whitespace. The format is a subset of systemd calendar events:
+// `[WEEKDAY] [[YEARS-]MONTHS-DAYS] [HOURS:MINUTES[:SECONDS]]`, where each component can be a list of values,
+// ranges (`mon..fri`, `8..17`) and repetitions (`*/15`, `0/30`), e.g. `mon..fri 02:30` or `*/5`.
+// The special names `minutely`, `hourly`, `daily`, `weekly`, `monthly`, `yearly`, `annually`, `quarterly`
+// and `semiannually` are supported as well.
+func ParseCalendarEvent(input string) (string, error) {
+	fields := strings.Fields(strings.ToLower(input))
+	if len(fields) == 0 {
+		return "", errors.New("calendar event must not be empty")
+	}
+
+	if len(fields) == 1 && slices.Contains(calendarEventSpecialNames, fields[0]) {
+		return fields[0], nil
+	}
+
+	i := 0
+
+	if fields[i][0] >= 'a' && fields[i][0] <= 'z' {
+		if err := parseCalendarEventWeekdays(fields[i]); err != nil {
+			return "", err
+		}
+
+		i++
+	}
+
+	if i < len(fields) && strings.Contains(fields[i], "-") {
+		if err := parseCalendarEventDate(fields[i]); err != nil {
+			return "", err
+		}
+
+		i++
+	}
+
+	if i < len(fields) {
+		if err := parseCalendarEventTime(fields[i]); err != nil {
+			return "", err
+		}
+
+		i++
+	}
+
+	if i != len(fields) {
+		return "", fmt.Errorf("unexpected %q in calendar event %q", fields[i], input)
+	}
+
+	return strings.Join(fields, " "), nil
+}
+
+func parseCalendarEventWeekdays(s string) error {
+	for _, item := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(item, "..")
+
+		if !isCalendarEventWeekday(from) || (isRange && !isCalendarEventWeekday(to)) {
+			return fmt.Errorf("invalid weekday %q in calendar event", item)
+		}
+	}
+
+	return nil
+}
+
+func isCalendarEventWeekday(s string) bool {
+	return slices.ContainsFunc(calendarEventWeekdays, func(day string) bool {
+		return strings.HasPrefix(s, day) && len(s) <= len("wednesday")
+	})
+}
+
+func parseCalendarEventDate(s string) error {
+	components := strings.Split(s, "-")
+
+	switch len(components) {
+	case 2:
+		return parseCalendarEventComponents(components, []string{"month", "day"}, []int{1, 1}, []int{12, 31})
+	case 3:
+		return parseCalendarEventComponents(
+			components, []string{"year", "month", "day"}, []int{1970, 1, 1}, []int{9999, 12, 31},
+		)
+	default:
+		return fmt.Errorf("invalid date %q in calendar event", s)
+	}
+}
+
+func parseCalendarEventTime(s string) error {
+	components := strings.Split(s, ":")
+
+	switch len(components) {
+	case 1:
+		return parseCalendarEventComponents(components, []string{"minute"}, []int{0}, []int{59})
+	case 2:
+		return parseCalendarEventComponents(components, []string{"hour", "minute"}, []int{0, 0}, []int{23, 59})
+	case 3:
+		return parseCalendarEventComponents(
+			components, []string{"hour", "minute", "second"}, []int{0, 0, 0}, []int{23, 59, 59},
+		)
+	default:
+		return fmt.Errorf("invalid time %q in calendar event", s)
+	}
+}
+
+func parseCalendarEventComponents(components []string, names []string, minValues []int, maxValues []int) error {
+	for i, component := range components {
+		for _, item := range strings.Split(component, ",") {
+			m := calendarEventItem.FindStringSubmatch(item)
+			if m == nil {
+				return fmt.Errorf("invalid %s %q in calendar event", names[i], item)
+			}
+
+			for _, value := range []string{m[2], m[4]} {
+				if value == "" {
+					continue
+				}
+
+				v, err := strconv.Atoi(value)
+				if err != nil || v < minValues[i] || v > maxValues[i] {
+					return fmt.Errorf(
+						"%s %q in calendar event is out of range %d..%d", names[i], value, minValues[i], maxValues[i],
+					)
+				}
+			}
+
+			if m[6] != "" {
+				if v, err := strconv.Atoi(m[6]); err != nil || v == 0 {
+					return fmt.Errorf("invalid repetition %q in calendar event", item)
+				}
+			}
+		}
+	}
+
+	return nil
+}
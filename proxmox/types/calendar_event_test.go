@@ -0,0 +1,54 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package types
+
+import (
+	"testing"
+)
+
+func TestParseCalendarEvent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"special name", "daily", "daily", false},
+		{"time", "21:00", "21:00", false},
+		{"minute repetition", "*/5", "*/5", false},
+		{"weekday and time", "sat 02:00", "sat 02:00", false},
+		{"weekday range", "mon..fri 8..17,22:0/15", "mon..fri 8..17,22:0/15", false},
+		{"weekday list", "mon,wed,fri 21:00", "mon,wed,fri 21:00", false},
+		{"full weekday name", "sunday 01:30", "sunday 01:30", false},
+		{"date", "*-01-01 00:00", "*-01-01 00:00", false},
+		{"year date", "2030-12-24 18:00:30", "2030-12-24 18:00:30", false},
+		{"whitespace is normalized", "  Sat   02:00 ", "sat 02:00", false},
+		{"empty", "", "", true},
+		{"invalid weekday", "foo 02:00", "", true},
+		{"hour out of range", "24:00", "", true},
+		{"minute out of range", "02:60", "", true},
+		{"zero repetition", "*/0", "", true},
+		{"invalid date", "1-2-3-4 00:00", "", true},
+		{"trailing garbage", "sat 02:00 03:00", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseCalendarEvent(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCalendarEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseCalendarEvent() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
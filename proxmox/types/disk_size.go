@@ -130,3 +130,22 @@ func FormatDiskSize(size *DiskSize) string {
 
 	return round(float64(*size)/1024/1024/1024/1024) + "T"
 }
+
+// FormatDiskSizeExact turns a number of bytes into a disk size string in the largest unit which represents
+// the size exactly, as Proxmox VE prints the sizes of the disks, e.g. `10752M` rather than `10.5G`.
+func FormatDiskSizeExact(size *DiskSize) string {
+	if size == nil || *size < 0 {
+		return ""
+	}
+
+	n := int64(*size)
+	units := []string{"", "K", "M", "G", "T"}
+	i := 0
+
+	for i < len(units)-1 && n != 0 && n%1024 == 0 {
+		n /= 1024
+		i++
+	}
+
+	return strconv.FormatInt(n, 10) + units[i]
+}
@@ -82,6 +82,33 @@ func TestFormatDiskSize(t *testing.T) {
 	}
 }
 
+func TestFormatDiskSizeExact(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		size int64
+		want string
+	}{
+		{"handle 0 size", 0, "0"},
+		{"handle bytes", 1234, "1234"},
+		{"handle kilobytes", 2690412 * 1024, "2690412K"},
+		{"handle megabytes", 10752 * 1024 * 1024, "10752M"},
+		{"handle gigabytes", 2147483648, "2G"},
+		{"handle terabytes", 2199023255552, "2T"},
+		{"handle petabytes", 1024 * 2199023255552, "2048T"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			size := DiskSize(tt.size)
+
+			assert.Equal(t, tt.want, FormatDiskSizeExact(&size))
+		})
+	}
+}
+
 func TestToFromGigabytes(t *testing.T) {
 	t.Parallel()
 
@@ -173,11 +173,9 @@ func ParseMap(input string) (Map, error) {
 	regExNotMatchErr := func(attr, attrName string, err error) error {
 		return errors.Join(
 			ErrMapParsingFormat(
-				fmt.Sprintf(
-					"invalid format %q for hardware mapping %q attribute",
-					attr,
-					attrName,
-				),
+				"invalid format %q for hardware mapping %q attribute",
+				attr,
+				attrName,
 			), err,
 		)
 	}
@@ -189,10 +187,8 @@ func ParseMap(input string) (Map, error) {
 		attrSplit := strings.Split(attr, string(attrValueSeparator))
 		if len(attrSplit) != 2 {
 			return hm, ErrMapParsingFormat(
-				fmt.Sprintf(
-					`invalid "key=value" format for hardware mapping attribute %q`,
-					attr,
-				),
+				`invalid "key=value" format for hardware mapping attribute %q`,
+				attr,
 			)
 		}
 
@@ -0,0 +1,254 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package optionstring serializes the option strings of the Proxmox VE API, i.e. the comma separated
+// `key=value` options of a property such as a disk or a network device, e.g. `local-lvm:vm-100-disk-0,size=8G`.
+package optionstring
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// Option is an option of an option string. The positional value of a string, e.g. the volume of a disk,
+// has an empty key.
+type Option struct {
+	Key   string
+	Value string
+}
+
+// Options are the options of an option string, in their order in the string.
+type Options []Option
+
+// Unmarshal parses an option string. The options are trimmed, and the empty ones are skipped. Only the first `=`
+// separates the key from the value, so that a value may contain `=`. An option without `=` is the positional value,
+// of which there may be only one.
+func Unmarshal(s string) (Options, error) {
+	var options Options
+
+	seen := map[string]bool{}
+
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(item, "=")
+		if !ok {
+			key, value = "", item
+		} else if key = strings.TrimSpace(key); key == "" {
+			return nil, fmt.Errorf("option %q has no key", item)
+		}
+
+		if seen[key] {
+			if key == "" {
+				return nil, fmt.Errorf("more than one positional value in %q", s)
+			}
+
+			return nil, fmt.Errorf("duplicate option %q in %q", key, s)
+		}
+
+		seen[key] = true
+
+		options = append(options, Option{Key: key, Value: value})
+	}
+
+	return options, nil
+}
+
+// Marshal serializes the options in their order.
+func Marshal(options Options) string {
+	items := make([]string, 0, len(options))
+
+	for _, o := range options {
+		if o.Key == "" {
+			items = append(items, o.Value)
+		} else {
+			items = append(items, o.Key+"="+o.Value)
+		}
+	}
+
+	return strings.Join(items, ",")
+}
+
+// UnmarshalMap parses an option string into a map of the values by key. The positional value has an empty key.
+func UnmarshalMap(s string) (map[string]string, error) {
+	options, err := Unmarshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return options.Map(), nil
+}
+
+// MarshalMap serializes a map of the values by key in the canonical order, see Options.Canonical.
+func MarshalMap(m map[string]string, leading ...string) string {
+	options := make(Options, 0, len(m))
+
+	for k, v := range m {
+		options = append(options, Option{Key: k, Value: v})
+	}
+
+	return Marshal(options.Canonical(nil, leading...))
+}
+
+// Get returns the value of the option with the given key, and whether the option is set.
+func (o Options) Get(key string) (string, bool) {
+	for _, opt := range o {
+		if opt.Key == key {
+			return opt.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Map returns the values of the options by key.
+func (o Options) Map() map[string]string {
+	m := make(map[string]string, len(o))
+
+	for _, opt := range o {
+		m[opt.Key] = opt.Value
+	}
+
+	return m
+}
+
+// Canonical returns the options in the order Proxmox VE prints them in: the positional value first, followed by
+// the leading keys in the given order, then the other options sorted by key. The options whose value is their
+// default are omitted, so that the serialized string is stable however the options were set.
+func (o Options) Canonical(defaults map[string]string, leading ...string) Options {
+	rank := func(key string) int {
+		if key == "" {
+			return 0
+		}
+
+		for i, l := range leading {
+			if key == l {
+				return i + 1
+			}
+		}
+
+		return len(leading) + 1
+	}
+
+	options := make(Options, 0, len(o))
+
+	for _, opt := range o {
+		if d, ok := defaults[opt.Key]; ok && d == opt.Value {
+			continue
+		}
+
+		options = append(options, opt)
+	}
+
+	sort.SliceStable(options, func(i, j int) bool {
+		ri, rj := rank(options[i].Key), rank(options[j].Key)
+		if ri != rj {
+			return ri < rj
+		}
+
+		return options[i].Key < options[j].Key
+	})
+
+	return options
+}
+
+// Ptr returns a pointer to the value of the option.
+func (o Option) Ptr() *string {
+	v := o.Value
+
+	return &v
+}
+
+// Bool parses the value of a `0`/`1` flag.
+func (o Option) Bool() *types.CustomBool {
+	return types.CustomBool(o.Value == "1").Pointer()
+}
+
+// Int parses the value of an integer option.
+func (o Option) Int() (*int, error) {
+	v, err := strconv.Atoi(o.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", o.Key, err)
+	}
+
+	return &v, nil
+}
+
+// Float parses the value of a decimal option.
+func (o Option) Float() (*float64, error) {
+	v, err := strconv.ParseFloat(o.Value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", o.Key, err)
+	}
+
+	return &v, nil
+}
+
+// Builder builds the options of a struct, skipping the optional fields which are not set.
+type Builder struct {
+	options Options
+}
+
+// Positional sets the positional value.
+func (b *Builder) Positional(value string) {
+	b.options = append(b.options, Option{Value: value})
+}
+
+// Add adds an option.
+func (b *Builder) Add(key string, value string) {
+	b.options = append(b.options, Option{Key: key, Value: value})
+}
+
+// String adds a string option, unless it is nil or empty.
+func (b *Builder) String(key string, value *string) {
+	if value != nil && *value != "" {
+		b.Add(key, *value)
+	}
+}
+
+// Int adds an integer option, unless it is nil.
+func (b *Builder) Int(key string, value *int) {
+	if value != nil {
+		b.Add(key, strconv.Itoa(*value))
+	}
+}
+
+// Float adds a decimal option, unless it is nil. The value is printed with as few digits as needed, e.g. `1.5`.
+func (b *Builder) Float(key string, value *float64) {
+	if value != nil {
+		b.Add(key, strconv.FormatFloat(*value, 'f', -1, 64))
+	}
+}
+
+// Bool adds a `0`/`1` flag, unless it is nil.
+func (b *Builder) Bool(key string, value *types.CustomBool) {
+	if value == nil {
+		return
+	}
+
+	if *value {
+		b.Add(key, "1")
+	} else {
+		b.Add(key, "0")
+	}
+}
+
+// Options returns the options added, in their order.
+func (b *Builder) Options() Options {
+	return b.options
+}
+
+// Marshal serializes the options added in the canonical order, see Options.Canonical.
+func (b *Builder) Marshal(leading ...string) string {
+	return Marshal(b.options.Canonical(nil, leading...))
+}
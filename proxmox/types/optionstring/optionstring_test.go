@@ -0,0 +1,140 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package optionstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+func TestUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       string
+		want    Options
+		wantErr bool
+	}{
+		{"empty", "", nil, false},
+		{"positional value", "local-lvm:vm-100-disk-0", Options{{Value: "local-lvm:vm-100-disk-0"}}, false},
+		{
+			"options",
+			" local-lvm:vm-100-disk-0 , size=8G,, serial=a=b",
+			Options{{Value: "local-lvm:vm-100-disk-0"}, {Key: "size", Value: "8G"}, {Key: "serial", Value: "a=b"}},
+			false,
+		},
+		{"empty value", "tag=", Options{{Key: "tag", Value: ""}}, false},
+		{"no key", "=1", nil, true},
+		{"duplicate key", "tag=1,tag=2", nil, true},
+		{"two positional values", "a,b", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Unmarshal(tt.s)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	t.Parallel()
+
+	options, err := Unmarshal("tag=10,bridge=vmbr0,firewall=0,virtio=BC:24:11:2E:C8:A4")
+	require.NoError(t, err)
+
+	assert.Equal(t, "tag=10,bridge=vmbr0,firewall=0,virtio=BC:24:11:2E:C8:A4", Marshal(options))
+	assert.Equal(t,
+		"virtio=BC:24:11:2E:C8:A4,bridge=vmbr0,tag=10",
+		Marshal(options.Canonical(map[string]string{"firewall": "0"}, "virtio")),
+	)
+
+	assert.Equal(t, "local:iso/a.iso,media=cdrom,size=1G", MarshalMap(map[string]string{
+		"size":  "1G",
+		"":      "local:iso/a.iso",
+		"media": "cdrom",
+	}))
+}
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+
+	b := Builder{}
+
+	b.Positional("local-lvm:vm-100-disk-0")
+	b.String("serial", nil)
+	b.String("discard", new(string))
+	b.String("cache", ptr.Ptr("none"))
+	b.Int("iops_rd", ptr.Ptr(100))
+	b.Float("rate", ptr.Ptr(12.5))
+	b.Bool("ssd", types.CustomBool(false).Pointer())
+	b.Bool("backup", nil)
+
+	assert.Len(t, b.Options(), 5)
+	assert.Equal(t, "local-lvm:vm-100-disk-0,cache=none,iops_rd=100,rate=12.5,ssd=0", b.Marshal())
+}
+
+func TestOption(t *testing.T) {
+	t.Parallel()
+
+	m, err := UnmarshalMap("tag=10,rate=1.5,firewall=1,mtu=x")
+	require.NoError(t, err)
+
+	tag, err := Option{Key: "tag", Value: m["tag"]}.Int()
+	require.NoError(t, err)
+	assert.Equal(t, 10, *tag)
+
+	rate, err := Option{Key: "rate", Value: m["rate"]}.Float()
+	require.NoError(t, err)
+	assert.InDelta(t, 1.5, *rate, 0)
+
+	assert.True(t, bool(*Option{Key: "firewall", Value: m["firewall"]}.Bool()))
+
+	_, err = Option{Key: "mtu", Value: m["mtu"]}.Int()
+	require.ErrorContains(t, err, "failed to parse mtu")
+}
+
+func FuzzUnmarshal(f *testing.F) {
+	f.Add("local-lvm:vm-100-disk-0,aio=io_uring,backup=0,size=32G")
+	f.Add("virtio=BC:24:11:2E:C8:A4,bridge=vmbr0,trunks=10;20")
+	f.Add(" a = b ,, c=d=e,=f")
+	f.Add("x,y")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		options, err := Unmarshal(s)
+		if err != nil {
+			return
+		}
+
+		// the options survive a round trip, in their order and in the canonical one
+		roundTrip, err := Unmarshal(Marshal(options))
+		require.NoError(t, err)
+		assert.Equal(t, options, roundTrip)
+
+		canonical := Marshal(options.Canonical(nil))
+
+		parsed, err := Unmarshal(canonical)
+		require.NoError(t, err)
+		assert.Equal(t, options.Map(), parsed.Map())
+		assert.Equal(t, canonical, Marshal(parsed.Canonical(nil)), "canonicalization is idempotent")
+	})
+}
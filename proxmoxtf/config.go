@@ -13,6 +13,7 @@ import (
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
 )
 
@@ -22,6 +23,10 @@ type ProviderConfiguration struct {
 	sshClient      ssh.Client
 	tmpDirOverride string
 	idGenerator    cluster.IDGenerator
+	tagValidator   *cluster.TagValidator
+	cpuValidator   *nodes.CPUModelValidator
+
+	allowPostUploadCommands bool
 }
 
 // NewProviderConfiguration creates a new provider configuration.
@@ -30,11 +35,14 @@ func NewProviderConfiguration(
 	sshClient ssh.Client,
 	tmpDirOverride string,
 	idCfg cluster.IDGeneratorConfig,
+	allowPostUploadCommands bool,
 ) (ProviderConfiguration, error) {
 	cfg := ProviderConfiguration{
 		apiClient:      apiClient,
 		sshClient:      sshClient,
 		tmpDirOverride: tmpDirOverride,
+
+		allowPostUploadCommands: allowPostUploadCommands,
 	}
 
 	client, err := cfg.GetClient()
@@ -43,6 +51,8 @@ func NewProviderConfiguration(
 	}
 
 	cfg.idGenerator = cluster.NewIDGenerator(client.Cluster(), idCfg)
+	cfg.tagValidator = cluster.NewTagValidator(client.Cluster())
+	cfg.cpuValidator = nodes.NewCPUModelValidator(client.API())
 
 	return cfg, nil
 }
@@ -77,3 +87,18 @@ func (c *ProviderConfiguration) TempDir() string {
 func (c *ProviderConfiguration) GetIDGenerator() cluster.IDGenerator {
 	return c.idGenerator
 }
+
+// GetTagValidator returns the TagValidator.
+func (c *ProviderConfiguration) GetTagValidator() *cluster.TagValidator {
+	return c.tagValidator
+}
+
+// GetCPUModelValidator returns the CPUModelValidator.
+func (c *ProviderConfiguration) GetCPUModelValidator() *nodes.CPUModelValidator {
+	return c.cpuValidator
+}
+
+// PostUploadCommandsAllowed returns whether the file resources may run their post-upload commands on the nodes.
+func (c *ProviderConfiguration) PostUploadCommandsAllowed() bool {
+	return c.allowPostUploadCommands
+}
@@ -21,6 +21,7 @@ const (
 	mkDataSourceVirtualEnvironmentTimeLocalTime = "local_time"
 	mkDataSourceVirtualEnvironmentTimeNodeName  = "node_name"
 	mkDataSourceVirtualEnvironmentTimeTimeZone  = "time_zone"
+	mkDataSourceVirtualEnvironmentTimeUTCOffset = "utc_offset"
 	mkDataSourceVirtualEnvironmentTimeUTCTime   = "utc_time"
 )
 
@@ -43,6 +44,11 @@ func Time() *schema.Resource {
 				Description: "The time zone",
 				Computed:    true,
 			},
+			mkDataSourceVirtualEnvironmentTimeUTCOffset: {
+				Type:        schema.TypeString,
+				Description: "The offset of the local time from UTC",
+				Computed:    true,
+			},
 			mkDataSourceVirtualEnvironmentTimeUTCTime: {
 				Type:        schema.TypeString,
 				Description: "The UTC timestamp",
@@ -77,13 +83,16 @@ func timeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.D
 
 	d.SetId(fmt.Sprintf("%s_time", nodeName))
 
-	localTimeOffset := time.Time(nodeTime.LocalTime).Sub(time.Now().UTC())
-	localTime := time.Time(nodeTime.LocalTime).Add(-localTimeOffset).In(localLocation)
+	// the local time is derived from the time zone rather than taken from the node, as the node may
+	// report the local time in the previous time zone for a while after the time zone is changed
+	localTime := time.Time(nodeTime.UTCTime).In(localLocation)
 
 	err = d.Set(mkDataSourceVirtualEnvironmentTimeLocalTime, localTime.Format(time.RFC3339))
 	diags = append(diags, diag.FromErr(err)...)
 	err = d.Set(mkDataSourceVirtualEnvironmentTimeTimeZone, nodeTime.TimeZone)
 	diags = append(diags, diag.FromErr(err)...)
+	err = d.Set(mkDataSourceVirtualEnvironmentTimeUTCOffset, localTime.Format("-07:00"))
+	diags = append(diags, diag.FromErr(err)...)
 	err = d.Set(
 		mkDataSourceVirtualEnvironmentTimeUTCTime,
 		time.Time(nodeTime.UTCTime).Format(time.RFC3339),
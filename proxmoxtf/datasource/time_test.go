@@ -37,6 +37,7 @@ func TestTimeSchema(t *testing.T) {
 	test.AssertComputedAttributes(t, s, []string{
 		mkDataSourceVirtualEnvironmentTimeLocalTime,
 		mkDataSourceVirtualEnvironmentTimeTimeZone,
+		mkDataSourceVirtualEnvironmentTimeUTCOffset,
 		mkDataSourceVirtualEnvironmentTimeUTCTime,
 	})
 
@@ -44,6 +45,7 @@ func TestTimeSchema(t *testing.T) {
 		mkDataSourceVirtualEnvironmentTimeLocalTime: schema.TypeString,
 		mkDataSourceVirtualEnvironmentTimeNodeName:  schema.TypeString,
 		mkDataSourceVirtualEnvironmentTimeTimeZone:  schema.TypeString,
+		mkDataSourceVirtualEnvironmentTimeUTCOffset: schema.TypeString,
 		mkDataSourceVirtualEnvironmentTimeUTCTime:   schema.TypeString,
 	})
 }
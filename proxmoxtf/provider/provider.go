@@ -10,8 +10,10 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -51,12 +53,17 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 
 	// Check environment variables
 	endpoint := utils.GetAnyStringEnv("PROXMOX_VE_ENDPOINT", "PM_VE_ENDPOINT")
+	endpoints := utils.GetAnyListEnv("PROXMOX_VE_ENDPOINTS")
 	insecure := utils.GetAnyBoolEnv("PROXMOX_VE_INSECURE", "PM_VE_INSECURE")
 	minTLS := utils.GetAnyStringEnv("PROXMOX_VE_MIN_TLS", "PM_VE_MIN_TLS")
+	caCertificate := utils.GetAnyStringEnv("PROXMOX_VE_CA_CERTIFICATE")
 	authTicket := utils.GetAnyStringEnv("PROXMOX_VE_AUTH_TICKET", "PM_VE_AUTH_TICKET")
 	csrfPreventionToken := utils.GetAnyStringEnv("PROXMOX_VE_CSRF_PREVENTION_TOKEN", "PM_VE_CSRF_PREVENTION_TOKEN")
+	credentialCommand := utils.GetAnyStringEnv("PROXMOX_VE_CREDENTIAL_COMMAND", "PM_VE_CREDENTIAL_COMMAND")
 	apiToken := utils.GetAnyStringEnv("PROXMOX_VE_API_TOKEN", "PM_VE_API_TOKEN")
 	otp := utils.GetAnyStringEnv("PROXMOX_VE_OTP", "PM_VE_OTP")
+	otpCode := utils.GetAnyStringEnv("PROXMOX_VE_OTP_CODE")
+	otpSecret := utils.GetAnyStringEnv("PROXMOX_VE_OTP_SECRET")
 	username := utils.GetAnyStringEnv("PROXMOX_VE_USERNAME", "PM_VE_USERNAME")
 	password := utils.GetAnyStringEnv("PROXMOX_VE_PASSWORD", "PM_VE_PASSWORD")
 
@@ -64,6 +71,16 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		endpoint = v.(string)
 	}
 
+	if v, ok := d.GetOk(mkProviderEndpoints); ok {
+		endpoints = nil
+
+		for _, e := range v.([]interface{}) {
+			if s, ok := e.(string); ok {
+				endpoints = append(endpoints, s)
+			}
+		}
+	}
+
 	if v, ok := d.GetOk(mkProviderInsecure); ok {
 		insecure = v.(bool)
 	}
@@ -72,6 +89,10 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		minTLS = v.(string)
 	}
 
+	if v, ok := d.GetOk(mkProviderCACertificate); ok {
+		caCertificate = v.(string)
+	}
+
 	if v, ok := d.GetOk(mkProviderAuthTicket); ok {
 		authTicket = v.(string)
 	}
@@ -80,6 +101,10 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		csrfPreventionToken = v.(string)
 	}
 
+	if v, ok := d.GetOk(mkProviderCredentialCommand); ok {
+		credentialCommand = v.(string)
+	}
+
 	//nolint:staticcheck
 	if v, ok := d.GetOkExists(mkProviderAPIToken); ok {
 		apiToken = v.(string)
@@ -89,6 +114,14 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		otp = v.(string)
 	}
 
+	if v, ok := d.GetOk(mkProviderOTPCode); ok {
+		otpCode = v.(string)
+	}
+
+	if v, ok := d.GetOk(mkProviderOTPSecret); ok {
+		otpSecret = v.(string)
+	}
+
 	///nolint:staticcheck
 	if v, ok := d.GetOkExists(mkProviderUsername); ok {
 		username = v.(string)
@@ -99,17 +132,122 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		password = v.(string)
 	}
 
-	creds, err = api.NewCredentials(username, password, otp, apiToken, authTicket, csrfPreventionToken)
+	if credentialCommand != "" {
+		cc, e := api.RunCredentialCommand(ctx, credentialCommand)
+		if e != nil {
+			return nil, diag.FromErr(e)
+		}
+
+		username, password, otp, apiToken = cc.Username, cc.Password, "", cc.APIToken
+		authTicket, csrfPreventionToken = cc.AuthTicket, cc.CSRFPreventionToken
+	}
+
+	creds, err = api.NewCredentials(
+		username, password, otp, apiToken, authTicket, csrfPreventionToken,
+		api.WithTOTP(otpSecret, otpCode),
+	)
 	diags = append(diags, diag.FromErr(err)...)
 
-	conn, err = api.NewConnection(endpoint, insecure, minTLS)
+	var connOpts []api.ConnectionOption
+
+	if caCertificate != "" {
+		rootCAs, e := api.LoadCACertificates(caCertificate)
+		if e != nil {
+			return nil, diag.FromErr(e)
+		}
+
+		if insecure {
+			tflog.Warn(ctx, "Both `insecure` and `ca_certificate` are set, the certificate of the Proxmox VE API "+
+				"is verified against `ca_certificate`")
+		}
+
+		connOpts = append(connOpts, api.WithRootCAs(rootCAs))
+	}
+
+	conn, err = api.NewFailoverConnection(ctx, append([]string{endpoint}, endpoints...), insecure, minTLS, connOpts...)
+	diags = append(diags, diag.FromErr(err)...)
+
+	taskPollInterval := utils.GetAnyStringEnv("PROXMOX_VE_TASK_POLL_INTERVAL")
+	taskPollBackoff := utils.GetAnyFloatEnv("PROXMOX_VE_TASK_POLL_BACKOFF")
+
+	if v, ok := d.GetOk(mkProviderTaskPollInterval); ok {
+		taskPollInterval = v.(string)
+	}
+
+	if v, ok := d.GetOk(mkProviderTaskPollBackoff); ok {
+		taskPollBackoff = v.(float64)
+	}
+
+	taskPolling, err := api.NewTaskPolling(taskPollInterval, taskPollBackoff)
 	diags = append(diags, diag.FromErr(err)...)
 
+	retries := utils.GetAnyStringEnv("PROXMOX_VE_RETRIES")
+	minBackoff := utils.GetAnyStringEnv("PROXMOX_VE_MIN_BACKOFF")
+	maxBackoff := utils.GetAnyStringEnv("PROXMOX_VE_MAX_BACKOFF")
+
+	// `0` is a valid number of retries, so the attribute is checked for presence rather than value
+	//nolint:staticcheck
+	if v, ok := d.GetOkExists(mkProviderRetries); ok {
+		retries = strconv.Itoa(v.(int))
+	}
+
+	if v, ok := d.GetOk(mkProviderMinBackoff); ok {
+		minBackoff = v.(string)
+	}
+
+	if v, ok := d.GetOk(mkProviderMaxBackoff); ok {
+		maxBackoff = v.(string)
+	}
+
+	retryPolicy, err := api.NewRetryPolicy(retries, minBackoff, maxBackoff)
+	diags = append(diags, diag.FromErr(err)...)
+
+	maxParallelism := utils.GetAnyIntEnv("PROXMOX_VE_API_MAX_PARALLELISM")
+	maxHeavyParallelism := utils.GetAnyIntEnv("PROXMOX_VE_API_MAX_HEAVY_PARALLELISM")
+
+	if v, ok := d.GetOk(mkProviderAPIMaxParallelism); ok {
+		maxParallelism = v.(int)
+	}
+
+	if v, ok := d.GetOk(mkProviderAPIMaxHeavy); ok {
+		maxHeavyParallelism = v.(int)
+	}
+
+	if maxParallelism <= 0 {
+		maxParallelism = api.DefaultMaxParallelism
+	}
+
+	if maxHeavyParallelism <= 0 {
+		maxHeavyParallelism = api.DefaultMaxHeavyParallelism
+	}
+
+	debugAPILogging := utils.GetAnyBoolEnv("PROXMOX_VE_DEBUG_API_LOGGING")
+
+	if v, ok := d.GetOk(mkProviderDebugAPILogging); ok {
+		debugAPILogging = v.(bool)
+	}
+
 	if diags.HasError() {
 		return nil, diags
 	}
 
-	apiClient, err = api.NewClient(creds, conn)
+	nodeConns, err := nodeAPIConnections(d, insecure, minTLS, connOpts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	clientOpts := []api.ClientOption{
+		api.WithTaskPolling(taskPolling),
+		api.WithRetryPolicy(retryPolicy),
+		api.WithMaxParallelism(maxParallelism, maxHeavyParallelism),
+		api.WithNodeEndpoints(nodeConns),
+	}
+
+	if debugAPILogging {
+		clientOpts = append(clientOpts, api.WithDebugLogging())
+	}
+
+	apiClient, err = api.NewClient(creds, conn, clientOpts...)
 	if err != nil {
 		return nil, diag.Errorf("error creating virtual environment client: %s", err)
 	}
@@ -129,9 +267,13 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	sshAgentSocket := utils.GetAnyStringEnv("SSH_AUTH_SOCK", "PROXMOX_VE_SSH_AUTH_SOCK", "PM_VE_SSH_AUTH_SOCK")
 	sshAgentForwarding := utils.GetAnyBoolEnv("PROXMOX_VE_SSH_AGENT_FORWARDING", "PM_VE_SSH_AGENT_FORWARDING")
 	sshPrivateKey := utils.GetAnyStringEnv("PROXMOX_VE_SSH_PRIVATE_KEY")
+	sshKeyPassphrase := utils.GetAnyStringEnv("PROXMOX_VE_SSH_PRIVATE_KEY_PASSPHRASE")
+	sshCertificate := utils.GetAnyStringEnv("PROXMOX_VE_SSH_CERTIFICATE")
 	sshSocks5Server := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_SERVER")
 	sshSocks5Username := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_USERNAME")
 	sshSocks5Password := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_PASSWORD")
+	sshProxyJump := utils.GetAnyStringEnv("PROXMOX_VE_SSH_PROXY_JUMP")
+	sshSFTPChunkSize := utils.GetAnyIntEnv("PROXMOX_VE_SSH_SFTP_CHUNK_SIZE")
 
 	if v, ok := sshConf[mkProviderSSHUsername]; !ok || v.(string) == "" {
 		switch {
@@ -171,6 +313,14 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		sshConf[mkProviderSSHPrivateKey] = sshPrivateKey
 	}
 
+	if v, ok := sshConf[mkProviderSSHKeyPassphrase]; !ok || v.(string) == "" {
+		sshConf[mkProviderSSHKeyPassphrase] = sshKeyPassphrase
+	}
+
+	if v, ok := sshConf[mkProviderSSHCertificate]; !ok || v.(string) == "" {
+		sshConf[mkProviderSSHCertificate] = sshCertificate
+	}
+
 	if v, ok := sshConf[mkProviderSSHSocks5Server]; !ok || v.(string) == "" {
 		sshConf[mkProviderSSHSocks5Server] = sshSocks5Server
 	}
@@ -183,6 +333,14 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		sshConf[mkProviderSSHSocks5Password] = sshSocks5Password
 	}
 
+	if v, ok := sshConf[mkProviderSSHProxyJump]; !ok || v.(string) == "" {
+		sshConf[mkProviderSSHProxyJump] = sshProxyJump
+	}
+
+	if v, ok := sshConf[mkProviderSSHSFTPChunkSize]; !ok || v.(int) == 0 {
+		sshConf[mkProviderSSHSFTPChunkSize] = sshSFTPChunkSize
+	}
+
 	nodeOverrides := map[string]ssh.ProxmoxNode{}
 
 	if ns, ok := sshConf[mkProviderSSHNode]; ok {
@@ -192,6 +350,9 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 				Address: node[mkProviderSSHNodeAddress].(string),
 
 				Port: int32(node[mkProviderSSHNodePort].(int)),
+
+				ProxyJump:    node[mkProviderSSHNodeProxy].(string),
+				Socks5Server: node[mkProviderSSHNodeSocks5].(string),
 			}
 		}
 	}
@@ -203,9 +364,13 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		sshConf[mkProviderSSHAgentSocket].(string),
 		sshConf[mkProviderSSHAgentForwarding].(bool),
 		sshConf[mkProviderSSHPrivateKey].(string),
+		sshConf[mkProviderSSHKeyPassphrase].(string),
+		sshConf[mkProviderSSHCertificate].(string),
 		sshConf[mkProviderSSHSocks5Server].(string),
 		sshConf[mkProviderSSHSocks5Username].(string),
 		sshConf[mkProviderSSHSocks5Password].(string),
+		sshConf[mkProviderSSHProxyJump].(string),
+		sshConf[mkProviderSSHSFTPChunkSize].(int),
 		&apiResolverWithOverrides{
 			ar:        apiResolver{c: apiClient},
 			overrides: nodeOverrides,
@@ -236,7 +401,13 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		idCfg.RandomIDEnd = v.(int)
 	}
 
-	config, err := proxmoxtf.NewProviderConfiguration(apiClient, sshClient, tmpDirOverride, idCfg)
+	allowPostUploadCommands := utils.GetAnyBoolEnv("PROXMOX_VE_ALLOW_POST_UPLOAD_COMMANDS")
+
+	if v, ok := d.GetOk(mkProviderAllowPostUpload); ok {
+		allowPostUploadCommands = v.(bool)
+	}
+
+	config, err := proxmoxtf.NewProviderConfiguration(apiClient, sshClient, tmpDirOverride, idCfg, allowPostUploadCommands)
 	if err != nil {
 		return nil, diag.Errorf("error creating provider's configuration: %s", err)
 	}
@@ -244,6 +415,59 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	return config, nil
 }
 
+// nodeAPIConnections creates the connections to the API endpoints set in the `ssh.node` blocks, keyed by the name
+// of the node. A node shares the TLS settings of the provider, unless it sets `api_insecure`.
+func nodeAPIConnections(
+	d *schema.ResourceData,
+	insecure bool,
+	minTLS string,
+	opts ...api.ConnectionOption,
+) (map[string]*api.Connection, error) {
+	conns := map[string]*api.Connection{}
+
+	sshBlock := d.Get(mkProviderSSH).([]interface{})
+	if len(sshBlock) == 0 || sshBlock[0] == nil {
+		return conns, nil
+	}
+
+	nodes := sshBlock[0].(map[string]interface{})[mkProviderSSHNode].([]interface{})
+
+	// a bool attribute of a nested block is `false` when it is not set, so the configuration tells them apart
+	var rawNodes []cty.Value
+
+	if raw := d.GetRawConfig(); raw.IsKnown() && !raw.IsNull() {
+		rawSSH := raw.GetAttr(mkProviderSSH)
+		if rawSSH.IsKnown() && !rawSSH.IsNull() && rawSSH.LengthInt() > 0 {
+			if n := rawSSH.Index(cty.NumberIntVal(0)).GetAttr(mkProviderSSHNode); n.IsKnown() && !n.IsNull() {
+				rawNodes = n.AsValueSlice()
+			}
+		}
+	}
+
+	for i, n := range nodes {
+		node := n.(map[string]interface{})
+
+		endpoint := node[mkProviderSSHNodeAPI].(string)
+		if endpoint == "" {
+			continue
+		}
+
+		nodeInsecure := insecure
+		if i < len(rawNodes) && !rawNodes[i].GetAttr(mkProviderSSHNodeAPITLS).IsNull() {
+			nodeInsecure = node[mkProviderSSHNodeAPITLS].(bool)
+		}
+
+		conn, err := api.NewConnection(endpoint, nodeInsecure, minTLS, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API endpoint of node %q: %w", node[mkProviderSSHNodeName], err)
+		}
+
+		conns[node[mkProviderSSHNodeName].(string)] = conn
+	}
+
+	return conns, nil
+}
+
 type apiResolver struct {
 	c api.Client
 }
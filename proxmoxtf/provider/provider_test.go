@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/test"
 )
@@ -32,24 +34,50 @@ func TestProviderSchema(t *testing.T) {
 
 	test.AssertOptionalArguments(t, s, []string{
 		mkProviderEndpoint,
+		mkProviderEndpoints,
 		mkProviderInsecure,
 		mkProviderMinTLS,
 		mkProviderAuthTicket,
 		mkProviderCSRFPreventionToken,
+		mkProviderCredentialCommand,
 		mkProviderOTP,
+		mkProviderOTPCode,
+		mkProviderOTPSecret,
 		mkProviderUsername,
 		mkProviderPassword,
+		mkProviderAllowPostUpload,
+		mkProviderDebugAPILogging,
+		mkProviderAPIMaxParallelism,
+		mkProviderAPIMaxHeavy,
+		mkProviderRetries,
+		mkProviderMinBackoff,
+		mkProviderMaxBackoff,
+		mkProviderTaskPollBackoff,
+		mkProviderTaskPollInterval,
 	})
 
 	test.AssertValueTypes(t, s, map[string]schema.ValueType{
 		mkProviderEndpoint:            schema.TypeString,
+		mkProviderEndpoints:           schema.TypeList,
 		mkProviderInsecure:            schema.TypeBool,
 		mkProviderMinTLS:              schema.TypeString,
 		mkProviderAuthTicket:          schema.TypeString,
 		mkProviderCSRFPreventionToken: schema.TypeString,
+		mkProviderCredentialCommand:   schema.TypeString,
 		mkProviderOTP:                 schema.TypeString,
+		mkProviderOTPCode:             schema.TypeString,
+		mkProviderOTPSecret:           schema.TypeString,
 		mkProviderUsername:            schema.TypeString,
 		mkProviderPassword:            schema.TypeString,
+		mkProviderAllowPostUpload:     schema.TypeBool,
+		mkProviderDebugAPILogging:     schema.TypeBool,
+		mkProviderAPIMaxParallelism:   schema.TypeInt,
+		mkProviderAPIMaxHeavy:         schema.TypeInt,
+		mkProviderRetries:             schema.TypeInt,
+		mkProviderMinBackoff:          schema.TypeString,
+		mkProviderMaxBackoff:          schema.TypeString,
+		mkProviderTaskPollBackoff:     schema.TypeFloat,
+		mkProviderTaskPollInterval:    schema.TypeString,
 	})
 
 	providerSSHSchema := test.AssertNestedSchemaExistence(t, s, mkProviderSSH)
@@ -57,3 +85,33 @@ func TestProviderSchema(t *testing.T) {
 	// do not limit number of nodes in the cluster
 	test.AssertListMaxItems(t, providerSSHSchema, mkProviderSSHNode, 0)
 }
+
+// TestNodeAPIConnections() tests that only the nodes with an API endpoint get a connection of their own.
+func TestNodeAPIConnections(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, ProxmoxVirtualEnvironment().Schema, map[string]interface{}{
+		mkProviderSSH: []interface{}{
+			map[string]interface{}{
+				mkProviderSSHNode: []interface{}{
+					map[string]interface{}{
+						mkProviderSSHNodeName:    "pve1",
+						mkProviderSSHNodeAddress: "10.0.0.1",
+						mkProviderSSHNodeAPI:     "https://10.0.0.1:8006/",
+						mkProviderSSHNodeAPITLS:  true,
+					},
+					map[string]interface{}{
+						mkProviderSSHNodeName:    "pve2",
+						mkProviderSSHNodeAddress: "10.0.0.2",
+					},
+				},
+			},
+		},
+	})
+
+	conns, err := nodeAPIConnections(d, false, "")
+	require.NoError(t, err)
+
+	assert.Len(t, conns, 1)
+	assert.Contains(t, conns, "pve1")
+}
@@ -7,26 +7,45 @@
 package provider
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource/validators"
 )
 
 const (
 	mkProviderEndpoint            = "endpoint"
+	mkProviderEndpoints           = "endpoints"
 	mkProviderInsecure            = "insecure"
 	mkProviderMinTLS              = "min_tls"
+	mkProviderCACertificate       = "ca_certificate"
 	mkProviderAuthTicket          = "auth_ticket"
 	mkProviderCSRFPreventionToken = "csrf_prevention_token" // #nosec G101
+	mkProviderCredentialCommand   = "credential_command"
 	mkProviderAPIToken            = "api_token"
+	mkProviderAPIMaxParallelism   = "api_max_parallelism"
+	mkProviderAPIMaxHeavy         = "api_max_heavy_parallelism"
 	mkProviderOTP                 = "otp"
+	mkProviderOTPCode             = "otp_code"
+	mkProviderOTPSecret           = "otp_secret" // #nosec G101
 	mkProviderPassword            = "password"
 	mkProviderUsername            = "username"
 	mkProviderTmpDir              = "tmp_dir"
+	mkProviderAllowPostUpload     = "allow_post_upload_commands"
+	mkProviderDebugAPILogging     = "debug_api_logging"
 	mkProviderRandomVMIDs         = "random_vm_ids"
 	mkProviderRandomVMIDStart     = "random_vm_id_start"
 	mkProviderRandomVMIDEnd       = "random_vm_id_end"
+	mkProviderRetries             = "retries"
+	mkProviderMinBackoff          = "min_backoff"
+	mkProviderMaxBackoff          = "max_backoff"
+	mkProviderTaskPollBackoff     = "task_poll_backoff"
+	mkProviderTaskPollInterval    = "task_poll_interval"
 	mkProviderSSH                 = "ssh"
 	mkProviderSSHUsername         = "username"
 	mkProviderSSHPassword         = "password"
@@ -34,6 +53,10 @@ const (
 	mkProviderSSHAgentSocket      = "agent_socket"
 	mkProviderSSHAgentForwarding  = "agent_forwarding"
 	mkProviderSSHPrivateKey       = "private_key"
+	mkProviderSSHKeyPassphrase    = "private_key_passphrase" // #nosec G101
+	mkProviderSSHCertificate      = "certificate"
+	mkProviderSSHProxyJump        = "proxy_jump"
+	mkProviderSSHSFTPChunkSize    = "sftp_chunk_size"
 	mkProviderSSHSocks5Server     = "socks5_server"
 	mkProviderSSHSocks5Username   = "socks5_username"
 	mkProviderSSHSocks5Password   = "socks5_password"
@@ -42,6 +65,10 @@ const (
 	mkProviderSSHNodeName    = "name"
 	mkProviderSSHNodeAddress = "address"
 	mkProviderSSHNodePort    = "port"
+	mkProviderSSHNodeProxy   = "proxy_jump"
+	mkProviderSSHNodeSocks5  = "socks5_server"
+	mkProviderSSHNodeAPI     = "api_endpoint"
+	mkProviderSSHNodeAPITLS  = "api_insecure"
 )
 
 func createSchema() map[string]*schema.Schema {
@@ -52,6 +79,16 @@ func createSchema() map[string]*schema.Schema {
 			Description:  "The endpoint for the Proxmox VE API.",
 			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
 		},
+		mkProviderEndpoints: {
+			Type:     schema.TypeList,
+			Optional: true,
+			Description: "The endpoints of the other nodes of the cluster for the Proxmox VE API, which are used " +
+				"in turn when `endpoint` can't be reached.",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+		},
 		mkProviderInsecure: {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -62,6 +99,14 @@ func createSchema() map[string]*schema.Schema {
 			Optional: true,
 			Description: "The minimum required TLS version for API calls." +
 				"Supported values: `1.0|1.1|1.2|1.3`. Defaults to `1.3`.",
+			ValidateDiagFunc: validators.TLSVersion(),
+		},
+		mkProviderCACertificate: {
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: "The PEM encoded CA certificate, or the path to the file, to verify the certificate " +
+				"of the Proxmox VE API against, e.g. for a cluster using an internal CA. Takes precedence over `insecure`.",
+			ValidateFunc: validation.StringIsNotEmpty,
 		},
 		mkProviderAuthTicket: {
 			Type:         schema.TypeString,
@@ -77,6 +122,14 @@ func createSchema() map[string]*schema.Schema {
 			Description:  "The pre-authenticated CSRF Prevention Token for the Proxmox VE API.",
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
+		mkProviderCredentialCommand: {
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: "A command executed with the system shell to obtain the credentials for the Proxmox VE API. " +
+				"It must print a JSON object with any of the `username`, `password`, `api_token`, `auth_ticket` " +
+				"and `csrf_prevention_token` keys, which replace the other credentials.",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
 		mkProviderAPIToken: {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -91,6 +144,22 @@ func createSchema() map[string]*schema.Schema {
 			Deprecated: "The `otp` attribute is deprecated and will be removed in a future release. " +
 				"Please use the `api_token` attribute instead.",
 		},
+		mkProviderOTPCode: {
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			Description: "A static TOTP code to complete the two-factor login for the Proxmox VE API. " +
+				"It can be used only once, so `otp_secret` is preferred.",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		mkProviderOTPSecret: {
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			Description: "The base32 encoded TOTP secret to compute the codes completing the two-factor login " +
+				"for the Proxmox VE API.",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
 		mkProviderUsername: {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -186,9 +255,45 @@ func createSchema() map[string]*schema.Schema {
 						Type:      schema.TypeString,
 						Optional:  true,
 						Sensitive: true,
-						Description: "The unencrypted private key (in PEM format) used for the SSH connection. " +
+						Description: "The private key (in PEM or OpenSSH format) used for the SSH connection. " +
 							"Defaults to the value of the `PROXMOX_VE_SSH_PRIVATE_KEY` environment variable.",
 					},
+					mkProviderSSHKeyPassphrase: {
+						Type:      schema.TypeString,
+						Optional:  true,
+						Sensitive: true,
+						Description: "The passphrase used to decrypt the `private_key`, if it is encrypted. " +
+							"Defaults to the value of the `PROXMOX_VE_SSH_PRIVATE_KEY_PASSPHRASE` environment variable.",
+					},
+					mkProviderSSHCertificate: {
+						Type:     schema.TypeString,
+						Optional: true,
+						Description: "The OpenSSH user certificate (the content of the `-cert.pub` file) signing the " +
+							"public key of the `private_key`, for the nodes which require signed keys. " +
+							"Defaults to the value of the `PROXMOX_VE_SSH_CERTIFICATE` environment variable.",
+					},
+					mkProviderSSHProxyJump: {
+						Type:     schema.TypeString,
+						Optional: true,
+						Description: "The jump host to reach the nodes through, as `[user@]host[:port]`, e.g. " +
+							"`admin@bastion.example.com:2222`. The user defaults to the SSH username, and the jump host " +
+							"is authenticated like the nodes. Defaults to the value of the `PROXMOX_VE_SSH_PROXY_JUMP` " +
+							"environment variable.",
+						DefaultFunc: schema.MultiEnvDefaultFunc(
+							[]string{"PROXMOX_VE_SSH_PROXY_JUMP"},
+							nil,
+						),
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					mkProviderSSHSFTPChunkSize: {
+						Type:     schema.TypeInt,
+						Optional: true,
+						Description: fmt.Sprintf("The size in bytes of the SFTP write requests of the staged uploads, "+
+							"between `%d` and `%d` (defaults to `%d`). Larger chunks improve the throughput to the distant "+
+							"nodes. Defaults to the value of the `PROXMOX_VE_SSH_SFTP_CHUNK_SIZE` environment variable.",
+							ssh.MinSFTPChunkSize, ssh.MaxSFTPChunkSize, ssh.DefaultSFTPChunkSize),
+						ValidateFunc: validation.IntBetween(ssh.MinSFTPChunkSize, ssh.MaxSFTPChunkSize),
+					},
 					mkProviderSSHSocks5Server: {
 						Type:     schema.TypeString,
 						Optional: true,
@@ -249,6 +354,32 @@ func createSchema() map[string]*schema.Schema {
 									Default:      22,
 									ValidateFunc: validation.IsPortNumber,
 								},
+								mkProviderSSHNodeProxy: {
+									Type:         schema.TypeString,
+									Optional:     true,
+									Description:  "The jump host to reach the Proxmox VE node through, overriding `proxy_jump`.",
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+								mkProviderSSHNodeSocks5: {
+									Type:     schema.TypeString,
+									Optional: true,
+									Description: "The address:port of the SOCKS5 proxy server to reach the Proxmox VE node " +
+										"through, overriding `socks5_server`.",
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+								mkProviderSSHNodeAPI: {
+									Type:     schema.TypeString,
+									Optional: true,
+									Description: "The API endpoint of the Proxmox VE node, e.g. `https://10.0.0.1:8006/`, to send " +
+										"the requests scoped to the node directly to, instead of proxying them through `endpoint`.",
+									ValidateFunc: validation.IsURLWithHTTPS,
+								},
+								mkProviderSSHNodeAPITLS: {
+									Type:     schema.TypeBool,
+									Optional: true,
+									Description: "Whether to skip the TLS verification of `api_endpoint`, overriding `insecure`, " +
+										"e.g. when the node presents a self-signed certificate.",
+								},
 							},
 						},
 					},
@@ -261,6 +392,12 @@ func createSchema() map[string]*schema.Schema {
 			Description:  "The alternative temporary directory.",
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
+		mkProviderAllowPostUpload: {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Description: "Whether to allow the file resources to run their post-upload commands on the nodes " +
+				"over SSH. Defaults to `false`.",
+		},
 		mkProviderRandomVMIDs: {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -278,5 +415,59 @@ func createSchema() map[string]*schema.Schema {
 			Description:  "The ending number for random VM / Container IDs.",
 			ValidateFunc: validation.IntBetween(100, 999999999),
 		},
+		mkProviderAPIMaxParallelism: {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Description: fmt.Sprintf("The maximum number of the API requests sent concurrently, to avoid "+
+				"overloading the nodes during large applies. Defaults to `%d`.", api.DefaultMaxParallelism),
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		mkProviderAPIMaxHeavy: {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Description: fmt.Sprintf("The maximum number of the API requests starting heavy operations, i.e. "+
+				"clones, migrations and backups, sent concurrently. Defaults to `%d`.", api.DefaultMaxHeavyParallelism),
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		mkProviderDebugAPILogging: {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Description: "Whether to log every API request and response, with the credentials and the secrets " +
+				"redacted, and a correlation ID added to the errors. Defaults to `false`.",
+		},
+		mkProviderRetries: {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Description: "The number of times a request failing with a transient server error, e.g. `503`, " +
+				"is retried. `0` disables the retries. Defaults to `3`.",
+			ValidateFunc: validation.IntBetween(0, api.MaxRetries),
+		},
+		mkProviderMinBackoff: {
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: "The delay before the first retry of a request, which doubles with each retry, " +
+				"e.g. `500ms`. Defaults to `1s`.",
+			ValidateDiagFunc: validators.RetryBackoff(),
+		},
+		mkProviderMaxBackoff: {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "The maximum delay between the retries of a request, e.g. `1m`. Defaults to `30s`.",
+			ValidateDiagFunc: validators.RetryBackoff(),
+		},
+		mkProviderTaskPollBackoff: {
+			Type:     schema.TypeFloat,
+			Optional: true,
+			Description: "The factor by which the delay between the polls of a task status grows, " +
+				"e.g. `2` to double it after each poll, up to 30 seconds. Defaults to `1`.",
+			ValidateFunc: validation.FloatBetween(1, api.MaxTaskPollBackoff),
+		},
+		mkProviderTaskPollInterval: {
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: "The delay between the polls of a task status while waiting for it to complete, " +
+				"e.g. `500ms`. Defaults to `1s`.",
+			ValidateDiagFunc: validators.TaskPollInterval(),
+		},
 	}
 }
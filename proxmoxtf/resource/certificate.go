@@ -21,9 +21,12 @@ import (
 )
 
 const (
+	dvResourceVirtualEnvironmentCertificateACME             = false
 	dvResourceVirtualEnvironmentCertificateCertificateChain = ""
 	dvResourceVirtualEnvironmentCertificateOverwrite        = false
+	dvResourceVirtualEnvironmentCertificateRestartProxy     = true
 
+	mkResourceVirtualEnvironmentCertificateACME                    = "acme"
 	mkResourceVirtualEnvironmentCertificateCertificate             = "certificate"
 	mkResourceVirtualEnvironmentCertificateCertificateChain        = "certificate_chain"
 	mkResourceVirtualEnvironmentCertificateFileName                = "file_name"
@@ -34,6 +37,7 @@ const (
 	mkResourceVirtualEnvironmentCertificatePrivateKey              = "private_key"
 	mkResourceVirtualEnvironmentCertificatePublicKeySize           = "public_key_size"
 	mkResourceVirtualEnvironmentCertificatePublicKeyType           = "public_key_type"
+	mkResourceVirtualEnvironmentCertificateRestartProxy            = "restart_proxy"
 	mkResourceVirtualEnvironmentCertificateSSLFingerprint          = "ssl_fingerprint"
 	mkResourceVirtualEnvironmentCertificateStartDate               = "start_date"
 	mkResourceVirtualEnvironmentCertificateSubject                 = "subject"
@@ -44,10 +48,22 @@ const (
 func Certificate() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
+			mkResourceVirtualEnvironmentCertificateACME: {
+				Type:        schema.TypeBool,
+				Description: "Whether to order the certificate from the ACME account configured for the node",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     dvResourceVirtualEnvironmentCertificateACME,
+				ConflictsWith: []string{
+					mkResourceVirtualEnvironmentCertificateCertificate,
+					mkResourceVirtualEnvironmentCertificateCertificateChain,
+					mkResourceVirtualEnvironmentCertificatePrivateKey,
+				},
+			},
 			mkResourceVirtualEnvironmentCertificateCertificate: {
 				Type:        schema.TypeString,
 				Description: "The PEM encoded certificate",
-				Required:    true,
+				Optional:    true,
 			},
 			mkResourceVirtualEnvironmentCertificateCertificateChain: {
 				Type:        schema.TypeString,
@@ -85,7 +101,7 @@ func Certificate() *schema.Resource {
 			mkResourceVirtualEnvironmentCertificatePrivateKey: {
 				Type:        schema.TypeString,
 				Description: "The PEM encoded private key",
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 			},
 			mkResourceVirtualEnvironmentCertificatePublicKeySize: {
@@ -98,6 +114,12 @@ func Certificate() *schema.Resource {
 				Description: "The public key type",
 				Computed:    true,
 			},
+			mkResourceVirtualEnvironmentCertificateRestartProxy: {
+				Type:        schema.TypeBool,
+				Description: "Whether to restart the proxy service to apply the certificate",
+				Optional:    true,
+				Default:     dvResourceVirtualEnvironmentCertificateRestartProxy,
+			},
 			mkResourceVirtualEnvironmentCertificateSSLFingerprint: {
 				Type:        schema.TypeString,
 				Description: "The SSL fingerprint",
@@ -124,9 +146,30 @@ func Certificate() *schema.Resource {
 		ReadContext:   certificateRead,
 		UpdateContext: certificateUpdate,
 		DeleteContext: certificateDelete,
+		CustomizeDiff: certificateCustomizeDiff,
 	}
 }
 
+// certificateCustomizeDiff ensures that the certificate and the private key are specified,
+// unless the certificate is ordered from the ACME account.
+func certificateCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(mkResourceVirtualEnvironmentCertificateACME) ||
+		d.Get(mkResourceVirtualEnvironmentCertificateACME).(bool) {
+		return nil
+	}
+
+	for _, k := range []string{
+		mkResourceVirtualEnvironmentCertificateCertificate,
+		mkResourceVirtualEnvironmentCertificatePrivateKey,
+	} {
+		if d.NewValueKnown(k) && d.Get(k).(string) == "" {
+			return fmt.Errorf("%q is required unless %q is set", k, mkResourceVirtualEnvironmentCertificateACME)
+		}
+	}
+
+	return nil
+}
+
 func certificateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	diags := certificateUpdate(ctx, d, m)
 	if diags.HasError() {
@@ -158,7 +201,7 @@ func certificateGetUpdateBody(d *schema.ResourceData) *nodes.CertificateUpdateRe
 		force = true
 	}
 
-	restart := types.CustomBool(true)
+	restart := types.CustomBool(d.Get(mkResourceVirtualEnvironmentCertificateRestartProxy).(bool))
 
 	body := &nodes.CertificateUpdateRequestBody{
 		Certificates: combinedCertificates,
@@ -187,6 +230,8 @@ func certificateRead(ctx context.Context, d *schema.ResourceData, m interface{})
 		return diag.FromErr(err)
 	}
 
+	acme := d.Get(mkResourceVirtualEnvironmentCertificateACME).(bool)
+
 	err = d.Set(mkResourceVirtualEnvironmentCertificateCertificate, "")
 	diags = append(diags, diag.FromErr(err)...)
 	err = d.Set(mkResourceVirtualEnvironmentCertificateCertificateChain, "")
@@ -196,7 +241,8 @@ func certificateRead(ctx context.Context, d *schema.ResourceData, m interface{})
 
 	for _, c := range *list {
 		if c.FileName != nil && *c.FileName == "pveproxy-ssl.pem" {
-			if c.Certificates != nil {
+			// the ordered certificate is not a part of the configuration, so only its attributes are read
+			if c.Certificates != nil && !acme {
 				newCertificate := ""
 				newCertificateChain := ""
 
@@ -312,6 +358,22 @@ func certificateUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 
 	nodeName := d.Get(mkResourceVirtualEnvironmentCertificateNodeName).(string)
 
+	if d.Get(mkResourceVirtualEnvironmentCertificateACME).(bool) {
+		// the certificate is only ordered once, the node renews it by itself
+		if d.Id() == "" {
+			force := types.CustomBool(d.Get(mkResourceVirtualEnvironmentCertificateOverwrite).(bool))
+
+			err = api.Node(nodeName).OrderACMECertificate(ctx, &nodes.CertificateOrderACMERequestBody{
+				Force: &force,
+			})
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		return certificateRead(ctx, d, m)
+	}
+
 	body := certificateGetUpdateBody(d)
 
 	err = api.Node(nodeName).UpdateCertificate(ctx, body)
@@ -332,7 +394,7 @@ func certificateDelete(ctx context.Context, d *schema.ResourceData, m interface{
 
 	nodeName := d.Get(mkResourceVirtualEnvironmentCertificateNodeName).(string)
 
-	restart := types.CustomBool(true)
+	restart := types.CustomBool(d.Get(mkResourceVirtualEnvironmentCertificateRestartProxy).(bool))
 
 	err = api.Node(nodeName).DeleteCertificate(
 		ctx,
@@ -31,13 +31,15 @@ func TestCertificateSchema(t *testing.T) {
 	s := Certificate().Schema
 
 	test.AssertRequiredArguments(t, s, []string{
-		mkResourceVirtualEnvironmentCertificateCertificate,
 		mkResourceVirtualEnvironmentCertificateNodeName,
-		mkResourceVirtualEnvironmentCertificatePrivateKey,
 	})
 
 	test.AssertOptionalArguments(t, s, []string{
+		mkResourceVirtualEnvironmentCertificateACME,
+		mkResourceVirtualEnvironmentCertificateCertificate,
 		mkResourceVirtualEnvironmentCertificateCertificateChain,
+		mkResourceVirtualEnvironmentCertificatePrivateKey,
+		mkResourceVirtualEnvironmentCertificateRestartProxy,
 	})
 
 	test.AssertComputedAttributes(t, s, []string{
@@ -53,6 +55,7 @@ func TestCertificateSchema(t *testing.T) {
 	})
 
 	test.AssertValueTypes(t, s, map[string]schema.ValueType{
+		mkResourceVirtualEnvironmentCertificateACME:                    schema.TypeBool,
 		mkResourceVirtualEnvironmentCertificateCertificate:             schema.TypeString,
 		mkResourceVirtualEnvironmentCertificateCertificateChain:        schema.TypeString,
 		mkResourceVirtualEnvironmentCertificateExpirationDate:          schema.TypeString,
@@ -62,6 +65,7 @@ func TestCertificateSchema(t *testing.T) {
 		mkResourceVirtualEnvironmentCertificatePrivateKey:              schema.TypeString,
 		mkResourceVirtualEnvironmentCertificatePublicKeySize:           schema.TypeInt,
 		mkResourceVirtualEnvironmentCertificatePublicKeyType:           schema.TypeString,
+		mkResourceVirtualEnvironmentCertificateRestartProxy:            schema.TypeBool,
 		mkResourceVirtualEnvironmentCertificateSSLFingerprint:          schema.TypeString,
 		mkResourceVirtualEnvironmentCertificateStartDate:               schema.TypeString,
 		mkResourceVirtualEnvironmentCertificateSubject:                 schema.TypeString,
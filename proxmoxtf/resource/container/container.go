@@ -23,6 +23,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/containers"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
@@ -206,11 +207,12 @@ func Container() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						mkCloneDatastoreID: {
-							Type:        schema.TypeString,
-							Description: "The ID of the target datastore",
-							Optional:    true,
-							ForceNew:    true,
-							Default:     dvCloneDatastoreID,
+							Type:             schema.TypeString,
+							Description:      "The ID of the target datastore",
+							Optional:         true,
+							ForceNew:         true,
+							Default:          dvCloneDatastoreID,
+							ValidateDiagFunc: validators.DatastoreID(),
 						},
 						mkCloneNodeName: {
 							Type:        schema.TypeString,
@@ -351,11 +353,12 @@ func Container() *schema.Resource {
 							Default:     dvDiskACL,
 						},
 						mkDiskDatastoreID: {
-							Type:        schema.TypeString,
-							Description: "The datastore id",
-							Optional:    true,
-							ForceNew:    true,
-							Default:     dvDiskDatastoreID,
+							Type:             schema.TypeString,
+							Description:      "The datastore id",
+							Optional:         true,
+							ForceNew:         true,
+							Default:          dvDiskDatastoreID,
+							ValidateDiagFunc: validators.DatastoreID(),
 						},
 						mkDiskQuota: {
 							Type:        schema.TypeBool,
@@ -1023,6 +1026,7 @@ func Container() *schema.Resource {
 		UpdateContext: containerUpdate,
 		DeleteContext: containerDelete,
 		CustomizeDiff: customdiff.All(
+			validators.TagPolicy(mkTags),
 			customdiff.ForceNewIf(
 				mkVMID,
 				func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
@@ -1147,6 +1151,8 @@ func containerCreateClone(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
+	// the ID is recorded as soon as the container exists, so that a failure of any of the following steps leaves
+	// the container in the state as tainted, and the next apply replaces it, instead of failing as it already exists
 	d.SetId(strconv.Itoa(vmID))
 
 	containerAPI := client.Node(nodeName).Container(vmID)
@@ -1498,20 +1504,6 @@ func containerCreateCustom(ctx context.Context, d *schema.ResourceData, m interf
 	vmIDUntyped, hasVMID := d.GetOk(mkVMID)
 	vmID := vmIDUntyped.(int)
 
-	if !hasVMID {
-		vmIDNew, err := config.GetIDGenerator().NextID(ctx)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		vmID = vmIDNew
-
-		err = d.Set(mkVMID, vmID)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-	}
-
 	nodeName := d.Get(mkNodeName).(string)
 	container := Container()
 
@@ -1959,13 +1951,49 @@ func containerCreateCustom(ctx context.Context, d *schema.ResourceData, m interf
 		createBody.Tags = &tagsString
 	}
 
-	err = client.Node(nodeName).Container(0).CreateContainer(ctx, &createBody)
+	createContainer := func(ctx context.Context, id int) error {
+		createBody.VMID = &id
+
+		containerAPI := client.Node(nodeName).Container(0)
+
+		taskID, err := containerAPI.CreateContainerAsync(ctx, &createBody)
+		if err != nil {
+			// the container has not been created, and the ID may be used by a VM or container of someone else
+			d.SetId("")
+
+			return err
+		}
+
+		// the ID is recorded once the creation has started, so that a partially created container is kept
+		// in the state as tainted, and replaced by the next apply, if the creation fails
+		d.SetId(strconv.Itoa(id))
+
+		if err = d.Set(mkVMID, id); err != nil {
+			return err
+		}
+
+		if err = containerAPI.Tasks().WaitForTask(ctx, *taskID); err != nil {
+			if cluster.IsIDTaken(err) {
+				// the ID has been taken by a VM or container of someone else, which must not be replaced by the next apply
+				d.SetId("")
+			}
+
+			return fmt.Errorf("error waiting for container created: %w", err)
+		}
+
+		return nil
+	}
+
+	if hasVMID {
+		err = createContainer(ctx, vmID)
+	} else {
+		vmID, err = config.GetIDGenerator().CreateWithNextID(ctx, createContainer)
+	}
+
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.Itoa(vmID))
-
 	// Wait for the container's lock to be released.
 	err = client.Node(nodeName).Container(vmID).WaitForContainerConfigUnlock(ctx, true)
 	if err != nil {
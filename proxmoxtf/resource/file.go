@@ -10,9 +10,11 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
-	"crypto/tls"
+	"crypto/sha512"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"net/url"
@@ -20,16 +22,23 @@ import (
 	"path/filepath"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/storage"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/version"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource/validators"
@@ -37,42 +46,128 @@ import (
 )
 
 const (
-	dvResourceVirtualEnvironmentFileSourceFileChanged  = false
-	dvResourceVirtualEnvironmentFileSourceFileChecksum = ""
-	dvResourceVirtualEnvironmentFileSourceFileFileName = ""
-	dvResourceVirtualEnvironmentFileSourceFileInsecure = false
-	dvResourceVirtualEnvironmentFileSourceFileMinTLS   = ""
-	dvResourceVirtualEnvironmentFileOverwrite          = true
-	dvResourceVirtualEnvironmentFileSourceRawResize    = 0
-	dvResourceVirtualEnvironmentFileTimeoutUpload      = 1800
-
-	mkResourceVirtualEnvironmentFileContentType          = "content_type"
-	mkResourceVirtualEnvironmentFileDatastoreID          = "datastore_id"
-	mkResourceVirtualEnvironmentFileFileModificationDate = "file_modification_date"
-	mkResourceVirtualEnvironmentFileFileName             = "file_name"
-	mkResourceVirtualEnvironmentFileFileMode             = "file_mode"
-	mkResourceVirtualEnvironmentFileFileSize             = "file_size"
-	mkResourceVirtualEnvironmentFileFileTag              = "file_tag"
-	mkResourceVirtualEnvironmentFileNodeName             = "node_name"
-	mkResourceVirtualEnvironmentFileOverwrite            = "overwrite"
-	mkResourceVirtualEnvironmentFileSourceFile           = "source_file"
-	mkResourceVirtualEnvironmentFileSourceFilePath       = "path"
-	mkResourceVirtualEnvironmentFileSourceFileChanged    = "changed"
-	mkResourceVirtualEnvironmentFileSourceFileChecksum   = "checksum"
-	mkResourceVirtualEnvironmentFileSourceFileFileName   = "file_name"
-	mkResourceVirtualEnvironmentFileSourceFileInsecure   = "insecure"
-	mkResourceVirtualEnvironmentFileSourceFileMinTLS     = "min_tls"
-	mkResourceVirtualEnvironmentFileSourceRaw            = "source_raw"
-	mkResourceVirtualEnvironmentFileSourceRawData        = "data"
-	mkResourceVirtualEnvironmentFileSourceRawFileName    = "file_name"
-	mkResourceVirtualEnvironmentFileSourceRawResize      = "resize"
-	mkResourceVirtualEnvironmentFileTimeoutUpload        = "timeout_upload"
+	dvResourceVirtualEnvironmentFileCheckInUse                   = "warn"
+	dvResourceVirtualEnvironmentFileCheckSpace                   = true
+	dvResourceVirtualEnvironmentFileEnabled                      = true
+	dvResourceVirtualEnvironmentFileSourceFileArchive            = ""
+	dvResourceVirtualEnvironmentFileSourceFileCache              = false
+	dvResourceVirtualEnvironmentFileSourceFileCacheMaxAge        = 0
+	dvResourceVirtualEnvironmentFileSourceFileCacheMaxSize       = 0
+	dvResourceVirtualEnvironmentFileSourceFileChanged            = false
+	dvResourceVirtualEnvironmentFileSourceFileChecksum           = ""
+	dvResourceVirtualEnvironmentFileSourceFileChecksumAlgorithm  = "sha256"
+	dvResourceVirtualEnvironmentFileSourceFileChecksumTarget     = "compressed"
+	dvResourceVirtualEnvironmentFileSourceFileConnectTimeout     = 30
+	dvResourceVirtualEnvironmentFileSourceFileExpectedETag       = ""
+	dvResourceVirtualEnvironmentFileSourceFileExpectedSize       = 0
+	dvResourceVirtualEnvironmentFileSourceFileFileName           = ""
+	dvResourceVirtualEnvironmentFileSourceFileInsecure           = false
+	dvResourceVirtualEnvironmentFileSourceFileLocalAddr          = ""
+	dvResourceVirtualEnvironmentFileSourceFileMinTLS             = ""
+	dvResourceVirtualEnvironmentFileSourceFileRetries            = 0
+	dvResourceVirtualEnvironmentFileSourceFileServerSideDownload = false
+	dvResourceVirtualEnvironmentFileOverwrite                    = true
+	dvResourceVirtualEnvironmentFileOverwriteUnmanaged           = false
+	dvResourceVirtualEnvironmentFileReadBackContent              = false
+	dvResourceVirtualEnvironmentFileSanitizeFilename             = false
+	dvResourceVirtualEnvironmentFileSourceRawEncoding            = "plain"
+	dvResourceVirtualEnvironmentFileSourceRawEnsureNewline       = false
+	dvResourceVirtualEnvironmentFileSourceRawNormalizeNewlines   = false
+	dvResourceVirtualEnvironmentFileSourceRawResize              = 0
+	dvResourceVirtualEnvironmentFileSourceRawStripBOM            = false
+	dvResourceVirtualEnvironmentFileTimeoutUpload                = 1800
+	dvResourceVirtualEnvironmentFileUploadMode                   = "stream"
+	dvResourceVirtualEnvironmentFileValidateBootable             = false
+
+	mkResourceVirtualEnvironmentFileBytesUploaded                = "bytes_uploaded"
+	mkResourceVirtualEnvironmentFileCheckInUse                   = "check_in_use"
+	mkResourceVirtualEnvironmentFileCheckSpace                   = "check_space"
+	mkResourceVirtualEnvironmentFileChecksums                    = "checksums"
+	mkResourceVirtualEnvironmentFileContentType                  = "content_type"
+	mkResourceVirtualEnvironmentFileDatastoreContentTypes        = "datastore_content_types"
+	mkResourceVirtualEnvironmentFileDatastoreID                  = "datastore_id"
+	mkResourceVirtualEnvironmentFileEnabled                      = "enabled"
+	mkResourceVirtualEnvironmentFileFileModificationDate         = "file_modification_date"
+	mkResourceVirtualEnvironmentFileFileName                     = "file_name"
+	mkResourceVirtualEnvironmentFileFileMode                     = "file_mode"
+	mkResourceVirtualEnvironmentFileFileSize                     = "file_size"
+	mkResourceVirtualEnvironmentFileFileTag                      = "file_tag"
+	mkResourceVirtualEnvironmentFileForceContentTypeDir          = "force_content_type_dir"
+	mkResourceVirtualEnvironmentFileImportSource                 = "import_source"
+	mkResourceVirtualEnvironmentFileMIMEType                     = "mime_type"
+	mkResourceVirtualEnvironmentFileNodeName                     = "node_name"
+	mkResourceVirtualEnvironmentFileOverwrite                    = "overwrite"
+	mkResourceVirtualEnvironmentFileOverwriteContentTypes        = "overwrite_content_types"
+	mkResourceVirtualEnvironmentFileOverwriteUnmanaged           = "overwrite_unmanaged"
+	mkResourceVirtualEnvironmentFileOverwritten                  = "overwritten"
+	mkResourceVirtualEnvironmentFilePostUploadCommand            = "post_upload_command"
+	mkResourceVirtualEnvironmentFileReadBackContent              = "read_back_content"
+	mkResourceVirtualEnvironmentFileSanitizeFilename             = "sanitize_filename"
+	mkResourceVirtualEnvironmentFileSourceFile                   = "source_file"
+	mkResourceVirtualEnvironmentFileSourceFilePath               = "path"
+	mkResourceVirtualEnvironmentFileSourceFileArchive            = "archive"
+	mkResourceVirtualEnvironmentFileSourceFileAzureBlob          = "azure_blob"
+	mkResourceVirtualEnvironmentFileSourceFileAzureBlobSASURL    = "sas_url"
+	mkResourceVirtualEnvironmentFileSourceFileGCS                = "gcs"
+	mkResourceVirtualEnvironmentFileSourceFileGCSBucket          = "bucket"
+	mkResourceVirtualEnvironmentFileSourceFileGCSObject          = "object"
+	mkResourceVirtualEnvironmentFileSourceFileGCSCredentials     = "credentials"
+	mkResourceVirtualEnvironmentFileSourceFileCache              = "cache"
+	mkResourceVirtualEnvironmentFileSourceFileCacheMaxAge        = "cache_max_age"
+	mkResourceVirtualEnvironmentFileSourceFileCacheMaxSize       = "cache_max_size"
+	mkResourceVirtualEnvironmentFileSourceFileChanged            = "changed"
+	mkResourceVirtualEnvironmentFileSourceFileChecksum           = "checksum"
+	mkResourceVirtualEnvironmentFileSourceFileChecksumAlgorithm  = "checksum_algorithm"
+	mkResourceVirtualEnvironmentFileSourceFileChecksumTarget     = "checksum_target"
+	mkResourceVirtualEnvironmentFileSourceFileConnectTimeout     = "connect_timeout"
+	mkResourceVirtualEnvironmentFileSourceFileExpectedETag       = "expected_etag"
+	mkResourceVirtualEnvironmentFileSourceFileExpectedSize       = "expected_size"
+	mkResourceVirtualEnvironmentFileSourceFileFileName           = "file_name"
+	mkResourceVirtualEnvironmentFileSourceFileInsecure           = "insecure"
+	mkResourceVirtualEnvironmentFileSourceFileLocalAddr          = "local_addr"
+	mkResourceVirtualEnvironmentFileSourceFileMinTLS             = "min_tls"
+	mkResourceVirtualEnvironmentFileSourceFileMirrorURLs         = "mirror_urls"
+	mkResourceVirtualEnvironmentFileSourceFileOAuth2             = "oauth2"
+	mkResourceVirtualEnvironmentFileSourceFileOAuth2ClientID     = "client_id"
+	mkResourceVirtualEnvironmentFileSourceFileOAuth2ClientSecret = "client_secret"
+	mkResourceVirtualEnvironmentFileSourceFileOAuth2Scopes       = "scopes"
+	mkResourceVirtualEnvironmentFileSourceFileOAuth2TokenURL     = "token_url"
+	mkResourceVirtualEnvironmentFileSourceFileRetries            = "retries"
+	mkResourceVirtualEnvironmentFileSourceFileRetryOnStatus      = "retry_on_status"
+	mkResourceVirtualEnvironmentFileSourceFileServerSideDownload = "server_side_download"
+	mkResourceVirtualEnvironmentFileSourceRaw                    = "source_raw"
+	mkResourceVirtualEnvironmentFileSourceRawData                = "data"
+	mkResourceVirtualEnvironmentFileSourceRawEncoding            = "encoding"
+	mkResourceVirtualEnvironmentFileSourceRawEnsureNewline       = "ensure_trailing_newline"
+	mkResourceVirtualEnvironmentFileSourceRawFileName            = "file_name"
+	mkResourceVirtualEnvironmentFileSourceRawNormalizeNewlines   = "normalize_newlines"
+	mkResourceVirtualEnvironmentFileSourceRawResize              = "resize"
+	mkResourceVirtualEnvironmentFileSourceRawResizeFill          = "resize_fill"
+	mkResourceVirtualEnvironmentFileSourceRawStripBOM            = "strip_bom"
+	mkResourceVirtualEnvironmentFileStoredContent                = "stored_content"
+	mkResourceVirtualEnvironmentFileTimeoutUpload                = "timeout_upload"
+	mkResourceVirtualEnvironmentFileTmpDir                       = "tmp_dir"
+	mkResourceVirtualEnvironmentFileUploadMode                   = "upload_mode"
+	mkResourceVirtualEnvironmentFileUploadedChecksum             = "uploaded_checksum"
+	mkResourceVirtualEnvironmentFileValidateBootable             = "validate_bootable"
 )
 
 // File returns a resource that manages files on a node.
 func File() *schema.Resource {
+	// exactly one of the sources of the source file must be specified
+	sourceFileKeys := []string{
+		fmt.Sprintf("%s.0.%s", mkResourceVirtualEnvironmentFileSourceFile, mkResourceVirtualEnvironmentFileSourceFilePath),
+		fmt.Sprintf("%s.0.%s", mkResourceVirtualEnvironmentFileSourceFile, mkResourceVirtualEnvironmentFileSourceFileAzureBlob),
+		fmt.Sprintf("%s.0.%s", mkResourceVirtualEnvironmentFileSourceFile, mkResourceVirtualEnvironmentFileSourceFileGCS),
+	}
+
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
+			mkResourceVirtualEnvironmentFileBytesUploaded: {
+				Type:        schema.TypeInt,
+				Description: "The number of bytes transferred to the node when uploading the file",
+				Computed:    true,
+			},
 			mkResourceVirtualEnvironmentFileContentType: {
 				Type:             schema.TypeString,
 				Description:      "The content type",
@@ -82,10 +177,19 @@ func File() *schema.Resource {
 				ValidateDiagFunc: validators.ContentType(),
 			},
 			mkResourceVirtualEnvironmentFileDatastoreID: {
-				Type:        schema.TypeString,
-				Description: "The datastore id",
-				Required:    true,
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Description:      "The datastore id",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validators.DatastoreID(),
+			},
+			mkResourceVirtualEnvironmentFileEnabled: {
+				Type: schema.TypeBool,
+				Description: "Whether to upload the file, the resource does nothing and its ID is `disabled` " +
+					"if false",
+				Optional: true,
+				ForceNew: true,
+				Default:  dvResourceVirtualEnvironmentFileEnabled,
 			},
 			mkResourceVirtualEnvironmentFileFileModificationDate: {
 				Type:        schema.TypeString,
@@ -119,11 +223,27 @@ func File() *schema.Resource {
 				Computed:    true,
 				ForceNew:    true,
 			},
+			mkResourceVirtualEnvironmentFileForceContentTypeDir: {
+				Type: schema.TypeString,
+				Description: "The directory, relative to the datastore path, to upload the file to over SFTP, " +
+					"instead of the directory of the content type",
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validators.ContentTypeDirectory(),
+			},
+			mkResourceVirtualEnvironmentFileImportSource: {
+				Type: schema.TypeString,
+				Description: "The volume ID of the file to be used as the `import-from` source of a VM disk. " +
+					"Empty if the content type is not `import`",
+				Computed: true,
+			},
 			mkResourceVirtualEnvironmentFileNodeName: {
-				Type:        schema.TypeString,
-				Description: "The node name",
-				Required:    true,
-				ForceNew:    true,
+				Type: schema.TypeString,
+				Description: "The node name or the cluster node ID, the least busy online node hosting " +
+					"the datastore is used if omitted and the datastore is shared",
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
 			},
 			mkResourceVirtualEnvironmentFileSourceFile: {
 				Type:        schema.TypeList,
@@ -136,10 +256,107 @@ func File() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						mkResourceVirtualEnvironmentFileSourceFilePath: {
-							Type:        schema.TypeString,
-							Description: "A path to a local file or a URL",
-							Required:    true,
-							ForceNew:    true,
+							Type:         schema.TypeString,
+							Description:  "A path to a local file, a URL or a file in a Git repository",
+							Optional:     true,
+							ForceNew:     true,
+							Default:      "",
+							ExactlyOneOf: sourceFileKeys,
+						},
+						mkResourceVirtualEnvironmentFileSourceFileArchive: {
+							Type: schema.TypeString,
+							Description: "The format of the archive to upload the local directory of `path` as, " +
+								"e.g. a container template built as a directory tree",
+							Optional: true,
+							ForceNew: true,
+							Default:  dvResourceVirtualEnvironmentFileSourceFileArchive,
+							ValidateDiagFunc: validation.ToDiagFunc(
+								validation.StringInSlice(append([]string{""}, fileArchiveFormats...), false),
+							),
+						},
+						mkResourceVirtualEnvironmentFileSourceFileAzureBlob: {
+							Type:         schema.TypeList,
+							Description:  "The Azure Blob Storage object to use as the source",
+							Optional:     true,
+							ForceNew:     true,
+							ExactlyOneOf: sourceFileKeys,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									mkResourceVirtualEnvironmentFileSourceFileAzureBlobSASURL: {
+										Type:             schema.TypeString,
+										Description:      "The shared access signature (SAS) URL of the blob",
+										Required:         true,
+										ForceNew:         true,
+										Sensitive:        true,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPS),
+									},
+								},
+							},
+							MaxItems: 1,
+							MinItems: 0,
+						},
+						mkResourceVirtualEnvironmentFileSourceFileGCS: {
+							Type:         schema.TypeList,
+							Description:  "The Google Cloud Storage object to use as the source",
+							Optional:     true,
+							ForceNew:     true,
+							ExactlyOneOf: sourceFileKeys,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									mkResourceVirtualEnvironmentFileSourceFileGCSBucket: {
+										Type:             schema.TypeString,
+										Description:      "The name of the bucket",
+										Required:         true,
+										ForceNew:         true,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+									},
+									mkResourceVirtualEnvironmentFileSourceFileGCSObject: {
+										Type:             schema.TypeString,
+										Description:      "The name of the object",
+										Required:         true,
+										ForceNew:         true,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+									},
+									mkResourceVirtualEnvironmentFileSourceFileGCSCredentials: {
+										Type: schema.TypeString,
+										Description: "The service account key in JSON format, " +
+											"the object is downloaded anonymously if not set",
+										Optional:         true,
+										ForceNew:         true,
+										Sensitive:        true,
+										Default:          "",
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+									},
+								},
+							},
+							MaxItems: 1,
+							MinItems: 0,
+						},
+						mkResourceVirtualEnvironmentFileSourceFileCache: {
+							Type: schema.TypeBool,
+							Description: "Whether to keep the downloaded file in a cache and revalidate it " +
+								"with a conditional request on subsequent downloads",
+							Optional: true,
+							ForceNew: true,
+							Default:  dvResourceVirtualEnvironmentFileSourceFileCache,
+						},
+						mkResourceVirtualEnvironmentFileSourceFileCacheMaxAge: {
+							Type: schema.TypeInt,
+							Description: "The time in seconds after which unused files are removed from the download cache " +
+								"(0 to keep them indefinitely)",
+							Optional:         true,
+							ForceNew:         true,
+							Default:          dvResourceVirtualEnvironmentFileSourceFileCacheMaxAge,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+						},
+						mkResourceVirtualEnvironmentFileSourceFileCacheMaxSize: {
+							Type: schema.TypeInt,
+							Description: "The maximum size of the download cache in bytes, the least recently used files " +
+								"are removed when it is exceeded (0 for no limit)",
+							Optional:         true,
+							ForceNew:         true,
+							Default:          dvResourceVirtualEnvironmentFileSourceFileCacheMaxSize,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 						},
 						mkResourceVirtualEnvironmentFileSourceFileChanged: {
 							Type:        schema.TypeBool,
@@ -150,10 +367,68 @@ func File() *schema.Resource {
 						},
 						mkResourceVirtualEnvironmentFileSourceFileChecksum: {
 							Type:        schema.TypeString,
-							Description: "The SHA256 checksum of the source file",
+							Description: "The checksum of the source file",
 							Optional:    true,
 							ForceNew:    true,
 							Default:     dvResourceVirtualEnvironmentFileSourceFileChecksum,
+							DiffSuppressFunc: func(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+								return fileNormalizeChecksum(oldValue) == fileNormalizeChecksum(newValue)
+							},
+						},
+						mkResourceVirtualEnvironmentFileSourceFileChecksumAlgorithm: {
+							Type: schema.TypeString,
+							Description: "The algorithm of the source file checksum. " +
+								"Must be `md5` | `sha1` | `sha224` | `sha256` | `sha384` | `sha512`.",
+							Optional: true,
+							ForceNew: true,
+							Default:  dvResourceVirtualEnvironmentFileSourceFileChecksumAlgorithm,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								"md5",
+								"sha1",
+								"sha224",
+								"sha256",
+								"sha384",
+								"sha512",
+							}, false)),
+						},
+						mkResourceVirtualEnvironmentFileSourceFileChecksumTarget: {
+							Type: schema.TypeString,
+							Description: "Whether the checksum of the source file is calculated over the file as is " +
+								"(`compressed`), or over its decompressed content (`decompressed`)",
+							Optional: true,
+							ForceNew: true,
+							Default:  dvResourceVirtualEnvironmentFileSourceFileChecksumTarget,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								"compressed",
+								"decompressed",
+							}, false)),
+						},
+						mkResourceVirtualEnvironmentFileSourceFileConnectTimeout: {
+							Type: schema.TypeInt,
+							Description: "Timeout for establishing the connection to URL sources in seconds, " +
+								"including the TLS handshake (0 to only rely on `timeout_upload`)",
+							Optional:         true,
+							ForceNew:         true,
+							Default:          dvResourceVirtualEnvironmentFileSourceFileConnectTimeout,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+						},
+						mkResourceVirtualEnvironmentFileSourceFileExpectedETag: {
+							Type:        schema.TypeString,
+							Description: "The expected ETag of the source file URL",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     dvResourceVirtualEnvironmentFileSourceFileExpectedETag,
+							DiffSuppressFunc: func(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+								return fileNormalizeETag(oldValue) == fileNormalizeETag(newValue)
+							},
+						},
+						mkResourceVirtualEnvironmentFileSourceFileExpectedSize: {
+							Type:             schema.TypeInt,
+							Description:      "The expected size of the source file in bytes",
+							Optional:         true,
+							ForceNew:         true,
+							Default:          dvResourceVirtualEnvironmentFileSourceFileExpectedSize,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 						},
 						mkResourceVirtualEnvironmentFileSourceFileFileName: {
 							Type:        schema.TypeString,
@@ -169,13 +444,103 @@ func File() *schema.Resource {
 							ForceNew:    true,
 							Default:     dvResourceVirtualEnvironmentFileSourceFileInsecure,
 						},
+						mkResourceVirtualEnvironmentFileSourceFileLocalAddr: {
+							Type: schema.TypeString,
+							Description: "The local IP address to download URL sources from, to select the network " +
+								"interface of a multi-homed host",
+							Optional:         true,
+							ForceNew:         true,
+							Default:          dvResourceVirtualEnvironmentFileSourceFileLocalAddr,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+						},
 						mkResourceVirtualEnvironmentFileSourceFileMinTLS: {
 							Type: schema.TypeString,
 							Description: "The minimum required TLS version for HTTPS sources." +
 								"Supported values: `1.0|1.1|1.2|1.3`. Defaults to `1.3`.",
+							Optional:         true,
+							ForceNew:         true,
+							Default:          dvResourceVirtualEnvironmentFileSourceFileMinTLS,
+							ValidateDiagFunc: validators.TLSVersion(),
+						},
+						mkResourceVirtualEnvironmentFileSourceFileMirrorURLs: {
+							Type: schema.TypeList,
+							Description: "The URLs of mirrors of the source file, which are tried in order " +
+								"if the file can't be downloaded from the path or fails the verification",
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+							},
+						},
+						mkResourceVirtualEnvironmentFileSourceFileOAuth2: {
+							Type: schema.TypeList,
+							Description: "The OAuth2 client credentials to request an access token with, " +
+								"which is sent in the `Authorization` header when downloading the source file",
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									mkResourceVirtualEnvironmentFileSourceFileOAuth2ClientID: {
+										Type:             schema.TypeString,
+										Description:      "The client identifier",
+										Required:         true,
+										ForceNew:         true,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+									},
+									mkResourceVirtualEnvironmentFileSourceFileOAuth2ClientSecret: {
+										Type:        schema.TypeString,
+										Description: "The client secret",
+										Required:    true,
+										ForceNew:    true,
+										Sensitive:   true,
+									},
+									mkResourceVirtualEnvironmentFileSourceFileOAuth2Scopes: {
+										Type:        schema.TypeList,
+										Description: "The scopes to request",
+										Optional:    true,
+										ForceNew:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+									mkResourceVirtualEnvironmentFileSourceFileOAuth2TokenURL: {
+										Type:             schema.TypeString,
+										Description:      "The URL of the token endpoint",
+										Required:         true,
+										ForceNew:         true,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+									},
+								},
+							},
+							MaxItems: 1,
+							MinItems: 0,
+						},
+						mkResourceVirtualEnvironmentFileSourceFileRetries: {
+							Type: schema.TypeInt,
+							Description: "The number of times to retry the download of URL sources when the request " +
+								"fails, or the server responds with one of the `retry_on_status` codes",
+							Optional:         true,
+							ForceNew:         true,
+							Default:          dvResourceVirtualEnvironmentFileSourceFileRetries,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+						},
+						mkResourceVirtualEnvironmentFileSourceFileRetryOnStatus: {
+							Type: schema.TypeList,
+							Description: "The HTTP status codes of the responses to retry the download on " +
+								"(defaults to `[429, 502, 503, 504]`)",
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeInt,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(400, 599)),
+							},
+						},
+						mkResourceVirtualEnvironmentFileSourceFileServerSideDownload: {
+							Type: schema.TypeBool,
+							Description: "Whether to let the node download the file from the URL directly, " +
+								"instead of downloading it locally and uploading it to the node",
 							Optional: true,
 							ForceNew: true,
-							Default:  dvResourceVirtualEnvironmentFileSourceFileMinTLS,
+							Default:  dvResourceVirtualEnvironmentFileSourceFileServerSideDownload,
 						},
 					},
 				},
@@ -198,12 +563,37 @@ func File() *schema.Resource {
 							Required:    true,
 							ForceNew:    true,
 						},
+						mkResourceVirtualEnvironmentFileSourceRawEncoding: {
+							Type:        schema.TypeString,
+							Description: "The encoding of the raw data, either `plain` or `base64`",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     dvResourceVirtualEnvironmentFileSourceRawEncoding,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								"plain",
+								"base64",
+							}, false)),
+						},
+						mkResourceVirtualEnvironmentFileSourceRawEnsureNewline: {
+							Type:        schema.TypeBool,
+							Description: "Whether to append a newline to the raw data if it doesn't end with one",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     dvResourceVirtualEnvironmentFileSourceRawEnsureNewline,
+						},
 						mkResourceVirtualEnvironmentFileSourceRawFileName: {
 							Type:        schema.TypeString,
 							Description: "The file name",
 							Required:    true,
 							ForceNew:    true,
 						},
+						mkResourceVirtualEnvironmentFileSourceRawNormalizeNewlines: {
+							Type:        schema.TypeBool,
+							Description: "Whether to convert CRLF and CR line endings of the raw data to LF",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     dvResourceVirtualEnvironmentFileSourceRawNormalizeNewlines,
+						},
 						mkResourceVirtualEnvironmentFileSourceRawResize: {
 							Type:        schema.TypeInt,
 							Description: "The number of bytes to resize the file to",
@@ -211,6 +601,24 @@ func File() *schema.Resource {
 							ForceNew:    true,
 							Default:     dvResourceVirtualEnvironmentFileSourceRawResize,
 						},
+						mkResourceVirtualEnvironmentFileSourceRawResizeFill: {
+							Type: schema.TypeString,
+							Description: "The byte used to pad the file when it's resized, either `space` or `null` " +
+								"(defaults to `space` for plain data, and `null` for base64 encoded data)",
+							Optional: true,
+							ForceNew: true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								"space",
+								"null",
+							}, false)),
+						},
+						mkResourceVirtualEnvironmentFileSourceRawStripBOM: {
+							Type:        schema.TypeBool,
+							Description: "Whether to remove the UTF-8 byte order mark from the beginning of the raw data",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     dvResourceVirtualEnvironmentFileSourceRawStripBOM,
+						},
 					},
 				},
 				MaxItems: 1,
@@ -222,19 +630,139 @@ func File() *schema.Resource {
 				Optional:    true,
 				Default:     dvResourceVirtualEnvironmentFileTimeoutUpload,
 			},
+			mkResourceVirtualEnvironmentFileTmpDir: {
+				Type: schema.TypeString,
+				Description: "The directory to store the temporary copies of the source file in, instead of " +
+					"the temporary directory of the provider",
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			mkResourceVirtualEnvironmentFileUploadMode: {
+				Type: schema.TypeString,
+				Description: "The mode of uploads over SSH, either `stream` to write the file into the datastore " +
+					"directly, or `staged` to write it to a temporary file on the node first, and move it into the datastore " +
+					"once transferred completely",
+				Optional: true,
+				Default:  dvResourceVirtualEnvironmentFileUploadMode,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					"stream",
+					"staged",
+				}, false)),
+			},
+			mkResourceVirtualEnvironmentFileCheckInUse: {
+				Type: schema.TypeString,
+				Description: "What to do when an existing file, which is about to be overwritten, is used by " +
+					"running VMs on the node, must be `warn`, `error` or `none`",
+				Optional: true,
+				Default:  dvResourceVirtualEnvironmentFileCheckInUse,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					"error",
+					"none",
+					"warn",
+				}, false)),
+			},
+			mkResourceVirtualEnvironmentFileCheckSpace: {
+				Type: schema.TypeBool,
+				Description: "Whether to verify that the datastore has enough free space for the file before " +
+					"uploading it",
+				Optional: true,
+				Default:  dvResourceVirtualEnvironmentFileCheckSpace,
+			},
+			mkResourceVirtualEnvironmentFileMIMEType: {
+				Type: schema.TypeString,
+				Description: "The MIME type declared by the file part of the uploads using the API, " +
+					"e.g. `application/x-iso9660-image` (defaults to `application/octet-stream`)",
+				Optional:         true,
+				ValidateDiagFunc: validators.MIMEType(),
+			},
 			mkResourceVirtualEnvironmentFileOverwrite: {
 				Type:        schema.TypeBool,
 				Description: "Whether to overwrite the file if it already exists",
 				Optional:    true,
 				Default:     dvResourceVirtualEnvironmentFileOverwrite,
 			},
+			mkResourceVirtualEnvironmentFileOverwriteContentTypes: {
+				Type: schema.TypeSet,
+				Description: "The content types of the files which may be overwritten if `overwrite` is set, " +
+					"the files of the other content types are never overwritten (defaults to all content types)",
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validators.ContentType(),
+				},
+			},
+			mkResourceVirtualEnvironmentFileOverwriteUnmanaged: {
+				Type: schema.TypeBool,
+				Description: "Whether to overwrite the file if it already exists and does not match the source, " +
+					"i.e. it was not created by this resource",
+				Optional: true,
+				Default:  dvResourceVirtualEnvironmentFileOverwriteUnmanaged,
+			},
+			mkResourceVirtualEnvironmentFilePostUploadCommand: {
+				Type: schema.TypeString,
+				Description: "The shell command to run on the node over SSH once the file is uploaded, e.g. " +
+					"to verify it, with the path of the file in the `FILE_PATH` environment variable. " +
+					"The creation fails if the command exits with a non-zero code. It requires " +
+					"`allow_post_upload_commands` to be enabled in the provider configuration",
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			mkResourceVirtualEnvironmentFileReadBackContent: {
+				Type: schema.TypeBool,
+				Description: "Whether to read the content of the file from the node over SSH into `stored_content` " +
+					"when the resource is read, for the `snippets` files of up to 64 KiB",
+				Optional: true,
+				Default:  dvResourceVirtualEnvironmentFileReadBackContent,
+			},
+			mkResourceVirtualEnvironmentFileSanitizeFilename: {
+				Type: schema.TypeBool,
+				Description: "Whether to percent-decode the file name, and replace the characters which are " +
+					"rejected by Proxmox VE with `_`",
+				Optional: true,
+				ForceNew: true,
+				Default:  dvResourceVirtualEnvironmentFileSanitizeFilename,
+			},
+			mkResourceVirtualEnvironmentFileValidateBootable: {
+				Type: schema.TypeBool,
+				Description: "Whether to inspect the El Torito boot catalog of an `iso` file before uploading it, " +
+					"and warn if it appears to be non-bootable",
+				Optional: true,
+				Default:  dvResourceVirtualEnvironmentFileValidateBootable,
+			},
+			mkResourceVirtualEnvironmentFileOverwritten: {
+				Type:        schema.TypeBool,
+				Description: "Whether an existing file was overwritten when the resource was created",
+				Computed:    true,
+			},
+			mkResourceVirtualEnvironmentFileUploadedChecksum: {
+				Type:        schema.TypeString,
+				Description: "The SHA-256 checksum of the uploaded file",
+				Computed:    true,
+			},
+			mkResourceVirtualEnvironmentFileStoredContent: {
+				Type:        schema.TypeString,
+				Description: "The content of the file on the node, if `read_back_content` is enabled",
+				Computed:    true,
+			},
+			mkResourceVirtualEnvironmentFileChecksums: {
+				Type:        schema.TypeMap,
+				Description: "The checksums of the uploaded file, by algorithm (`sha256` and `sha512`)",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			mkResourceVirtualEnvironmentFileDatastoreContentTypes: {
+				Type:        schema.TypeList,
+				Description: "The content types supported by the datastore",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 		CreateContext: fileCreate,
 		ReadContext:   fileRead,
 		DeleteContext: fileDelete,
 		UpdateContext: fileUpdate,
 		Importer: &schema.ResourceImporter{
-			StateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 				node, volID, err := fileParseImportID(d.Id())
 				if err != nil {
 					return nil, err
@@ -257,12 +785,41 @@ func File() *schema.Resource {
 					return nil, fmt.Errorf("failed setting 'content_type' in state during import: %w", err)
 				}
 
+				config := m.(proxmoxtf.ProviderConfiguration)
+
+				capi, err := config.GetClient()
+				if err != nil {
+					return nil, err
+				}
+
+				nodeName, err := capi.Cluster().ResolveNodeName(ctx, node)
+				if err != nil {
+					return nil, err
+				}
+
+				err = fileImportChecksum(ctx, d, m, nodeName)
+				if err != nil {
+					return nil, err
+				}
+
 				return []*schema.ResourceData{d}, nil
 			},
 		},
 	}
 }
 
+const (
+	// fileDisabledID is the ID of a disabled resource, which has no file.
+	fileDisabledID = "disabled"
+
+	// fileStoredContentMaxSize is the maximum size of the files whose content is read back from the node.
+	fileStoredContentMaxSize = 64 * 1024
+)
+
+// fileStoredContentTypes are the content types of the text files, uploaded over SFTP, whose content can be
+// read back from the node.
+var fileStoredContentTypes = []string{"snippets"}
+
 type fileVolumeID struct {
 	datastoreID string
 	contentType string
@@ -319,6 +876,19 @@ func fileParseImportID(id string) (string, fileVolumeID, error) {
 }
 
 func fileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if !d.Get(mkResourceVirtualEnvironmentFileEnabled).(bool) {
+		// the resource stays in the state without a file, as an empty ID would remove it
+		d.SetId(fileDisabledID)
+
+		// the computed collections are planned as unknown until set, even if empty
+		err := d.Set(mkResourceVirtualEnvironmentFileChecksums, map[string]interface{}{})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return diag.FromErr(d.Set(mkResourceVirtualEnvironmentFileDatastoreContentTypes, []interface{}{}))
+	}
+
 	uploadTimeout := d.Get(mkResourceVirtualEnvironmentFileTimeoutUpload).(int)
 	fileMode := d.Get(mkResourceVirtualEnvironmentFileFileMode).(string)
 
@@ -334,6 +904,15 @@ func fileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 		return diags
 	}
 
+	if originalFileName, e := fileGetOriginalSourceFileName(d); e == nil && *originalFileName != *fileName {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("the file name %q has been sanitized to %q", *originalFileName, *fileName),
+			Detail: fmt.Sprintf("The file is uploaded as %q, as \"%s\" is enabled.",
+				*fileName, mkResourceVirtualEnvironmentFileSanitizeFilename),
+		})
+	}
+
 	nodeName := d.Get(mkResourceVirtualEnvironmentFileNodeName).(string)
 	datastoreID := d.Get(mkResourceVirtualEnvironmentFileDatastoreID).(string)
 
@@ -344,33 +923,85 @@ func fileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 		return diag.FromErr(err)
 	}
 
+	tempDir := config.TempDir()
+	if v, ok := d.GetOk(mkResourceVirtualEnvironmentFileTmpDir); ok {
+		tempDir = v.(string)
+	}
+
+	// the command is checked before the upload, so that a disallowed command does not leave an unverified file
+	if d.Get(mkResourceVirtualEnvironmentFilePostUploadCommand).(string) != "" && !config.PostUploadCommandsAllowed() {
+		return diag.Errorf(
+			"%q is disabled, set \"allow_post_upload_commands\" in the provider configuration to run commands on the nodes",
+			mkResourceVirtualEnvironmentFilePostUploadCommand,
+		)
+	}
+
+	if nodeName == "" {
+		nodeName, err = capi.Cluster().GetDatastoreNodeName(ctx, datastoreID)
+		if err != nil {
+			return diag.Errorf("failed to select a node for the datastore %q: %s", datastoreID, err)
+		}
+
+		tflog.Debug(ctx, "Selected the node hosting the datastore", map[string]interface{}{
+			"datastore_id": datastoreID,
+			"node_name":    nodeName,
+		})
+
+		err = d.Set(mkResourceVirtualEnvironmentFileNodeName, nodeName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		nodeName, err = capi.Cluster().ResolveNodeName(ctx, nodeName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	contentType, dg := fileGetContentType(ctx, d, capi)
 	diags = append(diags, dg...)
 
+	if dg.HasError() {
+		return diags
+	}
+
+	// the inferred content type is kept in the state, so that it's not inferred again when the file is read
+	err = d.Set(mkResourceVirtualEnvironmentFileContentType, *contentType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	list, err := capi.Node(nodeName).Storage(datastoreID).ListDatastoreFiles(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	var existingFile *storage.DatastoreFileListResponseData
+
 	for _, file := range list {
 		volumeID, e := fileParseVolumeID(file.VolumeID)
 		if e != nil {
 			tflog.Warn(ctx, "failed to parse volume ID", map[string]interface{}{
-				"error": err,
+				"volume_id": file.VolumeID,
+				"error":     e,
+			})
+
+			// the volume can't be compared with the file name, so a conflicting file may go unnoticed
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("failed to parse the volume ID %q of the datastore %q", file.VolumeID, datastoreID),
+				Detail:   fmt.Sprintf("The volume is not checked for a conflict with the file: %s", e),
 			})
 
 			continue
 		}
 
 		if volumeID.fileName == *fileName {
-			if d.Get(mkResourceVirtualEnvironmentFileOverwrite).(bool) {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Warning,
-					Summary:  fmt.Sprintf("the existing file %q has been overwritten by the resource", volumeID),
-				})
-			} else {
+			if !fileOverwriteAllowed(d, *contentType) {
 				return diag.Errorf("file %q already exists", volumeID)
 			}
+
+			existingFile = file
 		}
 	}
 
@@ -389,129 +1020,154 @@ func fileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 		)...)
 	}
 
+	if len(sourceFile) > 0 {
+		diags = append(diags, diag.FromErr(fileSourceValidate(sourceFile[0].(map[string]interface{})))...)
+	}
+
 	if diags.HasError() {
 		return diags
 	}
 
+	if len(sourceFile) > 0 && fileIsURL(d) {
+		sourceFileBlock := sourceFile[0].(map[string]interface{})
+
+		httpClient, e := fileDownloadHTTPClient(sourceFileBlock)
+		if e != nil {
+			return diag.FromErr(e)
+		}
+
+		// the ETag is verified before anything is downloaded, by the provider or by the node
+		if e = fileVerifyETag(ctx, httpClient, sourceFileBlock); e != nil {
+			return diag.FromErr(e)
+		}
+	}
+
+	if len(sourceFile) > 0 &&
+		sourceFile[0].(map[string]interface{})[mkResourceVirtualEnvironmentFileSourceFileServerSideDownload].(bool) {
+		if existingFile != nil {
+			diags = append(diags, fileCheckInUse(ctx, d, capi, nodeName, existingFile.VolumeID)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+
+		diags = append(diags, fileServerSideDownload(ctx, d, capi, nodeName, *contentType, *fileName, existingFile)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		err = d.Set(mkResourceVirtualEnvironmentFileOverwritten, existingFile != nil)
+		diags = append(diags, diag.FromErr(err)...)
+		// the file is not read by the provider, so its checksums are unknown, but set to keep the plan empty
+		diags = append(diags, fileSetUploadedChecksum(d, map[string]string{})...)
+
+		return append(diags, fileCreateRead(ctx, d, m, capi, nodeName)...)
+	}
+
 	// Determine if we're dealing with raw file data or a reference to a file or URL.
 	// In case of a URL, we must first download the file before proceeding.
 	// This is due to lack of support for chunked transfers in the Proxmox VE API.
 	if len(sourceFile) > 0 {
 		sourceFileBlock := sourceFile[0].(map[string]interface{})
-		sourceFilePath := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFilePath].(string)
-		sourceFileChecksum := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileChecksum].(string)
-		sourceFileMinTLS := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileMinTLS].(string)
-		sourceFileInsecure := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileInsecure].(bool)
+		sourceFilePath := fileSourceLocation(sourceFileBlock)
+		sourceFileName := fileSourceName(sourceFileBlock)
 
 		if fileIsURL(d) {
-			tflog.Debug(ctx, "Downloading file from URL", map[string]interface{}{
-				"url": sourceFilePath,
-			})
-
-			minTLSVersion, e := api.GetMinTLSVersion(sourceFileMinTLS)
+			httpClient, e := fileDownloadHTTPClient(sourceFileBlock)
 			if e != nil {
 				return diag.FromErr(e)
 			}
 
-			httpClient := http.Client{
-				Transport: &http.Transport{
-					TLSClientConfig: &tls.Config{
-						MinVersion:         minTLSVersion,
-						InsecureSkipVerify: sourceFileInsecure,
-					},
-				},
-			}
+			// The mirrors are tried in order until the source file is downloaded and verified successfully.
+			sourceFileURLs := append([]string{sourceFilePath}, fileSourceMirrorURLs(sourceFileBlock)...)
 
-			res, err := httpClient.Get(sourceFilePath)
-			if err != nil {
-				return diag.FromErr(err)
-			}
+			var downloadErrs []error
 
-			defer utils.CloseOrLogError(ctx)(res.Body)
+			for _, sourceFileURL := range sourceFileURLs {
+				tflog.Debug(ctx, "Downloading file from URL", map[string]interface{}{
+					"source": sourceFileName,
+				})
 
-			tempDownloadedFile, err := os.CreateTemp(config.TempDir(), "download")
-			if err != nil {
-				return diag.FromErr(err)
-			}
+				localPath, cleanup, e := fileDownloadVerified(ctx, httpClient, sourceFileBlock, sourceFileURL, tempDir)
+				if e == nil {
+					defer cleanup()
 
-			tempDownloadedFileName := tempDownloadedFile.Name()
-			defer func(name string) {
-				err := os.Remove(name)
-				if err != nil {
-					tflog.Error(ctx, "Failed to remove temporary file", map[string]interface{}{
-						"error": err,
-						"file":  name,
-					})
+					sourceFilePathLocal = localPath
+
+					break
 				}
-			}(tempDownloadedFileName)
 
-			_, err = io.Copy(tempDownloadedFile, res.Body)
-			diags = append(diags, diag.FromErr(err)...)
-			err = tempDownloadedFile.Close()
-			diags = append(diags, diag.FromErr(err)...)
+				cleanup()
 
-			if diags.HasError() {
-				return diags
+				if len(sourceFileURLs) == 1 {
+					return diag.FromErr(e)
+				}
+
+				tflog.Warn(ctx, "Failed to download the source file, trying the next mirror", map[string]interface{}{
+					"source": sourceFileURL,
+					"error":  e,
+				})
+
+				downloadErrs = append(downloadErrs, fmt.Errorf("%s: %w", sourceFileURL, e))
 			}
 
-			sourceFilePathLocal = tempDownloadedFileName
-		} else {
-			sourceFilePathLocal = sourceFilePath
-		}
+			if sourceFilePathLocal == "" {
+				return diag.Errorf("failed to download the source file from any of the mirrors: %s", errors.Join(downloadErrs...))
+			}
+		} else if archive := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileArchive].(string); archive != "" {
+			localPath, cleanup, e := fileArchiveDirectory(ctx, sourceFilePath, archive, tempDir)
+			if e != nil {
+				return diag.FromErr(e)
+			}
 
-		// Calculate the checksum of the source file now that it's available locally.
-		if sourceFileChecksum != "" {
-			file, err := os.Open(sourceFilePathLocal)
-			if err != nil {
-				return diag.FromErr(err)
+			defer cleanup()
+
+			sourceFilePathLocal = localPath
+
+			if e := fileVerifySource(ctx, sourceFileBlock, sourceFilePathLocal); e != nil {
+				return diag.FromErr(e)
+			}
+		} else if fileIsGitSource(sourceFilePath) {
+			gitSource, e := fileParseGitSource(sourceFilePath)
+			if e != nil {
+				return diag.FromErr(e)
 			}
 
-			h := sha256.New()
-			_, err = io.Copy(h, file)
-			diags = append(diags, diag.FromErr(err)...)
-			err = file.Close()
-			diags = append(diags, diag.FromErr(err)...)
-			if diags.HasError() {
-				return diags
+			localPath, cleanup, e := fileGitCheckout(ctx, gitSource, tempDir)
+			if e != nil {
+				return diag.FromErr(e)
 			}
 
-			calculatedChecksum := fmt.Sprintf("%x", h.Sum(nil))
-			tflog.Debug(ctx, "Calculated checksum", map[string]interface{}{
-				"source": sourceFilePath,
-				"sha256": calculatedChecksum,
-			})
+			defer cleanup()
 
-			if sourceFileChecksum != calculatedChecksum {
-				return diag.Errorf(
-					"the calculated SHA256 checksum \"%s\" does not match source checksum \"%s\"",
-					calculatedChecksum,
-					sourceFileChecksum,
-				)
+			sourceFilePathLocal = localPath
+
+			if e := fileVerifySource(ctx, sourceFileBlock, sourceFilePathLocal); e != nil {
+				return diag.FromErr(e)
+			}
+		} else {
+			sourceFilePathLocal = sourceFilePath
+
+			if e := fileVerifySource(ctx, sourceFileBlock, sourceFilePathLocal); e != nil {
+				return diag.FromErr(e)
 			}
 		}
 	}
 
 	//nolint:nestif
 	if len(sourceRaw) > 0 {
-		sourceRawBlock := sourceRaw[0].(map[string]interface{})
-		sourceRawData := sourceRawBlock[mkResourceVirtualEnvironmentFileSourceRawData].(string)
-		sourceRawResize := sourceRawBlock[mkResourceVirtualEnvironmentFileSourceRawResize].(int)
-
-		if sourceRawResize > 0 {
-			if len(sourceRawData) <= sourceRawResize {
-				sourceRawData = fmt.Sprintf(fmt.Sprintf("%%-%dv", sourceRawResize), sourceRawData)
-			} else {
-				return diag.Errorf("cannot resize %d bytes to %d bytes", len(sourceRawData), sourceRawResize)
-			}
+		sourceRawData, e := fileSourceRawData(sourceRaw[0].(map[string]interface{}))
+		if e != nil {
+			return diag.FromErr(e)
 		}
 
-		tempRawFile, e := os.CreateTemp(config.TempDir(), "raw")
+		tempRawFile, e := os.CreateTemp(tempDir, "raw")
 		if e != nil {
 			return diag.FromErr(err)
 		}
 
 		tempRawFileName := tempRawFile.Name()
-		_, err = io.Copy(tempRawFile, bytes.NewBufferString(sourceRawData))
+		_, err = io.Copy(tempRawFile, bytes.NewReader(sourceRawData))
 		diags = append(diags, diag.FromErr(err)...)
 		err = tempRawFile.Close()
 		diags = append(diags, diag.FromErr(err)...)
@@ -532,15 +1188,75 @@ func fileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 		sourceFilePathLocal = tempRawFileName
 	}
 
-	// Open the source file for reading in order to upload it.
-	file, err := os.Open(sourceFilePathLocal)
-	if err != nil {
-		return diag.FromErr(err)
+	if *contentType == "iso" && d.Get(mkResourceVirtualEnvironmentFileValidateBootable).(bool) {
+		diags = append(diags, fileValidateBootable(sourceFilePathLocal, *fileName)...)
 	}
 
-	defer func(file *os.File) {
-		err := file.Close()
-		if err != nil {
+	// the source is only read once to compute its checksums, as it may be a multi-GB image
+	checksums, e := fileComputeLocalChecksums(sourceFilePathLocal)
+	if e != nil {
+		return append(diags, diag.Errorf("failed to compute the checksums of the source file: %s", e)...)
+	}
+
+	switch *contentType {
+	case "iso", "vztmpl", "import":
+		resumed, e := fileResumeUpload(ctx, capi, nodeName, datastoreID, *fileName, sourceFilePathLocal, existingFile)
+		if e != nil {
+			tflog.Warn(ctx, "Failed to look up an earlier upload of the file, uploading it again", map[string]interface{}{
+				"error": e,
+			})
+		}
+
+		if resumed {
+			// the existing file is the one uploaded earlier, so nothing has been overwritten
+			err = d.Set(mkResourceVirtualEnvironmentFileBytesUploaded, 0)
+			diags = append(diags, diag.FromErr(err)...)
+			err = d.Set(mkResourceVirtualEnvironmentFileOverwritten, false)
+			diags = append(diags, diag.FromErr(err)...)
+			diags = append(diags, fileSetUploadedChecksum(d, checksums)...)
+
+			return append(diags, fileCreateRead(ctx, d, m, capi, nodeName)...)
+		}
+	}
+
+	if existingFile != nil {
+		fileInfo, e := os.Stat(sourceFilePathLocal)
+		if e != nil {
+			return append(diags, diag.FromErr(e)...)
+		}
+
+		dg := fileCheckOverwrite(ctx, d, capi, nodeName, existingFile, fileInfo.Size(), func() (string, error) {
+			return checksums["sha256"], nil
+		})
+		diags = append(diags, dg...)
+
+		if !diags.HasError() {
+			diags = append(diags, fileCheckInUse(ctx, d, capi, nodeName, existingFile.VolumeID)...)
+		}
+
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	staged := d.Get(mkResourceVirtualEnvironmentFileUploadMode).(string) == "staged"
+
+	if d.Get(mkResourceVirtualEnvironmentFileCheckSpace).(bool) {
+		err = fileCheckSpace(ctx, capi, nodeName, datastoreID, sourceFilePathLocal, existingFile, staged)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	// Open the source file for reading in order to upload it.
+	file, err := os.Open(sourceFilePathLocal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	defer func(file *os.File) {
+		err := file.Close()
+		if err != nil {
 			tflog.Error(ctx, "Failed to close file", map[string]interface{}{
 				"error": err,
 			})
@@ -552,12 +1268,25 @@ func fileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 		FileName:    *fileName,
 		File:        file,
 		Mode:        fileMode,
+		MIMEType:    d.Get(mkResourceVirtualEnvironmentFileMIMEType).(string),
+		Staged:      staged,
 	}
 
+	contentTypeDir, forceContentTypeDir := d.GetOk(mkResourceVirtualEnvironmentFileForceContentTypeDir)
+
 	switch *contentType {
 	case "iso", "vztmpl", "import":
+		if forceContentTypeDir {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary: fmt.Sprintf("%q is ignored for content type %q, the file is uploaded using the API",
+					mkResourceVirtualEnvironmentFileForceContentTypeDir, *contentType,
+				),
+			})
+		}
+
 		_, err = capi.Node(nodeName).Storage(datastoreID).APIUpload(
-			ctx, request, config.TempDir(),
+			ctx, request, tempDir,
 		)
 		if err != nil {
 			diags = append(diags, diag.FromErr(err)...)
@@ -571,8 +1300,21 @@ func fileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 			return diag.Errorf("failed to get datastore: %s", err2)
 		}
 
-		if datastore.Path == nil || *datastore.Path == "" {
-			return diag.Errorf("failed to determine the datastore path")
+		// the block and backup storages, e.g. Ceph RBD, ZFS or PBS, have no path to copy the file to,
+		// only the content types uploaded using the API can be stored on them
+		datastorePath := datastore.FilePath()
+		if datastorePath == "" {
+			datastoreType := "unknown"
+			if datastore.Type != nil {
+				datastoreType = *datastore.Type
+			}
+
+			return diag.Errorf(
+				"the datastore %q of type %q has no file system path, so a file of content type %q can't be "+
+					"uploaded to it; only the \"iso\", \"vztmpl\" and \"import\" files are uploaded to such "+
+					"a datastore using the API, use a file-based datastore, e.g. a directory, for the other files",
+				datastoreID, datastoreType, *contentType,
+			)
 		}
 
 		sort.Strings(datastore.Content)
@@ -594,7 +1336,12 @@ func fileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 			request.ContentType = "dump"
 		}
 
-		err = capi.SSH().NodeStreamUpload(ctx, nodeName, *datastore.Path, request)
+		// the directory only changes where the file is stored, the content type of the volume stays as declared
+		if forceContentTypeDir {
+			request.ContentType = contentTypeDir.(string)
+		}
+
+		err = capi.SSH().NodeStreamUpload(ctx, nodeName, datastorePath, request)
 		if err != nil {
 			diags = append(diags, diag.FromErr(err)...)
 			return diags
@@ -602,15 +1349,40 @@ func fileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 
 	}
 
-	volID, di := fileGetVolumeID(ctx, d, capi)
+	if existingFile != nil {
+		diags = append(diags, fileOverwrittenWarning(existingFile.VolumeID))
+	}
+
+	err = d.Set(mkResourceVirtualEnvironmentFileBytesUploaded, request.BytesUploaded)
+	diags = append(diags, diag.FromErr(err)...)
+	err = d.Set(mkResourceVirtualEnvironmentFileOverwritten, existingFile != nil)
+	diags = append(diags, diag.FromErr(err)...)
+	diags = append(diags, fileSetUploadedChecksum(d, checksums)...)
+
+	return append(diags, fileCreateRead(ctx, d, m, capi, nodeName)...)
+}
 
-	diags = append(diags, di...)
+// fileCreateRead sets the ID of the newly created file and reads its attributes.
+func fileCreateRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+	capi proxmox.Client,
+	nodeName string,
+) diag.Diagnostics {
+	volID, diags := fileGetVolumeID(ctx, d, capi)
 	if diags.HasError() {
 		return diags
 	}
 
 	d.SetId(volID.String())
 
+	// the ID is kept when the command fails, so that the unverified file is tainted and replaced by the next apply
+	diags = append(diags, fileRunPostUploadCommand(ctx, d, capi, nodeName, volID.String())...)
+	if diags.HasError() {
+		return diags
+	}
+
 	diags = append(diags, fileRead(ctx, d, m)...)
 
 	if d.Id() == "" {
@@ -620,25 +1392,277 @@ func fileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 	return diags
 }
 
+// fileReadDatastoreContentTypes sets the content types supported by the datastore. The datastore configuration
+// may not be readable with the privileges sufficient to manage the files, so the previous value is kept then.
+func fileReadDatastoreContentTypes(
+	ctx context.Context,
+	d *schema.ResourceData,
+	capi proxmox.Client,
+	datastoreID string,
+) diag.Diagnostics {
+	datastore, err := capi.Storage().GetDatastore(ctx, datastoreID)
+	if err != nil {
+		tflog.Warn(ctx, "unable to read the content types of the datastore", map[string]interface{}{
+			"datastore_id": datastoreID,
+			"error":        err,
+		})
+
+		return nil
+	}
+
+	contentTypes := slices.Clone([]string(datastore.Content))
+	sort.Strings(contentTypes)
+
+	return diag.FromErr(d.Set(mkResourceVirtualEnvironmentFileDatastoreContentTypes, contentTypes))
+}
+
+// fileOverwriteAllowed returns whether an existing file of the content type may be overwritten, as per
+// `overwrite` and `overwrite_content_types`.
+func fileOverwriteAllowed(d *schema.ResourceData, contentType string) bool {
+	if !d.Get(mkResourceVirtualEnvironmentFileOverwrite).(bool) {
+		return false
+	}
+
+	contentTypes := d.Get(mkResourceVirtualEnvironmentFileOverwriteContentTypes).(*schema.Set)
+
+	return contentTypes.Len() == 0 || contentTypes.Contains(contentType)
+}
+
+// fileCheckOverwrite decides whether an existing file may be overwritten by the resource. A create has no prior state
+// to tell which resource uploaded the file, so the file is only considered to be created by this resource earlier,
+// e.g. by a previous instance of the resource or an interrupted apply, if its SHA-256 checksum on the node matches
+// the source. Any other file appeared out-of-band, or is managed by another resource, and is only overwritten
+// when `overwrite_unmanaged` is set. If the checksums can't be computed on the node, e.g. without SSH access or on
+// a datastore which doesn't store the volumes as files, the sizes of the files are compared instead, with a warning.
+func fileCheckOverwrite(
+	ctx context.Context,
+	d *schema.ResourceData,
+	capi proxmox.Client,
+	nodeName string,
+	existingFile *storage.DatastoreFileListResponseData,
+	sourceSize int64,
+	sourceChecksum func() (string, error),
+) diag.Diagnostics {
+	if d.Get(mkResourceVirtualEnvironmentFileOverwriteUnmanaged).(bool) {
+		return nil
+	}
+
+	unmanaged := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary: fmt.Sprintf(
+			"file %q already exists and does not match the source, it may be managed outside of this resource; "+
+				"set \"%s\" to overwrite it",
+			existingFile.VolumeID,
+			mkResourceVirtualEnvironmentFileOverwriteUnmanaged,
+		),
+	}
+
+	remoteChecksum, err := readRemoteFileChecksum(ctx, capi, nodeName, existingFile.VolumeID)
+	if err == nil {
+		var checksum string
+
+		checksum, err = sourceChecksum()
+		if err == nil {
+			if remoteChecksum != checksum {
+				return diag.Diagnostics{unmanaged}
+			}
+
+			return nil
+		}
+	}
+
+	if sourceSize != existingFile.FileSize {
+		unmanaged.Detail = fmt.Sprintf("The sizes of the files differ, and the checksum of the existing file "+
+			"can't be compared to the source: %s", err)
+
+		return diag.Diagnostics{unmanaged}
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary: fmt.Sprintf("the existing file %q is assumed to be created by this resource as its size matches "+
+			"the source", existingFile.VolumeID),
+		Detail: fmt.Sprintf("The checksum of the existing file can't be compared to the source: %s", err),
+	}}
+}
+
+// fileOverwrittenWarning returns the warning reported once an existing file has been replaced by the resource.
+func fileOverwrittenWarning(volumeID string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("the existing file %q has been overwritten by the resource", volumeID),
+	}
+}
+
+// fileCheckInUse looks up the running VMs on the node which use the file that is about to be overwritten,
+// as replacing e.g. an ISO image mounted in a guest causes I/O errors in it.
+func fileCheckInUse(
+	ctx context.Context,
+	d *schema.ResourceData,
+	capi proxmox.Client,
+	nodeName string,
+	volumeID string,
+) diag.Diagnostics {
+	checkInUse := d.Get(mkResourceVirtualEnvironmentFileCheckInUse).(string)
+	if checkInUse == "none" {
+		return nil
+	}
+
+	list, err := capi.Node(nodeName).VM(0).ListVMs(ctx)
+	if err != nil {
+		return diag.Errorf("failed to list the VMs on the node %q: %s", nodeName, err)
+	}
+
+	var vmIDs []string
+
+	for _, vm := range list {
+		if vm.Status == nil || *vm.Status != "running" {
+			continue
+		}
+
+		config, e := capi.Node(nodeName).VM(vm.VMID).GetVM(ctx)
+		if e != nil {
+			tflog.Warn(ctx, "Failed to read the VM configuration, skipping it", map[string]interface{}{
+				"vm_id": vm.VMID,
+				"error": e,
+			})
+
+			continue
+		}
+
+		if fileStorageDevicesUse(config.StorageDevices, volumeID) {
+			vmIDs = append(vmIDs, strconv.Itoa(vm.VMID))
+		}
+	}
+
+	if len(vmIDs) == 0 {
+		return nil
+	}
+
+	severity := diag.Warning
+	if checkInUse == "error" {
+		severity = diag.Error
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: severity,
+			Summary:  fmt.Sprintf("the file %q is used by running VMs on the node %q", volumeID, nodeName),
+			Detail: fmt.Sprintf(
+				"The file is used by the VMs %s, overwriting it may cause I/O errors in the guests. "+
+					"Set \"%s\" to \"none\" to skip this check.",
+				strings.Join(vmIDs, ", "),
+				mkResourceVirtualEnvironmentFileCheckInUse,
+			),
+		},
+	}
+}
+
+// fileStorageDevicesUse returns true if any of the storage devices is backed by the volume.
+func fileStorageDevicesUse(devices vms.CustomStorageDevices, volumeID string) bool {
+	for _, device := range devices {
+		if device != nil && device.FileVolume == volumeID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fileValidateBootable warns if the ISO image does not appear to be bootable, as it is likely to be
+// a data disc or a truncated or corrupted download. It never fails the upload.
+func fileValidateBootable(sourceFilePathLocal string, fileName string) diag.Diagnostics {
+	bootable, reason, err := fileISOBootable(sourceFilePathLocal)
+	if err != nil {
+		reason = err.Error()
+	} else if bootable {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The ISO image %q does not appear to be bootable", fileName),
+			Detail: fmt.Sprintf("Inspecting the boot catalog of the image failed: %s. Guests will not be able to boot "+
+				"from the image, unless it is a data disc. Set `%s` to `false` to skip the check.",
+				reason, mkResourceVirtualEnvironmentFileValidateBootable),
+		},
+	}
+}
+
+// fileCheckSpace verifies that the datastore has enough free space to store the source file, so that
+// an upload doesn't fail halfway and leave a partial file behind.
+func fileCheckSpace(
+	ctx context.Context,
+	capi proxmox.Client,
+	nodeName string,
+	datastoreID string,
+	sourceFilePath string,
+	existingFile *storage.DatastoreFileListResponseData,
+	staged bool,
+) error {
+	fileInfo, err := os.Stat(sourceFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to get the size of the source file: %w", err)
+	}
+
+	status, err := capi.Node(nodeName).Storage(datastoreID).GetDatastoreStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the available space of the datastore %q: %w", datastoreID, err)
+	}
+
+	if status.AvailableBytes == nil {
+		tflog.Warn(ctx, "The datastore does not report its available space, skipping the space check", map[string]interface{}{
+			"datastore_id": datastoreID,
+		})
+
+		return nil
+	}
+
+	need := fileRequiredSpace(fileInfo.Size(), existingFile, staged)
+	have := *status.AvailableBytes
+
+	tflog.Debug(ctx, "Checking the available space of the datastore", map[string]interface{}{
+		"datastore_id": datastoreID,
+		"need":         need,
+		"have":         have,
+	})
+
+	if need > have {
+		needSize, haveSize := types.DiskSize(need), types.DiskSize(have)
+
+		return fmt.Errorf(
+			"insufficient space in the datastore %q on node %q: need %s, have %s; set %q to false to skip this check",
+			datastoreID, nodeName, needSize.String(), haveSize.String(), mkResourceVirtualEnvironmentFileCheckSpace,
+		)
+	}
+
+	return nil
+}
+
+// fileRequiredSpace returns the free space the datastore needs to store a file of the given size. A file which
+// is overwritten in place frees its space, but a staged upload keeps it until the new file is moved over it.
+func fileRequiredSpace(size int64, existingFile *storage.DatastoreFileListResponseData, staged bool) int64 {
+	if existingFile == nil || staged {
+		return size
+	}
+
+	return max(size-existingFile.FileSize, 0)
+}
+
+// fileGetContentType returns the content type of the file, which is inferred from the source file name if it is
+// neither configured nor already stored in the state. The Proxmox VE version is only queried when the inference
+// depends on it, so that the content type of an existing file is resolved without any request.
 func fileGetContentType(ctx context.Context, d *schema.ResourceData, c proxmox.Client) (*string, diag.Diagnostics) {
 	contentType := d.Get(mkResourceVirtualEnvironmentFileContentType).(string)
 	sourceFile := d.Get(mkResourceVirtualEnvironmentFileSourceFile).([]interface{})
 	sourceRaw := d.Get(mkResourceVirtualEnvironmentFileSourceRaw).([]interface{})
 
-	ver := version.MinimumProxmoxVersion
-	if versionResp, err := c.Version().Version(ctx); err == nil {
-		ver = versionResp.Version
-	} else {
-		tflog.Warn(ctx, fmt.Sprintf("failed to determine Proxmox VE version, assume %v", ver), map[string]interface{}{
-			"error": err,
-		})
-	}
-
 	sourceFilePath := ""
 
 	if len(sourceFile) > 0 {
 		sourceFileBlock := sourceFile[0].(map[string]interface{})
-		sourceFilePath = sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFilePath].(string)
+		sourceFilePath = fileSourceName(sourceFileBlock)
 	} else if len(sourceRaw) > 0 {
 		sourceRawBlock := sourceRaw[0].(map[string]interface{})
 		sourceFilePath = sourceRawBlock[mkResourceVirtualEnvironmentFileSourceRawFileName].(string)
@@ -652,12 +1676,12 @@ func fileGetContentType(ctx context.Context, d *schema.ResourceData, c proxmox.C
 	}
 	if contentType == "" {
 		if strings.HasSuffix(sourceFilePath, ".tar.gz") ||
-			strings.HasSuffix(sourceFilePath, ".tar.xz") {
+			strings.HasSuffix(sourceFilePath, ".tar.xz") ||
+			strings.HasSuffix(sourceFilePath, ".tar.zst") {
 			contentType = "vztmpl"
-		} else if ver.SupportImportContentType() &&
-			(strings.HasSuffix(sourceFilePath, ".qcow2") ||
-				strings.HasSuffix(sourceFilePath, ".raw") ||
-				strings.HasSuffix(sourceFilePath, ".vmdk")) {
+		} else if (strings.HasSuffix(sourceFilePath, ".qcow2") ||
+			strings.HasSuffix(sourceFilePath, ".raw") ||
+			strings.HasSuffix(sourceFilePath, ".vmdk")) && fileProxmoxVersion(ctx, c).SupportImportContentType() {
 			contentType = "import"
 		} else {
 			ext := strings.TrimLeft(strings.ToLower(filepath.Ext(sourceFilePath)), ".")
@@ -685,17 +1709,67 @@ func fileGetContentType(ctx context.Context, d *schema.ResourceData, c proxmox.C
 	return &contentType, diags
 }
 
+// fileProxmoxVersion returns the version of Proxmox VE, or the minimum supported version if it can't be determined.
+func fileProxmoxVersion(ctx context.Context, c proxmox.Client) *version.ProxmoxVersion {
+	ver := version.MinimumProxmoxVersion
+
+	if versionResp, err := c.Version().Version(ctx); err == nil {
+		ver = versionResp.Version
+	} else {
+		tflog.Warn(ctx, fmt.Sprintf("failed to determine Proxmox VE version, assume %v", ver), map[string]interface{}{
+			"error": err,
+		})
+	}
+
+	return &ver
+}
+
+// fileGetSourceFileName returns the name of the uploaded file, sanitized if `sanitize_filename` is enabled.
 func fileGetSourceFileName(d *schema.ResourceData) (*string, error) {
+	fileName, err := fileGetOriginalSourceFileName(d)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Get(mkResourceVirtualEnvironmentFileSanitizeFilename).(bool) {
+		sanitized := fileSanitizeName(*fileName)
+		fileName = &sanitized
+	}
+
+	return fileName, nil
+}
+
+// fileSanitizeName percent-decodes the file name, and replaces the characters which Proxmox VE rejects in
+// the names of the uploaded files, i.e. anything but ASCII letters, digits, `-`, `_` and `.`, with `_`.
+func fileSanitizeName(name string) string {
+	if decoded, err := url.PathUnescape(name); err == nil {
+		name = decoded
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// fileGetOriginalSourceFileName returns the name of the source file, or the name specified for the file.
+func fileGetOriginalSourceFileName(d *schema.ResourceData) (*string, error) {
 	sourceFile := d.Get(mkResourceVirtualEnvironmentFileSourceFile).([]interface{})
 	sourceRaw := d.Get(mkResourceVirtualEnvironmentFileSourceRaw).([]interface{})
 
 	sourceFileFileName := ""
 	sourceFilePath := ""
+	objectStorage := false
 
 	if len(sourceFile) > 0 {
 		sourceFileBlock := sourceFile[0].(map[string]interface{})
 		sourceFileFileName = sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileFileName].(string)
-		sourceFilePath = sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFilePath].(string)
+		sourceFilePath = fileSourceName(sourceFileBlock)
+		objectStorage = fileSourceIsObjectStorage(sourceFileBlock)
 	} else if len(sourceRaw) > 0 {
 		sourceRawBlock := sourceRaw[0].(map[string]interface{})
 		sourceFileFileName = sourceRawBlock[mkResourceVirtualEnvironmentFileSourceRawFileName].(string)
@@ -708,7 +1782,17 @@ func fileGetSourceFileName(d *schema.ResourceData) (*string, error) {
 	}
 
 	if sourceFileFileName == "" {
-		if fileIsURL(d) {
+		if objectStorage {
+			sourceFileFileName = sourceFilePath
+
+			if sourceFileFileName == "" || sourceFileFileName == "." || sourceFileFileName == "/" {
+				return nil, fmt.Errorf(
+					"failed to determine file name from the object storage source, please specify \"%s.%s\"",
+					mkResourceVirtualEnvironmentFileSourceFile,
+					mkResourceVirtualEnvironmentFileSourceFileFileName,
+				)
+			}
+		} else if fileIsURL(d) {
 			downloadURL, err := url.ParseRequestURI(sourceFilePath)
 			if err != nil {
 				return nil, err
@@ -753,7 +1837,7 @@ func fileIsURL(d *schema.ResourceData) bool {
 
 	if len(sourceFile) > 0 {
 		sourceFileBlock := sourceFile[0].(map[string]interface{})
-		sourceFilePath = sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFilePath].(string)
+		sourceFilePath = fileSourceLocation(sourceFileBlock)
 	} else {
 		return false
 	}
@@ -763,6 +1847,10 @@ func fileIsURL(d *schema.ResourceData) bool {
 }
 
 func fileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Id() == fileDisabledID {
+		return nil
+	}
+
 	config := m.(proxmoxtf.ProviderConfiguration)
 
 	capi, err := config.GetClient()
@@ -771,20 +1859,45 @@ func fileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.D
 	}
 
 	datastoreID := d.Get(mkResourceVirtualEnvironmentFileDatastoreID).(string)
-	nodeName := d.Get(mkResourceVirtualEnvironmentFileNodeName).(string)
+
+	nodeName, err := capi.Cluster().ResolveNodeName(ctx, d.Get(mkResourceVirtualEnvironmentFileNodeName).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	sourceFile := d.Get(mkResourceVirtualEnvironmentFileSourceFile).([]interface{})
 
 	list, err := capi.Node(nodeName).Storage(datastoreID).ListDatastoreFiles(ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		fallbackNodeName, ok := fileFallbackNodeName(ctx, capi, nodeName, datastoreID, err)
+		if !ok {
+			return diag.FromErr(err)
+		}
+
+		list, err = capi.Node(fallbackNodeName).Storage(datastoreID).ListDatastoreFiles(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		nodeName = fallbackNodeName
 	}
 
+	isURL := fileIsURL(d)
+
 	readFileAttrs := readFile
-	if fileIsURL(d) {
-		readFileAttrs = readURL(capi.API().HTTP())
+	if isURL {
+		// the source file is read with the same client as it's downloaded with, not with the one of the API
+		sourceFileBlock := sourceFile[0].(map[string]interface{})
+
+		httpClient, e := fileDownloadHTTPClient(sourceFileBlock)
+		if e != nil {
+			return diag.FromErr(e)
+		}
+
+		readFileAttrs = readURL(httpClient, fileSourceAuthorizer(httpClient, sourceFileBlock))
 	}
 
-	var diags diag.Diagnostics
+	diags := fileReadDatastoreContentTypes(ctx, d, capi, datastoreID)
 
 	found := false
 	for _, v := range list {
@@ -800,16 +1913,56 @@ func fileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.D
 			err = d.Set(mkResourceVirtualEnvironmentFileContentType, v.ContentType)
 			diags = append(diags, diag.FromErr(err)...)
 
+			importSource := ""
+			if v.ContentType == "import" {
+				importSource = v.VolumeID
+			}
+
+			err = d.Set(mkResourceVirtualEnvironmentFileImportSource, importSource)
+			diags = append(diags, diag.FromErr(err)...)
+
+			diags = append(diags, fileReadStoredContent(ctx, d, capi, nodeName, v.ContentType, v.FileSize)...)
+
 			if len(sourceFile) == 0 {
 				continue
 			}
 
 			sourceFileBlock := sourceFile[0].(map[string]interface{})
-			sourceFilePath := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFilePath].(string)
+			sourceFilePath := fileSourceLocation(sourceFileBlock)
+
+			if fileIsGitSource(sourceFilePath) {
+				// the file is pinned by the ref in the path, so there is nothing to compare it with
+				continue
+			}
 
 			fileModificationDate, fileSize, fileTag, err := readFileAttrs(ctx, sourceFilePath)
 			diags = append(diags, diag.FromErr(err)...)
 
+			expectedETag := fileNormalizeETag(sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileExpectedETag].(string))
+			if isURL && err == nil && expectedETag != "" && fileNormalizeETag(fileTag) != expectedETag {
+				diags = append(diags, diag.Errorf(
+					"the ETag of the source file %q is %q instead of the expected %q, the file may have been replaced "+
+						"upstream; update \"%s.%s\" if the change is expected",
+					sourceFilePath,
+					fileTag,
+					expectedETag,
+					mkResourceVirtualEnvironmentFileSourceFile,
+					mkResourceVirtualEnvironmentFileSourceFileExpectedETag,
+				)...)
+			}
+
+			// the size of an archived directory can't be compared with the size of the uploaded archive
+			archived := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileArchive].(string) != ""
+
+			if !isURL && !archived && err == nil && fileModificationDate == "" && fileSize == 0 && fileTag == "" {
+				// the local file is not available on this runner, so fall back to the uploaded file
+				sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileChanged] = fileRemoteChanged(ctx, d, capi, nodeName)
+				err = d.Set(mkResourceVirtualEnvironmentFileSourceFile, sourceFile)
+				diags = append(diags, diag.FromErr(err)...)
+
+				return diags
+			}
+
 			if fileModificationDate != "" || fileSize != 0 || fileTag != "" {
 				// only when file from state exists
 				err = d.Set(mkResourceVirtualEnvironmentFileFileModificationDate, fileModificationDate)
@@ -834,10 +1987,7 @@ func fileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.D
 			err = d.Set(mkResourceVirtualEnvironmentFileSourceFile, sourceFile)
 			diags = append(diags, diag.FromErr(err)...)
 
-			if diags.HasError() {
-				return diags
-			}
-			return nil
+			return diags
 		}
 	}
 
@@ -847,9 +1997,285 @@ func fileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.D
 		d.SetId("")
 	}
 
+	return diags
+}
+
+// fileReadStoredContent reads the content of the file from the node into `stored_content`, if enabled.
+// The content is left empty, with a warning, if the file is not a text file or is larger than the cap,
+// as it would bloat the state.
+func fileReadStoredContent(
+	ctx context.Context,
+	d *schema.ResourceData,
+	capi proxmox.Client,
+	nodeName string,
+	contentType string,
+	fileSize int64,
+) diag.Diagnostics {
+	if !d.Get(mkResourceVirtualEnvironmentFileReadBackContent).(bool) {
+		return diag.FromErr(d.Set(mkResourceVirtualEnvironmentFileStoredContent, ""))
+	}
+
+	var warning string
+
+	content := ""
+
+	switch {
+	case !slices.Contains(fileStoredContentTypes, contentType):
+		warning = fmt.Sprintf("the content of the %q files can't be read back, only %s files are supported",
+			contentType, strings.Join(fileStoredContentTypes, ", "))
+	case fileSize > fileStoredContentMaxSize:
+		warning = fmt.Sprintf("the file is larger than %d bytes", fileStoredContentMaxSize)
+	default:
+		out, err := readRemoteFileContent(ctx, capi, nodeName, d.Id())
+
+		switch {
+		case err != nil:
+			warning = err.Error()
+		case len(out) > fileStoredContentMaxSize:
+			warning = fmt.Sprintf("the file is larger than %d bytes", fileStoredContentMaxSize)
+		case !utf8.Valid(out):
+			warning = "the file is not a UTF-8 text file"
+		default:
+			content = string(out)
+		}
+	}
+
+	diags := diag.FromErr(d.Set(mkResourceVirtualEnvironmentFileStoredContent, content))
+
+	if warning != "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Unable to read back the content of the file %q", d.Id()),
+			Detail:   fmt.Sprintf("The \"%s\" attribute is left empty, as %s.", mkResourceVirtualEnvironmentFileStoredContent, warning),
+		})
+	}
+
+	return diags
+}
+
+// readRemoteFileContent reads the content of a datastore volume using SSH, up to one byte more than
+// the maximum size of the stored content, so that a larger file is detected.
+func readRemoteFileContent(ctx context.Context, capi proxmox.Client, nodeName string, volumeID string) ([]byte, error) {
+	out, err := capi.SSH().ExecuteNodeCommands(ctx, nodeName, []string{
+		`set -e`,
+		ssh.TrySudo,
+		fmt.Sprintf(`volume_path=$(try_sudo "pvesm path %s")`, volumeID),
+		fmt.Sprintf(`try_sudo "head -c %d $volume_path"`, fileStoredContentMaxSize+1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the volume %q on node %q: %w", volumeID, nodeName, err)
+	}
+
+	return out, nil
+}
+
+// fileRemoteChanged reports whether the uploaded file in the datastore no longer matches the size of the
+// source file recorded in the state. It's used when the local source file is not available, e.g. when the
+// resource is refreshed on a different runner than the one which uploaded it. The modification date can't
+// be compared, as the uploaded file is stamped with the time of the upload.
+func fileRemoteChanged(ctx context.Context, d *schema.ResourceData, capi proxmox.Client, nodeName string) bool {
+	lastFileSize := int64(d.Get(mkResourceVirtualEnvironmentFileFileSize).(int))
+	if lastFileSize == 0 {
+		return false
+	}
+
+	remoteFileSize, remoteFileModificationDate, err := readRemoteFile(ctx, capi, nodeName, d.Id())
+	if err != nil {
+		tflog.Warn(ctx, "failed to read the attributes of the uploaded file, skipping drift detection", map[string]interface{}{
+			"volume_id": d.Id(),
+			"error":     err.Error(),
+		})
+
+		return false
+	}
+
+	tflog.Debug(ctx, "the source file is not available locally, using the attributes of the uploaded file", map[string]interface{}{
+		"volume_id":                 d.Id(),
+		"file_size":                 remoteFileSize,
+		"file_modification_date":    remoteFileModificationDate,
+		"source_file_size_in_state": lastFileSize,
+	})
+
+	return remoteFileSize != lastFileSize
+}
+
+// readRemoteFile reads the size and the modification date of a datastore volume using SSH.
+func readRemoteFile(
+	ctx context.Context,
+	capi proxmox.Client,
+	nodeName string,
+	volumeID string,
+) (int64, string, error) {
+	out, err := capi.SSH().ExecuteNodeCommands(ctx, nodeName, []string{
+		`set -e`,
+		ssh.TrySudo,
+		fmt.Sprintf(`volume_path=$(try_sudo "pvesm path %s")`, volumeID),
+		`try_sudo "stat -L -c %s:%Y $volume_path"`,
+	})
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to stat the volume %q on node %q: %w", volumeID, nodeName, err)
+	}
+
+	return fileParseRemoteStat(string(out))
+}
+
+// readRemoteFileChecksum computes the SHA-256 checksum of a datastore volume using SSH, which requires the volume
+// to be a file, i.e. it is not supported for e.g. Ceph RBD datastores.
+func readRemoteFileChecksum(ctx context.Context, capi proxmox.Client, nodeName string, volumeID string) (string, error) {
+	out, err := capi.SSH().ExecuteNodeCommands(ctx, nodeName, []string{
+		`set -e`,
+		ssh.TrySudo,
+		fmt.Sprintf(`volume_path=$(try_sudo "pvesm path %s")`, volumeID),
+		`try_sudo "sha256sum $volume_path"`,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to compute the checksum of the volume %q on node %q: %w", volumeID, nodeName, err)
+	}
+
+	checksum := fileNormalizeChecksum(string(out))
+	if len(checksum) != sha256.Size*2 {
+		return "", fmt.Errorf("unexpected sha256sum output: %q", string(out))
+	}
+
+	return checksum, nil
+}
+
+// fileRunPostUploadCommand runs the post-upload command of the file on its resolved node, if any, with the path
+// of the volume in the `FILE_PATH` environment variable.
+func fileRunPostUploadCommand(
+	ctx context.Context,
+	d *schema.ResourceData,
+	capi proxmox.Client,
+	nodeName string,
+	volumeID string,
+) diag.Diagnostics {
+	command := d.Get(mkResourceVirtualEnvironmentFilePostUploadCommand).(string)
+	if command == "" {
+		return nil
+	}
+
+	tflog.Debug(ctx, "Running the post-upload command", map[string]interface{}{
+		"volume_id": volumeID,
+		"node_name": nodeName,
+	})
+
+	out, err := capi.SSH().ExecuteNodeCommands(ctx, nodeName, []string{
+		`set -e`,
+		ssh.TrySudo,
+		fmt.Sprintf(`FILE_PATH=$(try_sudo "pvesm path %s")`, volumeID),
+		`export FILE_PATH`,
+		command,
+	})
+	if err != nil {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("The post-upload command of the file %q failed on node %q", volumeID, nodeName),
+				// the error of a command exiting with a non-zero code is its combined stdout and stderr
+				Detail: err.Error(),
+			},
+		}
+	}
+
+	tflog.Debug(ctx, "The post-upload command succeeded", map[string]interface{}{
+		"volume_id": volumeID,
+		"output":    string(out),
+	})
+
 	return nil
 }
 
+// fileSetUploadedChecksum stores the checksums of the uploaded local file.
+func fileSetUploadedChecksum(d *schema.ResourceData, checksums map[string]string) diag.Diagnostics {
+	diags := diag.FromErr(d.Set(mkResourceVirtualEnvironmentFileUploadedChecksum, checksums["sha256"]))
+
+	return append(diags, diag.FromErr(d.Set(mkResourceVirtualEnvironmentFileChecksums, checksums))...)
+}
+
+// fileComputeLocalChecksums computes the checksums of a local file by algorithm.
+func fileComputeLocalChecksums(filePath string) (map[string]string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	return fileComputeChecksums(f)
+}
+
+// fileComputeChecksums computes the checksums of the content by algorithm, reading it only once.
+func fileComputeChecksums(r io.Reader) (map[string]string, error) {
+	hashes := map[string]hash.Hash{
+		"sha256": sha256.New(),
+		"sha512": sha512.New(),
+	}
+
+	writers := make([]io.Writer, 0, len(hashes))
+	for _, h := range hashes {
+		writers = append(writers, h)
+	}
+
+	if _, err := io.Copy(io.MultiWriter(writers...), r); err != nil {
+		return nil, err
+	}
+
+	checksums := make(map[string]string, len(hashes))
+	for algorithm, h := range hashes {
+		checksums[algorithm] = hex.EncodeToString(h.Sum(nil))
+	}
+
+	return checksums, nil
+}
+
+// fileImportChecksum stores the checksum of an imported file, so it is the baseline of the later integrity checks.
+// The file is read over SSH, so the checksum is left empty with a warning if it can't be computed.
+func fileImportChecksum(ctx context.Context, d *schema.ResourceData, m interface{}, nodeName string) error {
+	config := m.(proxmoxtf.ProviderConfiguration)
+
+	capi, err := config.GetClient()
+	if err != nil {
+		return err
+	}
+
+	checksum, err := readRemoteFileChecksum(ctx, capi, nodeName, d.Id())
+	if err != nil {
+		tflog.Warn(ctx, "Failed to compute the checksum of the imported file", map[string]interface{}{
+			"volume_id": d.Id(),
+			"error":     err.Error(),
+		})
+
+		return nil
+	}
+
+	err = d.Set(mkResourceVirtualEnvironmentFileUploadedChecksum, checksum)
+	if err != nil {
+		return fmt.Errorf("failed setting 'uploaded_checksum' in state during import: %w", err)
+	}
+
+	return nil
+}
+
+// fileParseRemoteStat parses the `<size>:<unix modification time>` output of `stat`.
+func fileParseRemoteStat(out string) (int64, string, error) {
+	fields := strings.Split(strings.TrimSpace(out), ":")
+	if len(fields) != 2 {
+		return 0, "", fmt.Errorf("unexpected stat output: %q", out)
+	}
+
+	size, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to parse the file size %q: %w", fields[0], err)
+	}
+
+	mtime, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to parse the file modification time %q: %w", fields[1], err)
+	}
+
+	return size, time.Unix(mtime, 0).UTC().Format(time.RFC3339), nil
+}
+
 //nolint:nonamedreturns
 func readFile(
 	ctx context.Context,
@@ -878,15 +2304,26 @@ func readFile(
 		return
 	}
 
-	fileModificationDate = fileInfo.ModTime().UTC().Format(time.RFC3339)
-	fileSize = fileInfo.Size()
-	fileTag = fmt.Sprintf("%x-%x", fileInfo.ModTime().UTC().Unix(), fileInfo.Size())
+	modTime, size := fileInfo.ModTime(), fileInfo.Size()
+
+	// a directory is uploaded as an archive, so it has changed if any of its entries has
+	if fileInfo.IsDir() {
+		modTime, size, err = fileDirectoryAttrs(sourceFilePath)
+		if err != nil {
+			return
+		}
+	}
+
+	fileModificationDate = modTime.UTC().Format(time.RFC3339)
+	fileSize = size
+	fileTag = fmt.Sprintf("%x-%x", modTime.UTC().Unix(), size)
 
 	return fileModificationDate, fileSize, fileTag, nil
 }
 
 func readURL(
 	httClient *http.Client,
+	authorize func(ctx context.Context, req *http.Request) error,
 ) func(
 	ctx context.Context,
 	sourceFilePath string,
@@ -900,6 +2337,12 @@ func readURL(
 			return "", 0, "", fmt.Errorf("failed to create a new request: %w", err)
 		}
 
+		if authorize != nil {
+			if err = authorize(ctx, req); err != nil {
+				return "", 0, "", err
+			}
+		}
+
 		res, err := httClient.Do(req) //nolint:bodyclose
 		if err != nil {
 			return "", 0, "", fmt.Errorf("failed to HEAD the URL: %w", err)
@@ -941,6 +2384,10 @@ func readURL(
 }
 
 func fileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Id() == fileDisabledID {
+		return nil
+	}
+
 	config := m.(proxmoxtf.ProviderConfiguration)
 
 	capi, err := config.GetClient()
@@ -949,11 +2396,23 @@ func fileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 	}
 
 	datastoreID := d.Get(mkResourceVirtualEnvironmentFileDatastoreID).(string)
-	nodeName := d.Get(mkResourceVirtualEnvironmentFileNodeName).(string)
+
+	nodeName, err := capi.Cluster().ResolveNodeName(ctx, d.Get(mkResourceVirtualEnvironmentFileNodeName).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	err = capi.Node(nodeName).Storage(datastoreID).DeleteDatastoreFile(ctx, d.Id())
 	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
-		return diag.FromErr(err)
+		fallbackNodeName, ok := fileFallbackNodeName(ctx, capi, nodeName, datastoreID, err)
+		if !ok {
+			return diag.FromErr(err)
+		}
+
+		err = capi.Node(fallbackNodeName).Storage(datastoreID).DeleteDatastoreFile(ctx, d.Id())
+		if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+			return diag.FromErr(err)
+		}
 	}
 
 	d.SetId("")
@@ -961,8 +2420,38 @@ func fileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 	return nil
 }
 
-func fileUpdate(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+// fileFallbackNodeName returns another online node hosting the datastore of the file, after a request
+// through the node of the file failed, e.g. because the node is in maintenance. Only a shared datastore
+// is hosted by multiple nodes, so a local datastore never has a fallback.
+func fileFallbackNodeName(
+	ctx context.Context,
+	capi proxmox.Client,
+	nodeName string,
+	datastoreID string,
+	requestErr error,
+) (string, bool) {
+	fallbackNodeName, err := capi.Cluster().GetDatastoreNodeName(ctx, datastoreID)
+	if err != nil || fallbackNodeName == nodeName {
+		return "", false
+	}
+
+	tflog.Warn(ctx, "Request through the node of the file failed, retrying through another node", map[string]interface{}{
+		"datastore_id":       datastoreID,
+		"node_name":          nodeName,
+		"fallback_node_name": fallbackNodeName,
+		"error":              requestErr.Error(),
+	})
+
+	return fallbackNodeName, true
+}
+
+func fileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// a pass-through update function -- no actual resource update is needed / allowed
 	// only the TF state is updated, for example, a timeout_upload attribute value
+	if d.HasChange(mkResourceVirtualEnvironmentFileReadBackContent) {
+		// the stored content is read, or cleared, right away instead of on the next refresh
+		return fileRead(ctx, d, m)
+	}
+
 	return nil
 }
@@ -0,0 +1,221 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	fileArchiveTarGz  = "tar.gz"
+	fileArchiveTarZst = "tar.zst"
+)
+
+// fileArchiveFormats are the formats of the archives which a source directory can be uploaded as.
+var fileArchiveFormats = []string{fileArchiveTarGz, fileArchiveTarZst}
+
+// fileArchiveDirectory archives the directory into a temporary file of the given format, and returns the path
+// to the archive along with a function which removes it. The entries are stored relative to the directory,
+// i.e. as `./etc/hostname`, with their permissions and owners, as a container template is extracted as is.
+// The zstd compression requires the `zstd` command, as the standard library only supports gzip.
+func fileArchiveDirectory(ctx context.Context, dir string, format string, tempDir string) (string, func(), error) {
+	fileInfo, err := os.Stat(dir)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read the source directory: %w", err)
+	}
+
+	if !fileInfo.IsDir() {
+		return "", nil, fmt.Errorf("the source %q must be a directory to be archived", dir)
+	}
+
+	f, err := os.CreateTemp(tempDir, "archive*."+format)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create a temporary file: %w", err)
+	}
+
+	cleanup := func() {
+		if e := os.Remove(f.Name()); e != nil && !errors.Is(e, fs.ErrNotExist) {
+			tflog.Error(ctx, "Failed to remove temporary file", map[string]interface{}{
+				"error": e,
+				"file":  f.Name(),
+			})
+		}
+	}
+
+	switch format {
+	case fileArchiveTarGz:
+		gw := gzip.NewWriter(f)
+
+		err = fileWriteTar(dir, gw)
+		if e := gw.Close(); err == nil {
+			err = e
+		}
+	case fileArchiveTarZst:
+		err = fileWriteTarZst(ctx, dir, f)
+	default:
+		err = fmt.Errorf("unsupported archive format %q, must be one of: %s", format, strings.Join(fileArchiveFormats, ", "))
+	}
+
+	if e := f.Close(); err == nil {
+		err = e
+	}
+
+	if err != nil {
+		cleanup()
+
+		return "", nil, fmt.Errorf("failed to archive the source directory %q: %w", dir, err)
+	}
+
+	tflog.Debug(ctx, "Archived the source directory", map[string]interface{}{
+		"source":  dir,
+		"archive": f.Name(),
+	})
+
+	return f.Name(), cleanup, nil
+}
+
+// fileWriteTarZst writes the tar stream of the directory through the `zstd` command.
+func fileWriteTarZst(ctx context.Context, dir string, w io.Writer) error {
+	pr, pw := io.Pipe()
+
+	var stderr strings.Builder
+
+	cmd := exec.CommandContext(ctx, "zstd", "--quiet", "--stdout")
+	cmd.Stdin = pr
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to run zstd: %w", err)
+	}
+
+	go func() {
+		pw.CloseWithError(fileWriteTar(dir, pw))
+	}()
+
+	err := cmd.Wait()
+
+	// unblock the writer if zstd exited early
+	_ = pr.Close()
+
+	if err != nil {
+		return fmt.Errorf("zstd failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// fileWriteTar writes the entries of the directory as a tar stream. Symbolic links are stored as links,
+// and are not followed.
+func fileWriteTar(dir string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return fmt.Errorf("unsupported file %q: %w", path, err)
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		hdr.Name = "./" + filepath.ToSlash(rel)
+		if rel == "." {
+			hdr.Name = "./"
+		} else if entry.IsDir() {
+			hdr.Name += "/"
+		}
+
+		if err = tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(tw, src)
+		if e := src.Close(); err == nil {
+			err = e
+		}
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// fileDirectoryAttrs returns the latest modification time of the entries of the directory, and the total size
+// of its files, so that a change anywhere in the directory is detected.
+func fileDirectoryAttrs(dir string) (time.Time, int64, error) {
+	var (
+		modTime time.Time
+		size    int64
+	)
+
+	err := filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return modTime, size, err
+}
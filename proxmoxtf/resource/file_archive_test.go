@@ -0,0 +1,109 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_fileArchiveDirectory(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "template")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "etc"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "etc", "hostname"), []byte("ct\n"), 0o644))
+	require.NoError(t, os.Symlink("/etc/hostname", filepath.Join(dir, "hostname")))
+
+	want := map[string]string{
+		"./":             "",
+		"./etc/":         "",
+		"./etc/hostname": "ct\n",
+		"./hostname":     "-> /etc/hostname",
+	}
+
+	readEntries := func(t *testing.T, r io.Reader) map[string]string {
+		t.Helper()
+
+		entries := map[string]string{}
+		tr := tar.NewReader(r)
+
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			require.NoError(t, err)
+
+			data, err := io.ReadAll(tr)
+			require.NoError(t, err)
+
+			entries[hdr.Name] = string(data)
+			if hdr.Typeflag == tar.TypeSymlink {
+				entries[hdr.Name] = "-> " + hdr.Linkname
+			}
+		}
+
+		return entries
+	}
+
+	t.Run("tar.gz", func(t *testing.T) {
+		t.Parallel()
+
+		archive, cleanup, err := fileArchiveDirectory(context.Background(), dir, fileArchiveTarGz, t.TempDir())
+		require.NoError(t, err)
+
+		defer cleanup()
+
+		f, err := os.Open(archive)
+		require.NoError(t, err)
+
+		defer f.Close()
+
+		gr, err := gzip.NewReader(f)
+		require.NoError(t, err)
+
+		assert.Equal(t, want, readEntries(t, gr))
+	})
+
+	t.Run("tar.zst", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := exec.LookPath("zstd"); err != nil {
+			t.Skip("zstd is not installed")
+		}
+
+		archive, cleanup, err := fileArchiveDirectory(context.Background(), dir, fileArchiveTarZst, t.TempDir())
+		require.NoError(t, err)
+
+		defer cleanup()
+
+		out, err := exec.Command("zstd", "--quiet", "--decompress", "--stdout", archive).Output()
+		require.NoError(t, err)
+
+		assert.Equal(t, want, readEntries(t, bytes.NewReader(out)))
+	})
+
+	t.Run("not a directory", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := fileArchiveDirectory(context.Background(), filepath.Join(dir, "etc", "hostname"), fileArchiveTarGz, t.TempDir())
+		require.ErrorContains(t, err, "must be a directory")
+	})
+}
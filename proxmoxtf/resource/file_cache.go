@@ -0,0 +1,289 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	fileDownloadCacheDirName = "download-cache"
+
+	// fileDownloadCacheStaleAge is the age after which the leftovers of interrupted downloads are removed.
+	fileDownloadCacheStaleAge = time.Hour
+)
+
+// fileDownloadCacheKeyRegex matches the names of the cached files, which are the SHA256 hashes of the URLs.
+var fileDownloadCacheKeyRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// fileDownloadCache is an entry of the download cache, which keeps downloaded source files
+// along with the validators returned by the server, so they can be revalidated with a conditional request.
+type fileDownloadCache struct {
+	path     string
+	metaPath string
+}
+
+type fileDownloadCacheMeta struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// newFileDownloadCache returns the cache entry for the given URL in the given temporary directory.
+func newFileDownloadCache(tempDir string, sourceURL string) *fileDownloadCache {
+	key := fmt.Sprintf("%x", sha256.Sum256([]byte(sourceURL)))
+	dir := filepath.Join(tempDir, fileDownloadCacheDirName)
+
+	return &fileDownloadCache{
+		path:     filepath.Join(dir, key),
+		metaPath: filepath.Join(dir, key+".json"),
+	}
+}
+
+// readMeta returns the validators of the cached file, or nil if the file is not cached.
+func (c *fileDownloadCache) readMeta() *fileDownloadCacheMeta {
+	if _, err := os.Stat(c.path); err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.metaPath)
+	if err != nil {
+		return nil
+	}
+
+	meta := &fileDownloadCacheMeta{}
+	if err = json.Unmarshal(data, meta); err != nil {
+		return nil
+	}
+
+	return meta
+}
+
+// setConditionalHeaders adds the `If-None-Match` and `If-Modified-Since` headers to the request
+// if the file is cached, so the server can respond with `304 Not Modified` when the cached copy is current.
+func (c *fileDownloadCache) setConditionalHeaders(req *http.Request) {
+	meta := c.readMeta()
+	if meta == nil {
+		return
+	}
+
+	if meta.ETag != "" {
+		req.Header.Set("If-None-Match", meta.ETag)
+	}
+
+	if meta.LastModified != "" {
+		req.Header.Set("If-Modified-Since", meta.LastModified)
+	}
+}
+
+// store stores the response body in the cache, unless the server reported that the cached copy is current,
+// and returns the path to the cached file.
+func (c *fileDownloadCache) store(ctx context.Context, res *http.Response) (string, error) {
+	if res.StatusCode == http.StatusNotModified {
+		if c.readMeta() == nil {
+			return "", fmt.Errorf("the server responded with %q, but the file is not cached", res.Status)
+		}
+
+		tflog.Debug(ctx, "Using cached download", map[string]interface{}{
+			"file": c.path,
+		})
+
+		// the modification time tracks the last use of the cached file for the pruning
+		now := time.Now()
+		if err := os.Chtimes(c.path, now, now); err != nil {
+			tflog.Warn(ctx, "Failed to update the modification time of the cached download", map[string]interface{}{
+				"error": err,
+				"file":  c.path,
+			})
+		}
+
+		return c.path, nil
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download the source file: %s", res.Status)
+	}
+
+	dir := filepath.Dir(c.path)
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create the download cache directory: %w", err)
+	}
+
+	tempFile, err := os.CreateTemp(dir, "download")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temporary file: %w", err)
+	}
+
+	tempFileName := tempFile.Name()
+
+	_, err = io.Copy(tempFile, res.Body)
+	if e := tempFile.Close(); err == nil {
+		err = e
+	}
+
+	if err == nil {
+		// drop the validators before replacing the file, so a failure can't leave stale validators behind
+		_ = os.Remove(c.metaPath)
+		err = os.Rename(tempFileName, c.path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tempFileName)
+
+		return "", fmt.Errorf("failed to store the downloaded file: %w", err)
+	}
+
+	meta := fileDownloadCacheMeta{
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+	}
+
+	if meta.ETag != "" || meta.LastModified != "" {
+		data, err := json.Marshal(meta)
+		if err == nil {
+			err = os.WriteFile(c.metaPath, data, 0o600)
+		}
+
+		if err != nil {
+			tflog.Warn(ctx, "Failed to store the download cache metadata", map[string]interface{}{
+				"error": err,
+				"file":  c.metaPath,
+			})
+		}
+	}
+
+	return c.path, nil
+}
+
+// remove removes the cached file along with its validators, e.g. when the cached copy fails the verification,
+// so that it's downloaded again instead of being revalidated with a conditional request.
+func (c *fileDownloadCache) remove(ctx context.Context) {
+	for _, p := range []string{c.path, c.metaPath} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			tflog.Warn(ctx, "Failed to remove file from the download cache", map[string]interface{}{
+				"error": err,
+				"file":  p,
+			})
+		}
+	}
+}
+
+// prune removes the least recently used files from the download cache, which haven't been used for longer than
+// maxAge, or exceed maxSize bytes in total. A zero value disables the respective limit. The file of the cache entry
+// itself is always kept, as it's about to be used. Leftovers of interrupted downloads are removed as well.
+func (c *fileDownloadCache) prune(ctx context.Context, maxAge time.Duration, maxSize int64) {
+	dir := filepath.Dir(c.path)
+
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		tflog.Warn(ctx, "Failed to list the download cache", map[string]interface{}{
+			"error": err,
+			"dir":   dir,
+		})
+
+		return
+	}
+
+	type cachedFile struct {
+		name    string
+		size    int64
+		modTime time.Time
+	}
+
+	var files []cachedFile
+
+	now := time.Now()
+	remove := func(name string, reason string) {
+		tflog.Debug(ctx, "Removing file from the download cache", map[string]interface{}{
+			"file":   name,
+			"reason": reason,
+		})
+
+		for _, p := range []string{filepath.Join(dir, name), filepath.Join(dir, name+".json")} {
+			if e := os.Remove(p); e != nil && !os.IsNotExist(e) {
+				tflog.Warn(ctx, "Failed to remove file from the download cache", map[string]interface{}{
+					"error": e,
+					"file":  p,
+				})
+			}
+		}
+	}
+
+	for _, entry := range dirEntries {
+		name := entry.Name()
+
+		info, e := entry.Info()
+		if e != nil || !info.Mode().IsRegular() {
+			continue
+		}
+
+		key := strings.TrimSuffix(name, ".json")
+
+		switch {
+		case !fileDownloadCacheKeyRegex.MatchString(key):
+			if now.Sub(info.ModTime()) > fileDownloadCacheStaleAge {
+				remove(name, "interrupted download")
+			}
+		case key != name:
+			// the metadata is removed along with the cached file, unless the file is missing
+			if _, e = os.Stat(filepath.Join(dir, key)); os.IsNotExist(e) {
+				remove(key, "missing cached file")
+			}
+		default:
+			files = append(files, cachedFile{name: name, size: info.Size(), modTime: info.ModTime()})
+		}
+	}
+
+	// the most recently used files are kept first
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.After(files[j].modTime)
+	})
+
+	keep := filepath.Base(c.path)
+
+	// the size of the file in use counts against the limit, as it can't be removed
+	var totalSize int64
+
+	for _, f := range files {
+		if f.name == keep {
+			totalSize += f.size
+		}
+	}
+
+	full := false
+
+	for _, f := range files {
+		if f.name == keep {
+			continue
+		}
+
+		switch {
+		case maxAge > 0 && now.Sub(f.modTime) > maxAge:
+			remove(f.name, "max age exceeded")
+		case maxSize > 0 && (full || totalSize+f.size > maxSize):
+			// once the limit is reached, all the less recently used files are removed
+			full = true
+
+			remove(f.name, "max size exceeded")
+		default:
+			totalSize += f.size
+		}
+	}
+}
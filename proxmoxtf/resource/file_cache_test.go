@@ -0,0 +1,123 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_fileDownloadCache(t *testing.T) {
+	t.Parallel()
+
+	const etag = `"v1"`
+
+	downloads := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		downloads++
+
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte("content"))
+	}))
+	defer srv.Close()
+
+	get := func(cache *fileDownloadCache) string {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+
+		cache.setConditionalHeaders(req)
+
+		res, err := srv.Client().Do(req)
+		require.NoError(t, err)
+
+		defer res.Body.Close()
+
+		path, err := cache.store(context.Background(), res)
+		require.NoError(t, err)
+
+		return path
+	}
+
+	cache := newFileDownloadCache(t.TempDir(), srv.URL)
+
+	path := get(cache)
+	assert.Equal(t, 1, downloads)
+
+	// the second request is answered with 304 Not Modified, and the cached file is reused
+	assert.Equal(t, path, get(cache))
+	assert.Equal(t, 1, downloads)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+}
+
+func Test_fileDownloadCachePrune(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	dir := filepath.Join(tempDir, fileDownloadCacheDirName)
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+
+	now := time.Now()
+
+	// add a cached file of the given size for the URL, which was last used the given time ago
+	add := func(url string, size int, lastUsed time.Duration) *fileDownloadCache {
+		cache := newFileDownloadCache(tempDir, url)
+		require.NoError(t, os.WriteFile(cache.path, make([]byte, size), 0o600))
+		require.NoError(t, os.WriteFile(cache.metaPath, []byte(`{"etag":"v1"}`), 0o600))
+		require.NoError(t, os.Chtimes(cache.path, now.Add(-lastUsed), now.Add(-lastUsed)))
+
+		return cache
+	}
+
+	inUse := add("https://example.com/in-use.iso", 10, 48*time.Hour)
+	recent := add("https://example.com/recent.iso", 10, time.Minute)
+	older := add("https://example.com/older.iso", 10, time.Hour)
+	oldest := add("https://example.com/oldest.iso", 10, 2*time.Hour)
+	expired := add("https://example.com/expired.iso", 1, 25*time.Hour)
+
+	interrupted := filepath.Join(dir, "download123")
+	require.NoError(t, os.WriteFile(interrupted, []byte("partial"), 0o600))
+	require.NoError(t, os.Chtimes(interrupted, now.Add(-2*time.Hour), now.Add(-2*time.Hour)))
+
+	inProgress := filepath.Join(dir, "download456")
+	require.NoError(t, os.WriteFile(inProgress, []byte("partial"), 0o600))
+
+	inUse.prune(context.Background(), 24*time.Hour, 30)
+
+	// the file in use is kept regardless of its age, and counts against the size limit
+	assert.FileExists(t, inUse.path)
+	assert.FileExists(t, recent.path)
+	assert.FileExists(t, older.path)
+	assert.NoFileExists(t, oldest.path)
+	assert.NoFileExists(t, oldest.metaPath)
+	assert.NoFileExists(t, expired.path)
+	assert.NoFileExists(t, expired.metaPath)
+	assert.NoFileExists(t, interrupted)
+	assert.FileExists(t, inProgress)
+
+	// no limits
+	recent.prune(context.Background(), 0, 0)
+
+	assert.FileExists(t, inUse.path)
+	assert.FileExists(t, older.path)
+}
@@ -0,0 +1,739 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"context"
+	"crypto/md5" //nolint:gosec
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/storage"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+	"github.com/bpg/terraform-provider-proxmox/utils"
+)
+
+const (
+	// fileDownloadRetryMaxBackoff limits the exponential backoff between the download attempts.
+	fileDownloadRetryMaxBackoff = 30 * time.Second
+
+	// fileDownloadRetryMaxAfter limits the delay requested by the server with the `Retry-After` header.
+	fileDownloadRetryMaxAfter = 5 * time.Minute
+)
+
+// fileDownloadRetryOnStatus are the status codes of the responses which are retried by default, as they are
+// returned by rate-limited or overloaded servers.
+var fileDownloadRetryOnStatus = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// fileChecksumHash returns a new hash for the given checksum algorithm.
+func fileChecksumHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "md5":
+		return md5.New(), nil //nolint:gosec
+	case "sha1":
+		return sha1.New(), nil //nolint:gosec
+	case "sha224":
+		return sha256.New224(), nil
+	case "sha256":
+		return sha256.New(), nil
+	case "sha384":
+		return sha512.New384(), nil
+	case "sha512":
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm %q", algorithm)
+	}
+}
+
+// fileNormalizeChecksum returns the checksum in the lowercase hexadecimal form, so that checksums copied from
+// the output of the common tools can be used as is, e.g. `<checksum> *file.iso` from `sha256sum`,
+// `SHA256 (file.iso) = <checksum>` from BSD tools, or `sha256:<checksum>`.
+func fileNormalizeChecksum(checksum string) string {
+	checksum = strings.TrimSpace(checksum)
+
+	if i := strings.LastIndex(checksum, ") = "); i >= 0 {
+		checksum = checksum[i+len(") = "):]
+	}
+
+	// the checksum may be followed by the file name and the size
+	if fields := strings.Fields(checksum); len(fields) > 0 {
+		checksum = fields[0]
+	}
+
+	if i := strings.IndexAny(checksum, ":="); i >= 0 {
+		checksum = checksum[i+1:]
+	}
+
+	return strings.ToLower(checksum)
+}
+
+// fileServerSideDownload lets the node download the source file from its URL directly.
+// The checksum of the source file is passed along, so the node verifies the integrity of the download.
+func fileServerSideDownload(
+	ctx context.Context,
+	d *schema.ResourceData,
+	capi proxmox.Client,
+	nodeName string,
+	contentType string,
+	fileName string,
+	existingFile *storage.DatastoreFileListResponseData,
+) diag.Diagnostics {
+	sourceFileBlock := d.Get(mkResourceVirtualEnvironmentFileSourceFile).([]interface{})[0].(map[string]interface{})
+	sourceFileURL := fileSourceLocation(sourceFileBlock)
+	sourceFileChecksum := fileNormalizeChecksum(sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileChecksum].(string))
+	sourceFileChecksumAlgorithm := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileChecksumAlgorithm].(string)
+	sourceFileInsecure := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileInsecure].(bool)
+
+	if !fileIsURL(d) || fileSourceBlock(sourceFileBlock, mkResourceVirtualEnvironmentFileSourceFileGCS) != nil {
+		return diag.Errorf(
+			"\"%s.%s\" requires the source file to be a public URL or an Azure Blob Storage SAS URL",
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFileServerSideDownload,
+		)
+	}
+
+	// the node verifies the checksum of the downloaded file, before it's decompressed
+	if sourceFileChecksum != "" &&
+		sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileChecksumTarget].(string) == "decompressed" {
+		return diag.Errorf(
+			"\"%s.%s\" = \"decompressed\" is not supported with \"%s.%s\"",
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFileChecksumTarget,
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFileServerSideDownload,
+		)
+	}
+
+	switch contentType {
+	case "iso", "vztmpl", "import":
+	default:
+		return diag.Errorf(
+			"\"%s.%s\" is not supported for content type %q",
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFileServerSideDownload,
+			contentType,
+		)
+	}
+
+	datastoreID := d.Get(mkResourceVirtualEnvironmentFileDatastoreID).(string)
+	storageClient := capi.Node(nodeName).Storage(datastoreID)
+
+	var diags diag.Diagnostics
+
+	downloadFileName := fileName
+
+	// The node refuses to download over an existing file, so the file is downloaded under a temporary name,
+	// and the existing file is only replaced once the download has been verified.
+	if existingFile != nil {
+		suffix := make([]byte, 8)
+		if _, err := rand.Read(suffix); err != nil {
+			return diag.Errorf("failed to generate the temporary file name: %s", err)
+		}
+
+		downloadFileName = fmt.Sprintf("terraform-provider-proxmox-%x-%s", suffix, fileName)
+	}
+
+	req := &storage.DownloadURLPostRequestBody{
+		Content:  ptr.Ptr(contentType),
+		FileName: ptr.Ptr(downloadFileName),
+		Node:     ptr.Ptr(nodeName),
+		Storage:  ptr.Ptr(datastoreID),
+		URL:      ptr.Ptr(sourceFileURL),
+		Verify:   types.CustomBool(!sourceFileInsecure).Pointer(),
+	}
+
+	if sourceFileChecksum != "" {
+		req.Checksum = ptr.Ptr(sourceFileChecksum)
+		req.ChecksumAlgorithm = ptr.Ptr(sourceFileChecksumAlgorithm)
+	}
+
+	tflog.Debug(ctx, "Downloading file from URL on the node", map[string]interface{}{
+		"source":    fileSourceName(sourceFileBlock),
+		"node_name": nodeName,
+		"file_name": downloadFileName,
+		"checksum":  sourceFileChecksum != "",
+	})
+
+	if err := storageClient.DownloadFileByURL(ctx, req); err != nil {
+		if sourceFileChecksum != "" {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "failed to download the source file on the node",
+				Detail: fmt.Sprintf(
+					"The node either failed to download the file, or the %s checksum of the downloaded file "+
+						"does not match \"%s\": %s",
+					sourceFileChecksumAlgorithm,
+					sourceFileChecksum,
+					err,
+				),
+			})
+		}
+
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	if sourceFileChecksum != "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary: fmt.Sprintf("the node verified the %s checksum of the downloaded file %q",
+				sourceFileChecksumAlgorithm, fileName),
+			Detail: fmt.Sprintf("The %s checksum of the downloaded file matches \"%s\".",
+				sourceFileChecksumAlgorithm, sourceFileChecksum),
+		})
+	}
+
+	if existingFile == nil {
+		return diags
+	}
+
+	tempVolumeID := fmt.Sprintf("%s:%s/%s", datastoreID, contentType, downloadFileName)
+
+	defer fileRemoveTempVolume(ctx, storageClient, tempVolumeID)
+
+	diags = append(diags, fileServerSideReplace(ctx, d, capi, nodeName, fileName, existingFile, tempVolumeID, req)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, fileOverwrittenWarning(existingFile.VolumeID))
+}
+
+// fileServerSideReplace replaces an existing file with the file downloaded by the node under a temporary name,
+// if the existing file may be overwritten. The file is moved over the existing one using SSH, so the existing file
+// is never missing. If it can't be moved, e.g. without SSH access to the node, the existing file is deleted and
+// the verified source is downloaded once more under its name.
+func fileServerSideReplace(
+	ctx context.Context,
+	d *schema.ResourceData,
+	capi proxmox.Client,
+	nodeName string,
+	fileName string,
+	existingFile *storage.DatastoreFileListResponseData,
+	tempVolumeID string,
+	req *storage.DownloadURLPostRequestBody,
+) diag.Diagnostics {
+	storageClient := capi.Node(nodeName).Storage(*req.Storage)
+
+	tempFile, err := storageClient.GetDatastoreFile(ctx, tempVolumeID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if tempFile.FileSize == nil {
+		return diag.Errorf("the size of the downloaded file %q is unknown", tempVolumeID)
+	}
+
+	diags := fileCheckOverwrite(ctx, d, capi, nodeName, existingFile, *tempFile.FileSize, func() (string, error) {
+		return readRemoteFileChecksum(ctx, capi, nodeName, tempVolumeID)
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	_, err = capi.SSH().ExecuteNodeCommands(ctx, nodeName, []string{
+		`set -e`,
+		ssh.TrySudo,
+		fmt.Sprintf(`source_path=$(try_sudo "pvesm path %s")`, tempVolumeID),
+		fmt.Sprintf(`target_path=$(try_sudo "pvesm path %s")`, existingFile.VolumeID),
+		`try_sudo "mv -f -- $source_path $target_path"`,
+	})
+	if err == nil {
+		return diags
+	}
+
+	tflog.Warn(ctx, "Failed to move the downloaded file over the existing file, downloading it again", map[string]interface{}{
+		"volume_id": existingFile.VolumeID,
+		"error":     err,
+	})
+
+	if err = storageClient.DeleteDatastoreFile(ctx, existingFile.VolumeID); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	req.FileName = &fileName
+
+	if err = storageClient.DownloadFileByURL(ctx, req); err != nil {
+		return append(diags, diag.Errorf("failed to download the source file on the node again, after the "+
+			"existing file %q has been deleted: %s", existingFile.VolumeID, err)...)
+	}
+
+	return diags
+}
+
+// fileRemoveTempVolume removes the temporary volume of a server-side download, unless it has been moved already.
+func fileRemoveTempVolume(ctx context.Context, storageClient *storage.Client, volumeID string) {
+	err := storageClient.DeleteDatastoreFile(context.WithoutCancel(ctx), volumeID)
+	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+		tflog.Warn(ctx, "Failed to remove the temporary file", map[string]interface{}{
+			"volume_id": volumeID,
+			"error":     err,
+		})
+	}
+}
+
+// fileDownloadHTTPClient returns the HTTP client to download the source file with. Connecting to the host, including
+// the TLS handshake, is limited by the connect timeout, so an unreachable host fails fast instead of consuming the whole
+// upload timeout.
+func fileDownloadHTTPClient(sourceFileBlock map[string]interface{}) (*http.Client, error) {
+	minTLSVersion, err := api.GetMinTLSVersion(sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileMinTLS].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	connectTimeout := time.Duration(sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileConnectTimeout].(int)) * time.Second
+
+	dialer := &net.Dialer{Timeout: connectTimeout}
+
+	// the connections are made from the local address, so that they egress through its interface
+	if localAddr, _ := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileLocalAddr].(string); localAddr != "" {
+		ip := net.ParseIP(localAddr)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid local address %q", localAddr)
+		}
+
+		dialer.LocalAddr = &net.TCPAddr{IP: ip}
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: dialer.DialContext,
+			TLSClientConfig: &tls.Config{
+				MinVersion:         minTLSVersion,
+				InsecureSkipVerify: sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileInsecure].(bool),
+			},
+			TLSHandshakeTimeout: connectTimeout,
+		},
+	}, nil
+}
+
+// fileDownload downloads the source file from the URL, and returns the path to the local copy of the file
+// together with a function to remove the copy once it's no longer needed.
+func fileDownload(
+	ctx context.Context,
+	httpClient *http.Client,
+	sourceFileBlock map[string]interface{},
+	sourceFileURL string,
+	tempDir string,
+) (string, func(), error) {
+	noCleanup := func() {}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceFileURL, nil)
+	if err != nil {
+		return "", noCleanup, fmt.Errorf("failed to create the download request: %w", err)
+	}
+
+	if authorize := fileSourceAuthorizer(httpClient, sourceFileBlock); authorize != nil {
+		if err = authorize(ctx, req); err != nil {
+			return "", noCleanup, err
+		}
+	}
+
+	var cache *fileDownloadCache
+	if sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileCache].(bool) {
+		cache = newFileDownloadCache(tempDir, sourceFileURL)
+		cache.setConditionalHeaders(req)
+	}
+
+	// a cached copy is usually current, and replaced only once the new one is downloaded
+	if cache == nil || cache.readMeta() == nil {
+		if err = fileCheckTempSpace(ctx, httpClient, req, tempDir); err != nil {
+			return "", noCleanup, err
+		}
+	}
+
+	res, err := fileDownloadDo(ctx, httpClient, sourceFileBlock, req)
+	if err != nil {
+		return "", noCleanup, fmt.Errorf("failed to download the source file: %w", err)
+	}
+
+	defer utils.CloseOrLogError(ctx)(res.Body)
+
+	if cache != nil {
+		cachedFileName, err := cache.store(ctx, res)
+		if err == nil {
+			cache.prune(
+				ctx,
+				time.Duration(sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileCacheMaxAge].(int))*time.Second,
+				int64(sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileCacheMaxSize].(int)),
+			)
+		}
+
+		// the cached copy is kept for subsequent downloads
+		return cachedFileName, noCleanup, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		if fileSourceIsObjectStorage(sourceFileBlock) {
+			return "", noCleanup, fmt.Errorf("failed to download the source file from the object storage: %s", res.Status)
+		}
+
+		return "", noCleanup, fmt.Errorf("failed to download the source file: %s", res.Status)
+	}
+
+	tempDownloadedFile, err := os.CreateTemp(tempDir, "download")
+	if err != nil {
+		return "", noCleanup, fmt.Errorf("failed to create a temporary file: %w", err)
+	}
+
+	tempDownloadedFileName := tempDownloadedFile.Name()
+	cleanup := func() {
+		if e := os.Remove(tempDownloadedFileName); e != nil {
+			tflog.Error(ctx, "Failed to remove temporary file", map[string]interface{}{
+				"error": e,
+				"file":  tempDownloadedFileName,
+			})
+		}
+	}
+
+	_, err = io.Copy(tempDownloadedFile, res.Body)
+	if e := tempDownloadedFile.Close(); err == nil {
+		err = e
+	}
+
+	if err != nil {
+		cleanup()
+
+		return "", noCleanup, fmt.Errorf("failed to store the downloaded file: %w", err)
+	}
+
+	return tempDownloadedFileName, cleanup, nil
+}
+
+// fileDownloadVerified downloads the source file like fileDownload, and verifies it. A cached copy which fails
+// the verification is evicted from the download cache, as the server would otherwise keep confirming it's current.
+func fileDownloadVerified(
+	ctx context.Context,
+	httpClient *http.Client,
+	sourceFileBlock map[string]interface{},
+	sourceFileURL string,
+	tempDir string,
+) (string, func(), error) {
+	localPath, cleanup, err := fileDownload(ctx, httpClient, sourceFileBlock, sourceFileURL, tempDir)
+	if err != nil {
+		return "", cleanup, err
+	}
+
+	if err = fileVerifySource(ctx, sourceFileBlock, localPath); err != nil {
+		if sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileCache].(bool) {
+			newFileDownloadCache(tempDir, sourceFileURL).remove(ctx)
+		}
+
+		return "", cleanup, err
+	}
+
+	return localPath, cleanup, nil
+}
+
+// fileNormalizeETag strips the quotes and the weak indicator from an ETag, so that the tag can be specified
+// as it's shown by the server, or without the quotes.
+func fileNormalizeETag(tag string) string {
+	tag = strings.TrimSpace(tag)
+	tag = strings.TrimPrefix(tag, "W/")
+
+	return strings.Trim(tag, `"`)
+}
+
+// fileVerifyETag sends a HEAD request for the source file, and fails if the ETag of the file does not match
+// the expected one, i.e. the file was replaced upstream, before anything is downloaded. Only the path of the
+// source file is verified, as the mirrors are different servers with their own tags.
+func fileVerifyETag(ctx context.Context, httpClient *http.Client, sourceFileBlock map[string]interface{}) error {
+	expectedETag := fileNormalizeETag(sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileExpectedETag].(string))
+	if expectedETag == "" {
+		return nil
+	}
+
+	sourceFileURL := fileSourceLocation(sourceFileBlock)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, sourceFileURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create the HEAD request: %w", err)
+	}
+
+	if authorize := fileSourceAuthorizer(httpClient, sourceFileBlock); authorize != nil {
+		if err = authorize(ctx, req); err != nil {
+			return err
+		}
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to verify the ETag of the source file: %w", err)
+	}
+
+	utils.CloseOrLogError(ctx)(res.Body)
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to verify the ETag of the source file: %s", res.Status)
+	}
+
+	actualETag := res.Header.Get("ETag")
+	if actualETag == "" {
+		return fmt.Errorf("failed to verify the ETag of the source file: %q has no ETag", sourceFileURL)
+	}
+
+	if fileNormalizeETag(actualETag) != expectedETag {
+		return fmt.Errorf(
+			"the ETag of the source file %q is %s instead of the expected %q, the file may have been replaced upstream",
+			sourceFileURL, actualETag, expectedETag,
+		)
+	}
+
+	tflog.Debug(ctx, "Verified the ETag of the source file", map[string]interface{}{
+		"source": sourceFileURL,
+		"etag":   actualETag,
+	})
+
+	return nil
+}
+
+// fileCheckTempSpace sends a HEAD request for the source file, and fails if its `Content-Length` exceeds
+// the free space in the temporary directory, instead of failing once the disk is full. The check is skipped
+// when the length is unknown, or the free space can't be determined.
+func fileCheckTempSpace(ctx context.Context, httpClient *http.Client, req *http.Request, tempDir string) error {
+	headReq := req.Clone(ctx)
+	headReq.Method = http.MethodHead
+
+	res, err := httpClient.Do(headReq)
+	if err != nil {
+		tflog.Debug(ctx, "Failed to get the size of the source file, skipping the free space check", map[string]interface{}{
+			"error": err,
+		})
+
+		return nil
+	}
+
+	utils.CloseOrLogError(ctx)(res.Body)
+
+	if res.StatusCode != http.StatusOK || res.ContentLength <= 0 {
+		tflog.Debug(ctx, "The size of the source file is unknown, skipping the free space check", map[string]interface{}{
+			"status": res.Status,
+		})
+
+		return nil
+	}
+
+	dir := tempDir
+	if dir == "" {
+		dir = os.TempDir()
+	}
+
+	free, err := utils.FreeDiskSpace(dir)
+	if err != nil {
+		tflog.Debug(ctx, "Failed to get the free space of the temporary directory, skipping the free space check",
+			map[string]interface{}{
+				"error": err,
+			})
+
+		return nil
+	}
+
+	if uint64(res.ContentLength) > free {
+		return fmt.Errorf(
+			"not enough free space to download the source file: need %.2f GiB free in %q, have %.2f GiB; "+
+				"free some space, or set \"%s\" to a directory on a larger file system",
+			float64(res.ContentLength)/(1<<30), dir, float64(free)/(1<<30), mkResourceVirtualEnvironmentFileTmpDir,
+		)
+	}
+
+	return nil
+}
+
+// fileDownloadDo sends the download request, and retries it up to `retries` times when it fails, or the server
+// responds with one of the `retry_on_status` codes. The delay between the attempts is the one requested by the server
+// with the `Retry-After` header if any, and grows exponentially otherwise.
+func fileDownloadDo(
+	ctx context.Context,
+	httpClient *http.Client,
+	sourceFileBlock map[string]interface{},
+	req *http.Request,
+) (*http.Response, error) {
+	retries := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileRetries].(int)
+
+	retryOnStatus := fileDownloadRetryOnStatus
+	if statuses := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileRetryOnStatus].([]interface{}); len(statuses) > 0 {
+		retryOnStatus = make([]int, 0, len(statuses))
+		for _, status := range statuses {
+			retryOnStatus = append(retryOnStatus, status.(int))
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		res, err := httpClient.Do(req)
+		if attempt >= retries || ctx.Err() != nil || (err == nil && !slices.Contains(retryOnStatus, res.StatusCode)) {
+			return res, err
+		}
+
+		delay := fileDownloadRetryDelay(res, attempt, time.Now())
+
+		fields := map[string]interface{}{
+			"attempt": attempt + 1,
+			"delay":   delay.String(),
+		}
+
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = res.Status
+
+			// the body is drained, so the connection can be reused by the next attempt
+			_, _ = io.Copy(io.Discard, res.Body)
+			utils.CloseOrLogError(ctx)(res.Body)
+		}
+
+		tflog.Warn(ctx, "Failed to download the source file, retrying", fields)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// fileDownloadRetryDelay returns the delay before the next download attempt, which is the one requested by
+// the `Retry-After` header of the response, in seconds or as an HTTP date, or the exponential backoff.
+func fileDownloadRetryDelay(res *http.Response, attempt int, now time.Time) time.Duration {
+	if res != nil {
+		if retryAfter := res.Header.Get("Retry-After"); retryAfter != "" {
+			if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+				return min(time.Duration(seconds)*time.Second, fileDownloadRetryMaxAfter)
+			}
+
+			if date, err := http.ParseTime(retryAfter); err == nil {
+				return min(max(date.Sub(now), 0), fileDownloadRetryMaxAfter)
+			}
+		}
+	}
+
+	return min(time.Second<<min(attempt, 5), fileDownloadRetryMaxBackoff)
+}
+
+// fileVerifySource verifies the size and the checksum of the source file, once it's available locally.
+func fileVerifySource(ctx context.Context, sourceFileBlock map[string]interface{}, sourceFilePathLocal string) error {
+	sourceFileName := fileSourceName(sourceFileBlock)
+	sourceFileChecksum := fileNormalizeChecksum(sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileChecksum].(string))
+	sourceFileChecksumAlgorithm := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileChecksumAlgorithm].(string)
+	sourceFileExpectedSize := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileExpectedSize].(int)
+
+	// Verify the size of the source file first, this is a cheap way to catch truncated
+	// downloads before doing any further work.
+	if sourceFileExpectedSize > 0 {
+		fileInfo, err := os.Stat(sourceFilePathLocal)
+		if err != nil {
+			return fmt.Errorf("failed to get source file info: %w", err)
+		}
+
+		if fileInfo.Size() != int64(sourceFileExpectedSize) {
+			return fmt.Errorf(
+				"the size of the source file \"%s\" (%d bytes) does not match the expected size (%d bytes)",
+				sourceFileName,
+				fileInfo.Size(),
+				sourceFileExpectedSize,
+			)
+		}
+	}
+
+	if sourceFileChecksum == "" {
+		return nil
+	}
+
+	file, err := os.Open(sourceFilePathLocal)
+	if err != nil {
+		return fmt.Errorf("failed to open source file: %w", err)
+	}
+
+	h, err := fileChecksumHash(sourceFileChecksumAlgorithm)
+	if err != nil {
+		return errors.Join(err, file.Close())
+	}
+
+	var content io.Reader = file
+
+	sourceFileChecksumTarget, _ := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileChecksumTarget].(string)
+	if sourceFileChecksumTarget == "decompressed" {
+		content, err = fileDecompressingReader(sourceFileName, file)
+		if err != nil {
+			return errors.Join(err, file.Close())
+		}
+	}
+
+	_, err = io.Copy(h, content)
+	if err = errors.Join(err, file.Close()); err != nil {
+		return fmt.Errorf("failed to calculate the checksum of the source file: %w", err)
+	}
+
+	calculatedChecksum := fmt.Sprintf("%x", h.Sum(nil))
+	tflog.Debug(ctx, "Calculated checksum", map[string]interface{}{
+		"source":    sourceFileName,
+		"algorithm": sourceFileChecksumAlgorithm,
+		"checksum":  calculatedChecksum,
+	})
+
+	if sourceFileChecksum != calculatedChecksum {
+		return fmt.Errorf(
+			"the calculated %s checksum \"%s\" does not match source checksum \"%s\"",
+			strings.ToUpper(sourceFileChecksumAlgorithm),
+			calculatedChecksum,
+			sourceFileChecksum,
+		)
+	}
+
+	return nil
+}
+
+// fileDecompressingReader returns a reader of the decompressed content of the source file. The compression
+// is determined by the extension of the file name, only the formats supported by the standard library are available.
+func fileDecompressingReader(sourceFileName string, r io.Reader) (io.Reader, error) {
+	name := strings.ToLower(sourceFileName)
+
+	switch {
+	case strings.HasSuffix(name, ".gz"), strings.HasSuffix(name, ".tgz"):
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress the source file: %w", err)
+		}
+
+		return gr, nil
+	case strings.HasSuffix(name, ".bz2"):
+		return bzip2.NewReader(r), nil
+	default:
+		return nil, fmt.Errorf(
+			"unable to calculate the checksum of the decompressed source file %q, "+
+				"only gzip (.gz, .tgz) and bzip2 (.bz2) compressed files are supported",
+			sourceFileName,
+		)
+	}
+}
@@ -0,0 +1,545 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_fileChecksumHash(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"md5":    "acbd18db4cc2f85cedef654fccc4a4d8",
+		"sha1":   "0beec7b5ea3f0fdbc95d0dd47f3c5bc275da8a33",
+		"sha224": "0808f64e60d58979fcb676c96ec938270dea42445aeefcd3a4e6f8db",
+		"sha256": "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+	}
+
+	for algorithm, checksum := range tests {
+		t.Run(algorithm, func(t *testing.T) {
+			t.Parallel()
+
+			h, err := fileChecksumHash(algorithm)
+			require.NoError(t, err)
+
+			_, err = h.Write([]byte("foo"))
+			require.NoError(t, err)
+			assert.Equal(t, checksum, fmt.Sprintf("%x", h.Sum(nil)))
+		})
+	}
+
+	_, err := fileChecksumHash("crc32")
+	require.Error(t, err)
+}
+
+func Test_fileDownload(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/image.img" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("foo"))
+	}))
+	defer srv.Close()
+
+	sourceFileBlock := map[string]interface{}{
+		mkResourceVirtualEnvironmentFileSourceFileCache:         false,
+		mkResourceVirtualEnvironmentFileSourceFilePath:          srv.URL + "/image.img",
+		mkResourceVirtualEnvironmentFileSourceFileRetries:       0,
+		mkResourceVirtualEnvironmentFileSourceFileRetryOnStatus: []interface{}{},
+	}
+
+	_, cleanup, err := fileDownload(context.Background(), srv.Client(), sourceFileBlock, srv.URL+"/missing.img", t.TempDir())
+	require.ErrorContains(t, err, "404")
+
+	cleanup()
+
+	localPath, cleanup, err := fileDownload(context.Background(), srv.Client(), sourceFileBlock, srv.URL+"/image.img", t.TempDir())
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(localPath)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(data))
+
+	cleanup()
+
+	_, err = os.Stat(localPath)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func Test_fileDownloadVerifiedEvictsCache(t *testing.T) {
+	t.Parallel()
+
+	const etag = `"v1"`
+
+	downloads := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		if r.Method == http.MethodGet {
+			downloads++
+		}
+
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte("corrupt"))
+	}))
+	defer srv.Close()
+
+	sourceFileBlock := map[string]interface{}{
+		mkResourceVirtualEnvironmentFileSourceFileCache:             true,
+		mkResourceVirtualEnvironmentFileSourceFileCacheMaxAge:       0,
+		mkResourceVirtualEnvironmentFileSourceFileCacheMaxSize:      0,
+		mkResourceVirtualEnvironmentFileSourceFileChecksum:          "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		mkResourceVirtualEnvironmentFileSourceFileChecksumAlgorithm: "sha256",
+		mkResourceVirtualEnvironmentFileSourceFileExpectedSize:      0,
+		mkResourceVirtualEnvironmentFileSourceFilePath:              srv.URL + "/image.img",
+		mkResourceVirtualEnvironmentFileSourceFileRetries:           0,
+		mkResourceVirtualEnvironmentFileSourceFileRetryOnStatus:     []interface{}{},
+	}
+
+	tempDir := t.TempDir()
+	cache := newFileDownloadCache(tempDir, srv.URL+"/image.img")
+
+	for i := 1; i <= 2; i++ {
+		_, cleanup, err := fileDownloadVerified(context.Background(), srv.Client(), sourceFileBlock, srv.URL+"/image.img", tempDir)
+		require.ErrorContains(t, err, "does not match source checksum")
+
+		cleanup()
+
+		// the corrupt copy is evicted, so it's downloaded again instead of being revalidated
+		assert.Equal(t, i, downloads)
+		assert.Nil(t, cache.readMeta())
+
+		for _, p := range []string{cache.path, cache.metaPath} {
+			_, err = os.Stat(p)
+			assert.ErrorIs(t, err, os.ErrNotExist)
+		}
+	}
+}
+
+func Test_fileCheckTempSpace(t *testing.T) {
+	t.Parallel()
+
+	var gets atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets.Add(1)
+		}
+
+		if r.URL.Path == "/huge.img" {
+			// more than any file system has free
+			w.Header().Set("Content-Length", "9223372036854775807")
+
+			return
+		}
+
+		_, _ = w.Write([]byte("foo"))
+	}))
+	defer srv.Close()
+
+	sourceFileBlock := map[string]interface{}{
+		mkResourceVirtualEnvironmentFileSourceFileCache:         false,
+		mkResourceVirtualEnvironmentFileSourceFileRetries:       0,
+		mkResourceVirtualEnvironmentFileSourceFileRetryOnStatus: []interface{}{},
+	}
+
+	tempDir := t.TempDir()
+
+	_, cleanup, err := fileDownload(context.Background(), srv.Client(), sourceFileBlock, srv.URL+"/huge.img", tempDir)
+	require.ErrorContains(t, err, "not enough free space")
+	require.ErrorContains(t, err, tempDir)
+	assert.Equal(t, int32(0), gets.Load(), "the file must not be downloaded")
+
+	cleanup()
+
+	localPath, cleanup, err := fileDownload(context.Background(), srv.Client(), sourceFileBlock, srv.URL+"/image.img", tempDir)
+	require.NoError(t, err)
+
+	defer cleanup()
+
+	data, err := os.ReadFile(localPath)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(data))
+}
+
+func Test_fileVerifyETag(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image.img":
+			w.Header().Set("ETag", `W/"5f3e-1a2b"`)
+		case "/missing.img":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	sourceFileBlock := func(path string, expectedETag string) map[string]interface{} {
+		return map[string]interface{}{
+			mkResourceVirtualEnvironmentFileSourceFilePath:         srv.URL + path,
+			mkResourceVirtualEnvironmentFileSourceFileExpectedETag: expectedETag,
+		}
+	}
+
+	ctx := context.Background()
+
+	require.NoError(t, fileVerifyETag(ctx, srv.Client(), sourceFileBlock("/image.img", "")))
+	require.NoError(t, fileVerifyETag(ctx, srv.Client(), sourceFileBlock("/image.img", `"5f3e-1a2b"`)))
+	require.NoError(t, fileVerifyETag(ctx, srv.Client(), sourceFileBlock("/image.img", "5f3e-1a2b")))
+
+	err := fileVerifyETag(ctx, srv.Client(), sourceFileBlock("/image.img", "5f3e-ffff"))
+	require.ErrorContains(t, err, "may have been replaced upstream")
+
+	err = fileVerifyETag(ctx, srv.Client(), sourceFileBlock("/other.img", "5f3e-1a2b"))
+	require.ErrorContains(t, err, "has no ETag")
+
+	err = fileVerifyETag(ctx, srv.Client(), sourceFileBlock("/missing.img", "5f3e-1a2b"))
+	require.ErrorContains(t, err, "404")
+}
+
+func Test_fileDownloadRetry(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the size of the file is checked with a HEAD request first
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		n := requests.Add(1)
+
+		switch {
+		case r.URL.Path == "/forbidden.img":
+			w.WriteHeader(http.StatusForbidden)
+		case n < 3:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte("foo"))
+		}
+	}))
+	defer srv.Close()
+
+	sourceFileBlock := func(retries int, retryOnStatus ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			mkResourceVirtualEnvironmentFileSourceFileCache:         false,
+			mkResourceVirtualEnvironmentFileSourceFileRetries:       retries,
+			mkResourceVirtualEnvironmentFileSourceFileRetryOnStatus: retryOnStatus,
+		}
+	}
+
+	_, cleanup, err := fileDownload(context.Background(), srv.Client(), sourceFileBlock(1), srv.URL+"/image.img", t.TempDir())
+	require.ErrorContains(t, err, "429")
+	assert.Equal(t, int32(2), requests.Load())
+
+	cleanup()
+
+	requests.Store(0)
+
+	localPath, cleanup, err := fileDownload(context.Background(), srv.Client(), sourceFileBlock(2), srv.URL+"/image.img", t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+
+	data, err := os.ReadFile(localPath)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(data))
+
+	cleanup()
+
+	// the status codes which are not listed are not retried
+	requests.Store(10)
+
+	_, cleanup, err = fileDownload(context.Background(), srv.Client(), sourceFileBlock(3, 503), srv.URL+"/forbidden.img", t.TempDir())
+	require.ErrorContains(t, err, "403")
+	assert.Equal(t, int32(11), requests.Load())
+
+	cleanup()
+}
+
+func Test_fileDownloadRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	response := func(retryAfter string) *http.Response {
+		res := &http.Response{Header: http.Header{}}
+		if retryAfter != "" {
+			res.Header.Set("Retry-After", retryAfter)
+		}
+
+		return res
+	}
+
+	tests := []struct {
+		name    string
+		res     *http.Response
+		attempt int
+		want    time.Duration
+	}{
+		{"no response", nil, 0, time.Second},
+		{"backoff", response(""), 2, 4 * time.Second},
+		{"backoff is limited", response(""), 10, fileDownloadRetryMaxBackoff},
+		{"seconds", response("7"), 0, 7 * time.Second},
+		{"date", response(now.Add(90 * time.Second).Format(http.TimeFormat)), 0, 90 * time.Second},
+		{"date in the past", response(now.Add(-time.Minute).Format(http.TimeFormat)), 0, 0},
+		{"delay is limited", response("86400"), 0, fileDownloadRetryMaxAfter},
+		{"invalid header", response("soon"), 1, 2 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, fileDownloadRetryDelay(tt.res, tt.attempt, now))
+		})
+	}
+}
+
+func Test_fileDownloadHTTPClientConnectTimeout(t *testing.T) {
+	t.Parallel()
+
+	// the listener accepts connections, but never completes the TLS handshake
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer listener.Close()
+
+	go func() {
+		var conns []net.Conn
+
+		for {
+			conn, e := listener.Accept()
+			if e != nil {
+				for _, c := range conns {
+					_ = c.Close()
+				}
+
+				return
+			}
+
+			conns = append(conns, conn)
+		}
+	}()
+
+	httpClient, err := fileDownloadHTTPClient(map[string]interface{}{
+		mkResourceVirtualEnvironmentFileSourceFileConnectTimeout: 1,
+		mkResourceVirtualEnvironmentFileSourceFileInsecure:       false,
+		mkResourceVirtualEnvironmentFileSourceFileMinTLS:         "",
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+listener.Addr().String()+"/image.img", nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+
+	_, err = httpClient.Do(req) //nolint:bodyclose
+	require.ErrorContains(t, err, "TLS handshake timeout")
+	assert.Less(t, time.Since(start), 30*time.Second)
+}
+
+func Test_fileDownloadHTTPClientLocalAddr(t *testing.T) {
+	t.Parallel()
+
+	var remoteAddr string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteAddr = r.RemoteAddr
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	sourceFileBlock := func(localAddr string) map[string]interface{} {
+		return map[string]interface{}{
+			mkResourceVirtualEnvironmentFileSourceFileConnectTimeout: 1,
+			mkResourceVirtualEnvironmentFileSourceFileInsecure:       false,
+			mkResourceVirtualEnvironmentFileSourceFileLocalAddr:      localAddr,
+			mkResourceVirtualEnvironmentFileSourceFileMinTLS:         "",
+		}
+	}
+
+	httpClient, err := fileDownloadHTTPClient(sourceFileBlock("127.0.0.1"))
+	require.NoError(t, err)
+
+	res, err := httpClient.Get(srv.URL)
+	require.NoError(t, err)
+
+	_ = res.Body.Close()
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", host)
+
+	// the server listens on IPv4, so it can't be reached from an IPv6 address
+	httpClient, err = fileDownloadHTTPClient(sourceFileBlock("::1"))
+	require.NoError(t, err)
+
+	_, err = httpClient.Get(srv.URL) //nolint:bodyclose
+	require.Error(t, err)
+
+	_, err = fileDownloadHTTPClient(sourceFileBlock("eth0"))
+	require.ErrorContains(t, err, "invalid local address")
+}
+
+func Test_fileVerifySource(t *testing.T) {
+	t.Parallel()
+
+	localPath := t.TempDir() + "/image.img"
+	require.NoError(t, os.WriteFile(localPath, []byte("foo"), 0o600))
+
+	tests := []struct {
+		name         string
+		checksum     string
+		expectedSize int
+		wantErr      string
+	}{
+		{"no verification", "", 0, ""},
+		{"matching size and checksum", "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae", 3, ""},
+		{"size mismatch", "", 4, "does not match the expected size"},
+		{"checksum mismatch", "0000", 0, "does not match source checksum"},
+		{"sha256sum line", " 2C26B46B68FFC68FF99B453C1D30413413422D706483BFA0F98A5E886266E7AE *image.img\n", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := fileVerifySource(context.Background(), map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSourceFilePath:              "https://example.com/image.img",
+				mkResourceVirtualEnvironmentFileSourceFileChecksum:          tt.checksum,
+				mkResourceVirtualEnvironmentFileSourceFileChecksumAlgorithm: "sha256",
+				mkResourceVirtualEnvironmentFileSourceFileExpectedSize:      tt.expectedSize,
+			}, localPath)
+
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_fileNormalizeChecksum(t *testing.T) {
+	t.Parallel()
+
+	const checksum = "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+	tests := []struct {
+		name     string
+		checksum string
+	}{
+		{"plain", checksum},
+		{"uppercase", "2C26B46B68FFC68FF99B453C1D30413413422D706483BFA0F98A5E886266E7AE"},
+		{"whitespace", "  " + checksum + "\n"},
+		{"sha256sum text mode", checksum + "  image.img"},
+		{"sha256sum binary mode", checksum + " *image.img"},
+		{"bsd tag", "SHA256 (image.img) = " + checksum},
+		{"algorithm prefix", "sha256:" + checksum},
+		{"with size", checksum + " 3 image.img"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, checksum, fileNormalizeChecksum(tt.checksum))
+		})
+	}
+}
+
+func Test_fileVerifySourceDecompressed(t *testing.T) {
+	t.Parallel()
+
+	var compressed bytes.Buffer
+
+	gw := gzip.NewWriter(&compressed)
+	_, err := gw.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	dir := t.TempDir()
+	localPath := dir + "/image.img.gz"
+	require.NoError(t, os.WriteFile(localPath, compressed.Bytes(), 0o600))
+
+	compressedSum := sha256.Sum256(compressed.Bytes())
+
+	tests := []struct {
+		name     string
+		path     string
+		checksum string
+		target   string
+		wantErr  string
+	}{
+		{"compressed", "https://example.com/image.img.gz", hex.EncodeToString(compressedSum[:]), "compressed", ""},
+		{
+			"decompressed", "https://example.com/image.img.gz",
+			"2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae", "decompressed", "",
+		},
+		{
+			"decompressed checksum of the compressed file", "https://example.com/image.img.gz",
+			hex.EncodeToString(compressedSum[:]), "decompressed", "does not match source checksum",
+		},
+		{
+			"unsupported compression", "https://example.com/image.img.xz",
+			"2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae", "decompressed", "are supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := fileVerifySource(context.Background(), map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSourceFilePath:              tt.path,
+				mkResourceVirtualEnvironmentFileSourceFileChecksum:          tt.checksum,
+				mkResourceVirtualEnvironmentFileSourceFileChecksumAlgorithm: "sha256",
+				mkResourceVirtualEnvironmentFileSourceFileChecksumTarget:    tt.target,
+				mkResourceVirtualEnvironmentFileSourceFileExpectedSize:      0,
+			}, localPath)
+
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
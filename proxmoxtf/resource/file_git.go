@@ -0,0 +1,198 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// fileGitSchemes are the prefixes of the source file paths, which refer to a file in a Git repository.
+// The `git+` prefix is stripped off before the repository URL is passed to Git.
+var fileGitSchemes = []string{"git://", "git+https://", "git+http://", "git+ssh://"}
+
+// fileGitSource is a file in a Git repository, e.g.
+// `git+https://github.com/example/snippets.git//cloud-init/user-data.yaml?ref=v1.0.0`.
+type fileGitSource struct {
+	repository string
+	ref        string
+	subpath    string
+}
+
+// fileIsGitSource returns true if the source file path refers to a file in a Git repository.
+func fileIsGitSource(sourceFilePath string) bool {
+	for _, scheme := range fileGitSchemes {
+		if strings.HasPrefix(sourceFilePath, scheme) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fileParseGitSource parses a source file path in the `<scheme>://<repository>//<subpath>[?ref=<ref>]` format,
+// which is the same format as the one used by Terraform for module sources.
+func fileParseGitSource(sourceFilePath string) (fileGitSource, error) {
+	source := fileGitSource{}
+	location := strings.TrimPrefix(sourceFilePath, "git+")
+
+	if i := strings.Index(location, "?"); i >= 0 {
+		query, err := url.ParseQuery(location[i+1:])
+		if err != nil {
+			return source, fmt.Errorf("failed to parse the query of the Git source %q: %w", sourceFilePath, err)
+		}
+
+		for k := range query {
+			if k != "ref" {
+				return source, fmt.Errorf("unsupported parameter %q in the Git source %q", k, sourceFilePath)
+			}
+		}
+
+		source.ref = query.Get("ref")
+		location = location[:i]
+	}
+
+	schemeEnd := strings.Index(location, "://") + len("://")
+
+	i := strings.Index(location[schemeEnd:], "//")
+	if i < 0 {
+		return source, fmt.Errorf(
+			"missing the path of the file in the Git source %q, e.g. \"git+https://example.com/repo.git//path/to/file\"",
+			sourceFilePath,
+		)
+	}
+
+	source.repository = location[:schemeEnd+i]
+	source.subpath = path.Clean(location[schemeEnd+i+2:])
+
+	if source.subpath == "." || source.subpath == ".." || strings.HasPrefix(source.subpath, "../") {
+		return source, fmt.Errorf("invalid path of the file in the Git source %q", sourceFilePath)
+	}
+
+	return source, nil
+}
+
+// fileGitCheckout checks out the file of the Git source into a temporary directory, and returns the path
+// to the file along with a function which removes the directory. Only the requested ref is fetched.
+func fileGitCheckout(ctx context.Context, source fileGitSource, tempDir string) (string, func(), error) {
+	dir, err := os.MkdirTemp(tempDir, "git")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create a temporary directory: %w", err)
+	}
+
+	cleanup := func() {
+		if e := os.RemoveAll(dir); e != nil {
+			tflog.Error(ctx, "Failed to remove temporary directory", map[string]interface{}{
+				"error": e,
+				"dir":   dir,
+			})
+		}
+	}
+
+	ref := source.ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	commands := [][]string{
+		{"init", "--quiet"},
+		{"fetch", "--quiet", "--depth", "1", "--", source.repository, ref},
+		{"checkout", "--quiet", "FETCH_HEAD"},
+	}
+
+	for _, args := range commands {
+		if _, err = fileGitRun(ctx, dir, args...); err != nil {
+			cleanup()
+
+			return "", nil, err
+		}
+	}
+
+	commit, err := fileGitRun(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		cleanup()
+
+		return "", nil, err
+	}
+
+	tflog.Info(ctx, "Checked out the source file from Git", map[string]interface{}{
+		"repository": source.repository,
+		"ref":        ref,
+		"commit":     commit,
+		"path":       source.subpath,
+	})
+
+	localPath := filepath.Join(dir, filepath.FromSlash(source.subpath))
+
+	// symbolic links are not followed, as they may point outside of the repository
+	fileInfo, err := os.Lstat(localPath)
+	if err == nil && !fileInfo.Mode().IsRegular() {
+		err = errors.New("not a regular file")
+	}
+
+	// a directory of the path may be a symbolic link as well, e.g. `a -> /` for the path `a/etc/passwd`
+	if err == nil {
+		err = fileGitCheckContained(dir, localPath)
+	}
+
+	if err != nil {
+		cleanup()
+
+		return "", nil, fmt.Errorf(
+			"failed to find the file %q in the Git repository %q at %q: %w",
+			source.subpath,
+			source.repository,
+			ref,
+			err,
+		)
+	}
+
+	return localPath, cleanup, nil
+}
+
+// fileGitCheckContained checks that the path, with its symbolic links resolved, is inside the directory.
+func fileGitCheckContained(dir string, localPath string) error {
+	resolvedDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+
+	resolvedPath, err := filepath.EvalSymlinks(localPath)
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(resolvedDir, resolvedPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return errors.New("the path leaves the repository through a symbolic link")
+	}
+
+	return nil
+}
+
+// fileGitRun runs a Git command in the given directory, and returns its trimmed output.
+func fileGitRun(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	// fail instead of waiting for credentials, which can't be entered anyway
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,128 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_fileParseGitSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		path    string
+		want    fileGitSource
+		wantErr bool
+	}{
+		{
+			"https",
+			"git+https://github.com/example/snippets.git//cloud-init/user-data.yaml?ref=v1.0.0",
+			fileGitSource{"https://github.com/example/snippets.git", "v1.0.0", "cloud-init/user-data.yaml"},
+			false,
+		},
+		{
+			"git without ref",
+			"git://example.com/snippets.git//hook.sh",
+			fileGitSource{"git://example.com/snippets.git", "", "hook.sh"},
+			false,
+		},
+		{
+			"ssh with commit",
+			"git+ssh://git@example.com/org/snippets.git//a/b.yaml?ref=0123abc",
+			fileGitSource{"ssh://git@example.com/org/snippets.git", "0123abc", "a/b.yaml"},
+			false,
+		},
+		{"missing subpath", "git+https://github.com/example/snippets.git", fileGitSource{}, true},
+		{"empty subpath", "git+https://github.com/example/snippets.git//", fileGitSource{}, true},
+		{"subpath outside of repository", "git+https://example.com/repo.git//../etc/passwd", fileGitSource{}, true},
+		{"unsupported parameter", "git+https://example.com/repo.git//a.yaml?depth=1", fileGitSource{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.True(t, fileIsGitSource(tt.path))
+
+			got, err := fileParseGitSource(tt.path)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_fileGitCheckout(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	ctx := context.Background()
+	repo := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "snippets"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "snippets", "user-data.yaml"), []byte("#cloud-config\n"), 0o600))
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "init"},
+		{"tag", "v1"},
+	} {
+		_, err := fileGitRun(ctx, repo, args...)
+		require.NoError(t, err)
+	}
+
+	localPath, cleanup, err := fileGitCheckout(ctx, fileGitSource{repo, "v1", "snippets/user-data.yaml"}, t.TempDir())
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(localPath)
+	require.NoError(t, err)
+	assert.Equal(t, "#cloud-config\n", string(data))
+
+	cleanup()
+	assert.NoFileExists(t, localPath)
+
+	_, _, err = fileGitCheckout(ctx, fileGitSource{repo, "v1", "snippets"}, t.TempDir())
+	require.Error(t, err)
+
+	// a directory linking outside of the checkout must not expose the files of the machine running Terraform
+	outside := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "secret"), []byte("secret\n"), 0o600))
+	require.NoError(t, os.Symlink(outside, filepath.Join(repo, "escape")))
+	require.NoError(t, os.Symlink("snippets", filepath.Join(repo, "inside")))
+
+	for _, args := range [][]string{
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "links"},
+		{"tag", "v2"},
+	} {
+		_, err = fileGitRun(ctx, repo, args...)
+		require.NoError(t, err)
+	}
+
+	_, _, err = fileGitCheckout(ctx, fileGitSource{repo, "v2", "escape/secret"}, t.TempDir())
+	require.ErrorContains(t, err, "leaves the repository")
+
+	localPath, cleanup, err = fileGitCheckout(ctx, fileGitSource{repo, "v2", "inside/user-data.yaml"}, t.TempDir())
+	require.NoError(t, err)
+	cleanup()
+}
@@ -0,0 +1,112 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	fileISOSectorSize = 2048
+
+	// fileISOFirstDescriptorSector is the sector of the first volume descriptor, following the system area.
+	fileISOFirstDescriptorSector = 16
+
+	// fileISOMaxDescriptors limits the number of volume descriptors read, in case the set is not terminated.
+	fileISOMaxDescriptors = 64
+
+	fileISODescriptorTypeBootRecord = 0
+	fileISODescriptorTypeTerminator = 255
+
+	fileISOBootIndicatorBootable = 0x88
+)
+
+var (
+	fileISOStandardIdentifier = []byte("CD001")
+	fileISOElToritoIdentifier = []byte("EL TORITO SPECIFICATION")
+)
+
+// fileISOBootable reports whether the ISO 9660 image at the path has a valid El Torito boot catalog,
+// with a bootable initial entry. It returns a reason when the image appears to be non-bootable.
+func fileISOBootable(path string) (bool, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to open the ISO image: %w", err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	sector := make([]byte, fileISOSectorSize)
+	catalogSector := int64(-1)
+
+	for i := int64(0); i < fileISOMaxDescriptors; i++ {
+		_, err = f.ReadAt(sector, (fileISOFirstDescriptorSector+i)*fileISOSectorSize)
+		if errors.Is(err, io.EOF) {
+			return false, "the image is too short to contain an ISO 9660 file system", nil
+		}
+
+		if err != nil {
+			return false, "", fmt.Errorf("failed to read the volume descriptors: %w", err)
+		}
+
+		if !bytes.Equal(sector[1:6], fileISOStandardIdentifier) {
+			return false, "the image is not an ISO 9660 file system", nil
+		}
+
+		if sector[0] == fileISODescriptorTypeTerminator {
+			break
+		}
+
+		// the boot system identifier is padded with zeros to 32 bytes
+		if sector[0] == fileISODescriptorTypeBootRecord &&
+			bytes.Equal(bytes.TrimRight(sector[7:39], "\x00"), fileISOElToritoIdentifier) {
+			catalogSector = int64(binary.LittleEndian.Uint32(sector[0x47:0x4B]))
+
+			break
+		}
+	}
+
+	if catalogSector < 0 {
+		return false, "the image does not have an El Torito boot record", nil
+	}
+
+	_, err = f.ReadAt(sector[:64], catalogSector*fileISOSectorSize)
+	if errors.Is(err, io.EOF) {
+		return false, "the boot catalog is beyond the end of the image", nil
+	}
+
+	if err != nil {
+		return false, "", fmt.Errorf("failed to read the boot catalog at sector %d: %w", catalogSector, err)
+	}
+
+	// the validation entry is followed by the initial (default) entry
+	validation := sector[:32]
+
+	if validation[0] != 0x01 || validation[30] != 0x55 || validation[31] != 0xAA {
+		return false, "the boot catalog does not start with a validation entry", nil
+	}
+
+	var sum uint16
+	for i := 0; i < len(validation); i += 2 {
+		sum += binary.LittleEndian.Uint16(validation[i:])
+	}
+
+	if sum != 0 {
+		return false, "the checksum of the boot catalog validation entry is invalid", nil
+	}
+
+	if sector[32] != fileISOBootIndicatorBootable {
+		return false, "the initial entry of the boot catalog is not bootable", nil
+	}
+
+	return true, "", nil
+}
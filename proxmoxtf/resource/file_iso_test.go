@@ -0,0 +1,125 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testISOImage builds a minimal ISO 9660 image with the primary volume descriptor, an optional El Torito
+// boot record pointing to the boot catalog in sector 20, and the volume descriptor set terminator.
+func testISOImage(t *testing.T, elTorito bool, modify func(catalog []byte)) string {
+	t.Helper()
+
+	image := make([]byte, 21*fileISOSectorSize)
+
+	descriptor := func(sector int, descriptorType byte) []byte {
+		d := image[sector*fileISOSectorSize : (sector+1)*fileISOSectorSize]
+		d[0] = descriptorType
+		copy(d[1:6], fileISOStandardIdentifier)
+		d[6] = 1
+
+		return d
+	}
+
+	descriptor(16, 1)
+
+	terminator := 17
+
+	if elTorito {
+		boot := descriptor(17, fileISODescriptorTypeBootRecord)
+		copy(boot[7:], fileISOElToritoIdentifier)
+		binary.LittleEndian.PutUint32(boot[0x47:], 20)
+
+		terminator++
+	}
+
+	descriptor(terminator, fileISODescriptorTypeTerminator)
+
+	catalog := image[20*fileISOSectorSize:]
+	catalog[0] = 0x01
+	catalog[30] = 0x55
+	catalog[31] = 0xAA
+
+	var sum uint16
+	for i := 0; i < 32; i += 2 {
+		sum += binary.LittleEndian.Uint16(catalog[i:])
+	}
+
+	binary.LittleEndian.PutUint16(catalog[28:], -sum)
+
+	catalog[32] = fileISOBootIndicatorBootable
+
+	if modify != nil {
+		modify(catalog)
+	}
+
+	path := filepath.Join(t.TempDir(), "test.iso")
+	require.NoError(t, os.WriteFile(path, image, 0o600))
+
+	return path
+}
+
+func Test_fileISOBootable(t *testing.T) {
+	t.Parallel()
+
+	notISO := filepath.Join(t.TempDir(), "data.iso")
+	require.NoError(t, os.WriteFile(notISO, make([]byte, 20*fileISOSectorSize), 0o600))
+
+	short := filepath.Join(t.TempDir(), "short.iso")
+	require.NoError(t, os.WriteFile(short, []byte("truncated"), 0o600))
+
+	tests := []struct {
+		name       string
+		path       string
+		want       bool
+		wantReason string
+	}{
+		{"bootable", testISOImage(t, true, nil), true, ""},
+		{"no boot record", testISOImage(t, false, nil), false, "does not have an El Torito boot record"},
+		{
+			"invalid checksum",
+			testISOImage(t, true, func(catalog []byte) { catalog[4] = 0x42 }),
+			false,
+			"checksum of the boot catalog validation entry is invalid",
+		},
+		{
+			"missing key bytes",
+			testISOImage(t, true, func(catalog []byte) { catalog[31] = 0 }),
+			false,
+			"does not start with a validation entry",
+		},
+		{
+			"initial entry not bootable",
+			testISOImage(t, true, func(catalog []byte) { catalog[32] = 0 }),
+			false,
+			"initial entry of the boot catalog is not bootable",
+		},
+		{"not ISO 9660", notISO, false, "not an ISO 9660 file system"},
+		{"truncated", short, false, "too short"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			bootable, reason, err := fileISOBootable(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, bootable)
+			assert.Contains(t, reason, tt.wantReason)
+		})
+	}
+
+	_, _, err := fileISOBootable(filepath.Join(t.TempDir(), "missing.iso"))
+	require.Error(t, err)
+}
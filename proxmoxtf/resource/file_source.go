@@ -0,0 +1,493 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bpg/terraform-provider-proxmox/utils"
+)
+
+const (
+	fileGCSDownloadURLFormat = "https://storage.googleapis.com/storage/v1/b/%s/o/%s?alt=media"
+	fileGCSReadOnlyScope     = "https://www.googleapis.com/auth/devstorage.read_only"
+	fileGCSDefaultTokenURI   = "https://oauth2.googleapis.com/token"
+)
+
+// fileSourceBlock returns the nested block with the given key of the source file block, or nil if not set.
+func fileSourceBlock(sourceFileBlock map[string]interface{}, key string) map[string]interface{} {
+	list, ok := sourceFileBlock[key].([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return nil
+	}
+
+	return list[0].(map[string]interface{})
+}
+
+// fileSourceLocation returns the location of the source file, i.e. a local path or a URL to download the file from.
+func fileSourceLocation(sourceFileBlock map[string]interface{}) string {
+	if azureBlob := fileSourceBlock(sourceFileBlock, mkResourceVirtualEnvironmentFileSourceFileAzureBlob); azureBlob != nil {
+		return azureBlob[mkResourceVirtualEnvironmentFileSourceFileAzureBlobSASURL].(string)
+	}
+
+	if gcs := fileSourceBlock(sourceFileBlock, mkResourceVirtualEnvironmentFileSourceFileGCS); gcs != nil {
+		return fmt.Sprintf(
+			fileGCSDownloadURLFormat,
+			url.PathEscape(gcs[mkResourceVirtualEnvironmentFileSourceFileGCSBucket].(string)),
+			url.PathEscape(gcs[mkResourceVirtualEnvironmentFileSourceFileGCSObject].(string)),
+		)
+	}
+
+	return sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFilePath].(string)
+}
+
+// fileSourceName returns the name of the source file, which is used to determine the file name and content type.
+func fileSourceName(sourceFileBlock map[string]interface{}) string {
+	if azureBlob := fileSourceBlock(sourceFileBlock, mkResourceVirtualEnvironmentFileSourceFileAzureBlob); azureBlob != nil {
+		sasURL, err := url.Parse(azureBlob[mkResourceVirtualEnvironmentFileSourceFileAzureBlobSASURL].(string))
+		if err != nil {
+			return ""
+		}
+
+		return path.Base(sasURL.Path)
+	}
+
+	if gcs := fileSourceBlock(sourceFileBlock, mkResourceVirtualEnvironmentFileSourceFileGCS); gcs != nil {
+		return path.Base(gcs[mkResourceVirtualEnvironmentFileSourceFileGCSObject].(string))
+	}
+
+	sourceFilePath := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFilePath].(string)
+	if fileIsGitSource(sourceFilePath) {
+		gitSource, err := fileParseGitSource(sourceFilePath)
+		if err != nil {
+			return ""
+		}
+
+		return gitSource.subpath
+	}
+
+	// the directory is uploaded as an archive, which is named after it
+	if archive, _ := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileArchive].(string); archive != "" {
+		return filepath.Clean(sourceFilePath) + "." + archive
+	}
+
+	return sourceFilePath
+}
+
+// fileSourceIsObjectStorage returns true if the source file is stored in an object storage.
+func fileSourceIsObjectStorage(sourceFileBlock map[string]interface{}) bool {
+	return fileSourceBlock(sourceFileBlock, mkResourceVirtualEnvironmentFileSourceFileAzureBlob) != nil ||
+		fileSourceBlock(sourceFileBlock, mkResourceVirtualEnvironmentFileSourceFileGCS) != nil
+}
+
+// fileSourceMirrorURLs returns the URLs of the mirrors of the source file.
+func fileSourceMirrorURLs(sourceFileBlock map[string]interface{}) []string {
+	list, _ := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileMirrorURLs].([]interface{})
+	mirrorURLs := make([]string, 0, len(list))
+
+	for _, v := range list {
+		if mirrorURL, ok := v.(string); ok && mirrorURL != "" {
+			mirrorURLs = append(mirrorURLs, mirrorURL)
+		}
+	}
+
+	return mirrorURLs
+}
+
+// fileSourceValidate ensures that exactly one source of the source file block is specified,
+// and that mirrors are only specified for a path URL.
+func fileSourceValidate(sourceFileBlock map[string]interface{}) error {
+	count := 0
+
+	if sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFilePath].(string) != "" {
+		count++
+	}
+
+	if fileSourceBlock(sourceFileBlock, mkResourceVirtualEnvironmentFileSourceFileAzureBlob) != nil {
+		count++
+	}
+
+	if fileSourceBlock(sourceFileBlock, mkResourceVirtualEnvironmentFileSourceFileGCS) != nil {
+		count++
+	}
+
+	if count != 1 {
+		return fmt.Errorf(
+			"please specify exactly one of \"%s.%s\", \"%s.%s\" or \"%s.%s\"",
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFilePath,
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFileAzureBlob,
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFileGCS,
+		)
+	}
+
+	sourceFilePath := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFilePath].(string)
+
+	if fileIsGitSource(sourceFilePath) {
+		if _, err := fileParseGitSource(sourceFilePath); err != nil {
+			return err
+		}
+	}
+
+	sourceFileIsURL := strings.HasPrefix(sourceFilePath, "http://") || strings.HasPrefix(sourceFilePath, "https://")
+
+	archive, _ := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileArchive].(string)
+	if archive != "" && (sourceFilePath == "" || sourceFileIsURL || fileIsGitSource(sourceFilePath)) {
+		return fmt.Errorf(
+			"\"%s.%s\" can only be specified if \"%s.%s\" is a local directory",
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFileArchive,
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFilePath,
+		)
+	}
+
+	sourceLocation := fileSourceLocation(sourceFileBlock)
+
+	expectedETag, _ := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileExpectedETag].(string)
+	if expectedETag != "" && !strings.HasPrefix(sourceLocation, "http://") && !strings.HasPrefix(sourceLocation, "https://") {
+		return fmt.Errorf(
+			"\"%s.%s\" can only be specified if the source file is a URL",
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFileExpectedETag,
+		)
+	}
+
+	serverSideDownload, _ := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileServerSideDownload].(bool)
+
+	// the node downloads the file through its own interfaces
+	if localAddr, _ := sourceFileBlock[mkResourceVirtualEnvironmentFileSourceFileLocalAddr].(string); localAddr != "" &&
+		serverSideDownload {
+		return fmt.Errorf(
+			"\"%s.%s\" is not supported with \"%s.%s\"",
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFileLocalAddr,
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFileServerSideDownload,
+		)
+	}
+
+	if fileSourceBlock(sourceFileBlock, mkResourceVirtualEnvironmentFileSourceFileOAuth2) != nil {
+		if !sourceFileIsURL {
+			return fmt.Errorf(
+				"\"%s.%s\" can only be specified if \"%s.%s\" is a URL",
+				mkResourceVirtualEnvironmentFileSourceFile,
+				mkResourceVirtualEnvironmentFileSourceFileOAuth2,
+				mkResourceVirtualEnvironmentFileSourceFile,
+				mkResourceVirtualEnvironmentFileSourceFilePath,
+			)
+		}
+
+		// the node downloads the file by itself, without the access token
+		if serverSideDownload {
+			return fmt.Errorf(
+				"\"%s.%s\" is not supported with \"%s.%s\"",
+				mkResourceVirtualEnvironmentFileSourceFile,
+				mkResourceVirtualEnvironmentFileSourceFileOAuth2,
+				mkResourceVirtualEnvironmentFileSourceFile,
+				mkResourceVirtualEnvironmentFileSourceFileServerSideDownload,
+			)
+		}
+	}
+
+	if len(fileSourceMirrorURLs(sourceFileBlock)) == 0 {
+		return nil
+	}
+
+	if !sourceFileIsURL {
+		return fmt.Errorf(
+			"\"%s.%s\" can only be specified if \"%s.%s\" is a URL",
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFileMirrorURLs,
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFilePath,
+		)
+	}
+
+	if serverSideDownload {
+		return fmt.Errorf(
+			"\"%s.%s\" is not supported with \"%s.%s\"",
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFileMirrorURLs,
+			mkResourceVirtualEnvironmentFileSourceFile,
+			mkResourceVirtualEnvironmentFileSourceFileServerSideDownload,
+		)
+	}
+
+	return nil
+}
+
+// fileSourceRawData returns the decoded data of the raw source block, resized to the requested number of bytes.
+// The data is cleaned up as requested before it's resized, so the padding is not affected by the clean up.
+// Plain data is padded with spaces, while base64 encoded (i.e. binary) data is padded with zero bytes.
+func fileSourceRawData(sourceRawBlock map[string]interface{}) ([]byte, error) {
+	data := []byte(sourceRawBlock[mkResourceVirtualEnvironmentFileSourceRawData].(string))
+	resize := sourceRawBlock[mkResourceVirtualEnvironmentFileSourceRawResize].(int)
+	resizeFill, _ := sourceRawBlock[mkResourceVirtualEnvironmentFileSourceRawResizeFill].(string)
+	padding := byte(' ')
+
+	if sourceRawBlock[mkResourceVirtualEnvironmentFileSourceRawEncoding] == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(string(data))
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to decode \"%s.%s\" as base64: %w",
+				mkResourceVirtualEnvironmentFileSourceRaw,
+				mkResourceVirtualEnvironmentFileSourceRawData,
+				err,
+			)
+		}
+
+		data = decoded
+		padding = 0
+	}
+
+	if sourceRawBlock[mkResourceVirtualEnvironmentFileSourceRawStripBOM] == true {
+		data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	}
+
+	if sourceRawBlock[mkResourceVirtualEnvironmentFileSourceRawNormalizeNewlines] == true {
+		data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+		data = bytes.ReplaceAll(data, []byte("\r"), []byte("\n"))
+	}
+
+	// the newline is added before resizing, so that the padding accounts for it
+	if sourceRawBlock[mkResourceVirtualEnvironmentFileSourceRawEnsureNewline] == true &&
+		len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+
+	switch resizeFill {
+	case "space":
+		padding = ' '
+	case "null":
+		padding = 0
+	}
+
+	if resize > 0 {
+		if len(data) > resize {
+			return nil, fmt.Errorf("cannot resize %d bytes to %d bytes", len(data), resize)
+		}
+
+		data = append(data, bytes.Repeat([]byte{padding}, resize-len(data))...)
+	}
+
+	return data, nil
+}
+
+// fileSourceAuthorizer returns a function that adds the credentials of the source to a request.
+// Azure Blob Storage SAS URLs carry their own credentials, so only GCS and OAuth2 protected URLs
+// require an access token. A new token is requested for each request, so it never expires in between.
+func fileSourceAuthorizer(
+	httpClient *http.Client,
+	sourceFileBlock map[string]interface{},
+) func(ctx context.Context, req *http.Request) error {
+	if oauth2 := fileSourceBlock(sourceFileBlock, mkResourceVirtualEnvironmentFileSourceFileOAuth2); oauth2 != nil {
+		return func(ctx context.Context, req *http.Request) error {
+			token, err := oauth2AccessToken(ctx, httpClient, oauth2)
+			if err != nil {
+				return err
+			}
+
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			return nil
+		}
+	}
+
+	gcs := fileSourceBlock(sourceFileBlock, mkResourceVirtualEnvironmentFileSourceFileGCS)
+	if gcs == nil {
+		return nil
+	}
+
+	credentials := gcs[mkResourceVirtualEnvironmentFileSourceFileGCSCredentials].(string)
+	if credentials == "" {
+		// public objects can be downloaded anonymously
+		return nil
+	}
+
+	return func(ctx context.Context, req *http.Request) error {
+		token, err := gcsAccessToken(ctx, httpClient, credentials)
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		return nil
+	}
+}
+
+type gcsServiceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// gcsAccessToken exchanges a service account key for an OAuth2 access token using the JWT bearer flow.
+func gcsAccessToken(ctx context.Context, httpClient *http.Client, credentials string) (string, error) {
+	var sa gcsServiceAccount
+
+	if err := json.Unmarshal([]byte(credentials), &sa); err != nil {
+		return "", fmt.Errorf("failed to parse GCS service account credentials: %w", err)
+	}
+
+	if sa.ClientEmail == "" || sa.PrivateKey == "" {
+		return "", errors.New("GCS service account credentials must contain 'client_email' and 'private_key'")
+	}
+
+	if sa.TokenURI == "" {
+		sa.TokenURI = fileGCSDefaultTokenURI
+	}
+
+	assertion, err := gcsSignedJWT(sa, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sa.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create GCS token request: %w", err)
+	}
+
+	return fileAccessToken(ctx, httpClient, req, "GCS")
+}
+
+// oauth2AccessToken requests an access token from the token endpoint of the OAuth2 block, using the
+// client credentials grant. The client authenticates with HTTP basic authentication, as recommended by RFC 6749.
+func oauth2AccessToken(ctx context.Context, httpClient *http.Client, oauth2 map[string]interface{}) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+
+	scopes, _ := oauth2[mkResourceVirtualEnvironmentFileSourceFileOAuth2Scopes].([]interface{})
+	scopeValues := make([]string, 0, len(scopes))
+
+	for _, v := range scopes {
+		if scope, ok := v.(string); ok && scope != "" {
+			scopeValues = append(scopeValues, scope)
+		}
+	}
+
+	if len(scopeValues) > 0 {
+		form.Set("scope", strings.Join(scopeValues, " "))
+	}
+
+	tokenURL := oauth2[mkResourceVirtualEnvironmentFileSourceFileOAuth2TokenURL].(string)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create OAuth2 token request: %w", err)
+	}
+
+	req.SetBasicAuth(
+		url.QueryEscape(oauth2[mkResourceVirtualEnvironmentFileSourceFileOAuth2ClientID].(string)),
+		url.QueryEscape(oauth2[mkResourceVirtualEnvironmentFileSourceFileOAuth2ClientSecret].(string)),
+	)
+
+	return fileAccessToken(ctx, httpClient, req, "OAuth2")
+}
+
+// fileAccessToken sends the token request, and returns the access token from the response.
+// The issuer names the token service in the errors.
+func fileAccessToken(ctx context.Context, httpClient *http.Client, req *http.Request, issuer string) (string, error) {
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request %s access token: %w", issuer, err)
+	}
+
+	defer utils.CloseOrLogError(ctx)(res.Body)
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to request %s access token: %s", issuer, res.Status)
+	}
+
+	var tokenRes struct {
+		AccessToken string `json:"access_token"`
+	}
+
+	if err = json.NewDecoder(res.Body).Decode(&tokenRes); err != nil {
+		return "", fmt.Errorf("failed to decode %s access token response: %w", issuer, err)
+	}
+
+	if tokenRes.AccessToken == "" {
+		return "", fmt.Errorf("%s token response does not contain an access token", issuer)
+	}
+
+	return tokenRes.AccessToken, nil
+}
+
+// gcsSignedJWT creates the RS256 signed JWT assertion for the service account.
+func gcsSignedJWT(sa gcsServiceAccount, now time.Time) (string, error) {
+	block, _ := pem.Decode([]byte(sa.PrivateKey))
+	if block == nil {
+		return "", errors.New("failed to decode GCS service account private key")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse GCS service account private key: %w", err)
+		}
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return "", errors.New("GCS service account private key is not an RSA key")
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JWT header: %w", err)
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   sa.ClientEmail,
+		"scope": fileGCSReadOnlyScope,
+		"aud":   sa.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JWT claims: %w", err)
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT: %w", err)
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
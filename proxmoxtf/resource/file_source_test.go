@@ -0,0 +1,300 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_fileSourceLocation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		block    map[string]interface{}
+		location string
+		fileName string
+		wantErr  bool
+	}{
+		{
+			"path",
+			map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSourceFilePath: "/tmp/file.iso",
+			},
+			"/tmp/file.iso",
+			"/tmp/file.iso",
+			false,
+		},
+		{
+			"azure blob",
+			map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSourceFilePath: "",
+				mkResourceVirtualEnvironmentFileSourceFileAzureBlob: []interface{}{
+					map[string]interface{}{
+						mkResourceVirtualEnvironmentFileSourceFileAzureBlobSASURL: "https://acc.blob.core.windows.net/c/dir/file.iso?sig=x",
+					},
+				},
+			},
+			"https://acc.blob.core.windows.net/c/dir/file.iso?sig=x",
+			"file.iso",
+			false,
+		},
+		{
+			"gcs",
+			map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSourceFilePath: "",
+				mkResourceVirtualEnvironmentFileSourceFileGCS: []interface{}{
+					map[string]interface{}{
+						mkResourceVirtualEnvironmentFileSourceFileGCSBucket: "bucket",
+						mkResourceVirtualEnvironmentFileSourceFileGCSObject: "dir/file.qcow2",
+					},
+				},
+			},
+			"https://storage.googleapis.com/storage/v1/b/bucket/o/dir%2Ffile.qcow2?alt=media",
+			"file.qcow2",
+			false,
+		},
+		{
+			"git",
+			map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSourceFilePath: "git+https://example.com/repo.git//snippets/user-data.yaml?ref=v1",
+			},
+			"git+https://example.com/repo.git//snippets/user-data.yaml?ref=v1",
+			"snippets/user-data.yaml",
+			false,
+		},
+		{
+			"git without file",
+			map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSourceFilePath: "git+https://example.com/repo.git?ref=v1",
+			},
+			"",
+			"",
+			true,
+		},
+		{
+			"none",
+			map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSourceFilePath: "",
+			},
+			"",
+			"",
+			true,
+		},
+		{
+			"archived directory",
+			map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSourceFilePath:    "/tmp/template/",
+				mkResourceVirtualEnvironmentFileSourceFileArchive: "tar.zst",
+			},
+			"/tmp/template/",
+			"/tmp/template.tar.zst",
+			false,
+		},
+		{
+			"archived url",
+			map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSourceFilePath:    "https://example.com/template",
+				mkResourceVirtualEnvironmentFileSourceFileArchive: "tar.gz",
+			},
+			"",
+			"",
+			true,
+		},
+		{
+			"url with etag",
+			map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSourceFilePath:         "https://example.com/file.iso",
+				mkResourceVirtualEnvironmentFileSourceFileExpectedETag: `"abc"`,
+			},
+			"https://example.com/file.iso",
+			"https://example.com/file.iso",
+			false,
+		},
+		{
+			"local file with etag",
+			map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSourceFilePath:         "/tmp/file.iso",
+				mkResourceVirtualEnvironmentFileSourceFileExpectedETag: `"abc"`,
+			},
+			"",
+			"",
+			true,
+		},
+		{
+			"path and gcs",
+			map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSourceFilePath: "/tmp/file.iso",
+				mkResourceVirtualEnvironmentFileSourceFileGCS: []interface{}{
+					map[string]interface{}{
+						mkResourceVirtualEnvironmentFileSourceFileGCSBucket: "bucket",
+						mkResourceVirtualEnvironmentFileSourceFileGCSObject: "file.iso",
+					},
+				},
+			},
+			"",
+			"",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := fileSourceValidate(tt.block)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.location, fileSourceLocation(tt.block))
+			assert.Equal(t, tt.fileName, fileSourceName(tt.block))
+		})
+	}
+}
+
+func Test_fileSourceRawData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		data              string
+		encoding          string
+		normalizeNewlines bool
+		stripBOM          bool
+		ensureNewline     bool
+		resize            int
+		resizeFill        string
+		want              []byte
+		wantErr           bool
+	}{
+		{"plain", "foo", "plain", false, false, false, 0, "", []byte("foo"), false},
+		{"plain resized", "foo", "plain", false, false, false, 5, "", []byte("foo  "), false},
+		{"base64", "AAH/", "base64", false, false, false, 0, "", []byte{0x00, 0x01, 0xff}, false},
+		{"base64 resized", "AAH/", "base64", false, false, false, 5, "", []byte{0x00, 0x01, 0xff, 0x00, 0x00}, false},
+		{"base64 too large", "AAH/", "base64", false, false, false, 2, "", nil, true},
+		{"invalid base64", "not base64!", "base64", false, false, false, 0, "", nil, true},
+		{"bom kept", "\ufefffoo\r\n", "plain", false, false, false, 0, "", []byte("\xef\xbb\xbffoo\r\n"), false},
+		{"bom stripped", "\ufefffoo: bar\n", "plain", false, true, false, 0, "", []byte("foo: bar\n"), false},
+		{"newlines normalized", "a\r\nb\rc\n", "plain", true, false, false, 0, "", []byte("a\nb\nc\n"), false},
+		{"base64 cleaned up", "77u/YQ0KYg0K", "base64", true, true, false, 0, "", []byte("a\nb\n"), false},
+		{"plain resized with spaces", "foo", "plain", false, false, false, 5, "space", []byte("foo  "), false},
+		{"plain resized with nulls", "foo", "plain", false, false, false, 5, "null", []byte("foo\x00\x00"), false},
+		{"base64 resized with spaces", "AAH/", "base64", false, false, false, 4, "space", []byte{0x00, 0x01, 0xff, ' '}, false},
+		{"cleaned up before resize", "\ufeffa\r\n", "plain", true, true, false, 4, "", []byte("a\n  "), false},
+		{"newline appended", "foo", "plain", false, false, true, 0, "", []byte("foo\n"), false},
+		{"newline kept", "foo\n", "plain", false, false, true, 0, "", []byte("foo\n"), false},
+		{"newline not appended to empty data", "", "plain", false, false, true, 0, "", []byte(""), false},
+		{"newline appended after normalizing", "a\r", "plain", true, false, true, 0, "", []byte("a\n"), false},
+		{"newline appended before resize", "foo", "plain", false, false, true, 5, "", []byte("foo\n "), false},
+		{"newline too large to resize", "foo", "plain", false, false, true, 3, "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := fileSourceRawData(map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSourceRawData:              tt.data,
+				mkResourceVirtualEnvironmentFileSourceRawEncoding:          tt.encoding,
+				mkResourceVirtualEnvironmentFileSourceRawEnsureNewline:     tt.ensureNewline,
+				mkResourceVirtualEnvironmentFileSourceRawNormalizeNewlines: tt.normalizeNewlines,
+				mkResourceVirtualEnvironmentFileSourceRawResize:            tt.resize,
+				mkResourceVirtualEnvironmentFileSourceRawResizeFill:        tt.resizeFill,
+				mkResourceVirtualEnvironmentFileSourceRawStripBOM:          tt.stripBOM,
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, data)
+		})
+	}
+}
+
+func Test_gcsAccessToken(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+		assert.NotEmpty(t, r.PostForm.Get("assertion"))
+
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	credentials, err := json.Marshal(gcsServiceAccount{
+		ClientEmail: "test@example.iam.gserviceaccount.com",
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})),
+		TokenURI:    srv.URL,
+	})
+	require.NoError(t, err)
+
+	token, err := gcsAccessToken(context.Background(), srv.Client(), string(credentials))
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+
+	_, err = gcsAccessToken(context.Background(), srv.Client(), `{"client_email":"test"}`)
+	require.Error(t, err)
+}
+
+func Test_oauth2AccessToken(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "client" || clientSecret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "read:artifacts openid", r.PostForm.Get("scope"))
+
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":300}`))
+	}))
+	defer srv.Close()
+
+	oauth2 := map[string]interface{}{
+		mkResourceVirtualEnvironmentFileSourceFileOAuth2ClientID:     "client",
+		mkResourceVirtualEnvironmentFileSourceFileOAuth2ClientSecret: "s3cret",
+		mkResourceVirtualEnvironmentFileSourceFileOAuth2Scopes:       []interface{}{"read:artifacts", "openid"},
+		mkResourceVirtualEnvironmentFileSourceFileOAuth2TokenURL:     srv.URL,
+	}
+
+	token, err := oauth2AccessToken(context.Background(), srv.Client(), oauth2)
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+
+	oauth2[mkResourceVirtualEnvironmentFileSourceFileOAuth2ClientSecret] = "wrong"
+
+	_, err = oauth2AccessToken(context.Background(), srv.Client(), oauth2)
+	require.ErrorContains(t, err, "401")
+}
@@ -7,11 +7,16 @@
 package resource
 
 import (
+	"context"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/storage"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/test"
 )
 
@@ -33,57 +38,159 @@ func TestFileSchema(t *testing.T) {
 
 	test.AssertRequiredArguments(t, s, []string{
 		mkResourceVirtualEnvironmentFileDatastoreID,
-		mkResourceVirtualEnvironmentFileNodeName,
 	})
 
 	test.AssertOptionalArguments(t, s, []string{
+		mkResourceVirtualEnvironmentFileCheckInUse,
+		mkResourceVirtualEnvironmentFileCheckSpace,
 		mkResourceVirtualEnvironmentFileContentType,
+		mkResourceVirtualEnvironmentFileEnabled,
 		mkResourceVirtualEnvironmentFileSourceFile,
 		mkResourceVirtualEnvironmentFileFileMode,
+		mkResourceVirtualEnvironmentFileForceContentTypeDir,
+		mkResourceVirtualEnvironmentFileMIMEType,
+		mkResourceVirtualEnvironmentFileNodeName,
+		mkResourceVirtualEnvironmentFileOverwrite,
+		mkResourceVirtualEnvironmentFileOverwriteContentTypes,
+		mkResourceVirtualEnvironmentFileOverwriteUnmanaged,
+		mkResourceVirtualEnvironmentFilePostUploadCommand,
+		mkResourceVirtualEnvironmentFileReadBackContent,
+		mkResourceVirtualEnvironmentFileSanitizeFilename,
 		mkResourceVirtualEnvironmentFileSourceRaw,
 		mkResourceVirtualEnvironmentFileTimeoutUpload,
+		mkResourceVirtualEnvironmentFileTmpDir,
+		mkResourceVirtualEnvironmentFileUploadMode,
+		mkResourceVirtualEnvironmentFileValidateBootable,
 	})
 
 	test.AssertComputedAttributes(t, s, []string{
+		mkResourceVirtualEnvironmentFileBytesUploaded,
+		mkResourceVirtualEnvironmentFileChecksums,
+		mkResourceVirtualEnvironmentFileDatastoreContentTypes,
 		mkResourceVirtualEnvironmentFileFileModificationDate,
 		mkResourceVirtualEnvironmentFileFileName,
 		mkResourceVirtualEnvironmentFileFileSize,
 		mkResourceVirtualEnvironmentFileFileTag,
+		mkResourceVirtualEnvironmentFileImportSource,
+		mkResourceVirtualEnvironmentFileOverwritten,
+		mkResourceVirtualEnvironmentFileStoredContent,
+		mkResourceVirtualEnvironmentFileUploadedChecksum,
 	})
 
 	test.AssertValueTypes(t, s, map[string]schema.ValueType{
-		mkResourceVirtualEnvironmentFileContentType:          schema.TypeString,
-		mkResourceVirtualEnvironmentFileDatastoreID:          schema.TypeString,
-		mkResourceVirtualEnvironmentFileFileModificationDate: schema.TypeString,
-		mkResourceVirtualEnvironmentFileFileName:             schema.TypeString,
-		mkResourceVirtualEnvironmentFileFileMode:             schema.TypeString,
-		mkResourceVirtualEnvironmentFileFileSize:             schema.TypeInt,
-		mkResourceVirtualEnvironmentFileFileTag:              schema.TypeString,
-		mkResourceVirtualEnvironmentFileNodeName:             schema.TypeString,
-		mkResourceVirtualEnvironmentFileSourceFile:           schema.TypeList,
-		mkResourceVirtualEnvironmentFileSourceRaw:            schema.TypeList,
-		mkResourceVirtualEnvironmentFileTimeoutUpload:        schema.TypeInt,
+		mkResourceVirtualEnvironmentFileBytesUploaded:         schema.TypeInt,
+		mkResourceVirtualEnvironmentFileCheckInUse:            schema.TypeString,
+		mkResourceVirtualEnvironmentFileCheckSpace:            schema.TypeBool,
+		mkResourceVirtualEnvironmentFileChecksums:             schema.TypeMap,
+		mkResourceVirtualEnvironmentFileContentType:           schema.TypeString,
+		mkResourceVirtualEnvironmentFileDatastoreContentTypes: schema.TypeList,
+		mkResourceVirtualEnvironmentFileDatastoreID:           schema.TypeString,
+		mkResourceVirtualEnvironmentFileEnabled:               schema.TypeBool,
+		mkResourceVirtualEnvironmentFileFileModificationDate:  schema.TypeString,
+		mkResourceVirtualEnvironmentFileFileName:              schema.TypeString,
+		mkResourceVirtualEnvironmentFileFileMode:              schema.TypeString,
+		mkResourceVirtualEnvironmentFileFileSize:              schema.TypeInt,
+		mkResourceVirtualEnvironmentFileFileTag:               schema.TypeString,
+		mkResourceVirtualEnvironmentFileForceContentTypeDir:   schema.TypeString,
+		mkResourceVirtualEnvironmentFileImportSource:          schema.TypeString,
+		mkResourceVirtualEnvironmentFileMIMEType:              schema.TypeString,
+		mkResourceVirtualEnvironmentFileNodeName:              schema.TypeString,
+		mkResourceVirtualEnvironmentFileOverwrite:             schema.TypeBool,
+		mkResourceVirtualEnvironmentFileOverwriteContentTypes: schema.TypeSet,
+		mkResourceVirtualEnvironmentFileOverwriteUnmanaged:    schema.TypeBool,
+		mkResourceVirtualEnvironmentFileOverwritten:           schema.TypeBool,
+		mkResourceVirtualEnvironmentFilePostUploadCommand:     schema.TypeString,
+		mkResourceVirtualEnvironmentFileReadBackContent:       schema.TypeBool,
+		mkResourceVirtualEnvironmentFileSanitizeFilename:      schema.TypeBool,
+		mkResourceVirtualEnvironmentFileSourceFile:            schema.TypeList,
+		mkResourceVirtualEnvironmentFileSourceRaw:             schema.TypeList,
+		mkResourceVirtualEnvironmentFileStoredContent:         schema.TypeString,
+		mkResourceVirtualEnvironmentFileTimeoutUpload:         schema.TypeInt,
+		mkResourceVirtualEnvironmentFileTmpDir:                schema.TypeString,
+		mkResourceVirtualEnvironmentFileUploadMode:            schema.TypeString,
+		mkResourceVirtualEnvironmentFileUploadedChecksum:      schema.TypeString,
+		mkResourceVirtualEnvironmentFileValidateBootable:      schema.TypeBool,
 	})
 
 	sourceFileSchema := test.AssertNestedSchemaExistence(t, s, mkResourceVirtualEnvironmentFileSourceFile)
 
-	test.AssertRequiredArguments(t, sourceFileSchema, []string{
-		mkResourceVirtualEnvironmentFileSourceFilePath,
-	})
-
 	test.AssertOptionalArguments(t, sourceFileSchema, []string{
+		mkResourceVirtualEnvironmentFileSourceFilePath,
+		mkResourceVirtualEnvironmentFileSourceFileArchive,
+		mkResourceVirtualEnvironmentFileSourceFileAzureBlob,
+		mkResourceVirtualEnvironmentFileSourceFileGCS,
+		mkResourceVirtualEnvironmentFileSourceFileCache,
+		mkResourceVirtualEnvironmentFileSourceFileCacheMaxAge,
+		mkResourceVirtualEnvironmentFileSourceFileCacheMaxSize,
 		mkResourceVirtualEnvironmentFileSourceFileChanged,
 		mkResourceVirtualEnvironmentFileSourceFileChecksum,
+		mkResourceVirtualEnvironmentFileSourceFileChecksumAlgorithm,
+		mkResourceVirtualEnvironmentFileSourceFileChecksumTarget,
+		mkResourceVirtualEnvironmentFileSourceFileConnectTimeout,
+		mkResourceVirtualEnvironmentFileSourceFileExpectedETag,
+		mkResourceVirtualEnvironmentFileSourceFileExpectedSize,
 		mkResourceVirtualEnvironmentFileSourceFileFileName,
 		mkResourceVirtualEnvironmentFileSourceFileInsecure,
+		mkResourceVirtualEnvironmentFileSourceFileLocalAddr,
+		mkResourceVirtualEnvironmentFileSourceFileMirrorURLs,
+		mkResourceVirtualEnvironmentFileSourceFileOAuth2,
+		mkResourceVirtualEnvironmentFileSourceFileRetries,
+		mkResourceVirtualEnvironmentFileSourceFileRetryOnStatus,
+		mkResourceVirtualEnvironmentFileSourceFileServerSideDownload,
 	})
 
 	test.AssertValueTypes(t, sourceFileSchema, map[string]schema.ValueType{
-		mkResourceVirtualEnvironmentFileSourceFileChanged:  schema.TypeBool,
-		mkResourceVirtualEnvironmentFileSourceFileChecksum: schema.TypeString,
-		mkResourceVirtualEnvironmentFileSourceFileFileName: schema.TypeString,
-		mkResourceVirtualEnvironmentFileSourceFileInsecure: schema.TypeBool,
-		mkResourceVirtualEnvironmentFileSourceFilePath:     schema.TypeString,
+		mkResourceVirtualEnvironmentFileSourceFileCache:              schema.TypeBool,
+		mkResourceVirtualEnvironmentFileSourceFileCacheMaxAge:        schema.TypeInt,
+		mkResourceVirtualEnvironmentFileSourceFileCacheMaxSize:       schema.TypeInt,
+		mkResourceVirtualEnvironmentFileSourceFileChanged:            schema.TypeBool,
+		mkResourceVirtualEnvironmentFileSourceFileChecksum:           schema.TypeString,
+		mkResourceVirtualEnvironmentFileSourceFileChecksumAlgorithm:  schema.TypeString,
+		mkResourceVirtualEnvironmentFileSourceFileChecksumTarget:     schema.TypeString,
+		mkResourceVirtualEnvironmentFileSourceFileConnectTimeout:     schema.TypeInt,
+		mkResourceVirtualEnvironmentFileSourceFileExpectedETag:       schema.TypeString,
+		mkResourceVirtualEnvironmentFileSourceFileExpectedSize:       schema.TypeInt,
+		mkResourceVirtualEnvironmentFileSourceFileFileName:           schema.TypeString,
+		mkResourceVirtualEnvironmentFileSourceFileInsecure:           schema.TypeBool,
+		mkResourceVirtualEnvironmentFileSourceFileLocalAddr:          schema.TypeString,
+		mkResourceVirtualEnvironmentFileSourceFileMirrorURLs:         schema.TypeList,
+		mkResourceVirtualEnvironmentFileSourceFileOAuth2:             schema.TypeList,
+		mkResourceVirtualEnvironmentFileSourceFileRetries:            schema.TypeInt,
+		mkResourceVirtualEnvironmentFileSourceFileRetryOnStatus:      schema.TypeList,
+		mkResourceVirtualEnvironmentFileSourceFileServerSideDownload: schema.TypeBool,
+		mkResourceVirtualEnvironmentFileSourceFilePath:               schema.TypeString,
+		mkResourceVirtualEnvironmentFileSourceFileArchive:            schema.TypeString,
+		mkResourceVirtualEnvironmentFileSourceFileAzureBlob:          schema.TypeList,
+		mkResourceVirtualEnvironmentFileSourceFileGCS:                schema.TypeList,
+	})
+
+	azureBlobSchema := test.AssertNestedSchemaExistence(t, sourceFileSchema, mkResourceVirtualEnvironmentFileSourceFileAzureBlob)
+
+	test.AssertRequiredArguments(t, azureBlobSchema, []string{
+		mkResourceVirtualEnvironmentFileSourceFileAzureBlobSASURL,
+	})
+
+	gcsSchema := test.AssertNestedSchemaExistence(t, sourceFileSchema, mkResourceVirtualEnvironmentFileSourceFileGCS)
+
+	test.AssertRequiredArguments(t, gcsSchema, []string{
+		mkResourceVirtualEnvironmentFileSourceFileGCSBucket,
+		mkResourceVirtualEnvironmentFileSourceFileGCSObject,
+	})
+
+	test.AssertOptionalArguments(t, gcsSchema, []string{
+		mkResourceVirtualEnvironmentFileSourceFileGCSCredentials,
+	})
+
+	oauth2Schema := test.AssertNestedSchemaExistence(t, sourceFileSchema, mkResourceVirtualEnvironmentFileSourceFileOAuth2)
+
+	test.AssertRequiredArguments(t, oauth2Schema, []string{
+		mkResourceVirtualEnvironmentFileSourceFileOAuth2ClientID,
+		mkResourceVirtualEnvironmentFileSourceFileOAuth2ClientSecret,
+		mkResourceVirtualEnvironmentFileSourceFileOAuth2TokenURL,
+	})
+
+	test.AssertOptionalArguments(t, oauth2Schema, []string{
+		mkResourceVirtualEnvironmentFileSourceFileOAuth2Scopes,
 	})
 
 	sourceRawSchema := test.AssertNestedSchemaExistence(t, s, mkResourceVirtualEnvironmentFileSourceRaw)
@@ -94,13 +201,23 @@ func TestFileSchema(t *testing.T) {
 	})
 
 	test.AssertOptionalArguments(t, sourceRawSchema, []string{
+		mkResourceVirtualEnvironmentFileSourceRawEncoding,
+		mkResourceVirtualEnvironmentFileSourceRawEnsureNewline,
+		mkResourceVirtualEnvironmentFileSourceRawNormalizeNewlines,
 		mkResourceVirtualEnvironmentFileSourceRawResize,
+		mkResourceVirtualEnvironmentFileSourceRawResizeFill,
+		mkResourceVirtualEnvironmentFileSourceRawStripBOM,
 	})
 
 	test.AssertValueTypes(t, sourceRawSchema, map[string]schema.ValueType{
-		mkResourceVirtualEnvironmentFileSourceRawData:     schema.TypeString,
-		mkResourceVirtualEnvironmentFileSourceRawFileName: schema.TypeString,
-		mkResourceVirtualEnvironmentFileSourceRawResize:   schema.TypeInt,
+		mkResourceVirtualEnvironmentFileSourceRawData:              schema.TypeString,
+		mkResourceVirtualEnvironmentFileSourceRawEncoding:          schema.TypeString,
+		mkResourceVirtualEnvironmentFileSourceRawEnsureNewline:     schema.TypeBool,
+		mkResourceVirtualEnvironmentFileSourceRawFileName:          schema.TypeString,
+		mkResourceVirtualEnvironmentFileSourceRawNormalizeNewlines: schema.TypeBool,
+		mkResourceVirtualEnvironmentFileSourceRawResize:            schema.TypeInt,
+		mkResourceVirtualEnvironmentFileSourceRawResizeFill:        schema.TypeString,
+		mkResourceVirtualEnvironmentFileSourceRawStripBOM:          schema.TypeBool,
 	})
 }
 
@@ -147,6 +264,266 @@ func Test_fileParseVolumeID(t *testing.T) {
 	}
 }
 
+func Test_fileRequiredSpace(t *testing.T) {
+	t.Parallel()
+
+	existing := &storage.DatastoreFileListResponseData{FileSize: 300}
+
+	tests := []struct {
+		name     string
+		size     int64
+		existing *storage.DatastoreFileListResponseData
+		staged   bool
+		want     int64
+	}{
+		{"new file", 1000, nil, false, 1000},
+		{"new file staged", 1000, nil, true, 1000},
+		{"overwritten file", 1000, existing, false, 700},
+		{"overwritten larger file", 100, existing, false, 0},
+		{"overwritten file staged", 1000, existing, true, 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := fileRequiredSpace(tt.size, tt.existing, tt.staged); got != tt.want {
+				t.Errorf("fileRequiredSpace() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fileGetContentType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		contentType string
+		path        string
+		want        string
+	}{
+		{"stored", "snippets", "https://example.com/file.iso", "snippets"},
+		{"inferred iso", "", "https://example.com/file.iso", "iso"},
+		{"inferred vztmpl", "", "/tmp/template.tar.zst", "vztmpl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, File().Schema, map[string]interface{}{
+				mkResourceVirtualEnvironmentFileContentType: tt.contentType,
+				mkResourceVirtualEnvironmentFileSourceFile: []interface{}{
+					map[string]interface{}{
+						mkResourceVirtualEnvironmentFileSourceFilePath: tt.path,
+					},
+				},
+			})
+
+			// the client is nil, so resolving the content type must not send any request
+			got, diags := fileGetContentType(t.Context(), d, nil)
+			if diags.HasError() {
+				t.Fatalf("fileGetContentType() error = %v", diags)
+			}
+
+			if *got != tt.want {
+				t.Errorf("fileGetContentType() = %q, want %q", *got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fileGetSourceFileName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		path     string
+		fileName string
+		sanitize bool
+		want     string
+	}{
+		{"url", "https://example.com/images/my%20image%2B1.iso", "", false, "my image+1.iso"},
+		{"sanitized url", "https://example.com/images/my%20image%2B1.iso", "", true, "my_image_1.iso"},
+		{"sanitized path", "/tmp/räksmörgås (1).iso", "", true, "r_ksm_rg_s__1_.iso"},
+		{"sanitized file name", "https://example.com/download?id=1", "ubuntu%2024.04.iso", true, "ubuntu_24.04.iso"},
+		{"valid", "https://example.com/ubuntu-24.04_amd64.iso", "", true, "ubuntu-24.04_amd64.iso"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, File().Schema, map[string]interface{}{
+				mkResourceVirtualEnvironmentFileSanitizeFilename: tt.sanitize,
+				mkResourceVirtualEnvironmentFileSourceFile: []interface{}{
+					map[string]interface{}{
+						mkResourceVirtualEnvironmentFileSourceFilePath:     tt.path,
+						mkResourceVirtualEnvironmentFileSourceFileFileName: tt.fileName,
+					},
+				},
+			})
+
+			got, err := fileGetSourceFileName(d)
+			if err != nil {
+				t.Fatalf("fileGetSourceFileName() error = %v", err)
+			}
+
+			if *got != tt.want {
+				t.Errorf("fileGetSourceFileName() = %q, want %q", *got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fileStorageDevicesUse(t *testing.T) {
+	t.Parallel()
+
+	devices := vms.CustomStorageDevices{
+		"ide2":  &vms.CustomStorageDevice{FileVolume: "local:iso/ubuntu.iso"},
+		"scsi0": &vms.CustomStorageDevice{FileVolume: "local-lvm:vm-100-disk-0"},
+		"ide3":  nil,
+	}
+
+	tests := []struct {
+		name     string
+		volumeID string
+		want     bool
+	}{
+		{"mounted iso", "local:iso/ubuntu.iso", true},
+		{"disk", "local-lvm:vm-100-disk-0", true},
+		{"other iso", "local:iso/debian.iso", false},
+		{"same name on other datastore", "nfs:iso/ubuntu.iso", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := fileStorageDevicesUse(devices, tt.volumeID); got != tt.want {
+				t.Errorf("fileStorageDevicesUse() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fileParseRemoteStat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		out      string
+		wantSize int64
+		wantDate string
+		wantErr  bool
+	}{
+		{"empty", "", 0, "", true},
+		{"missing time", "1024\n", 0, "", true},
+		{"invalid size", "abc:1700000000\n", 0, "", true},
+		{"invalid time", "1024:abc\n", 0, "", true},
+		{"valid", "1024:1700000000\n", 1024, "2023-11-14T22:13:20Z", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			size, date, err := fileParseRemoteStat(tt.out)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("fileParseRemoteStat() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if size != tt.wantSize || date != tt.wantDate {
+				t.Errorf("fileParseRemoteStat() got = %d %q, want %d %q", size, date, tt.wantSize, tt.wantDate)
+			}
+		})
+	}
+}
+
+func Test_fileOverwriteAllowed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		overwrite    bool
+		contentTypes []interface{}
+		contentType  string
+		want         bool
+	}{
+		{"overwrite", true, nil, "backup", true},
+		{"no overwrite", false, nil, "iso", false},
+		{"listed content type", true, []interface{}{"iso", "vztmpl"}, "iso", true},
+		{"unlisted content type", true, []interface{}{"iso", "vztmpl"}, "backup", false},
+		{"no overwrite of listed content type", false, []interface{}{"iso"}, "iso", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := map[string]interface{}{
+				mkResourceVirtualEnvironmentFileDatastoreID: "local",
+				mkResourceVirtualEnvironmentFileOverwrite:   tt.overwrite,
+			}
+
+			if tt.contentTypes != nil {
+				raw[mkResourceVirtualEnvironmentFileOverwriteContentTypes] = tt.contentTypes
+			}
+
+			d := schema.TestResourceDataRaw(t, File().Schema, raw)
+
+			if got := fileOverwriteAllowed(d, tt.contentType); got != tt.want {
+				t.Errorf("fileOverwriteAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fileReadStoredContent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		readBack     bool
+		contentType  string
+		fileSize     int64
+		wantWarnings int
+	}{
+		{"disabled", false, "iso", 1024, 0},
+		{"unsupported content type", true, "iso", 1024, 1},
+		{"too large", true, "snippets", fileStoredContentMaxSize + 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, File().Schema, map[string]interface{}{
+				mkResourceVirtualEnvironmentFileDatastoreID:     "local",
+				mkResourceVirtualEnvironmentFileReadBackContent: tt.readBack,
+			})
+			d.SetId("local:" + tt.contentType + "/file")
+			_ = d.Set(mkResourceVirtualEnvironmentFileStoredContent, "stale")
+
+			// the file is not read from the node in these cases, so no client is needed
+			diags := fileReadStoredContent(context.Background(), d, nil, "pve", tt.contentType, tt.fileSize)
+			if diags.HasError() {
+				t.Fatalf("fileReadStoredContent() unexpected error: %v", diags)
+			}
+
+			if len(diags) != tt.wantWarnings {
+				t.Errorf("fileReadStoredContent() got %d warnings, want %d", len(diags), tt.wantWarnings)
+			}
+
+			if content := d.Get(mkResourceVirtualEnvironmentFileStoredContent).(string); content != "" {
+				t.Errorf("fileReadStoredContent() stored content = %q, want empty", content)
+			}
+		})
+	}
+}
+
 func Test_fileParseImportID(t *testing.T) {
 	t.Parallel()
 
@@ -192,3 +569,60 @@ func Test_fileParseImportID(t *testing.T) {
 		})
 	}
 }
+
+func Test_fileComputeChecksums(t *testing.T) {
+	t.Parallel()
+
+	checksums, err := fileComputeChecksums(strings.NewReader("hello\n"))
+	if err != nil {
+		t.Fatalf("fileComputeChecksums() error = %v", err)
+	}
+
+	want := map[string]string{
+		"sha256": "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03",
+		"sha512": "e7c22b994c59d9cf2b48e549b1e24666636045930d3da7c1acb299d1c3b7f931" +
+			"f94aae41edda2c2b207a36e10f8bcb8d45223e54878f5b316e7ce3b6bc019629",
+	}
+
+	if !reflect.DeepEqual(checksums, want) {
+		t.Errorf("fileComputeChecksums() got = %v, want %v", checksums, want)
+	}
+}
+
+func Test_fileSourceFileExactlyOneOf(t *testing.T) {
+	t.Parallel()
+
+	azureBlob := []interface{}{map[string]interface{}{
+		mkResourceVirtualEnvironmentFileSourceFileAzureBlobSASURL: "https://account.blob.core.windows.net/c/file.iso?sig=x",
+	}}
+
+	tests := []struct {
+		name   string
+		source map[string]interface{}
+		valid  bool
+	}{
+		{"path", map[string]interface{}{mkResourceVirtualEnvironmentFileSourceFilePath: "/tmp/file.iso"}, true},
+		{"azure blob", map[string]interface{}{mkResourceVirtualEnvironmentFileSourceFileAzureBlob: azureBlob}, true},
+		{"no source", map[string]interface{}{mkResourceVirtualEnvironmentFileSourceFileInsecure: true}, false},
+		{"two sources", map[string]interface{}{
+			mkResourceVirtualEnvironmentFileSourceFilePath:      "/tmp/file.iso",
+			mkResourceVirtualEnvironmentFileSourceFileAzureBlob: azureBlob,
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := File().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				mkResourceVirtualEnvironmentFileDatastoreID: "local",
+				mkResourceVirtualEnvironmentFileNodeName:    "pve",
+				mkResourceVirtualEnvironmentFileSourceFile:  []interface{}{tt.source},
+			}))
+
+			if diags.HasError() == tt.valid {
+				t.Errorf("File().Validate() = %v, want valid %v", diags, tt.valid)
+			}
+		})
+	}
+}
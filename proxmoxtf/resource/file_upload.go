@@ -0,0 +1,91 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/storage"
+)
+
+// fileResumeUpload looks for an upload of the source file to the datastore, which was started by an earlier apply
+// that got interrupted, e.g. by a timeout or a killed process, and adopts its result instead of uploading the
+// file again. It waits for the upload task if it's still running, and reports whether the upload was adopted.
+// The task is matched by the name and the size of the file, so a different source is always uploaded again.
+func fileResumeUpload(
+	ctx context.Context,
+	capi proxmox.Client,
+	nodeName string,
+	datastoreID string,
+	fileName string,
+	sourceFilePathLocal string,
+	existingFile *storage.DatastoreFileListResponseData,
+) (bool, error) {
+	fileInfo, err := os.Stat(sourceFilePathLocal)
+	if err != nil {
+		return false, fmt.Errorf("failed to get source file info: %w", err)
+	}
+
+	datastore, err := capi.Storage().GetDatastore(ctx, datastoreID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get datastore: %w", err)
+	}
+
+	datastorePath := datastore.FilePath()
+	if datastorePath == "" {
+		return false, nil
+	}
+
+	storageClient := capi.Node(nodeName).Storage(datastoreID)
+
+	task, err := storageClient.FindUploadTask(ctx, datastorePath, fileName)
+	if err != nil {
+		return false, err
+	}
+
+	if task == nil || task.FileSize != fileInfo.Size() {
+		return false, nil
+	}
+
+	status, err := storageClient.Tasks().GetTaskStatus(ctx, task.UPID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the status of the upload task %q: %w", task.UPID, err)
+	}
+
+	if status.Status == "running" {
+		tflog.Info(ctx, "Waiting for an earlier upload of the file to complete", map[string]interface{}{
+			"upid": task.UPID,
+			"file": task.TargetFile,
+		})
+
+		if err = storageClient.Tasks().WaitForTask(ctx, task.UPID); err != nil {
+			tflog.Warn(ctx, "The earlier upload of the file failed, uploading it again", map[string]interface{}{
+				"upid":  task.UPID,
+				"error": err,
+			})
+
+			return false, nil
+		}
+	} else {
+		// the file may have been removed or replaced since the task completed
+		if status.ExitCode != "OK" || existingFile == nil || existingFile.FileSize != fileInfo.Size() {
+			return false, nil
+		}
+	}
+
+	tflog.Info(ctx, "Adopted an earlier upload of the file", map[string]interface{}{
+		"upid": task.UPID,
+		"file": task.TargetFile,
+	})
+
+	return true, nil
+}
@@ -9,6 +9,7 @@ package resource
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -20,15 +21,17 @@ import (
 )
 
 const (
-	dvResourceVirtualEnvironmentGroupComment = ""
-
-	mkResourceVirtualEnvironmentGroupACL          = "acl"
-	mkResourceVirtualEnvironmentGroupACLPath      = "path"
-	mkResourceVirtualEnvironmentGroupACLPropagate = "propagate"
-	mkResourceVirtualEnvironmentGroupACLRoleID    = "role_id"
-	mkResourceVirtualEnvironmentGroupComment      = "comment"
-	mkResourceVirtualEnvironmentGroupID           = "group_id"
-	mkResourceVirtualEnvironmentGroupMembers      = "members"
+	dvResourceVirtualEnvironmentGroupComment       = ""
+	dvResourceVirtualEnvironmentGroupManageMembers = false
+
+	mkResourceVirtualEnvironmentGroupACL           = "acl"
+	mkResourceVirtualEnvironmentGroupACLPath       = "path"
+	mkResourceVirtualEnvironmentGroupACLPropagate  = "propagate"
+	mkResourceVirtualEnvironmentGroupACLRoleID     = "role_id"
+	mkResourceVirtualEnvironmentGroupComment       = "comment"
+	mkResourceVirtualEnvironmentGroupID            = "group_id"
+	mkResourceVirtualEnvironmentGroupManageMembers = "manage_members"
+	mkResourceVirtualEnvironmentGroupMembers       = "members"
 )
 
 // Group returns a resource that manages a group in the Proxmox VE access control list.
@@ -75,23 +78,94 @@ func Group() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			mkResourceVirtualEnvironmentGroupManageMembers: {
+				Type: schema.TypeBool,
+				Description: "Whether the group members are managed by the `members` attribute. " +
+					"Disable `manage_groups` on the member users when this is enabled",
+				Optional: true,
+				Default:  dvResourceVirtualEnvironmentGroupManageMembers,
+			},
 			mkResourceVirtualEnvironmentGroupMembers: {
 				Type:        schema.TypeSet,
 				Description: "The group members",
+				Optional:    true,
 				Computed:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
+				DiffSuppressFunc: func(_, _, _ string, d *schema.ResourceData) bool {
+					// the memberships are managed by the users, or by group membership resources
+					return !d.Get(mkResourceVirtualEnvironmentGroupManageMembers).(bool)
+				},
 			},
 		},
+		CustomizeDiff: groupCustomizeDiff,
 		CreateContext: groupCreate,
 		ReadContext:   groupRead,
 		UpdateContext: groupUpdate,
 		DeleteContext: groupDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+				err := d.Set(mkResourceVirtualEnvironmentGroupManageMembers, dvResourceVirtualEnvironmentGroupManageMembers)
+				if err != nil {
+					return nil, fmt.Errorf("failed setting state during import: %w", err)
+				}
+
+				return []*schema.ResourceData{d}, nil
+			},
 		},
 	}
 }
 
+// groupCustomizeDiff removes the members missing from the configuration when the group manages its members.
+// The attribute is also computed, so an empty or missing list would otherwise keep the members of the state.
+func groupCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.Get(mkResourceVirtualEnvironmentGroupManageMembers).(bool) {
+		return nil
+	}
+
+	members := d.GetRawConfig().GetAttr(mkResourceVirtualEnvironmentGroupMembers)
+	if !members.IsKnown() || (!members.IsNull() && members.LengthInt() > 0) {
+		return nil
+	}
+
+	if d.Get(mkResourceVirtualEnvironmentGroupMembers).(*schema.Set).Len() == 0 {
+		return nil
+	}
+
+	err := d.SetNew(mkResourceVirtualEnvironmentGroupMembers, []interface{}{})
+	if err != nil {
+		return fmt.Errorf("failed to clear the group members: %w", err)
+	}
+
+	return nil
+}
+
+// groupUpdateMembers adds and removes the users of the group, when the group manages its members.
+// PVE stores the memberships in the user objects, so each change is an update of a user.
+func groupUpdateMembers(ctx context.Context, client *access.Client, d *schema.ResourceData) error {
+	if !d.Get(mkResourceVirtualEnvironmentGroupManageMembers).(bool) {
+		return nil
+	}
+
+	groupID := d.Id()
+	membersOld, membersNew := d.GetChange(mkResourceVirtualEnvironmentGroupMembers)
+
+	for _, v := range membersNew.(*schema.Set).Difference(membersOld.(*schema.Set)).List() {
+		err := client.AddUserToGroup(ctx, v.(string), groupID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, v := range membersOld.(*schema.Set).Difference(membersNew.(*schema.Set)).List() {
+		err := client.RemoveUserFromGroup(ctx, v.(string), groupID)
+		if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func groupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	config := m.(proxmoxtf.ProviderConfiguration)
 
@@ -115,6 +189,11 @@ func groupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 
 	d.SetId(groupID)
 
+	err = groupUpdateMembers(ctx, client.Access(), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	aclParsed := d.Get(mkResourceVirtualEnvironmentGroupACL).(*schema.Set).List()
 
 	for _, v := range aclParsed {
@@ -224,6 +303,11 @@ func groupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 		return diag.FromErr(err)
 	}
 
+	err = groupUpdateMembers(ctx, client.Access(), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	aclArgOld, aclArg := d.GetChange(mkResourceVirtualEnvironmentGroupACL)
 	aclParsedOld := aclArgOld.(*schema.Set).List()
 
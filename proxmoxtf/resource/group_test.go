@@ -37,6 +37,8 @@ func TestGroupSchema(t *testing.T) {
 	test.AssertOptionalArguments(t, s, []string{
 		mkResourceVirtualEnvironmentGroupACL,
 		mkResourceVirtualEnvironmentGroupComment,
+		mkResourceVirtualEnvironmentGroupManageMembers,
+		mkResourceVirtualEnvironmentGroupMembers,
 	})
 
 	test.AssertComputedAttributes(t, s, []string{
@@ -44,10 +46,11 @@ func TestGroupSchema(t *testing.T) {
 	})
 
 	test.AssertValueTypes(t, s, map[string]schema.ValueType{
-		mkResourceVirtualEnvironmentGroupACL:     schema.TypeSet,
-		mkResourceVirtualEnvironmentGroupComment: schema.TypeString,
-		mkResourceVirtualEnvironmentGroupID:      schema.TypeString,
-		mkResourceVirtualEnvironmentGroupMembers: schema.TypeSet,
+		mkResourceVirtualEnvironmentGroupACL:           schema.TypeSet,
+		mkResourceVirtualEnvironmentGroupComment:       schema.TypeString,
+		mkResourceVirtualEnvironmentGroupID:            schema.TypeString,
+		mkResourceVirtualEnvironmentGroupManageMembers: schema.TypeBool,
+		mkResourceVirtualEnvironmentGroupMembers:       schema.TypeSet,
 	})
 
 	aclSchema := test.AssertNestedSchemaExistence(t, s, mkResourceVirtualEnvironmentGroupACL)
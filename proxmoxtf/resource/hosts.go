@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -29,8 +30,93 @@ const (
 	mkResourceVirtualEnvironmentHostsEntryHostnames   = "hostnames"
 	mkResourceVirtualEnvironmentHostsHostnames        = "hostnames"
 	mkResourceVirtualEnvironmentHostsNodeName         = "node_name"
+
+	// hostsUpdateRetries is the number of attempts to update the hosts file when it's modified concurrently.
+	hostsUpdateRetries = 5
 )
 
+// hostsEntry is an entry of the hosts file.
+type hostsEntry struct {
+	address   string
+	hostnames []string
+}
+
+// hostsParseLine parses a line of the hosts file, and reports false for blank lines and comments.
+func hostsParseLine(line string) (hostsEntry, bool) {
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return hostsEntry{}, false
+	}
+
+	values := strings.Fields(line)
+	if len(values) == 0 {
+		return hostsEntry{}, false
+	}
+
+	return hostsEntry{address: values[0], hostnames: values[1:]}, true
+}
+
+// hostsMergeEntries replaces the lines of the hosts file with the given addresses by the entries, and
+// preserves all other lines. The entries are written in place of the first replaced line, or appended
+// to the file if no line is replaced.
+func hostsMergeEntries(data string, addresses map[string]struct{}, entries []hostsEntry) string {
+	rendered := make([]string, 0, len(entries))
+
+	for _, e := range entries {
+		rendered = append(rendered, strings.Join(append([]string{e.address}, e.hostnames...), " "))
+	}
+
+	var lines, existing []string
+
+	if trimmed := strings.TrimRight(data, "\n"); trimmed != "" {
+		existing = strings.Split(trimmed, "\n")
+	}
+
+	inserted := false
+
+	for _, line := range existing {
+		if e, ok := hostsParseLine(line); ok {
+			if _, managed := addresses[e.address]; managed {
+				if !inserted {
+					lines = append(lines, rendered...)
+					inserted = true
+				}
+
+				continue
+			}
+		}
+
+		lines = append(lines, line)
+	}
+
+	if !inserted {
+		lines = append(lines, rendered...)
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// hostsGetEntries returns the entries of the hosts block list.
+func hostsGetEntries(entry []interface{}) []hostsEntry {
+	entries := make([]hostsEntry, 0, len(entry))
+
+	for _, e := range entry {
+		eMap := e.(map[string]interface{})
+
+		var hostnames []string
+
+		for _, h := range eMap[mkResourceVirtualEnvironmentHostsEntryHostnames].([]interface{}) {
+			hostnames = append(hostnames, h.(string))
+		}
+
+		entries = append(entries, hostsEntry{
+			address:   eMap[mkResourceVirtualEnvironmentHostsEntryAddress].(string),
+			hostnames: hostnames,
+		})
+	}
+
+	return entries
+}
+
 // Hosts returns a resource that manages hosts settings for a node.
 func Hosts() *schema.Resource {
 	return &schema.Resource{
@@ -154,39 +240,44 @@ func hostsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.
 		return diag.FromErr(err)
 	}
 
+	// The entries of the resource are the entries with the addresses managed by it, all other lines
+	// of the hosts file are preserved. An imported resource manages all entries.
+	managedAddresses := map[string]struct{}{}
+
+	for _, e := range hostsGetEntries(d.Get(mkResourceVirtualEnvironmentHostsEntry).([]interface{})) {
+		managedAddresses[e.address] = struct{}{}
+	}
+
 	// Parse the entries in the hosts file.
 	var addresses []interface{}
 	var entries []interface{}
+	var managedEntries []interface{}
 	var hostnames []interface{}
 	lines := strings.Split(hosts.Data, "\n")
 
 	for _, line := range lines {
-		if strings.HasPrefix(line, "#") {
-			continue
-		}
-
-		line = strings.ReplaceAll(line, "\t", " ")
-		values := strings.Split(line, " ")
-
-		if values[0] == "" {
+		e, ok := hostsParseLine(line)
+		if !ok {
 			continue
 		}
 
-		addresses = append(addresses, values[0])
+		addresses = append(addresses, e.address)
 		entry := map[string]interface{}{}
 		var hostnamesForAddress []interface{}
 
-		for _, hostname := range values[1:] {
-			if hostname != "" {
-				hostnamesForAddress = append(hostnamesForAddress, hostname)
-			}
+		for _, hostname := range e.hostnames {
+			hostnamesForAddress = append(hostnamesForAddress, hostname)
 		}
 
-		entry[mkResourceVirtualEnvironmentHostsEntriesAddress] = values[0]
+		entry[mkResourceVirtualEnvironmentHostsEntriesAddress] = e.address
 		entry[mkResourceVirtualEnvironmentHostsEntriesHostnames] = hostnamesForAddress
 
 		entries = append(entries, entry)
 		hostnames = append(hostnames, hostnamesForAddress)
+
+		if _, managed := managedAddresses[e.address]; managed || len(managedAddresses) == 0 {
+			managedEntries = append(managedEntries, entry)
+		}
 	}
 
 	err = d.Set(mkResourceVirtualEnvironmentHostsAddresses, addresses)
@@ -201,7 +292,7 @@ func hostsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.
 
 	err = d.Set(mkResourceVirtualEnvironmentHostsEntries, entries)
 	diags = append(diags, diag.FromErr(err)...)
-	err = d.Set(mkResourceVirtualEnvironmentHostsEntry, entries)
+	err = d.Set(mkResourceVirtualEnvironmentHostsEntry, managedEntries)
 	diags = append(diags, diag.FromErr(err)...)
 	err = d.Set(mkResourceVirtualEnvironmentHostsHostnames, hostnames)
 	diags = append(diags, diag.FromErr(err)...)
@@ -217,33 +308,45 @@ func hostsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 		return diag.FromErr(err)
 	}
 
-	entry := d.Get(mkResourceVirtualEnvironmentHostsEntry).([]interface{})
 	nodeName := d.Get(mkResourceVirtualEnvironmentHostsNodeName).(string)
 
-	// Generate the data for the hosts file based on the specified entries.
-	body := nodes.HostsUpdateRequestBody{
-		Data: "",
-	}
+	oldEntry, newEntry := d.GetChange(mkResourceVirtualEnvironmentHostsEntry)
+	entries := hostsGetEntries(newEntry.([]interface{}))
 
-	for _, e := range entry {
-		eMap := e.(map[string]interface{})
+	// The lines with the addresses of the previous entries are replaced too, so that removed entries
+	// are removed from the hosts file.
+	addresses := map[string]struct{}{}
+
+	for _, e := range append(hostsGetEntries(oldEntry.([]interface{})), entries...) {
+		addresses[e.address] = struct{}{}
+	}
 
-		address := eMap[mkResourceVirtualEnvironmentHostsEntryAddress].(string)
-		hostnames := eMap[mkResourceVirtualEnvironmentHostsEntryHostnames].([]interface{})
+	// PVE rejects the update if the hosts file was modified since it was read, in which case the file is
+	// read again and the entries are merged into the new content.
+	for attempt := 1; ; attempt++ {
+		hosts, e := api.Node(nodeName).GetHosts(ctx)
+		if e != nil {
+			return diag.FromErr(e)
+		}
 
-		body.Data += address
+		body := nodes.HostsUpdateRequestBody{
+			Data:   hostsMergeEntries(hosts.Data, addresses, entries),
+			Digest: hosts.Digest,
+		}
 
-		for _, h := range hostnames {
-			hostname := h.(string)
-			body.Data += " " + hostname
+		err = api.Node(nodeName).UpdateHosts(ctx, &body)
+		if err == nil {
+			break
 		}
 
-		body.Data += "\n"
-	}
+		if attempt >= hostsUpdateRetries || !strings.Contains(err.Error(), "detected modified configuration") {
+			return diag.FromErr(err)
+		}
 
-	err = api.Node(nodeName).UpdateHosts(ctx, &body)
-	if err != nil {
-		return diag.FromErr(err)
+		tflog.Warn(ctx, "The hosts file was modified concurrently, retrying the update", map[string]interface{}{
+			"node_name": nodeName,
+			"attempt":   attempt,
+		})
 	}
 
 	return hostsRead(ctx, d, m)
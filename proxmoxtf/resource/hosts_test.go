@@ -75,3 +75,55 @@ func TestHostsSchema(t *testing.T) {
 		mkResourceVirtualEnvironmentHostsEntryHostnames: schema.TypeList,
 	})
 }
+
+func Test_hostsMergeEntries(t *testing.T) {
+	t.Parallel()
+
+	entries := []hostsEntry{
+		{address: "10.0.0.1", hostnames: []string{"pve1.example.com", "pve1"}},
+		{address: "10.0.0.2", hostnames: []string{"pve2.example.com", "pve2"}},
+	}
+
+	tests := []struct {
+		name      string
+		data      string
+		addresses []string
+		want      string
+	}{
+		{
+			"empty file",
+			"",
+			[]string{"10.0.0.1", "10.0.0.2"},
+			"10.0.0.1 pve1.example.com pve1\n10.0.0.2 pve2.example.com pve2\n",
+		},
+		{
+			"unmanaged lines are preserved",
+			"# comment\n127.0.0.1 localhost\n\n::1 ip6-localhost\n",
+			[]string{"10.0.0.1", "10.0.0.2"},
+			"# comment\n127.0.0.1 localhost\n\n::1 ip6-localhost\n" +
+				"10.0.0.1 pve1.example.com pve1\n10.0.0.2 pve2.example.com pve2\n",
+		},
+		{
+			"managed lines are replaced in place",
+			"127.0.0.1 localhost\n10.0.0.2\told\n10.0.0.3 removed\n::1 ip6-localhost\n",
+			[]string{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+			"127.0.0.1 localhost\n10.0.0.1 pve1.example.com pve1\n10.0.0.2 pve2.example.com pve2\n" +
+				"::1 ip6-localhost\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			addresses := map[string]struct{}{}
+			for _, a := range tt.addresses {
+				addresses[a] = struct{}{}
+			}
+
+			if got := hostsMergeEntries(tt.data, addresses, entries); got != tt.want {
+				t.Errorf("hostsMergeEntries() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
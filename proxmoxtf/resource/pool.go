@@ -10,19 +10,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/pools"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf"
 )
 
 const (
-	dvResourceVirtualEnvironmentPoolComment = ""
+	dvResourceVirtualEnvironmentPoolComment       = ""
+	dvResourceVirtualEnvironmentPoolManageMembers = false
 
 	mkResourceVirtualEnvironmentPoolComment            = "comment"
+	mkResourceVirtualEnvironmentPoolManageMembers      = "manage_members"
+	mkResourceVirtualEnvironmentPoolMemberDatastoreIDs = "member_datastore_ids"
+	mkResourceVirtualEnvironmentPoolMemberVMIDs        = "member_vm_ids"
 	mkResourceVirtualEnvironmentPoolMembers            = "members"
 	mkResourceVirtualEnvironmentPoolMembersDatastoreID = "datastore_id"
 	mkResourceVirtualEnvironmentPoolMembersID          = "id"
@@ -42,6 +51,25 @@ func Pool() *schema.Resource {
 				Optional:    true,
 				Default:     dvResourceVirtualEnvironmentPoolComment,
 			},
+			mkResourceVirtualEnvironmentPoolManageMembers: {
+				Type: schema.TypeBool,
+				Description: "Whether the member lists are authoritative, i.e. members not listed in " +
+					"member_vm_ids and member_datastore_ids are removed from the pool",
+				Optional: true,
+				Default:  dvResourceVirtualEnvironmentPoolManageMembers,
+			},
+			mkResourceVirtualEnvironmentPoolMemberDatastoreIDs: {
+				Type:        schema.TypeSet,
+				Description: "The datastores to add to the pool",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			mkResourceVirtualEnvironmentPoolMemberVMIDs: {
+				Type:        schema.TypeSet,
+				Description: "The virtual machines and containers to add to the pool",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
 			mkResourceVirtualEnvironmentPoolMembers: {
 				Type:        schema.TypeList,
 				Description: "The pool members",
@@ -78,9 +106,13 @@ func Pool() *schema.Resource {
 			},
 			mkResourceVirtualEnvironmentPoolPoolID: {
 				Type:        schema.TypeString,
-				Description: "The pool id",
+				Description: "The pool id, nested pools are specified as `parent/child`",
 				Required:    true,
 				ForceNew:    true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(
+					regexp.MustCompile(`^[A-Za-z0-9._-]+(/[A-Za-z0-9._-]+){0,2}$`),
+					"must be a pool name, or a path of up to three pool names separated by '/'",
+				)),
 			},
 		},
 		CreateContext: poolCreate,
@@ -124,6 +156,11 @@ func poolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 
 	d.SetId(poolID)
 
+	err = poolUpdateMembers(ctx, client.Pool(), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return poolRead(ctx, d, m)
 }
 
@@ -183,9 +220,127 @@ func poolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.D
 	err = d.Set(mkResourceVirtualEnvironmentPoolMembers, members)
 	diags = append(diags, diag.FromErr(err)...)
 
+	vmIDs, datastoreIDs := poolMemberIDs(pool.Members)
+
+	// Unless the member lists are authoritative, only report the members declared by this resource,
+	// so that VMs and containers joining the pool via their own `pool_id` do not cause a diff.
+	if !d.Get(mkResourceVirtualEnvironmentPoolManageMembers).(bool) {
+		vmIDs = poolIntersect(vmIDs, d.Get(mkResourceVirtualEnvironmentPoolMemberVMIDs).(*schema.Set))
+		datastoreIDs = poolIntersect(datastoreIDs, d.Get(mkResourceVirtualEnvironmentPoolMemberDatastoreIDs).(*schema.Set))
+	}
+
+	err = d.Set(mkResourceVirtualEnvironmentPoolMemberVMIDs, vmIDs)
+	diags = append(diags, diag.FromErr(err)...)
+	err = d.Set(mkResourceVirtualEnvironmentPoolMemberDatastoreIDs, datastoreIDs)
+	diags = append(diags, diag.FromErr(err)...)
+
 	return diags
 }
 
+// poolMemberIDs returns the IDs of the VM / container and datastore members of a pool.
+func poolMemberIDs(members []pools.VirtualEnvironmentPoolGetResponseMembers) ([]interface{}, []interface{}) {
+	vmIDs := []interface{}{}
+	datastoreIDs := []interface{}{}
+
+	for _, v := range members {
+		switch {
+		case v.Type == "storage" && v.DatastoreID != nil:
+			datastoreIDs = append(datastoreIDs, *v.DatastoreID)
+		case v.VMID != nil:
+			vmIDs = append(vmIDs, *v.VMID)
+		}
+	}
+
+	return vmIDs, datastoreIDs
+}
+
+// poolIntersect returns the values that are also contained in the given set.
+func poolIntersect(values []interface{}, set *schema.Set) []interface{} {
+	result := []interface{}{}
+
+	for _, v := range values {
+		if set.Contains(v) {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// poolUpdateMembers adds the new members to the pool and removes the members that are no longer listed.
+func poolUpdateMembers(ctx context.Context, client *pools.Client, d *schema.ResourceData) error {
+	oldVMIDs, newVMIDs := d.GetChange(mkResourceVirtualEnvironmentPoolMemberVMIDs)
+	oldDatastoreIDs, newDatastoreIDs := d.GetChange(mkResourceVirtualEnvironmentPoolMemberDatastoreIDs)
+
+	err := poolModifyMembers(
+		ctx,
+		client,
+		d.Id(),
+		oldVMIDs.(*schema.Set).Difference(newVMIDs.(*schema.Set)),
+		oldDatastoreIDs.(*schema.Set).Difference(newDatastoreIDs.(*schema.Set)),
+		true,
+	)
+	if err != nil {
+		return err
+	}
+
+	return poolModifyMembers(
+		ctx,
+		client,
+		d.Id(),
+		newVMIDs.(*schema.Set).Difference(oldVMIDs.(*schema.Set)),
+		newDatastoreIDs.(*schema.Set).Difference(oldDatastoreIDs.(*schema.Set)),
+		false,
+	)
+}
+
+// poolModifyMembers adds or removes the given members of a pool.
+func poolModifyMembers(
+	ctx context.Context,
+	client *pools.Client,
+	poolID string,
+	vmIDs *schema.Set,
+	datastoreIDs *schema.Set,
+	remove bool,
+) error {
+	if vmIDs.Len() == 0 && datastoreIDs.Len() == 0 {
+		return nil
+	}
+
+	body := &pools.PoolUpdateRequestBody{}
+
+	if remove {
+		body.Delete = types.CustomBool(true).Pointer()
+	}
+
+	if vmIDs.Len() > 0 {
+		vms := make(types.CustomCommaSeparatedList, 0, vmIDs.Len())
+		for _, v := range vmIDs.List() {
+			vms = append(vms, strconv.Itoa(v.(int)))
+		}
+
+		sort.Strings(vms)
+		body.VMs = &vms
+	}
+
+	if datastoreIDs.Len() > 0 {
+		storage := make(types.CustomCommaSeparatedList, 0, datastoreIDs.Len())
+		for _, v := range datastoreIDs.List() {
+			storage = append(storage, v.(string))
+		}
+
+		sort.Strings(storage)
+		body.Storage = &storage
+	}
+
+	err := client.UpdatePool(ctx, poolID, body)
+	if err != nil {
+		return fmt.Errorf("error updating pool members: %w", err)
+	}
+
+	return nil
+}
+
 func poolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	config := m.(proxmoxtf.ProviderConfiguration)
 
@@ -206,6 +361,11 @@ func poolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 		return diag.FromErr(err)
 	}
 
+	err = poolUpdateMembers(ctx, client.Pool(), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return poolRead(ctx, d, m)
 }
 
@@ -219,6 +379,19 @@ func poolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 
 	poolID := d.Id()
 
+	// A pool can only be deleted once it's empty, so release the members declared by this resource first.
+	err = poolModifyMembers(
+		ctx,
+		client.Pool(),
+		poolID,
+		d.Get(mkResourceVirtualEnvironmentPoolMemberVMIDs).(*schema.Set),
+		d.Get(mkResourceVirtualEnvironmentPoolMemberDatastoreIDs).(*schema.Set),
+		true,
+	)
+	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+		return diag.FromErr(err)
+	}
+
 	err = client.Pool().DeletePool(ctx, poolID)
 	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
 		return diag.FromErr(err)
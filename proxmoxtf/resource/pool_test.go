@@ -36,6 +36,18 @@ func TestPoolSchema(t *testing.T) {
 
 	test.AssertOptionalArguments(t, s, []string{
 		mkResourceVirtualEnvironmentPoolComment,
+		mkResourceVirtualEnvironmentPoolManageMembers,
+		mkResourceVirtualEnvironmentPoolMemberDatastoreIDs,
+		mkResourceVirtualEnvironmentPoolMemberVMIDs,
+	})
+
+	test.AssertValueTypes(t, s, map[string]schema.ValueType{
+		mkResourceVirtualEnvironmentPoolComment:            schema.TypeString,
+		mkResourceVirtualEnvironmentPoolManageMembers:      schema.TypeBool,
+		mkResourceVirtualEnvironmentPoolMemberDatastoreIDs: schema.TypeSet,
+		mkResourceVirtualEnvironmentPoolMemberVMIDs:        schema.TypeSet,
+		mkResourceVirtualEnvironmentPoolMembers:            schema.TypeList,
+		mkResourceVirtualEnvironmentPoolPoolID:             schema.TypeString,
 	})
 
 	test.AssertComputedAttributes(t, s, []string{
@@ -10,7 +10,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -18,6 +22,7 @@ import (
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf"
+	"github.com/bpg/terraform-provider-proxmox/utils"
 )
 
 const (
@@ -46,6 +51,7 @@ func Role() *schema.Resource {
 		ReadContext:   roleRead,
 		UpdateContext: roleUpdate,
 		DeleteContext: roleDelete,
+		CustomizeDiff: roleCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
 				roleID := d.Id()
@@ -61,6 +67,126 @@ func Role() *schema.Resource {
 	}
 }
 
+// roleCustomizeDiff validates the privileges of the role against the privileges known to the server,
+// so a typo or a privilege missing from the PVE version of the cluster is reported during the plan.
+func roleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown(mkResourceVirtualEnvironmentRolePrivileges) ||
+		!d.HasChange(mkResourceVirtualEnvironmentRolePrivileges) {
+		return nil
+	}
+
+	config := m.(proxmoxtf.ProviderConfiguration)
+
+	client, err := config.GetClient()
+	if err != nil {
+		return err
+	}
+
+	knownPrivileges, err := client.Access().ListPrivileges(ctx)
+	if err == nil && len(knownPrivileges) == 0 {
+		err = errors.New("no built-in roles found")
+	}
+
+	if err != nil {
+		tflog.Warn(ctx, "Unable to retrieve the privileges known to the server, the role privileges are not validated", map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return nil
+	}
+
+	oldValue, newValue := d.GetChange(mkResourceVirtualEnvironmentRolePrivileges)
+	oldPrivileges := oldValue.(*schema.Set)
+
+	var errs []error
+
+	for _, v := range newValue.(*schema.Set).List() {
+		privilege := v.(string)
+
+		if slices.Contains(knownPrivileges, privilege) {
+			continue
+		}
+
+		// the privilege is already granted by the role, so it has been accepted by the server before
+		if oldPrivileges.Contains(privilege) {
+			tflog.Warn(ctx, "The role privilege is not granted by any built-in role", map[string]interface{}{
+				"role_id":   d.Get(mkResourceVirtualEnvironmentRoleRoleID).(string),
+				"privilege": privilege,
+			})
+
+			continue
+		}
+
+		errs = append(errs, fmt.Errorf(
+			"unknown privilege %q, did you mean %q?",
+			privilege,
+			roleClosestPrivilege(privilege, knownPrivileges),
+		))
+	}
+
+	return errors.Join(errs...)
+}
+
+// roleClosestPrivilege returns the known privilege with the smallest edit distance to the given one.
+func roleClosestPrivilege(privilege string, knownPrivileges []string) string {
+	closest := ""
+	closestDistance := -1
+
+	for _, known := range knownPrivileges {
+		if strings.EqualFold(known, privilege) {
+			return known
+		}
+
+		distance := levenshteinDistance(strings.ToLower(privilege), strings.ToLower(known))
+		if closestDistance < 0 || distance < closestDistance {
+			closest = known
+			closestDistance = distance
+		}
+	}
+
+	return closest
+}
+
+// levenshteinDistance returns the number of single character edits required to change one string into the other.
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+// rolePrivileges returns the configured privileges of the role, sorted to keep the requests stable.
+func rolePrivileges(d *schema.ResourceData) types.CustomPrivileges {
+	privileges := types.CustomPrivileges(
+		utils.ConvertToStringSlice(d.Get(mkResourceVirtualEnvironmentRolePrivileges).(*schema.Set).List()),
+	)
+
+	sort.Strings(privileges)
+
+	return privileges
+}
+
 func roleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	config := m.(proxmoxtf.ProviderConfiguration)
 
@@ -69,17 +195,11 @@ func roleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 		return diag.FromErr(err)
 	}
 
-	privileges := d.Get(mkResourceVirtualEnvironmentRolePrivileges).(*schema.Set).List()
-	customPrivileges := make(types.CustomPrivileges, len(privileges))
 	roleID := d.Get(mkResourceVirtualEnvironmentRoleRoleID).(string)
 
-	for i, v := range privileges {
-		customPrivileges[i] = v.(string)
-	}
-
 	body := &access.RoleCreateRequestBody{
 		ID:         roleID,
-		Privileges: customPrivileges,
+		Privileges: rolePrivileges(d),
 	}
 
 	err = client.Access().CreateRole(ctx, body)
@@ -134,16 +254,10 @@ func roleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 		return diag.FromErr(err)
 	}
 
-	privileges := d.Get(mkResourceVirtualEnvironmentRolePrivileges).(*schema.Set).List()
-	customPrivileges := make(types.CustomPrivileges, len(privileges))
 	roleID := d.Id()
 
-	for i, v := range privileges {
-		customPrivileges[i] = v.(string)
-	}
-
 	body := &access.RoleUpdateRequestBody{
-		Privileges: customPrivileges,
+		Privileges: rolePrivileges(d),
 	}
 
 	err = client.Access().UpdateRole(ctx, roleID, body)
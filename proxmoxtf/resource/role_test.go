@@ -40,3 +40,36 @@ func TestRoleSchema(t *testing.T) {
 		mkResourceVirtualEnvironmentRoleRoleID:     schema.TypeString,
 	})
 }
+
+func TestRoleClosestPrivilege(t *testing.T) {
+	t.Parallel()
+
+	knownPrivileges := []string{
+		"Mapping.Audit",
+		"Mapping.Use",
+		"Sys.Audit",
+		"VM.Audit",
+		"VM.Config.Disk",
+		"VM.GuestAgent.Audit",
+		"VM.PowerMgmt",
+	}
+
+	tests := map[string]string{
+		"vm.audit":       "VM.Audit",
+		"VM.Adit":        "VM.Audit",
+		"VM.Config.Dsik": "VM.Config.Disk",
+		"VM.PowerMgnt":   "VM.PowerMgmt",
+		"Mapping.Used":   "Mapping.Use",
+		"Sys.Auditor":    "Sys.Audit",
+	}
+
+	for privilege, expected := range tests {
+		t.Run(privilege, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := roleClosestPrivilege(privilege, knownPrivileges); actual != expected {
+				t.Fatalf("closest privilege of %q is %q, expected %q", privilege, actual, expected)
+			}
+		})
+	}
+}
@@ -17,12 +17,14 @@ import (
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf"
+	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource/validators"
 )
 
 const (
 	mkResourceVirtualEnvironmentTimeLocalTime = "local_time"
 	mkResourceVirtualEnvironmentTimeNodeName  = "node_name"
 	mkResourceVirtualEnvironmentTimeTimeZone  = "time_zone"
+	mkResourceVirtualEnvironmentTimeUTCOffset = "utc_offset"
 	mkResourceVirtualEnvironmentTimeUTCTime   = "utc_time"
 )
 
@@ -41,9 +43,15 @@ func Time() *schema.Resource {
 				Required:    true,
 			},
 			mkResourceVirtualEnvironmentTimeTimeZone: {
+				Type:             schema.TypeString,
+				Description:      "The time zone",
+				Required:         true,
+				ValidateDiagFunc: validators.TimeZone(),
+			},
+			mkResourceVirtualEnvironmentTimeUTCOffset: {
 				Type:        schema.TypeString,
-				Description: "The time zone",
-				Required:    true,
+				Description: "The offset of the local time from UTC",
+				Computed:    true,
 			},
 			mkResourceVirtualEnvironmentTimeUTCTime: {
 				Type:        schema.TypeString,
@@ -109,13 +117,16 @@ func timeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.D
 
 	d.SetId(fmt.Sprintf("%s_time", nodeName))
 
-	localTimeOffset := time.Time(nodeTime.LocalTime).Sub(time.Now().UTC())
-	localTime := time.Time(nodeTime.LocalTime).Add(-localTimeOffset).In(localLocation)
+	// the local time is derived from the time zone rather than taken from the node, as the node may
+	// report the local time in the previous time zone for a while after the time zone is changed
+	localTime := time.Time(nodeTime.UTCTime).In(localLocation)
 
 	err = d.Set(mkResourceVirtualEnvironmentTimeLocalTime, localTime.Format(time.RFC3339))
 	diags = append(diags, diag.FromErr(err)...)
 	err = d.Set(mkResourceVirtualEnvironmentTimeTimeZone, nodeTime.TimeZone)
 	diags = append(diags, diag.FromErr(err)...)
+	err = d.Set(mkResourceVirtualEnvironmentTimeUTCOffset, localTime.Format("-07:00"))
+	diags = append(diags, diag.FromErr(err)...)
 	err = d.Set(
 		mkResourceVirtualEnvironmentTimeUTCTime,
 		time.Time(nodeTime.UTCTime).Format(time.RFC3339),
@@ -149,8 +160,21 @@ func timeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 	return timeRead(ctx, d, m)
 }
 
+// timeDelete only removes the resource from the state, as a node always has a time zone.
 func timeDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	nodeName := d.Get(mkResourceVirtualEnvironmentTimeNodeName).(string)
+
 	d.SetId("")
 
-	return nil
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "The time zone of the node is left unchanged",
+			Detail: fmt.Sprintf(
+				"The time zone of the node %q is not reset when the resource is destroyed, it remains %q.",
+				nodeName,
+				d.Get(mkResourceVirtualEnvironmentTimeTimeZone).(string),
+			),
+		},
+	}
 }
@@ -37,6 +37,7 @@ func TestTimeSchema(t *testing.T) {
 
 	test.AssertComputedAttributes(t, s, []string{
 		mkResourceVirtualEnvironmentTimeLocalTime,
+		mkResourceVirtualEnvironmentTimeUTCOffset,
 		mkResourceVirtualEnvironmentTimeUTCTime,
 	})
 
@@ -44,6 +45,7 @@ func TestTimeSchema(t *testing.T) {
 		mkResourceVirtualEnvironmentTimeLocalTime: schema.TypeString,
 		mkResourceVirtualEnvironmentTimeNodeName:  schema.TypeString,
 		mkResourceVirtualEnvironmentTimeTimeZone:  schema.TypeString,
+		mkResourceVirtualEnvironmentTimeUTCOffset: schema.TypeString,
 		mkResourceVirtualEnvironmentTimeUTCTime:   schema.TypeString,
 	})
 }
@@ -23,12 +23,13 @@ import (
 )
 
 const (
-	dvResourceVirtualEnvironmentUserComment   = ""
-	dvResourceVirtualEnvironmentUserEmail     = ""
-	dvResourceVirtualEnvironmentUserEnabled   = true
-	dvResourceVirtualEnvironmentUserFirstName = ""
-	dvResourceVirtualEnvironmentUserKeys      = ""
-	dvResourceVirtualEnvironmentUserLastName  = ""
+	dvResourceVirtualEnvironmentUserComment      = ""
+	dvResourceVirtualEnvironmentUserEmail        = ""
+	dvResourceVirtualEnvironmentUserEnabled      = true
+	dvResourceVirtualEnvironmentUserFirstName    = ""
+	dvResourceVirtualEnvironmentUserKeys         = ""
+	dvResourceVirtualEnvironmentUserLastName     = ""
+	dvResourceVirtualEnvironmentUserManageGroups = true
 
 	mkResourceVirtualEnvironmentUserACL            = "acl"
 	mkResourceVirtualEnvironmentUserACLPath        = "path"
@@ -42,6 +43,7 @@ const (
 	mkResourceVirtualEnvironmentUserGroups         = "groups"
 	mkResourceVirtualEnvironmentUserKeys           = "keys"
 	mkResourceVirtualEnvironmentUserLastName       = "last_name"
+	mkResourceVirtualEnvironmentUserManageGroups   = "manage_groups"
 	mkResourceVirtualEnvironmentUserPassword       = "password"
 	mkResourceVirtualEnvironmentUserUserID         = "user_id"
 )
@@ -117,6 +119,10 @@ func User() *schema.Resource {
 					return []string{}, nil
 				},
 				Elem: &schema.Schema{Type: schema.TypeString},
+				DiffSuppressFunc: func(_, _, _ string, d *schema.ResourceData) bool {
+					// the memberships are managed by the groups, or by group membership resources
+					return !d.Get(mkResourceVirtualEnvironmentUserManageGroups).(bool)
+				},
 			},
 			mkResourceVirtualEnvironmentUserKeys: {
 				Type:        schema.TypeString,
@@ -130,6 +136,13 @@ func User() *schema.Resource {
 				Optional:    true,
 				Default:     dvResourceVirtualEnvironmentUserLastName,
 			},
+			mkResourceVirtualEnvironmentUserManageGroups: {
+				Type: schema.TypeBool,
+				Description: "Whether the user's group memberships are managed by the `groups` attribute. " +
+					"Disable this when the memberships are managed by the groups or by group membership resources",
+				Optional: true,
+				Default:  dvResourceVirtualEnvironmentUserManageGroups,
+			},
 			mkResourceVirtualEnvironmentUserPassword: {
 				Type:        schema.TypeString,
 				Description: "The user's password",
@@ -156,6 +169,11 @@ func User() *schema.Resource {
 					return nil, fmt.Errorf("failed setting state during import: %w", err)
 				}
 
+				err = d.Set(mkResourceVirtualEnvironmentUserManageGroups, dvResourceVirtualEnvironmentUserManageGroups)
+				if err != nil {
+					return nil, fmt.Errorf("failed setting state during import: %w", err)
+				}
+
 				return []*schema.ResourceData{d}, nil
 			},
 		},
@@ -202,13 +220,16 @@ func userCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 		Enabled:        &enabled,
 		ExpirationDate: &expirationDateCustom,
 		FirstName:      &firstName,
-		Groups:         groupsCustom,
 		ID:             userID,
 		Keys:           &keys,
 		LastName:       &lastName,
 		Password:       password,
 	}
 
+	if d.Get(mkResourceVirtualEnvironmentUserManageGroups).(bool) {
+		body.Groups = groupsCustom
+	}
+
 	err = client.Access().CreateUser(ctx, body)
 	if err != nil {
 		return diag.FromErr(err)
@@ -399,11 +420,15 @@ func userUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 		Enabled:        &enabled,
 		ExpirationDate: &expirationDateCustom,
 		FirstName:      &firstName,
-		Groups:         groupsCustom,
 		Keys:           &keys,
 		LastName:       &lastName,
 	}
 
+	// an empty list is sent as well, so the groups removed from the configuration are removed from the user
+	if d.Get(mkResourceVirtualEnvironmentUserManageGroups).(bool) {
+		body.Groups = &groupsCustom
+	}
+
 	userID := d.Id()
 
 	err = client.Access().UpdateUser(ctx, userID, body)
@@ -44,6 +44,7 @@ func TestUserSchema(t *testing.T) {
 		mkResourceVirtualEnvironmentUserGroups,
 		mkResourceVirtualEnvironmentUserKeys,
 		mkResourceVirtualEnvironmentUserLastName,
+		mkResourceVirtualEnvironmentUserManageGroups,
 		mkResourceVirtualEnvironmentUserPassword,
 	})
 
@@ -57,6 +58,7 @@ func TestUserSchema(t *testing.T) {
 		mkResourceVirtualEnvironmentUserGroups:         schema.TypeSet,
 		mkResourceVirtualEnvironmentUserKeys:           schema.TypeString,
 		mkResourceVirtualEnvironmentUserLastName:       schema.TypeString,
+		mkResourceVirtualEnvironmentUserManageGroups:   schema.TypeBool,
 		mkResourceVirtualEnvironmentUserPassword:       schema.TypeString,
 		mkResourceVirtualEnvironmentUserUserID:         schema.TypeString,
 	})
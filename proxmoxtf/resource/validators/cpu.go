@@ -0,0 +1,51 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmoxtf"
+)
+
+// CPUModel returns a CustomizeDiff function which checks the CPU type of a plan against the CPU models
+// supported by the target node. The models are cached per node, so a plan with many VMs on the same node
+// reads them only once.
+func CPUModel(nodeKey string, typeKey string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if !d.HasChange(typeKey) && !d.HasChange(nodeKey) {
+			return nil
+		}
+
+		if !d.NewValueKnown(typeKey) || !d.NewValueKnown(nodeKey) {
+			return nil
+		}
+
+		config, ok := m.(proxmoxtf.ProviderConfiguration)
+		if !ok {
+			return nil
+		}
+
+		nodeName, _ := d.Get(nodeKey).(string)
+		cpuType, _ := d.Get(typeKey).(string)
+
+		warning, err := config.GetCPUModelValidator().Validate(ctx, nodeName, cpuType)
+		if warning != "" {
+			tflog.Warn(ctx, warning)
+		}
+
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", typeKey, err)
+		}
+
+		return nil
+	}
+}
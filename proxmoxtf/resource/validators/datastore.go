@@ -0,0 +1,59 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package validators
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// datastoreIDMaxLength is the maximum length of a datastore identifier. PVE doesn't document a limit,
+// but the identifier is used in configuration file keys and volume IDs, so keep it reasonable.
+const datastoreIDMaxLength = 64
+
+var datastoreIDRegex = regexp.MustCompile(`^(?i)[a-z][a-z\d\-_.]*[a-z\d]$`)
+
+// DatastoreID returns a schema validation function for a datastore identifier, following the
+// `pve-storage-id` format: it must start with a letter, end with a letter or digit, and contain only
+// letters, digits, '-', '_' and '.'. Empty values are accepted, as they mean "not set" in this provider.
+func DatastoreID() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+
+		var es []error
+
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %s to be string", k))
+			return nil, es
+		}
+
+		if v == "" {
+			return nil, es
+		}
+
+		if len(v) > datastoreIDMaxLength {
+			es = append(es, fmt.Errorf(
+				"expected %s to be at most %d characters long, got %d characters", k, datastoreIDMaxLength, len(v),
+			))
+
+			return nil, es
+		}
+
+		if !datastoreIDRegex.MatchString(v) {
+			es = append(es, fmt.Errorf(
+				"expected %s to be a valid datastore identifier (at least 2 characters, starting with a letter, "+
+					"ending with a letter or digit, and containing only letters, digits, '-', '_' and '.'), got %q",
+				k, v,
+			))
+		}
+
+		return nil, es
+	})
+}
@@ -0,0 +1,51 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package validators
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatastoreID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"empty", "", true},
+		{"simple", "local", true},
+		{"with dash", "local-lvm", true},
+		{"with dots and underscores", "terraform.proxmox_storage.zen", true},
+		{"uppercase", "NFS01", true},
+		{"single character", "a", false},
+		{"starts with digit", "1local", false},
+		{"ends with dash", "local-", false},
+		{"contains colon", "local:iso", false},
+		{"contains space", "local lvm", false},
+		{"too long", "a" + strings.Repeat("b", 64), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := DatastoreID()
+			res := f(tt.value, nil)
+
+			if tt.valid {
+				require.Empty(t, res, "validate: '%s'", tt.value)
+			} else {
+				require.NotEmpty(t, res, "validate: '%s'", tt.value)
+			}
+		})
+	}
+}
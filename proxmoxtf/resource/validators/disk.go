@@ -0,0 +1,35 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package validators
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DiskSerial is a schema validation function for the serial number of a disk, which Proxmox VE limits to
+// 20 bytes once URL-encoded. Only the characters left as is by the encoding are accepted, so that the serial
+// reported to the guest is the configured one.
+func DiskSerial() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.All(
+		validation.StringLenBetween(0, 20),
+		validation.StringMatch(
+			regexp.MustCompile(`^[A-Za-z0-9._~-]*$`),
+			"must only contain letters, digits, '.', '_', '~' and '-'",
+		),
+	))
+}
+
+// DiskWWN is a schema validation function for the World Wide Name of a disk.
+func DiskWWN() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.StringMatch(
+		regexp.MustCompile(`^(0x[0-9A-Fa-f]{16})?$`),
+		"must be '0x' followed by 16 hexadecimal digits, e.g. '0x5000c500a1b2c3d4'",
+	))
+}
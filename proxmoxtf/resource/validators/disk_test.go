@@ -0,0 +1,79 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskSerial(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"empty", "", true},
+		{"valid", "data-disk_1.0~a", true},
+		{"max length", "abcdefghij0123456789", true},
+		{"too long", "abcdefghij0123456789x", false},
+		{"space", "data disk", false},
+		{"url-encoded", "data%20disk", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := DiskSerial()
+			res := f(tt.value, cty.Path{})
+
+			if tt.valid {
+				require.Empty(t, res, "validate: '%s'", tt.value)
+			} else {
+				require.NotEmpty(t, res, "validate: '%s'", tt.value)
+			}
+		})
+	}
+}
+
+func TestDiskWWN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"empty", "", true},
+		{"valid", "0x5000c500a1b2c3d4", true},
+		{"valid uppercase", "0x5000C500A1B2C3D4", true},
+		{"no prefix", "5000c500a1b2c3d4", false},
+		{"too short", "0x5000c500a1b2c3d", false},
+		{"too long", "0x5000c500a1b2c3d4e", false},
+		{"not hex", "0x5000c500a1b2c3dg", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := DiskWWN()
+			res := f(tt.value, cty.Path{})
+
+			if tt.valid {
+				require.Empty(t, res, "validate: '%s'", tt.value)
+			} else {
+				require.NotEmpty(t, res, "validate: '%s'", tt.value)
+			}
+		})
+	}
+}
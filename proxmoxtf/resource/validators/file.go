@@ -8,8 +8,11 @@ package validators
 
 import (
 	"fmt"
+	"mime"
+	"path"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -28,6 +31,45 @@ func ContentType() schema.SchemaValidateDiagFunc {
 	}, false))
 }
 
+// ContentTypeDirectory returns a schema validation function for a directory relative to a datastore path.
+func ContentTypeDirectory() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+		}
+
+		if v == "" || path.IsAbs(v) {
+			return nil, []error{fmt.Errorf("expected %s to be a path relative to the datastore path, got %q", k, v)}
+		}
+
+		for _, segment := range strings.Split(v, "/") {
+			if segment == ".." {
+				return nil, []error{fmt.Errorf("expected %s not to contain \"..\", got %q", k, v)}
+			}
+		}
+
+		return nil, nil
+	})
+}
+
+// MIMEType returns a schema validation function for a MIME type, e.g. `application/x-iso9660-image`.
+func MIMEType() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+		}
+
+		mediaType, _, err := mime.ParseMediaType(v)
+		if err != nil || !strings.Contains(mediaType, "/") {
+			return nil, []error{fmt.Errorf("expected %s to be a MIME type, e.g. \"application/octet-stream\", got %q", k, v)}
+		}
+
+		return nil, nil
+	})
+}
+
 // FileFormat returns a schema validation function for a file format.
 func FileFormat() schema.SchemaValidateDiagFunc {
 	return validation.ToDiagFunc(validation.StringInSlice([]string{
@@ -12,6 +12,70 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestContentTypeDirectory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"empty", "", false},
+		{"directory", "dump", true},
+		{"nested directory", "custom/dump", true},
+		{"absolute", "/var/lib/vz/dump", false},
+		{"parent directory", "..", false},
+		{"traversal", "dump/../../etc", false},
+		{"dots in name", "dump..old", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := ContentTypeDirectory()
+			res := f(tt.value, nil)
+
+			if tt.valid {
+				require.Empty(t, res, "validate: '%s'", tt.value)
+			} else {
+				require.NotEmpty(t, res, "validate: '%s'", tt.value)
+			}
+		})
+	}
+}
+
+func TestMIMEType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"empty", "", false},
+		{"ISO image", "application/x-iso9660-image", true},
+		{"with parameter", "text/plain; charset=utf-8", true},
+		{"no subtype", "application", false},
+		{"invalid", "application/x iso", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := MIMEType()
+			res := f(tt.value, nil)
+
+			if tt.valid {
+				require.Empty(t, res, "validate: '%s'", tt.value)
+			} else {
+				require.NotEmpty(t, res, "validate: '%s'", tt.value)
+			}
+		})
+	}
+}
+
 func TestFileID(t *testing.T) {
 	t.Parallel()
 
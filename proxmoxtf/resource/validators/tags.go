@@ -0,0 +1,58 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmoxtf"
+)
+
+// TagPolicy returns a CustomizeDiff function which checks the tags added or removed by a plan against
+// the cluster tag policy, so changes the cluster would reject fail at plan time instead of during apply.
+func TagPolicy(key string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if !d.HasChange(key) || !d.NewValueKnown(key) {
+			return nil
+		}
+
+		config, ok := m.(proxmoxtf.ProviderConfiguration)
+		if !ok {
+			return nil
+		}
+
+		oldValue, newValue := d.GetChange(key)
+
+		warning, err := config.GetTagValidator().Validate(ctx, tagsList(oldValue), tagsList(newValue))
+		if warning != "" {
+			tflog.Warn(ctx, warning)
+		}
+
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+
+		return nil
+	}
+}
+
+func tagsList(v interface{}) []string {
+	values, _ := v.([]interface{})
+	tags := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if tag, ok := value.(string); ok {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,37 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package validators
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // Load time zone data, see https://pkg.go.dev/time/tzdata
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// TimeZone returns a schema validation function for an IANA time zone name, e.g. "Europe/Berlin".
+func TimeZone() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+		}
+
+		// "Local" and an empty name are accepted by the time package, but they are not time zones
+		if v == "" || v == "Local" {
+			return nil, []error{fmt.Errorf("expected %s to be a time zone name, got %q", k, v)}
+		}
+
+		if _, err := time.LoadLocation(v); err != nil {
+			return nil, []error{fmt.Errorf("expected %s to be a time zone name, got %q", k, v)}
+		}
+
+		return nil, nil
+	})
+}
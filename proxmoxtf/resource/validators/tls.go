@@ -0,0 +1,41 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package validators
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// TLSVersion returns a schema validation function for a minimal TLS version. It accepts the same values
+// as the client does, so an unsupported version is reported at plan time rather than at apply.
+// The deprecated versions are accepted with a warning, so that the existing configurations keep working.
+func TLSVersion() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+		}
+
+		version, err := api.GetMinTLSVersion(v)
+		if err != nil {
+			return nil, []error{fmt.Errorf("invalid value for %s: %w", k, err)}
+		}
+
+		if version < tls.VersionTLS12 {
+			return []string{fmt.Sprintf("%s: TLS %s is insecure and deprecated, and its support will be removed "+
+				"in a future release, please use `1.2` or `1.3`", k, v)}, nil
+		}
+
+		return nil, nil
+	})
+}
@@ -0,0 +1,54 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		value      string
+		valid      bool
+		deprecated bool
+	}{
+		{"empty", "", true, false},
+		{"1.0", "1.0", true, true},
+		{"1.1", "1.1", true, true},
+		{"1.2", "1.2", true, false},
+		{"1.3", "1.3", true, false},
+		{"unknown version", "1.4", false, false},
+		{"without minor version", "1", false, false},
+		{"with prefix", "TLS1.2", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := TLSVersion()
+			res := f(tt.value, nil)
+
+			switch {
+			case tt.deprecated:
+				require.Len(t, res, 1, "validate: '%s'", tt.value)
+				require.Equal(t, diag.Warning, res[0].Severity, "validate: '%s'", tt.value)
+			case tt.valid:
+				require.Empty(t, res, "validate: '%s'", tt.value)
+			default:
+				require.NotEmpty(t, res, "validate: '%s'", tt.value)
+				require.True(t, res.HasError(), "validate: '%s'", tt.value)
+			}
+		})
+	}
+}
@@ -184,6 +184,11 @@ func GetDiskDeviceObjects(
 		serial := block[mkDiskSerial].(string)
 		size, _ := block[mkDiskSize].(int)
 		ssd := types.CustomBool(block[mkDiskSSD].(bool))
+		wwn, _ := block[mkDiskWWN].(string)
+
+		if generateSerial, _ := block[mkDiskGenerateSerial].(bool); generateSerial && serial == "" {
+			serial = generatedSerial(diskInterface)
+		}
 
 		speedBlock, err := structure.GetSchemaBlock(
 			resource,
@@ -217,6 +222,7 @@ func GetDiskDeviceObjects(
 		diskDevice.Replicate = &replicate
 		diskDevice.Serial = &serial
 		diskDevice.Size = types.DiskSizeFromGigabytes(int64(size))
+		diskDevice.WWN = &wwn
 
 		if fileFormat != "" {
 			diskDevice.Format = &fileFormat
@@ -363,6 +369,12 @@ func createCustomDisk(
 	return nil
 }
 
+// generatedSerial returns the serial number of a drive with `generate_serial` enabled, which is stable as long
+// as the drive stays on the same interface.
+func generatedSerial(diskInterface string) string {
+	return diskInterface
+}
+
 // Read reads the disk configuration of a VM.
 func Read(
 	ctx context.Context,
@@ -465,6 +477,12 @@ func Read(
 			disk[mkDiskSSD] = false
 		}
 
+		if dd.WWN != nil {
+			disk[mkDiskWWN] = *dd.WWN
+		} else {
+			disk[mkDiskWWN] = ""
+		}
+
 		if dd.Discard != nil {
 			disk[mkDiskDiscard] = *dd.Discard
 		} else {
@@ -556,6 +574,17 @@ func Read(
 							diskMap[k].(map[string]interface{})[mkDiskImportFrom] = importFrom
 						}
 					}
+
+					// the generated serial is not kept in the state, as it is not set in the config
+					if generateSerial, ok := disk[mkDiskGenerateSerial].(bool); ok && generateSerial {
+						if newDisk, exists := diskMap[k].(map[string]interface{}); exists {
+							newDisk[mkDiskGenerateSerial] = true
+
+							if disk[mkDiskSerial] == "" && newDisk[mkDiskSerial] == generatedSerial(k) {
+								newDisk[mkDiskSerial] = ""
+							}
+						}
+					}
 				}
 			}
 
@@ -638,9 +667,15 @@ func Update(
 			tmp.MaxIopsWrite = disk.MaxIopsWrite
 			tmp.MaxReadSpeedMbps = disk.MaxReadSpeedMbps
 			tmp.MaxWriteSpeedMbps = disk.MaxWriteSpeedMbps
+			// the guest only sees the new identity of the drive once it is restarted
+			if ptr.Or(tmp.Serial, "") != ptr.Or(disk.Serial, "") || ptr.Or(tmp.WWN, "") != ptr.Or(disk.WWN, "") {
+				rebootRequired = true
+			}
+
 			tmp.Replicate = disk.Replicate
 			tmp.Serial = disk.Serial
 			tmp.SSD = disk.SSD
+			tmp.WWN = disk.WWN
 
 			updateBody.AddCustomStorageDevice(iface, *tmp)
 		}
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
 )
@@ -349,3 +350,96 @@ func TestDiskUpdateSkipsUnchangedDisks(t *testing.T) {
 	// Note: We can't directly inspect the updateBody content in this test framework,
 	// but the fact that no error occurred means the logic worked correctly
 }
+
+// TestDiskGenerateSerial tests that the interface name is used as the serial of the disks with
+// `generate_serial` enabled, unless the serial is set.
+func TestDiskGenerateSerial(t *testing.T) {
+	t.Parallel()
+
+	diskSchema := Schema()
+
+	resourceData := schema.TestResourceDataRaw(t, diskSchema, map[string]interface{}{
+		MkDisk: []interface{}{
+			map[string]interface{}{
+				mkDiskInterface:      "scsi0",
+				mkDiskGenerateSerial: true,
+			},
+			map[string]interface{}{
+				mkDiskInterface:      "scsi1",
+				mkDiskGenerateSerial: true,
+				mkDiskSerial:         "data",
+				mkDiskWWN:            "0x5000c500a1b2c3d4",
+			},
+			map[string]interface{}{
+				mkDiskInterface: "scsi2",
+			},
+		},
+	})
+
+	disks, err := GetDiskDeviceObjects(resourceData, &schema.Resource{Schema: diskSchema}, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, "scsi0", *disks["scsi0"].Serial)
+	require.Equal(t, "data", *disks["scsi1"].Serial)
+	require.Equal(t, "0x5000c500a1b2c3d4", *disks["scsi1"].WWN)
+	require.Empty(t, *disks["scsi2"].Serial)
+}
+
+// TestDiskUpdateSerialRequiresReboot tests that a change of the serial or the WWN of a disk requires a reboot,
+// while the other changes don't.
+func TestDiskUpdateSerialRequiresReboot(t *testing.T) {
+	t.Parallel()
+
+	datastoreID := "local"
+
+	tests := []struct {
+		name       string
+		current    *vms.CustomStorageDevice
+		plan       *vms.CustomStorageDevice
+		wantReboot bool
+	}{
+		{
+			name:       "size",
+			current:    &vms.CustomStorageDevice{Size: types.DiskSizeFromGigabytes(5), DatastoreID: &datastoreID},
+			plan:       &vms.CustomStorageDevice{Size: types.DiskSizeFromGigabytes(10), DatastoreID: &datastoreID, Serial: ptr.Ptr("")},
+			wantReboot: false,
+		},
+		{
+			name:       "serial",
+			current:    &vms.CustomStorageDevice{Serial: ptr.Ptr("old"), DatastoreID: &datastoreID},
+			plan:       &vms.CustomStorageDevice{Serial: ptr.Ptr("new"), DatastoreID: &datastoreID},
+			wantReboot: true,
+		},
+		{
+			name:       "wwn",
+			current:    &vms.CustomStorageDevice{DatastoreID: &datastoreID},
+			plan:       &vms.CustomStorageDevice{WWN: ptr.Ptr("0x5000c500a1b2c3d4"), DatastoreID: &datastoreID},
+			wantReboot: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resourceData := schema.TestResourceDataRaw(t, Schema(), map[string]interface{}{
+				MkDisk: []interface{}{
+					map[string]interface{}{
+						mkDiskInterface: "scsi0",
+					},
+				},
+			})
+			resourceData.MarkNewResource()
+
+			updateBody := &vms.UpdateRequestBody{}
+
+			rebootRequired, err := Update(context.Background(), nil, "test-node", 100, resourceData,
+				vms.CustomStorageDevices{"scsi0": tt.plan},
+				vms.CustomStorageDevices{"scsi0": tt.current},
+				updateBody,
+			)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantReboot, rebootRequired)
+		})
+	}
+}
@@ -31,6 +31,7 @@ const (
 	mkDiskDiscard             = "discard"
 	mkDiskFileFormat          = "file_format"
 	mkDiskFileID              = "file_id"
+	mkDiskGenerateSerial      = "generate_serial"
 	mkDiskImportFrom          = "import_from"
 	mkDiskInterface           = "interface"
 	mkDiskIopsRead            = "iops_read"
@@ -48,6 +49,7 @@ const (
 	mkDiskSpeedWrite          = "write"
 	mkDiskSpeedWriteBurstable = "write_burstable"
 	mkDiskSSD                 = "ssd"
+	mkDiskWWN                 = "wwn"
 )
 
 // Schema returns the schema for the disk resource.
@@ -67,6 +69,7 @@ func Schema() map[string]*schema.Schema {
 						mkDiskDiscard:         dvDiskDiscard,
 						mkDiskImportFrom:      "",
 						mkDiskFileID:          "",
+						mkDiskGenerateSerial:  false,
 						mkDiskInterface:       dvDiskInterface,
 						mkDiskIOThread:        false,
 						mkDiskPathInDatastore: nil,
@@ -74,6 +77,7 @@ func Schema() map[string]*schema.Schema {
 						mkDiskSerial:          "",
 						mkDiskSize:            dvDiskSize,
 						mkDiskSSD:             false,
+						mkDiskWWN:             "",
 					},
 				}, nil
 			},
@@ -89,10 +93,11 @@ func Schema() map[string]*schema.Schema {
 						Required:    true,
 					},
 					mkDiskDatastoreID: {
-						Type:        schema.TypeString,
-						Description: "The datastore id",
-						Optional:    true,
-						Default:     dvDiskDatastoreID,
+						Type:             schema.TypeString,
+						Description:      "The datastore id",
+						Optional:         true,
+						Default:          dvDiskDatastoreID,
+						ValidateDiagFunc: validators.DatastoreID(),
 					},
 					mkDiskPathInDatastore: {
 						Type:        schema.TypeString,
@@ -148,7 +153,21 @@ func Schema() map[string]*schema.Schema {
 						Description:      "The drive’s reported serial number",
 						Optional:         true,
 						Default:          "",
-						ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(0, 20)),
+						ValidateDiagFunc: validators.DiskSerial(),
+					},
+					mkDiskGenerateSerial: {
+						Type: schema.TypeBool,
+						Description: "Whether to report the interface name, e.g. `scsi1`, as the serial number " +
+							"of the drive if `serial` is not set",
+						Optional: true,
+						Default:  false,
+					},
+					mkDiskWWN: {
+						Type:             schema.TypeString,
+						Description:      "The drive’s reported World Wide Name, e.g. `0x5000c500a1b2c3d4`",
+						Optional:         true,
+						Default:          "",
+						ValidateDiagFunc: validators.DiskWWN(),
 					},
 					mkDiskSize: {
 						Type:             schema.TypeInt,
@@ -555,11 +555,12 @@ func VM() *schema.Resource {
 						Default:     dvCloneRetries,
 					},
 					mkCloneDatastoreID: {
-						Type:        schema.TypeString,
-						Description: "The ID of the target datastore",
-						Optional:    true,
-						ForceNew:    true,
-						Default:     dvCloneDatastoreID,
+						Type:             schema.TypeString,
+						Description:      "The ID of the target datastore",
+						Optional:         true,
+						ForceNew:         true,
+						Default:          dvCloneDatastoreID,
+						ValidateDiagFunc: validators.DatastoreID(),
 					},
 					mkCloneNodeName: {
 						Type:        schema.TypeString,
@@ -716,10 +717,11 @@ func VM() *schema.Resource {
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					mkEFIDiskDatastoreID: {
-						Type:        schema.TypeString,
-						Description: "The datastore id",
-						Optional:    true,
-						Default:     dvEFIDiskDatastoreID,
+						Type:             schema.TypeString,
+						Description:      "The datastore id",
+						Optional:         true,
+						Default:          dvEFIDiskDatastoreID,
+						ValidateDiagFunc: validators.DatastoreID(),
 					},
 					mkEFIDiskFileFormat: {
 						Type:             schema.TypeString,
@@ -764,10 +766,11 @@ func VM() *schema.Resource {
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					mkTPMStateDatastoreID: {
-						Type:        schema.TypeString,
-						Description: "Datastore ID",
-						Optional:    true,
-						Default:     dvTPMStateDatastoreID,
+						Type:             schema.TypeString,
+						Description:      "Datastore ID",
+						Optional:         true,
+						Default:          dvTPMStateDatastoreID,
+						ValidateDiagFunc: validators.DatastoreID(),
 					},
 					mkTPMStateVersion: {
 						Type:        schema.TypeString,
@@ -795,10 +798,11 @@ func VM() *schema.Resource {
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					mkInitializationDatastoreID: {
-						Type:        schema.TypeString,
-						Description: "The datastore id",
-						Optional:    true,
-						Default:     dvInitializationDatastoreID,
+						Type:             schema.TypeString,
+						Description:      "The datastore id",
+						Optional:         true,
+						Default:          dvInitializationDatastoreID,
+						ValidateDiagFunc: validators.DatastoreID(),
 					},
 					mkInitializationInterface: {
 						Type:             schema.TypeString,
@@ -1666,6 +1670,8 @@ func VM() *schema.Resource {
 		DeleteContext: vmDelete,
 		CustomizeDiff: customdiff.All(
 			customdiff.All(network.CustomizeDiff()...),
+			validators.TagPolicy(mkTags),
+			validators.CPUModel(mkNodeName, fmt.Sprintf("%s.0.%s", mkCPU, mkCPUType)),
 			customdiff.ForceNewIf(
 				mkVMID,
 				func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
@@ -1716,11 +1722,32 @@ func vmCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.D
 	// reset the default timeout for the create operation
 	ctx = context.WithoutCancel(ctx)
 
+	diags := vmCPUModelWarning(ctx, d, m)
+
 	if len(clone) > 0 {
-		return vmCreateClone(ctx, d, m)
+		return append(diags, vmCreateClone(ctx, d, m)...)
 	}
 
-	return vmCreateCustom(ctx, d, m)
+	return append(diags, vmCreateCustom(ctx, d, m)...)
+}
+
+// vmCPUModelWarning returns a warning if the CPU type is a custom model which is not defined on the node. The plan
+// can only log it, since a CustomizeDiff function can't return warnings. Unsupported models already failed the plan.
+func vmCPUModelWarning(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(proxmoxtf.ProviderConfiguration)
+
+	nodeName := d.Get(mkNodeName).(string)
+	cpuType, _ := d.Get(fmt.Sprintf("%s.0.%s", mkCPU, mkCPUType)).(string)
+
+	warning, _ := config.GetCPUModelValidator().Validate(ctx, nodeName, cpuType)
+	if warning == "" {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  warning,
+	}}
 }
 
 // Check for an existing CloudInit IDE drive. If no such drive is found, return the specified `defaultValue`.
@@ -1944,6 +1971,10 @@ func vmCreateClone(ctx context.Context, d *schema.ResourceData, m interface{}) d
 				return diag.FromErr(err)
 			}
 
+			// the clone is tracked before it's migrated, so that it's tainted instead of orphaned if the migration
+			// fails, the node is refreshed from the cluster resources when the VM is read
+			d.SetId(strconv.Itoa(vmID))
+
 			// Wait for the virtual machine to be created and its configuration lock to be released before migrating.
 
 			err = client.Node(cloneNodeName).VM(vmID).WaitForVMConfigUnlock(ctx, true)
@@ -1975,6 +2006,8 @@ func vmCreateClone(ctx context.Context, d *schema.ResourceData, m interface{}) d
 		return diag.FromErr(e)
 	}
 
+	// the ID is recorded as soon as the VM exists, so that a failure of any of the following steps leaves
+	// the VM in the state as tainted, and the next apply replaces it, instead of failing as it already exists
 	d.SetId(strconv.Itoa(vmID))
 
 	vmAPI := client.Node(nodeName).VM(vmID)
@@ -2683,23 +2716,10 @@ func vmCreateCustom(ctx context.Context, d *schema.ResourceData, m interface{})
 	virtiofsShares := vmGetVirtiofsShares(d)
 	vgaDevice := vmGetVGADeviceObject(d)
 
+	// a missing VM ID is allocated when the VM is created, so it can be re-allocated if taken concurrently
 	vmIDUntyped, hasVMID := d.GetOk(mkVMID)
 	vmID := vmIDUntyped.(int)
 
-	if !hasVMID {
-		vmIDNew, e := config.GetIDGenerator().NextID(ctx)
-		if e != nil {
-			return diag.FromErr(e)
-		}
-
-		vmID = vmIDNew
-
-		e = d.Set(mkVMID, vmID)
-		if e != nil {
-			return diag.FromErr(e)
-		}
-	}
-
 	diskDeviceObjects, err := disk.GetDiskDeviceObjects(d, resource, nil)
 	if err != nil {
 		return diag.FromErr(err)
@@ -2901,13 +2921,53 @@ func vmCreateCustom(ctx context.Context, d *schema.ResourceData, m interface{})
 		createBody.HookScript = &hookScript
 	}
 
-	err = client.Node(nodeName).VM(0).CreateVM(ctx, createBody)
+	createVM := func(ctx context.Context, id int) error {
+		createBody.VMID = id
+
+		if createBody.SharedMemory != nil {
+			createBody.SharedMemory.Name = ptr.Ptr(fmt.Sprintf("vm-%d-ivshmem", id))
+		}
+
+		vmAPI := client.Node(nodeName).VM(0)
+
+		taskID, err := vmAPI.CreateVMAsync(ctx, createBody)
+		if err != nil {
+			// the VM has not been created, and the ID may be used by a VM or container of someone else
+			d.SetId("")
+
+			return err
+		}
+
+		// the ID is recorded once the creation has started, so that a partially created VM is kept in the state
+		// as tainted, and replaced by the next apply, if the creation fails
+		d.SetId(strconv.Itoa(id))
+
+		if err = d.Set(mkVMID, id); err != nil {
+			return err
+		}
+
+		if err = vmAPI.Tasks().WaitForTask(ctx, *taskID); err != nil {
+			if cluster.IsIDTaken(err) {
+				// the ID has been taken by a VM or container of someone else, which must not be replaced by the next apply
+				d.SetId("")
+			}
+
+			return fmt.Errorf("error waiting for VM creation: %w", err)
+		}
+
+		return nil
+	}
+
+	if hasVMID {
+		err = createVM(ctx, vmID)
+	} else {
+		vmID, err = config.GetIDGenerator().CreateWithNextID(ctx, createVM)
+	}
+
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.Itoa(vmID))
-
 	diags := disk.CreateCustomDisks(ctx, client, nodeName, vmID, diskDeviceObjects)
 	if diags.HasError() {
 		return diags
@@ -5778,13 +5838,19 @@ func vmUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.D
 		}
 	}
 
+	var diags diag.Diagnostics
+
+	if d.HasChange(mkNodeName) || d.HasChange(fmt.Sprintf("%s.0.%s", mkCPU, mkCPUType)) {
+		diags = vmCPUModelWarning(ctx, d, m)
+	}
+
 	// Change the disk locations and/or sizes, if necessary.
-	return vmUpdateDiskLocationAndSize(
+	return append(diags, vmUpdateDiskLocationAndSize(
 		ctx,
 		d,
 		m,
 		!bool(template) && rebootRequired,
-	)
+	)...)
 }
 
 func vmUpdateDiskLocationAndSize(
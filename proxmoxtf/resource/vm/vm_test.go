@@ -7,11 +7,19 @@
 package resource
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+	"github.com/bpg/terraform-provider-proxmox/proxmoxtf"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource/vm/disk"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource/vm/network"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/test"
@@ -446,3 +454,60 @@ func Test_parseImportIDWIthNodeName(t *testing.T) {
 		})
 	}
 }
+
+func Test_vmCPUModelWarning(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"name":"host","vendor":"default","custom":0},` +
+			`{"name":"custom-gpu","vendor":"AuthenticAMD","custom":1}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	conn, err := api.NewConnection(server.URL, true, "")
+	require.NoError(t, err)
+
+	creds := api.Credentials{TokenCredentials: &api.TokenCredentials{
+		APIToken: "root@pam!test=00000000-0000-0000-0000-000000000000",
+	}}
+
+	client, err := api.NewClient(creds, conn)
+	require.NoError(t, err)
+
+	// the SSH client is not used by the validation
+	config, err := proxmoxtf.NewProviderConfiguration(client, struct{ ssh.Client }{}, "", cluster.IDGeneratorConfig{}, false)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		cpuType string
+		warning bool
+	}{
+		{"built-in", "host", false},
+		{"defined custom", "custom-gpu", false},
+		{"undefined custom", "custom-missing", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, VM().Schema, map[string]interface{}{
+				mkNodeName: "pve",
+				mkCPU:      []interface{}{map[string]interface{}{mkCPUType: tt.cpuType}},
+			})
+
+			diags := vmCPUModelWarning(t.Context(), d, config)
+
+			if !tt.warning {
+				assert.Empty(t, diags)
+
+				return
+			}
+
+			require.Len(t, diags, 1)
+			assert.Equal(t, diag.Warning, diags[0].Severity)
+			assert.Contains(t, diags[0].Summary, `the custom CPU model "missing" is not defined`)
+		})
+	}
+}
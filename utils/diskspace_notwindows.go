@@ -0,0 +1,27 @@
+//go:build !windows
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package utils
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// FreeDiskSpace returns the space in bytes available to the user on the file system of the directory.
+func FreeDiskSpace(dir string) (uint64, error) {
+	var stat unix.Statfs_t
+
+	if err := unix.Statfs(dir, &stat); err != nil {
+		return 0, fmt.Errorf("failed to get the free space of %q: %w", dir, err)
+	}
+
+	// the types of the fields differ between the platforms
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil //nolint:gosec,unconvert
+}
@@ -0,0 +1,31 @@
+//go:build windows
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package utils
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+// FreeDiskSpace returns the space in bytes available to the user on the file system of the directory.
+func FreeDiskSpace(dir string) (uint64, error) {
+	path, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return 0, fmt.Errorf("invalid directory %q: %w", dir, err)
+	}
+
+	var free uint64
+
+	if err = windows.GetDiskFreeSpaceEx(path, &free, nil, nil); err != nil {
+		return 0, fmt.Errorf("failed to get the free space of %q: %w", dir, err)
+	}
+
+	return free, nil
+}
@@ -9,6 +9,7 @@ package utils
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 // GetAnyStringEnv returns the first non-empty string value from the environment variables.
@@ -48,3 +49,29 @@ func GetAnyIntEnv(ks ...string) int {
 
 	return 0
 }
+
+// GetAnyFloatEnv returns the first non-empty floating-point value from the environment variables.
+func GetAnyFloatEnv(ks ...string) float64 {
+	for _, k := range ks {
+		if v := os.Getenv(k); v != "" {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f
+			}
+		}
+	}
+
+	return 0
+}
+
+// GetAnyListEnv returns the comma-separated values of the first non-empty environment variable.
+func GetAnyListEnv(ks ...string) []string {
+	var list []string
+
+	for _, v := range strings.Split(GetAnyStringEnv(ks...), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+
+	return list
+}
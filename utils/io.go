@@ -9,6 +9,7 @@ package utils
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -23,3 +24,71 @@ func CloseOrLogError(ctx context.Context) func(io.Closer) {
 		}
 	}
 }
+
+// progressLogInterval is the minimum interval between two progress log entries.
+const progressLogInterval = 10 * time.Second
+
+// ProgressReader is an io.Reader that periodically logs the progress of reading from the underlying reader.
+// When the total size is known, the percentage and the estimated time of completion are logged as well.
+type ProgressReader struct {
+	ctx     context.Context //nolint:containedctx
+	reader  io.Reader
+	message string
+	total   int64
+	read    int64
+	start   time.Time
+	logged  time.Time
+}
+
+// NewProgressReader creates a new ProgressReader, the total size is ignored if it is not positive.
+func NewProgressReader(ctx context.Context, r io.Reader, total int64, message string) *ProgressReader {
+	now := time.Now()
+
+	return &ProgressReader{
+		ctx:     ctx,
+		reader:  r,
+		message: message,
+		total:   total,
+		start:   now,
+		logged:  now,
+	}
+}
+
+// Read implements io.Reader.
+func (p *ProgressReader) Read(b []byte) (int, error) {
+	n, err := p.reader.Read(b)
+	p.read += int64(n)
+
+	now := time.Now()
+
+	if err == io.EOF || now.Sub(p.logged) >= progressLogInterval {
+		p.logged = now
+		tflog.Info(p.ctx, p.message, p.progress(now))
+	}
+
+	return n, err //nolint:wrapcheck
+}
+
+// progress returns the progress fields at the given time.
+func (p *ProgressReader) progress(now time.Time) map[string]interface{} {
+	elapsed := now.Sub(p.start)
+
+	fields := map[string]interface{}{
+		"bytes":   p.read,
+		"elapsed": elapsed.Truncate(time.Second).String(),
+	}
+
+	if p.total <= 0 {
+		return fields
+	}
+
+	fields["total_bytes"] = p.total
+	fields["percent"] = float64(p.read*1000/p.total) / 10
+
+	if p.read > 0 && p.read < p.total {
+		eta := time.Duration(float64(elapsed) * float64(p.total-p.read) / float64(p.read))
+		fields["eta"] = eta.Truncate(time.Second).String()
+	}
+
+	return fields
+}
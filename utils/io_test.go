@@ -7,8 +7,11 @@
 package utils
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -31,6 +34,35 @@ func TestCloseOrLogError(t *testing.T) {
 	assert.True(t, c.isClosed)
 }
 
+func TestProgressReader(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte("a"), 1000)
+
+	p := NewProgressReader(t.Context(), bytes.NewReader(data), int64(len(data)), "reading")
+
+	_, err := p.Read(make([]byte, 250))
+	assert.NoError(t, err)
+
+	fields := p.progress(p.start.Add(10 * time.Second))
+	assert.Equal(t, int64(250), fields["bytes"])
+	assert.Equal(t, int64(1000), fields["total_bytes"])
+	assert.InDelta(t, 25.0, fields["percent"], 0.001)
+	assert.Equal(t, "30s", fields["eta"])
+
+	read, err := io.ReadAll(p)
+	assert.NoError(t, err)
+	assert.Len(t, read, 750)
+
+	fields = p.progress(p.start.Add(40 * time.Second))
+	assert.InDelta(t, 100.0, fields["percent"], 0.001)
+	assert.NotContains(t, fields, "eta")
+
+	p = NewProgressReader(t.Context(), bytes.NewReader(data), 0, "reading")
+	fields = p.progress(p.start)
+	assert.NotContains(t, fields, "percent")
+}
+
 type testCloser struct {
 	isClosed bool
 }